@@ -0,0 +1,238 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerifyED25519(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signED25519(priv, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := verifyED25519(pub, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifyED25519(pub, sigma, []byte("hello world!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = signED25519(priv[:10], msg, nil)
+	assert.EqualError(t, err, "Invalid ED25519 private key size [10]")
+
+	_, err = verifyED25519(pub[:10], sigma, msg, nil)
+	assert.EqualError(t, err, "Invalid ED25519 public key size [10]")
+}
+
+func TestED25519Keys(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	k := &ed25519PrivateKey{priv}
+	assert.False(t, k.Symmetric())
+	assert.True(t, k.Private())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+
+	expectedSKI := sha256.Sum256(pub)
+	assert.Equal(t, expectedSKI[:], k.SKI())
+	assert.Nil(t, (&ed25519PrivateKey{}).SKI())
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.False(t, pk.Symmetric())
+	assert.False(t, pk.Private())
+	assert.Equal(t, k.SKI(), pk.SKI())
+	assert.Nil(t, (&ed25519PublicKey{}).SKI())
+
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	lowLevelKey, err := x509.ParsePKIXPublicKey(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, lowLevelKey)
+}
+
+func TestED25519KeyGenSignVerify(t *testing.T) {
+	t.Parallel()
+	provider, ks, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+
+	// the key is stored in the key store
+	k2, err := ks.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	// Ed25519 signs the message itself rather than a digest of it
+	msg := []byte("Hello World")
+	signature, err := provider.Sign(k, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := provider.Verify(k, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = provider.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the signature is a standard Ed25519 signature of the message
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pub, err := utils.DERToPublicKey(raw)
+	assert.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub.(ed25519.PublicKey), msg, signature))
+
+	msg[0] ^= 0xff
+	valid, err = provider.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	sk, err := provider.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, sk.Private())
+
+	pkRaw, err := x509.MarshalPKIXPublicKey(pub)
+	assert.NoError(t, err)
+	pk, err := provider.KeyImport(pkRaw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, sk.SKI(), pk.SKI())
+
+	pk2, err := provider.KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk, pk2)
+
+	_, err = provider.KeyImport(pkRaw, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = provider.KeyImport("not a key", &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+
+	ecdsaKey, err := provider.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	ecdsaPK, err := ecdsaKey.PublicKey()
+	assert.NoError(t, err)
+	ecdsaRaw, err := ecdsaPK.Bytes()
+	assert.NoError(t, err)
+	_, err = provider.KeyImport(ecdsaRaw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Failed casting to ED25519 public key. Invalid raw material.")
+}
+
+func TestKeyImportFromX509ED25519PublicKey(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	cryptoSigner, err := signer.New(provider, k)
+	assert.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test.example.com"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, cryptoSigner.Public(), cryptoSigner)
+	assert.NoError(t, err)
+
+	cert, err := utils.DERToX509Certificate(certRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, x509.PureEd25519, cert.SignatureAlgorithm)
+	assert.NoError(t, cert.CheckSignatureFrom(cert))
+
+	pk, err := provider.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	msg := []byte("Hello World")
+	signature, err := provider.Sign(k, msg, nil)
+	assert.NoError(t, err)
+	valid, err := provider.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestED25519FileKeyStore(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ks, err := NewFileBasedKeyStore(nil, tempDir, false)
+	assert.NoError(t, err)
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	sk := &ed25519PrivateKey{priv}
+	pk, err := sk.PublicKey()
+	assert.NoError(t, err)
+
+	assert.NoError(t, ks.StoreKey(sk))
+	loaded, err := ks.GetKey(sk.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, sk, loaded)
+
+	// private keys are also found when their file is not named after their SKI
+	fks := ks.(*fileBasedKeyStore)
+	assert.NoError(t, os.Rename(fks.getPathForAlias(hex.EncodeToString(sk.SKI()), "sk"), filepath.Join(tempDir, "priv_sk")))
+	loaded, err = ks.GetKey(sk.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, sk, loaded)
+
+	assert.NoError(t, ks.StoreKey(pk))
+	loaded, err = ks.GetKey(pk.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, pk, loaded)
+}
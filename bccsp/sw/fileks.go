@@ -18,6 +18,7 @@ package sw
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/hex"
 	"errors"
@@ -139,6 +140,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (bccsp.Key, error) {
 			return &ecdsaPrivateKey{k}, nil
 		case *rsa.PrivateKey:
 			return &rsaPrivateKey{k}, nil
+		case ed25519.PrivateKey:
+			return &ed25519PrivateKey{k}, nil
 		default:
 			return nil, errors.New("Secret key type not recognized")
 		}
@@ -154,6 +157,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (bccsp.Key, error) {
 			return &ecdsaPublicKey{k}, nil
 		case *rsa.PublicKey:
 			return &rsaPublicKey{k}, nil
+		case ed25519.PublicKey:
+			return &ed25519PublicKey{k}, nil
 		default:
 			return nil, errors.New("Public key type not recognized")
 		}
@@ -197,6 +202,18 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing RSA public key [%s]", err)
 		}
 
+	case *ed25519PrivateKey:
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), kk.privKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing ED25519 private key [%s]", err)
+		}
+
+	case *ed25519PublicKey:
+		err = ks.storePublicKey(hex.EncodeToString(k.SKI()), kk.pubKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing ED25519 public key [%s]", err)
+		}
+
 	case *aesPrivateKey:
 		err = ks.storeKey(hex.EncodeToString(k.SKI()), kk.privKey)
 		if err != nil {
@@ -237,6 +254,8 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 			k = &ecdsaPrivateKey{kk}
 		case *rsa.PrivateKey:
 			k = &rsaPrivateKey{kk}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{kk}
 		default:
 			continue
 		}
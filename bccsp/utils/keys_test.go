@@ -18,6 +18,7 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -318,6 +319,59 @@ func TestECDSAKeys(t *testing.T) {
 	}
 }
 
+func TestED25519Keys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	// Private Key DER format
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	key, err := DERToPrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	// Private Key PEM format
+	pemBytes, err := PrivateKeyToPEM(priv, nil)
+	assert.NoError(t, err)
+	key, err = PEMtoPrivateKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	// Encrypted Private Key PEM format
+	pemBytes, err = PrivateKeyToPEM(priv, []byte("passwd"))
+	assert.NoError(t, err)
+	key, err = PEMtoPrivateKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	_, err = PrivateKeyToPEM(priv[:10], nil)
+	assert.Error(t, err)
+
+	// Public Key DER format
+	der, err = PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	pubKey, err := DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubKey)
+
+	// Public Key PEM format
+	pemBytes, err = PublicKeyToPEM(pub, nil)
+	assert.NoError(t, err)
+	pubKey, err = PEMtoPublicKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubKey)
+
+	// Encrypted Public Key PEM format
+	pemBytes, err = PublicKeyToPEM(pub, []byte("passwd"))
+	assert.NoError(t, err)
+	pubKey, err = PEMtoPublicKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubKey)
+
+	_, err = PublicKeyToDER(pub[:10])
+	assert.Error(t, err)
+}
+
 func TestAESKey(t *testing.T) {
 	k := []byte{0, 1, 2, 3, 4, 5}
 	pem := AEStoPEM(k)
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package signer
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/asn1"
@@ -35,7 +37,7 @@ type Config struct {
 // initialize an MSP without a CA cert that signs the signing identity,
 // this will do for now.
 type Signer struct {
-	key     *ecdsa.PrivateKey
+	key     crypto.PrivateKey
 	Creator []byte
 }
 
@@ -68,11 +70,18 @@ func serializeIdentity(clientCert string, mspID string) ([]byte, error) {
 }
 
 func (si *Signer) Sign(msg []byte) ([]byte, error) {
-	digest := util.ComputeSHA256(msg)
-	return signECDSA(si.key, digest)
+	switch key := si.key.(type) {
+	case *ecdsa.PrivateKey:
+		return signECDSA(key, util.ComputeSHA256(msg))
+	case ed25519.PrivateKey:
+		// like the MSP, Ed25519 signs the message itself rather than its digest
+		return ed25519.Sign(key, msg), nil
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", si.key)
+	}
 }
 
-func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
+func loadPrivateKey(file string) (crypto.PrivateKey, error) {
 	b, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -85,7 +94,12 @@ func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to parse private key from %s", file)
 	}
-	return key.(*ecdsa.PrivateKey), nil
+	switch key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, errors.Errorf("unsupported private key type %T in %s", key, file)
+	}
 }
 
 func signECDSA(k *ecdsa.PrivateKey, digest []byte) (signature []byte, err error) {
@@ -43,10 +43,11 @@ type SpecData struct {
 }
 
 type NodeTemplate struct {
-	Count    int      `yaml:"Count"`
-	Start    int      `yaml:"Start"`
-	Hostname string   `yaml:"Hostname"`
-	SANS     []string `yaml:"SANS"`
+	Count              int      `yaml:"Count"`
+	Start              int      `yaml:"Start"`
+	Hostname           string   `yaml:"Hostname"`
+	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type NodeSpec struct {
@@ -59,10 +60,12 @@ type NodeSpec struct {
 	StreetAddress      string   `yaml:"StreetAddress"`
 	PostalCode         string   `yaml:"PostalCode"`
 	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type UsersSpec struct {
-	Count int `yaml:"Count"`
+	Count              int    `yaml:"Count"`
+	PublicKeyAlgorithm string `yaml:"PublicKeyAlgorithm"`
 }
 
 type OrgSpec struct {
@@ -122,6 +125,7 @@ PeerOrgs:
     #    OrganizationalUnit: Hyperledger Fabric
     #    StreetAddress: address for org # default nil
     #    PostalCode: postalCode for org # default nil
+    #    PublicKeyAlgorithm: ecdsa # ecdsa (default) or ed25519
 
     # ---------------------------------------------------------------------------
     # "Specs"
@@ -146,6 +150,9 @@ PeerOrgs:
     #                 NOTE: Two implicit entries are created for you:
     #                     - {{ .CommonName }}
     #                     - {{ .Hostname }}
+    #   - PublicKeyAlgorithm: (Optional) The algorithm of the key pairs of the
+    #                 node, either ecdsa or ed25519.  By default, this is the
+    #                 algorithm of the CA of the organization.
     # ---------------------------------------------------------------------------
     # Specs:
     #   - Hostname: foo # implicitly "foo.org1.example.com"
@@ -176,14 +183,18 @@ PeerOrgs:
       # Hostname: {{.Prefix}}{{.Index}} # default
       # SANS:
       #   - "{{.Hostname}}.alt.{{.Domain}}"
+      # PublicKeyAlgorithm: ecdsa # default is the algorithm of the CA
 
     # ---------------------------------------------------------------------------
     # "Users"
     # ---------------------------------------------------------------------------
     # Count: The number of user accounts _in addition_ to Admin
+    # PublicKeyAlgorithm: The algorithm of the key pairs of the users, including
+    #                     Admin.  By default, this is the algorithm of the CA.
     # ---------------------------------------------------------------------------
     Users:
       Count: 1
+      # PublicKeyAlgorithm: ecdsa
 
   # ---------------------------------------------------------------------------
   # Org2: See "Org1" for full specification
@@ -312,7 +323,8 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 	// copy the admin cert to each of the org's peer's MSP admincerts
 	for _, spec := range orgSpec.Specs {
@@ -329,7 +341,8 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
@@ -357,7 +370,8 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, false)
 
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	for _, spec := range orgSpec.Specs {
@@ -473,19 +487,47 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		}
 
 		spec := NodeSpec{
-			Hostname: hostname,
-			SANS:     orgSpec.Template.SANS,
+			Hostname:           hostname,
+			SANS:               orgSpec.Template.SANS,
+			PublicKeyAlgorithm: orgSpec.Template.PublicKeyAlgorithm,
 		}
 		orgSpec.Specs = append(orgSpec.Specs, spec)
 	}
 
-	// Touch up all general node-specs to add the domain
+	// The CA generates ECDSA keys unless told otherwise
+	if len(orgSpec.CA.PublicKeyAlgorithm) == 0 {
+		orgSpec.CA.PublicKeyAlgorithm = csp.ECDSA
+	}
+	err := validateKeyAlgorithm(orgSpec.CA.PublicKeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	// Users default to the public key algorithm of the CA
+	if len(orgSpec.Users.PublicKeyAlgorithm) == 0 {
+		orgSpec.Users.PublicKeyAlgorithm = orgSpec.CA.PublicKeyAlgorithm
+	}
+	err = validateKeyAlgorithm(orgSpec.Users.PublicKeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	// Touch up all general node-specs to add the domain, and the public key
+	// algorithm of the CA if none was set
 	for idx, spec := range orgSpec.Specs {
 		err := renderNodeSpec(orgSpec.Domain, &spec)
 		if err != nil {
 			return err
 		}
 
+		if len(spec.PublicKeyAlgorithm) == 0 {
+			spec.PublicKeyAlgorithm = orgSpec.CA.PublicKeyAlgorithm
+		}
+		err = validateKeyAlgorithm(spec.PublicKeyAlgorithm)
+		if err != nil {
+			return err
+		}
+
 		orgSpec.Specs[idx] = spec
 	}
 
@@ -493,7 +535,7 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 	if len(orgSpec.CA.Hostname) == 0 {
 		orgSpec.CA.Hostname = "ca"
 	}
-	err := renderNodeSpec(orgSpec.Domain, &orgSpec.CA)
+	err = renderNodeSpec(orgSpec.Domain, &orgSpec.CA)
 	if err != nil {
 		return err
 	}
@@ -501,6 +543,15 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 	return nil
 }
 
+func validateKeyAlgorithm(keyAlg string) error {
+	switch keyAlg {
+	case csp.ECDSA, csp.ED25519:
+		return nil
+	default:
+		return fmt.Errorf("Unsupported public key algorithm: %s", keyAlg)
+	}
+}
+
 func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 
 	orgName := orgSpec.Domain
@@ -515,19 +566,19 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, orgSpec.EnableNodeOUs, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -539,14 +590,16 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
 	}
 	// add an admin user
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	users = append(users, adminUser)
@@ -602,7 +655,7 @@ func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA
 	for _, node := range nodes {
 		nodeDir := filepath.Join(baseDir, node.CommonName)
 		if _, err := os.Stat(nodeDir); os.IsNotExist(err) {
-			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, nodeType, nodeOUs)
+			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, nodeType, nodeOUs, node.PublicKeyAlgorithm)
 			if err != nil {
 				fmt.Printf("Error generating local MSP for %s:\n%v\n", node, err)
 				os.Exit(1)
@@ -624,19 +677,19 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, false, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating MSP for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -645,7 +698,8 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, false)
 
 	adminUser := NodeSpec{
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.Users.PublicKeyAlgorithm,
 	}
 
 	// generate an admin for the orderer org
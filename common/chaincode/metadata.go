@@ -34,6 +34,10 @@ type Metadata struct {
 	Policy            []byte
 	Id                []byte
 	CollectionsConfig *common.CollectionConfigPackage
+	// CollectionPolicies maps the names of the collections that define
+	// an endorsement policy to that policy, as a marshaled
+	// SignaturePolicyEnvelope. It is only set for _lifecycle chaincodes.
+	CollectionPolicies map[string][]byte
 	// These two fields (Approved, Installed) are only set for
 	// _lifecycle chaincodes. They are used to ensure service
 	// discovery doesn't publish a stale chaincode definition
@@ -106,7 +106,9 @@ type Receiver interface {
 // responses.
 type ResponseSender interface {
 	SendStatusResponse(status cb.Status) error
-	SendBlockResponse(block *cb.Block) error
+	// SendBlockResponse sends the block of the given channel to the client
+	// whose signed request is given
+	SendBlockResponse(block *cb.Block, channelID string, chain Chain, signedData *protoutil.SignedData) error
 }
 
 // Filtered is a marker interface that indicates a response sender
@@ -258,11 +260,23 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_FORBIDDEN, nil
 	}
 
+	signedData, err := protoutil.EnvelopeAsSignedData(envelope)
+	if err != nil {
+		logger.Warningf("[channel: %s] Failed extracting signed data from the deliver request from %s: %s", chdr.ChannelId, addr, err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
 	if seekInfo.Start == nil || seekInfo.Stop == nil {
 		logger.Warningf("[channel: %s] Received seekInfo message from %s with missing start or stop %v, %v", chdr.ChannelId, addr, seekInfo.Start, seekInfo.Stop)
 		return cb.Status_BAD_REQUEST, nil
 	}
 
+	filter, err := newBlockFilter(seekInfo.Filter)
+	if err != nil {
+		logger.Warningf("[channel: %s] Received seekInfo message from %s with an invalid filter: %s", chdr.ChannelId, addr, err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
 	cursor, number := chain.Reader().Iterator(seekInfo.Start)
@@ -333,7 +347,18 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 		logger.Debugf("[channel: %s] Delivering block [%d] for (%p) for %s", chdr.ChannelId, block.Header.Number, seekInfo, addr)
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		if seekInfo.ContentType == ab.SeekInfo_HEADER_WITH_SIG {
+			block = &cb.Block{
+				Header:   block.Header,
+				Metadata: block.Metadata,
+			}
+		}
+
+		if filter != nil {
+			block = filter.apply(block)
+		}
+
+		if err := srv.SendBlockResponse(block, chdr.ChannelId, chain, signedData[0]); err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return cb.Status_INTERNAL_SERVER_ERROR, err
 		}
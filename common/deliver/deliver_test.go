@@ -20,7 +20,9 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -292,6 +294,20 @@ var _ = Describe("Deliver", func() {
 			Expect(cid).To(Equal("chain-id"))
 		})
 
+		It("sends the blocks along with the channel and the signed request", func() {
+			err := handler.Handle(context.Background(), server)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+			_, channelID, chain, signedData := fakeResponseSender.SendBlockResponseArgsForCall(0)
+			Expect(channelID).To(Equal("chain-id"))
+			Expect(chain).To(Equal(fakeChain))
+			Expect(signedData).To(Equal(&protoutil.SignedData{
+				Data:      envelope.Payload,
+				Signature: envelope.Signature,
+			}))
+		})
+
 		It("gets a block iterator from the starting block", func() {
 			err := handler.Handle(context.Background(), server)
 			Expect(err).NotTo(HaveOccurred())
@@ -323,7 +339,7 @@ var _ = Describe("Deliver", func() {
 
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
 				for i := 0; i < 5; i++ {
-					b := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
 					Expect(b).To(Equal(&cb.Block{
 						Header: &cb.BlockHeader{Number: 995 + uint64(i)},
 					}))
@@ -366,6 +382,173 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when only block headers and signatures are requested", func() {
+			BeforeEach(func() {
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Data:     &cb.BlockData{Data: [][]byte{[]byte("tx")}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}, cb.Status_SUCCESS)
+				seekInfo.ContentType = ab.SeekInfo_HEADER_WITH_SIG
+			})
+
+			It("sends the blocks without their data", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b).To(Equal(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}))
+			})
+		})
+
+		Context("when the seek info has a filter", func() {
+			var (
+				tx1, tx2, tx3, configTx []byte
+				block                   *cb.Block
+			)
+
+			BeforeEach(func() {
+				tx1 = filterTestEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", "Org1MSP", "mycc", "transfer")
+				tx2 = filterTestEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", "Org2MSP", "mycc", "")
+				tx3 = filterTestEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3", "Org1MSP", "othercc", "transfer")
+				configTx = filterTestEnvelope(cb.HeaderType_CONFIG, "", "Org1MSP", "", "")
+				block = &cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data:   &cb.BlockData{Data: [][]byte{tx1, tx2, tx3, configTx}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{
+						{},
+						{},
+						{byte(pb.TxValidationCode_VALID), byte(pb.TxValidationCode_MVCC_READ_CONFLICT), byte(pb.TxValidationCode_VALID), byte(pb.TxValidationCode_VALID)},
+					}},
+				}
+				fakeBlockIterator.NextReturns(block, cb.Status_SUCCESS)
+			})
+
+			sentData := func() [][]byte {
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b.Header).To(Equal(block.Header))
+				Expect(b.Metadata).To(Equal(block.Metadata))
+				return b.Data.Data
+			}
+
+			Context("when filtering by chaincode names", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{ChaincodeNames: []string{"mycc"}}
+				})
+
+				It("sends only the transactions of the chaincodes", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{tx1, tx2, nil, nil}))
+				})
+			})
+
+			Context("when filtering by chaincode event name", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{ChaincodeEventNameRegex: "^trans"}
+				})
+
+				It("sends only the transactions with matching chaincode events", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{tx1, nil, tx3, nil}))
+				})
+			})
+
+			Context("when filtering by several criteria", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{
+						ChaincodeNames:          []string{"othercc"},
+						ChaincodeEventNameRegex: "transfer",
+						CreatorMspIds:           []string{"Org1MSP"},
+					}
+				})
+
+				It("sends only the transactions matching all of them", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{nil, nil, tx3, nil}))
+				})
+			})
+
+			Context("when filtering by transaction ID", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{TxId: "tx2"}
+				})
+
+				It("sends only the transaction with the ID", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{nil, tx2, nil, nil}))
+				})
+			})
+
+			Context("when filtering by creator MSP", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{CreatorMspIds: []string{"Org2MSP"}}
+				})
+
+				It("sends only the transactions of the creator MSPs", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{nil, tx2, nil, nil}))
+				})
+			})
+
+			Context("when filtering by validation code", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{ValidationCodes: []int32{int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}}
+				})
+
+				It("sends only the transactions with the validation codes", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{nil, tx2, nil, nil}))
+				})
+			})
+
+			Context("when no transaction matches the filter", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{TxId: "unknown"}
+				})
+
+				It("sends an empty block marker", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sentData()).To(Equal([][]byte{nil, nil, nil, nil}))
+					Expect(block.Data.Data).To(Equal([][]byte{tx1, tx2, tx3, configTx}), "the block of the ledger should not be modified")
+				})
+			})
+
+			Context("when the chaincode event name regex is invalid", func() {
+				BeforeEach(func() {
+					seekInfo.Filter = &ab.SeekFilter{ChaincodeEventNameRegex: "("}
+				})
+
+				It("sends a bad request message", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
+
 		Context("when seek info is configured to stop at the oldest block", func() {
 			BeforeEach(func() {
 				seekInfo = &ab.SeekInfo{Start: &ab.SeekPosition{}, Stop: seekOldest}
@@ -381,7 +564,7 @@ var _ = Describe("Deliver", func() {
 				Expect(fakeBlockIterator.NextCallCount()).To(Equal(1))
 
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
-				b := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(0)
 				Expect(b).To(Equal(&cb.Block{
 					Header: &cb.BlockHeader{Number: 100},
 				}))
@@ -412,7 +595,7 @@ var _ = Describe("Deliver", func() {
 				Expect(fakeBlockIterator.NextCallCount()).To(Equal(2))
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
 				for i := 0; i < fakeResponseSender.SendBlockResponseCallCount(); i++ {
-					b := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
 					Expect(b).To(Equal(&cb.Block{
 						Header: &cb.BlockHeader{Number: uint64(i + 1)},
 					}))
@@ -443,7 +626,7 @@ var _ = Describe("Deliver", func() {
 				Expect(fakeBlockIterator.NextCallCount()).To(Equal(1))
 				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
 				for i := 0; i < fakeResponseSender.SendBlockResponseCallCount(); i++ {
-					b := fakeResponseSender.SendBlockResponseArgsForCall(i)
+					b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(i)
 					Expect(b).To(Equal(&cb.Block{
 						Header: &cb.BlockHeader{Number: uint64(i)},
 					}))
@@ -901,3 +1084,37 @@ var _ = Describe("Deliver", func() {
 		})
 	})
 })
+
+func filterTestEnvelope(headerType cb.HeaderType, txID, mspID, chaincodeName, eventName string) []byte {
+	ccAction := &pb.ChaincodeAction{
+		ChaincodeId: &pb.ChaincodeID{Name: chaincodeName},
+	}
+	if eventName != "" {
+		ccAction.Events = protoutil.MarshalOrPanic(&pb.ChaincodeEvent{ChaincodeId: chaincodeName, EventName: eventName})
+	}
+	tx := &pb.Transaction{
+		Actions: []*pb.TransactionAction{{
+			Payload: protoutil.MarshalOrPanic(&pb.ChaincodeActionPayload{
+				Action: &pb.ChaincodeEndorsedAction{
+					ProposalResponsePayload: protoutil.MarshalOrPanic(&pb.ProposalResponsePayload{
+						Extension: protoutil.MarshalOrPanic(ccAction),
+					}),
+				},
+			}),
+		}},
+	}
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+				Type:      int32(headerType),
+				ChannelId: "chain-id",
+				TxId:      txID,
+			}),
+			SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+				Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID}),
+			}),
+		},
+		Data: protoutil.MarshalOrPanic(tx),
+	}
+	return protoutil.MarshalOrPanic(&cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// blockFilter selects the transactions of the delivered blocks that match
+// the filter of a seek request.
+type blockFilter struct {
+	chaincodeNames  map[string]struct{}
+	eventNameRegex  *regexp.Regexp
+	txID            string
+	creatorMSPIDs   map[string]struct{}
+	validationCodes map[int32]struct{}
+}
+
+// newBlockFilter creates a blockFilter out of the filter of a seek request,
+// or returns nil if the request has no filter.
+func newBlockFilter(filter *ab.SeekFilter) (*blockFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	bf := &blockFilter{
+		txID:            filter.TxId,
+		chaincodeNames:  toSet(filter.ChaincodeNames),
+		creatorMSPIDs:   toSet(filter.CreatorMspIds),
+		validationCodes: map[int32]struct{}{},
+	}
+	for _, code := range filter.ValidationCodes {
+		bf.validationCodes[code] = struct{}{}
+	}
+	if filter.ChaincodeEventNameRegex != "" {
+		regex, err := regexp.Compile(filter.ChaincodeEventNameRegex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chaincode event name regex %s", filter.ChaincodeEventNameRegex)
+		}
+		bf.eventNameRegex = regex
+	}
+	return bf, nil
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+// apply returns a copy of the block in which the envelopes of the transactions
+// that do not match the filter are removed. Their entry in the block data is
+// left empty so that the block metadata still applies to the remaining ones.
+func (bf *blockFilter) apply(block *cb.Block) *cb.Block {
+	if block.Data == nil {
+		return block
+	}
+
+	var validationFlags []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		validationFlags = block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	data := make([][]byte, len(block.Data.Data))
+	for txIndex, envBytes := range block.Data.Data {
+		validationCode := int32(-1)
+		if txIndex < len(validationFlags) {
+			validationCode = int32(validationFlags[txIndex])
+		}
+		if bf.matches(envBytes, validationCode) {
+			data[txIndex] = envBytes
+		}
+	}
+
+	return &cb.Block{
+		Header:   block.Header,
+		Data:     &cb.BlockData{Data: data},
+		Metadata: block.Metadata,
+	}
+}
+
+// matches returns true if the transaction of the envelope matches every
+// criterion of the filter
+func (bf *blockFilter) matches(envBytes []byte, validationCode int32) bool {
+	if len(bf.validationCodes) > 0 {
+		if _, ok := bf.validationCodes[validationCode]; !ok {
+			return false
+		}
+	}
+
+	env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return false
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return false
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return false
+	}
+
+	if bf.txID != "" && chdr.TxId != bf.txID {
+		return false
+	}
+
+	if len(bf.creatorMSPIDs) > 0 {
+		shdr, err := protoutil.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return false
+		}
+		creator := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(shdr.Creator, creator); err != nil {
+			return false
+		}
+		if _, ok := bf.creatorMSPIDs[creator.Mspid]; !ok {
+			return false
+		}
+	}
+
+	if len(bf.chaincodeNames) == 0 && bf.eventNameRegex == nil {
+		return true
+	}
+	if cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+		return false
+	}
+	return bf.matchesChaincodeActions(payload.Data)
+}
+
+// matchesChaincodeActions returns true if an action of the transaction invokes
+// one of the chaincodes of the filter and sets an event matching its regex
+func (bf *blockFilter) matchesChaincodeActions(txBytes []byte) bool {
+	tx, err := protoutil.GetTransaction(txBytes)
+	if err != nil {
+		return false
+	}
+
+	for _, action := range tx.Actions {
+		ccActionPayload, err := protoutil.GetChaincodeActionPayload(action.Payload)
+		if err != nil || ccActionPayload.Action == nil {
+			continue
+		}
+		prp, err := protoutil.GetProposalResponsePayload(ccActionPayload.Action.ProposalResponsePayload)
+		if err != nil {
+			continue
+		}
+		ccAction, err := protoutil.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			continue
+		}
+
+		if len(bf.chaincodeNames) > 0 {
+			if _, ok := bf.chaincodeNames[ccAction.GetChaincodeId().GetName()]; !ok {
+				continue
+			}
+		}
+		if bf.eventNameRegex != nil {
+			ccEvent, err := protoutil.GetChaincodeEvents(ccAction.Events)
+			if err != nil || ccEvent.EventName == "" || !bf.eventNameRegex.MatchString(ccEvent.EventName) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -4,7 +4,9 @@ package mock
 import (
 	sync "sync"
 
+	deliver "github.com/hyperledger/fabric/common/deliver"
 	common "github.com/hyperledger/fabric/protos/common"
+	protoutil "github.com/hyperledger/fabric/protoutil"
 )
 
 type FilteredResponseSender struct {
@@ -18,10 +20,13 @@ type FilteredResponseSender struct {
 	isFilteredReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	SendBlockResponseStub        func(*common.Block) error
+	SendBlockResponseStub        func(*common.Block, string, deliver.Chain, *protoutil.SignedData) error
 	sendBlockResponseMutex       sync.RWMutex
 	sendBlockResponseArgsForCall []struct {
 		arg1 *common.Block
+		arg2 string
+		arg3 deliver.Chain
+		arg4 *protoutil.SignedData
 	}
 	sendBlockResponseReturns struct {
 		result1 error
@@ -96,16 +101,19 @@ func (fake *FilteredResponseSender) IsFilteredReturnsOnCall(i int, result1 bool)
 	}{result1}
 }
 
-func (fake *FilteredResponseSender) SendBlockResponse(arg1 *common.Block) error {
+func (fake *FilteredResponseSender) SendBlockResponse(arg1 *common.Block, arg2 string, arg3 deliver.Chain, arg4 *protoutil.SignedData) error {
 	fake.sendBlockResponseMutex.Lock()
 	ret, specificReturn := fake.sendBlockResponseReturnsOnCall[len(fake.sendBlockResponseArgsForCall)]
 	fake.sendBlockResponseArgsForCall = append(fake.sendBlockResponseArgsForCall, struct {
 		arg1 *common.Block
-	}{arg1})
-	fake.recordInvocation("SendBlockResponse", []interface{}{arg1})
+		arg2 string
+		arg3 deliver.Chain
+		arg4 *protoutil.SignedData
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SendBlockResponse", []interface{}{arg1, arg2, arg3, arg4})
 	fake.sendBlockResponseMutex.Unlock()
 	if fake.SendBlockResponseStub != nil {
-		return fake.SendBlockResponseStub(arg1)
+		return fake.SendBlockResponseStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -120,17 +128,17 @@ func (fake *FilteredResponseSender) SendBlockResponseCallCount() int {
 	return len(fake.sendBlockResponseArgsForCall)
 }
 
-func (fake *FilteredResponseSender) SendBlockResponseCalls(stub func(*common.Block) error) {
+func (fake *FilteredResponseSender) SendBlockResponseCalls(stub func(*common.Block, string, deliver.Chain, *protoutil.SignedData) error) {
 	fake.sendBlockResponseMutex.Lock()
 	defer fake.sendBlockResponseMutex.Unlock()
 	fake.SendBlockResponseStub = stub
 }
 
-func (fake *FilteredResponseSender) SendBlockResponseArgsForCall(i int) *common.Block {
+func (fake *FilteredResponseSender) SendBlockResponseArgsForCall(i int) (*common.Block, string, deliver.Chain, *protoutil.SignedData) {
 	fake.sendBlockResponseMutex.RLock()
 	defer fake.sendBlockResponseMutex.RUnlock()
 	argsForCall := fake.sendBlockResponseArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FilteredResponseSender) SendBlockResponseReturns(result1 error) {
@@ -6,13 +6,17 @@ import (
 
 	deliver "github.com/hyperledger/fabric/common/deliver"
 	common "github.com/hyperledger/fabric/protos/common"
+	protoutil "github.com/hyperledger/fabric/protoutil"
 )
 
 type ResponseSender struct {
-	SendBlockResponseStub        func(*common.Block) error
+	SendBlockResponseStub        func(*common.Block, string, deliver.Chain, *protoutil.SignedData) error
 	sendBlockResponseMutex       sync.RWMutex
 	sendBlockResponseArgsForCall []struct {
 		arg1 *common.Block
+		arg2 string
+		arg3 deliver.Chain
+		arg4 *protoutil.SignedData
 	}
 	sendBlockResponseReturns struct {
 		result1 error
@@ -35,16 +39,19 @@ type ResponseSender struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ResponseSender) SendBlockResponse(arg1 *common.Block) error {
+func (fake *ResponseSender) SendBlockResponse(arg1 *common.Block, arg2 string, arg3 deliver.Chain, arg4 *protoutil.SignedData) error {
 	fake.sendBlockResponseMutex.Lock()
 	ret, specificReturn := fake.sendBlockResponseReturnsOnCall[len(fake.sendBlockResponseArgsForCall)]
 	fake.sendBlockResponseArgsForCall = append(fake.sendBlockResponseArgsForCall, struct {
 		arg1 *common.Block
-	}{arg1})
-	fake.recordInvocation("SendBlockResponse", []interface{}{arg1})
+		arg2 string
+		arg3 deliver.Chain
+		arg4 *protoutil.SignedData
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SendBlockResponse", []interface{}{arg1, arg2, arg3, arg4})
 	fake.sendBlockResponseMutex.Unlock()
 	if fake.SendBlockResponseStub != nil {
-		return fake.SendBlockResponseStub(arg1)
+		return fake.SendBlockResponseStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -59,17 +66,17 @@ func (fake *ResponseSender) SendBlockResponseCallCount() int {
 	return len(fake.sendBlockResponseArgsForCall)
 }
 
-func (fake *ResponseSender) SendBlockResponseCalls(stub func(*common.Block) error) {
+func (fake *ResponseSender) SendBlockResponseCalls(stub func(*common.Block, string, deliver.Chain, *protoutil.SignedData) error) {
 	fake.sendBlockResponseMutex.Lock()
 	defer fake.sendBlockResponseMutex.Unlock()
 	fake.SendBlockResponseStub = stub
 }
 
-func (fake *ResponseSender) SendBlockResponseArgsForCall(i int) *common.Block {
+func (fake *ResponseSender) SendBlockResponseArgsForCall(i int) (*common.Block, string, deliver.Chain, *protoutil.SignedData) {
 	fake.sendBlockResponseMutex.RLock()
 	defer fake.sendBlockResponseMutex.RUnlock()
 	argsForCall := fake.sendBlockResponseArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *ResponseSender) SendBlockResponseReturns(result1 error) {
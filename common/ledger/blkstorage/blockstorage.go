@@ -25,6 +25,10 @@ const (
 	IndexableAttrBlockNumTranNum  = IndexableAttr("BlockNumTranNum")
 	IndexableAttrBlockTxID        = IndexableAttr("BlockTxID")
 	IndexableAttrTxValidationCode = IndexableAttr("TxValidationCode")
+	// IndexableAttrChaincodeNamespace indexes the transactions by the chaincode namespaces they touch
+	IndexableAttrChaincodeNamespace = IndexableAttr("ChaincodeNamespace")
+	// IndexableAttrCreator indexes the transactions by the identity that created them
+	IndexableAttrCreator = IndexableAttr("Creator")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -38,14 +42,31 @@ var (
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrTxDetailsNotAvailable is used to indicate that the txid is present in the block store
+	// but the details of the transaction are not available, as the block store was bootstrapped
+	// from a snapshot taken at a later height
+	ErrTxDetailsNotAvailable = l.TxDetailsNotAvailableErr("")
+
+	// ErrBlockArchived is used to indicate that the requested block has been moved to an archive
+	// and no archive reader is configured for serving the archived blocks
+	ErrBlockArchived = errors.New("block is archived")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	BootstrapFromSnapshottedTxIDs(snapshotDir string, snapshotInfo *SnapshotInfo, ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// Rollback removes the blocks after the given block number from the block store of a ledger
+	// that is not open. This is meant to be used for an offline maintenance of the ledger
+	Rollback(ledgerid string, blockNum uint64) error
+	// ValidateRollback returns an error if the block store of a ledger cannot be rolled back to the given block number
+	ValidateRollback(ledgerid string, blockNum uint64) error
+	// Drop removes the block store of a ledger that is not open
+	Drop(ledgerid string) error
 	Close()
 }
 
@@ -62,5 +83,20 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveTxsByChaincodeNamespace returns, in the order of the chain, at most pageSize transactions that
+	// touched the given chaincode namespace, starting at the bookmark returned along with the previous page.
+	// An empty bookmark starts at the first transaction and an empty bookmark is returned along with the last page
+	RetrieveTxsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error)
+	// RetrieveTxsByCreator returns, in the order of the chain, at most pageSize transactions that were created
+	// by the given serialized identity. The bookmark is used as in RetrieveTxsByChaincodeNamespace
+	RetrieveTxsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error)
+	ExportTxIds(dir string) (map[string][]byte, error)
 	Shutdown()
 }
+
+// SnapshotInfo captures some of the details about the snapshot
+type SnapshotInfo struct {
+	LastBlockNum      uint64
+	LastBlockHash     []byte
+	PreviousBlockHash []byte
+}
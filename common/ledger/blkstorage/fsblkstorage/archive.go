@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/pkg/errors"
+)
+
+// ArchiveConf encapsulates the configurations for archiving the block files of a ledger.
+// A block file is moved to the archive when the `PrunePolicy` allows pruning the last block in the file.
+// The `Reader` is optional and, if not supplied, a request for an archived block returns `blkstorage.ErrBlockArchived`
+type ArchiveConf struct {
+	PrunePolicy ledger.PrunePolicy
+	Writer      ArchiveWriter
+	Reader      ArchiveReader
+}
+
+// ArchiveWriter copies the block files of a ledger to an archive
+type ArchiveWriter interface {
+	// Archive copies the block file present at the given path to the archive. The block store removes its local
+	// copy of the block file only after this function returns successfully. This function may be invoked again
+	// for the same block file if a crash happens before the block store records the block file as archived
+	Archive(ledgerID string, fileNum int, blockfilePath string) error
+}
+
+// ArchiveReader serves the archived block files of a ledger
+type ArchiveReader interface {
+	// OpenBlockfile returns a reader that reads the archived block file from its beginning
+	OpenBlockfile(ledgerID string, fileNum int) (io.ReadCloser, error)
+}
+
+// RetentionPolicy is a `ledger.PrunePolicy` that retains the latest `RetainBlocks` blocks and the blocks
+// that were persisted within the last `RetainPeriod`. A zero value for either of the fields does not retain
+// any block on that criterion
+type RetentionPolicy struct {
+	RetainBlocks uint64
+	RetainPeriod time.Duration
+}
+
+// ShouldPrune implements function in the interface `ledger.PrunePolicy`
+func (p *RetentionPolicy) ShouldPrune(blockNum uint64, persistedAt time.Time, ledgerHeight uint64) bool {
+	if blockNum+p.RetainBlocks >= ledgerHeight {
+		return false
+	}
+	return p.RetainPeriod == 0 || time.Since(persistedAt) > p.RetainPeriod
+}
+
+// DirArchive is an `ArchiveWriter` and `ArchiveReader` that keeps the archived block files
+// as is, in a separate directory per ledger
+type DirArchive struct {
+	rootDir string
+}
+
+// NewDirArchive constructs a `DirArchive` that keeps the block files under the given dir
+func NewDirArchive(rootDir string) *DirArchive {
+	return &DirArchive{rootDir}
+}
+
+// Archive implements function in the interface `ArchiveWriter`
+func (a *DirArchive) Archive(ledgerID string, fileNum int, blockfilePath string) error {
+	return writeArchiveFile(a.archivedFilePath(ledgerID, fileNum), func(w io.Writer) error {
+		return copyFileContent(w, blockfilePath)
+	})
+}
+
+// OpenBlockfile implements function in the interface `ArchiveReader`
+func (a *DirArchive) OpenBlockfile(ledgerID string, fileNum int) (io.ReadCloser, error) {
+	file, err := os.Open(a.archivedFilePath(ledgerID, fileNum))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening archived block file [%d] of ledger [%s]", fileNum, ledgerID)
+	}
+	return file, nil
+}
+
+func (a *DirArchive) archivedFilePath(ledgerID string, fileNum int) string {
+	return deriveBlockfilePath(filepath.Join(a.rootDir, ledgerID), fileNum)
+}
+
+// TarArchive is an `ArchiveWriter` and `ArchiveReader` that keeps each archived block file
+// as a gzip compressed tar, in a separate directory per ledger
+type TarArchive struct {
+	rootDir string
+}
+
+// NewTarArchive constructs a `TarArchive` that keeps the compressed block files under the given dir
+func NewTarArchive(rootDir string) *TarArchive {
+	return &TarArchive{rootDir}
+}
+
+// Archive implements function in the interface `ArchiveWriter`
+func (a *TarArchive) Archive(ledgerID string, fileNum int, blockfilePath string) error {
+	fileInfo, err := os.Stat(blockfilePath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading the stats of block file %s", blockfilePath)
+	}
+	return writeArchiveFile(a.archivedFilePath(ledgerID, fileNum), func(w io.Writer) error {
+		gzipWriter := gzip.NewWriter(w)
+		tarWriter := tar.NewWriter(gzipWriter)
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    filepath.Base(blockfilePath),
+			Mode:    0600,
+			Size:    fileInfo.Size(),
+			ModTime: fileInfo.ModTime(),
+		}); err != nil {
+			return errors.Wrapf(err, "error writing the tar header for block file %s", blockfilePath)
+		}
+		if err := copyFileContent(tarWriter, blockfilePath); err != nil {
+			return err
+		}
+		if err := tarWriter.Close(); err != nil {
+			return errors.Wrapf(err, "error closing the tar writer for block file %s", blockfilePath)
+		}
+		return errors.Wrapf(gzipWriter.Close(), "error closing the gzip writer for block file %s", blockfilePath)
+	})
+}
+
+// OpenBlockfile implements function in the interface `ArchiveReader`
+func (a *TarArchive) OpenBlockfile(ledgerID string, fileNum int) (io.ReadCloser, error) {
+	filePath := a.archivedFilePath(ledgerID, fileNum)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening archived block file [%d] of ledger [%s]", fileNum, ledgerID)
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error reading the archive %s", filePath)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	if _, err := tarReader.Next(); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error reading the archive %s", filePath)
+	}
+	return &tarEntryReader{tarReader, file}, nil
+}
+
+func (a *TarArchive) archivedFilePath(ledgerID string, fileNum int) string {
+	return deriveBlockfilePath(filepath.Join(a.rootDir, ledgerID), fileNum) + ".tar.gz"
+}
+
+type tarEntryReader struct {
+	io.Reader
+	file *os.File
+}
+
+func (r *tarEntryReader) Close() error {
+	return r.file.Close()
+}
+
+// writeArchiveFile writes the content to a temporary file and renames it to the given path
+// after syncing it, so that a crash does not leave a partially written file at the given path
+func writeArchiveFile(filePath string, writeContent func(io.Writer) error) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating archive dir %s", dir)
+	}
+	tmpFilePath := fmt.Sprintf("%s.tmp", filePath)
+	file, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %s", tmpFilePath)
+	}
+	if err := writeContent(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "error syncing file %s", tmpFilePath)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "error closing file %s", tmpFilePath)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error renaming file %s to %s", tmpFilePath, filePath)
+	}
+	return syncDir(dir)
+}
+
+func copyFileContent(w io.Writer, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening block file %s", filePath)
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return errors.Wrapf(err, "error copying block file %s", filePath)
+}
+
+func syncDir(dirPath string) error {
+	dir, err := os.Open(dirPath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening dir %s", dirPath)
+	}
+	defer dir.Close()
+	return errors.Wrapf(dir.Sync(), "error syncing dir %s", dirPath)
+}
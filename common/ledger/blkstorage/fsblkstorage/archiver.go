@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/pkg/errors"
+)
+
+var blkMgrArchiveInfoKey = []byte("blkMgrArchiveInfo")
+
+// initArchiveInfo loads the number of block files that have been moved to the archive and removes the
+// local copies of the archived block files that may be left behind by a crash
+func (mgr *blockfileMgr) initArchiveInfo() error {
+	b, err := mgr.db.Get(blkMgrArchiveInfoKey)
+	if err != nil || b == nil {
+		return err
+	}
+	numArchivedFiles, err := proto.NewBuffer(b).DecodeVarint()
+	if err != nil {
+		return errors.Wrap(err, "error while unmarshaling archive info")
+	}
+	mgr.numArchivedFiles = int(numArchivedFiles)
+	for fileNum := 0; fileNum < mgr.numArchivedFiles; fileNum++ {
+		if err := os.Remove(deriveBlockfilePath(mgr.rootDir, fileNum)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing archived block file [%d]", fileNum)
+		}
+	}
+	logger.Debugf("Number of archived block files = [%d]", mgr.numArchivedFiles)
+	return nil
+}
+
+func (mgr *blockfileMgr) isArchived(fileNum int) bool {
+	return fileNum < mgr.numArchivedFiles
+}
+
+// triggerArchiving archives the eligible block files in the background. The trigger is ignored
+// if the archiving is not enabled or if an archiving is already in progress
+func (mgr *blockfileMgr) triggerArchiving() {
+	if mgr.conf.archiveConf == nil {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&mgr.archivingInProgress, 0, 1) {
+		return
+	}
+	mgr.archiveWG.Add(1)
+	go func() {
+		defer mgr.archiveWG.Done()
+		defer atomic.StoreInt32(&mgr.archivingInProgress, 0)
+		if err := mgr.archiveEligibleBlockfiles(); err != nil {
+			logger.Errorf("Error while archiving block files: %+v", err)
+		}
+	}()
+}
+
+// archiveEligibleBlockfiles moves the block files, starting from the oldest, to the archive until it
+// encounters a block file that is not eligible as per the prune policy. The block file that is currently
+// being appended to is never archived
+func (mgr *blockfileMgr) archiveEligibleBlockfiles() error {
+	mgr.cpInfoCond.L.Lock()
+	cpInfo := mgr.cpInfo
+	mgr.cpInfoCond.L.Unlock()
+
+	ledgerHeight := mgr.getBlockchainInfo().Height
+	for fileNum := mgr.numArchivedFiles; fileNum < cpInfo.latestFileChunkSuffixNum; fileNum++ {
+		eligible, err := mgr.isEligibleForArchiving(fileNum, cpInfo, ledgerHeight)
+		if err != nil {
+			return err
+		}
+		if !eligible {
+			return nil
+		}
+		if err := mgr.archiveBlockfile(fileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mgr *blockfileMgr) isEligibleForArchiving(fileNum int, cpInfo *checkpointInfo, ledgerHeight uint64) (bool, error) {
+	filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return false, errors.Wrapf(err, "error reading the stats of block file %s", filePath)
+	}
+	lastBlockNum, err := mgr.lastBlockNumInFile(fileNum, cpInfo)
+	if err != nil {
+		return false, err
+	}
+	return mgr.conf.archiveConf.PrunePolicy.ShouldPrune(lastBlockNum, fileInfo.ModTime(), ledgerHeight), nil
+}
+
+// lastBlockNumInFile derives the number of the last block in a block file from the number
+// of the first block present in any of the subsequent block files
+func (mgr *blockfileMgr) lastBlockNumInFile(fileNum int, cpInfo *checkpointInfo) (uint64, error) {
+	for nextFileNum := fileNum + 1; nextFileNum <= cpInfo.latestFileChunkSuffixNum; nextFileNum++ {
+		stream, err := newBlockfileStream(mgr.rootDir, nextFileNum, 0)
+		if err != nil {
+			return 0, err
+		}
+		blockBytes, err := stream.nextBlockBytes()
+		stream.close()
+		if err != nil {
+			return 0, err
+		}
+		if blockBytes == nil {
+			continue
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return 0, err
+		}
+		if info.blockHeader.Number == 0 {
+			return 0, nil
+		}
+		return info.blockHeader.Number - 1, nil
+	}
+	return cpInfo.lastBlockNumber, nil
+}
+
+// archiveBlockfile copies the block file to the archive, records the block file as archived,
+// and then removes the local copy of the block file. The local copy is removed while holding
+// the archive lock so that the readers of the block file do not observe a missing file
+func (mgr *blockfileMgr) archiveBlockfile(fileNum int) error {
+	filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+	logger.Infof("Archiving block file [%s]", filePath)
+	if err := mgr.conf.archiveConf.Writer.Archive(mgr.ledgerID, fileNum, filePath); err != nil {
+		return errors.WithMessagef(err, "error archiving block file %s", filePath)
+	}
+
+	mgr.archiveLock.Lock()
+	defer mgr.archiveLock.Unlock()
+	if err := mgr.db.Put(blkMgrArchiveInfoKey, proto.EncodeVarint(uint64(fileNum+1)), true); err != nil {
+		return errors.WithMessage(err, "error saving archive info to db")
+	}
+	mgr.numArchivedFiles = fileNum + 1
+	if err := os.Remove(filePath); err != nil {
+		return errors.Wrapf(err, "error removing archived block file %s", filePath)
+	}
+	return nil
+}
+
+// fetchArchivedBlockBytes reads the block bytes present at the given location in an archived block file
+func (mgr *blockfileMgr) fetchArchivedBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	reader, err := mgr.openArchivedBlockfile(lp)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	bufReader := bufio.NewReader(reader)
+	length, err := binary.ReadUvarint(bufReader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the length of the archived block at %s", lp)
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(bufReader, b); err != nil {
+		return nil, errors.Wrapf(err, "error reading the archived block at %s", lp)
+	}
+	return b, nil
+}
+
+// fetchArchivedRawBytes reads the bytes present at the given location in an archived block file
+func (mgr *blockfileMgr) fetchArchivedRawBytes(lp *fileLocPointer) ([]byte, error) {
+	reader, err := mgr.openArchivedBlockfile(lp)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	b := make([]byte, lp.bytesLength)
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return nil, errors.Wrapf(err, "error reading the archived bytes at %s", lp)
+	}
+	return b, nil
+}
+
+// openArchivedBlockfile returns a reader positioned at the offset of the given location in an archived block file
+func (mgr *blockfileMgr) openArchivedBlockfile(lp *fileLocPointer) (io.ReadCloser, error) {
+	if mgr.conf.archiveConf == nil || mgr.conf.archiveConf.Reader == nil {
+		return nil, errors.WithMessagef(blkstorage.ErrBlockArchived, "block file [%d] is archived", lp.fileSuffixNum)
+	}
+	reader, err := mgr.conf.archiveConf.Reader.OpenBlockfile(mgr.ledgerID, lp.fileSuffixNum)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(ioutil.Discard, reader, int64(lp.offset)); err != nil {
+		reader.Close()
+		return nil, errors.Wrapf(err, "error seeking the archived block file [%d] to offset [%d]", lp.fileSuffixNum, lp.offset)
+	}
+	return reader, nil
+}
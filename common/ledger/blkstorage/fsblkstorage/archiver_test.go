@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		policy       *RetentionPolicy
+		blockNum     uint64
+		persistedAt  time.Time
+		ledgerHeight uint64
+		shouldPrune  bool
+	}{
+		{policy: &RetentionPolicy{RetainBlocks: 5}, blockNum: 4, persistedAt: now, ledgerHeight: 10, shouldPrune: true},
+		{policy: &RetentionPolicy{RetainBlocks: 5}, blockNum: 5, persistedAt: now, ledgerHeight: 10, shouldPrune: false},
+		{policy: &RetentionPolicy{RetainBlocks: 5, RetainPeriod: time.Hour}, blockNum: 4, persistedAt: now, ledgerHeight: 10, shouldPrune: false},
+		{policy: &RetentionPolicy{RetainBlocks: 5, RetainPeriod: time.Hour}, blockNum: 4, persistedAt: now.Add(-2 * time.Hour), ledgerHeight: 10, shouldPrune: true},
+		{policy: &RetentionPolicy{RetainPeriod: time.Hour}, blockNum: 8, persistedAt: now.Add(-2 * time.Hour), ledgerHeight: 10, shouldPrune: true},
+		{policy: &RetentionPolicy{RetainPeriod: time.Hour}, blockNum: 8, persistedAt: now, ledgerHeight: 10, shouldPrune: false},
+	}
+	for i, test := range tests {
+		require.Equal(t, test.shouldPrune, test.policy.ShouldPrune(test.blockNum, test.persistedAt, test.ledgerHeight), "test case %d", i)
+	}
+}
+
+func TestArchiving(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	archives := map[string]interface {
+		ArchiveWriter
+		ArchiveReader
+	}{
+		"dir": NewDirArchive(filepath.Join(archiveDir, "dir")),
+		"tar": NewTarArchive(filepath.Join(archiveDir, "tar")),
+	}
+	for name, archive := range archives {
+		t.Run(name, func(t *testing.T) {
+			testArchiving(t, archive, archive)
+		})
+	}
+}
+
+func testArchiving(t *testing.T, writer ArchiveWriter, reader ArchiveReader) {
+	// a small max block file size places each block in a separate block file, leaving the first block file empty
+	conf := NewConfWithArchiving(testPath(), 1, &ArchiveConf{
+		PrunePolicy: &RetentionPolicy{RetainBlocks: 3},
+		Writer:      writer,
+		Reader:      reader,
+	})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	w.addBlocks(blocks)
+	w.blockfileMgr.archiveWG.Wait()
+	require.NoError(t, w.blockfileMgr.archiveEligibleBlockfiles())
+
+	// the blocks 0 to 6 in the block files 1 to 7 fall outside the retention of the last 3 blocks
+	verifyArchived := func(mgr *blockfileMgr) {
+		require.Equal(t, 8, mgr.numArchivedFiles)
+		for fileNum := 0; fileNum <= 10; fileNum++ {
+			_, err := os.Stat(deriveBlockfilePath(mgr.rootDir, fileNum))
+			require.Equal(t, fileNum < 8, os.IsNotExist(err), "block file %d", fileNum)
+		}
+	}
+	verifyRetrieval := func(mgr *blockfileMgr) {
+		w := &testBlockfileMgrWrapper{t, mgr}
+		w.testGetBlockByNumber(blocks, 0)
+		w.testGetBlockByHash(blocks)
+
+		txEnvFromArchive, err := mgr.retrieveTransactionByBlockNumTranNum(2, 0)
+		require.NoError(t, err)
+		txEnv, err := protoutil.GetEnvelopeFromBlock(blocks[2].Data.Data[0])
+		require.NoError(t, err)
+		require.Equal(t, txEnv, txEnvFromArchive)
+
+		itr, err := mgr.retrieveBlocks(0)
+		require.NoError(t, err)
+		defer itr.Close()
+		for _, expectedBlock := range blocks {
+			block, err := itr.Next()
+			require.NoError(t, err)
+			require.Equal(t, expectedBlock, block)
+		}
+	}
+	verifyArchived(w.blockfileMgr)
+	verifyRetrieval(w.blockfileMgr)
+
+	// a restart after a crash between recording the archiving and removing the local copy of a block file
+	rootDir := w.blockfileMgr.rootDir
+	w.close()
+	require.NoError(t, ioutil.WriteFile(deriveBlockfilePath(rootDir, 7), []byte("archived-content"), 0600))
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	w.blockfileMgr.archiveWG.Wait()
+	verifyArchived(w.blockfileMgr)
+	verifyRetrieval(w.blockfileMgr)
+}
+
+func TestArchivedBlockWithoutReader(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	conf := NewConfWithArchiving(testPath(), 1, &ArchiveConf{
+		PrunePolicy: &RetentionPolicy{RetainBlocks: 1},
+		Writer:      NewDirArchive(archiveDir),
+	})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	w.addBlocks(blocks)
+	w.blockfileMgr.archiveWG.Wait()
+	require.NoError(t, w.blockfileMgr.archiveEligibleBlockfiles())
+
+	_, err = w.blockfileMgr.retrieveBlockByNumber(0)
+	require.Equal(t, blkstorage.ErrBlockArchived, errors.Cause(err))
+	require.EqualError(t, err, "block file [1] is archived: block is archived")
+
+	itr, err := w.blockfileMgr.retrieveBlocks(1)
+	require.NoError(t, err)
+	defer itr.Close()
+	_, err = itr.Next()
+	require.Equal(t, blkstorage.ErrBlockArchived, errors.Cause(err))
+
+	block, err := w.blockfileMgr.retrieveBlockByNumber(2)
+	require.NoError(t, err)
+	require.Equal(t, blocks[2], block)
+}
+
+func TestSyncIndexWithArchivedBlockfiles(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	conf := NewConfWithArchiving(testPath(), 1, &ArchiveConf{
+		PrunePolicy: &RetentionPolicy{RetainBlocks: 2},
+		Writer:      NewDirArchive(archiveDir),
+	})
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	w.addBlocks(blocks)
+	w.blockfileMgr.archiveWG.Wait()
+	require.NoError(t, w.blockfileMgr.archiveEligibleBlockfiles())
+	require.Equal(t, 4, w.blockfileMgr.numArchivedFiles)
+
+	// an index built from scratch covers only the blocks present in the local block files
+	w.blockfileMgr.index = &blockIndex{
+		indexItemsMap: w.blockfileMgr.index.(*blockIndex).indexItemsMap,
+		db:            env.provider.leveldbProvider.GetDBHandle("rebuiltIndex"),
+	}
+	require.NoError(t, w.blockfileMgr.syncIndex())
+	lastBlockIndexed, err := w.blockfileMgr.index.getLastBlockIndexed()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), lastBlockIndexed)
+	block, err := w.blockfileMgr.retrieveBlockByNumber(3)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3], block)
+	_, err = w.blockfileMgr.retrieveBlockByNumber(2)
+	require.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+}
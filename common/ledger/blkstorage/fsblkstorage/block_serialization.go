@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	ledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
@@ -24,6 +25,8 @@ type txindexInfo struct {
 	txID        string
 	loc         *locPointer
 	isDuplicate bool
+	// txEnvBytes is only parsed for the indexes that need more than the txid
+	txEnvBytes []byte
 }
 
 func serializeBlock(block *common.Block) ([]byte, *serializedBlockInfo, error) {
@@ -109,7 +112,7 @@ func addDataBytes(blockData *common.BlockData, buf *proto.Buffer) ([]*txindexInf
 		if err := buf.EncodeRawBytes(txEnvelopeBytes); err != nil {
 			return nil, err
 		}
-		idxInfo := &txindexInfo{txID: txid, loc: &locPointer{offset, len(buf.Bytes()) - offset}}
+		idxInfo := &txindexInfo{txID: txid, loc: &locPointer{offset, len(buf.Bytes()) - offset}, txEnvBytes: txEnvelopeBytes}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return txOffsets, nil
@@ -169,7 +172,7 @@ func extractData(buf *ledgerutil.Buffer) (*common.BlockData, []*txindexInfo, err
 			return nil, nil, err
 		}
 		data.Data = append(data.Data, txEnvBytes)
-		idxInfo := &txindexInfo{txID: txid, loc: &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}}
+		idxInfo := &txindexInfo{txID: txid, loc: &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}, txEnvBytes: txEnvBytes}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return data, txOffsets, nil
@@ -207,3 +210,61 @@ func extractTxID(txEnvelopBytes []byte) (string, error) {
 	}
 	return chdr.TxId, nil
 }
+
+// extractNamespacesAndCreator returns the chaincode namespaces touched by the transaction and the serialized
+// identity of its creator. The namespaces are only present for the endorser transactions. A transaction that
+// cannot be parsed, which is possible for an invalid transaction, is not indexed by these attributes
+func extractNamespacesAndCreator(txEnvelopeBytes []byte) ([]string, []byte) {
+	txEnvelope, err := protoutil.GetEnvelopeFromBlock(txEnvelopeBytes)
+	if err != nil {
+		return nil, nil
+	}
+	txPayload, err := protoutil.GetPayload(txEnvelope)
+	if err != nil || txPayload.Header == nil {
+		return nil, nil
+	}
+	var creator []byte
+	if shdr, err := protoutil.GetSignatureHeader(txPayload.Header.SignatureHeader); err == nil {
+		creator = shdr.Creator
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(txPayload.Header.ChannelHeader)
+	if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, creator
+	}
+	tx, err := protoutil.GetTransaction(txPayload.Data)
+	if err != nil {
+		return nil, creator
+	}
+
+	var namespaces []string
+	added := map[string]bool{}
+	addNamespace := func(ns string) {
+		if ns != "" && !added[ns] {
+			added[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for _, action := range tx.Actions {
+		ccActionPayload, err := protoutil.GetChaincodeActionPayload(action.Payload)
+		if err != nil || ccActionPayload.Action == nil {
+			continue
+		}
+		prp, err := protoutil.GetProposalResponsePayload(ccActionPayload.Action.ProposalResponsePayload)
+		if err != nil {
+			continue
+		}
+		ccAction, err := protoutil.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			continue
+		}
+		addNamespace(ccAction.GetChaincodeId().GetName())
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(ccAction.Results, txRWSet); err != nil {
+			continue
+		}
+		for _, nsRWSet := range txRWSet.NsRwset {
+			addNamespace(nsRWSet.Namespace)
+		}
+	}
+	return namespaces, creator
+}
@@ -8,6 +8,7 @@ package fsblkstorage
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"sync"
@@ -36,6 +37,7 @@ var (
 )
 
 type blockfileMgr struct {
+	ledgerID          string
 	rootDir           string
 	conf              *Conf
 	db                *leveldbhelper.DBHandle
@@ -44,6 +46,15 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	// bootstrappingSnapshotInfo is non-nil if the block store was bootstrapped from a snapshot
+	bootstrappingSnapshotInfo *blkstorage.SnapshotInfo
+	// numArchivedFiles is the number of block files, starting from the first block file,
+	// that have been moved to the archive. archiveLock guards the removal of the archived
+	// block files against the concurrent readers of these files
+	numArchivedFiles    int
+	archiveLock         sync.RWMutex
+	archivingInProgress int32
+	archiveWG           sync.WaitGroup
 }
 
 /*
@@ -96,7 +107,21 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
 	// Instantiate the manager, i.e. blockFileMgr structure
-	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	mgr := &blockfileMgr{ledgerID: id, rootDir: rootDir, conf: conf, db: indexStore}
+
+	if err := checkNoRollbackInProgress(id, indexStore); err != nil {
+		panic(err.Error())
+	}
+
+	bsInfo, err := loadBootstrappingSnapshotInfo(indexStore)
+	if err != nil {
+		panic(fmt.Sprintf("Could not load bootstrapping snapshot info from db: %s", err))
+	}
+	mgr.bootstrappingSnapshotInfo = bsInfo
+
+	if err := mgr.initArchiveInfo(); err != nil {
+		panic(fmt.Sprintf("Could not load archive info from db: %s", err))
+	}
 
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
@@ -113,7 +138,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(cpInfo))
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
-		syncCPInfoFromFS(rootDir, cpInfo)
+		syncCPInfoFromFS(rootDir, cpInfo, bsInfo)
 	}
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
@@ -150,6 +175,14 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		CurrentBlockHash:  nil,
 		PreviousBlockHash: nil}
 
+	if cpInfo.isChainEmpty && bsInfo != nil {
+		// No block has been added since the block store was bootstrapped from a snapshot
+		bcInfo = &common.BlockchainInfo{
+			Height:            bsInfo.LastBlockNum + 1,
+			CurrentBlockHash:  bsInfo.LastBlockHash,
+			PreviousBlockHash: bsInfo.PreviousBlockHash}
+	}
+
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
@@ -165,6 +198,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	mgr.triggerArchiving()
 	return mgr
 }
 
@@ -172,7 +206,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
 //checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
-func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
+//For a block store that was bootstrapped from a snapshot, an empty chain has its lastBlockNumber
+//set to the last block number included in the snapshot
+func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo, bsInfo *blkstorage.SnapshotInfo) {
 	logger.Debugf("Starting checkpoint=%s", cpInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
@@ -199,7 +235,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 		return
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
-	if cpInfo.isChainEmpty {
+	if cpInfo.isChainEmpty && bsInfo == nil {
 		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
@@ -213,6 +249,7 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
+	mgr.archiveWG.Wait()
 	mgr.currentFileWriter.close()
 }
 
@@ -220,6 +257,7 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	cpInfo := &checkpointInfo{
 		latestFileChunkSuffixNum: mgr.cpInfo.latestFileChunkSuffixNum + 1,
 		latestFileChunksize:      0,
+		isChainEmpty:             mgr.cpInfo.isChainEmpty,
 		lastBlockNumber:          mgr.cpInfo.lastBlockNumber}
 
 	nextFileWriter, err := newBlockfileWriter(
@@ -271,9 +309,11 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 
 	//Determine if we need to start a new file since the size of this block
 	//exceeds the amount of space left in the current file
+	movedToNextFile := false
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
 		currentOffset = 0
+		movedToNextFile = true
 	}
 	//append blockBytesEncodedLen to the file
 	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
@@ -322,6 +362,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	if movedToNextFile {
+		mgr.triggerArchiving()
+	}
 	return nil
 }
 
@@ -344,6 +387,25 @@ func (mgr *blockfileMgr) syncIndex() error {
 	//get the last file that blocks were added to using the checkpoint info
 	endFileNum := mgr.cpInfo.latestFileChunkSuffixNum
 	startingBlockNum := uint64(0)
+	if mgr.bootstrappingSnapshotInfo != nil {
+		startingBlockNum = mgr.bootstrappingSnapshotInfo.LastBlockNum + 1
+	}
+
+	//build the entries of the optional attributes that were enabled after some blocks were indexed
+	if !indexEmpty {
+		attrs, err := mgr.index.optionalAttrsToRebuild()
+		if err != nil {
+			return err
+		}
+		if len(attrs) > 0 {
+			if err := mgr.rebuildOptionalAttrs(attrs, lastBlockIndexed); err != nil {
+				return err
+			}
+		}
+	}
+	if err := mgr.index.markOptionalAttrsIndexed(); err != nil {
+		return err
+	}
 
 	//if the index stored in the db has value, update the index information with those values
 	if !indexEmpty {
@@ -362,6 +424,12 @@ func (mgr *blockfileMgr) syncIndex() error {
 		startingBlockNum = lastBlockIndexed + 1
 	} else {
 		logger.Debugf("No block indexed, Last block present in block files=[%d]", mgr.cpInfo.lastBlockNumber)
+		if mgr.numArchivedFiles > 0 {
+			// the archived block files cannot be indexed again, so the index starts from the first local block file
+			logger.Warningf("Index is empty and [%d] block files are archived. The blocks in the archived block files are not indexed",
+				mgr.numArchivedFiles)
+			startFileNum = mgr.numArchivedFiles
+		}
 	}
 
 	logger.Infof("Start building index from block [%d] to last block [%d]", startingBlockNum, mgr.cpInfo.lastBlockNumber)
@@ -371,11 +439,11 @@ func (mgr *blockfileMgr) syncIndex() error {
 	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
-	var blockBytes []byte
-	var blockPlacementInfo *blockPlacementInfo
+	defer stream.close()
 
 	if skipFirstBlock {
-		if blockBytes, _, err = stream.nextBlockBytesAndPlacementInfo(); err != nil {
+		blockBytes, _, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
 			return err
 		}
 		if blockBytes == nil {
@@ -387,34 +455,15 @@ func (mgr *blockfileMgr) syncIndex() error {
 	//Should be at the last block already, but go ahead and loop looking for next blockBytes.
 	//If there is another block, add it to the index.
 	//This will ensure block indexes are correct, for example if peer had crashed before indexes got updated.
-	blockIdxInfo := &blockIdxInfo{}
+	var lastBlockNum uint64
 	for {
-		if blockBytes, blockPlacementInfo, err = stream.nextBlockBytesAndPlacementInfo(); err != nil {
-			return err
-		}
-		if blockBytes == nil {
-			break
-		}
-		info, err := extractSerializedBlockInfo(blockBytes)
+		blockIdxInfo, err := nextBlockIdxInfo(stream)
 		if err != nil {
 			return err
 		}
-
-		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		if blockIdxInfo == nil {
+			break
 		}
-
-		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = protoutil.BlockHeaderHash(info.blockHeader)
-		blockIdxInfo.blockNum = info.blockHeader.Number
-		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
-			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
-		blockIdxInfo.txOffsets = info.txOffsets
-		blockIdxInfo.metadata = info.metadata
-
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
 			return err
@@ -422,11 +471,72 @@ func (mgr *blockfileMgr) syncIndex() error {
 		if blockIdxInfo.blockNum%10000 == 0 {
 			logger.Infof("Indexed block number [%d]", blockIdxInfo.blockNum)
 		}
+		lastBlockNum = blockIdxInfo.blockNum
 	}
-	logger.Infof("Finished building index. Last block indexed [%d]", blockIdxInfo.blockNum)
+	logger.Infof("Finished building index. Last block indexed [%d]", lastBlockNum)
 	return nil
 }
 
+// rebuildOptionalAttrs adds the entries of the given optional attributes for the blocks that were indexed
+// before these attributes were enabled
+func (mgr *blockfileMgr) rebuildOptionalAttrs(attrs []blkstorage.IndexableAttr, lastBlockIndexed uint64) error {
+	startFileNum := 0
+	if mgr.numArchivedFiles > 0 {
+		logger.Warningf("[%d] block files are archived. The blocks in the archived block files are not indexed for %s",
+			mgr.numArchivedFiles, attrs)
+		startFileNum = mgr.numArchivedFiles
+	}
+	logger.Infof("Start building index for %s up to block [%d]", attrs, lastBlockIndexed)
+	stream, err := newBlockStream(mgr.rootDir, startFileNum, 0, mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockIdxInfo, err := nextBlockIdxInfo(stream)
+		if err != nil {
+			return err
+		}
+		if blockIdxInfo == nil || blockIdxInfo.blockNum > lastBlockIndexed {
+			break
+		}
+		if err := mgr.index.indexOptionalAttrs(blockIdxInfo, attrs); err != nil {
+			return err
+		}
+	}
+	logger.Infof("Finished building index for %s", attrs)
+	return nil
+}
+
+// nextBlockIdxInfo reads the next block of the stream and returns its index info, or nil at the end of the stream
+func nextBlockIdxInfo(stream *blockStream) (*blockIdxInfo, error) {
+	blockBytes, blockPlacementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+	if err != nil || blockBytes == nil {
+		return nil, err
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
+	//therefore just shift by the difference between blockBytesOffset and blockStartOffset
+	numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+	for _, offset := range info.txOffsets {
+		offset.loc.offset += numBytesToShift
+	}
+
+	//Update the blockIndexInfo with what was actually stored in file system
+	return &blockIdxInfo{
+		blockHash: protoutil.BlockHeaderHash(info.blockHeader),
+		blockNum:  info.blockHeader.Number,
+		flp: &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
+			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}},
+		txOffsets: info.txOffsets,
+		metadata:  info.metadata,
+	}, nil
+}
+
 func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
@@ -465,6 +575,9 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if err := mgr.checkBlockAvailable(blockNum); err != nil {
+		return nil, err
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -507,9 +620,25 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if err := mgr.checkBlockAvailable(startNum); err != nil {
+		return nil, err
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
+// checkBlockAvailable returns an error if the block store was bootstrapped from a snapshot
+// and the requested block was included in the snapshot, as such a block is not present in the block store
+func (mgr *blockfileMgr) checkBlockAvailable(blockNum uint64) error {
+	bsInfo := mgr.bootstrappingSnapshotInfo
+	if bsInfo != nil && blockNum <= bsInfo.LastBlockNum {
+		return errors.Errorf(
+			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
+			blockNum, bsInfo.LastBlockNum+1,
+		)
+	}
+	return nil
+}
+
 func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByID() - txId = [%s]", txID)
 	loc, err := mgr.index.getTxLoc(txID)
@@ -521,6 +650,9 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
+	if err := mgr.checkBlockAvailable(blockNum); err != nil {
+		return nil, err
+	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
 		return nil, err
@@ -528,6 +660,78 @@ func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, t
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
+func (mgr *blockfileMgr) retrieveTxsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	logger.Debugf("retrieveTxsByChaincodeNamespace() - namespace = [%s], bookmark = [%s]", namespace, bookmark)
+	return mgr.retrieveIndexedTxs(bookmark, pageSize, func(startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+		return mgr.index.getTxsByChaincodeNamespace(namespace, startBlockNum, startTranNum, limit)
+	})
+}
+
+func (mgr *blockfileMgr) retrieveTxsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	logger.Debugf("retrieveTxsByCreator() - bookmark = [%s]", bookmark)
+	return mgr.retrieveIndexedTxs(bookmark, pageSize, func(startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+		return mgr.index.getTxsByCreator(creator, startBlockNum, startTranNum, limit)
+	})
+}
+
+// retrieveIndexedTxs returns a page of the transactions returned by getTxs. The bookmark points to the block and
+// transaction number of the first transaction of the page, and the one returned to the first transaction of the next page
+func (mgr *blockfileMgr) retrieveIndexedTxs(
+	bookmark string,
+	pageSize int,
+	getTxs func(startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error),
+) ([]*peer.ProcessedTransaction, string, error) {
+	if pageSize <= 0 {
+		return nil, "", errors.Errorf("invalid page size [%d], it should be greater than zero", pageSize)
+	}
+	startBlockNum, startTranNum, err := decodeTxsBookmark(bookmark)
+	if err != nil {
+		return nil, "", err
+	}
+	// one more transaction is retrieved to find out whether there is a next page
+	txs, err := getTxs(startBlockNum, startTranNum, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	nextBookmark := ""
+	if len(txs) > pageSize {
+		nextBookmark = hex.EncodeToString(encodeBlockNumTranNum(txs[pageSize].blockNum, txs[pageSize].tranNum))
+		txs = txs[:pageSize]
+	}
+
+	processedTxs := make([]*peer.ProcessedTransaction, 0, len(txs))
+	for _, tx := range txs {
+		txEnvelope, err := mgr.fetchTransactionEnvelope(tx.txLoc)
+		if err != nil {
+			return nil, "", errors.WithMessagef(err, "error while retrieving transaction [%d] of block [%d]", tx.tranNum, tx.blockNum)
+		}
+		processedTxs = append(processedTxs, &peer.ProcessedTransaction{
+			TransactionEnvelope: txEnvelope,
+			ValidationCode:      int32(tx.validationCode),
+		})
+	}
+	return processedTxs, nextBookmark, nil
+}
+
+func decodeTxsBookmark(bookmark string) (uint64, uint64, error) {
+	if bookmark == "" {
+		return 0, 0, nil
+	}
+	b, err := hex.DecodeString(bookmark)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	blockNum, n, err := util.DecodeOrderPreservingVarUint64(b)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	tranNum, _, err := util.DecodeOrderPreservingVarUint64(b[n:])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return blockNum, tranNum, nil
+}
+
 func (mgr *blockfileMgr) fetchBlock(lp *fileLocPointer) (*common.Block, error) {
 	blockBytes, err := mgr.fetchBlockBytes(lp)
 	if err != nil {
@@ -552,6 +756,11 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	mgr.archiveLock.RLock()
+	defer mgr.archiveLock.RUnlock()
+	if mgr.isArchived(lp.fileSuffixNum) {
+		return mgr.fetchArchivedBlockBytes(lp)
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
@@ -565,6 +774,11 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	mgr.archiveLock.RLock()
+	defer mgr.archiveLock.RUnlock()
+	if mgr.isArchived(lp.fileSuffixNum) {
+		return mgr.fetchArchivedRawBytes(lp)
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -8,7 +8,9 @@ package fsblkstorage
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -27,14 +29,26 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	chaincodeNamespaceIdxKeyPrefix = 'c'
+	creatorIdxKeyPrefix            = 'r'
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	indexedOptionalAttrsKeyStr     = "indexedOptionalAttrsKey"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
+var indexedOptionalAttrsKey = []byte(indexedOptionalAttrsKeyStr)
 var errIndexEmpty = errors.New("NoBlockIndexed")
 
+// optionalAttrs are the attributes that can be enabled on a ledger that already has blocks, in which
+// case the index entries of the blocks committed before enabling them are built by syncIndex
+var optionalAttrs = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrChaincodeNamespace,
+	blkstorage.IndexableAttrCreator,
+}
+
 type index interface {
 	getLastBlockIndexed() (uint64, error)
+	isAttributeIndexed(attribute blkstorage.IndexableAttr) bool
 	indexBlock(blockIdxInfo *blockIdxInfo) error
 	getBlockLocByHash(blockHash []byte) (*fileLocPointer, error)
 	getBlockLocByBlockNum(blockNum uint64) (*fileLocPointer, error)
@@ -42,6 +56,19 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	getTxsByChaincodeNamespace(namespace string, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error)
+	getTxsByCreator(creator []byte, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error)
+	optionalAttrsToRebuild() ([]blkstorage.IndexableAttr, error)
+	indexOptionalAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error
+	markOptionalAttrsIndexed() error
+}
+
+// indexedTx is a transaction found through the chaincode namespace or the creator index
+type indexedTx struct {
+	blockNum       uint64
+	tranNum        uint64
+	validationCode peer.TxValidationCode
+	txLoc          *fileLocPointer
 }
 
 type blockIdxInfo struct {
@@ -72,7 +99,14 @@ func newBlockIndex(indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHand
 		return nil, errors.Errorf("dependent index [%s] is not enabled for [%s] or [%s]",
 			blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrTxValidationCode, blkstorage.IndexableAttrBlockTxID)
 	}
-	return &blockIndex{indexItemsMap, db}, nil
+	index := &blockIndex{indexItemsMap, db}
+	if _, err := index.getLastBlockIndexed(); err == errIndexEmpty {
+		// nothing is indexed yet, so all the optional attributes are built along with the blocks
+		if err := index.markOptionalAttrsIndexed(); err != nil {
+			return nil, err
+		}
+	}
+	return index, nil
 }
 
 func (index *blockIndex) getLastBlockIndexed() (uint64, error) {
@@ -87,6 +121,11 @@ func (index *blockIndex) getLastBlockIndexed() (uint64, error) {
 	return decodeBlockNum(blockNumBytes), nil
 }
 
+func (index *blockIndex) isAttributeIndexed(attribute blkstorage.IndexableAttr) bool {
+	_, ok := index.indexItemsMap[attribute]
+	return ok
+}
+
 func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	// do not index anything
 	if len(index.indexItemsMap) == 0 {
@@ -167,6 +206,11 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Index7 - Store the transactions by the chaincode namespaces they touch and by their creator
+	if err := index.addOptionalAttrsEntries(batch, blockIdxInfo, index.enabledOptionalAttrs()); err != nil {
+		return err
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -175,6 +219,100 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	return nil
 }
 
+func (index *blockIndex) enabledOptionalAttrs() []blkstorage.IndexableAttr {
+	var attrs []blkstorage.IndexableAttr
+	for _, attr := range optionalAttrs {
+		if index.indexItemsMap[attr] {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// addOptionalAttrsEntries adds to the batch the entries of the given optional attributes for the transactions
+// of the block. The value of an entry holds the validation code of the transaction followed by its location
+func (index *blockIndex) addOptionalAttrsEntries(batch *leveldbhelper.UpdateBatch, blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error {
+	if len(attrs) == 0 {
+		return nil
+	}
+	indexNamespaces, indexCreator := false, false
+	for _, attr := range attrs {
+		switch attr {
+		case blkstorage.IndexableAttrChaincodeNamespace:
+			indexNamespaces = true
+		case blkstorage.IndexableAttrCreator:
+			indexCreator = true
+		}
+	}
+
+	flp := blockIdxInfo.flp
+	txsfltr := ledgerUtil.TxValidationFlags(blockIdxInfo.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum, txoffset := range blockIdxInfo.txOffsets {
+		namespaces, creator := extractNamespacesAndCreator(txoffset.txEnvBytes)
+		if len(namespaces) == 0 && creator == nil {
+			continue
+		}
+		txFlpBytes, err := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc).marshal()
+		if err != nil {
+			return err
+		}
+		validationCode := peer.TxValidationCode_NOT_VALIDATED
+		if txNum < len(txsfltr) {
+			validationCode = txsfltr.Flag(txNum)
+		}
+		value := append([]byte{byte(validationCode)}, txFlpBytes...)
+
+		if indexNamespaces {
+			for _, namespace := range namespaces {
+				batch.Put(constructChaincodeNamespaceKey(namespace, blockIdxInfo.blockNum, uint64(txNum)), value)
+			}
+		}
+		if indexCreator && creator != nil {
+			batch.Put(constructCreatorKey(creator, blockIdxInfo.blockNum, uint64(txNum)), value)
+		}
+	}
+	return nil
+}
+
+// optionalAttrsToRebuild returns the optional attributes that are enabled but whose entries have not been
+// built for the blocks already indexed
+func (index *blockIndex) optionalAttrsToRebuild() ([]blkstorage.IndexableAttr, error) {
+	b, err := index.db.Get(indexedOptionalAttrsKey)
+	if err != nil {
+		return nil, err
+	}
+	indexed := map[string]bool{}
+	for _, attr := range strings.Split(string(b), ",") {
+		indexed[attr] = true
+	}
+	var attrs []blkstorage.IndexableAttr
+	for _, attr := range index.enabledOptionalAttrs() {
+		if !indexed[string(attr)] {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs, nil
+}
+
+// indexOptionalAttrs adds the entries of the given optional attributes for a block that is already indexed
+func (index *blockIndex) indexOptionalAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := index.addOptionalAttrsEntries(batch, blockIdxInfo, attrs); err != nil {
+		return err
+	}
+	return index.db.WriteBatch(batch, true)
+}
+
+// markOptionalAttrsIndexed records that the entries of the enabled optional attributes are present for all
+// the blocks indexed. An attribute that is disabled afterwards is rebuilt if it is enabled again
+func (index *blockIndex) markOptionalAttrsIndexed() error {
+	var attrs []string
+	for _, attr := range index.enabledOptionalAttrs() {
+		attrs = append(attrs, string(attr))
+	}
+	return index.db.Put(indexedOptionalAttrsKey, []byte(strings.Join(attrs, ",")), true)
+}
+
 func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 	uniqueTxids := make(map[string]bool)
 	for _, txIdxInfo := range blockIdxInfo.txOffsets {
@@ -184,14 +322,14 @@ func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 			continue
 		}
 
-		loc, err := index.getTxLoc(txid)
-		if loc != nil { // txid is duplicate of a previous tx in the index
+		exists, err := index.txIDExists(txid)
+		if err != nil {
+			return err
+		}
+		if exists { // txid is duplicate of a previous tx in the index
 			txIdxInfo.isDuplicate = true
 			continue
 		}
-		if err != blkstorage.ErrNotFoundInIndex {
-			return err
-		}
 		uniqueTxids[txid] = true
 	}
 	return nil
@@ -240,11 +378,36 @@ func (index *blockIndex) getTxLoc(txID string) (*fileLocPointer, error) {
 	if b == nil {
 		return nil, blkstorage.ErrNotFoundInIndex
 	}
+	if len(b) == 0 {
+		// the txid was imported from a snapshot and hence, the location is not available
+		return nil, blkstorage.ErrTxDetailsNotAvailable
+	}
 	txFLP := &fileLocPointer{}
 	txFLP.unmarshal(b)
 	return txFLP, nil
 }
 
+// txIDExists returns true if the txid is present in the index, including the
+// txids that were imported from a snapshot
+func (index *blockIndex) txIDExists(txID string) (bool, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxID]; !ok {
+		return false, blkstorage.ErrAttrNotIndexed
+	}
+	b, err := index.db.Get(constructTxIDKey(txID))
+	if err != nil {
+		return false, err
+	}
+	return b != nil, nil
+}
+
+// notFoundErr returns ErrTxDetailsNotAvailable, instead of ErrNotFoundInIndex, for a txid that was imported from a snapshot
+func (index *blockIndex) notFoundErr(txID string) error {
+	if exists, err := index.txIDExists(txID); err == nil && exists {
+		return blkstorage.ErrTxDetailsNotAvailable
+	}
+	return blkstorage.ErrNotFoundInIndex
+}
+
 func (index *blockIndex) getBlockLocByTxID(txID string) (*fileLocPointer, error) {
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockTxID]; !ok {
 		return nil, blkstorage.ErrAttrNotIndexed
@@ -254,7 +417,7 @@ func (index *blockIndex) getBlockLocByTxID(txID string) (*fileLocPointer, error)
 		return nil, err
 	}
 	if b == nil {
-		return nil, blkstorage.ErrNotFoundInIndex
+		return nil, index.notFoundErr(txID)
 	}
 	txFLP := &fileLocPointer{}
 	txFLP.unmarshal(b)
@@ -287,7 +450,7 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	if err != nil {
 		return peer.TxValidationCode(-1), err
 	} else if raw == nil {
-		return peer.TxValidationCode(-1), blkstorage.ErrNotFoundInIndex
+		return peer.TxValidationCode(-1), index.notFoundErr(txID)
 	} else if len(raw) != 1 {
 		return peer.TxValidationCode(-1), errors.New("invalid value in indexItems")
 	}
@@ -297,6 +460,61 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+func (index *blockIndex) getTxsByChaincodeNamespace(namespace string, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrChaincodeNamespace]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	return index.getIndexedTxs(constructChaincodeNamespaceKeyPrefix(namespace), startBlockNum, startTranNum, limit)
+}
+
+func (index *blockIndex) getTxsByCreator(creator []byte, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrCreator]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	return index.getIndexedTxs(constructCreatorKeyPrefix(creator), startBlockNum, startTranNum, limit)
+}
+
+// getIndexedTxs returns at most limit transactions of the entries that have the given key prefix, starting
+// at the given block and transaction number
+func (index *blockIndex) getIndexedTxs(keyPrefix []byte, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+	startKey := append(append([]byte{}, keyPrefix...), encodeBlockNumTranNum(startBlockNum, startTranNum)...)
+	// the first byte of an order preserving encoded number is its length, hence lower than 0xff
+	endKey := append(append([]byte{}, keyPrefix...), 0xff)
+	itr := index.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	var txs []*indexedTx
+	for len(txs) < limit && itr.Next() {
+		blockNumTranNum := itr.Key()[len(keyPrefix):]
+		blockNum, n, err := util.DecodeOrderPreservingVarUint64(blockNumTranNum)
+		if err != nil {
+			return nil, err
+		}
+		tranNum, _, err := util.DecodeOrderPreservingVarUint64(blockNumTranNum[n:])
+		if err != nil {
+			return nil, err
+		}
+		v := itr.Value()
+		if len(v) < 2 {
+			return nil, errors.Errorf("invalid index entry for block [%d], transaction [%d]", blockNum, tranNum)
+		}
+		txLoc := &fileLocPointer{}
+		if err := txLoc.unmarshal(v[1:]); err != nil {
+			return nil, err
+		}
+		txs = append(txs, &indexedTx{
+			blockNum:       blockNum,
+			tranNum:        tranNum,
+			validationCode: peer.TxValidationCode(int32(v[0])),
+			txLoc:          txLoc,
+		})
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating the block index")
+	}
+	return txs, nil
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
@@ -325,6 +543,29 @@ func constructBlockNumTranNumKey(blockNum uint64, txNum uint64) []byte {
 	return append([]byte{blockNumTranNumIdxKeyPrefix}, key...)
 }
 
+func constructChaincodeNamespaceKeyPrefix(namespace string) []byte {
+	key := append([]byte{chaincodeNamespaceIdxKeyPrefix}, []byte(namespace)...)
+	return append(key, 0x00)
+}
+
+func constructChaincodeNamespaceKey(namespace string, blockNum uint64, txNum uint64) []byte {
+	return append(constructChaincodeNamespaceKeyPrefix(namespace), encodeBlockNumTranNum(blockNum, txNum)...)
+}
+
+// constructCreatorKeyPrefix uses the hash of the serialized identity in order to keep the keys short
+func constructCreatorKeyPrefix(creator []byte) []byte {
+	creatorHash := sha256.Sum256(creator)
+	return append([]byte{creatorIdxKeyPrefix}, creatorHash[:]...)
+}
+
+func constructCreatorKey(creator []byte, blockNum uint64, txNum uint64) []byte {
+	return append(constructCreatorKeyPrefix(creator), encodeBlockNumTranNum(blockNum, txNum)...)
+}
+
+func encodeBlockNumTranNum(blockNum uint64, txNum uint64) []byte {
+	return append(util.EncodeOrderPreservingVarUint64(blockNum), util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
 func encodeBlockNum(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,9 @@ type noopIndex struct {
 func (i *noopIndex) getLastBlockIndexed() (uint64, error) {
 	return 0, nil
 }
+func (i *noopIndex) isAttributeIndexed(attribute blkstorage.IndexableAttr) bool {
+	return false
+}
 func (i *noopIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	return nil
 }
@@ -50,6 +54,26 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) getTxsByChaincodeNamespace(namespace string, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxsByCreator(creator []byte, startBlockNum, startTranNum uint64, limit int) ([]*indexedTx, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) optionalAttrsToRebuild() ([]blkstorage.IndexableAttr, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) indexOptionalAttrs(blockIdxInfo *blockIdxInfo, attrs []blkstorage.IndexableAttr) error {
+	return nil
+}
+
+func (i *noopIndex) markOptionalAttrsIndexed() error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -248,3 +272,163 @@ func containsAttr(indexItems []blkstorage.IndexableAttr, attr blkstorage.Indexab
 	}
 	return false
 }
+
+func TestBlockIndexChaincodeNamespaceAndCreator(t *testing.T) {
+	attrsToIndex := []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrChaincodeNamespace,
+		blkstorage.IndexableAttrCreator,
+	}
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), attrsToIndex, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks, signedCreator, unsignedCreator := constructNamespaceAndCreatorTestBlocks(t)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	t.Run("chaincode namespace", func(t *testing.T) {
+		txs, bookmark, err := blkfileMgr.retrieveTxsByChaincodeNamespace("cc1", "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, "", bookmark)
+		assert.Equal(t, []string{"tx1", "tx3", "tx4"}, txIDsOf(t, txs))
+		assert.Equal(t, int32(peer.TxValidationCode_MVCC_READ_CONFLICT), txs[2].ValidationCode)
+
+		txs, _, err = blkfileMgr.retrieveTxsByChaincodeNamespace("cc2", "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tx1", "tx2"}, txIDsOf(t, txs))
+
+		txs, bookmark, err = blkfileMgr.retrieveTxsByChaincodeNamespace("unknown", "", 10)
+		assert.NoError(t, err)
+		assert.Empty(t, txs)
+		assert.Equal(t, "", bookmark)
+	})
+
+	t.Run("creator", func(t *testing.T) {
+		txs, _, err := blkfileMgr.retrieveTxsByCreator(signedCreator, "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tx1", "tx4"}, txIDsOf(t, txs))
+
+		txs, _, err = blkfileMgr.retrieveTxsByCreator(unsignedCreator, "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tx2", "tx3"}, txIDsOf(t, txs))
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		txs, bookmark, err := blkfileMgr.retrieveTxsByChaincodeNamespace("cc1", "", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tx1", "tx3"}, txIDsOf(t, txs))
+		assert.NotEqual(t, "", bookmark)
+
+		txs, bookmark, err = blkfileMgr.retrieveTxsByChaincodeNamespace("cc1", bookmark, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tx4"}, txIDsOf(t, txs))
+		assert.Equal(t, "", bookmark)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, _, err := blkfileMgr.retrieveTxsByChaincodeNamespace("cc1", "", 0)
+		assert.EqualError(t, err, "invalid page size [0], it should be greater than zero")
+
+		_, _, err = blkfileMgr.retrieveTxsByCreator(signedCreator, "not-a-bookmark", 10)
+		assert.EqualError(t, err, "invalid bookmark [not-a-bookmark]")
+	})
+}
+
+func TestBlockIndexChaincodeNamespaceAndCreatorNotIndexed(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+
+	_, _, err := blkfileMgrWrapper.blockfileMgr.retrieveTxsByChaincodeNamespace("cc1", "", 10)
+	assert.Exactly(t, blkstorage.ErrAttrNotIndexed, err)
+	_, _, err = blkfileMgrWrapper.blockfileMgr.retrieveTxsByCreator([]byte("creator"), "", 10)
+	assert.Exactly(t, blkstorage.ErrAttrNotIndexed, err)
+}
+
+func TestBlockIndexRebuildOptionalAttrs(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	blocks, signedCreator, _ := constructNamespaceAndCreatorTestBlocks(t)
+	blkfileMgrWrapper.addBlocks(blocks[:1])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// enabling the optional attributes builds their entries for the blocks already indexed
+	attrsToIndex := []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrChaincodeNamespace,
+		blkstorage.IndexableAttrCreator,
+	}
+	env = newTestEnvSelectiveIndexing(t, conf, attrsToIndex, &disabled.Provider{})
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testledger")
+	blkfileMgrWrapper.addBlocks(blocks[1:])
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	txs, _, err := blkfileMgr.retrieveTxsByChaincodeNamespace("cc1", "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx3", "tx4"}, txIDsOf(t, txs))
+	txs, _, err = blkfileMgr.retrieveTxsByCreator(signedCreator, "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx4"}, txIDsOf(t, txs))
+
+	attrs, err := blkfileMgr.index.optionalAttrsToRebuild()
+	assert.NoError(t, err)
+	assert.Empty(t, attrs)
+	blkfileMgrWrapper.close()
+}
+
+// constructNamespaceAndCreatorTestBlocks returns two blocks, the first one with tx1 (cc1 writing to cc2, signed)
+// and tx2 (cc2, unsigned), the second one with tx3 (cc1, unsigned) and tx4 (cc1, signed, invalid), along with the serialized identities of the creators of the signed and unsigned transactions
+func constructNamespaceAndCreatorTestBlocks(t *testing.T) ([]*common.Block, []byte, []byte) {
+	results := func(namespaces ...string) []byte {
+		txRWSet := &rwset.TxReadWriteSet{}
+		for _, ns := range namespaces {
+			txRWSet.NsRwset = append(txRWSet.NsRwset, &rwset.NsReadWriteSet{Namespace: ns})
+		}
+		return protoutil.MarshalOrPanic(txRWSet)
+	}
+	tx := func(txID, chaincodeName string, simulationResults []byte) *testutil.TxDetails {
+		return &testutil.TxDetails{
+			TxID:              txID,
+			ChaincodeName:     chaincodeName,
+			ChaincodeVersion:  "v1",
+			SimulationResults: simulationResults,
+			Type:              common.HeaderType_ENDORSER_TRANSACTION,
+		}
+	}
+
+	signedTx1, _, err := testutil.ConstructTransactionFromTxDetails(tx("tx1", "cc1", results("cc1", "cc2")), true)
+	assert.NoError(t, err)
+	unsignedTx2, _, err := testutil.ConstructTransactionFromTxDetails(tx("tx2", "cc2", results("cc2")), false)
+	assert.NoError(t, err)
+	block0 := testutil.NewBlock([]*common.Envelope{signedTx1, unsignedTx2}, 0, nil)
+
+	unsignedTx3, _, err := testutil.ConstructTransactionFromTxDetails(tx("tx3", "cc1", results("cc1")), false)
+	assert.NoError(t, err)
+	signedTx4, _, err := testutil.ConstructTransactionFromTxDetails(tx("tx4", "cc1", results("cc1")), true)
+	assert.NoError(t, err)
+	block1 := testutil.NewBlock([]*common.Envelope{unsignedTx3, signedTx4}, 1, protoutil.BlockHeaderHash(block0.Header))
+	txsFilter := util.NewTxValidationFlagsSetValue(2, peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block1.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	_, signedCreator := extractNamespacesAndCreator(block0.Data.Data[0])
+	_, unsignedCreator := extractNamespacesAndCreator(block0.Data.Data[1])
+	assert.NotEqual(t, signedCreator, unsignedCreator)
+	return []*common.Block{block0, block1}, signedCreator, unsignedCreator
+}
+
+func txIDsOf(t *testing.T, txs []*peer.ProcessedTransaction) []string {
+	var txIDs []string
+	for _, tx := range txs {
+		txID, err := extractTxID(protoutil.MarshalOrPanic(tx.TransactionEnvelope))
+		assert.NoError(t, err)
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs
+}
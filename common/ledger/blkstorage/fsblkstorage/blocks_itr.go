@@ -50,16 +50,24 @@ func (itr *blocksItr) waitForBlock(blockNum uint64) uint64 {
 	return itr.mgr.cpInfo.lastBlockNumber
 }
 
-func (itr *blocksItr) initStream() error {
+// initStream opens a block stream from the location of the next block to retrieve. The stream is
+// not opened if the next block is present in an archived block file, and the returned location
+// can be used for fetching the block from the archive
+func (itr *blocksItr) initStream() (*fileLocPointer, error) {
 	var lp *fileLocPointer
 	var err error
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
-		return err
+		return nil, err
+	}
+	itr.mgr.archiveLock.RLock()
+	defer itr.mgr.archiveLock.RUnlock()
+	if itr.mgr.isArchived(lp.fileSuffixNum) {
+		return lp, nil
 	}
 	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return nil, nil
 }
 
 func (itr *blocksItr) shouldClose() bool {
@@ -80,9 +88,18 @@ func (itr *blocksItr) Next() (ledger.QueryResult, error) {
 	}
 	if itr.stream == nil {
 		logger.Debugf("Initializing block stream for iterator. itr.maxBlockNumAvailable=%d", itr.maxBlockNumAvailable)
-		if err := itr.initStream(); err != nil {
+		archivedBlockLoc, err := itr.initStream()
+		if err != nil {
 			return nil, err
 		}
+		if archivedBlockLoc != nil {
+			block, err := itr.mgr.fetchBlock(archivedBlockLoc)
+			if err != nil {
+				return nil, err
+			}
+			itr.blockNumToRetrieve++
+			return block, nil
+		}
 	}
 	nextBlockBytes, err := itr.stream.nextBlockBytes()
 	if err != nil {
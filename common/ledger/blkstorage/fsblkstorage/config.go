@@ -30,6 +30,7 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +39,15 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, nil}
+}
+
+// NewConfWithArchiving constructs new `Conf` that, in addition, enables the archiving of the block files
+// as per the supplied `ArchiveConf`
+func NewConfWithArchiving(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.archiveConf = archiveConf
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
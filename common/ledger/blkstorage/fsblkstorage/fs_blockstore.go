@@ -97,6 +97,24 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxsByChaincodeNamespace returns a page of the transactions that touched the given chaincode namespace
+func (store *fsBlockStore) RetrieveTxsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	return store.fileMgr.retrieveTxsByChaincodeNamespace(namespace, bookmark, pageSize)
+}
+
+// RetrieveTxsByCreator returns a page of the transactions that were created by the given serialized identity
+func (store *fsBlockStore) RetrieveTxsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	return store.fileMgr.retrieveTxsByCreator(creator, bookmark, pageSize)
+}
+
+// ExportTxIds creates two files in the specified dir and returns a map that contains
+// the mapping between the names of the files and their hashes.
+// Technically, the TxIDs appear in the sort order of radix-sort/shortlex. However,
+// since practically all the TxIDs are of same length, so the sort order would be the lexical sort order
+func (store *fsBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return store.fileMgr.exportTxIds(dir)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -17,10 +17,13 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
@@ -52,6 +55,19 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle, p.stats), nil
 }
 
+// BootstrapFromSnapshottedTxIDs initializes blockstore from a previously generated snapshot
+// Any failure during bootstrapping the blockstore may leave the partial loaded data
+// on disk. The consumer, such as peer is expected to keep track of failures and cleanup the
+// data explicitly.
+func (p *FsBlockstoreProvider) BootstrapFromSnapshottedTxIDs(
+	snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo, ledgerid string) (blkstorage.BlockStore, error) {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	if err := bootstrapFromSnapshottedTxIDs(snapshotDir, snapshotInfo, ledgerid, p.conf, indexStoreHandle); err != nil {
+		return nil, err
+	}
+	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle, p.stats), nil
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -63,6 +79,28 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Rollback removes the blocks after the given block number from the block store of the given ledger.
+// The block store should not be open while this function is invoked. If this function fails midway,
+// the block store cannot be opened until this function is invoked again with the same block number
+func (p *FsBlockstoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	return rollback(ledgerid, p.conf, p.leveldbProvider.GetDBHandle(ledgerid), blockNum)
+}
+
+// ValidateRollback returns an error if the block store of the given ledger cannot be rolled back to the given block number
+func (p *FsBlockstoreProvider) ValidateRollback(ledgerid string, blockNum uint64) error {
+	return validateRollback(ledgerid, p.conf, p.leveldbProvider.GetDBHandle(ledgerid), blockNum)
+}
+
+// Drop removes the block files and the index of the given ledger.
+// The block store should not be open while this function is invoked
+func (p *FsBlockstoreProvider) Drop(ledgerid string) error {
+	if err := p.leveldbProvider.GetDBHandle(ledgerid).DeleteAll(); err != nil {
+		return errors.WithMessagef(err, "error while deleting the block index of ledger [%s]", ledgerid)
+	}
+	return errors.Wrapf(os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)),
+		"error while removing the block files of ledger [%s]", ledgerid)
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
@@ -0,0 +1,304 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// rollbackInfoKey records the location of the first block to be removed by a rollback until
+// the rollback completes, so that a rollback that fails midway can be performed again
+var rollbackInfoKey = []byte("rollbackInfo")
+
+// rollbackMgr rolls back the block files and the index of a ledger. Unlike the blockfileMgr, the rollbackMgr
+// does not expect the block files and the index to be in sync, which is the case if a previous rollback failed midway
+type rollbackMgr struct {
+	ledgerID       string
+	rootDir        string
+	db             *leveldbhelper.DBHandle
+	targetBlockNum uint64
+}
+
+// rollback removes the blocks after the given block number from the block files and the index of the given ledger
+func rollback(ledgerID string, conf *Conf, indexStore *leveldbhelper.DBHandle, targetBlockNum uint64) error {
+	r, err := newRollbackMgr(ledgerID, conf, indexStore, targetBlockNum)
+	if err != nil {
+		return err
+	}
+	targetLoc, err := r.loadRollbackInfo()
+	if err != nil {
+		return err
+	}
+	if targetLoc == nil {
+		if targetLoc, err = r.locateFirstBlockToRemove(conf); err != nil || targetLoc == nil {
+			return err
+		}
+		if err := r.saveRollbackInfo(targetLoc); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("Rolling back the block index of ledger [%s] to block number [%d]", ledgerID, targetBlockNum)
+	if err := r.rollbackIndex(targetLoc); err != nil {
+		return err
+	}
+	logger.Infof("Rolling back the block files of ledger [%s] to block number [%d]", ledgerID, targetBlockNum)
+	if err := r.rollbackBlockfiles(targetLoc); err != nil {
+		return err
+	}
+	return r.db.Delete(rollbackInfoKey, true)
+}
+
+// validateRollback returns an error if the given ledger cannot be rolled back to the given block number.
+// A rollback that did not complete is considered valid only for the block number it was started with
+func validateRollback(ledgerID string, conf *Conf, indexStore *leveldbhelper.DBHandle, targetBlockNum uint64) error {
+	r, err := newRollbackMgr(ledgerID, conf, indexStore, targetBlockNum)
+	if err != nil {
+		return err
+	}
+	targetLoc, err := r.loadRollbackInfo()
+	if err != nil || targetLoc != nil {
+		return err
+	}
+	_, err = r.locateFirstBlockToRemove(conf)
+	return err
+}
+
+func newRollbackMgr(ledgerID string, conf *Conf, indexStore *leveldbhelper.DBHandle, targetBlockNum uint64) (*rollbackMgr, error) {
+	rootDir := conf.getLedgerBlockDir(ledgerID)
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("block store for ledger [%s] does not exist", ledgerID)
+	}
+	return &rollbackMgr{
+		ledgerID:       ledgerID,
+		rootDir:        rootDir,
+		db:             indexStore,
+		targetBlockNum: targetBlockNum,
+	}, nil
+}
+
+// locateFirstBlockToRemove validates the target block number and returns the location of the block next to it.
+// A nil location is returned if the target block is the last block in the block store, as there is nothing to remove
+func (r *rollbackMgr) locateFirstBlockToRemove(conf *Conf) (*fileLocPointer, error) {
+	mgr := &blockfileMgr{ledgerID: r.ledgerID, rootDir: r.rootDir, conf: conf, db: r.db}
+	bsInfo, err := loadBootstrappingSnapshotInfo(r.db)
+	if err != nil {
+		return nil, err
+	}
+	mgr.bootstrappingSnapshotInfo = bsInfo
+	if err := mgr.checkBlockAvailable(r.targetBlockNum); err != nil {
+		return nil, errors.WithMessage(err, "cannot roll back to a block that is not present in the block store")
+	}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return nil, err
+	}
+	if cpInfo == nil || cpInfo.isChainEmpty {
+		return nil, errors.Errorf("ledger [%s] does not contain any block", r.ledgerID)
+	}
+	if r.targetBlockNum > cpInfo.lastBlockNumber {
+		return nil, errors.Errorf("target block number [%d] should not be greater than the last block number [%d] of ledger [%s]",
+			r.targetBlockNum, cpInfo.lastBlockNumber, r.ledgerID)
+	}
+	if r.targetBlockNum == cpInfo.lastBlockNumber {
+		return nil, nil
+	}
+	b, err := r.db.Get(constructBlockNumKey(r.targetBlockNum + 1))
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.Errorf("location of block [%d] is not present in the block index of ledger [%s]",
+			r.targetBlockNum+1, r.ledgerID)
+	}
+	targetLoc := &fileLocPointer{}
+	if err := targetLoc.unmarshal(b); err != nil {
+		return nil, err
+	}
+	if err := mgr.initArchiveInfo(); err != nil {
+		return nil, err
+	}
+	if mgr.isArchived(targetLoc.fileSuffixNum) {
+		return nil, errors.Errorf("cannot roll back to block number [%d] as the block file [%d] is archived",
+			r.targetBlockNum, targetLoc.fileSuffixNum)
+	}
+	return targetLoc, nil
+}
+
+// rollbackIndex first moves the index checkpoint to the target block and then removes the index entries of
+// the blocks present at or after the given location. An entry for a txid is retained if the txid first
+// appeared before the given location, as the index maintains the location of the first occurrence of a txid
+func (r *rollbackMgr) rollbackIndex(targetLoc *fileLocPointer) error {
+	if err := r.db.Put(indexCheckpointKey, encodeBlockNum(r.targetBlockNum), true); err != nil {
+		return err
+	}
+	lastFileNum := r.lastBlockfileNum(targetLoc.fileSuffixNum)
+	stream, err := newBlockStream(r.rootDir, targetLoc.fileSuffixNum, int64(targetLoc.offset), lastFileNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		blockNum := info.blockHeader.Number
+		batch := leveldbhelper.NewUpdateBatch()
+		batch.Delete(constructBlockNumKey(blockNum))
+		batch.Delete(constructBlockHashKey(protoutil.BlockHeaderHash(info.blockHeader)))
+		for txNum, txOffset := range info.txOffsets {
+			batch.Delete(constructBlockNumTranNumKey(blockNum, uint64(txNum)))
+			namespaces, creator := extractNamespacesAndCreator(txOffset.txEnvBytes)
+			for _, namespace := range namespaces {
+				batch.Delete(constructChaincodeNamespaceKey(namespace, blockNum, uint64(txNum)))
+			}
+			if creator != nil {
+				batch.Delete(constructCreatorKey(creator, blockNum, uint64(txNum)))
+			}
+			removable, err := r.isTxIDAddedAtOrAfter(txOffset.txID, targetLoc)
+			if err != nil {
+				return err
+			}
+			if removable {
+				batch.Delete(constructTxIDKey(txOffset.txID))
+				batch.Delete(constructBlockTxIDKey(txOffset.txID))
+				batch.Delete(constructTxValidationCodeIDKey(txOffset.txID))
+			}
+		}
+		if err := r.db.WriteBatch(batch, true); err != nil {
+			return err
+		}
+		logger.Debugf("Removed the index entries of block [%d] present at file [%d], offset [%d]",
+			blockNum, placementInfo.fileNum, placementInfo.blockStartOffset)
+	}
+}
+
+func (r *rollbackMgr) isTxIDAddedAtOrAfter(txID string, targetLoc *fileLocPointer) (bool, error) {
+	for _, key := range [][]byte{constructTxIDKey(txID), constructBlockTxIDKey(txID)} {
+		b, err := r.db.Get(key)
+		if err != nil {
+			return false, err
+		}
+		if b == nil {
+			continue
+		}
+		if len(b) == 0 {
+			// the txid was imported from a snapshot
+			return false, nil
+		}
+		loc := &fileLocPointer{}
+		if err := loc.unmarshal(b); err != nil {
+			return false, err
+		}
+		return loc.fileSuffixNum > targetLoc.fileSuffixNum ||
+			(loc.fileSuffixNum == targetLoc.fileSuffixNum && loc.offset >= targetLoc.offset), nil
+	}
+	return false, nil
+}
+
+// rollbackBlockfiles records the target block as the last block in the checkpoint info, removes the block files
+// after the block file that contains the given location, starting from the last block file, and truncates the
+// block file that contains the given location
+func (r *rollbackMgr) rollbackBlockfiles(targetLoc *fileLocPointer) error {
+	mgr := &blockfileMgr{ledgerID: r.ledgerID, rootDir: r.rootDir, db: r.db}
+	cpInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: targetLoc.fileSuffixNum,
+		latestFileChunksize:      targetLoc.offset,
+		lastBlockNumber:          r.targetBlockNum,
+	}
+	if err := mgr.saveCurrentInfo(cpInfo, true); err != nil {
+		return err
+	}
+	for fileNum := r.lastBlockfileNum(targetLoc.fileSuffixNum); fileNum > targetLoc.fileSuffixNum; fileNum-- {
+		filePath := deriveBlockfilePath(r.rootDir, fileNum)
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "error removing block file %s", filePath)
+		}
+	}
+	writer, err := newBlockfileWriter(deriveBlockfilePath(r.rootDir, targetLoc.fileSuffixNum))
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+	if err := writer.truncateFile(targetLoc.offset); err != nil {
+		return errors.Wrapf(err, "error truncating block file [%d]", targetLoc.fileSuffixNum)
+	}
+	return syncDir(r.rootDir)
+}
+
+// lastBlockfileNum returns the suffix number of the last block file present on the file system
+func (r *rollbackMgr) lastBlockfileNum(startFileNum int) int {
+	fileNum := startFileNum
+	for {
+		if _, err := os.Stat(deriveBlockfilePath(r.rootDir, fileNum+1)); err != nil {
+			return fileNum
+		}
+		fileNum++
+	}
+}
+
+func (r *rollbackMgr) saveRollbackInfo(targetLoc *fileLocPointer) error {
+	b, err := targetLoc.marshal()
+	if err != nil {
+		return err
+	}
+	return r.db.Put(rollbackInfoKey, append(encodeBlockNum(r.targetBlockNum), b...), true)
+}
+
+// loadRollbackInfo returns the location saved by a previous rollback that did not complete. A previous
+// rollback that did not complete should be completed before the block store is rolled back to a different block
+func (r *rollbackMgr) loadRollbackInfo() (*fileLocPointer, error) {
+	b, err := r.db.Get(rollbackInfoKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	buffer := util.NewBuffer(b)
+	blockNum, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling rollback info")
+	}
+	if blockNum != r.targetBlockNum {
+		return nil, errors.Errorf("a previous rollback of ledger [%s] to block number [%d] did not complete, "+
+			"roll back to block number [%d] before rolling back to another block number", r.ledgerID, blockNum, blockNum)
+	}
+	targetLoc := &fileLocPointer{}
+	if err := targetLoc.unmarshal(b[buffer.GetBytesConsumed():]); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling rollback info")
+	}
+	return targetLoc, nil
+}
+
+// checkNoRollbackInProgress returns an error if a rollback of the block store did not complete
+func checkNoRollbackInProgress(ledgerID string, indexStore *leveldbhelper.DBHandle) error {
+	b, err := indexStore.Get(rollbackInfoKey)
+	if err != nil {
+		return err
+	}
+	if b != nil {
+		blockNum, _ := util.NewBuffer(b).DecodeVarint()
+		return errors.Errorf("a rollback of ledger [%s] to block number [%d] did not complete, run the rollback again",
+			ledgerID, blockNum)
+	}
+	return nil
+}
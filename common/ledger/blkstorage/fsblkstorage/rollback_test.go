@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback(t *testing.T) {
+	// a small max block file size places each block in a separate block file
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := []*common.Block{gb}
+	blocks = append(blocks, bg.NextBlockWithTxid([][]byte{[]byte("tx1")}, []string{"txid-1"}))
+	blocks = append(blocks, bg.NextTestBlocks(3)...)
+	blocks = append(blocks, bg.NextBlockWithTxid([][]byte{[]byte("tx1-again"), []byte("tx2")}, []string{"txid-1", "txid-2"}))
+	blocks = append(blocks, bg.NextTestBlocks(4)...)
+	w.addBlocks(blocks)
+	w.close()
+
+	require.NoError(t, env.provider.Rollback("testLedger", 3))
+
+	store, err := env.provider.OpenBlockStore("testLedger")
+	require.NoError(t, err)
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, &common.BlockchainInfo{
+		Height:            4,
+		CurrentBlockHash:  protoutil.BlockHeaderHash(blocks[3].Header),
+		PreviousBlockHash: protoutil.BlockHeaderHash(blocks[2].Header),
+	}, bcInfo)
+	for blockNum := uint64(4); blockNum < uint64(len(blocks)); blockNum++ {
+		_, err := store.RetrieveBlockByHash(protoutil.BlockHeaderHash(blocks[blockNum].Header))
+		require.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	}
+	// the block 4 is present in the block file 5, which is truncated, and the subsequent block files are removed
+	_, err = os.Stat(deriveBlockfilePath(env.provider.conf.getLedgerBlockDir("testLedger"), 6))
+	require.True(t, os.IsNotExist(err))
+
+	// the txid that first appeared before the target block is retained in the index
+	block, err := store.RetrieveBlockByTxID("txid-1")
+	require.NoError(t, err)
+	require.Equal(t, blocks[1], block)
+	_, err = store.RetrieveBlockByTxID("txid-2")
+	require.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// the rolled back blocks can be added again
+	for _, block := range blocks[4:] {
+		require.NoError(t, store.AddBlock(block))
+	}
+	block, err = store.RetrieveBlockByTxID("txid-2")
+	require.NoError(t, err)
+	require.Equal(t, blocks[5], block)
+	store.Shutdown()
+}
+
+func TestRollbackOptionalAttrs(t *testing.T) {
+	attrsToIndex := []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+		blkstorage.IndexableAttrBlockNumTranNum,
+		blkstorage.IndexableAttrBlockTxID,
+		blkstorage.IndexableAttrTxValidationCode,
+		blkstorage.IndexableAttrChaincodeNamespace,
+		blkstorage.IndexableAttrCreator,
+	}
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), attrsToIndex, &disabled.Provider{})
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks, signedCreator, _ := constructNamespaceAndCreatorTestBlocks(t)
+	w.addBlocks(blocks)
+	w.close()
+
+	require.NoError(t, env.provider.Rollback("testLedger", 0))
+
+	store, err := env.provider.OpenBlockStore("testLedger")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	txs, _, err := store.RetrieveTxsByChaincodeNamespace("cc1", "", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tx1"}, txIDsOf(t, txs))
+	txs, _, err = store.RetrieveTxsByCreator(signedCreator, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tx1"}, txIDsOf(t, txs))
+}
+
+func TestRollbackResumesIncompleteRollback(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 6)
+	w.addBlocks(blocks)
+	w.close()
+
+	// simulate a rollback that failed after removing the index entries of the blocks
+	indexStore := env.provider.leveldbProvider.GetDBHandle("testLedger")
+	r := &rollbackMgr{
+		ledgerID:       "testLedger",
+		rootDir:        env.provider.conf.getLedgerBlockDir("testLedger"),
+		db:             indexStore,
+		targetBlockNum: 2,
+	}
+	targetLoc, err := r.locateFirstBlockToRemove(env.provider.conf)
+	require.NoError(t, err)
+	require.NoError(t, r.saveRollbackInfo(targetLoc))
+	require.NoError(t, r.rollbackIndex(targetLoc))
+
+	require.PanicsWithValue(t,
+		"a rollback of ledger [testLedger] to block number [2] did not complete, run the rollback again",
+		func() { env.provider.OpenBlockStore("testLedger") },
+	)
+	require.NoError(t, env.provider.ValidateRollback("testLedger", 2))
+	require.EqualError(t, env.provider.ValidateRollback("testLedger", 1),
+		"a previous rollback of ledger [testLedger] to block number [2] did not complete, roll back to block number [2] before rolling back to another block number")
+	require.EqualError(t, env.provider.Rollback("testLedger", 1),
+		"a previous rollback of ledger [testLedger] to block number [2] did not complete, roll back to block number [2] before rolling back to another block number")
+	require.NoError(t, env.provider.Rollback("testLedger", 2))
+
+	store, err := env.provider.OpenBlockStore("testLedger")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), bcInfo.Height)
+}
+
+func TestRollbackInvalidTarget(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	w.addBlocks(testutil.ConstructTestBlocks(t, 3))
+	w.close()
+
+	require.NoError(t, env.provider.ValidateRollback("testLedger", 1))
+	require.EqualError(t, env.provider.ValidateRollback("testLedger", 3),
+		"target block number [3] should not be greater than the last block number [2] of ledger [testLedger]")
+	require.EqualError(t, env.provider.Rollback("testLedger", 3),
+		"target block number [3] should not be greater than the last block number [2] of ledger [testLedger]")
+	require.EqualError(t, env.provider.ValidateRollback("nonExistingLedger", 0),
+		"block store for ledger [nonExistingLedger] does not exist")
+	require.EqualError(t, env.provider.Rollback("nonExistingLedger", 0),
+		"block store for ledger [nonExistingLedger] does not exist")
+}
+
+func TestRollbackToLastBlock(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	w.addBlocks(blocks)
+	w.close()
+
+	require.NoError(t, env.provider.ValidateRollback("testLedger", 2))
+	require.NoError(t, env.provider.Rollback("testLedger", 2))
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	w.testGetBlockByNumber(blocks, 0)
+}
+
+func TestDrop(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		w := newTestBlockfileWrapper(env, ledgerID)
+		w.addBlocks(testutil.ConstructTestBlocks(t, 3))
+		w.close()
+	}
+
+	require.NoError(t, env.provider.Drop("ledger1"))
+	exists, err := env.provider.Exists("ledger1")
+	require.NoError(t, err)
+	require.False(t, exists)
+	itr := env.provider.leveldbProvider.GetDBHandle("ledger1").GetIterator(nil, nil)
+	defer itr.Release()
+	require.False(t, itr.Next())
+
+	ledgerIDs, err := env.provider.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ledger2"}, ledgerIDs)
+	store, err := env.provider.OpenBlockStore("ledger2")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), bcInfo.Height)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotDataFormat = byte(1)
+	// SnapshotDataFileName is the name of the file that contains the txids in a snapshot
+	SnapshotDataFileName = "txids.data"
+	// SnapshotMetadataFileName is the name of the file that contains the number of txids in a snapshot
+	SnapshotMetadataFileName = "txids.metadata"
+
+	maxTxIDsInImportBatch = 10000
+)
+
+var bootstrappingSnapshotInfoKey = []byte("bootstrappingSnapshotInfo")
+
+// exportTxIds creates two files in the specified dir and returns a map that contains
+// the mapping between the names of the files and their hashes.
+// Technically, the TxIDs appear in the sort order of radix-sort/shortlex. However,
+// since practically all the TxIDs are of same length, so the sort order would be the lexical sort order
+func (mgr *blockfileMgr) exportTxIds(dir string) (map[string][]byte, error) {
+	if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+		return nil, errors.New("transaction IDs not maintained in index")
+	}
+
+	dataHash, numTxIDs, err := mgr.exportTxIDsData(dir)
+	if err != nil {
+		return nil, err
+	}
+	metadataFile, err := snapshot.CreateFile(filepath.Join(dir, SnapshotMetadataFileName), snapshotDataFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer metadataFile.Close()
+	if err = metadataFile.EncodeUVarint(numTxIDs); err != nil {
+		return nil, err
+	}
+	metadataHash, err := metadataFile.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		SnapshotDataFileName:     dataHash,
+		SnapshotMetadataFileName: metadataHash,
+	}, nil
+}
+
+func (mgr *blockfileMgr) exportTxIDsData(dir string) ([]byte, uint64, error) {
+	dataFile, err := snapshot.CreateFile(filepath.Join(dir, SnapshotDataFileName), snapshotDataFormat)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer dataFile.Close()
+
+	itr := mgr.db.GetIterator([]byte{txIDIdxKeyPrefix}, []byte{txIDIdxKeyPrefix + 1})
+	defer itr.Release()
+	numTxIDs := uint64(0)
+	for itr.Next() {
+		if err := itr.Error(); err != nil {
+			return nil, 0, errors.Wrap(err, "internal leveldb error while iterating for txids")
+		}
+		txID := string(itr.Key()[1:])
+		if err := dataFile.EncodeString(txID); err != nil {
+			return nil, 0, err
+		}
+		numTxIDs++
+	}
+	dataHash, err := dataFile.Done()
+	if err != nil {
+		return nil, 0, err
+	}
+	return dataHash, numTxIDs, nil
+}
+
+// bootstrapFromSnapshottedTxIDs initializes an empty block store for the ledger from the txids exported in a snapshot.
+// The txids are loaded in the txid index without any location details so that a later transaction with a
+// duplicate txid can be detected. The block store starts accepting blocks from the block number next to the
+// last block number included in the snapshot
+func bootstrapFromSnapshottedTxIDs(
+	snapshotDir string,
+	snapshotInfo *blkstorage.SnapshotInfo,
+	ledgerID string,
+	conf *Conf,
+	indexStore *leveldbhelper.DBHandle,
+) error {
+	rootDir := conf.getLedgerBlockDir(ledgerID)
+	isEmpty, err := util.CreateDirIfMissing(rootDir)
+	if err != nil {
+		return err
+	}
+	if !isEmpty {
+		return errors.Errorf("dir %s not empty", rootDir)
+	}
+
+	bsInfoBytes, err := marshalSnapshotInfo(snapshotInfo)
+	if err != nil {
+		return err
+	}
+	if err := importTxIDsFromSnapshot(snapshotDir, indexStore); err != nil {
+		return err
+	}
+
+	cpInfo := &checkpointInfo{
+		isChainEmpty:    true,
+		lastBlockNumber: snapshotInfo.LastBlockNum,
+	}
+	cpInfoBytes, err := cpInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(bootstrappingSnapshotInfoKey, bsInfoBytes)
+	batch.Put(blkMgrInfoKey, cpInfoBytes)
+	return indexStore.WriteBatch(batch, true)
+}
+
+func importTxIDsFromSnapshot(snapshotDir string, indexStore *leveldbhelper.DBHandle) error {
+	metadataFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, SnapshotMetadataFileName), snapshotDataFormat)
+	if err != nil {
+		return err
+	}
+	defer metadataFile.Close()
+	numTxIDs, err := metadataFile.DecodeUVarInt()
+	if err != nil {
+		return err
+	}
+
+	txIDsFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, SnapshotDataFileName), snapshotDataFormat)
+	if err != nil {
+		return err
+	}
+	defer txIDsFile.Close()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for i := uint64(0); i < numTxIDs; i++ {
+		txID, err := txIDsFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		batch.Put(constructTxIDKey(txID), []byte{})
+		if batch.Len() >= maxTxIDsInImportBatch {
+			if err := indexStore.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return indexStore.WriteBatch(batch, true)
+}
+
+func loadBootstrappingSnapshotInfo(indexStore *leveldbhelper.DBHandle) (*blkstorage.SnapshotInfo, error) {
+	b, err := indexStore.Get(bootstrappingSnapshotInfoKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return unmarshalSnapshotInfo(b)
+}
+
+func marshalSnapshotInfo(snapshotInfo *blkstorage.SnapshotInfo) ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(snapshotInfo.LastBlockNum); err != nil {
+		return nil, errors.Wrap(err, "error while marshaling snapshot info")
+	}
+	if err := buffer.EncodeRawBytes(snapshotInfo.LastBlockHash); err != nil {
+		return nil, errors.Wrap(err, "error while marshaling snapshot info")
+	}
+	if err := buffer.EncodeRawBytes(snapshotInfo.PreviousBlockHash); err != nil {
+		return nil, errors.Wrap(err, "error while marshaling snapshot info")
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalSnapshotInfo(b []byte) (*blkstorage.SnapshotInfo, error) {
+	buffer := proto.NewBuffer(b)
+	lastBlockNum, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling snapshot info")
+	}
+	lastBlockHash, err := buffer.DecodeRawBytes(true)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling snapshot info")
+	}
+	previousBlockHash, err := buffer.DecodeRawBytes(true)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling snapshot info")
+	}
+	return &blkstorage.SnapshotInfo{
+		LastBlockNum:      lastBlockNum,
+		LastBlockHash:     lastBlockHash,
+		PreviousBlockHash: previousBlockHash,
+	}, nil
+}
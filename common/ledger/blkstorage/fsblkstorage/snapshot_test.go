@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTxIds(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	fileHashes, err := blkfileMgrWrapper.blockfileMgr.exportTxIds(snapshotDir)
+	require.NoError(t, err)
+	require.Len(t, fileHashes, 2)
+	for fileName, hash := range fileHashes {
+		fileContent, err := ioutil.ReadFile(filepath.Join(snapshotDir, fileName))
+		require.NoError(t, err)
+		expectedHash := sha256.Sum256(fileContent)
+		require.Equal(t, expectedHash[:], hash)
+	}
+
+	expectedTxIDs := txIDsFromBlocks(t, blocks)
+	sort.Strings(expectedTxIDs)
+	require.Equal(t, expectedTxIDs, readTxIDsFromSnapshot(t, snapshotDir))
+}
+
+func TestExportTxIdsErrorCases(t *testing.T) {
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0),
+		[]blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+
+	_, err := blkfileMgrWrapper.blockfileMgr.exportTxIds("non-existent-dir")
+	require.EqualError(t, err, "transaction IDs not maintained in index")
+}
+
+func TestBootstrapFromSnapshottedTxIDs(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 8)
+	blocksInSnapshot, blocksAfterSnapshot := blocks[:5], blocks[5:]
+	lastBlockInSnapshot := blocksInSnapshot[4]
+
+	// export the txids from a block store that contains the first five blocks
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "sourceLedger")
+	blkfileMgrWrapper.addBlocks(blocksInSnapshot)
+	_, err = blkfileMgrWrapper.blockfileMgr.exportTxIds(snapshotDir)
+	require.NoError(t, err)
+	blkfileMgrWrapper.close()
+
+	// bootstrap another block store from the snapshot
+	bootstrappedEnv := newTestEnv(t, NewConf(testPath(), 0))
+	defer bootstrappedEnv.Cleanup()
+	snapshotInfo := &blkstorage.SnapshotInfo{
+		LastBlockNum:      lastBlockInSnapshot.Header.Number,
+		LastBlockHash:     protoutil.BlockHeaderHash(lastBlockInSnapshot.Header),
+		PreviousBlockHash: lastBlockInSnapshot.Header.PreviousHash,
+	}
+	store, err := bootstrappedEnv.provider.BootstrapFromSnapshottedTxIDs(snapshotDir, snapshotInfo, "bootstrappedLedger")
+	require.NoError(t, err)
+
+	verifyBlockchainInfo := func(store blkstorage.BlockStore, lastBlock *common.Block) {
+		bcInfo, err := store.GetBlockchainInfo()
+		require.NoError(t, err)
+		require.Equal(t,
+			&common.BlockchainInfo{
+				Height:            lastBlock.Header.Number + 1,
+				CurrentBlockHash:  protoutil.BlockHeaderHash(lastBlock.Header),
+				PreviousBlockHash: lastBlock.Header.PreviousHash,
+			},
+			bcInfo,
+		)
+	}
+	verifyBlockchainInfo(store, lastBlockInSnapshot)
+
+	// the blocks included in the snapshot are not available
+	_, err = store.RetrieveBlockByNumber(2)
+	require.EqualError(t, err, "cannot serve block [2]. The ledger is bootstrapped from a snapshot. First available block = [5]")
+	_, err = store.RetrieveBlocks(0)
+	require.EqualError(t, err, "cannot serve block [0]. The ledger is bootstrapped from a snapshot. First available block = [5]")
+
+	// the txids included in the snapshot are known but their details are not available
+	txIDInSnapshot := txIDsFromBlocks(t, blocksInSnapshot)[3]
+	_, err = store.RetrieveTxByID(txIDInSnapshot)
+	require.Equal(t, blkstorage.ErrTxDetailsNotAvailable, err)
+	_, err = store.RetrieveTxValidationCodeByTxID(txIDInSnapshot)
+	require.Equal(t, blkstorage.ErrTxDetailsNotAvailable, err)
+	_, err = store.RetrieveBlockByTxID(txIDInSnapshot)
+	require.Equal(t, blkstorage.ErrTxDetailsNotAvailable, err)
+	_, err = store.RetrieveTxByID("non-existent-txid")
+	require.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// the blocks after the snapshot can be added and retrieved
+	for _, b := range blocksAfterSnapshot {
+		require.NoError(t, store.AddBlock(b))
+	}
+	verifyBlockchainInfo(store, blocksAfterSnapshot[2])
+	b, err := store.RetrieveBlockByNumber(6)
+	require.NoError(t, err)
+	require.Equal(t, blocksAfterSnapshot[1], b)
+	itr, err := store.RetrieveBlocks(5)
+	require.NoError(t, err)
+	for _, expectedBlock := range blocksAfterSnapshot {
+		r, err := itr.Next()
+		require.NoError(t, err)
+		require.Equal(t, expectedBlock, r.(*common.Block))
+	}
+	itr.Close()
+
+	// a transaction with a txid included in the snapshot is detected as a duplicate
+	blockWithDupTxID := testutil.ConstructBlockWithTxid(t, 8, protoutil.BlockHeaderHash(blocksAfterSnapshot[2].Header),
+		[][]byte{{1}}, []string{txIDInSnapshot}, false)
+	blockWithDupTxID.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+	require.NoError(t, store.AddBlock(blockWithDupTxID))
+	_, err = store.RetrieveTxByID(txIDInSnapshot)
+	require.Equal(t, blkstorage.ErrTxDetailsNotAvailable, err)
+
+	// the block store retains the bootstrapping info after a restart
+	store.Shutdown()
+	bootstrappedEnv.provider.Close()
+	bootstrappedEnv = newTestEnv(t, bootstrappedEnv.provider.conf)
+	store, err = bootstrappedEnv.provider.OpenBlockStore("bootstrappedLedger")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	verifyBlockchainInfo(store, blockWithDupTxID)
+	_, err = store.RetrieveBlockByNumber(4)
+	require.EqualError(t, err, "cannot serve block [4]. The ledger is bootstrapped from a snapshot. First available block = [5]")
+}
+
+func TestBootstrapFromSnapshottedTxIDsCrashBeforeCheckpoint(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 6)
+	lastBlockInSnapshot := blocks[3]
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	writeTxIDsToSnapshot(t, snapshotDir, txIDsFromBlocks(t, blocks[:4]))
+
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotInfo := &blkstorage.SnapshotInfo{
+		LastBlockNum:      lastBlockInSnapshot.Header.Number,
+		LastBlockHash:     protoutil.BlockHeaderHash(lastBlockInSnapshot.Header),
+		PreviousBlockHash: lastBlockInSnapshot.Header.PreviousHash,
+	}
+	store, err := env.provider.BootstrapFromSnapshottedTxIDs(snapshotDir, snapshotInfo, "bootstrappedLedger")
+	require.NoError(t, err)
+	mgr := store.(*fsBlockStore).fileMgr
+	cpInfoAtBootstrap := mgr.cpInfo
+	require.NoError(t, store.AddBlock(blocks[4]))
+	require.NoError(t, store.AddBlock(blocks[5]))
+
+	// simulate a crash before the checkpoint info gets updated
+	require.NoError(t, mgr.saveCurrentInfo(cpInfoAtBootstrap, true))
+	store.Shutdown()
+	env.provider.Close()
+
+	env = newTestEnv(t, env.provider.conf)
+	store, err = env.provider.OpenBlockStore("bootstrappedLedger")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), bcInfo.Height)
+	b, err := store.RetrieveBlockByNumber(5)
+	require.NoError(t, err)
+	require.Equal(t, blocks[5], b)
+}
+
+func TestBootstrapFromSnapshottedTxIDsNonEmptyDir(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(testutil.ConstructTestBlocks(t, 2))
+	blkfileMgrWrapper.close()
+
+	_, err := env.provider.BootstrapFromSnapshottedTxIDs("snapshotDir", &blkstorage.SnapshotInfo{}, "testLedger")
+	require.EqualError(t, err, "dir "+env.provider.conf.getLedgerBlockDir("testLedger")+" not empty")
+}
+
+func TestSnapshotInfoMarshaling(t *testing.T) {
+	snapshotInfo := &blkstorage.SnapshotInfo{
+		LastBlockNum:      20,
+		LastBlockHash:     []byte("last-block-hash"),
+		PreviousBlockHash: []byte("previous-block-hash"),
+	}
+	b, err := marshalSnapshotInfo(snapshotInfo)
+	require.NoError(t, err)
+	unmarshaled, err := unmarshalSnapshotInfo(b)
+	require.NoError(t, err)
+	require.Equal(t, snapshotInfo, unmarshaled)
+
+	_, err = unmarshalSnapshotInfo([]byte{0x81})
+	require.Contains(t, err.Error(), "error while unmarshaling snapshot info")
+}
+
+func txIDsFromBlocks(t *testing.T, blocks []*common.Block) []string {
+	txIDs := []string{}
+	for _, b := range blocks {
+		for _, txEnvBytes := range b.Data.Data {
+			txID, err := extractTxID(txEnvBytes)
+			require.NoError(t, err)
+			txIDs = append(txIDs, txID)
+		}
+	}
+	return txIDs
+}
+
+func readTxIDsFromSnapshot(t *testing.T, snapshotDir string) []string {
+	metadataReader, err := snapshot.OpenFile(filepath.Join(snapshotDir, SnapshotMetadataFileName), snapshotDataFormat)
+	require.NoError(t, err)
+	defer metadataReader.Close()
+	numTxIDs, err := metadataReader.DecodeUVarInt()
+	require.NoError(t, err)
+
+	dataReader, err := snapshot.OpenFile(filepath.Join(snapshotDir, SnapshotDataFileName), snapshotDataFormat)
+	require.NoError(t, err)
+	defer dataReader.Close()
+	txIDs := []string{}
+	for i := uint64(0); i < numTxIDs; i++ {
+		txID, err := dataReader.DecodeString()
+		require.NoError(t, err)
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs
+}
+
+func writeTxIDsToSnapshot(t *testing.T, snapshotDir string, txIDs []string) {
+	dataWriter, err := snapshot.CreateFile(filepath.Join(snapshotDir, SnapshotDataFileName), snapshotDataFormat)
+	require.NoError(t, err)
+	for _, txID := range txIDs {
+		require.NoError(t, dataWriter.EncodeString(txID))
+	}
+	_, err = dataWriter.Done()
+	require.NoError(t, err)
+
+	metadataWriter, err := snapshot.CreateFile(filepath.Join(snapshotDir, SnapshotMetadataFileName), snapshotDataFormat)
+	require.NoError(t, err)
+	require.NoError(t, metadataWriter.EncodeUVarint(uint64(len(txIDs))))
+	_, err = metadataWriter.Done()
+	require.NoError(t, err)
+}
@@ -61,6 +61,18 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove closes the ledger of the given chain and deletes its block files and index
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if ledger, ok := flf.ledgers[chainID]; ok {
+		ledger.(*FileLedger).close()
+		delete(flf.ledgers, chainID)
+	}
+	return flf.blkstorageProvider.Drop(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
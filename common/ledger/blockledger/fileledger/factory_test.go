@@ -9,6 +9,7 @@ package fileledger
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -33,6 +34,10 @@ func (mbsp *mockBlockStoreProvider) OpenBlockStore(ledgerid string) (blkstorage.
 	return mbsp.blockstore, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) BootstrapFromSnapshottedTxIDs(snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo, ledgerid string) (blkstorage.BlockStore, error) {
+	return mbsp.blockstore, mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Exists(ledgerid string) (bool, error) {
 	return mbsp.exists, mbsp.error
 }
@@ -41,6 +46,18 @@ func (mbsp *mockBlockStoreProvider) List() ([]string, error) {
 	return mbsp.list, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	return mbsp.error
+}
+
+func (mbsp *mockBlockStoreProvider) ValidateRollback(ledgerid string, blockNum uint64) error {
+	return mbsp.error
+}
+
+func (mbsp *mockBlockStoreProvider) Drop(ledgerid string) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Close() {
 }
 
@@ -83,3 +100,35 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(dir)
+
+	flf := New(dir, &disabled.Provider{})
+	defer flf.Close()
+
+	ledger, err := flf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.Append(genesisBlock))
+	_, err = flf.GetOrCreate("bar")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"foo", "bar"}, flf.ChainIDs())
+
+	err = flf.Remove("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, flf.ChainIDs())
+
+	ledger, err = flf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), ledger.Height(), "Expected the recreated ledger to be empty")
+}
+
+func TestRemoveError(t *testing.T) {
+	flf := &fileLedgerFactory{
+		blkstorageProvider: &mockBlockStoreProvider{error: fmt.Errorf("blockstorage provider error")},
+		ledgers:            make(map[string]blockledger.ReadWriter),
+	}
+	assert.EqualError(t, flf.Remove("foo"), "blockstorage provider error")
+}
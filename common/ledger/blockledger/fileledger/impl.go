@@ -35,6 +35,13 @@ func NewFileLedger(blockStore FileLedgerBlockStore) *FileLedger {
 	return &FileLedger{blockStore: blockStore, signal: make(chan struct{})}
 }
 
+// close shuts down the block store of the ledger, if it can be shut down
+func (fl *FileLedger) close() {
+	if s, ok := fl.blockStore.(interface{ Shutdown() }); ok {
+		s.Shutdown()
+	}
+}
+
 type fileLedgerIterator struct {
 	ledger         *FileLedger
 	blockNumber    uint64
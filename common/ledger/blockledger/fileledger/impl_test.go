@@ -109,6 +109,18 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) RetrieveTxsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	return nil, "", mbs.defaultError
+}
+
+func (mbs *mockBlockStore) RetrieveTxsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	return nil, "", mbs.defaultError
+}
+
+func (mbs *mockBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -17,6 +17,8 @@ limitations under the License.
 package ledger
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/protos/common"
 )
 
@@ -54,4 +56,8 @@ type QueryResultsIterator interface {
 type QueryResult interface{}
 
 // PrunePolicy - a general interface for supporting different pruning policies
-type PrunePolicy interface{}
+type PrunePolicy interface {
+	// ShouldPrune returns true if the block with the given number, persisted at the given time,
+	// can be pruned from the primary storage of a ledger of the given height
+	ShouldPrune(blockNum uint64, persistedAt time.Time, ledgerHeight uint64) bool
+}
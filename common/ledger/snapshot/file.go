@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// FileWriter creates a new file for ledger snapshot. This is expected to be used by various
+// components of ledger, such as blockstorage and statedb for exporting the relevant snapshot data
+type FileWriter struct {
+	file              *os.File
+	hasher            hash.Hash
+	bufWriter         *bufio.Writer
+	multiWriter       io.Writer
+	varintReusableBuf []byte
+}
+
+// CreateFile creates a new file for exporting the ledger snapshot data
+// This function returns an error if the file already exists. The `dataformat` is the first byte
+// written to the file. The function computes the hash of all the bytes written to the file,
+// including the `dataformat` byte, which is returned by the function `Done`
+func CreateFile(filePath string, dataformat byte) (*FileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating the snapshot file: %s", filePath)
+	}
+	bufWriter := bufio.NewWriter(file)
+	hasher := sha256.New()
+	multiWriter := io.MultiWriter(bufWriter, hasher)
+	if _, err := multiWriter.Write([]byte{dataformat}); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error while writing data format to the snapshot file: %s", filePath)
+	}
+	return &FileWriter{
+		file:              file,
+		bufWriter:         bufWriter,
+		multiWriter:       multiWriter,
+		hasher:            hasher,
+		varintReusableBuf: make([]byte, binary.MaxVarintLen64),
+	}, nil
+}
+
+// EncodeString encodes and appends the string to the data stream
+func (c *FileWriter) EncodeString(str string) error {
+	return c.EncodeBytes([]byte(str))
+}
+
+// EncodeBytes encodes and appends bytes to the data stream
+func (c *FileWriter) EncodeBytes(b []byte) error {
+	if err := c.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	if _, err := c.multiWriter.Write(b); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", c.file.Name())
+	}
+	return nil
+}
+
+// EncodeUVarint encodes and appends a number to the data stream
+func (c *FileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(c.varintReusableBuf, u)
+	if _, err := c.multiWriter.Write(c.varintReusableBuf[:n]); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", c.file.Name())
+	}
+	return nil
+}
+
+// Done closes the snapshot file and returns the final hash of the data
+func (c *FileWriter) Done() ([]byte, error) {
+	if err := c.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error while flushing to the snapshot file: %s ", c.file.Name())
+	}
+	if err := c.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error while syncing the snapshot file: %s", c.file.Name())
+	}
+	if err := c.file.Close(); err != nil {
+		return nil, errors.Wrapf(err, "error while closing the snapshot file: %s ", c.file.Name())
+	}
+	return c.hasher.Sum(nil), nil
+}
+
+// Close closes the file. This is expected to be invoked in a defer statement to make sure that the
+// file is closed, in case of error paths. The normal path is expected to call the function `Done`
+func (c *FileWriter) Close() error {
+	if c == nil {
+		return nil
+	}
+	return errors.Wrapf(c.file.Close(), "error while closing the snapshot file: %s", c.file.Name())
+}
+
+// FileReader reads from a ledger snapshot file. This is expected to be used for loading the ledger snapshot data
+// during bootstrapping a channel from snapshot. The data should be read, using the functions `DecodeXXX`,
+// in the same sequence in which the data was written by the functions `EncodeXXX` in the `FileWriter`.
+type FileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+}
+
+// OpenFile constructs a FileReader. This function returns an error if the format of the file, stored in the
+// first byte, does not match with the expectedDataFormat
+func OpenFile(filePath string, expectedDataFormat byte) (*FileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	bufReader := bufio.NewReader(file)
+	dataFormat, err := bufReader.ReadByte()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error while reading from the snapshot file: %s", filePath)
+	}
+	if dataFormat != expectedDataFormat {
+		file.Close()
+		return nil, errors.Errorf("unexpected data format in the snapshot file [%s]: expected [%x], found [%x]",
+			filePath, expectedDataFormat, dataFormat)
+	}
+	return &FileReader{
+		file:      file,
+		bufReader: bufReader,
+	}, nil
+}
+
+// DecodeString reads and decodes a string
+func (r *FileReader) DecodeString() (string, error) {
+	b, err := r.DecodeBytes()
+	return string(b), err
+}
+
+// DecodeBytes reads and decodes bytes
+func (r *FileReader) DecodeBytes() ([]byte, error) {
+	sizeToRead, err := r.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	size := int(sizeToRead)
+	if size == 0 {
+		return []byte{}, nil
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.bufReader, b); err != nil {
+		return nil, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return b, nil
+}
+
+// DecodeUVarInt reads a number
+func (r *FileReader) DecodeUVarInt() (uint64, error) {
+	u, err := binary.ReadUvarint(r.bufReader)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return u, nil
+}
+
+// Close closes the file
+func (r *FileReader) Close() error {
+	if r == nil {
+		return nil
+	}
+	return errors.Wrapf(r.file.Close(), "error while closing the snapshot file: %s", r.file.Name())
+}
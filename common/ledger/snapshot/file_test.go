@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCreateAndRead(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	// create file and encode some data
+	fileCreator, err := CreateFile(filepath.Join(testDir, "dataFile"), byte(5))
+	require.NoError(t, err)
+	defer fileCreator.Close()
+
+	require.NoError(t, fileCreator.EncodeString("Hi there"))
+	require.NoError(t, fileCreator.EncodeString("How are you?"))
+	require.NoError(t, fileCreator.EncodeString("")) // zero length string
+	require.NoError(t, fileCreator.EncodeUVarint(uint64(25)))
+	require.NoError(t, fileCreator.EncodeBytes([]byte("some junk bytes")))
+	require.NoError(t, fileCreator.EncodeBytes([]byte{})) // zero length slice
+
+	// Done and verify the returned hash
+	dataHash, err := fileCreator.Done()
+	require.NoError(t, err)
+	fileContent, err := ioutil.ReadFile(filepath.Join(testDir, "dataFile"))
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(fileContent)
+	require.Equal(t, expectedHash[:], dataHash)
+
+	// open the file and verify the reads
+	fileReader, err := OpenFile(filepath.Join(testDir, "dataFile"), byte(5))
+	require.NoError(t, err)
+	defer fileReader.Close()
+
+	str, err := fileReader.DecodeString()
+	require.NoError(t, err)
+	require.Equal(t, "Hi there", str)
+
+	str, err = fileReader.DecodeString()
+	require.NoError(t, err)
+	require.Equal(t, "How are you?", str)
+
+	str, err = fileReader.DecodeString()
+	require.NoError(t, err)
+	require.Equal(t, "", str)
+
+	number, err := fileReader.DecodeUVarInt()
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), number)
+
+	b, err := fileReader.DecodeBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte("some junk bytes"), b)
+
+	b, err = fileReader.DecodeBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, b)
+
+	_, err = fileReader.DecodeBytes()
+	require.Contains(t, err.Error(), "error while reading from the snapshot file")
+}
+
+func TestFileCreateErrorCases(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	existingFilePath := filepath.Join(testDir, "an-existing-file")
+	require.NoError(t, ioutil.WriteFile(existingFilePath, []byte("some data"), 0644))
+	_, err = CreateFile(existingFilePath, byte(1))
+	require.Contains(t, err.Error(), "error while creating the snapshot file: "+existingFilePath)
+}
+
+func TestFileOpenErrorCases(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	_, err = OpenFile(filepath.Join(testDir, "non-existent-file"), byte(1))
+	require.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	emptyFile := filepath.Join(testDir, "empty-file")
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte{}, 0644))
+	_, err = OpenFile(emptyFile, byte(1))
+	require.Contains(t, err.Error(), "error while reading from the snapshot file")
+
+	fileWithWrongFormat := filepath.Join(testDir, "file-with-wrong-format")
+	require.NoError(t, ioutil.WriteFile(fileWithWrongFormat, []byte{2}, 0644))
+	_, err = OpenFile(fileWithWrongFormat, byte(1))
+	require.EqualError(t, err, "unexpected data format in the snapshot file ["+fileWithWrongFormat+"]: expected [1], found [2]")
+}
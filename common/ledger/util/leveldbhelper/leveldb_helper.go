@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package leveldbhelper
 
 import (
-	"fmt"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -62,23 +61,32 @@ func CreateDB(conf *Conf) *DB {
 
 // Open opens the underlying db
 func (dbInst *DB) Open() {
+	if err := dbInst.TryOpen(); err != nil {
+		panic(err.Error())
+	}
+}
+
+// TryOpen opens the underlying db. Unlike the function `Open`, this function returns an error
+// instead of panicking if the db cannot be opened, e.g., when another process holds the lock on the db
+func (dbInst *DB) TryOpen() error {
 	dbInst.mutex.Lock()
 	defer dbInst.mutex.Unlock()
 	if dbInst.dbState == opened {
-		return
+		return nil
 	}
 	dbOpts := &opt.Options{}
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
 	if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
-		panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+		return errors.Errorf("Error creating dir if missing: %s", err)
 	}
 	dbOpts.ErrorIfMissing = !dirEmpty
 	if dbInst.db, err = leveldb.OpenFile(dbPath, dbOpts); err != nil {
-		panic(fmt.Sprintf("Error opening leveldb: %s", err))
+		return errors.Errorf("Error opening leveldb: %s", err)
 	}
 	dbInst.dbState = opened
+	return nil
 }
 
 // Close closes the underlying db
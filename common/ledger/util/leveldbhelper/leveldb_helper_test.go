@@ -137,3 +137,16 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestTryOpenLockedDB(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath), "")
+	defer os.RemoveAll(testDBPath)
+	db := CreateDB(&Conf{testDBPath})
+	assert.NoError(t, db.TryOpen())
+	defer db.Close()
+
+	anotherDB := CreateDB(&Conf{testDBPath})
+	err := anotherDB.TryOpen()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error opening leveldb")
+}
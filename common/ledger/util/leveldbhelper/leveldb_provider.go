@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -99,6 +100,23 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	return nil
 }
 
+// DeleteAll deletes all the keys that belong to the named db, in a single batch
+func (h *DBHandle) DeleteAll() error {
+	itr := h.GetIterator(nil, nil)
+	defer itr.Release()
+	levelBatch := &leveldb.Batch{}
+	for itr.Next() {
+		levelBatch.Delete(itr.Iterator.Key())
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error while iterating over the db [%s]", h.dbName)
+	}
+	if levelBatch.Len() == 0 {
+		return nil
+	}
+	return h.db.WriteBatch(levelBatch, true)
+}
+
 // GetIterator gets an handle to iterator. The iterator should be released after the use.
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
@@ -90,6 +90,31 @@ func TestBatchedUpdates(t *testing.T) {
 	}
 }
 
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < 20; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	assert.NoError(t, db1.DeleteAll())
+	itr1 := db1.GetIterator(nil, nil)
+	defer itr1.Release()
+	assert.False(t, itr1.Next())
+
+	itr2 := db2.GetIterator(nil, nil)
+	defer itr2.Release()
+	checkItrResults(t, itr2, createTestKeys(0, 19), createTestValues("db2", 0, 19))
+
+	// deleting an empty db is a no-op
+	assert.NoError(t, p.GetDBHandle("db3").DeleteAll())
+}
+
 func testDBBasicWriteAndReads(t *testing.T, dbNames ...string) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
@@ -81,6 +81,9 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByChaincodeNamespace] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByCreator] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetPurgeRecords] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
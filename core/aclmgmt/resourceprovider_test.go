@@ -67,6 +67,9 @@ func TestPolicyBase(t *testing.T) {
 	assert.NoError(t, err)
 	err = pprov.CheckACL("pol", env)
 	assert.NoError(t, err)
+
+	err = pprov.CheckACL("pol", []*protoutil.SignedData{{Data: []byte("data"), Identity: []byte("Alice"), Signature: []byte("sig")}})
+	assert.NoError(t, err)
 }
 
 func TestPolicyBad(t *testing.T) {
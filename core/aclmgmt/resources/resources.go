@@ -39,6 +39,10 @@ const (
 	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
 	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
 
+	Qscc_GetTransactionsByChaincodeNamespace = "qscc/GetTransactionsByChaincodeNamespace"
+	Qscc_GetTransactionsByCreator            = "qscc/GetTransactionsByCreator"
+	Qscc_GetPurgeRecords                     = "qscc/GetPurgeRecords"
+
 	//Cscc resources
 	Cscc_JoinChain      = "cscc/JoinChain"
 	Cscc_GetConfigBlock = "cscc/GetConfigBlock"
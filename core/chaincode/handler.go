@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -207,6 +208,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_PURGE_PRIVATE_DATA:
+		go h.HandleTransaction(msg, h.HandlePurgePrivateData)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 	case pb.ChaincodeMessage_GET_STATE:
@@ -223,6 +226,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleQueryStateClose)
 	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH:
 		go h.HandleTransaction(msg, h.HandleGetPrivateDataHash)
+	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY:
+		go h.HandleTransaction(msg, h.HandleGetPrivateDataHashHistory)
 	case pb.ChaincodeMessage_GET_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
@@ -925,15 +930,31 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(namespaceID, getHistoryForKey.Key)
+	metadata, err := getHistoryQueryMetadataFromBytes(getHistoryForKey.Metadata)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
+	var historyIter commonledger.ResultsIterator
+	isPaginated := false
 	totalReturnLimit := h.calculateTotalReturnLimit(nil)
 
+	if metadata != nil {
+		isPaginated = metadata.PageSize != 0 || metadata.Bookmark != ""
+		if isPaginated {
+			totalReturnLimit = h.calculateTotalReturnLimit(&pb.QueryMetadata{PageSize: metadata.PageSize})
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithMetadata(namespaceID, getHistoryForKey.Key,
+			createHistoryQueryInfoFromMetadata(metadata, isPaginated, totalReturnLimit))
+	} else {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(namespaceID, getHistoryForKey.Key)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -949,6 +970,49 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to the history of the hash of a private data key
+func (h *Handler) HandleGetPrivateDataHashHistory(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	if txContext.HistoryQueryExecutor == nil {
+		return nil, errors.New("history database is not enabled")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	iterID := h.UUIDGenerator.New()
+	namespaceID := txContext.NamespaceID
+
+	getHistoryForKey := &pb.GetHistoryForKey{}
+	err := proto.Unmarshal(msg.Payload, getHistoryForKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	chaincodeLogger.Debugf("[%s] getting private data hash history for chaincode %s, collection %s, key %s, channel %s",
+		shorttxid(msg.Txid), namespaceID, getHistoryForKey.Collection, getHistoryForKey.Key, txContext.ChainID)
+
+	historyIter, err := txContext.HistoryQueryExecutor.GetPrivateDataHashHistory(namespaceID,
+		getHistoryForKey.Collection, ledgerutil.ComputeStringHash(getHistoryForKey.Key))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	totalReturnLimit := h.calculateTotalReturnLimit(nil)
+	txContext.InitializeQueryContext(iterID, historyIter)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.WithStack(err)
+	}
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	chaincodeLogger.Debugf("Got key hash modifications. Sending %s", pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func isCollectionSet(collection string) bool {
 	return collection != ""
 }
@@ -977,6 +1041,41 @@ func getQueryMetadataFromBytes(metadataBytes []byte) (*pb.QueryMetadata, error)
 	return nil, nil
 }
 
+func getHistoryQueryMetadataFromBytes(metadataBytes []byte) (*pb.HistoryQueryMetadata, error) {
+	if metadataBytes != nil {
+		metadata := &pb.HistoryQueryMetadata{}
+		err := proto.Unmarshal(metadataBytes, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal failed")
+		}
+		return metadata, nil
+	}
+	return nil, nil
+}
+
+// createHistoryQueryInfoFromMetadata translates the metadata of a history query into the query parameters
+// of the history query executor. A zero end block and an unset start or end time denote an unbounded range
+func createHistoryQueryInfoFromMetadata(metadata *pb.HistoryQueryMetadata, isPaginated bool, totalReturnLimit int32) map[string]interface{} {
+	queryInfoMap := map[string]interface{}{
+		"startBlock":  metadata.StartBlock,
+		"newestFirst": metadata.NewestFirst,
+	}
+	if metadata.EndBlock != 0 {
+		queryInfoMap["endBlock"] = metadata.EndBlock
+	}
+	if metadata.StartTime != nil {
+		queryInfoMap["startTime"] = metadata.StartTime
+	}
+	if metadata.EndTime != nil {
+		queryInfoMap["endTime"] = metadata.EndTime
+	}
+	if isPaginated {
+		queryInfoMap["bookmark"] = metadata.Bookmark
+		queryInfoMap["limit"] = totalReturnLimit
+	}
+	return queryInfoMap
+}
+
 func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLimit int32, queryType pb.ChaincodeMessage_Type) (map[string]interface{}, error) {
 	paginationInfoMap := make(map[string]interface{})
 
@@ -1129,6 +1228,32 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+func (h *Handler) HandlePurgePrivateData(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	delState := &pb.DelState{}
+	err := proto.Unmarshal(msg.Payload, delState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	namespaceID := txContext.NamespaceID
+	collection := delState.Collection
+	if !isCollectionSet(collection) {
+		return nil, errors.New("only private data can be purged")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	if err := errorIfCreatorHasNoWritePermission(namespaceID, collection, txContext); err != nil {
+		return nil, err
+	}
+	if err := txContext.TXSimulator.PurgePrivateData(namespaceID, collection, delState.Key); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"crypto/sha256"
 	"io"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
@@ -917,6 +919,105 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePurgePrivateData", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.DelState
+
+		BeforeEach(func() {
+			request = &pb.DelState{
+				Key:        "purge-key",
+				Collection: "collection-name",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PURGE_PRIVATE_DATA,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			fakeCollectionStore.RetrieveReadWritePermissionReturns(false, true, nil)
+		})
+
+		It("calls PurgePrivateData on the transaction simulator and returns a response message", func() {
+			resp, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.PurgePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("purge-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("only private data can be purged"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when PurgePrivateData fails due to ledger error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.PurgePrivateDataReturns(errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("mango"))
+			})
+		})
+
+		Context("when called in an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns the error from errorIfInitTransaction", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when the creator has no write access permission", func() {
+			BeforeEach(func() {
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+			})
+
+			It("returns the error from errorIfCreatorHasNoWriteAccess", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("tx creator does not have write access" +
+					" permission on privatedata in chaincodeName:cc-instance-name" +
+					" collectionName: collection-name"))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -2128,6 +2229,111 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the request contains history query metadata", func() {
+			var (
+				startTime *timestamp.Timestamp
+				metadata  *pb.HistoryQueryMetadata
+			)
+
+			BeforeEach(func() {
+				handler.TotalQueryLimit = 100
+				startTime = &timestamp.Timestamp{Seconds: 1000}
+				metadata = &pb.HistoryQueryMetadata{
+					StartBlock:  2,
+					EndBlock:    5,
+					StartTime:   startTime,
+					NewestFirst: true,
+					PageSize:    10,
+					Bookmark:    "history-bookmark",
+				}
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				request.Metadata, err = proto.Marshal(metadata)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload, err = proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("calls GetHistoryForKeyWithMetadata on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				ccname, key, queryInfo := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(proto.Equal(queryInfo["startTime"].(*timestamp.Timestamp), startTime)).To(BeTrue())
+				delete(queryInfo, "startTime")
+				Expect(queryInfo).To(Equal(map[string]interface{}{
+					"startBlock":  uint64(2),
+					"endBlock":    uint64(5),
+					"newestFirst": true,
+					"bookmark":    "history-bookmark",
+					"limit":       int32(10),
+				}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, iter, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(iter).To(Equal(fakeIterator))
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(10)))
+			})
+
+			Context("when the metadata does not contain the pagination parameters", func() {
+				BeforeEach(func() {
+					metadata = &pb.HistoryQueryMetadata{StartBlock: 2}
+				})
+
+				It("does not paginate the history", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, queryInfo := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+					Expect(queryInfo).To(Equal(map[string]interface{}{
+						"startBlock":  uint64(2),
+						"newestFirst": false,
+					}))
+					_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+					Expect(isPaginated).To(BeFalse())
+					Expect(totalReturnLimit).To(Equal(int32(100)))
+				})
+			})
+
+			Context("when unmarshalling the metadata fails", func() {
+				JustBeforeEach(func() {
+					request.Metadata = []byte("this-is-a-bogus-payload")
+					var err error
+					incomingMessage.Payload, err = proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+				})
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
 		Context("when HistoryQueryExecutor is nil", func() {
 			BeforeEach(func() {
 				txContext.HistoryQueryExecutor = nil
@@ -2140,6 +2346,131 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetPrivateDataHashHistory", func() {
+		var (
+			request               *pb.GetHistoryForKey
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetHistoryForKey{
+				Key:        "history-key",
+				Collection: "collection-name",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetPrivateDataHashHistoryReturns(fakeIterator, nil)
+		})
+
+		It("calls GetPrivateDataHashHistory with the hash of the key on the history query executor", func() {
+			_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetPrivateDataHashHistoryCallCount()).To(Equal(1))
+			ccname, collection, keyHash := fakeHistoryQueryExecutor.GetPrivateDataHashHistoryArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			expectedKeyHash := sha256.Sum256([]byte("history-key"))
+			Expect(keyHash).To(Equal(expectedKeyHash[:]))
+		})
+
+		It("builds a query response", func() {
+			resp, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+			Expect(isPaginated).To(BeFalse())
+
+			payload, err := proto.Marshal(expectedQueryResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetPrivateDataHashHistoryReturns(nil, errors.New("private data hash history is not enabled"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data hash history is not enabled"))
+			})
+		})
+
+		Context("when building the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("mushrooms"))
+			})
+
+			It("returns an error and cleans up the query context", func() {
+				_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+				Expect(err).To(MatchError("mushrooms"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+			})
+		})
+
+		Context("when the transaction is an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				Expect(fakeHistoryQueryExecutor.GetPrivateDataHashHistoryCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when HistoryQueryExecutor is nil", func() {
+			BeforeEach(func() {
+				txContext.HistoryQueryExecutor = nil
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHashHistory(incomingMessage, txContext)
+				Expect(err).To(MatchError("history database is not enabled"))
+			})
+		})
+	})
+
 	Describe("HandleInvokeChaincode", func() {
 		var (
 			expectedSignedProp      *pb.SignedProposal
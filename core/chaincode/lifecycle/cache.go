@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protoutil"
@@ -245,13 +246,13 @@ func (c *Cache) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
 	// if the channel cache does not yet exist, there are no interesting hashes, so skip
 	if ok {
 		for collection, privateUpdates := range updates.CollHashUpdates {
-			matches := ImplicitCollectionMatcher.FindStringSubmatch(collection)
-			if len(matches) != 2 {
+			isImplicit, mspID := privdata.MspIDIfImplicitCollection(collection)
+			if !isImplicit {
 				// This is not an implicit collection
 				continue
 			}
 
-			if matches[1] != c.MyOrgMSPID {
+			if mspID != c.MyOrgMSPID {
 				// This is not our implicit collection
 				continue
 			}
@@ -332,7 +333,7 @@ func (c *Cache) update(initializing bool, channelID string, dirtyChaincodes map[
 
 	orgState := &PrivateQueryExecutorShim{
 		Namespace:  LifecycleNamespace,
-		Collection: ImplicitCollectionNameForOrg(c.MyOrgMSPID),
+		Collection: privdata.ImplicitCollectionNameForOrg(c.MyOrgMSPID),
 		State:      qe,
 	}
 
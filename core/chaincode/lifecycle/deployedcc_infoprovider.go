@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	validationState "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -44,6 +45,12 @@ var (
 type ValidatorCommitter struct {
 	Resources                    *Resources
 	LegacyDeployedCCInfoProvider LegacyDeployedCCInfoProvider
+
+	// LocalMSPID and ImplicitCollDisseminationPolicy determine the number of peers
+	// the private data of the implicit collection of the peer's own org is sent to
+	// upon endorsement
+	LocalMSPID                      string
+	ImplicitCollDisseminationPolicy privdata.ImplicitCollectionDisseminationPolicy
 }
 
 // Namespaces returns the list of namespaces which are relevant to chaincode lifecycle
@@ -105,8 +112,6 @@ func (vc *ValidatorCommitter) ChaincodeInfo(channelName, chaincodeName string, q
 	}, nil
 }
 
-var ImplicitCollectionMatcher = regexp.MustCompile("^" + ImplicitCollectionNameForOrg("(.+)") + "$")
-
 // CollectionInfo implements function in interface ledger.DeployedChaincodeInfoProvider, it returns config for
 // both static and implicit collections.
 func (vc *ValidatorCommitter) CollectionInfo(channelName, chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*cb.StaticCollectionConfig, error) {
@@ -122,9 +127,8 @@ func (vc *ValidatorCommitter) CollectionInfo(channelName, chaincodeName, collect
 		return vc.LegacyDeployedCCInfoProvider.CollectionInfo(channelName, chaincodeName, collectionName, qe)
 	}
 
-	matches := ImplicitCollectionMatcher.FindStringSubmatch(collectionName)
-	if len(matches) == 2 {
-		return GenerateImplicitCollectionForOrg(matches[1]), nil
+	if isImplicit, mspID := privdata.MspIDIfImplicitCollection(collectionName); isImplicit {
+		return vc.GenerateImplicitCollectionForOrg(mspID), nil
 	}
 
 	if definedChaincode.Collections != nil {
@@ -171,25 +175,23 @@ func (vc *ValidatorCommitter) ChaincodeImplicitCollections(channelName string) (
 	orgs := ac.Organizations()
 	implicitCollections := make([]*cb.StaticCollectionConfig, 0, len(orgs))
 	for _, org := range orgs {
-		implicitCollections = append(implicitCollections, GenerateImplicitCollectionForOrg(org.MSPID()))
+		implicitCollections = append(implicitCollections, vc.GenerateImplicitCollectionForOrg(org.MSPID()))
 	}
 
 	return implicitCollections, nil
 }
 
-func GenerateImplicitCollectionForOrg(mspid string) *cb.StaticCollectionConfig {
-	return &cb.StaticCollectionConfig{
-		Name: ImplicitCollectionNameForOrg(mspid),
-		MemberOrgsPolicy: &cb.CollectionPolicyConfig{
-			Payload: &cb.CollectionPolicyConfig_SignaturePolicy{
-				SignaturePolicy: cauthdsl.SignedByMspMember(mspid),
-			},
-		},
+// GenerateImplicitCollectionForOrg returns the config of the implicit collection of the org
+// with the given MSP ID. The private data of the implicit collection of the peer's own org is
+// disseminated according to the configured dissemination policy, while the private data of the
+// implicit collections of the other orgs is never disseminated by this peer
+func (vc *ValidatorCommitter) GenerateImplicitCollectionForOrg(mspid string) *cb.StaticCollectionConfig {
+	collConfig := privdata.GenerateImplicitCollectionForOrg(mspid)
+	if mspid == vc.LocalMSPID {
+		collConfig.RequiredPeerCount = int32(vc.ImplicitCollDisseminationPolicy.RequiredPeerCount)
+		collConfig.MaximumPeerCount = int32(vc.ImplicitCollDisseminationPolicy.MaxPeerCount)
 	}
-}
-
-func ImplicitCollectionNameForOrg(mspid string) string {
-	return fmt.Sprintf("_implicit_org_%s", mspid)
+	return collConfig
 }
 
 func (vc *ValidatorCommitter) ImplicitCollectionEndorsementPolicyAsBytes(channelID, orgMSPID string) (policy []byte, unexpectedErr, validationErr error) {
@@ -314,16 +316,20 @@ func (vc *ValidatorCommitter) CollectionValidationInfo(channelID, chaincodeName,
 		return nil, nil, nil
 	}
 
-	matches := ImplicitCollectionMatcher.FindStringSubmatch(collectionName)
-	if len(matches) == 2 {
-		return vc.ImplicitCollectionEndorsementPolicyAsBytes(channelID, matches[1])
+	if isImplicit, mspID := privdata.MspIDIfImplicitCollection(collectionName); isImplicit {
+		return vc.ImplicitCollectionEndorsementPolicyAsBytes(channelID, mspID)
 	}
 
 	if definedChaincode.Collections != nil {
 		for _, conf := range definedChaincode.Collections.Config {
 			staticCollConfig := conf.GetStaticCollectionConfig()
 			if staticCollConfig != nil && staticCollConfig.Name == collectionName {
-				// TODO, return the collection EP if defined
+				if staticCollConfig.EndorsementPolicy != nil {
+					// the collection endorsement policy is wire compatible
+					// with the ApplicationPolicy understood by the validator
+					return protoutil.MarshalOrPanic(staticCollConfig.EndorsementPolicy), nil, nil
+				}
+				// default to the chaincode endorsement policy
 				return definedChaincode.ValidationInfo.ValidationParameter, nil, nil
 			}
 		}
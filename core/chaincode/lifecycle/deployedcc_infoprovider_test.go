@@ -9,10 +9,12 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
@@ -261,6 +263,39 @@ var _ = Describe("ValidatorCommitter", func() {
 			})
 		})
 
+		Context("when the collection is an implicit collection", func() {
+			BeforeEach(func() {
+				vc.LocalMSPID = "first-mspid"
+				vc.ImplicitCollDisseminationPolicy = privdata.ImplicitCollectionDisseminationPolicy{
+					RequiredPeerCount: 1,
+					MaxPeerCount:      2,
+				}
+			})
+
+			It("applies the dissemination policy to the implicit collection of the local org", func() {
+				res, err := vc.CollectionInfo("channel-name", "cc-name", "_implicit_org_first-mspid", fakeQueryExecutor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(res, &cb.StaticCollectionConfig{
+					Name: "_implicit_org_first-mspid",
+					MemberOrgsPolicy: &cb.CollectionPolicyConfig{
+						Payload: &cb.CollectionPolicyConfig_SignaturePolicy{
+							SignaturePolicy: cauthdsl.SignedByMspMember("first-mspid"),
+						},
+					},
+					RequiredPeerCount: 1,
+					MaximumPeerCount:  2,
+				})).To(BeTrue())
+			})
+
+			It("does not disseminate the private data of the implicit collections of other orgs", func() {
+				res, err := vc.CollectionInfo("channel-name", "cc-name", "_implicit_org_second-mspid", fakeQueryExecutor)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(res, privdata.GenerateImplicitCollectionForOrg("second-mspid"))).To(BeTrue())
+				Expect(res.RequiredPeerCount).To(Equal(int32(0)))
+				Expect(res.MaximumPeerCount).To(Equal(int32(0)))
+			})
+		})
+
 		Context("when the ledger returns an error", func() {
 			BeforeEach(func() {
 				fakeQueryExecutor.GetStateReturns(nil, fmt.Errorf("state-error"))
@@ -527,6 +562,47 @@ var _ = Describe("ValidatorCommitter", func() {
 			})
 		})
 
+		Context("when the collection defines an endorsement policy", func() {
+			BeforeEach(func() {
+				err := resources.Serializer.Serialize(lifecycle.NamespacesName, "cc-name", &lifecycle.ChaincodeDefinition{
+					EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+						Version: "version",
+					},
+					ValidationInfo: &lb.ChaincodeValidationInfo{
+						ValidationPlugin:    "validation-plugin",
+						ValidationParameter: []byte("validation-parameter"),
+					},
+					Collections: &cb.CollectionConfigPackage{
+						Config: []*cb.CollectionConfig{
+							{
+								Payload: &cb.CollectionConfig_StaticCollectionConfig{
+									StaticCollectionConfig: &cb.StaticCollectionConfig{
+										Name: "collection-name",
+										EndorsementPolicy: &cb.CollectionEndorsementPolicy{
+											Type: &cb.CollectionEndorsementPolicy_ChannelConfigPolicyReference{
+												ChannelConfigPolicyReference: "/Channel/Application/Collection",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, fakePublicState)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the collection endorsement policy as an application policy", func() {
+				ep, uErr, vErr := vc.CollectionValidationInfo("channel-id", "cc-name", "collection-name", fakeValidationState)
+				Expect(uErr).NotTo(HaveOccurred())
+				Expect(vErr).NotTo(HaveOccurred())
+				policy := &pb.ApplicationPolicy{}
+				err := proto.Unmarshal(ep, policy)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policy.GetChannelConfigPolicyReference()).To(Equal("/Channel/Application/Collection"))
+			})
+		})
+
 		Context("when the collection is an implicit collection", func() {
 			It("returns the implicit endorsement policy", func() {
 				ep, uErr, vErr := vc.CollectionValidationInfo("channel-id", "cc-name", "_implicit_org_first-mspid", fakeValidationState)
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"sort"
 	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -62,6 +65,7 @@ func NewMetadataProvider(cip ChaincodeInfoProvider, lmp LegacyMetadataProvider,
 		ChannelPolicyReferenceProvider: &channelPolicyReferenceProviderImpl{
 			ChannelConfigSource: ccs,
 		},
+		ChannelConfigSource: ccs,
 	}
 }
 
@@ -69,6 +73,7 @@ type MetadataProvider struct {
 	ChaincodeInfoProvider          ChaincodeInfoProvider
 	LegacyMetadataProvider         LegacyMetadataProvider
 	ChannelPolicyReferenceProvider ChannelPolicyReferenceProvider
+	ChannelConfigSource            ChannelConfigSource
 }
 
 func (mp *MetadataProvider) toSignaturePolicyEnvelope(channelID string, policyBytes []byte) ([]byte, error) {
@@ -123,11 +128,71 @@ func (mp *MetadataProvider) Metadata(channel string, ccName string, includeColle
 	// the version is no longer required to change when updating any
 	// part of the chaincode definition
 	ccMetadata := &chaincode.Metadata{
-		Name:              ccName,
-		Version:           strconv.FormatInt(ccInfo.Definition.Sequence, 10),
-		Policy:            spe,
-		CollectionsConfig: ccInfo.Definition.Collections,
+		Name:               ccName,
+		Version:            strconv.FormatInt(ccInfo.Definition.Sequence, 10),
+		Policy:             spe,
+		CollectionsConfig:  mp.withImplicitCollections(channel, ccInfo.Definition.Collections),
+		CollectionPolicies: mp.collectionPolicies(channel, ccName, ccInfo.Definition.Collections),
 	}
 
 	return ccMetadata
 }
+
+// collectionPolicies returns the endorsement policies of the collections of
+// the chaincode that define one, converted to SignaturePolicyEnvelopes
+func (mp *MetadataProvider) collectionPolicies(channelID, ccName string, collections *cb.CollectionConfigPackage) map[string][]byte {
+	var collectionPolicies map[string][]byte
+	for _, conf := range collections.GetConfig() {
+		staticCollConfig := conf.GetStaticCollectionConfig()
+		if staticCollConfig == nil || staticCollConfig.EndorsementPolicy == nil {
+			continue
+		}
+		// the collection endorsement policy is wire compatible with ApplicationPolicy
+		spe, err := mp.toSignaturePolicyEnvelope(channelID, protoutil.MarshalOrPanic(staticCollConfig.EndorsementPolicy))
+		if err != nil {
+			logger.Errorf("could not convert policy for collection '%s' of chaincode '%s' on channel '%s', err '%s'", staticCollConfig.Name, ccName, channelID, err)
+			spe = cauthdsl.MarshaledRejectAllPolicy
+		}
+		if collectionPolicies == nil {
+			collectionPolicies = map[string][]byte{}
+		}
+		collectionPolicies[staticCollConfig.Name] = spe
+	}
+	return collectionPolicies
+}
+
+// withImplicitCollections returns the given collection config package extended with
+// the implicit collections of the orgs of the channel, so that service discovery can
+// compute the endorsers of a chaincode invocation writing to an implicit collection
+func (mp *MetadataProvider) withImplicitCollections(channelID string, collections *cb.CollectionConfigPackage) *cb.CollectionConfigPackage {
+	channelConfig := mp.ChannelConfigSource.GetStableChannelConfig(channelID)
+	if channelConfig == nil {
+		logger.Warningf("could not get channel config for channel '%s', omitting implicit collections", channelID)
+		return collections
+	}
+	ac, ok := channelConfig.ApplicationConfig()
+	if !ok {
+		logger.Warningf("could not get application config for channel '%s', omitting implicit collections", channelID)
+		return collections
+	}
+
+	orgs := ac.Organizations()
+	mspIDs := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		mspIDs = append(mspIDs, org.MSPID())
+	}
+	sort.Strings(mspIDs)
+
+	allCollections := &cb.CollectionConfigPackage{}
+	if collections != nil {
+		allCollections.Config = append(allCollections.Config, collections.Config...)
+	}
+	for _, mspID := range mspIDs {
+		allCollections.Config = append(allCollections.Config, &cb.CollectionConfig{
+			Payload: &cb.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+			},
+		})
+	}
+	return allCollections
+}
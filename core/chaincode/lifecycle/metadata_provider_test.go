@@ -8,8 +8,10 @@ package lifecycle_test
 
 import (
 	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	cb "github.com/hyperledger/fabric/protos/common"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
@@ -26,6 +28,7 @@ var _ = Describe("MetadataProvider", func() {
 		fakeChaincodeInfoProvider          *mock.ChaincodeInfoProvider
 		fakeLegacyMetadataProvider         *mock.LegacyMetadataProvider
 		fakeChannelPolicyReferenceProvider *mock.ChannelPolicyReferenceProvider
+		fakeChannelConfigSource            *mock.ChannelConfigSource
 		fakeConvertedPolicy                *mock.ConvertiblePolicy
 		metadataProvider                   *lifecycle.MetadataProvider
 		ccInfo                             *lifecycle.LocalChaincodeInfo
@@ -60,7 +63,9 @@ var _ = Describe("MetadataProvider", func() {
 		fakeLegacyMetadataProvider = &mock.LegacyMetadataProvider{}
 		fakeLegacyMetadataProvider.MetadataReturns(legacyCCMetadata)
 
-		metadataProvider = lifecycle.NewMetadataProvider(fakeChaincodeInfoProvider, fakeLegacyMetadataProvider, nil)
+		fakeChannelConfigSource = &mock.ChannelConfigSource{}
+
+		metadataProvider = lifecycle.NewMetadataProvider(fakeChaincodeInfoProvider, fakeLegacyMetadataProvider, fakeChannelConfigSource)
 	})
 
 	It("returns metadata using the ChaincodeInfoProvider (SignaturePolicyEnvelope case)", func() {
@@ -205,4 +210,108 @@ var _ = Describe("MetadataProvider", func() {
 			})
 		})
 	})
+
+	Context("when collections define endorsement policies", func() {
+		BeforeEach(func() {
+			ccInfo.Definition.Collections = &cb.CollectionConfigPackage{
+				Config: []*cb.CollectionConfig{
+					{
+						Payload: &cb.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: &cb.StaticCollectionConfig{Name: "coll-without-ep"},
+						},
+					},
+					{
+						Payload: &cb.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: &cb.StaticCollectionConfig{
+								Name: "coll-with-signature-ep",
+								EndorsementPolicy: &cb.CollectionEndorsementPolicy{
+									Type: &cb.CollectionEndorsementPolicy_SignaturePolicy{
+										SignaturePolicy: cauthdsl.AcceptAllPolicy,
+									},
+								},
+							},
+						},
+					},
+					{
+						Payload: &cb.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: &cb.StaticCollectionConfig{
+								Name: "coll-with-reference-ep",
+								EndorsementPolicy: &cb.CollectionEndorsementPolicy{
+									Type: &cb.CollectionEndorsementPolicy_ChannelConfigPolicyReference{
+										ChannelConfigPolicyReference: "barf",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			fakeChaincodeInfoProvider.ChaincodeInfoReturns(ccInfo, nil)
+
+			fakeChannelPolicyReferenceProvider = &mock.ChannelPolicyReferenceProvider{}
+			fakeChannelPolicyReferenceProvider.NewPolicyReturns(nil, errors.New("go away"))
+			metadataProvider.ChannelPolicyReferenceProvider = fakeChannelPolicyReferenceProvider
+		})
+
+		It("returns metadata including the translated collection policies", func() {
+			metadata := metadataProvider.Metadata("testchannel", "cc-name", true)
+			Expect(metadata.CollectionPolicies).To(Equal(map[string][]byte{
+				"coll-with-signature-ep": cauthdsl.MarshaledAcceptAllPolicy,
+				"coll-with-reference-ep": cauthdsl.MarshaledRejectAllPolicy,
+			}))
+			Expect(fakeChannelPolicyReferenceProvider.NewPolicyCallCount()).To(Equal(1))
+			channelID, ref := fakeChannelPolicyReferenceProvider.NewPolicyArgsForCall(0)
+			Expect(channelID).To(Equal("testchannel"))
+			Expect(ref).To(Equal("barf"))
+		})
+	})
+
+	Context("when the channel has application orgs", func() {
+		BeforeEach(func() {
+			ccInfo.Definition.Collections = &cb.CollectionConfigPackage{
+				Config: []*cb.CollectionConfig{
+					{
+						Payload: &cb.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: &cb.StaticCollectionConfig{Name: "explicit-coll"},
+						},
+					},
+				},
+			}
+			fakeChaincodeInfoProvider.ChaincodeInfoReturns(ccInfo, nil)
+
+			fakeOrgConfigs := []*mock.ApplicationOrgConfig{{}, {}}
+			fakeOrgConfigs[0].MSPIDReturns("second-mspid")
+			fakeOrgConfigs[1].MSPIDReturns("first-mspid")
+			fakeApplicationConfig := &mock.ApplicationConfig{}
+			fakeApplicationConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+				"org0": fakeOrgConfigs[0],
+				"org1": fakeOrgConfigs[1],
+			})
+			fakeChannelConfig := &mock.ChannelConfig{}
+			fakeChannelConfig.ApplicationConfigReturns(fakeApplicationConfig, true)
+			fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+		})
+
+		It("returns metadata including the implicit collections of the orgs", func() {
+			metadata := metadataProvider.Metadata("testchannel", "cc-name", true)
+			Expect(metadata.CollectionsConfig.Config).To(HaveLen(3))
+			Expect(metadata.CollectionsConfig.Config[0].GetStaticCollectionConfig().Name).To(Equal("explicit-coll"))
+			Expect(metadata.CollectionsConfig.Config[1].GetStaticCollectionConfig()).To(Equal(privdata.GenerateImplicitCollectionForOrg("first-mspid")))
+			Expect(metadata.CollectionsConfig.Config[2].GetStaticCollectionConfig()).To(Equal(privdata.GenerateImplicitCollectionForOrg("second-mspid")))
+			Expect(ccInfo.Definition.Collections.Config).To(HaveLen(1))
+		})
+
+		Context("when the application config cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeChannelConfig := &mock.ChannelConfig{}
+				fakeChannelConfig.ApplicationConfigReturns(nil, false)
+				fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+			})
+
+			It("returns metadata with the explicit collections only", func() {
+				metadata := metadataProvider.Metadata("testchannel", "cc-name", true)
+				Expect(metadata.CollectionsConfig).To(Equal(ccInfo.Definition.Collections))
+			})
+		})
+	})
 })
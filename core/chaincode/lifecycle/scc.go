@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	persistenceintf "github.com/hyperledger/fabric/core/chaincode/persistence/intf"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
@@ -329,7 +330,7 @@ func (i *Invocation) ApproveChaincodeDefinitionForMyOrg(input *lb.ApproveChainco
 	if err := i.validateInput(input.Name, input.Version, input.Collections); err != nil {
 		return nil, err
 	}
-	collectionName := ImplicitCollectionNameForOrg(i.SCC.OrgMSPID)
+	collectionName := privdata.ImplicitCollectionNameForOrg(i.SCC.OrgMSPID)
 	var collectionConfig []*cb.CollectionConfig
 	if input.Collections != nil {
 		collectionConfig = input.Collections.Config
@@ -395,7 +396,7 @@ func (i *Invocation) SimulateCommitChaincodeDefinition(input *lb.SimulateCommitC
 	for _, org := range orgs {
 		orgNames = append(orgNames, org.MSPID())
 		opaqueStates = append(opaqueStates, &ChaincodePrivateLedgerShim{
-			Collection: ImplicitCollectionNameForOrg(org.MSPID()),
+			Collection: privdata.ImplicitCollectionNameForOrg(org.MSPID()),
 			Stub:       i.Stub,
 		})
 	}
@@ -456,7 +457,7 @@ func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefiniti
 	myOrgIndex := -1
 	for _, org := range orgs {
 		opaqueStates = append(opaqueStates, &ChaincodePrivateLedgerShim{
-			Collection: ImplicitCollectionNameForOrg(org.MSPID()),
+			Collection: privdata.ImplicitCollectionNameForOrg(org.MSPID()),
 			Stub:       i.Stub,
 		})
 		if org.MSPID() == i.SCC.OrgMSPID {
@@ -667,6 +668,30 @@ func validateCollectionConfigs(collConfigs []*common.StaticCollectionConfig, msp
 		if err := validateCollectionConfigMemberOrgsPolicy(c, mspMgr); err != nil {
 			return err
 		}
+		if err := validateCollectionConfigEndorsementPolicy(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCollectionConfigEndorsementPolicy checks that the endorsement policy
+// of the supplied collection, if any, is well formed
+func validateCollectionConfigEndorsementPolicy(coll *common.StaticCollectionConfig) error {
+	if coll.EndorsementPolicy == nil {
+		return nil
+	}
+	switch policy := coll.EndorsementPolicy.Type.(type) {
+	case *common.CollectionEndorsementPolicy_SignaturePolicy:
+		if policy.SignaturePolicy == nil || policy.SignaturePolicy.Rule == nil {
+			return errors.Errorf("collection-name: %s -- signature policy of the endorsement policy is empty", coll.Name)
+		}
+	case *common.CollectionEndorsementPolicy_ChannelConfigPolicyReference:
+		if policy.ChannelConfigPolicyReference == "" {
+			return errors.Errorf("collection-name: %s -- channel config policy reference of the endorsement policy is empty", coll.Name)
+		}
+	default:
+		return errors.Errorf("collection-name: %s -- endorsement policy type is not set", coll.Name)
 	}
 	return nil
 }
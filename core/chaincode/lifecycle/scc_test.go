@@ -707,6 +707,33 @@ var _ = Describe("SCC", func() {
 				})
 			})
 
+			Context("when collection config contains an endorsement policy", func() {
+				BeforeEach(func() {
+					collConfigs[0].EndorsementPolicyRef = "/Channel/Application/Endorsement"
+				})
+
+				It("passes the collection config to the backing implementation", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(200)))
+					Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(1))
+					_, _, cd, _, _, _ := fakeSCCFuncs.ApproveChaincodeDefinitionForOrgArgsForCall(0)
+					staticCollConfig := cd.Collections.Config[0].GetStaticCollectionConfig()
+					Expect(staticCollConfig.EndorsementPolicy.GetChannelConfigPolicyReference()).To(Equal("/Channel/Application/Endorsement"))
+				})
+			})
+
+			Context("when collection config contains an endorsement policy without a type", func() {
+				BeforeEach(func() {
+					collConfigs[0].EmptyEndorsementPolicy = true
+				})
+
+				It("wraps and returns error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'ApproveChaincodeDefinitionForMyOrg': collection-name: test-collection -- endorsement policy type is not set"))
+				})
+			})
+
 			Context("when committed definition and proposed definition both contains no collection config", func() {
 				BeforeEach(func() {
 					fakeDeployedCCInfoProvider.ChaincodeInfoReturns(&ledger.DeployedChaincodeInfo{}, nil)
@@ -1408,6 +1435,9 @@ type collectionConfig struct {
 	Identities              []*mspprotos.MSPPrincipal
 	UseGivenMemberOrgPolicy bool
 	MemberOrgPolicy         *cb.CollectionPolicyConfig
+
+	EndorsementPolicyRef   string
+	EmptyEndorsementPolicy bool
 }
 
 func (cc *collectionConfig) toCollectionConfigProto() *cb.CollectionConfig {
@@ -1422,6 +1452,17 @@ func (cc *collectionConfig) toCollectionConfigProto() *cb.CollectionConfig {
 			},
 		}
 	}
+	var endorsementPolicy *cb.CollectionEndorsementPolicy
+	switch {
+	case cc.EmptyEndorsementPolicy:
+		endorsementPolicy = &cb.CollectionEndorsementPolicy{}
+	case cc.EndorsementPolicyRef != "":
+		endorsementPolicy = &cb.CollectionEndorsementPolicy{
+			Type: &cb.CollectionEndorsementPolicy_ChannelConfigPolicyReference{
+				ChannelConfigPolicyReference: cc.EndorsementPolicyRef,
+			},
+		}
+	}
 	return &cb.CollectionConfig{
 		Payload: &cb.CollectionConfig_StaticCollectionConfig{
 			StaticCollectionConfig: &cb.StaticCollectionConfig{
@@ -1430,6 +1471,7 @@ func (cc *collectionConfig) toCollectionConfigProto() *cb.CollectionConfig {
 				RequiredPeerCount: cc.RequiredPeerCount,
 				BlockToLive:       cc.BlockToLive,
 				MemberOrgsPolicy:  memberOrgPolicy,
+				EndorsementPolicy: endorsementPolicy,
 			},
 		},
 	}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	ledgera "github.com/hyperledger/fabric/core/ledger"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +23,36 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithMetadataStub        func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyWithMetadataMutex       sync.RWMutex
+	getHistoryForKeyWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}
+	getHistoryForKeyWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	GetPrivateDataHashHistoryStub        func(string, string, []byte) (ledger.ResultsIterator, error)
+	getPrivateDataHashHistoryMutex       sync.RWMutex
+	getPrivateDataHashHistoryArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	getPrivateDataHashHistoryReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getPrivateDataHashHistoryReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +121,150 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithMetadataReturnsOnCall[len(fake.getHistoryForKeyWithMetadataArgsForCall)]
+	fake.getHistoryForKeyWithMetadataArgsForCall = append(fake.getHistoryForKeyWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyWithMetadata", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	if fake.GetHistoryForKeyWithMetadataStub != nil {
+		return fake.GetHistoryForKeyWithMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCallCount() int {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithMetadataArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCalls(stub func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataArgsForCall(i int) (string, string, map[string]interface{}) {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	fake.getHistoryForKeyWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	if fake.getHistoryForKeyWithMetadataReturnsOnCall == nil {
+		fake.getHistoryForKeyWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistory(arg1 string, arg2 string, arg3 []byte) (ledger.ResultsIterator, error) {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getPrivateDataHashHistoryMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHashHistoryReturnsOnCall[len(fake.getPrivateDataHashHistoryArgsForCall)]
+	fake.getPrivateDataHashHistoryArgsForCall = append(fake.getPrivateDataHashHistoryArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("GetPrivateDataHashHistory", []interface{}{arg1, arg2, arg3Copy})
+	fake.getPrivateDataHashHistoryMutex.Unlock()
+	if fake.GetPrivateDataHashHistoryStub != nil {
+		return fake.GetPrivateDataHashHistoryStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHashHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistoryCallCount() int {
+	fake.getPrivateDataHashHistoryMutex.RLock()
+	defer fake.getPrivateDataHashHistoryMutex.RUnlock()
+	return len(fake.getPrivateDataHashHistoryArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistoryCalls(stub func(string, string, []byte) (ledger.ResultsIterator, error)) {
+	fake.getPrivateDataHashHistoryMutex.Lock()
+	defer fake.getPrivateDataHashHistoryMutex.Unlock()
+	fake.GetPrivateDataHashHistoryStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistoryArgsForCall(i int) (string, string, []byte) {
+	fake.getPrivateDataHashHistoryMutex.RLock()
+	defer fake.getPrivateDataHashHistoryMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHashHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistoryReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataHashHistoryMutex.Lock()
+	defer fake.getPrivateDataHashHistoryMutex.Unlock()
+	fake.GetPrivateDataHashHistoryStub = nil
+	fake.getPrivateDataHashHistoryReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetPrivateDataHashHistoryReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataHashHistoryMutex.Lock()
+	defer fake.getPrivateDataHashHistoryMutex.Unlock()
+	fake.GetPrivateDataHashHistoryStub = nil
+	if fake.getPrivateDataHashHistoryReturnsOnCall == nil {
+		fake.getPrivateDataHashHistoryReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getPrivateDataHashHistoryReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	fake.getPrivateDataHashHistoryMutex.RLock()
+	defer fake.getPrivateDataHashHistoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
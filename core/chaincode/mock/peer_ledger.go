@@ -11,6 +11,17 @@ import (
 )
 
 type PeerLedger struct {
+	CancelSnapshotRequestStub        func(uint64) error
+	cancelSnapshotRequestMutex       sync.RWMutex
+	cancelSnapshotRequestArgsForCall []struct {
+		arg1 uint64
+	}
+	cancelSnapshotRequestReturns struct {
+		result1 error
+	}
+	cancelSnapshotRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CloseStub        func()
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
@@ -127,6 +138,20 @@ type PeerLedger struct {
 		result1 ledger.MissingPvtDataTracker
 		result2 error
 	}
+	GetPurgeRecordsStub        func(uint64, uint64) ([]*ledger.PurgedPvtKey, error)
+	getPurgeRecordsMutex       sync.RWMutex
+	getPurgeRecordsArgsForCall []struct {
+		arg1 uint64
+		arg2 uint64
+	}
+	getPurgeRecordsReturns struct {
+		result1 []*ledger.PurgedPvtKey
+		result2 error
+	}
+	getPurgeRecordsReturnsOnCall map[int]struct {
+		result1 []*ledger.PurgedPvtKey
+		result2 error
+	}
 	GetPvtDataAndBlockByNumStub        func(uint64, ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error)
 	getPvtDataAndBlockByNumMutex       sync.RWMutex
 	getPvtDataAndBlockByNumArgsForCall []struct {
@@ -168,6 +193,40 @@ type PeerLedger struct {
 		result1 *peer.ProcessedTransaction
 		result2 error
 	}
+	GetTransactionsByChaincodeNamespaceStub        func(string, string, int) ([]*peer.ProcessedTransaction, string, error)
+	getTransactionsByChaincodeNamespaceMutex       sync.RWMutex
+	getTransactionsByChaincodeNamespaceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	getTransactionsByChaincodeNamespaceReturns struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}
+	getTransactionsByChaincodeNamespaceReturnsOnCall map[int]struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}
+	GetTransactionsByCreatorStub        func([]byte, string, int) ([]*peer.ProcessedTransaction, string, error)
+	getTransactionsByCreatorMutex       sync.RWMutex
+	getTransactionsByCreatorArgsForCall []struct {
+		arg1 []byte
+		arg2 string
+		arg3 int
+	}
+	getTransactionsByCreatorReturns struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}
+	getTransactionsByCreatorReturnsOnCall map[int]struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}
 	GetTxValidationCodeByTxIDStub        func(string) (peer.TxValidationCode, error)
 	getTxValidationCodeByTxIDMutex       sync.RWMutex
 	getTxValidationCodeByTxIDArgsForCall []struct {
@@ -218,10 +277,93 @@ type PeerLedger struct {
 		result1 ledger.TxSimulator
 		result2 error
 	}
+	PendingSnapshotRequestsStub        func() ([]uint64, error)
+	pendingSnapshotRequestsMutex       sync.RWMutex
+	pendingSnapshotRequestsArgsForCall []struct {
+	}
+	pendingSnapshotRequestsReturns struct {
+		result1 []uint64
+		result2 error
+	}
+	pendingSnapshotRequestsReturnsOnCall map[int]struct {
+		result1 []uint64
+		result2 error
+	}
+	SubmitSnapshotRequestStub        func(uint64) error
+	submitSnapshotRequestMutex       sync.RWMutex
+	submitSnapshotRequestArgsForCall []struct {
+		arg1 uint64
+	}
+	submitSnapshotRequestReturns struct {
+		result1 error
+	}
+	submitSnapshotRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PeerLedger) CancelSnapshotRequest(arg1 uint64) error {
+	fake.cancelSnapshotRequestMutex.Lock()
+	ret, specificReturn := fake.cancelSnapshotRequestReturnsOnCall[len(fake.cancelSnapshotRequestArgsForCall)]
+	fake.cancelSnapshotRequestArgsForCall = append(fake.cancelSnapshotRequestArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("CancelSnapshotRequest", []interface{}{arg1})
+	fake.cancelSnapshotRequestMutex.Unlock()
+	if fake.CancelSnapshotRequestStub != nil {
+		return fake.CancelSnapshotRequestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.cancelSnapshotRequestReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestCallCount() int {
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
+	return len(fake.cancelSnapshotRequestArgsForCall)
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestCalls(stub func(uint64) error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = stub
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestArgsForCall(i int) uint64 {
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
+	argsForCall := fake.cancelSnapshotRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestReturns(result1 error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = nil
+	fake.cancelSnapshotRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) CancelSnapshotRequestReturnsOnCall(i int, result1 error) {
+	fake.cancelSnapshotRequestMutex.Lock()
+	defer fake.cancelSnapshotRequestMutex.Unlock()
+	fake.CancelSnapshotRequestStub = nil
+	if fake.cancelSnapshotRequestReturnsOnCall == nil {
+		fake.cancelSnapshotRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelSnapshotRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
@@ -795,6 +937,70 @@ func (fake *PeerLedger) GetMissingPvtDataTrackerReturnsOnCall(i int, result1 led
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetPurgeRecords(arg1 uint64, arg2 uint64) ([]*ledger.PurgedPvtKey, error) {
+	fake.getPurgeRecordsMutex.Lock()
+	ret, specificReturn := fake.getPurgeRecordsReturnsOnCall[len(fake.getPurgeRecordsArgsForCall)]
+	fake.getPurgeRecordsArgsForCall = append(fake.getPurgeRecordsArgsForCall, struct {
+		arg1 uint64
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("GetPurgeRecords", []interface{}{arg1, arg2})
+	fake.getPurgeRecordsMutex.Unlock()
+	if fake.GetPurgeRecordsStub != nil {
+		return fake.GetPurgeRecordsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPurgeRecordsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetPurgeRecordsCallCount() int {
+	fake.getPurgeRecordsMutex.RLock()
+	defer fake.getPurgeRecordsMutex.RUnlock()
+	return len(fake.getPurgeRecordsArgsForCall)
+}
+
+func (fake *PeerLedger) GetPurgeRecordsCalls(stub func(uint64, uint64) ([]*ledger.PurgedPvtKey, error)) {
+	fake.getPurgeRecordsMutex.Lock()
+	defer fake.getPurgeRecordsMutex.Unlock()
+	fake.GetPurgeRecordsStub = stub
+}
+
+func (fake *PeerLedger) GetPurgeRecordsArgsForCall(i int) (uint64, uint64) {
+	fake.getPurgeRecordsMutex.RLock()
+	defer fake.getPurgeRecordsMutex.RUnlock()
+	argsForCall := fake.getPurgeRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PeerLedger) GetPurgeRecordsReturns(result1 []*ledger.PurgedPvtKey, result2 error) {
+	fake.getPurgeRecordsMutex.Lock()
+	defer fake.getPurgeRecordsMutex.Unlock()
+	fake.GetPurgeRecordsStub = nil
+	fake.getPurgeRecordsReturns = struct {
+		result1 []*ledger.PurgedPvtKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetPurgeRecordsReturnsOnCall(i int, result1 []*ledger.PurgedPvtKey, result2 error) {
+	fake.getPurgeRecordsMutex.Lock()
+	defer fake.getPurgeRecordsMutex.Unlock()
+	fake.GetPurgeRecordsStub = nil
+	if fake.getPurgeRecordsReturnsOnCall == nil {
+		fake.getPurgeRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*ledger.PurgedPvtKey
+			result2 error
+		})
+	}
+	fake.getPurgeRecordsReturnsOnCall[i] = struct {
+		result1 []*ledger.PurgedPvtKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetPvtDataAndBlockByNum(arg1 uint64, arg2 ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
 	fake.getPvtDataAndBlockByNumMutex.Lock()
 	ret, specificReturn := fake.getPvtDataAndBlockByNumReturnsOnCall[len(fake.getPvtDataAndBlockByNumArgsForCall)]
@@ -986,6 +1192,141 @@ func (fake *PeerLedger) GetTransactionByIDReturnsOnCall(i int, result1 *peer.Pro
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespace(arg1 string, arg2 string, arg3 int) ([]*peer.ProcessedTransaction, string, error) {
+	fake.getTransactionsByChaincodeNamespaceMutex.Lock()
+	ret, specificReturn := fake.getTransactionsByChaincodeNamespaceReturnsOnCall[len(fake.getTransactionsByChaincodeNamespaceArgsForCall)]
+	fake.getTransactionsByChaincodeNamespaceArgsForCall = append(fake.getTransactionsByChaincodeNamespaceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetTransactionsByChaincodeNamespace", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionsByChaincodeNamespaceMutex.Unlock()
+	if fake.GetTransactionsByChaincodeNamespaceStub != nil {
+		return fake.GetTransactionsByChaincodeNamespaceStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getTransactionsByChaincodeNamespaceReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespaceCallCount() int {
+	fake.getTransactionsByChaincodeNamespaceMutex.RLock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.RUnlock()
+	return len(fake.getTransactionsByChaincodeNamespaceArgsForCall)
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespaceCalls(stub func(string, string, int) ([]*peer.ProcessedTransaction, string, error)) {
+	fake.getTransactionsByChaincodeNamespaceMutex.Lock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.Unlock()
+	fake.GetTransactionsByChaincodeNamespaceStub = stub
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespaceArgsForCall(i int) (string, string, int) {
+	fake.getTransactionsByChaincodeNamespaceMutex.RLock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.RUnlock()
+	argsForCall := fake.getTransactionsByChaincodeNamespaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespaceReturns(result1 []*peer.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByChaincodeNamespaceMutex.Lock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.Unlock()
+	fake.GetTransactionsByChaincodeNamespaceStub = nil
+	fake.getTransactionsByChaincodeNamespaceReturns = struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetTransactionsByChaincodeNamespaceReturnsOnCall(i int, result1 []*peer.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByChaincodeNamespaceMutex.Lock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.Unlock()
+	fake.GetTransactionsByChaincodeNamespaceStub = nil
+	if fake.getTransactionsByChaincodeNamespaceReturnsOnCall == nil {
+		fake.getTransactionsByChaincodeNamespaceReturnsOnCall = make(map[int]struct {
+			result1 []*peer.ProcessedTransaction
+			result2 string
+			result3 error
+		})
+	}
+	fake.getTransactionsByChaincodeNamespaceReturnsOnCall[i] = struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetTransactionsByCreator(arg1 []byte, arg2 string, arg3 int) ([]*peer.ProcessedTransaction, string, error) {
+	fake.getTransactionsByCreatorMutex.Lock()
+	ret, specificReturn := fake.getTransactionsByCreatorReturnsOnCall[len(fake.getTransactionsByCreatorArgsForCall)]
+	fake.getTransactionsByCreatorArgsForCall = append(fake.getTransactionsByCreatorArgsForCall, struct {
+		arg1 []byte
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetTransactionsByCreator", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionsByCreatorMutex.Unlock()
+	if fake.GetTransactionsByCreatorStub != nil {
+		return fake.GetTransactionsByCreatorStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getTransactionsByCreatorReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *PeerLedger) GetTransactionsByCreatorCallCount() int {
+	fake.getTransactionsByCreatorMutex.RLock()
+	defer fake.getTransactionsByCreatorMutex.RUnlock()
+	return len(fake.getTransactionsByCreatorArgsForCall)
+}
+
+func (fake *PeerLedger) GetTransactionsByCreatorCalls(stub func([]byte, string, int) ([]*peer.ProcessedTransaction, string, error)) {
+	fake.getTransactionsByCreatorMutex.Lock()
+	defer fake.getTransactionsByCreatorMutex.Unlock()
+	fake.GetTransactionsByCreatorStub = stub
+}
+
+func (fake *PeerLedger) GetTransactionsByCreatorArgsForCall(i int) ([]byte, string, int) {
+	fake.getTransactionsByCreatorMutex.RLock()
+	defer fake.getTransactionsByCreatorMutex.RUnlock()
+	argsForCall := fake.getTransactionsByCreatorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PeerLedger) GetTransactionsByCreatorReturns(result1 []*peer.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByCreatorMutex.Lock()
+	defer fake.getTransactionsByCreatorMutex.Unlock()
+	fake.GetTransactionsByCreatorStub = nil
+	fake.getTransactionsByCreatorReturns = struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetTransactionsByCreatorReturnsOnCall(i int, result1 []*peer.ProcessedTransaction, result2 string, result3 error) {
+	fake.getTransactionsByCreatorMutex.Lock()
+	defer fake.getTransactionsByCreatorMutex.Unlock()
+	fake.GetTransactionsByCreatorStub = nil
+	if fake.getTransactionsByCreatorReturnsOnCall == nil {
+		fake.getTransactionsByCreatorReturnsOnCall = make(map[int]struct {
+			result1 []*peer.ProcessedTransaction
+			result2 string
+			result3 error
+		})
+	}
+	fake.getTransactionsByCreatorReturnsOnCall[i] = struct {
+		result1 []*peer.ProcessedTransaction
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
 func (fake *PeerLedger) GetTxValidationCodeByTxID(arg1 string) (peer.TxValidationCode, error) {
 	fake.getTxValidationCodeByTxIDMutex.Lock()
 	ret, specificReturn := fake.getTxValidationCodeByTxIDReturnsOnCall[len(fake.getTxValidationCodeByTxIDArgsForCall)]
@@ -1222,9 +1563,126 @@ func (fake *PeerLedger) NewTxSimulatorReturnsOnCall(i int, result1 ledger.TxSimu
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) PendingSnapshotRequests() ([]uint64, error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	ret, specificReturn := fake.pendingSnapshotRequestsReturnsOnCall[len(fake.pendingSnapshotRequestsArgsForCall)]
+	fake.pendingSnapshotRequestsArgsForCall = append(fake.pendingSnapshotRequestsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PendingSnapshotRequests", []interface{}{})
+	fake.pendingSnapshotRequestsMutex.Unlock()
+	if fake.PendingSnapshotRequestsStub != nil {
+		return fake.PendingSnapshotRequestsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pendingSnapshotRequestsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsCallCount() int {
+	fake.pendingSnapshotRequestsMutex.RLock()
+	defer fake.pendingSnapshotRequestsMutex.RUnlock()
+	return len(fake.pendingSnapshotRequestsArgsForCall)
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsCalls(stub func() ([]uint64, error)) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = stub
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsReturns(result1 []uint64, result2 error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = nil
+	fake.pendingSnapshotRequestsReturns = struct {
+		result1 []uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) PendingSnapshotRequestsReturnsOnCall(i int, result1 []uint64, result2 error) {
+	fake.pendingSnapshotRequestsMutex.Lock()
+	defer fake.pendingSnapshotRequestsMutex.Unlock()
+	fake.PendingSnapshotRequestsStub = nil
+	if fake.pendingSnapshotRequestsReturnsOnCall == nil {
+		fake.pendingSnapshotRequestsReturnsOnCall = make(map[int]struct {
+			result1 []uint64
+			result2 error
+		})
+	}
+	fake.pendingSnapshotRequestsReturnsOnCall[i] = struct {
+		result1 []uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequest(arg1 uint64) error {
+	fake.submitSnapshotRequestMutex.Lock()
+	ret, specificReturn := fake.submitSnapshotRequestReturnsOnCall[len(fake.submitSnapshotRequestArgsForCall)]
+	fake.submitSnapshotRequestArgsForCall = append(fake.submitSnapshotRequestArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("SubmitSnapshotRequest", []interface{}{arg1})
+	fake.submitSnapshotRequestMutex.Unlock()
+	if fake.SubmitSnapshotRequestStub != nil {
+		return fake.SubmitSnapshotRequestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.submitSnapshotRequestReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestCallCount() int {
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
+	return len(fake.submitSnapshotRequestArgsForCall)
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestCalls(stub func(uint64) error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = stub
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestArgsForCall(i int) uint64 {
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
+	argsForCall := fake.submitSnapshotRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestReturns(result1 error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = nil
+	fake.submitSnapshotRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) SubmitSnapshotRequestReturnsOnCall(i int, result1 error) {
+	fake.submitSnapshotRequestMutex.Lock()
+	defer fake.submitSnapshotRequestMutex.Unlock()
+	fake.SubmitSnapshotRequestStub = nil
+	if fake.submitSnapshotRequestReturnsOnCall == nil {
+		fake.submitSnapshotRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitSnapshotRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cancelSnapshotRequestMutex.RLock()
+	defer fake.cancelSnapshotRequestMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
 	fake.commitPvtDataOfOldBlocksMutex.RLock()
@@ -1245,12 +1703,18 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
 	fake.getMissingPvtDataTrackerMutex.RLock()
 	defer fake.getMissingPvtDataTrackerMutex.RUnlock()
+	fake.getPurgeRecordsMutex.RLock()
+	defer fake.getPurgeRecordsMutex.RUnlock()
 	fake.getPvtDataAndBlockByNumMutex.RLock()
 	defer fake.getPvtDataAndBlockByNumMutex.RUnlock()
 	fake.getPvtDataByNumMutex.RLock()
 	defer fake.getPvtDataByNumMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
+	fake.getTransactionsByChaincodeNamespaceMutex.RLock()
+	defer fake.getTransactionsByChaincodeNamespaceMutex.RUnlock()
+	fake.getTransactionsByCreatorMutex.RLock()
+	defer fake.getTransactionsByCreatorMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
 	defer fake.getTxValidationCodeByTxIDMutex.RUnlock()
 	fake.newHistoryQueryExecutorMutex.RLock()
@@ -1259,6 +1723,10 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.newQueryExecutorMutex.RUnlock()
 	fake.newTxSimulatorMutex.RLock()
 	defer fake.newTxSimulatorMutex.RUnlock()
+	fake.pendingSnapshotRequestsMutex.RLock()
+	defer fake.pendingSnapshotRequestsMutex.RUnlock()
+	fake.submitSnapshotRequestMutex.RLock()
+	defer fake.submitSnapshotRequestMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
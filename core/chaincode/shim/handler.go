@@ -383,6 +383,29 @@ func (h *Handler) handleDelState(collection string, key string, channelId string
 	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handlePurgeState communicates with the peer to purge a key and its past versions from the private data in the ledger.
+func (h *Handler) handlePurgeState(collection string, key string, channelId string, txid string) error {
+	payloadBytes := marshalOrPanic(&pb.DelState{Collection: collection, Key: key})
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PURGE_PRIVATE_DATA, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	// Execute the request and get response
+	responseMsg, err := h.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return errors.Errorf("[%s] error sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_PURGE_PRIVATE_DATA)
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		return nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (h *Handler) handleGetStateByRange(collection, startKey, endKey string, metadata []byte,
 	channelId string, txid string) (*pb.QueryResponse, error) {
 	// Send GET_STATE_BY_RANGE message to peer chaincode support
@@ -514,7 +537,7 @@ func (h *Handler) handleGetQueryResult(collection string, query string, metadata
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-func (h *Handler) handleGetHistoryForKey(key string, channelId string, txid string) (*pb.QueryResponse, error) {
+func (h *Handler) handleGetHistoryForKey(key string, metadata []byte, channelId string, txid string) (*pb.QueryResponse, error) {
 	// Create the channel on which to communicate the response from validating peer
 	respChan, err := h.createResponseChannel(channelId, txid)
 	if err != nil {
@@ -523,7 +546,7 @@ func (h *Handler) handleGetHistoryForKey(key string, channelId string, txid stri
 	defer h.deleteResponseChannel(channelId, txid)
 
 	// Send GET_HISTORY_FOR_KEY message to peer chaincode support
-	payloadBytes := marshalOrPanic(&pb.GetHistoryForKey{Key: key})
+	payloadBytes := marshalOrPanic(&pb.GetHistoryForKey{Key: key, Metadata: metadata})
 
 	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
 	var responseMsg pb.ChaincodeMessage
@@ -550,6 +573,34 @@ func (h *Handler) handleGetHistoryForKey(key string, channelId string, txid stri
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+func (h *Handler) handleGetPrivateDataHashHistory(collection string, key string, channelId string, txid string) (*pb.QueryResponse, error) {
+	// Construct payload for GET_PRIVATE_DATA_HASH_HISTORY
+	payloadBytes := marshalOrPanic(&pb.GetHistoryForKey{Collection: collection, Key: key})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	responseMsg, err := h.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "[%s] error sending %s", shorttxid(txid), pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY)
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		getHistoryResponse := &pb.QueryResponse{}
+		if err = proto.Unmarshal(responseMsg.Payload, getHistoryResponse); err != nil {
+			return nil, errors.Errorf("[%s] unmarshal error", shorttxid(responseMsg.Txid))
+		}
+
+		return getHistoryResponse, nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (h *Handler) createResponse(status int32, payload []byte) pb.Response {
 	return pb.Response{Status: status, Payload: payload}
 }
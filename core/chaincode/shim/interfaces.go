@@ -232,6 +232,35 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns a history of key values across time,
+	// as restricted and ordered by the given options. The history can be
+	// restricted to a range of blocks and to a range of transaction timestamps,
+	// and can be returned with the most recent modification first. A nil
+	// options returns the same history as GetHistoryForKey. Note that the
+	// block range bounds the history that is scanned by the peer, whereas the
+	// time range only filters the scanned history, as the history is not
+	// indexed by the transaction timestamp.
+	// GetHistoryForKeyWithOptions requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// Like GetHistoryForKey, the query is NOT re-executed during validation
+	// phase and should be limited to read-only chaincode operations.
+	GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyWithPagination returns a history of key values across
+	// time, as restricted and ordered by the given options.
+	// When an empty string is passed as a value to the bookmark argument, the
+	// returned iterator can be used to fetch the first `pageSize` history records.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` history records starting from the bookmark (inclusive).
+	// Note that only the bookmark present in a prior page of query results
+	// (ResponseMetadata) for the same key and options can be used as a value to
+	// the bookmark argument. Otherwise, an empty string must be passed as bookmark.
+	// GetHistoryForKeyWithPagination requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// This call is only supported in a read only transaction.
+	GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -243,6 +272,19 @@ type ChaincodeStubInterface interface {
 	// `collection`
 	GetPrivateDataHash(collection, key string) ([]byte, error)
 
+	// GetPrivateDataHashHistory returns the history of the hash of the value
+	// of the specified `key` in the specified `collection`. For each modification
+	// of the key, the block number, the transaction ID, the hash of the value and
+	// the delete marker are returned. As the history is maintained over the
+	// hashed writes, GetPrivateDataHashHistory can be used on the peers that are
+	// not members of the `collection`.
+	// GetPrivateDataHashHistory requires peer configurations
+	// core.ledger.history.enableHistoryDatabase and
+	// core.ledger.history.enablePrivateDataHashHistory to be true.
+	// Like GetHistoryForKey, the query is NOT re-executed during validation
+	// phase and should be limited to read-only chaincode operations.
+	GetPrivateDataHashHistory(collection, key string) (HashHistoryQueryIteratorInterface, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -262,6 +304,16 @@ type ChaincodeStubInterface interface {
 	// when the transaction is validated and successfully committed.
 	DelPrivateData(collection, key string) error
 
+	// PurgePrivateData records the specified `key` to be purged in the private writeset
+	// of the transaction. Note that only hash of the private writeset goes into the
+	// transaction proposal response (which is sent to the client who issued the
+	// transaction) and the actual private writeset gets temporarily stored in a
+	// transient store. When the transaction is validated and successfully committed,
+	// the `key` and its value are deleted from the collection and, unlike
+	// DelPrivateData, all the past versions of the `key` are removed from the
+	// private data stores of the peers, leaving only the hashes on the chain.
+	PurgePrivateData(collection, key string) error
+
 	// SetPrivateDataValidationParameter sets the key-level endorsement policy
 	// for the private data specified by `key`.
 	SetPrivateDataValidationParameter(collection, key string, ep []byte) error
@@ -379,6 +431,36 @@ type HistoryQueryIteratorInterface interface {
 	Next() (*queryresult.KeyModification, error)
 }
 
+// HashHistoryQueryIteratorInterface allows a chaincode to iterate over the
+// modifications of the hash of a private data key returned by a private data
+// hash history query.
+type HashHistoryQueryIteratorInterface interface {
+	// Inherit HasNext() and Close()
+	CommonIteratorInterface
+
+	// Next returns the next modification in the private data hash history query iterator.
+	Next() (*queryresult.KeyHashModification, error)
+}
+
+// HistoryQueryOptions restricts and orders the history returned by a history query.
+type HistoryQueryOptions struct {
+	// StartBlock and EndBlock restrict the history to the modifications committed in
+	// the blocks between the two block numbers (both inclusive). A zero EndBlock
+	// denotes no upper bound.
+	StartBlock uint64
+	EndBlock   uint64
+
+	// StartTime and EndTime restrict the history to the transactions with a timestamp
+	// between StartTime (inclusive) and EndTime (exclusive). The timestamp is the
+	// timestamp provided by the client in the proposal header. A nil StartTime or
+	// EndTime denotes no lower or upper bound respectively.
+	StartTime *timestamp.Timestamp
+	EndTime   *timestamp.Timestamp
+
+	// NewestFirst returns the most recent modification first.
+	NewestFirst bool
+}
+
 // MockQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by range query.
 // TODO: Once the execute query and history query are implemented in MockStub,
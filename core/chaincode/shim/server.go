@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS properties of a ChaincodeServer.
+type TLSProperties struct {
+	// Disabled disables TLS, which is enabled by default.
+	Disabled bool
+	// Key is the PEM encoded private key of the server.
+	Key []byte
+	// Cert is the PEM encoded certificate of the server.
+	Cert []byte
+	// ClientCACerts, if set, are the PEM encoded certificates of the CAs
+	// the connecting peers' client certificates are verified against.
+	ClientCACerts []byte
+}
+
+// ChaincodeServer runs chaincode as a gRPC server, which peers connect to
+// instead of the chaincode connecting to the peer. Each connection of a
+// peer is served by its own handler, so the server accepts connections
+// from one or more peers.
+type ChaincodeServer struct {
+	// CCID is the ID of the chaincode, which must match the
+	// package ID of the chaincode on the peers.
+	CCID string
+	// Address is the listen address of the server.
+	Address string
+	// CC is the chaincode that handles Init and Invoke.
+	CC Chaincode
+	// TLSProps are the TLS properties of the server.
+	TLSProps TLSProperties
+	// KaOpts are the keepalive options of the server,
+	// comm.DefaultKeepaliveOptions are used if nil.
+	KaOpts *comm.KeepaliveOptions
+
+	mutex  sync.Mutex
+	server *comm.GRPCServer
+}
+
+// Connect is the bidi stream entry point called by a peer connecting to the chaincode.
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(cs.CCID, &serverStream{Chaincode_ConnectServer: stream}, cs.CC)
+}
+
+// Start starts the server, and blocks until the server is stopped.
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+
+	kaOpts := comm.DefaultKeepaliveOptions
+	if cs.KaOpts != nil {
+		kaOpts = *cs.KaOpts
+	}
+
+	secOpts := comm.SecureOptions{}
+	if !cs.TLSProps.Disabled {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return errors.New("key and cert must be specified when TLS is enabled")
+		}
+		secOpts = comm.SecureOptions{
+			UseTLS:      true,
+			Key:         cs.TLSProps.Key,
+			Certificate: cs.TLSProps.Cert,
+		}
+		if cs.TLSProps.ClientCACerts != nil {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		KaOpts:  kaOpts,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the chaincode server")
+	}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	cs.mutex.Lock()
+	cs.server = server
+	cs.mutex.Unlock()
+
+	return server.Start()
+}
+
+// Stop stops the server.
+func (cs *ChaincodeServer) Stop() {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if cs.server != nil {
+		cs.server.Stop()
+	}
+}
+
+// serverStream adapts the server side of a peer connection to a
+// PeerChaincodeStream. The server side of a stream is closed when
+// the handler returns, so closing it for sending is a no-op.
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+func (s *serverStream) CloseSend() error {
+	return nil
+}
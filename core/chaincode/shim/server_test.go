@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startChaincodeServer(t *testing.T, cs *ChaincodeServer) string {
+	errCh := make(chan error, 1)
+	go func() { errCh <- cs.Start() }()
+
+	gt := NewGomegaWithT(t)
+	gt.Eventually(func() *comm.GRPCServer {
+		cs.mutex.Lock()
+		defer cs.mutex.Unlock()
+		return cs.server
+	}, 5*time.Second).ShouldNot(BeNil())
+	gt.Consistently(errCh, 100*time.Millisecond).ShouldNot(Receive())
+
+	return cs.server.Address()
+}
+
+func TestChaincodeServerBadConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cs          *ChaincodeServer
+		expectedErr string
+	}{
+		{
+			name:        "missing ccid",
+			cs:          &ChaincodeServer{Address: "127.0.0.1:0", CC: &shimTestCC{}},
+			expectedErr: "ccid must be specified",
+		},
+		{
+			name:        "missing address",
+			cs:          &ChaincodeServer{CCID: "mycc:1234", CC: &shimTestCC{}},
+			expectedErr: "address must be specified",
+		},
+		{
+			name:        "missing chaincode",
+			cs:          &ChaincodeServer{CCID: "mycc:1234", Address: "127.0.0.1:0"},
+			expectedErr: "chaincode must be specified",
+		},
+		{
+			name:        "missing TLS material",
+			cs:          &ChaincodeServer{CCID: "mycc:1234", Address: "127.0.0.1:0", CC: &shimTestCC{}},
+			expectedErr: "key and cert must be specified when TLS is enabled",
+		},
+		{
+			name: "bad address",
+			cs: &ChaincodeServer{
+				CCID:     "mycc:1234",
+				Address:  "bad-address",
+				CC:       &shimTestCC{},
+				TLSProps: TLSProperties{Disabled: true},
+			},
+			expectedErr: "failed to create the chaincode server: listen tcp: address bad-address: missing port in address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cs.Start(), tt.expectedErr)
+		})
+	}
+}
+
+func TestChaincodeServerConnect(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	cs := &ChaincodeServer{
+		CCID:    "mycc:1234",
+		Address: "127.0.0.1:0",
+		CC:      &shimTestCC{},
+		TLSProps: TLSProperties{
+			Key:           serverKeyPair.Key,
+			Cert:          serverKeyPair.Cert,
+			ClientCACerts: ca.CertBytes(),
+		},
+	}
+	address := startChaincodeServer(t, cs)
+	defer cs.Stop()
+
+	// a peer without a client certificate is rejected
+	client, err := comm.NewGRPCClient(comm.ClientConfig{
+		Timeout: time.Second,
+		SecOpts: comm.SecureOptions{
+			UseTLS:        true,
+			ServerRootCAs: [][]byte{ca.CertBytes()},
+		},
+	})
+	require.NoError(t, err)
+	_, err = client.NewConnection(address, "")
+	assert.Error(t, err)
+
+	// each peer connection is served by its own handler
+	for i := 0; i < 2; i++ {
+		client, err := comm.NewGRPCClient(comm.ClientConfig{
+			Timeout: time.Second,
+			SecOpts: comm.SecureOptions{
+				UseTLS:            true,
+				ServerRootCAs:     [][]byte{ca.CertBytes()},
+				RequireClientCert: true,
+				Key:               clientKeyPair.Key,
+				Certificate:       clientKeyPair.Cert,
+			},
+		})
+		require.NoError(t, err)
+		conn, err := client.NewConnection(address, "")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		stream, err := pb.NewChaincodeClient(conn).Connect(context.Background())
+		require.NoError(t, err)
+
+		msg, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+		chaincodeID := &pb.ChaincodeID{}
+		require.NoError(t, proto.Unmarshal(msg.Payload, chaincodeID))
+		assert.Equal(t, "mycc:1234", chaincodeID.Name)
+	}
+}
@@ -8,8 +8,10 @@ package shim
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	mockpeer "github.com/hyperledger/fabric/common/mocks/peer"
@@ -68,6 +70,9 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 	} else if function == "delete" {
 		// Deletes an entity from its state
 		return t.delete(stub, args)
+	} else if function == "purge" {
+		// Purges an entity from a private data collection
+		return t.purge(stub, args)
 	} else if function == "query" {
 		// the old "Query" is now implemtned in invoke
 		return t.query(stub, args)
@@ -77,6 +82,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "hashhistoryq" {
+		return t.hashhistoryq(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	} else if function == "putep" {
@@ -161,6 +168,19 @@ func (t *shimTestCC) delete(stub ChaincodeStubInterface, args []string) pb.Respo
 	return Success(nil)
 }
 
+// Purges an entity from a private data collection
+func (t *shimTestCC) purge(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	if err := stub.PurgePrivateData(args[0], args[1]); err != nil {
+		return Error("Failed to purge private data")
+	}
+
+	return Success(nil)
+}
+
 // query callback representing the query of a chaincode
 func (t *shimTestCC) query(stub ChaincodeStubInterface, args []string) pb.Response {
 	var A string // Entities
@@ -286,6 +306,30 @@ func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Respon
 	return Success(buffer.Bytes())
 }
 
+// hashhistoryq calls private data hash history query
+func (t *shimTestCC) hashhistoryq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) < 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	resultsIterator, err := stub.GetPrivateDataHashHistory(args[0], args[1])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var txIDs []string
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		txIDs = append(txIDs, fmt.Sprintf("%s@%d", response.TxId, response.BlockNum))
+	}
+
+	return Success([]byte(strings.Join(txIDs, ",")))
+}
+
 // rangeq calls range query
 func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) < 1 {
@@ -544,6 +588,42 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//bad purge
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PURGE_PRIVATE_DATA, Txid: "4b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "4b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("purge"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = protoutil.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//good purge
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PURGE_PRIVATE_DATA, Txid: "4c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "4c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "4c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("purge"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = protoutil.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//bad invoke
 	respSet = &mockpeer.MockResponseSet{
 		DoneFunc:  errorFunc,
@@ -703,6 +783,52 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//private data hash history query
+
+	//create the response
+	hashHistoryQueryResponse := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: protoutil.MarshalOrPanic(&lproto.KeyHashModification{BlockNum: 3, TxId: "6", ValueHash: []byte("hash")})}},
+		HasMore: false}
+	payload = protoutil.MarshalOrPanic(hashHistoryQueryResponse)
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("hashhistoryq"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = protoutil.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error private data hash history query
+
+	//create the response
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY, Txid: "7c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("private data hash history is not enabled"), Txid: "7c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("hashhistoryq"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = protoutil.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//query result
 
 	//create the response
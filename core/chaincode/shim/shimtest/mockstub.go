@@ -182,6 +182,10 @@ func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error)
 	return nil, errors.New("Not Implemented")
 }
 
+func (stub *MockStub) GetPrivateDataHashHistory(collection, key string) (shim.HashHistoryQueryIteratorInterface, error) {
+	return nil, errors.New("Not Implemented")
+}
+
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
 	m, in := stub.PvtState[collection]
 	if !in {
@@ -198,6 +202,10 @@ func (stub *MockStub) DelPrivateData(collection string, key string) error {
 	return errors.New("Not Implemented")
 }
 
+func (stub *MockStub) PurgePrivateData(collection string, key string) error {
+	return errors.New("Not Implemented")
+}
+
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
 	return nil, errors.New("Not Implemented")
 }
@@ -311,6 +319,19 @@ func (stub *MockStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInt
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyWithOptions function can be invoked by a chaincode to return a history of
+// key values across time, as restricted and ordered by the given options.
+func (stub *MockStub) GetHistoryForKeyWithOptions(key string, options *shim.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetHistoryForKeyWithPagination function can be invoked by a chaincode to return a page of the
+// history of key values across time, as restricted and ordered by the given options.
+func (stub *MockStub) GetHistoryForKeyWithPagination(key string, options *shim.HistoryQueryOptions, pageSize int32,
+	bookmark string) (shim.HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
@@ -185,6 +185,18 @@ func (s *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byt
 	return s.handler.handleGetPrivateDataHash(collection, key, s.ChannelId, s.TxID)
 }
 
+// GetPrivateDataHashHistory documentation can be found in interfaces.go
+func (s *ChaincodeStub) GetPrivateDataHashHistory(collection string, key string) (HashHistoryQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	response, err := s.handler.handleGetPrivateDataHashHistory(collection, key, s.ChannelId, s.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &HashHistoryQueryIterator{CommonIterator: &CommonIterator{s.handler, s.ChannelId, s.TxID, response, 0}}, nil
+}
+
 // PutPrivateData documentation can be found in interfaces.go
 func (s *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
 	if collection == "" {
@@ -204,6 +216,17 @@ func (s *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	return s.handler.handleDelState(collection, key, s.ChannelId, s.TxID)
 }
 
+// PurgePrivateData documentation can be found in interfaces.go
+func (s *ChaincodeStub) PurgePrivateData(collection string, key string) error {
+	if collection == "" {
+		return fmt.Errorf("collection must not be an empty string")
+	}
+	if key == "" {
+		return fmt.Errorf("key must not be an empty string")
+	}
+	return s.handler.handlePurgeState(collection, key, s.ChannelId, s.TxID)
+}
+
 // GetPrivateDataByRange documentation can be found in interfaces.go
 func (s *ChaincodeStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if collection == "" {
@@ -295,11 +318,17 @@ type HistoryQueryIterator struct {
 	*CommonIterator
 }
 
+// HashHistoryQueryIterator documentation can be found in interfaces.go
+type HashHistoryQueryIterator struct {
+	*CommonIterator
+}
+
 type resultType uint8
 
 const (
 	STATE_QUERY_RESULT resultType = iota + 1
 	HISTORY_QUERY_RESULT
+	HASH_HISTORY_QUERY_RESULT
 )
 
 func createQueryResponseMetadata(metadataBytes []byte) (*pb.QueryResponseMetadata, error) {
@@ -362,13 +391,68 @@ func (s *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryIter
 	return iterator, err
 }
 
+func (s *ChaincodeStub) handleGetHistoryForKey(key string,
+	metadata []byte) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	response, err := s.handler.handleGetHistoryForKey(key, metadata, s.ChannelId, s.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{s.handler, s.ChannelId, s.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
+}
+
 // GetHistoryForKey documentation can be found in interfaces.go
 func (s *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := s.handler.handleGetHistoryForKey(key, s.ChannelId, s.TxID)
+	// ignore QueryResponseMetadata as it is not applicable for a history query without pagination
+	iterator, _, err := s.handleGetHistoryForKey(key, nil)
+	return iterator, err
+}
+
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (s *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	metadata, err := createHistoryQueryMetadata(options, 0, "")
 	if err != nil {
 		return nil, err
 	}
-	return &HistoryQueryIterator{CommonIterator: &CommonIterator{s.handler, s.ChannelId, s.TxID, response, 0}}, nil
+
+	// ignore QueryResponseMetadata as it is not applicable for a history query without pagination
+	iterator, _, err := s.handleGetHistoryForKey(key, metadata)
+	return iterator, err
+}
+
+// GetHistoryForKeyWithPagination documentation can be found in interfaces.go
+func (s *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createHistoryQueryMetadata(options, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.handleGetHistoryForKey(key, metadata)
+}
+
+func createHistoryQueryMetadata(options *HistoryQueryOptions, pageSize int32, bookmark string) ([]byte, error) {
+	// Construct the HistoryQueryMetadata with the options, a page size and a bookmark needed for pagination
+	metadata := &pb.HistoryQueryMetadata{PageSize: pageSize, Bookmark: bookmark}
+	if options != nil {
+		metadata.StartBlock = options.StartBlock
+		metadata.EndBlock = options.EndBlock
+		metadata.StartTime = options.StartTime
+		metadata.EndTime = options.EndTime
+		metadata.NewestFirst = options.NewestFirst
+	}
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadataBytes, nil
 }
 
 //CreateCompositeKey documentation can be found in interfaces.go
@@ -526,6 +610,14 @@ func (iter *HistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
 	}
 }
 
+func (iter *HashHistoryQueryIterator) Next() (*queryresult.KeyHashModification, error) {
+	if result, err := iter.nextResult(HASH_HISTORY_QUERY_RESULT); err == nil {
+		return result.(*queryresult.KeyHashModification), err
+	} else {
+		return nil, err
+	}
+}
+
 // HasNext documentation can be found in interfaces.go
 func (iter *CommonIterator) HasNext() bool {
 	if iter.currentLoc < len(iter.response.Results) || iter.response.HasMore {
@@ -534,9 +626,9 @@ func (iter *CommonIterator) HasNext() bool {
 	return false
 }
 
-// getResultsFromBytes deserializes QueryResult and return either a KV struct
-// or KeyModification depending on the result type (i.e., state (range/execute)
-// query, history query). Note that commonledger.QueryResult is an empty golang
+// getResultsFromBytes deserializes QueryResult and return either a KV struct,
+// KeyModification or KeyHashModification depending on the result type (i.e.,
+// state (range/execute) query, history query, private data hash history query). Note that commonledger.QueryResult is an empty golang
 // interface that can hold values of any type.
 func (iter *CommonIterator) getResultFromBytes(queryResultBytes *pb.QueryResultBytes,
 	rType resultType) (commonledger.QueryResult, error) {
@@ -554,6 +646,13 @@ func (iter *CommonIterator) getResultFromBytes(queryResultBytes *pb.QueryResultB
 			return nil, err
 		}
 		return historyQueryResult, nil
+
+	} else if rType == HASH_HISTORY_QUERY_RESULT {
+		hashHistoryQueryResult := &queryresult.KeyHashModification{}
+		if err := proto.Unmarshal(queryResultBytes.ResultBytes, hashHistoryQueryResult); err != nil {
+			return nil, err
+		}
+		return hashHistoryQueryResult, nil
 	}
 	return nil, errors.New("wrong result type")
 }
@@ -216,3 +216,40 @@ func TestChaincodeStubGetTxTimestamp(t *testing.T) {
 		gt.Expect(proto.Equal(ts, tt.ts)).To(BeTrue())
 	}
 }
+
+func TestCreateHistoryQueryMetadata(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	startTime := ptypes.TimestampNow()
+	tests := []struct {
+		options  *HistoryQueryOptions
+		pageSize int32
+		bookmark string
+		expected *pb.HistoryQueryMetadata
+	}{
+		{
+			expected: &pb.HistoryQueryMetadata{},
+		},
+		{
+			options:  &HistoryQueryOptions{StartBlock: 2, EndBlock: 5, StartTime: startTime, NewestFirst: true},
+			pageSize: 10,
+			bookmark: "bookmark",
+			expected: &pb.HistoryQueryMetadata{
+				StartBlock:  2,
+				EndBlock:    5,
+				StartTime:   startTime,
+				NewestFirst: true,
+				PageSize:    10,
+				Bookmark:    "bookmark",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		metadataBytes, err := createHistoryQueryMetadata(tt.options, tt.pageSize, tt.bookmark)
+		gt.Expect(err).NotTo(HaveOccurred())
+		metadata := &pb.HistoryQueryMetadata{}
+		gt.Expect(proto.Unmarshal(metadataBytes, metadata)).To(Succeed())
+		gt.Expect(proto.Equal(metadata, tt.expected)).To(BeTrue())
+	}
+}
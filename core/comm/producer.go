@@ -68,6 +68,9 @@ type ConnectionProducer interface {
 	UpdateEndpoints(endpoints []string)
 	// GetEndpoints return ordering service endpoints
 	GetEndpoints() []string
+	// DisableEndpoint makes the ConnectionProducer not connect to the given endpoint
+	// for the given duration, unless all of its endpoints are disabled
+	DisableEndpoint(endpoint string, duration time.Duration)
 }
 
 type connProducer struct {
@@ -75,6 +78,7 @@ type connProducer struct {
 	endpoints             []string
 	connect               ConnectionFactory
 	nextEndpointIndex     int
+	disabledEndpoints     map[string]time.Time
 	deliverClientDialOpts []grpc.DialOption
 	peerTLSEnabled        bool
 	connectionTimeout     time.Duration
@@ -95,6 +99,7 @@ func NewConnectionProducer(
 	return &connProducer{
 		endpoints:             shuffle(endpoints),
 		connect:               factory,
+		disabledEndpoints:     make(map[string]time.Time),
 		deliverClientDialOpts: deliverClientDialOpts,
 		peerTLSEnabled:        peerTLSEnabled,
 		connectionTimeout:     connectionTimeout,
@@ -110,10 +115,24 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 
 	logger.Debugf("Creating a new connection")
 
+	// The disabled endpoints are connected to only if all of the endpoints are disabled
+	now := time.Now()
+	allDisabled := true
+	for _, endpoint := range cp.endpoints {
+		if !cp.isDisabled(endpoint, now) {
+			allDisabled = false
+			break
+		}
+	}
+
 	for i := 0; i < len(cp.endpoints); i++ {
 		currentEndpoint := cp.endpoints[cp.nextEndpointIndex]
-		conn, err := cp.connect(currentEndpoint, cp.connectionTimeout)
 		cp.nextEndpointIndex = (cp.nextEndpointIndex + 1) % len(cp.endpoints)
+		if !allDisabled && cp.isDisabled(currentEndpoint, now) {
+			logger.Debugf("Skipping disabled endpoint %s", currentEndpoint)
+			continue
+		}
+		conn, err := cp.connect(currentEndpoint, cp.connectionTimeout)
 		if err != nil {
 			logger.Error("Failed connecting to", currentEndpoint, ", error:", err)
 			continue
@@ -141,6 +160,28 @@ func (cp *connProducer) UpdateEndpoints(endpoints []string) {
 	cp.endpoints = endpoints
 }
 
+// DisableEndpoint makes the ConnectionProducer not connect to the given endpoint
+// for the given duration, unless all of its endpoints are disabled
+func (cp *connProducer) DisableEndpoint(endpoint string, duration time.Duration) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	logger.Infof("Disabling endpoint %s for %s", endpoint, duration)
+	cp.disabledEndpoints[endpoint] = time.Now().Add(duration)
+}
+
+func (cp *connProducer) isDisabled(endpoint string, now time.Time) bool {
+	until, disabled := cp.disabledEndpoints[endpoint]
+	if !disabled {
+		return false
+	}
+	if !now.Before(until) {
+		delete(cp.disabledEndpoints, endpoint)
+		return false
+	}
+	return true
+}
+
 func shuffle(a []string) []string {
 	n := len(a)
 	returnedSlice := make([]string, n)
@@ -205,3 +205,38 @@ func defDeliverClientDialOpts() []grpc.DialOption {
 
 	return dialOpts
 }
+
+func TestDisableEndpoint(t *testing.T) {
+	t.Parallel()
+
+	connFactory := func(endpoint string, connectionTimeout time.Duration) (*grpc.ClientConn, error) {
+		return &grpc.ClientConn{}, nil
+	}
+	producer := NewConnectionProducer(connFactory, []string{"a", "b", "c"}, defDeliverClientDialOpts(), peerTLSEnabled, defaultConnectionTimeout)
+
+	// A disabled endpoint is not connected to
+	producer.DisableEndpoint("a", time.Minute)
+	for i := 0; i < 10; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.NotEqual(t, "a", endpoint)
+	}
+
+	// The disabled endpoints are connected to when all of the endpoints are disabled
+	producer.DisableEndpoint("b", time.Minute)
+	producer.DisableEndpoint("c", time.Minute)
+	_, _, err := producer.NewConnection()
+	assert.NoError(t, err)
+
+	// An endpoint is connected to again once it is no longer disabled
+	producer.DisableEndpoint("b", time.Millisecond)
+	producer.DisableEndpoint("c", time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	connectedEndpoints := make(map[string]struct{})
+	for i := 0; i < 10; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		connectedEndpoints[endpoint] = struct{}{}
+	}
+	assert.Equal(t, map[string]struct{}{"b": {}, "c": {}}, connectedEndpoints)
+}
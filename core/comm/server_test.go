@@ -11,11 +11,13 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net"
+	"os"
 	"path/filepath"
 	"sync/atomic"
 	"testing"
@@ -24,6 +26,8 @@ import (
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/comm/testpb"
+	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -963,6 +967,73 @@ func TestMutualAuth(t *testing.T) {
 	}
 }
 
+func TestMutualAuthED25519(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "comm-ed25519")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tlsCA, err := ca.NewCA(filepath.Join(dir, "tlsca"), "example.com", "tlsca.example.com", "", "", "", "", "", "", csp.ED25519)
+	assert.NoError(t, err)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsCA.SignCert.Raw})
+
+	// issues an Ed25519 TLS key pair, and returns it PEM encoded
+	newKeyPair := func(name string) (certPEM, keyPEM []byte) {
+		keyDir := filepath.Join(dir, name)
+		err := os.Mkdir(keyDir, 0755)
+		assert.NoError(t, err)
+		priv, err := csp.GeneratePrivateKey(keyDir, csp.ED25519)
+		assert.NoError(t, err)
+		cert, err := tlsCA.SignCertificate(keyDir, name, nil, []string{"127.0.0.1"}, priv.Public(),
+			x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth})
+		assert.NoError(t, err)
+		keyPEM, err = ioutil.ReadFile(filepath.Join(keyDir, "priv_sk"))
+		assert.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), keyPEM
+	}
+	serverCert, serverKey := newKeyPair("server")
+	clientCert, clientKey := newKeyPair("client")
+
+	srv, err := comm.NewGRPCServer("127.0.0.1:", comm.ServerConfig{
+		SecOpts: comm.SecureOptions{
+			UseTLS:            true,
+			RequireClientCert: true,
+			Certificate:       serverCert,
+			Key:               serverKey,
+			ClientRootCAs:     [][]byte{caPEM},
+		},
+	})
+	assert.NoError(t, err)
+	testpb.RegisterEmptyServiceServer(srv.Server(), &emptyServiceServer{})
+	go srv.Start()
+	defer srv.Stop()
+
+	client, err := comm.NewGRPCClient(comm.ClientConfig{
+		Timeout: testTimeout,
+		SecOpts: comm.SecureOptions{
+			UseTLS:            true,
+			RequireClientCert: true,
+			Certificate:       clientCert,
+			Key:               clientKey,
+			ServerRootCAs:     [][]byte{caPEM},
+		},
+	})
+	assert.NoError(t, err)
+
+	conn, err := client.NewConnection(srv.Address(), "")
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = testpb.NewEmptyServiceClient(conn).EmptyCall(context.Background(), &testpb.Empty{})
+	assert.NoError(t, err)
+
+	// a client without a certificate is rejected
+	certPool, err := createCertPool([][]byte{caPEM})
+	assert.NoError(t, err)
+	_, err = invokeEmptyCall(srv.Address(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: certPool})))
+	assert.Error(t, err)
+}
+
 func TestAppendWithInvalidBytes(t *testing.T) {
 	// TODO: revisit when msp serialization without PEM type is resolved
 	t.Skip()
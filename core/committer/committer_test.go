@@ -74,6 +74,16 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), args.Error(1)
 }
 
+func (m *mockLedger) GetTransactionsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	args := m.Called(namespace, bookmark, pageSize)
+	return args.Get(0).([]*peer.ProcessedTransaction), args.String(1), args.Error(2)
+}
+
+func (m *mockLedger) GetTransactionsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	args := m.Called(creator, bookmark, pageSize)
+	return args.Get(0).([]*peer.ProcessedTransaction), args.String(1), args.Error(2)
+}
+
 func (m *mockLedger) NewTxSimulator(txid string) (ledger2.TxSimulator, error) {
 	args := m.Called(txid)
 	return args.Get(0).(ledger2.TxSimulator), args.Error(1)
@@ -115,6 +125,22 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger2.MissingPvtDataTracker,
 	panic("implement me")
 }
 
+func (m *mockLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (m *mockLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	panic("implement me")
+}
+
+func (m *mockLedger) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger2.PurgedPvtKey, error) {
+	panic("implement me")
+}
+
 func createLedger(channelID string) (*common.Block, *mockLedger) {
 	gb, _ := test.MakeGenesisBlock(channelID)
 	ledger := &mockLedger{
@@ -1486,6 +1486,16 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), nil
 }
 
+func (m *mockLedger) GetTransactionsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	args := m.Called(namespace, bookmark, pageSize)
+	return args.Get(0).([]*peer.ProcessedTransaction), args.String(1), args.Error(2)
+}
+
+func (m *mockLedger) GetTransactionsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	args := m.Called(creator, bookmark, pageSize)
+	return args.Get(0).([]*peer.ProcessedTransaction), args.String(1), args.Error(2)
+}
+
 // NewTxSimulator creates new transaction simulator
 func (m *mockLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	args := m.Called()
@@ -1559,6 +1569,26 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, e
 	return args.Get(0).(ledger.MissingPvtDataTracker), nil
 }
 
+func (m *mockLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	args := m.Called(blockNumber)
+	return args.Error(0)
+}
+
+func (m *mockLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	args := m.Called(blockNumber)
+	return args.Error(0)
+}
+
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	args := m.Called()
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *mockLedger) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	args := m.Called(startBlockNum, endBlockNum)
+	return args.Get(0).([]*ledger.PurgedPvtKey), args.Error(1)
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
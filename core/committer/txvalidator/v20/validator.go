@@ -489,6 +489,14 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 			tIdx:           tIdx,
 			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
 		}
+	case ledger.TxDetailsNotAvailableErr:
+		// invalid case, the ledger was bootstrapped from a snapshot and a tx with the same id
+		// was committed before the snapshot was taken, only the id is known to the ledger
+		logger.Error("Duplicate transaction found, ", txID, ", skipping")
+		return &blockValidationResult{
+			tIdx:           tIdx,
+			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
+		}
 	case ledger.NotFoundInIndexErr:
 		// valid case, returned error is of type NotFoundInIndexErr.
 		// It means that no tx with the same id is found in the ledger
@@ -1123,6 +1123,34 @@ func TestDuplicateTxId(t *testing.T) {
 	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
 }
 
+func TestDuplicateTxIdInSnapshot(t *testing.T) {
+	ccID := "mycc"
+
+	v, _, _, _ := setupValidator()
+
+	mockLedger := &txvalidatormocks.LedgerResources{}
+	v.LedgerResources = mockLedger
+	mockLedger.On("GetTransactionByID", mock.Anything).Return(nil, ledger.TxDetailsNotAvailableErr(""))
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err := v.Validate(b)
+
+	assertion := assert.New(t)
+	// We expect no validation error because we simply mark the tx as invalid
+	assertion.NoError(err)
+
+	// We expect the tx to be invalid because the txid is present in the snapshot the ledger was bootstrapped from
+	txsfltr := ledgerutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assertion.True(txsfltr.IsInvalid(0))
+	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
+}
+
 func TestValidationInvalidEndorsing(t *testing.T) {
 	ccID := "mycc"
 
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+const implicitCollectionNamePrefix = "_implicit_org_"
+
+// ImplicitCollectionDisseminationPolicy specifies the dissemination policy of the private
+// data written to the implicit collection of the peer's own organization
+type ImplicitCollectionDisseminationPolicy struct {
+	// RequiredPeerCount is the minimum number of peers of the organization the private data
+	// is sent to upon endorsement
+	RequiredPeerCount int
+	// MaxPeerCount is the maximum number of peers of the organization the private data
+	// is sent to upon endorsement
+	MaxPeerCount int
+}
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the
+// organization with the given MSP ID
+func ImplicitCollectionNameForOrg(mspid string) string {
+	return implicitCollectionNamePrefix + mspid
+}
+
+// MspIDIfImplicitCollection returns true and the MSP ID of the organization owning the
+// collection if the given collection name is the name of an implicit collection
+func MspIDIfImplicitCollection(collectionName string) (isImplicitCollection bool, mspid string) {
+	if !strings.HasPrefix(collectionName, implicitCollectionNamePrefix) {
+		return false, ""
+	}
+	mspid = collectionName[len(implicitCollectionNamePrefix):]
+	if mspid == "" {
+		return false, ""
+	}
+	return true, mspid
+}
+
+// GenerateImplicitCollectionForOrg returns the config of the implicit collection of the
+// organization with the given MSP ID. The members of the collection are the members of
+// the organization and the private data of the collection never expires
+func GenerateImplicitCollectionForOrg(mspid string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspid),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspid),
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitCollectionNameForOrg(t *testing.T) {
+	assert.Equal(t, "_implicit_org_Org1MSP", ImplicitCollectionNameForOrg("Org1MSP"))
+}
+
+func TestMspIDIfImplicitCollection(t *testing.T) {
+	isImplicit, mspid := MspIDIfImplicitCollection("_implicit_org_Org1MSP")
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspid)
+
+	for _, collectionName := range []string{"coll1", "_implicit_org_", "_implicit_Org1MSP", "implicit_org_Org1MSP"} {
+		isImplicit, mspid = MspIDIfImplicitCollection(collectionName)
+		assert.False(t, isImplicit, collectionName)
+		assert.Equal(t, "", mspid, collectionName)
+	}
+}
+
+func TestGenerateImplicitCollectionForOrg(t *testing.T) {
+	collConfig := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.True(t, proto.Equal(&common.StaticCollectionConfig{
+		Name: "_implicit_org_Org1MSP",
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember("Org1MSP"),
+			},
+		},
+	}, collConfig))
+
+	isImplicit, mspid := MspIDIfImplicitCollection(collConfig.Name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspid)
+}
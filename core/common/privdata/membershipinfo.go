@@ -8,9 +8,11 @@ package privdata
 
 import (
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.privdata")
@@ -41,3 +43,64 @@ func (m *MembershipProvider) AmMemberOf(channelName string, collectionPolicyConf
 	}
 	return true, nil
 }
+
+// CollectionPolicyChecker checks whether an identity is a member of a collection
+// as the collection was configured when a given block was committed
+type CollectionPolicyChecker struct{}
+
+// CheckCollectionPolicy checks whether the signed data satisfies the member orgs policy
+// of the given collection, as it was defined below the given block number.
+// A collection that is not defined, or whose policy cannot be constructed, has no members.
+func (c *CollectionPolicyChecker) CheckCollectionPolicy(
+	blockNum uint64,
+	ccName string,
+	collName string,
+	cfgHistoryRetriever ledger.ConfigHistoryRetriever,
+	deserializer msp.IdentityDeserializer,
+	signedData *protoutil.SignedData,
+) (bool, error) {
+	collectionConfig, err := collectionConfigBelow(blockNum, ccName, collName, cfgHistoryRetriever)
+	if err != nil {
+		return false, err
+	}
+	if collectionConfig == nil {
+		logger.Debugf("Collection %s of chaincode %s is not defined below block %d", collName, ccName, blockNum)
+		return false, nil
+	}
+
+	accessPolicy, err := getPolicy(collectionConfig.MemberOrgsPolicy, deserializer)
+	if err != nil {
+		// drop the error and return false - same as reject all policy
+		logger.Errorf("Reject all due to error getting policy of collection %s of chaincode %s: %s", collName, ccName, err)
+		return false, nil
+	}
+	if err := accessPolicy.Evaluate([]*protoutil.SignedData{signedData}); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// collectionConfigBelow returns the config of the collection that was in effect
+// below the given block number, or nil if the collection was not defined
+func collectionConfigBelow(blockNum uint64, ccName, collName string, cfgHistoryRetriever ledger.ConfigHistoryRetriever) (*common.StaticCollectionConfig, error) {
+	// implicit collections are derived from the channel orgs and are
+	// therefore not recorded in the collection config history
+	if isImplicit, mspID := MspIDIfImplicitCollection(collName); isImplicit {
+		return GenerateImplicitCollectionForOrg(mspID), nil
+	}
+
+	configInfo, err := cfgHistoryRetriever.MostRecentCollectionConfigBelow(blockNum, ccName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed retrieving the collection config of chaincode %s below block %d", ccName, blockNum)
+	}
+	if configInfo == nil {
+		return nil, nil
+	}
+	for _, config := range configInfo.CollectionConfig.GetConfig() {
+		staticConfig := config.GetStaticCollectionConfig()
+		if staticConfig != nil && staticConfig.Name == collName {
+			return staticConfig, nil
+		}
+	}
+	return nil, nil
+}
@@ -10,9 +10,12 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +52,90 @@ func TestMembershipInfoProvider(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+type configHistoryRetriever struct {
+	configs map[string]*ledger.CollectionConfigInfo
+	err     error
+}
+
+func (c *configHistoryRetriever) CollectionConfigAt(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
+	panic("not implemented")
+}
+
+func (c *configHistoryRetriever) MostRecentCollectionConfigBelow(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
+	return c.configs[chaincodeName], c.err
+}
+
+func TestCollectionPolicyChecker(t *testing.T) {
+	retriever := &configHistoryRetriever{
+		configs: map[string]*ledger.CollectionConfigInfo{
+			"cc1": {
+				CollectionConfig: &common.CollectionConfigPackage{
+					Config: []*common.CollectionConfig{
+						{
+							Payload: &common.CollectionConfig_StaticCollectionConfig{
+								StaticCollectionConfig: &common.StaticCollectionConfig{
+									Name:             "coll1",
+									MemberOrgsPolicy: getAccessPolicy([]string{"peer0", "peer1"}),
+								},
+							},
+						},
+						{
+							Payload: &common.CollectionConfig_StaticCollectionConfig{
+								StaticCollectionConfig: &common.StaticCollectionConfig{
+									Name:             "badcoll",
+									MemberOrgsPolicy: getBadAccessPolicy([]string{"peer0"}, 1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	checker := &CollectionPolicyChecker{}
+	member := &protoutil.SignedData{Identity: []byte("peer0"), Data: []byte{4, 5, 6}, Signature: []byte{1, 2, 3}}
+	nonMember := &protoutil.SignedData{Identity: []byte("peer2"), Data: []byte{4, 5, 6}, Signature: []byte{1, 2, 3}}
+
+	// verify members of the collection pass the check
+	res, err := checker.CheckCollectionPolicy(10, "cc1", "coll1", retriever, &mockDeserializer{}, member)
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	// verify non members of the collection do not pass the check
+	res, err = checker.CheckCollectionPolicy(10, "cc1", "coll1", retriever, &mockDeserializer{}, nonMember)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	// verify collections that are not defined have no members
+	res, err = checker.CheckCollectionPolicy(10, "cc1", "coll2", retriever, &mockDeserializer{}, member)
+	assert.NoError(t, err)
+	assert.False(t, res)
+	res, err = checker.CheckCollectionPolicy(10, "cc2", "coll1", retriever, &mockDeserializer{}, member)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	// verify collections with an invalid policy have no members
+	res, err = checker.CheckCollectionPolicy(10, "cc1", "badcoll", retriever, &mockDeserializer{}, member)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	// verify the members of implicit collections are the members of the organization
+	orgMember := &protoutil.SignedData{
+		Identity: protoutil.MarshalOrPanic(&mb.MSPRole{Role: mb.MSPRole_MEMBER, MspIdentifier: "Org1MSP"}),
+	}
+	res, err = checker.CheckCollectionPolicy(10, "cc2", ImplicitCollectionNameForOrg("Org1MSP"), retriever, &mockDeserializer{}, orgMember)
+	assert.NoError(t, err)
+	assert.True(t, res)
+	res, err = checker.CheckCollectionPolicy(10, "cc2", ImplicitCollectionNameForOrg("Org2MSP"), retriever, &mockDeserializer{}, orgMember)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	// verify the config history retrieval error is returned
+	retriever.err = errors.New("ledger closed")
+	_, err = checker.CheckCollectionPolicy(10, "cc1", "coll1", retriever, &mockDeserializer{}, member)
+	assert.EqualError(t, err, "failed retrieving the collection config of chaincode cc1 below block 10: ledger closed")
+}
+
 func getAccessPolicy(signers []string) *common.CollectionPolicyConfig {
 	var data [][]byte
 	for _, signer := range signers {
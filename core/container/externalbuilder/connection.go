@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// DefaultDialTimeout is how long the peer waits for a connection
+// to a chaincode server that does not specify a dial timeout.
+const DefaultDialTimeout = 3 * time.Second
+
+// ServerConnectionFile is the path, relative to the release output directory,
+// of the file released for chaincode that runs as a server the peer connects to.
+var ServerConnectionFile = filepath.Join("chaincode", "server", "connection.json")
+
+// ChaincodeServerInfo describes how to connect to a chaincode server.
+type ChaincodeServerInfo struct {
+	Address string `json:"address"`
+	// DialTimeout is a duration string, such as "10s".
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	// ClientKey, ClientCert and RootCert are PEM encoded.
+	ClientKey  string `json:"client_key"`
+	ClientCert string `json:"client_cert"`
+	RootCert   string `json:"root_cert"`
+}
+
+// ReadServerInfo reads the chaincode server connection information released
+// to the given release output directory. It returns nil if none was released.
+func ReadServerInfo(releaseDir string) (*ChaincodeServerInfo, error) {
+	serverInfoBytes, err := ioutil.ReadFile(filepath.Join(releaseDir, ServerConnectionFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the chaincode server connection information")
+	}
+
+	serverInfo := &ChaincodeServerInfo{}
+	if err := json.Unmarshal(serverInfoBytes, serverInfo); err != nil {
+		return nil, errors.Wrap(err, "malformed chaincode server connection information")
+	}
+	if serverInfo.Address == "" {
+		return nil, errors.New("chaincode server address must be specified")
+	}
+	return serverInfo, nil
+}
+
+// ClientConfig returns the configuration of a gRPC client
+// for connecting to the chaincode server.
+func (s *ChaincodeServerInfo) ClientConfig() (comm.ClientConfig, error) {
+	config := comm.ClientConfig{
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: DefaultDialTimeout,
+	}
+	if s.DialTimeout != "" {
+		timeout, err := time.ParseDuration(s.DialTimeout)
+		if err != nil {
+			return comm.ClientConfig{}, errors.Wrapf(err, "malformed dial timeout %s", s.DialTimeout)
+		}
+		config.Timeout = timeout
+	}
+
+	if !s.TLSRequired {
+		return config, nil
+	}
+	if s.RootCert == "" {
+		return comm.ClientConfig{}, errors.New("root cert must be specified when TLS is required")
+	}
+	config.SecOpts = comm.SecureOptions{
+		UseTLS:        true,
+		ServerRootCAs: [][]byte{[]byte(s.RootCert)},
+	}
+	if s.ClientAuthRequired {
+		if s.ClientKey == "" || s.ClientCert == "" {
+			return comm.ClientConfig{}, errors.New("client key and cert must be specified when client auth is required")
+		}
+		config.SecOpts.RequireClientCert = true
+		config.SecOpts.Key = []byte(s.ClientKey)
+		config.SecOpts.Certificate = []byte(s.ClientCert)
+	}
+	return config, nil
+}
+
+// Connect connects to the chaincode server, and runs the chaincode
+// support handler over the stream until the instance is stopped.
+func (i *Instance) Connect(support ccintf.CCSupport) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.done != nil {
+		return errors.Errorf("chaincode %s is already running", i.PackageID)
+	}
+	if i.ServerInfo == nil {
+		return errors.Errorf("chaincode %s is not a chaincode server", i.PackageID)
+	}
+
+	config, err := i.ServerInfo.ClientConfig()
+	if err != nil {
+		return err
+	}
+	client, err := comm.NewGRPCClient(config)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the chaincode server client")
+	}
+	conn, err := client.NewConnection(i.ServerInfo.Address, "")
+	if err != nil {
+		return errors.WithMessagef(err, "could not connect to chaincode server at %s", i.ServerInfo.Address)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return errors.WithMessagef(err, "could not establish a stream with chaincode server at %s", i.ServerInfo.Address)
+	}
+
+	done := make(chan struct{})
+	i.done = done
+	i.cancel = cancel
+	go func() {
+		err := support.HandleChaincodeStream(stream)
+		cancel()
+		conn.Close()
+
+		i.mutex.Lock()
+		if !i.stopped {
+			i.err = err
+		}
+		i.mutex.Unlock()
+		close(done)
+	}()
+
+	return nil
+}
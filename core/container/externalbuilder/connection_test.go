@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testChaincode struct{}
+
+func (*testChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response   { return shim.Success(nil) }
+func (*testChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response { return shim.Success(nil) }
+
+// registeringSupport records the chaincode ID of the chaincode registering
+// on each stream, and serves the stream until it fails.
+type registeringSupport struct {
+	registered chan string
+}
+
+func (s *registeringSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	chaincodeID := &pb.ChaincodeID{}
+	if err := proto.Unmarshal(msg.Payload, chaincodeID); err != nil {
+		return err
+	}
+	s.registered <- chaincodeID.Name
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func startChaincodeServer(t *testing.T, tlsProps shim.TLSProperties) (*shim.ChaincodeServer, string) {
+	// reserve an address for the chaincode server
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	address := server.Address()
+	server.Listener().Close()
+
+	cs := &shim.ChaincodeServer{
+		CCID:     "mycc:1234",
+		Address:  address,
+		CC:       &testChaincode{},
+		TLSProps: tlsProps,
+	}
+	go cs.Start()
+	return cs, address
+}
+
+func writeServerInfo(t *testing.T, releaseDir string, serverInfo interface{}) {
+	path := filepath.Join(releaseDir, ServerConnectionFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	var serverInfoBytes []byte
+	switch si := serverInfo.(type) {
+	case string:
+		serverInfoBytes = []byte(si)
+	default:
+		var err error
+		serverInfoBytes, err = json.Marshal(si)
+		require.NoError(t, err)
+	}
+	require.NoError(t, ioutil.WriteFile(path, serverInfoBytes, 0600))
+}
+
+func TestReadServerInfo(t *testing.T) {
+	releaseDir, err := ioutil.TempDir("", "release")
+	require.NoError(t, err)
+	defer os.RemoveAll(releaseDir)
+
+	serverInfo, err := ReadServerInfo(releaseDir)
+	assert.NoError(t, err)
+	assert.Nil(t, serverInfo)
+
+	writeServerInfo(t, releaseDir, "{")
+	_, err = ReadServerInfo(releaseDir)
+	assert.EqualError(t, err, "malformed chaincode server connection information: unexpected end of JSON input")
+
+	writeServerInfo(t, releaseDir, &ChaincodeServerInfo{DialTimeout: "10s"})
+	_, err = ReadServerInfo(releaseDir)
+	assert.EqualError(t, err, "chaincode server address must be specified")
+
+	expected := &ChaincodeServerInfo{
+		Address:            "ccserver:9999",
+		DialTimeout:        "10s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		ClientKey:          "key",
+		ClientCert:         "cert",
+		RootCert:           "root",
+	}
+	writeServerInfo(t, releaseDir, expected)
+	serverInfo, err = ReadServerInfo(releaseDir)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, serverInfo)
+}
+
+func TestServerInfoClientConfig(t *testing.T) {
+	config, err := (&ChaincodeServerInfo{Address: "ccserver:9999"}).ClientConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, comm.ClientConfig{KaOpts: comm.DefaultKeepaliveOptions, Timeout: DefaultDialTimeout}, config)
+
+	config, err = (&ChaincodeServerInfo{
+		Address:            "ccserver:9999",
+		DialTimeout:        "10s",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		ClientKey:          "key",
+		ClientCert:         "cert",
+		RootCert:           "root",
+	}).ClientConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, comm.ClientConfig{
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: 10 * time.Second,
+		SecOpts: comm.SecureOptions{
+			UseTLS:            true,
+			RequireClientCert: true,
+			Key:               []byte("key"),
+			Certificate:       []byte("cert"),
+			ServerRootCAs:     [][]byte{[]byte("root")},
+		},
+	}, config)
+
+	_, err = (&ChaincodeServerInfo{DialTimeout: "forever"}).ClientConfig()
+	assert.EqualError(t, err, "malformed dial timeout forever: time: invalid duration \"forever\"")
+
+	_, err = (&ChaincodeServerInfo{TLSRequired: true}).ClientConfig()
+	assert.EqualError(t, err, "root cert must be specified when TLS is required")
+
+	_, err = (&ChaincodeServerInfo{TLSRequired: true, ClientAuthRequired: true, RootCert: "root"}).ClientConfig()
+	assert.EqualError(t, err, "client key and cert must be specified when client auth is required")
+}
+
+func TestInstanceConnect(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	cs, address := startChaincodeServer(t, shim.TLSProperties{
+		Key:           serverKeyPair.Key,
+		Cert:          serverKeyPair.Cert,
+		ClientCACerts: ca.CertBytes(),
+	})
+	defer cs.Stop()
+
+	instance := &Instance{
+		PackageID: "mycc:1234",
+		ServerInfo: &ChaincodeServerInfo{
+			Address:            address,
+			DialTimeout:        "5s",
+			TLSRequired:        true,
+			ClientAuthRequired: true,
+			ClientKey:          string(clientKeyPair.Key),
+			ClientCert:         string(clientKeyPair.Cert),
+			RootCert:           string(ca.CertBytes()),
+		},
+	}
+	support := &registeringSupport{registered: make(chan string, 1)}
+	err = instance.Connect(support)
+	require.NoError(t, err)
+
+	gt := NewGomegaWithT(t)
+	gt.Eventually(support.registered, 5*time.Second).Should(Receive(Equal("mycc:1234")))
+
+	err = instance.Connect(support)
+	assert.EqualError(t, err, "chaincode mycc:1234 is already running")
+
+	err = instance.Stop()
+	assert.NoError(t, err)
+	exitCode, err := instance.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+}
+
+func TestInstanceConnectFailure(t *testing.T) {
+	instance := &Instance{PackageID: "mycc:1234"}
+	err := instance.Connect(&registeringSupport{})
+	assert.EqualError(t, err, "chaincode mycc:1234 is not a chaincode server")
+
+	instance.ServerInfo = &ChaincodeServerInfo{Address: "127.0.0.1:0", DialTimeout: "forever"}
+	err = instance.Connect(&registeringSupport{})
+	assert.EqualError(t, err, "malformed dial timeout forever: time: invalid duration \"forever\"")
+
+	instance.ServerInfo = &ChaincodeServerInfo{Address: "127.0.0.1:0", DialTimeout: "100ms"}
+	err = instance.Connect(&registeringSupport{})
+	assert.Contains(t, err.Error(), "could not connect to chaincode server at 127.0.0.1:0")
+}
+
+func TestExternalVMChaincodeServer(t *testing.T) {
+	cs, address := startChaincodeServer(t, shim.TLSProperties{Disabled: true})
+	defer cs.Stop()
+
+	provider, fakeVM := newTestProvider()
+	support := &registeringSupport{registered: make(chan string, 1)}
+	provider.ChaincodeSupport = support
+	vm := provider.NewVM()
+	ccid := ccintf.CCID("mycc:1234")
+
+	serverInfo, err := json.Marshal(&ChaincodeServerInfo{Address: address, DialTimeout: "5s"})
+	require.NoError(t, err)
+	builder := &container.PlatformBuilder{
+		Type:        "GOLANG",
+		CodePackage: codePackage(t, map[string]string{"connection.json": string(serverInfo)}),
+	}
+	err = vm.Start(ccid, nil, nil, nil, builder)
+	require.NoError(t, err)
+	assert.Equal(t, 0, fakeVM.StartCallCount())
+
+	gt := NewGomegaWithT(t)
+	gt.Eventually(support.registered, 5*time.Second).Should(Receive(Equal("mycc:1234")))
+
+	instance := provider.getInstance(ccid)
+	require.NotNil(t, instance)
+	assert.Equal(t, address, instance.ServerInfo.Address)
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	_, err = instance.Wait()
+	assert.NoError(t, err)
+}
+
+func TestDetectorBuildBadServerInfo(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"connection.json": "{}"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	assert.EqualError(t, err, "builder 'good' released invalid chaincode server information for mycc:1234: chaincode server address must be specified")
+	assert.Nil(t, instance)
+}
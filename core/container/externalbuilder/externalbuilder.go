@@ -0,0 +1,480 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MetadataFile is the name of the file, in the chaincode metadata
+	// directory, that describes the chaincode package to the builders.
+	MetadataFile = "metadata.json"
+
+	// RunConfigFile is the name of the file, in the run metadata
+	// directory, that holds what the chaincode needs to reach the peer.
+	RunConfigFile = "chaincode.json"
+
+	// DefaultStopTimeout is how long a running chaincode is given to
+	// exit after it is asked to terminate, before it is killed.
+	DefaultStopTimeout = 5 * time.Second
+)
+
+// DefaultEnvironmentWhitelist lists the environment variables of the peer
+// that are passed to every builder, on top of its own whitelist.
+var DefaultEnvironmentWhitelist = []string{"LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+var logger = flogging.MustGetLogger("externalbuilder")
+
+// ChaincodeMetadata describes a chaincode package to the builders.
+// It is written to the MetadataFile of the chaincode metadata directory.
+type ChaincodeMetadata struct {
+	PackageID string `json:"package_id"`
+	Type      string `json:"type"`
+	Path      string `json:"path"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+}
+
+// RunConfig holds what a chaincode needs in order to connect to the peer.
+// It is written to the RunConfigFile of the run metadata directory.
+type RunConfig struct {
+	ChaincodeID string   `json:"chaincode_id"`
+	PeerAddress string   `json:"peer_address"`
+	Args        []string `json:"args"`
+	Env         []string `json:"env"`
+	// Files maps the paths the chaincode expects its files at, such as its TLS
+	// key and certificates, to the paths of the files in the run metadata directory.
+	Files map[string]string `json:"files"`
+}
+
+// Builder is an external chaincode builder. Its location is a directory
+// with a bin directory holding the detect, build and run executables,
+// and optionally a release executable.
+type Builder struct {
+	Name                 string
+	Location             string
+	EnvironmentWhitelist []string
+	Logger               *flogging.FabricLogger
+}
+
+// NewBuilder creates a builder with the given name, located at the given directory.
+func NewBuilder(name, location string, environmentWhitelist []string) *Builder {
+	if name == "" {
+		name = filepath.Base(location)
+	}
+	return &Builder{
+		Name:                 name,
+		Location:             location,
+		EnvironmentWhitelist: environmentWhitelist,
+		Logger:               logger.With("builder", name),
+	}
+}
+
+// Detect returns true if the builder claims the chaincode package
+// held by the given build context.
+func (b *Builder) Detect(bc *BuildContext) bool {
+	detect := filepath.Join(b.Location, "bin", "detect")
+	cmd := b.newCommand(detect, bc.SourceDir, bc.MetadataDir)
+	if err := b.runCommand(cmd); err != nil {
+		b.Logger.Debugf("detect did not claim package %s: %s", bc.PackageID, err)
+		return false
+	}
+	return true
+}
+
+// Build builds the chaincode package held by the given build context
+// into the build output directory of the context.
+func (b *Builder) Build(bc *BuildContext) error {
+	build := filepath.Join(b.Location, "bin", "build")
+	cmd := b.newCommand(build, bc.SourceDir, bc.MetadataDir, bc.BuildDir)
+	if err := b.runCommand(cmd); err != nil {
+		return errors.WithMessagef(err, "builder '%s' failed to build %s", b.Name, bc.PackageID)
+	}
+	return nil
+}
+
+// Release copies the release artifacts of the build, such as CouchDB indexes,
+// into the release output directory of the context. Builders without a release
+// executable do not release anything.
+func (b *Builder) Release(bc *BuildContext) error {
+	release := filepath.Join(b.Location, "bin", "release")
+	if _, err := os.Stat(release); os.IsNotExist(err) {
+		b.Logger.Debugf("builder has no release executable, skipping release of %s", bc.PackageID)
+		return nil
+	}
+	cmd := b.newCommand(release, bc.BuildDir, bc.ReleaseDir)
+	if err := b.runCommand(cmd); err != nil {
+		return errors.WithMessagef(err, "builder '%s' failed to release %s", b.Name, bc.PackageID)
+	}
+	return nil
+}
+
+// Run starts the chaincode built in the given build output directory.
+// The returned command is started, and it is up to the caller to wait for it.
+func (b *Builder) Run(buildDir, runDir string) (*exec.Cmd, error) {
+	run := filepath.Join(b.Location, "bin", "run")
+	cmd := b.newCommand(run, buildDir, runDir)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to capture the output of the chaincode")
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "builder '%s' failed to run the chaincode", b.Name)
+	}
+	go b.logOutput(stderr)
+	return cmd, nil
+}
+
+func (b *Builder) newCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	whitelist := append(append([]string{}, DefaultEnvironmentWhitelist...), b.EnvironmentWhitelist...)
+	for _, key := range whitelist {
+		if value, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+	return cmd
+}
+
+// runCommand runs the command, logging what it writes to its standard error.
+func (b *Builder) runCommand(cmd *exec.Cmd) error {
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	b.logOutput(stderr)
+	return cmd.Wait()
+}
+
+func (b *Builder) logOutput(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		b.Logger.Info(scanner.Text())
+	}
+}
+
+// BuildContext holds the directories a chaincode package is built in.
+type BuildContext struct {
+	PackageID   string
+	ScratchDir  string
+	SourceDir   string
+	MetadataDir string
+	BuildDir    string
+	ReleaseDir  string
+	RunDir      string
+}
+
+// NewBuildContext creates the directories for building the given chaincode
+// package, and extracts the code package into the source directory.
+func NewBuildContext(metadata *ChaincodeMetadata, codePackage []byte) (bc *BuildContext, err error) {
+	scratchDir, err := ioutil.TempDir("", "fabric-"+sanitize(metadata.PackageID))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the scratch directory")
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(scratchDir)
+		}
+	}()
+
+	bc = &BuildContext{
+		PackageID:   metadata.PackageID,
+		ScratchDir:  scratchDir,
+		SourceDir:   filepath.Join(scratchDir, "src"),
+		MetadataDir: filepath.Join(scratchDir, "metadata"),
+		BuildDir:    filepath.Join(scratchDir, "bld"),
+		ReleaseDir:  filepath.Join(scratchDir, "release"),
+		RunDir:      filepath.Join(scratchDir, "run"),
+	}
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BuildDir, bc.ReleaseDir, bc.RunDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return nil, errors.Wrapf(err, "could not create directory %s", dir)
+		}
+	}
+
+	if err := Untar(bytes.NewReader(codePackage), bc.SourceDir); err != nil {
+		return nil, errors.WithMessage(err, "could not extract the code package")
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the chaincode metadata")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.MetadataDir, MetadataFile), metadataBytes, 0600); err != nil {
+		return nil, errors.Wrap(err, "could not write the chaincode metadata")
+	}
+
+	return bc, nil
+}
+
+// Cleanup removes the directories of the build context.
+func (bc *BuildContext) Cleanup() {
+	os.RemoveAll(bc.ScratchDir)
+}
+
+// Detector builds chaincode packages with the first of
+// its builders that claims them.
+type Detector struct {
+	Builders []*Builder
+}
+
+// Build builds the chaincode package with the first builder that claims it.
+// It returns a nil instance if no builder claims the package.
+func (d *Detector) Build(metadata *ChaincodeMetadata, codePackage []byte) (*Instance, error) {
+	if len(d.Builders) == 0 {
+		return nil, nil
+	}
+
+	bc, err := NewBuildContext(metadata, codePackage)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := d.detect(bc)
+	if builder == nil {
+		logger.Debugf("no external builder claimed package %s", metadata.PackageID)
+		bc.Cleanup()
+		return nil, nil
+	}
+
+	if err := builder.Build(bc); err != nil {
+		bc.Cleanup()
+		return nil, err
+	}
+	if err := builder.Release(bc); err != nil {
+		bc.Cleanup()
+		return nil, err
+	}
+	serverInfo, err := ReadServerInfo(bc.ReleaseDir)
+	if err != nil {
+		bc.Cleanup()
+		return nil, errors.WithMessagef(err, "builder '%s' released invalid chaincode server information for %s", builder.Name, metadata.PackageID)
+	}
+
+	return &Instance{
+		PackageID:    metadata.PackageID,
+		Builder:      builder,
+		BuildContext: bc,
+		ServerInfo:   serverInfo,
+		StopTimeout:  DefaultStopTimeout,
+	}, nil
+}
+
+func (d *Detector) detect(bc *BuildContext) *Builder {
+	for _, builder := range d.Builders {
+		if builder.Detect(bc) {
+			logger.Debugf("builder '%s' claimed package %s", builder.Name, bc.PackageID)
+			return builder
+		}
+	}
+	return nil
+}
+
+// Instance is a chaincode package built by an external builder. The chaincode
+// is either run by the builder, or, if the builder released chaincode server
+// information, it runs as a server the peer connects to.
+type Instance struct {
+	PackageID    string
+	Builder      *Builder
+	BuildContext *BuildContext
+	ServerInfo   *ChaincodeServerInfo
+	StopTimeout  time.Duration
+
+	mutex    sync.Mutex
+	cmd      *exec.Cmd
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopped  bool
+	exitCode int
+	err      error
+}
+
+// Start writes the run configuration and the files of the chaincode
+// to the run metadata directory, and starts the chaincode.
+func (i *Instance) Start(config *RunConfig, files map[string][]byte) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.done != nil {
+		return errors.Errorf("chaincode %s is already running", i.PackageID)
+	}
+
+	runDir := i.BuildContext.RunDir
+	config.Files = map[string]string{}
+	for path, contents := range files {
+		target := filepath.Join(runDir, "files", filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return errors.Wrapf(err, "could not create the directory of %s", path)
+		}
+		if err := ioutil.WriteFile(target, contents, 0600); err != nil {
+			return errors.Wrapf(err, "could not write %s", path)
+		}
+		config.Files[path] = target
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the run configuration")
+	}
+	if err := ioutil.WriteFile(filepath.Join(runDir, RunConfigFile), configBytes, 0600); err != nil {
+		return errors.Wrap(err, "could not write the run configuration")
+	}
+
+	cmd, err := i.Builder.Run(i.BuildContext.BuildDir, runDir)
+	if err != nil {
+		return err
+	}
+	i.cmd = cmd
+	i.done = make(chan struct{})
+	go i.wait(cmd, i.done)
+
+	return nil
+}
+
+func (i *Instance) wait(cmd *exec.Cmd, done chan struct{}) {
+	err := cmd.Wait()
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+		err = nil
+	}
+
+	i.mutex.Lock()
+	i.exitCode, i.err = exitCode, err
+	i.mutex.Unlock()
+	close(done)
+}
+
+// Stop disconnects from a chaincode server, or asks the chaincode
+// to terminate and kills it if it does not exit within the stop timeout.
+func (i *Instance) Stop() error {
+	i.mutex.Lock()
+	cmd, cancel, done := i.cmd, i.cancel, i.done
+	i.stopped = true
+	i.mutex.Unlock()
+	if done == nil {
+		return errors.Errorf("chaincode %s is not running", i.PackageID)
+	}
+
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	if cancel != nil {
+		cancel()
+		<-done
+		return nil
+	}
+
+	cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-done:
+		return nil
+	case <-time.After(i.StopTimeout):
+	}
+
+	if err := cmd.Process.Kill(); err != nil {
+		return errors.Wrapf(err, "could not kill chaincode %s", i.PackageID)
+	}
+	<-done
+	return nil
+}
+
+// Wait waits for the chaincode to exit, and returns its exit code.
+func (i *Instance) Wait() (int, error) {
+	i.mutex.Lock()
+	done := i.done
+	i.mutex.Unlock()
+	if done == nil {
+		return -1, errors.Errorf("chaincode %s is not running", i.PackageID)
+	}
+
+	<-done
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.exitCode, i.err
+}
+
+// Untar extracts a gzipped tar stream into the given directory,
+// refusing entries that would land outside of it.
+func Untar(r io.Reader, dir string) error {
+	gzReader, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "could not create gzip reader")
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read the tar stream")
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return errors.Errorf("illegal file path %s in the tar stream", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return errors.Wrapf(err, "could not create directory %s", header.Name)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return errors.Wrapf(err, "could not create the directory of %s", header.Name)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode)&0700|0600)
+			if err != nil {
+				return errors.Wrapf(err, "could not create file %s", header.Name)
+			}
+			_, err = io.Copy(f, tarReader)
+			f.Close()
+			if err != nil {
+				return errors.Wrapf(err, "could not write file %s", header.Name)
+			}
+		default:
+			logger.Debugf("skipping entry %s of unsupported type %v", header.Name, header.Typeflag)
+		}
+	}
+}
+
+func sanitize(packageID string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':':
+			return '-'
+		}
+		return r
+	}, packageID)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(contents)),
+			Mode:     0600,
+		})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func testMetadata(ccType string) *ChaincodeMetadata {
+	return &ChaincodeMetadata{
+		PackageID: "mycc:1234",
+		Type:      ccType,
+		Path:      "github.com/example/mycc",
+		Name:      "mycc",
+		Version:   "1.0",
+	}
+}
+
+func testBuilders() []*Builder {
+	return []*Builder{
+		NewBuilder("fail", "testdata/failbuilder", nil),
+		NewBuilder("good", "testdata/goodbuilder", nil),
+		NewBuilder("broken", "testdata/brokenbuilder", nil),
+	}
+}
+
+func TestNewBuildContext(t *testing.T) {
+	pkg := codePackage(t, map[string]string{"src/main.go": "package main"})
+	bc, err := NewBuildContext(testMetadata("GOLANG"), pkg)
+	require.NoError(t, err)
+	defer bc.Cleanup()
+
+	contents, err := ioutil.ReadFile(filepath.Join(bc.SourceDir, "src", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main", string(contents))
+
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(bc.MetadataDir, MetadataFile))
+	require.NoError(t, err)
+	metadata := &ChaincodeMetadata{}
+	require.NoError(t, json.Unmarshal(metadataBytes, metadata))
+	assert.Equal(t, testMetadata("GOLANG"), metadata)
+
+	for _, dir := range []string{bc.BuildDir, bc.ReleaseDir, bc.RunDir} {
+		assert.DirExists(t, dir)
+	}
+
+	bc.Cleanup()
+	_, err = os.Stat(bc.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewBuildContextBadPackage(t *testing.T) {
+	_, err := NewBuildContext(testMetadata("GOLANG"), []byte("garbage"))
+	assert.EqualError(t, err, "could not extract the code package: could not create gzip reader: unexpected EOF")
+}
+
+func TestUntarIllegalPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pkg := codePackage(t, map[string]string{"../escape": "boo"})
+	err = Untar(bytes.NewReader(pkg), dir)
+	assert.EqualError(t, err, "illegal file path ../escape in the tar stream")
+}
+
+func TestDetectorBuild(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"main.go": "package main"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	require.NoError(t, err)
+	require.NotNil(t, instance)
+	defer instance.BuildContext.Cleanup()
+
+	assert.Equal(t, "good", instance.Builder.Name)
+	assert.Equal(t, "mycc:1234", instance.PackageID)
+	assert.FileExists(t, filepath.Join(instance.BuildContext.BuildDir, "main.go"))
+	assert.FileExists(t, filepath.Join(instance.BuildContext.BuildDir, "metadata.json"))
+	assert.FileExists(t, filepath.Join(instance.BuildContext.ReleaseDir, "released"))
+}
+
+func TestDetectorBuildFailure(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"main.go": "package main"})
+
+	instance, err := detector.Build(testMetadata("NODE"), pkg)
+	assert.EqualError(t, err, "builder 'broken' failed to build mycc:1234: exit status 3")
+	assert.Nil(t, instance)
+}
+
+func TestDetectorNoBuilderClaims(t *testing.T) {
+	detector := &Detector{Builders: []*Builder{NewBuilder("", "testdata/failbuilder", nil)}}
+	pkg := codePackage(t, map[string]string{"main.go": "package main"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	assert.NoError(t, err)
+	assert.Nil(t, instance)
+	assert.Equal(t, "failbuilder", detector.Builders[0].Name)
+
+	instance, err = (&Detector{}).Build(testMetadata("GOLANG"), pkg)
+	assert.NoError(t, err)
+	assert.Nil(t, instance)
+}
+
+func TestInstanceStartStop(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"main.go": "package main"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	require.NoError(t, err)
+	defer instance.BuildContext.Cleanup()
+
+	_, err = instance.Wait()
+	assert.EqualError(t, err, "chaincode mycc:1234 is not running")
+	err = instance.Stop()
+	assert.EqualError(t, err, "chaincode mycc:1234 is not running")
+
+	err = instance.Start(&RunConfig{
+		ChaincodeID: "mycc:1234",
+		PeerAddress: "peer0:7052",
+		Args:        []string{"chaincode"},
+		Env:         []string{"CORE_PEER_TLS_ENABLED=true"},
+	}, map[string][]byte{"/etc/hyperledger/fabric/client.crt": []byte("cert")})
+	require.NoError(t, err)
+
+	err = instance.Start(&RunConfig{}, nil)
+	assert.EqualError(t, err, "chaincode mycc:1234 is already running")
+
+	certPath := filepath.Join(instance.BuildContext.RunDir, "files", "etc", "hyperledger", "fabric", "client.crt")
+	runConfig := &RunConfig{}
+	gt := NewGomegaWithT(t)
+	gt.Eventually(func() error {
+		configBytes, err := ioutil.ReadFile(filepath.Join(instance.BuildContext.BuildDir, RunConfigFile))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(configBytes, runConfig)
+	}, 5*time.Second).Should(Succeed())
+	assert.Equal(t, &RunConfig{
+		ChaincodeID: "mycc:1234",
+		PeerAddress: "peer0:7052",
+		Args:        []string{"chaincode"},
+		Env:         []string{"CORE_PEER_TLS_ENABLED=true"},
+		Files:       map[string]string{"/etc/hyperledger/fabric/client.crt": certPath},
+	}, runConfig)
+	contents, err := ioutil.ReadFile(certPath)
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(contents))
+
+	err = instance.Stop()
+	assert.NoError(t, err)
+	exitCode, err := instance.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, -1, exitCode)
+}
+
+func TestInstanceKilledAfterTimeout(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"main.go": "package main"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	require.NoError(t, err)
+	defer instance.BuildContext.Cleanup()
+
+	instance.StopTimeout = 0
+	err = instance.Start(&RunConfig{}, nil)
+	require.NoError(t, err)
+
+	err = instance.Stop()
+	assert.NoError(t, err)
+	_, err = instance.Wait()
+	assert.NoError(t, err)
+}
+
+func TestInstanceExitCode(t *testing.T) {
+	detector := &Detector{Builders: testBuilders()}
+	pkg := codePackage(t, map[string]string{"exit_code": "7"})
+
+	instance, err := detector.Build(testMetadata("GOLANG"), pkg)
+	require.NoError(t, err)
+	defer instance.BuildContext.Cleanup()
+
+	err = instance.Start(&RunConfig{}, nil)
+	require.NoError(t, err)
+
+	exitCode, err := instance.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, exitCode)
+
+	err = instance.Stop()
+	assert.NoError(t, err)
+}
+
+func TestBuilderEnvironmentWhitelist(t *testing.T) {
+	os.Setenv("EXTERNAL_BUILDER_TEST_VAR", "value")
+	defer os.Unsetenv("EXTERNAL_BUILDER_TEST_VAR")
+
+	builder := NewBuilder("good", "testdata/goodbuilder", []string{"EXTERNAL_BUILDER_TEST_VAR", "UNSET_VAR"})
+	cmd := builder.newCommand("true")
+	assert.Contains(t, cmd.Env, "EXTERNAL_BUILDER_TEST_VAR=value")
+	for _, env := range cmd.Env {
+		assert.NotContains(t, env, "UNSET_VAR")
+	}
+
+	builder = NewBuilder("good", "testdata/goodbuilder", nil)
+	cmd = builder.newCommand("true")
+	assert.NotContains(t, cmd.Env, "EXTERNAL_BUILDER_TEST_VAR=value")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+)
+
+// Provider implements container.VMProvider. It builds and runs chaincode
+// with the first external builder that claims its package, and hands the
+// chaincode that no builder claims to the fallback provider.
+//
+// The ChaincodeSupport must be set before chaincode that runs as a server
+// is started, as it handles the streams of the connections to such chaincode.
+type Provider struct {
+	Detector         *Detector
+	PeerAddress      string
+	Fallback         container.VMProvider
+	ChaincodeSupport ccintf.CCSupport
+
+	mutex     sync.Mutex
+	instances map[ccintf.CCID]*Instance
+}
+
+// NewProvider creates a provider that tries the given builders in order,
+// before falling back to the given provider.
+func NewProvider(builders []*Builder, peerAddress string, fallback container.VMProvider) *Provider {
+	return &Provider{
+		Detector:    &Detector{Builders: builders},
+		PeerAddress: peerAddress,
+		Fallback:    fallback,
+		instances:   make(map[ccintf.CCID]*Instance),
+	}
+}
+
+// NewVM creates an ExternalVM instance
+func (p *Provider) NewVM() container.VM {
+	return &ExternalVM{
+		provider: p,
+		fallback: p.Fallback.NewVM(),
+	}
+}
+
+func (p *Provider) getInstance(ccid ccintf.CCID) *Instance {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.instances[ccid]
+}
+
+func (p *Provider) setInstance(ccid ccintf.CCID, instance *Instance) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.instances[ccid] = instance
+}
+
+func (p *Provider) removeInstance(ccid ccintf.CCID) *Instance {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	instance := p.instances[ccid]
+	delete(p.instances, ccid)
+	return instance
+}
+
+// ExternalVM is a vm. Chaincode built by an external builder runs as a
+// process launched by the builder, or as a server the peer connects to.
+type ExternalVM struct {
+	provider *Provider
+	fallback container.VM
+}
+
+// Start builds and runs the chaincode with an external builder, or
+// with the fallback VM if no external builder claims its package.
+func (vm *ExternalVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	pb, ok := builder.(*container.PlatformBuilder)
+	if !ok {
+		return vm.fallback.Start(ccid, args, env, filesToUpload, builder)
+	}
+
+	if instance := vm.provider.removeInstance(ccid); instance != nil {
+		logger.Debugf("stopping running instance of %s before starting it", ccid)
+		vm.stopInstance(instance)
+	}
+
+	instance, err := vm.provider.Detector.Build(&ChaincodeMetadata{
+		PackageID: ccid.String(),
+		Type:      pb.Type,
+		Path:      pb.Path,
+		Name:      pb.Name,
+		Version:   pb.Version,
+	}, pb.CodePackage)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return vm.fallback.Start(ccid, args, env, filesToUpload, builder)
+	}
+
+	if instance.ServerInfo != nil {
+		logger.Debugf("connecting to chaincode server of %s at %s", ccid, instance.ServerInfo.Address)
+		err = instance.Connect(vm.provider.ChaincodeSupport)
+	} else {
+		err = instance.Start(&RunConfig{
+			ChaincodeID: ccid.String(),
+			PeerAddress: vm.provider.PeerAddress,
+			Args:        args,
+			Env:         env,
+		}, filesToUpload)
+	}
+	if err != nil {
+		instance.BuildContext.Cleanup()
+		return err
+	}
+
+	vm.provider.setInstance(ccid, instance)
+	return nil
+}
+
+// Stop stops the chaincode, and removes its build output.
+func (vm *ExternalVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	instance := vm.provider.removeInstance(ccid)
+	if instance == nil {
+		return vm.fallback.Stop(ccid, timeout, dontkill, dontremove)
+	}
+	return vm.stopInstance(instance)
+}
+
+func (vm *ExternalVM) stopInstance(instance *Instance) error {
+	defer instance.BuildContext.Cleanup()
+	return instance.Stop()
+}
+
+// Wait blocks until the chaincode exits, and returns its exit code.
+func (vm *ExternalVM) Wait(ccid ccintf.CCID) (int, error) {
+	instance := vm.provider.getInstance(ccid)
+	if instance == nil {
+		return vm.fallback.Wait(ccid)
+	}
+	return instance.Wait()
+}
+
+// HealthCheck checks the health of the fallback VM.
+func (vm *ExternalVM) HealthCheck(ctx context.Context) error {
+	return vm.fallback.HealthCheck(ctx)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider() (*Provider, *mock.VM) {
+	fakeVM := &mock.VM{}
+	fakeProvider := &mock.VMProvider{}
+	fakeProvider.NewVMReturns(fakeVM)
+	return NewProvider(testBuilders(), "peer0:7052", fakeProvider), fakeVM
+}
+
+func TestExternalVMStartStop(t *testing.T) {
+	provider, fakeVM := newTestProvider()
+	vm := provider.NewVM()
+	ccid := ccintf.CCID("mycc:1234")
+
+	builder := &container.PlatformBuilder{
+		Type:        "GOLANG",
+		Path:        "github.com/example/mycc",
+		Name:        "mycc",
+		Version:     "1.0",
+		CodePackage: codePackage(t, map[string]string{"main.go": "package main"}),
+	}
+	err := vm.Start(ccid, []string{"chaincode"}, nil, nil, builder)
+	require.NoError(t, err)
+	assert.Equal(t, 0, fakeVM.StartCallCount())
+
+	instance := provider.getInstance(ccid)
+	require.NotNil(t, instance)
+	assert.Equal(t, "good", instance.Builder.Name)
+
+	// starting again replaces the running instance
+	err = vm.Start(ccid, []string{"chaincode"}, nil, nil, builder)
+	require.NoError(t, err)
+	_, err = os.Stat(instance.BuildContext.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+	instance = provider.getInstance(ccid)
+	require.NotNil(t, instance)
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fakeVM.StopCallCount())
+	assert.Nil(t, provider.getInstance(ccid))
+	_, err = os.Stat(instance.BuildContext.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+
+	exitCode, err := instance.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, -1, exitCode)
+}
+
+func TestExternalVMWait(t *testing.T) {
+	provider, fakeVM := newTestProvider()
+	vm := provider.NewVM()
+	ccid := ccintf.CCID("mycc:1234")
+
+	builder := &container.PlatformBuilder{
+		Type:        "GOLANG",
+		CodePackage: codePackage(t, map[string]string{"exit_code": "2"}),
+	}
+	err := vm.Start(ccid, nil, nil, nil, builder)
+	require.NoError(t, err)
+
+	exitCode, err := vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+	assert.Equal(t, 0, fakeVM.WaitCallCount())
+
+	err = vm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+}
+
+func TestExternalVMFallback(t *testing.T) {
+	provider, fakeVM := newTestProvider()
+	provider.Detector.Builders = provider.Detector.Builders[:1]
+	vm := provider.NewVM()
+	ccid := ccintf.CCID("mycc:1234")
+
+	builder := &container.PlatformBuilder{
+		Type:        "GOLANG",
+		CodePackage: codePackage(t, map[string]string{"main.go": "package main"}),
+	}
+	err := vm.Start(ccid, []string{"chaincode"}, []string{"ENV=1"}, nil, builder)
+	assert.NoError(t, err)
+	require.Equal(t, 1, fakeVM.StartCallCount())
+	startCCID, args, env, _, startBuilder := fakeVM.StartArgsForCall(0)
+	assert.Equal(t, ccid, startCCID)
+	assert.Equal(t, []string{"chaincode"}, args)
+	assert.Equal(t, []string{"ENV=1"}, env)
+	assert.Equal(t, builder, startBuilder)
+	assert.Nil(t, provider.getInstance(ccid))
+
+	fakeVM.WaitReturns(3, nil)
+	exitCode, err := vm.Wait(ccid)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+
+	fakeVM.StopReturns(errors.New("stop-error"))
+	err = vm.Stop(ccid, 10, true, true)
+	assert.EqualError(t, err, "stop-error")
+	require.Equal(t, 1, fakeVM.StopCallCount())
+	stopCCID, timeout, dontkill, dontremove := fakeVM.StopArgsForCall(0)
+	assert.Equal(t, ccid, stopCCID)
+	assert.Equal(t, uint(10), timeout)
+	assert.True(t, dontkill)
+	assert.True(t, dontremove)
+
+	fakeVM.HealthCheckReturns(errors.New("unhealthy"))
+	assert.EqualError(t, vm.HealthCheck(context.Background()), "unhealthy")
+}
+
+func TestExternalVMOtherBuilder(t *testing.T) {
+	provider, fakeVM := newTestProvider()
+	vm := provider.NewVM()
+
+	err := vm.Start(ccintf.CCID("mycc:1234"), nil, nil, nil, &mock.Builder{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeVM.StartCallCount())
+}
+
+func TestExternalVMBuildFailure(t *testing.T) {
+	provider, fakeVM := newTestProvider()
+	vm := provider.NewVM()
+
+	builder := &container.PlatformBuilder{
+		Type:        "NODE",
+		CodePackage: codePackage(t, map[string]string{"main.go": "package main"}),
+	}
+	err := vm.Start(ccintf.CCID("mycc:1234"), nil, nil, nil, builder)
+	assert.EqualError(t, err, "builder 'broken' failed to build mycc:1234: exit status 3")
+	assert.Equal(t, 0, fakeVM.StartCallCount())
+}
@@ -258,6 +258,27 @@ func (bc *broadcastClient) Disconnect() {
 	bc.blocksDeliverer = nil
 }
 
+// DisconnectFrom makes the client close the existing connection if it is to the given endpoint,
+// and makes the endpoint unavailable for the given duration
+func (bc *broadcastClient) DisconnectFrom(endpoint string, duration time.Duration) {
+	if endpoint == "" {
+		bc.Disconnect()
+		return
+	}
+	bc.prod.DisableEndpoint(endpoint, duration)
+	if bc.connectedEndpoint() == endpoint {
+		bc.Disconnect()
+	}
+}
+
+// connectedEndpoint returns the endpoint the client is connected to,
+// or an empty string if it is not connected
+func (bc *broadcastClient) connectedEndpoint() string {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+	return bc.endpoint
+}
+
 // UpdateEndpoints update endpoints to new values
 func (bc *broadcastClient) UpdateEndpoints(endpoints []string) {
 	bc.prod.UpdateEndpoints(endpoints)
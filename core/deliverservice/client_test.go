@@ -149,10 +149,46 @@ func (cp *connProducer) GetEndpoints() []string {
 	panic("Not implemented")
 }
 
-func (cp *connProducer) DisableEndpoint(endpoint string) {
+func (cp *connProducer) DisableEndpoint(endpoint string, duration time.Duration) {
 	panic("Not implemented")
 }
 
+func TestDisconnectFromExcludesEndpoint(t *testing.T) {
+	connFactory := func(endpoint string, connectionTimeout time.Duration) (*grpc.ClientConn, error) {
+		return newConnection(), nil
+	}
+	prod := comm.NewConnectionProducer(connFactory, []string{"orderer1", "orderer2", "orderer3"}, nil, false, time.Second)
+	clFactory := func(*grpc.ClientConn) orderer.AtomicBroadcastClient {
+		return &abclient{}
+	}
+	setup := func(blocksprovider.BlocksDeliverer) error {
+		return nil
+	}
+	backoffStrategy := func(attemptNum int, elapsedTime time.Duration) (time.Duration, bool) {
+		return 0, false
+	}
+	bc := NewBroadcastClient(prod, clFactory, setup, backoffStrategy)
+	defer bc.Close()
+
+	_, err := bc.Recv()
+	assert.NoError(t, err)
+	excluded := bc.connectedEndpoint()
+	assert.NotEmpty(t, excluded)
+
+	// Disconnecting from another endpoint keeps the connection
+	bc.DisconnectFrom("unknown", time.Minute)
+	assert.Equal(t, excluded, bc.connectedEndpoint())
+
+	bc.DisconnectFrom(excluded, time.Minute)
+	assert.Empty(t, bc.connectedEndpoint())
+	for i := 0; i < 10; i++ {
+		_, err := bc.Recv()
+		assert.NoError(t, err)
+		assert.NotEqual(t, excluded, bc.connectedEndpoint())
+		bc.Disconnect()
+	}
+}
+
 func TestOrderingServiceConnFailure(t *testing.T) {
 	testOrderingServiceConnFailure(t, blockDelivererConsumerWithRecv)
 	testOrderingServiceConnFailure(t, blockDelivererConsumerWithSend)
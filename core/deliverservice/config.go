@@ -16,6 +16,8 @@ const (
 	DefaultReConnectBackoffThreshold   = float64(time.Hour)
 	DefaultReConnectTotalTimeThreshold = time.Second * 60 * 60
 	DefaultConnectionTimeout           = time.Second * 3
+	DefaultBlockCensorshipTimeout      = time.Second * 30
+	DefaultEndpointExclusionPeriod     = time.Minute * 5
 )
 
 // DeliverServiceConfig is the struct that defines the deliverservice configuration.
@@ -29,6 +31,19 @@ type DeliverServiceConfig struct {
 	ReconnectTotalTimeThreshold time.Duration
 	// ConnectionTimeout sets the delivery service <-> ordering service node connection timeout
 	ConnectionTimeout time.Duration
+	// ConsenterSignatures sets how many distinct consenters of a BFT ordering service must
+	// sign a block for it to be valid, on top of the block validation policy.
+	// It is either empty or "policy" for the policy alone, "f+1" or "2f+1".
+	ConsenterSignatures string
+	// HeaderMonitorEnabled enables pulling block headers from all ordering service nodes,
+	// in order to detect an ordering service node that withholds or equivocates on blocks.
+	HeaderMonitorEnabled bool
+	// BlockCensorshipTimeout sets how long the ordering service node blocks are pulled from may lag
+	// behind the block headers of other ordering service nodes before it is considered to withhold blocks.
+	BlockCensorshipTimeout time.Duration
+	// EndpointExclusionPeriod sets how long blocks are not pulled from an ordering service node
+	// after it is detected to withhold or equivocate on blocks.
+	EndpointExclusionPeriod time.Duration
 }
 
 // GlobalConfig obtains a set of configuration from viper, build and returns the config struct.
@@ -55,4 +70,18 @@ func (c *DeliverServiceConfig) loadDeliverServiceConfig() {
 	if c.ConnectionTimeout == 0 {
 		c.ConnectionTimeout = DefaultConnectionTimeout
 	}
+
+	c.ConsenterSignatures = viper.GetString("peer.deliveryclient.consenterSignatures")
+
+	c.HeaderMonitorEnabled = viper.GetBool("peer.deliveryclient.headerMonitor.enabled")
+
+	c.BlockCensorshipTimeout = viper.GetDuration("peer.deliveryclient.headerMonitor.blockCensorshipTimeout")
+	if c.BlockCensorshipTimeout == 0 {
+		c.BlockCensorshipTimeout = DefaultBlockCensorshipTimeout
+	}
+
+	c.EndpointExclusionPeriod = viper.GetDuration("peer.deliveryclient.headerMonitor.endpointExclusionPeriod")
+	if c.EndpointExclusionPeriod == 0 {
+		c.EndpointExclusionPeriod = DefaultEndpointExclusionPeriod
+	}
 }
@@ -24,6 +24,10 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.deliveryclient.reConnectBackoffThreshold", 25.5)
 	viper.Set("peer.deliveryclient.reconnectTotalTimeThreshold", "20s")
 	viper.Set("peer.deliveryclient.connTimeout", "10s")
+	viper.Set("peer.deliveryclient.consenterSignatures", "2f+1")
+	viper.Set("peer.deliveryclient.headerMonitor.enabled", true)
+	viper.Set("peer.deliveryclient.headerMonitor.blockCensorshipTimeout", "1m")
+	viper.Set("peer.deliveryclient.headerMonitor.endpointExclusionPeriod", "10m")
 
 	coreConfig := deliverservice.GlobalConfig()
 
@@ -32,6 +36,10 @@ func TestGlobalConfig(t *testing.T) {
 		ReConnectBackoffThreshold:   25.5,
 		ReconnectTotalTimeThreshold: 20 * time.Second,
 		ConnectionTimeout:           10 * time.Second,
+		ConsenterSignatures:         "2f+1",
+		HeaderMonitorEnabled:        true,
+		BlockCensorshipTimeout:      time.Minute,
+		EndpointExclusionPeriod:     10 * time.Minute,
 	}
 
 	assert.Equal(t, expectedConfig, coreConfig)
@@ -48,6 +56,8 @@ func TestGlobalConfigDefault(t *testing.T) {
 		ReConnectBackoffThreshold:   deliverservice.DefaultReConnectBackoffThreshold,
 		ReconnectTotalTimeThreshold: deliverservice.DefaultReConnectTotalTimeThreshold,
 		ConnectionTimeout:           deliverservice.DefaultConnectionTimeout,
+		BlockCensorshipTimeout:      deliverservice.DefaultBlockCensorshipTimeout,
+		EndpointExclusionPeriod:     deliverservice.DefaultEndpointExclusionPeriod,
 	}
 
 	assert.Equal(t, expectedConfig, coreConfig)
@@ -15,9 +15,11 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
+	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/spf13/viper"
@@ -81,6 +83,8 @@ type Config struct {
 	// Configuration values for deliver service.
 	// TODO: merge 2 Config struct
 	DeliverServiceConfig *DeliverServiceConfig
+	// Metrics records the detection of ordering service nodes that withhold or equivocate on blocks.
+	Metrics *gossipmetrics.DeliverMetrics
 }
 
 // ConnectionCriteria defines how to connect to ordering service nodes.
@@ -195,7 +199,11 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 	}
 	client := d.newClient(chainID, ledgerInfo)
 	logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
-	d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
+	if monitor := d.monitorHeaders(chainID, ledgerInfo, client); monitor != nil {
+		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, &monitoredClient{broadcastClient: client, monitor: monitor}, d.conf.Gossip, d.conf.CryptoSvc)
+	} else {
+		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
+	}
 	go d.launchBlockProvider(chainID, finalizer)
 	return nil
 }
@@ -246,6 +254,10 @@ func (d *deliverServiceImpl) Stop() {
 }
 
 func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
+	return d.newBroadcastClient(chainID, d.conf.Endpoints, ledgerInfoProvider, orderer.SeekInfo_BLOCK)
+}
+
+func (d *deliverServiceImpl) newBroadcastClient(chainID string, endpoints []string, ledgerInfoProvider blocksprovider.LedgerInfo, contentType orderer.SeekInfo_SeekContentType) *broadcastClient {
 	reconnectBackoffThreshold := d.conf.DeliverServiceConfig.ReConnectBackoffThreshold
 	reconnectTotalTimeThreshold := d.conf.DeliverServiceConfig.ReconnectTotalTimeThreshold
 
@@ -254,6 +266,7 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		chainID:     chainID,
 		signer:      d.conf.Signer,
 		credSupport: d.conf.CredentialSupport,
+		contentType: contentType,
 	}
 	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
 		return requester.RequestBlocks(ledgerInfoProvider)
@@ -269,7 +282,7 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 	connectionFactory := d.conf.ConnFactory(chainID)
 	connProd := comm.NewConnectionProducer(
 		connectionFactory,
-		d.conf.Endpoints,
+		endpoints,
 		d.conf.DeliverClientDialOpts,
 		d.conf.DeliverServiceConfig.PeerTLSEnabled,
 		d.conf.DeliverServiceConfig.ConnectionTimeout,
@@ -279,6 +292,53 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 	return bClient
 }
 
+// monitorHeaders starts monitoring the headers of the blocks of the channel delivered by all
+// ordering service nodes, and returns nil if header monitoring is not enabled.
+// Header monitoring requires headers to be signed by a consenter quorum, as otherwise
+// a single ordering service node could forge headers and have the source excluded.
+func (d *deliverServiceImpl) monitorHeaders(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo, source *broadcastClient) *headerMonitor {
+	if !d.conf.DeliverServiceConfig.HeaderMonitorEnabled {
+		return nil
+	}
+	switch d.conf.DeliverServiceConfig.ConsenterSignatures {
+	case "f+1", "2f+1":
+	default:
+		logger.Warningf("[%s] Header monitoring is enabled, but consenterSignatures is %q instead of f+1 or 2f+1, not monitoring headers",
+			chainID, d.conf.DeliverServiceConfig.ConsenterSignatures)
+		return nil
+	}
+	verifier, ok := d.conf.CryptoSvc.(HeaderVerifier)
+	if !ok {
+		logger.Warningf("[%s] Header monitoring is enabled, but the crypto service cannot verify block headers", chainID)
+		return nil
+	}
+	metrics := d.conf.Metrics
+	if metrics == nil {
+		metrics = gossipmetrics.NewGossipMetrics(&disabled.Provider{}).DeliverMetrics
+	}
+
+	monitor := newHeaderMonitor(
+		chainID,
+		verifier,
+		ledgerInfoProvider,
+		source,
+		nil,
+		d.conf.DeliverServiceConfig.BlockCensorshipTimeout,
+		d.conf.DeliverServiceConfig.EndpointExclusionPeriod,
+		metrics,
+	)
+	monitor.newHeaderClient = func(endpoint string) headerClient {
+		return d.newBroadcastClient(chainID, []string{endpoint}, monitor, orderer.SeekInfo_HEADER_WITH_SIG)
+	}
+	setup := source.onConnect
+	source.onConnect = func(bd blocksprovider.BlocksDeliverer) error {
+		monitor.sourceConnected(source.connectedEndpoint())
+		return setup(bd)
+	}
+	monitor.start(d.conf.Endpoints)
+	return monitor
+}
+
 func KeepaliveOptions() comm.KeepaliveOptions {
 	keepaliveOptions := comm.DefaultKeepaliveOptions
 	if viper.IsSet("peer.keepalive.deliveryClient.interval") {
@@ -0,0 +1,419 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverservice
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// maxRetainedSourceHeaders is the number of headers of blocks received from the
+// source of blocks that are kept in order to compare them with the headers received
+// from the other ordering service nodes.
+const maxRetainedSourceHeaders = 100
+
+// HeaderVerifier verifies the signatures of blocks that are
+// delivered with only their header and metadata.
+type HeaderVerifier interface {
+	// VerifyHeader returns nil if the header of the block is signed by the
+	// consenter quorum of the channel. The source is only ever blamed for
+	// headers that pass this verification, so it must not accept headers
+	// that a single ordering service node could have produced on its own.
+	VerifyHeader(chainID string, block *common.Block) error
+}
+
+// headerClient receives block headers from an ordering service node.
+type headerClient interface {
+	// Recv retrieves a response from the ordering service node
+	Recv() (*orderer.DeliverResponse, error)
+
+	// Close closes the stream and its underlying connection
+	Close()
+}
+
+// blocksSource is the connection to the ordering service node blocks are pulled from.
+type blocksSource interface {
+	// DisconnectFrom disconnects from the given ordering service node, making blocks
+	// to be pulled from the next one, and excludes the node for the given period.
+	DisconnectFrom(endpoint string, period time.Duration)
+}
+
+// observedHeader is a verified block header received
+// from an ordering service node.
+type observedHeader struct {
+	hash     []byte
+	endpoint string
+	seen     time.Time
+}
+
+// headerMonitor pulls the headers and signatures of blocks from all the ordering service
+// nodes of a channel, and compares them with the blocks pulled from the source of blocks.
+// A source that does not deliver a block for which another node delivered a valid header
+// within the censorship timeout is considered to withhold blocks, and a source that delivers
+// a block that conflicts with a valid header of another node is considered to equivocate.
+// In both cases, the source is disconnected and excluded for a period, so that blocks are
+// pulled from another node.
+type headerMonitor struct {
+	chainID         string
+	verifier        HeaderVerifier
+	ledgerInfo      blocksprovider.LedgerInfo
+	source          blocksSource
+	newHeaderClient func(endpoint string) headerClient
+	timeout         time.Duration
+	exclusion       time.Duration
+	metrics         *gossipmetrics.DeliverMetrics
+
+	mutex          sync.Mutex
+	sourceEndpoint string
+	sourceHeight   uint64
+	sourceHeaders  map[uint64]*common.Block
+	headers        map[uint64]*observedHeader
+	monitors       map[string]chan struct{}
+	clients        map[string]headerClient
+	stopped        bool
+	stopChan       chan struct{}
+}
+
+func newHeaderMonitor(
+	chainID string,
+	verifier HeaderVerifier,
+	ledgerInfo blocksprovider.LedgerInfo,
+	source blocksSource,
+	newHeaderClient func(endpoint string) headerClient,
+	timeout time.Duration,
+	exclusion time.Duration,
+	metrics *gossipmetrics.DeliverMetrics,
+) *headerMonitor {
+	return &headerMonitor{
+		chainID:         chainID,
+		verifier:        verifier,
+		ledgerInfo:      ledgerInfo,
+		source:          source,
+		newHeaderClient: newHeaderClient,
+		timeout:         timeout,
+		exclusion:       exclusion,
+		metrics:         metrics,
+		sourceHeaders:   make(map[uint64]*common.Block),
+		headers:         make(map[uint64]*observedHeader),
+		monitors:        make(map[string]chan struct{}),
+		clients:         make(map[string]headerClient),
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// start starts pulling headers from the given endpoints
+// and checking whether the source withholds blocks.
+func (m *headerMonitor) start(endpoints []string) {
+	m.updateEndpoints(endpoints)
+	go m.checkWithholding()
+}
+
+// stop stops pulling headers from all endpoints.
+func (m *headerMonitor) stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.stopped {
+		return
+	}
+	m.stopped = true
+	close(m.stopChan)
+	for endpoint, stop := range m.monitors {
+		close(stop)
+		delete(m.monitors, endpoint)
+	}
+	for _, client := range m.clients {
+		client.Close()
+	}
+}
+
+// updateEndpoints starts pulling headers from endpoints that are not yet monitored,
+// and stops pulling headers from endpoints that are no longer in the given endpoints.
+func (m *headerMonitor) updateEndpoints(endpoints []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.stopped {
+		return
+	}
+
+	current := make(map[string]struct{})
+	for _, endpoint := range endpoints {
+		current[endpoint] = struct{}{}
+		if _, exists := m.monitors[endpoint]; exists {
+			continue
+		}
+		stop := make(chan struct{})
+		m.monitors[endpoint] = stop
+		go m.monitor(endpoint, stop)
+	}
+
+	for endpoint, stop := range m.monitors {
+		if _, exists := current[endpoint]; exists {
+			continue
+		}
+		close(stop)
+		delete(m.monitors, endpoint)
+		if client, exists := m.clients[endpoint]; exists {
+			client.Close()
+		}
+	}
+}
+
+// LedgerHeight returns the number of the next block expected from the source,
+// which is where headers are pulled from when connecting to an ordering service node.
+func (m *headerMonitor) LedgerHeight() (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.sourceHeight, nil
+}
+
+// sourceConnected is called whenever a connection to a new source is established.
+func (m *headerMonitor) sourceConnected(endpoint string) {
+	height, err := m.ledgerInfo.LedgerHeight()
+	if err != nil {
+		logger.Warningf("[%s] Failed obtaining the ledger height: %s", m.chainID, err)
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sourceEndpoint = endpoint
+	m.sourceHeight = height
+	m.resetTimers()
+}
+
+// onSourceBlock records a block received from the source, and returns false
+// if the block conflicts with a valid header received from another node.
+func (m *headerMonitor) onSourceBlock(block *common.Block) bool {
+	number := block.Header.Number
+	hash := protoutil.BlockHeaderHash(block.Header)
+
+	m.mutex.Lock()
+	observed, exists := m.headers[number]
+	m.mutex.Unlock()
+
+	if exists && !bytes.Equal(observed.hash, hash) {
+		// A source that sends a block that is not properly signed is not
+		// equivocating, the block is rejected when it is verified.
+		if err := m.verifier.VerifyHeader(m.chainID, block); err == nil {
+			logger.Warningf("[%s] Block [%d] received from the ordering service conflicts with the header received from %s, switching to another ordering service node",
+				m.chainID, number, observed.endpoint)
+			m.metrics.BlocksEquivocated.With("channel", m.chainID).Add(1)
+			m.switchSource()
+			return false
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sourceHeaders[number] = &common.Block{Header: block.Header, Metadata: block.Metadata}
+	if number >= maxRetainedSourceHeaders {
+		delete(m.sourceHeaders, number-maxRetainedSourceHeaders)
+	}
+	if number+1 > m.sourceHeight {
+		m.sourceHeight = number + 1
+	}
+	for n := range m.headers {
+		if n < m.sourceHeight {
+			delete(m.headers, n)
+		}
+	}
+	return true
+}
+
+// onHeader records a header received from the given endpoint, which
+// must have been verified to carry the consenter quorum.
+func (m *headerMonitor) onHeader(endpoint string, block *common.Block) {
+	number := block.Header.Number
+	hash := protoutil.BlockHeaderHash(block.Header)
+
+	m.mutex.Lock()
+	sourceHeader, delivered := m.sourceHeaders[number]
+	if !delivered {
+		m.recordHeader(endpoint, number, hash)
+		m.mutex.Unlock()
+		return
+	}
+	m.mutex.Unlock()
+
+	if bytes.Equal(protoutil.BlockHeaderHash(sourceHeader.Header), hash) {
+		return
+	}
+	if err := m.verifier.VerifyHeader(m.chainID, sourceHeader); err != nil {
+		return
+	}
+	logger.Warningf("[%s] Block [%d] received from the ordering service conflicts with the header received from %s, switching to another ordering service node",
+		m.chainID, number, endpoint)
+	m.metrics.BlocksEquivocated.With("channel", m.chainID).Add(1)
+	m.switchSource()
+}
+
+// recordHeader records a header of a block that was not yet delivered by the source,
+// unless a header of the same block was already received from another node.
+func (m *headerMonitor) recordHeader(endpoint string, number uint64, hash []byte) {
+	if number < m.sourceHeight {
+		return
+	}
+	observed, exists := m.headers[number]
+	if !exists {
+		m.headers[number] = &observedHeader{hash: hash, endpoint: endpoint, seen: time.Now()}
+		return
+	}
+	if !bytes.Equal(observed.hash, hash) {
+		logger.Warningf("[%s] Headers of block [%d] received from %s and %s conflict", m.chainID, number, observed.endpoint, endpoint)
+	}
+}
+
+// checkWithholding periodically checks whether the source lags behind
+// the headers received from other nodes for longer than the censorship timeout.
+func (m *headerMonitor) checkWithholding() {
+	ticker := time.NewTicker(m.timeout / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+		}
+
+		if number, observed := m.withheldHeader(); observed != nil {
+			logger.Warningf("[%s] The ordering service has not delivered block [%d] within %v of receiving its header from %s, switching to another ordering service node",
+				m.chainID, number, m.timeout, observed.endpoint)
+			m.metrics.BlocksWithheld.With("channel", m.chainID).Add(1)
+			m.switchSource()
+		}
+	}
+}
+
+func (m *headerMonitor) withheldHeader() (uint64, *observedHeader) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for number, observed := range m.headers {
+		if number >= m.sourceHeight && time.Since(observed.seen) > m.timeout {
+			return number, observed
+		}
+	}
+	return 0, nil
+}
+
+// switchSource disconnects from the source and excludes it, so that blocks are
+// not pulled from it again, and gives the next source a full timeout to deliver blocks.
+func (m *headerMonitor) switchSource() {
+	m.mutex.Lock()
+	m.resetTimers()
+	endpoint := m.sourceEndpoint
+	m.mutex.Unlock()
+	m.source.DisconnectFrom(endpoint, m.exclusion)
+}
+
+func (m *headerMonitor) resetTimers() {
+	now := time.Now()
+	for _, observed := range m.headers {
+		observed.seen = now
+	}
+}
+
+// monitor pulls headers from the given endpoint until it is stopped.
+func (m *headerMonitor) monitor(endpoint string, stop chan struct{}) {
+	for {
+		client := m.newHeaderClient(endpoint)
+
+		m.mutex.Lock()
+		select {
+		case <-stop:
+			m.mutex.Unlock()
+			client.Close()
+			return
+		default:
+		}
+		m.clients[endpoint] = client
+		m.mutex.Unlock()
+
+		m.pullHeaders(endpoint, client)
+		client.Close()
+
+		m.mutex.Lock()
+		if m.clients[endpoint] == client {
+			delete(m.clients, endpoint)
+		}
+		m.mutex.Unlock()
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(m.timeout):
+		}
+	}
+}
+
+func (m *headerMonitor) pullHeaders(endpoint string, client headerClient) {
+	for {
+		msg, err := client.Recv()
+		if err != nil {
+			logger.Debugf("[%s] Stopped receiving headers from %s: %s", m.chainID, endpoint, err)
+			return
+		}
+		switch t := msg.Type.(type) {
+		case *orderer.DeliverResponse_Status:
+			logger.Debugf("[%s] Got status %v while receiving headers from %s", m.chainID, t.Status, endpoint)
+			return
+		case *orderer.DeliverResponse_Block:
+			if t.Block == nil || t.Block.Header == nil {
+				logger.Warningf("[%s] Received a block without a header from %s", m.chainID, endpoint)
+				continue
+			}
+			if err := m.verifier.VerifyHeader(m.chainID, t.Block); err != nil {
+				logger.Warningf("[%s] Error verifying header of block [%d] received from %s: %s", m.chainID, t.Block.Header.Number, endpoint, err)
+				continue
+			}
+			m.onHeader(endpoint, t.Block)
+		default:
+			logger.Warningf("[%s] Received unknown response from %s: %v", m.chainID, endpoint, t)
+			return
+		}
+	}
+}
+
+// monitoredClient is a client of the source of blocks
+// that reports the blocks it receives to a headerMonitor.
+type monitoredClient struct {
+	*broadcastClient
+	monitor *headerMonitor
+}
+
+// Recv receives a block from the source, skipping blocks that
+// conflict with the headers received from other nodes.
+func (mc *monitoredClient) Recv() (*orderer.DeliverResponse, error) {
+	for {
+		msg, err := mc.broadcastClient.Recv()
+		if err != nil {
+			return nil, err
+		}
+		block := msg.GetBlock()
+		if block == nil || block.Header == nil || mc.monitor.onSourceBlock(block) {
+			return msg, nil
+		}
+	}
+}
+
+// UpdateEndpoints updates the endpoints of the source and of the header monitor.
+func (mc *monitoredClient) UpdateEndpoints(endpoints []string) {
+	mc.broadcastClient.UpdateEndpoints(endpoints)
+	mc.monitor.updateEndpoints(endpoints)
+}
+
+// Close stops the header monitor and closes the connection to the source.
+func (mc *monitoredClient) Close() {
+	mc.monitor.stop()
+	mc.broadcastClient.Close()
+}
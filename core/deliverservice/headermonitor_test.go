@@ -0,0 +1,365 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverservice
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/deliverservice/mocks"
+	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	censorshipTimeout = 200 * time.Millisecond
+	exclusionPeriod   = time.Minute
+)
+
+type fakeHeaderClient struct {
+	responses chan *orderer.DeliverResponse
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newFakeHeaderClient() *fakeHeaderClient {
+	return &fakeHeaderClient{
+		responses: make(chan *orderer.DeliverResponse, 10),
+		closed:    make(chan struct{}),
+	}
+}
+
+func (c *fakeHeaderClient) Recv() (*orderer.DeliverResponse, error) {
+	select {
+	case resp := <-c.responses:
+		return resp, nil
+	case <-c.closed:
+		return nil, errors.New("closed")
+	}
+}
+
+func (c *fakeHeaderClient) Close() {
+	c.closeOnce.Do(func() { close(c.closed) })
+}
+
+func (c *fakeHeaderClient) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *fakeHeaderClient) sendHeader(block *common.Block) {
+	c.responses <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Block{Block: &common.Block{Header: block.Header, Metadata: block.Metadata}},
+	}
+}
+
+type fakeSource struct {
+	lock      sync.Mutex
+	excluded  []string
+	exclusion time.Duration
+}
+
+func (s *fakeSource) DisconnectFrom(endpoint string, period time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.excluded = append(s.excluded, endpoint)
+	s.exclusion = period
+}
+
+func (s *fakeSource) disconnectCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.excluded)
+}
+
+func (s *fakeSource) excludedEndpoints() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.excluded...)
+}
+
+type fakeHeaderVerifier struct {
+	invalid sync.Map
+}
+
+func (v *fakeHeaderVerifier) VerifyHeader(chainID string, block *common.Block) error {
+	if _, invalid := v.invalid.Load(string(block.Header.DataHash)); invalid {
+		return errors.New("invalid signatures")
+	}
+	return nil
+}
+
+type headerMonitorTestEnv struct {
+	monitor     *headerMonitor
+	source      *fakeSource
+	verifier    *fakeHeaderVerifier
+	withheld    *metricsfakes.Counter
+	equivocated *metricsfakes.Counter
+
+	lock    sync.Mutex
+	clients map[string][]*fakeHeaderClient
+}
+
+func newHeaderMonitorTestEnv(t *testing.T, endpoints ...string) *headerMonitorTestEnv {
+	env := &headerMonitorTestEnv{
+		source:      &fakeSource{},
+		verifier:    &fakeHeaderVerifier{},
+		withheld:    &metricsfakes.Counter{},
+		equivocated: &metricsfakes.Counter{},
+		clients:     make(map[string][]*fakeHeaderClient),
+	}
+	env.withheld.WithReturns(env.withheld)
+	env.equivocated.WithReturns(env.equivocated)
+
+	newHeaderClient := func(endpoint string) headerClient {
+		env.lock.Lock()
+		defer env.lock.Unlock()
+		client := newFakeHeaderClient()
+		env.clients[endpoint] = append(env.clients[endpoint], client)
+		return client
+	}
+	metrics := &gossipmetrics.DeliverMetrics{
+		BlocksWithheld:    env.withheld,
+		BlocksEquivocated: env.equivocated,
+	}
+
+	env.monitor = newHeaderMonitor("testchannel", env.verifier, &mocks.MockLedgerInfo{Height: 5}, env.source, newHeaderClient, censorshipTimeout, exclusionPeriod, metrics)
+	env.monitor.sourceConnected("orderer1")
+	env.monitor.start(endpoints)
+	for _, endpoint := range endpoints {
+		assertEventually(t, func() bool { return env.client(endpoint) != nil }, time.Second)
+	}
+	return env
+}
+
+func (env *headerMonitorTestEnv) client(endpoint string) *fakeHeaderClient {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	clients := env.clients[endpoint]
+	if len(clients) == 0 {
+		return nil
+	}
+	return clients[len(clients)-1]
+}
+
+func (env *headerMonitorTestEnv) waitForHeader(t *testing.T, number uint64) {
+	assertEventually(t, func() bool {
+		env.monitor.mutex.Lock()
+		defer env.monitor.mutex.Unlock()
+		_, exists := env.monitor.headers[number]
+		return exists
+	}, time.Second)
+}
+
+func assertEventually(t *testing.T, condition func() bool, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.FailNow(t, "condition not satisfied within "+timeout.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testBlock(number uint64, dataHash string) *common.Block {
+	return &common.Block{
+		Header:   &common.BlockHeader{Number: number, DataHash: []byte(dataHash)},
+		Data:     &common.BlockData{Data: [][]byte{[]byte(dataHash)}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}},
+	}
+}
+
+func TestHeaderMonitorDetectsWithholding(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1", "orderer2")
+	defer env.monitor.stop()
+
+	assert.True(t, env.monitor.onSourceBlock(testBlock(5, "block5")))
+	env.client("orderer2").sendHeader(testBlock(5, "block5"))
+	env.client("orderer2").sendHeader(testBlock(6, "block6"))
+	env.waitForHeader(t, 6)
+
+	assertEventually(t, func() bool { return env.source.disconnectCount() == 1 }, 5*censorshipTimeout)
+	assert.Equal(t, []string{"orderer1"}, env.source.excludedEndpoints())
+	assert.Equal(t, exclusionPeriod, env.source.exclusion)
+	assert.Equal(t, 1, env.withheld.AddCallCount())
+	assert.Equal(t, []string{"channel", "testchannel"}, env.withheld.WithArgsForCall(0))
+	assert.Equal(t, float64(1), env.withheld.AddArgsForCall(0))
+	assert.Equal(t, 0, env.equivocated.AddCallCount())
+
+	// The new source delivers the block in time
+	env.monitor.sourceConnected("orderer2")
+	assert.True(t, env.monitor.onSourceBlock(testBlock(6, "block6")))
+	time.Sleep(2 * censorshipTimeout)
+	assert.Equal(t, 1, env.source.disconnectCount())
+	assert.Equal(t, 1, env.withheld.AddCallCount())
+}
+
+func TestHeaderMonitorSourceDeliversInTime(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1", "orderer2")
+	defer env.monitor.stop()
+
+	for number := uint64(5); number < 10; number++ {
+		env.client("orderer1").sendHeader(testBlock(number, "block"))
+		env.waitForHeader(t, number)
+		time.Sleep(censorshipTimeout / 2)
+		assert.True(t, env.monitor.onSourceBlock(testBlock(number, "block")))
+	}
+
+	time.Sleep(2 * censorshipTimeout)
+	assert.Equal(t, 0, env.source.disconnectCount())
+	assert.Equal(t, 0, env.withheld.AddCallCount())
+}
+
+func TestHeaderMonitorIgnoresInvalidHeaders(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1")
+	defer env.monitor.stop()
+
+	env.verifier.invalid.Store("forged", struct{}{})
+	env.client("orderer1").sendHeader(testBlock(7, "forged"))
+
+	time.Sleep(3 * censorshipTimeout)
+	assert.Equal(t, 0, env.source.disconnectCount())
+	assert.Equal(t, 0, env.withheld.AddCallCount())
+}
+
+func TestHeaderMonitorIgnoresHeadersForgedByOtherNodes(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1", "orderer2")
+	defer env.monitor.stop()
+
+	// orderer2 signs headers on its own, which lack the consenter quorum
+	for _, forged := range []string{"forged5", "forged6", "forged7"} {
+		env.verifier.invalid.Store(forged, struct{}{})
+	}
+	rogue := env.client("orderer2")
+
+	// A header that conflicts with a block the source already delivered
+	assert.True(t, env.monitor.onSourceBlock(testBlock(5, "block5")))
+	rogue.sendHeader(testBlock(5, "forged5"))
+
+	// A header of a block the source is about to deliver
+	rogue.sendHeader(testBlock(6, "forged6"))
+	time.Sleep(censorshipTimeout / 2)
+	assert.True(t, env.monitor.onSourceBlock(testBlock(6, "block6")))
+
+	// A header of a block that does not exist
+	rogue.sendHeader(testBlock(7, "forged7"))
+
+	time.Sleep(3 * censorshipTimeout)
+	assert.Equal(t, 0, env.source.disconnectCount())
+	assert.Equal(t, 0, env.withheld.AddCallCount())
+	assert.Equal(t, 0, env.equivocated.AddCallCount())
+
+	env.monitor.mutex.Lock()
+	defer env.monitor.mutex.Unlock()
+	assert.Empty(t, env.monitor.headers)
+}
+
+func TestHeaderMonitorDetectsEquivocation(t *testing.T) {
+	t.Run("block conflicts with a header received before it", func(t *testing.T) {
+		env := newHeaderMonitorTestEnv(t, "orderer1")
+		defer env.monitor.stop()
+
+		env.client("orderer1").sendHeader(testBlock(5, "block5"))
+		env.waitForHeader(t, 5)
+
+		assert.False(t, env.monitor.onSourceBlock(testBlock(5, "other block5")))
+		assert.Equal(t, []string{"orderer1"}, env.source.excludedEndpoints())
+		assert.Equal(t, 1, env.equivocated.AddCallCount())
+		assert.Equal(t, []string{"channel", "testchannel"}, env.equivocated.WithArgsForCall(0))
+
+		assert.True(t, env.monitor.onSourceBlock(testBlock(5, "block5")))
+		assert.Equal(t, 1, env.source.disconnectCount())
+	})
+
+	t.Run("header conflicts with a block received before it", func(t *testing.T) {
+		env := newHeaderMonitorTestEnv(t, "orderer1")
+		defer env.monitor.stop()
+
+		assert.True(t, env.monitor.onSourceBlock(testBlock(5, "block5")))
+		env.client("orderer1").sendHeader(testBlock(5, "other block5"))
+
+		assertEventually(t, func() bool { return env.source.disconnectCount() == 1 }, time.Second)
+		assert.Equal(t, 1, env.equivocated.AddCallCount())
+	})
+
+	t.Run("blocks that are not properly signed are not equivocation", func(t *testing.T) {
+		env := newHeaderMonitorTestEnv(t, "orderer1")
+		defer env.monitor.stop()
+
+		env.client("orderer1").sendHeader(testBlock(5, "block5"))
+		env.waitForHeader(t, 5)
+
+		env.verifier.invalid.Store("forged", struct{}{})
+		assert.True(t, env.monitor.onSourceBlock(testBlock(5, "forged")))
+		assert.Equal(t, 0, env.source.disconnectCount())
+		assert.Equal(t, 0, env.equivocated.AddCallCount())
+	})
+}
+
+func TestHeaderMonitorUpdateEndpoints(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1", "orderer2")
+
+	orderer1 := env.client("orderer1")
+	orderer2 := env.client("orderer2")
+	env.monitor.updateEndpoints([]string{"orderer2", "orderer3"})
+	assertEventually(t, func() bool { return env.client("orderer3") != nil }, time.Second)
+	assert.True(t, orderer1.isClosed())
+	assert.False(t, orderer2.isClosed())
+
+	env.monitor.stop()
+	assert.True(t, orderer2.isClosed())
+	assert.True(t, env.client("orderer3").isClosed())
+
+	// Stopping twice is harmless
+	env.monitor.stop()
+}
+
+func TestHeaderMonitorReconnects(t *testing.T) {
+	env := newHeaderMonitorTestEnv(t, "orderer1")
+	defer env.monitor.stop()
+
+	first := env.client("orderer1")
+	first.responses <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Status{Status: common.Status_SERVICE_UNAVAILABLE},
+	}
+	assertEventually(t, first.isClosed, time.Second)
+	assertEventually(t, func() bool { return env.client("orderer1") != first }, 5*censorshipTimeout)
+
+	height, err := env.monitor.LedgerHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), height)
+}
+
+func TestMonitorHeadersDisabled(t *testing.T) {
+	d := &deliverServiceImpl{
+		conf: &Config{
+			CryptoSvc:            &mockMCS{},
+			DeliverServiceConfig: &DeliverServiceConfig{HeaderMonitorEnabled: false},
+		},
+	}
+	assert.Nil(t, d.monitorHeaders("testchannel", &mocks.MockLedgerInfo{}, nil))
+
+	// Headers are not required to be signed by a consenter quorum
+	d.conf.DeliverServiceConfig.HeaderMonitorEnabled = true
+	for _, consenterSignatures := range []string{"", "policy"} {
+		d.conf.DeliverServiceConfig.ConsenterSignatures = consenterSignatures
+		assert.Nil(t, d.monitorHeaders("testchannel", &mocks.MockLedgerInfo{}, nil))
+	}
+
+	// The crypto service cannot verify headers
+	d.conf.DeliverServiceConfig.ConsenterSignatures = "2f+1"
+	assert.Nil(t, d.monitorHeaders("testchannel", &mocks.MockLedgerInfo{}, nil))
+}
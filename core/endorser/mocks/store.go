@@ -99,6 +99,20 @@ func (_m *Store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return r0
 }
 
+// PurgeByPvtKeys provides a mock function with given fields: purgedKeys
+func (_m *Store) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	ret := _m.Called(purgedKeys)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*ledger.PurgedPvtKey) error); ok {
+		r0 = rf(purgedKeys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PurgeByTxids provides a mock function with given fields: txids
 func (_m *Store) PurgeByTxids(txids []string) error {
 	ret := _m.Called(txids)
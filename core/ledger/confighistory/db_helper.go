@@ -17,6 +17,7 @@ import (
 
 const (
 	keyPrefix     = "s"
+	nextKeyPrefix = "t"
 	separatorByte = byte(0)
 )
 
@@ -95,6 +96,10 @@ func (d *db) entryAt(blockNum uint64, ns, key string) (*compositeKV, error) {
 	return &compositeKV{k, v}, nil
 }
 
+func (d *db) getAllEntriesIterator() *leveldbhelper.Iterator {
+	return d.GetIterator([]byte(keyPrefix), []byte(nextKeyPrefix))
+}
+
 func encodeCompositeKey(ns, key string, blockNum uint64) []byte {
 	b := []byte(keyPrefix + ns)
 	b = append(b, separatorByte)
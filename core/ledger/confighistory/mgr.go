@@ -27,6 +27,9 @@ const (
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	ExportConfigHistory(ledgerID, dir string) (map[string][]byte, error)
+	ImportConfigHistory(ledgerID, dir string) error
+	Drop(ledgerID string) error
 	Close()
 }
 
@@ -104,6 +107,13 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	}
 }
 
+// Drop implements the function in the interface 'Mgr'. This removes the config history of the given
+// ledger, which is expected to be regenerated when the blocks of the ledger are committed again
+func (m *mgr) Drop(ledgerID string) error {
+	return errors.WithMessagef(m.dbProvider.getDB(ledgerID).DeleteAll(),
+		"error while dropping the config history of [%s]", ledgerID)
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
@@ -155,6 +155,36 @@ func TestMgr(t *testing.T) {
 	})
 }
 
+func TestDrop(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "confighistory")
+	if err != nil {
+		t.Fatalf("Failed to create config history directory: %s", err)
+	}
+	defer os.RemoveAll(dbPath)
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	mgr := NewMgr(dbPath, mockCCInfoProvider)
+	defer mgr.Close()
+	dummyLedgerInfoRetriever := &dummyLedgerInfoRetriever{
+		info: &common.BlockchainInfo{Height: 100},
+		qe:   &mock.QueryExecutor{},
+	}
+	for _, ledgerid := range []string{"ledger1", "ledger2"} {
+		testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(mockCCInfoProvider, "chaincode1", sampleCollectionConfigPackage(ledgerid, 50))
+		assert.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:           ledgerid,
+			CommittingBlockNum: 50},
+		))
+	}
+	assert.NoError(t, mgr.Drop("ledger1"))
+
+	collConfig, err := mgr.GetRetriever("ledger1", dummyLedgerInfoRetriever).MostRecentCollectionConfigBelow(90, "chaincode1")
+	assert.NoError(t, err)
+	assert.Nil(t, collConfig)
+	collConfig, err = mgr.GetRetriever("ledger2", dummyLedgerInfoRetriever).MostRecentCollectionConfigBelow(90, "chaincode1")
+	assert.NoError(t, err)
+	assert.Equal(t, sampleCollectionConfigPackage("ledger2", 50), collConfig.CollectionConfig)
+}
+
 func TestWithImplicitColls(t *testing.T) {
 	dbPath, err := ioutil.TempDir("", "confighistory")
 	if err != nil {
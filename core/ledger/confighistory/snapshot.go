@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// SnapshotDataFileName is the name of the file that contains the config history in a snapshot
+	SnapshotDataFileName = "confighistory.data"
+	// SnapshotMetadataFileName is the name of the file that contains the number of entries in the config history file
+	SnapshotMetadataFileName = "confighistory.metadata"
+
+	maxEntriesInImportBatch = 10000
+)
+
+// ExportConfigHistory exports the entire config history of the given ledger into two files in the specified dir.
+// The data file contains a series of tuple <namespace, key, blockNum, value> and the metadata file contains
+// the number of tuples. This function returns the mapping between the names of the files and their hashes
+func (m *mgr) ExportConfigHistory(ledgerID, dir string) (map[string][]byte, error) {
+	dataFile, err := snapshot.CreateFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer dataFile.Close()
+
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	itr := dbHandle.getAllEntriesIterator()
+	defer itr.Release()
+	numEntries := uint64(0)
+	for itr.Next() {
+		if err := itr.Error(); err != nil {
+			return nil, errors.Wrap(err, "internal leveldb error while iterating for config history")
+		}
+		k := decodeCompositeKey(itr.Key())
+		if err := dataFile.EncodeString(k.ns); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeString(k.key); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeUVarint(k.blockNum); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeBytes(itr.Value()); err != nil {
+			return nil, err
+		}
+		numEntries++
+	}
+	dataHash, err := dataFile.Done()
+	if err != nil {
+		return nil, err
+	}
+
+	metadataFile, err := snapshot.CreateFile(filepath.Join(dir, SnapshotMetadataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer metadataFile.Close()
+	if err := metadataFile.EncodeUVarint(numEntries); err != nil {
+		return nil, err
+	}
+	metadataHash, err := metadataFile.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		SnapshotDataFileName:     dataHash,
+		SnapshotMetadataFileName: metadataHash,
+	}, nil
+}
+
+// ImportConfigHistory loads the config history, exported by the function `ExportConfigHistory`,
+// from the snapshot files present in the specified dir for the given ledger
+func (m *mgr) ImportConfigHistory(ledgerID, dir string) error {
+	metadataFile, err := snapshot.OpenFile(filepath.Join(dir, SnapshotMetadataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer metadataFile.Close()
+	numEntries, err := metadataFile.DecodeUVarInt()
+	if err != nil {
+		return err
+	}
+
+	dataFile, err := snapshot.OpenFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer dataFile.Close()
+
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	b := newBatch()
+	for i := uint64(0); i < numEntries; i++ {
+		ns, err := dataFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		key, err := dataFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		blockNum, err := dataFile.DecodeUVarInt()
+		if err != nil {
+			return err
+		}
+		value, err := dataFile.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		b.add(ns, key, blockNum, value)
+		if b.Len() >= maxEntriesInImportBatch {
+			if err := dbHandle.writeBatch(b, true); err != nil {
+				return err
+			}
+			b = newBatch()
+		}
+	}
+	return dbHandle.writeBatch(b, true)
+}
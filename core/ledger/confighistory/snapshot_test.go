@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportConfigHistory(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "confighistory")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbPath)
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	mgr := NewMgr(dbPath, mockCCInfoProvider)
+	defer mgr.Close()
+
+	configCommittingBlockNums := []uint64{5, 10, 15, 100}
+	for _, chaincodeName := range []string{"chaincode1", "chaincode2"} {
+		for _, committingBlockNum := range configCommittingBlockNums {
+			testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(
+				mockCCInfoProvider,
+				chaincodeName,
+				sampleCollectionConfigPackage(chaincodeName, committingBlockNum),
+			)
+			require.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+				LedgerID:           "sourceLedger",
+				CommittingBlockNum: committingBlockNum},
+			))
+		}
+	}
+	// an entry in a different ledger should not be exported
+	testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(
+		mockCCInfoProvider,
+		"chaincode1",
+		sampleCollectionConfigPackage("otherLedger", 20),
+	)
+	require.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+		LedgerID:           "otherLedger",
+		CommittingBlockNum: 20},
+	))
+
+	snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	fileHashes, err := mgr.ExportConfigHistory("sourceLedger", snapshotDir)
+	require.NoError(t, err)
+	require.Len(t, fileHashes, 2)
+	for fileName, hash := range fileHashes {
+		fileContent, err := ioutil.ReadFile(filepath.Join(snapshotDir, fileName))
+		require.NoError(t, err)
+		expectedHash := sha256.Sum256(fileContent)
+		require.Equal(t, expectedHash[:], hash)
+	}
+
+	require.NoError(t, mgr.ImportConfigHistory("destinationLedger", snapshotDir))
+	retriever := mgr.GetRetriever(
+		"destinationLedger",
+		&dummyLedgerInfoRetriever{
+			info: &common.BlockchainInfo{Height: 101},
+			qe:   &mock.QueryExecutor{},
+		},
+	)
+	for _, chaincodeName := range []string{"chaincode1", "chaincode2"} {
+		for _, committingBlockNum := range configCommittingBlockNums {
+			retrievedConfig, err := retriever.CollectionConfigAt(committingBlockNum, chaincodeName)
+			require.NoError(t, err)
+			require.Equal(t, committingBlockNum, retrievedConfig.CommittingBlockNum)
+			require.Equal(t, sampleCollectionConfigPackage(chaincodeName, committingBlockNum), retrievedConfig.CollectionConfig)
+		}
+	}
+	retrievedConfig, err := retriever.CollectionConfigAt(20, "chaincode1")
+	require.NoError(t, err)
+	require.Nil(t, retrievedConfig)
+}
+
+func TestExportAndImportConfigHistoryErrorCases(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "confighistory")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbPath)
+	mgr := NewMgr(dbPath, &mock.DeployedChaincodeInfoProvider{})
+	defer mgr.Close()
+
+	snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	err = mgr.ImportConfigHistory("ledger1", snapshotDir)
+	require.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, SnapshotDataFileName), []byte("junk"), 0644))
+	_, err = mgr.ExportConfigHistory("ledger1", snapshotDir)
+	require.Contains(t, err.Error(), "error while creating the snapshot file")
+}
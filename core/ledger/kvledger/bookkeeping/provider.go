@@ -20,6 +20,8 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// SnapshotRequest maintains the bookkeeping about the pending requests for generating the snapshots
+	SnapshotRequest
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
@@ -54,6 +54,36 @@ func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []b
 	return compositeKey
 }
 
+// PvtDataHashHistoryKeyPrefix is the prefix of the history keys of the hashed private data writes. The prefix
+// cannot clash with the history keys of the public writes, as the namespaces do not begin with this byte
+var PvtDataHashHistoryKeyPrefix = []byte{0x01}
+
+// ConstructCompositePvtDataHashHistoryKey builds the History Key of a hashed private data write
+// in the form prefix~namespace~collection~keyHash~blocknum~trannum
+func ConstructCompositePvtDataHashHistoryKey(ns, coll string, keyHash []byte, blocknum uint64, trannum uint64) []byte {
+	compositeKey := ConstructPartialCompositePvtDataHashHistoryKey(ns, coll, keyHash, false)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blocknum)...)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(trannum)...)
+	return compositeKey
+}
+
+// ConstructPartialCompositePvtDataHashHistoryKey builds a partial History Key prefix~namespace~collection~keyHash~
+// for use in the range queries over the history of a private data key hash
+func ConstructPartialCompositePvtDataHashHistoryKey(ns, coll string, keyHash []byte, endkey bool) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, PvtDataHashHistoryKeyPrefix...)
+	compositeKey = append(compositeKey, []byte(ns)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, []byte(coll)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, keyHash...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	if endkey {
+		compositeKey = append(compositeKey, []byte{0xff}...)
+	}
+	return compositeKey
+}
+
 //SplitCompositeHistoryKey splits the key bytes using a separator
 func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
 	split := bytes.SplitN(bytesToSplit, separator, 2)
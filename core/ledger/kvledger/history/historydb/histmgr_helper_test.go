@@ -46,3 +46,16 @@ func TestSplitCompositeKey(t *testing.T) {
 	// second position should hold the extra bytes that were split off
 	assert.Equal(t, []byte("extra bytes to split"), extraBytes)
 }
+
+func TestConstructPvtDataHashHistoryKey(t *testing.T) {
+	keyHash := []byte{0x0a, 0x0b}
+	compositeStartKey := ConstructPartialCompositePvtDataHashHistoryKey("ns1", "coll1", keyHash, false)
+	compositeEndKey := ConstructPartialCompositePvtDataHashHistoryKey("ns1", "coll1", keyHash, true)
+
+	assert.Equal(t, []byte("\x01ns1"+strKeySep+"coll1"+strKeySep+"\x0a\x0b"+strKeySep), compositeStartKey)
+	assert.Equal(t, []byte("\x01ns1"+strKeySep+"coll1"+strKeySep+"\x0a\x0b"+strKeySep+string([]byte{0xff})), compositeEndKey)
+
+	compositeKey := ConstructCompositePvtDataHashHistoryKey("ns1", "coll1", keyHash, 1, 1)
+	_, blockNumTranNumBytes := SplitCompositeHistoryKey(compositeKey, compositeStartKey)
+	assert.Equal(t, []byte{0x01, 0x01, 0x01, 0x01}, blockNumTranNumBytes)
+}
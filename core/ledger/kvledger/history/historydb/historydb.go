@@ -38,4 +38,7 @@ type HistoryDB interface {
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// RecordSnapshotSavepoint records the savepoint for a ledger that is bootstrapped from a snapshot.
+	// The history of the keys committed before the snapshot height is not available in such a ledger
+	RecordSnapshotSavepoint(height *version.Height) error
 }
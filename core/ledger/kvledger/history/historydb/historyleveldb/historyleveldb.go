@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	protoutil "github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 )
 
 var logger historydbLogger = flogging.MustGetLogger("historyleveldb")
@@ -36,15 +37,17 @@ type historydbLogger interface {
 
 // HistoryDBProvider implements interface HistoryDBProvider
 type HistoryDBProvider struct {
-	dbProvider *leveldbhelper.Provider
+	dbProvider                *leveldbhelper.Provider
+	pvtDataHashHistoryEnabled bool
 }
 
 // NewHistoryDBProvider instantiates HistoryDBProvider
-func NewHistoryDBProvider(dbPath string) *HistoryDBProvider {
+func NewHistoryDBProvider(dbPath string, config *ledger.HistoryDBConfig) *HistoryDBProvider {
 	logger.Debugf("constructing HistoryDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
 	return &HistoryDBProvider{
-		dbProvider: dbProvider,
+		dbProvider:                dbProvider,
+		pvtDataHashHistoryEnabled: config != nil && config.PvtDataHashHistoryEnabled,
 	}
 }
 
@@ -53,10 +56,17 @@ func (p *HistoryDBProvider) GetDBHandle(dbName string) (historydb.HistoryDB, err
 	return newHistoryDB(
 			p.dbProvider.GetDBHandle(dbName),
 			dbName,
+			p.pvtDataHashHistoryEnabled,
 		),
 		nil
 }
 
+// Drop drops all the history data of the named database. The database should not be in use
+func (p *HistoryDBProvider) Drop(dbName string) error {
+	return errors.WithMessagef(p.dbProvider.GetDBHandle(dbName).DeleteAll(),
+		"error while dropping the history of [%s]", dbName)
+}
+
 // Close closes the underlying db
 func (p *HistoryDBProvider) Close() {
 	p.dbProvider.Close()
@@ -64,15 +74,17 @@ func (p *HistoryDBProvider) Close() {
 
 // historyDB implements HistoryDB interface
 type historyDB struct {
-	db     *leveldbhelper.DBHandle
-	dbName string
+	db                        *leveldbhelper.DBHandle
+	dbName                    string
+	pvtDataHashHistoryEnabled bool
 }
 
 // newHistoryDB constructs an instance of HistoryDB
-func newHistoryDB(db *leveldbhelper.DBHandle, dbName string) *historyDB {
+func newHistoryDB(db *leveldbhelper.DBHandle, dbName string, pvtDataHashHistoryEnabled bool) *historyDB {
 	return &historyDB{
-		db:     db,
-		dbName: dbName,
+		db:                        db,
+		dbName:                    dbName,
+		pvtDataHashHistoryEnabled: pvtDataHashHistoryEnabled,
 	}
 }
 
@@ -147,6 +159,18 @@ func (h *historyDB) Commit(block *common.Block) error {
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
 				}
+
+				if !h.pvtDataHashHistoryEnabled {
+					continue
+				}
+				for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+					for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+						//composite key for private data hash history records is in the form prefix~ns~coll~keyHash~blockNo~tranNo
+						compositeHistoryKey := historydb.ConstructCompositePvtDataHashHistoryKey(
+							ns, collHashedRWSet.CollectionName, kvWriteHash.KeyHash, blockNo, tranNo)
+						dbBatch.Put(compositeHistoryKey, emptyValue)
+					}
+				}
 			}
 
 		} else {
@@ -184,6 +208,13 @@ func (h *historyDB) GetLastSavepoint() (*version.Height, error) {
 	return height, nil
 }
 
+// RecordSnapshotSavepoint implements method in HistoryDB interface
+func (h *historyDB) RecordSnapshotSavepoint(height *version.Height) error {
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	dbBatch.Put(savePointKey, height.ToBytes())
+	return h.db.WriteBatch(dbBatch, true)
+}
+
 // ShouldRecover implements method in interface kvledger.Recoverer
 func (h *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error) {
 	savepoint, err := h.GetLastSavepoint()
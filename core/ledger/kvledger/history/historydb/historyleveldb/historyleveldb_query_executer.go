@@ -7,9 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protos/common"
@@ -27,28 +34,220 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
-	var compositeStartKey []byte
-	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	return q.GetHistoryForKeyWithMetadata(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithMetadata implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	options, err := parseHistoryQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey, compositeEndKey := options.scanRange(compositePartialKey,
+		historydb.ConstructPartialCompositeHistoryKey(namespace, key, true))
 
 	// range scan to find any history records starting with namespace~key
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore, options,
+		keyModificationExtractor(namespace, key)), nil
+}
+
+// GetPrivateDataHashHistory implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetPrivateDataHashHistory(namespace, collection string, keyHash []byte) (commonledger.ResultsIterator, error) {
+	if !q.historyDB.pvtDataHashHistoryEnabled {
+		return nil, errors.New("private data hash history is not enabled")
+	}
+	compositePartialKey := historydb.ConstructPartialCompositePvtDataHashHistoryKey(namespace, collection, keyHash, false)
+	compositeEndKey := historydb.ConstructPartialCompositePvtDataHashHistoryKey(namespace, collection, keyHash, true)
+
+	// range scan to find any history records starting with prefix~namespace~collection~keyHash
+	dbItr := q.historyDB.db.GetIterator(compositePartialKey, compositeEndKey)
+	return newHistoryScanner(compositePartialKey, namespace, fmt.Sprintf("%s:%x", collection, keyHash), dbItr, q.blockStore,
+		&historyQueryOptions{}, keyHashModificationExtractor(namespace, collection, keyHash)), nil
+}
+
+const (
+	optionStartBlock  = "startBlock"
+	optionEndBlock    = "endBlock"
+	optionStartTime   = "startTime"
+	optionEndTime     = "endTime"
+	optionNewestFirst = "newestFirst"
+	optionLimit       = "limit"
+	optionBookmark    = "bookmark"
+)
+
+// historyQueryOptions holds the query parameters supplied in the metadata of a history query.
+// The bookmark holds the encoded blockNum:tranNum of the first history record to be returned
+type historyQueryOptions struct {
+	startBlock  uint64
+	endBlock    *uint64
+	startTime   *timestamp.Timestamp
+	endTime     *timestamp.Timestamp
+	newestFirst bool
+	limit       int32
+	bookmark    []byte
+}
+
+func parseHistoryQueryMetadata(metadata map[string]interface{}) (*historyQueryOptions, error) {
+	options := &historyQueryOptions{}
+	for option, value := range metadata {
+		ok := true
+		switch option {
+		case optionStartBlock:
+			options.startBlock, ok = value.(uint64)
+		case optionEndBlock:
+			var endBlock uint64
+			endBlock, ok = value.(uint64)
+			options.endBlock = &endBlock
+		case optionStartTime:
+			options.startTime, ok = value.(*timestamp.Timestamp)
+		case optionEndTime:
+			options.endTime, ok = value.(*timestamp.Timestamp)
+		case optionNewestFirst:
+			options.newestFirst, ok = value.(bool)
+		case optionLimit:
+			options.limit, ok = value.(int32)
+		case optionBookmark:
+			var bookmark string
+			if bookmark, ok = value.(string); ok && bookmark != "" {
+				b, err := hex.DecodeString(bookmark)
+				if err != nil {
+					return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+				}
+				if _, _, err := decodeBlockNumTranNum(b); err != nil {
+					return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+				}
+				options.bookmark = b
+			}
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not recognized", option)
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid entry, option %s has an unexpected type %T", option, value)
+		}
+	}
+	if options.endBlock != nil && options.startBlock > *options.endBlock {
+		return nil, errors.Errorf("start block [%d] should not be greater than end block [%d]", options.startBlock, *options.endBlock)
+	}
+	return options, nil
+}
+
+// scanRange returns the start key (inclusive) and the end key (exclusive) of the history records that fall in the
+// range of blocks. If a bookmark is supplied, the range starts (or ends, for newest first) at the bookmark
+func (o *historyQueryOptions) scanRange(compositePartialKey, compositeEndKey []byte) ([]byte, []byte) {
+	withSuffix := func(suffix ...[]byte) []byte {
+		key := append([]byte{}, compositePartialKey...)
+		for _, s := range suffix {
+			key = append(key, s...)
+		}
+		return key
+	}
+	compositeStartKey := compositePartialKey
+	if o.startBlock > 0 {
+		compositeStartKey = withSuffix(util.EncodeOrderPreservingVarUint64(o.startBlock))
+	}
+	if o.endBlock != nil && *o.endBlock < math.MaxUint64 {
+		compositeEndKey = withSuffix(util.EncodeOrderPreservingVarUint64(*o.endBlock + 1))
+	}
+	if o.bookmark != nil {
+		if o.newestFirst {
+			// the nil byte suffix makes the end key just greater than the record at the bookmark
+			compositeEndKey = withSuffix(o.bookmark, []byte{0x00})
+		} else {
+			compositeStartKey = withSuffix(o.bookmark)
+		}
+	}
+	return compositeStartKey, compositeEndKey
+}
+
+// inTimeRange returns true if the given timestamp falls between the start time (inclusive) and the end time (exclusive)
+func (o *historyQueryOptions) inTimeRange(ts *timestamp.Timestamp) bool {
+	if o.startTime != nil && compareTimestamps(ts, o.startTime) < 0 {
+		return false
+	}
+	if o.endTime != nil && compareTimestamps(ts, o.endTime) >= 0 {
+		return false
+	}
+	return true
+}
+
+func compareTimestamps(t1, t2 *timestamp.Timestamp) int {
+	switch {
+	case t1.GetSeconds() != t2.GetSeconds():
+		if t1.GetSeconds() < t2.GetSeconds() {
+			return -1
+		}
+		return 1
+	case t1.GetNanos() < t2.GetNanos():
+		return -1
+	case t1.GetNanos() > t2.GetNanos():
+		return 1
+	default:
+		return 0
+	}
+}
+
+// modificationExtractor returns the modification of the scanned key in a transaction, along with the timestamp
+// of the transaction. A nil modification is returned if the transaction does not modify the scanned key
+type modificationExtractor func(tranEnvelope *common.Envelope, blockNum uint64) (commonledger.QueryResult, *timestamp.Timestamp, error)
+
+func keyModificationExtractor(namespace, key string) modificationExtractor {
+	return func(tranEnvelope *common.Envelope, _ uint64) (commonledger.QueryResult, *timestamp.Timestamp, error) {
+		keyModification, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil || keyModification == nil {
+			return nil, nil, err
+		}
+		return keyModification, keyModification.Timestamp, nil
+	}
+}
+
+func keyHashModificationExtractor(namespace, collection string, keyHash []byte) modificationExtractor {
+	return func(tranEnvelope *common.Envelope, blockNum uint64) (commonledger.QueryResult, *timestamp.Timestamp, error) {
+		keyHashModification, err := getKeyHashModificationFromTran(tranEnvelope, blockNum, namespace, collection, keyHash)
+		if err != nil || keyHashModification == nil {
+			return nil, nil, err
+		}
+		return keyHashModification, keyHashModification.Timestamp, nil
+	}
 }
 
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
 	namespace           string
-	key                 string
+	key                 string // key is used for logging and holds collection:keyHash for the private data hash history
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	options             *historyQueryOptions
+	extract             modificationExtractor
+	positioned          bool
+	numReturned         int32
 }
 
-func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+func newHistoryScanner(compositePartialKey []byte, namespace string, key string, dbItr iterator.Iterator,
+	blockStore blkstorage.BlockStore, options *historyQueryOptions, extract modificationExtractor) *historyScanner {
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+		options:             options,
+		extract:             extract,
+	}
+}
+
+// moveNext moves the underlying db iterator to the next history record in the order requested by the query
+func (scanner *historyScanner) moveNext() bool {
+	if !scanner.options.newestFirst {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.positioned {
+		scanner.positioned = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
 }
 
 // Next iterates to the next key from history scanner, decodes blockNumTranNumBytes to get blockNum and tranNum,
@@ -62,8 +261,11 @@ func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 // was actually added for some other <ns, key, blockNum, tranNum>. It would cause this iterator to
 // return a history query result out of the order.
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.options.limit > 0 && scanner.numReturned >= scanner.options.limit {
+		return nil, nil
+	}
 	for {
-		if !scanner.dbItr.Next() {
+		if !scanner.moveNext() {
 			return nil, nil
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
@@ -110,7 +312,7 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		}
 
 		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-		queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
+		queryResult, ts, err := scanner.extract(tranEnvelope, blockNum)
 		if err != nil {
 			return nil, err
 		}
@@ -123,8 +325,12 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 				historyKey, scanner.key)
 			continue
 		}
-		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
-			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+		if !scanner.options.inTimeRange(ts) {
+			continue
+		}
+		logger.Debugf("Found historic key value for namespace:%s key:%s at blockNumTranNum %v:%v",
+			scanner.namespace, scanner.key, blockNum, tranNum)
+		scanner.numReturned++
 		return queryResult, nil
 	}
 }
@@ -133,58 +339,109 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose implements method in interface `ledger.QueryResultsIterator`. The bookmark refers to the
+// history record that follows the last returned record and is empty if the limit has not been reached
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	if scanner.options.limit <= 0 || scanner.numReturned < scanner.options.limit {
+		return ""
+	}
+	for scanner.moveNext() {
+		_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(scanner.dbItr.Key(), scanner.compositePartialKey)
+		if _, _, err := decodeBlockNumTranNum(blockNumTranNumBytes); err == nil {
+			return hex.EncodeToString(blockNumTranNumBytes)
+		}
+	}
+	return ""
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
-func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
+func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (*queryresult.KeyModification, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
 
+	txRWSet, chdr, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	// look for the namespace and key by looping through the transaction's ReadWriteSets
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace == namespace {
+			// got the correct namespace, now find the key write
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				if kvWrite.Key == key {
+					return &queryresult.KeyModification{TxId: chdr.TxId, Value: kvWrite.Value,
+						Timestamp: chdr.Timestamp, IsDelete: kvWrite.IsDelete}, nil
+				}
+			} // end keys loop
+			logger.Debugf("key [%s] not found in namespace [%s]'s writeset", key, namespace)
+			return nil, nil
+		} // end if
+	} //end namespaces loop
+	logger.Debugf("namespace [%s] not found in transaction's ReadWriteSets", namespace)
+	return nil, nil
+}
+
+// getKeyHashModificationFromTran inspects a transaction for the hashed writes to a given private data key hash
+func getKeyHashModificationFromTran(tranEnvelope *common.Envelope, blockNum uint64,
+	namespace, collection string, keyHash []byte) (*queryresult.KeyHashModification, error) {
+	txRWSet, chdr, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRWSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.KeyHashModification{BlockNum: blockNum, TxId: chdr.TxId,
+						ValueHash: kvWriteHash.ValueHash, Timestamp: chdr.Timestamp, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+		}
+	}
+	logger.Debugf("key hash [%x] not found in collection [%s] of namespace [%s] in transaction's ReadWriteSets",
+		keyHash, collection, namespace)
+	return nil, nil
+}
+
+// getTxRWSetFromTran extracts the read-write set and the channel header of a transaction
+func getTxRWSetFromTran(tranEnvelope *common.Envelope) (*rwsetutil.TxRwSet, *common.ChannelHeader, error) {
 	// extract action from the envelope
 	payload, err := protoutil.GetPayload(tranEnvelope)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	tx, err := protoutil.GetTransaction(payload.Data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	_, respPayload, err := protoutil.GetPayloads(tx.Actions[0])
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	txID := chdr.TxId
-	timestamp := chdr.Timestamp
-
 	txRWSet := &rwsetutil.TxRwSet{}
 
 	// Get the Result from the Action and then Unmarshal
 	// it into a TxReadWriteSet using custom unmarshalling
 	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	// look for the namespace and key by looping through the transaction's ReadWriteSets
-	for _, nsRWSet := range txRWSet.NsRwSets {
-		if nsRWSet.NameSpace == namespace {
-			// got the correct namespace, now find the key write
-			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
-				if kvWrite.Key == key {
-					return &queryresult.KeyModification{TxId: txID, Value: kvWrite.Value,
-						Timestamp: timestamp, IsDelete: kvWrite.IsDelete}, nil
-				}
-			} // end keys loop
-			logger.Debugf("key [%s] not found in namespace [%s]'s writeset", key, namespace)
-			return nil, nil
-		} // end if
-	} //end namespaces loop
-	logger.Debugf("namespace [%s] not found in transaction's ReadWriteSets", namespace)
-	return nil, nil
+	return txRWSet, chdr, nil
 }
 
 // decodeBlockNumTranNum decodes blockNumTranNumBytes to get blockNum and tranNum.
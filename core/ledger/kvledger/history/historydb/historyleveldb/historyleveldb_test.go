@@ -9,10 +9,12 @@ package historyleveldb
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -20,6 +22,8 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb/fakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -96,6 +100,39 @@ func TestSavepoint(t *testing.T) {
 	assert.Equal(t, uint64(3), blockNum)
 }
 
+func TestRecordSnapshotSavepoint(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	assert.NoError(t, env.testHistoryDB.RecordSnapshotSavepoint(version.NewHeight(10, 0)))
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(10, 0), savepoint)
+
+	// no recovery is needed when the block store is also bootstrapped from the same snapshot
+	status, _, err := env.testHistoryDB.ShouldRecover(10)
+	assert.NoError(t, err)
+	assert.False(t, status)
+}
+
+func TestDrop(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	assert.NoError(t, env.testHistoryDBProvider.(*HistoryDBProvider).Drop("TestHistoryDB"))
+
+	// the history db is recovered from the genesis block once dropped
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	status, blockNum, err := env.testHistoryDB.ShouldRecover(0)
+	assert.NoError(t, err)
+	assert.True(t, status)
+	assert.Equal(t, uint64(0), blockNum)
+}
+
 func TestHistory(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -195,6 +232,115 @@ func TestHistory(t *testing.T) {
 	assert.Equal(t, 4, count)
 }
 
+func TestPrivateDataHashHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	store1, err := env.testBlockStorageEnv.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	pvtWriteBlock := func(writes ...[]byte) *common.Block {
+		var simulationResults [][]byte
+		for _, value := range writes {
+			rwsetBuilder := rwsetutil.NewRWSetBuilder()
+			rwsetBuilder.AddToWriteSet("ns1", "pubkey", []byte("pubvalue"))
+			rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", value)
+			rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll2", "key1", []byte("other-value"))
+			simRes, err := rwsetBuilder.GetTxSimulationResults()
+			assert.NoError(t, err)
+			pubSimResBytes, err := simRes.GetPubSimulationBytes()
+			assert.NoError(t, err)
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+		return block
+	}
+	pvtWriteBlock([]byte("value1"))
+	pvtWriteBlock([]byte("value2"), nil)
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err)
+	itr, err := qhistory.GetPrivateDataHashHistory("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	defer itr.Close()
+
+	var results []*queryresult.KeyHashModification
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*queryresult.KeyHashModification))
+	}
+	assert.Len(t, results, 3)
+	expectedBlockNums := []uint64{1, 2, 2}
+	expectedValueHashes := [][]byte{util.ComputeStringHash("value1"), util.ComputeStringHash("value2"), nil}
+	for i, res := range results {
+		assert.Equal(t, expectedBlockNums[i], res.BlockNum)
+		assert.Equal(t, expectedValueHashes[i], res.ValueHash)
+		assert.Equal(t, i == 2, res.IsDelete)
+		assert.NotEmpty(t, res.TxId)
+		assert.NotNil(t, res.Timestamp)
+	}
+
+	// the history of the public key is not affected by the private data hash history
+	testutilVerifyResults(t, qhistory, "ns1", "pubkey", []string{"pubvalue", "pubvalue", "pubvalue"})
+
+	// a key hash that has not been written has an empty history
+	itr, err = qhistory.GetPrivateDataHashHistory("ns1", "coll1", util.ComputeStringHash("key2"))
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	itr.Close()
+}
+
+func TestPrivateDataHashHistoryDisabled(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	store1, err := env.testBlockStorageEnv.provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store1.Shutdown()
+
+	testHistoryDBPath, err := ioutil.TempDir("", "historyldb")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testHistoryDBPath)
+	provider := NewHistoryDBProvider(testHistoryDBPath, &ledger.HistoryDBConfig{Enabled: true})
+	defer provider.Close()
+	hdb, err := provider.GetDBHandle("ledger1")
+	assert.NoError(t, err)
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, hdb.Commit(gb))
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	assert.NoError(t, store1.AddBlock(block1))
+	assert.NoError(t, hdb.Commit(block1))
+
+	// no private data hash history records are added
+	dbItr := hdb.(*historyDB).db.GetIterator(historydb.PvtDataHashHistoryKeyPrefix, []byte{0x02})
+	assert.False(t, dbItr.Next())
+	dbItr.Release()
+
+	qhistory, err := hdb.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err)
+	_, err = qhistory.GetPrivateDataHashHistory("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.EqualError(t, err, "private data hash history is not enabled")
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -505,6 +651,138 @@ func TestHistoryWithBlockNumber256(t *testing.T) {
 	assert.Equal(t, "value256", valueInBlock256)
 }
 
+func TestHistoryWithMetadata(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	store1, err := provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// the blocks 1 to 6 set the value of "key" to "value<blockNum>", except the block 3 that contains two transactions
+	blockValues := [][]string{{"value1"}, {"value2"}, {"value3a", "value3b"}, {"value4"}, {"value5"}, {"value6"}}
+	for _, values := range blockValues {
+		simResults := [][]byte{}
+		for _, value := range values {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			simulator.SetState("ns1", "key", []byte(value))
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simResults = append(simResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	hqe, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+	allValues := []string{"value1", "value2", "value3a", "value3b", "value4", "value5", "value6"}
+	values, timestamps, bookmark := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key", nil)
+	assert.Equal(t, allValues, values)
+	assert.Equal(t, "", bookmark)
+
+	t.Run("block range", func(t *testing.T) {
+		values, _, _ := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key",
+			map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(4)})
+		assert.Equal(t, []string{"value2", "value3a", "value3b", "value4"}, values)
+	})
+
+	t.Run("newest first", func(t *testing.T) {
+		values, _, _ := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key",
+			map[string]interface{}{"newestFirst": true})
+		assert.Equal(t, []string{"value6", "value5", "value4", "value3b", "value3a", "value2", "value1"}, values)
+
+		values, _, _ = testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key",
+			map[string]interface{}{"newestFirst": true, "startBlock": uint64(3)})
+		assert.Equal(t, []string{"value6", "value5", "value4", "value3b", "value3a"}, values)
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		values, _, _ := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key",
+			map[string]interface{}{"startTime": timestamps[1], "endTime": timestamps[4]})
+		assert.Equal(t, []string{"value2", "value3a", "value3b"}, values)
+
+		values, _, _ = testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key",
+			map[string]interface{}{"startTime": timestamps[5], "newestFirst": true})
+		assert.Equal(t, []string{"value6", "value5"}, values)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		pages := [][]string{}
+		metadata := map[string]interface{}{"limit": int32(3), "bookmark": ""}
+		for {
+			values, _, bookmark := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key", metadata)
+			pages = append(pages, values)
+			if bookmark == "" {
+				break
+			}
+			metadata["bookmark"] = bookmark
+		}
+		assert.Equal(t, [][]string{{"value1", "value2", "value3a"}, {"value3b", "value4", "value5"}, {"value6"}}, pages)
+
+		pages = [][]string{}
+		metadata = map[string]interface{}{"limit": int32(2), "newestFirst": true, "endBlock": uint64(5)}
+		for {
+			values, _, bookmark := testutilQueryHistoryWithMetadata(t, hqe, "ns1", "key", metadata)
+			pages = append(pages, values)
+			if bookmark == "" {
+				break
+			}
+			metadata["bookmark"] = bookmark
+		}
+		assert.Equal(t, [][]string{{"value5", "value4"}, {"value3b", "value3a"}, {"value2", "value1"}}, pages)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		_, err := hqe.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"unknown": true})
+		assert.EqualError(t, err, "invalid entry, option unknown not recognized")
+		_, err = hqe.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"startBlock": 1})
+		assert.EqualError(t, err, "invalid entry, option startBlock has an unexpected type int")
+		_, err = hqe.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"startBlock": uint64(5), "endBlock": uint64(4)})
+		assert.EqualError(t, err, "start block [5] should not be greater than end block [4]")
+		_, err = hqe.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"bookmark": "not-a-bookmark"})
+		assert.EqualError(t, err, "invalid bookmark [not-a-bookmark]")
+	})
+}
+
+func TestHistoryQueryOptionsTimeRange(t *testing.T) {
+	options := &historyQueryOptions{
+		startTime: &timestamp.Timestamp{Seconds: 10, Nanos: 500},
+		endTime:   &timestamp.Timestamp{Seconds: 20},
+	}
+	assert.False(t, options.inTimeRange(&timestamp.Timestamp{Seconds: 10, Nanos: 499}))
+	assert.True(t, options.inTimeRange(&timestamp.Timestamp{Seconds: 10, Nanos: 500}))
+	assert.True(t, options.inTimeRange(&timestamp.Timestamp{Seconds: 19, Nanos: 999999999}))
+	assert.False(t, options.inTimeRange(&timestamp.Timestamp{Seconds: 20}))
+	assert.True(t, (&historyQueryOptions{}).inTimeRange(&timestamp.Timestamp{Seconds: 20}))
+}
+
+// testutilQueryHistoryWithMetadata returns the values and the timestamps of the history records
+// returned by a history query with the given metadata, along with the bookmark of the query
+func testutilQueryHistoryWithMetadata(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string,
+	metadata map[string]interface{}) ([]string, []*timestamp.Timestamp, string) {
+	itr, err := hqe.GetHistoryForKeyWithMetadata(ns, key, metadata)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithMetadata()")
+	values := []string{}
+	timestamps := []*timestamp.Timestamp{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		values = append(values, string(kmod.(*queryresult.KeyModification).Value))
+		timestamps = append(timestamps, kmod.(*queryresult.KeyModification).Timestamp)
+	}
+	return values, timestamps, itr.GetBookmarkAndClose()
+}
+
 func testutilVerifyResults(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, expectedVals []string) {
 	itr, err := hqe.GetHistoryForKey(ns, key)
 	assert.NoError(t, err, "Error upon GetHistoryForKey()")
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
@@ -40,6 +41,11 @@ type kvLedger struct {
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
+
+	vdb                       privacyenabledstate.DB
+	configHistoryMgr          confighistory.Mgr
+	snapshotsConfig           *ledger.SnapshotsConfig
+	snapshotRequestBookkeeper *snapshotRequestBookkeeper
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -53,12 +59,22 @@ func newKVLedger(
 	bookkeeperProvider bookkeeping.Provider,
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider,
 	ccLifecycleEventProvider ledger.ChaincodeLifecycleEventProvider,
+	snapshotsConfig *ledger.SnapshotsConfig,
 	stats *ledgerStats,
 ) (*kvLedger, error) {
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{
+		ledgerID:                  ledgerID,
+		blockStore:                blockStore,
+		historyDB:                 historyDB,
+		blockAPIsRWLock:           &sync.RWMutex{},
+		vdb:                       versionedDB,
+		configHistoryMgr:          configHistoryMgr,
+		snapshotsConfig:           snapshotsConfig,
+		snapshotRequestBookkeeper: newSnapshotRequestBookkeeper(ledgerID, bookkeeperProvider),
+	}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, ccInfoProvider})
 	if err := l.initTxMgr(versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
@@ -73,8 +89,14 @@ func newKVLedger(
 	ccEventListener := versionedDB.GetChaincodeEventListener()
 	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
 	if ccEventListener != nil {
-		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
-		ccLifecycleEventProvider.RegisterListener(l.ledgerID, &ccEventListenerAdaptor{ccEventListener})
+		// the event sources are not set up when the ledger is used outside of a peer, for instance, by
+		// the offline peer commands, in which case there are no chaincode deployments to listen to
+		if ccEventMgr := cceventmgmt.GetMgr(); ccEventMgr != nil {
+			ccEventMgr.Register(ledgerID, ccEventListener)
+		}
+		if ccLifecycleEventProvider != nil {
+			ccLifecycleEventProvider.RegisterListener(l.ledgerID, &ccEventListenerAdaptor{ccEventListener})
+		}
 	}
 
 	//Recover both state DB and history DB if they are out of sync with block storage
@@ -84,6 +106,19 @@ func newKVLedger(
 	l.configHistoryRetriever = configHistoryMgr.GetRetriever(ledgerID, l)
 
 	l.stats = stats
+
+	// A snapshot request may be pending for the last committed block if the peer
+	// crashed after committing the block but before generating the snapshot
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	// Similarly, the pvtdata keys purged by the last committed block may not have
+	// been removed from the pvtdata store. Purging is idempotent
+	if info.Height > 0 {
+		l.processSnapshotRequest(info.Height - 1)
+		l.reapplyPvtKeyPurges(info.Height - 1)
+	}
 	return l, nil
 }
 
@@ -283,6 +318,22 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, err
 }
 
+// GetTransactionsByChaincodeNamespace returns a page of the transactions that touched the given chaincode namespace
+func (l *kvLedger) GetTransactionsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	txs, nextBookmark, err := l.blockStore.RetrieveTxsByChaincodeNamespace(namespace, bookmark, pageSize)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txs, nextBookmark, err
+}
+
+// GetTransactionsByCreator returns a page of the transactions that were created by the given serialized identity
+func (l *kvLedger) GetTransactionsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error) {
+	txs, nextBookmark, err := l.blockStore.RetrieveTxsByCreator(creator, bookmark, pageSize)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txs, nextBookmark, err
+}
+
 // NewTxSimulator returns new `ledger.TxSimulator`
 func (l *kvLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	return l.txtmgmt.NewTxSimulator(txid)
@@ -327,6 +378,9 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
 		return err
 	}
+	if err = l.purgePvtKeys(block); err != nil {
+		panic(errors.WithMessage(err, "error while purging pvtdata keys"))
+	}
 	elapsedBlockstorageAndPvtdataCommit := time.Since(startBlockstorageAndPvtdataCommit)
 
 	startCommitState := time.Now()
@@ -345,6 +399,8 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		}
 	}
 
+	l.processSnapshotRequest(blockNo)
+
 	logger.Infof("[%s] Committed block [%d] with %d transaction(s) in %dms (state_validation=%dms block_and_pvtdata_commit=%dms state_commit=%dms)",
 		l.ledgerID, block.Header.Number, len(block.Data.Data),
 		time.Since(startBlockProcessing)/time.Millisecond,
@@ -361,6 +417,35 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	return nil
 }
 
+// purgePvtKeys removes from the pvtdata store the past versions of the keys
+// purged by the valid transactions in the block
+func (l *kvLedger) purgePvtKeys(block *common.Block) error {
+	purgedKeys, err := rwsetutil.GetPurgedPvtKeys(block)
+	if err != nil {
+		return err
+	}
+	return l.blockStore.PurgePvtKeys(purgedKeys)
+}
+
+func (l *kvLedger) reapplyPvtKeyPurges(blockNum uint64) {
+	block, err := l.blockStore.RetrieveBlockByNumber(blockNum)
+	if err != nil {
+		// the block is not available if the ledger was bootstrapped from a snapshot
+		// and no block has been committed since then
+		logger.Warningf("[%s] Could not retrieve block [%d] to re-apply its pvtdata purges: %s", l.ledgerID, blockNum, err)
+		return
+	}
+	if err := l.purgePvtKeys(block); err != nil {
+		panic(errors.WithMessagef(err, "error while re-applying the pvtdata purges of block [%d]", blockNum))
+	}
+}
+
+// GetPurgeRecords returns the records of the pvtdata keys purged by the blocks
+// within the given range (both inclusive)
+func (l *kvLedger) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	return l.blockStore.GetPurgeRecords(startBlockNum, endBlockNum)
+}
+
 func (l *kvLedger) updateBlockStats(
 	blockProcessingTime time.Duration,
 	blockstorageAndPvtdataCommitTime time.Duration,
@@ -379,6 +464,18 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.blockStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information of eligible
+// collections for the blocks in the range [startBlk, endBlk]
+func (l *kvLedger) GetMissingPvtDataInfoForBlockRange(startBlk, endBlk uint64) (ledger.MissingPvtDataInfo, error) {
+	return l.blockStore.GetMissingPvtDataInfoForBlockRange(startBlk, endBlk)
+}
+
+// GetMissingPvtDataStats returns, for each collection, statistics about both the eligible and
+// the ineligible private data that is missing on the peer
+func (l *kvLedger) GetMissingPvtDataStats() ([]*ledger.MissingCollectionPvtDataStats, error) {
+	return l.blockStore.GetMissingPvtDataStats()
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (l *kvLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
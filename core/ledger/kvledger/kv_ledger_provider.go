@@ -8,10 +8,12 @@ package kvledger
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
@@ -19,7 +21,10 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pvtstatepurgemgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protoutil"
@@ -51,11 +56,15 @@ type Provider struct {
 	initializer         *ledger.Initializer
 	collElgNotifier     *collElgNotifier
 	stats               *stats
+	snapshotsConfig     *ledger.SnapshotsConfig
 }
 
 // NewProvider instantiates a new Provider.
 // This is not thread-safe and assumed to be synchronized by the caller
 func NewProvider(initializer *ledger.Initializer) (*Provider, error) {
+	if err := validateBlockArchiveConfig(initializer.Config.BlockArchiveConfig); err != nil {
+		return nil, err
+	}
 	p := &Provider{}
 	p.initializer = initializer
 	// initialize the ID store (inventory of chainIds/ledgerIds)
@@ -69,6 +78,8 @@ func NewProvider(initializer *ledger.Initializer) (*Provider, error) {
 	ledgerStoreProvider := ledgerstorage.NewProvider(
 		p.initializer.Config.RootFSPath,
 		privateData,
+		p.initializer.Config.BlockArchiveConfig,
+		p.initializer.Config.BlockIndexConfig,
 		p.initializer.MetricsProvider,
 	)
 	p.ledgerStoreProvider = ledgerStoreProvider
@@ -76,6 +87,7 @@ func NewProvider(initializer *ledger.Initializer) (*Provider, error) {
 		// Initialize the history database (index for history of values by key)
 		historydbProvider := historyleveldb.NewHistoryDBProvider(
 			filepath.Join(p.initializer.Config.RootFSPath, "historyLeveldb"),
+			initializer.Config.HistoryDBConfig,
 		)
 		p.historydbProvider = historydbProvider
 	}
@@ -105,6 +117,7 @@ func NewProvider(initializer *ledger.Initializer) (*Provider, error) {
 	stateDB := &privacyenabledstate.StateDBConfig{
 		StateDBConfig: initializer.Config.StateDBConfig,
 		LevelDBPath:   filepath.Join(initializer.Config.RootFSPath, "stateLeveldb"),
+		BoltDBPath:    filepath.Join(initializer.Config.RootFSPath, "stateBoltdb"),
 	}
 	p.vdbProvider, err = privacyenabledstate.NewCommonStorageDBProvider(
 		p.bookkeepingProvider,
@@ -116,6 +129,12 @@ func NewProvider(initializer *ledger.Initializer) (*Provider, error) {
 		return nil, err
 	}
 	p.stats = newStats(initializer.MetricsProvider)
+	p.snapshotsConfig = initializer.Config.SnapshotsConfig
+	if p.snapshotsConfig == nil || p.snapshotsConfig.RootDir == "" {
+		p.snapshotsConfig = &ledger.SnapshotsConfig{
+			RootDir: filepath.Join(initializer.Config.RootFSPath, "snapshots"),
+		}
+	}
 	p.recoverUnderConstructionLedger()
 	return p, nil
 }
@@ -157,6 +176,106 @@ func (p *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger, error)
 	return lgr, nil
 }
 
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function verifies the hashes of the snapshot files before loading any data. Like the function
+// `Create`, this function sets the under construction flag before loading the data from the snapshot
+// and upon a successful load, removes the flag and adds the entry into created ledgers list (atomically)
+func (p *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, metadataBytes, err := loadAndVerifySnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := p.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = p.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	lgr, err := p.createFromSnapshot(snapshotDir, metadata)
+	if err != nil {
+		logger.Errorf("Error creating ledger [%s] from snapshot. Unsetting under construction flag. Error: %+v", ledgerID, err)
+		panicOnErr(p.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(p.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	panicOnErr(p.idStore.addLedgerID(ledgerID, metadataBytes), "Error while marking ledger as created")
+	return lgr, ledgerID, nil
+}
+
+func (p *Provider) createFromSnapshot(snapshotDir string, metadata *snapshotSignableMetadata) (ledger.PeerLedger, error) {
+	ledgerID := metadata.ChannelName
+	lastBlockHash, err := hex.DecodeString(metadata.LastBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding the last block hash from the snapshot metadata")
+	}
+	previousBlockHash, err := hex.DecodeString(metadata.PreviousBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding the previous block hash from the snapshot metadata")
+	}
+	blockStore, err := p.ledgerStoreProvider.BootstrapFromSnapshot(
+		snapshotDir,
+		&blkstorage.SnapshotInfo{
+			LastBlockNum:      metadata.LastBlockNumber,
+			LastBlockHash:     lastBlockHash,
+			PreviousBlockHash: previousBlockHash,
+		},
+		ledgerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the block store is opened again by the function `openInternal`
+	blockStore.Shutdown()
+
+	savepoint := version.NewHeight(metadata.LastBlockNumber, 0)
+	if err := p.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return nil, err
+	}
+	if p.historydbProvider != nil {
+		historyDB, err := p.historydbProvider.GetDBHandle(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if err := historyDB.RecordSnapshotSavepoint(savepoint); err != nil {
+			return nil, err
+		}
+	}
+	vdb, err := p.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := vdb.ImportPubStateAndPvtStateHashes(snapshotDir, savepoint); err != nil {
+		return nil, err
+	}
+
+	lgr, err := p.openInternal(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	// the expiry schedule of the private data hashes depends on the collection configs that become
+	// available only after the state is loaded, hence the bookkeeping is updated via the opened ledger
+	purgeMgr, err := pvtstatepurgemgmt.InstantiatePurgeMgr(
+		ledgerID,
+		vdb,
+		pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, lgr, p.initializer.DeployedChaincodeInfoProvider}),
+		p.bookkeepingProvider,
+	)
+	if err != nil {
+		lgr.Close()
+		return nil, err
+	}
+	if err := privacyenabledstate.LoadPvtStateHashesFromSnapshot(snapshotDir, purgeMgr.UpdateBookkeepingForImportedHashes); err != nil {
+		lgr.Close()
+		return nil, err
+	}
+	return lgr, nil
+}
+
 // Open implements the corresponding method from interface ledger.PeerLedgerProvider
 func (p *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	logger.Debugf("Open() opening kvledger: %s", ledgerID)
@@ -206,6 +325,7 @@ func (p *Provider) openInternal(ledgerID string) (ledger.PeerLedger, error) {
 		p.bookkeepingProvider,
 		p.initializer.DeployedChaincodeInfoProvider,
 		p.initializer.ChaincodeLifecycleEventProvider,
+		p.snapshotsConfig,
 		p.stats.ledgerStats(ledgerID),
 	)
 	if err != nil {
@@ -292,6 +412,20 @@ func panicOnErr(err error, mgsFormat string, args ...interface{}) {
 	panic(fmt.Sprintf(mgsFormat+" Error: %s", args...))
 }
 
+// validateBlockArchiveConfig returns an error if the archiving of the block files is enabled with an invalid configuration
+func validateBlockArchiveConfig(c *ledger.BlockArchiveConfig) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	if c.RootDir == "" {
+		return errors.New("root dir for the block archive is not specified")
+	}
+	if c.Format != "dir" && c.Format != "tar" {
+		return errors.Errorf("unsupported block archive format [%s], the supported formats are dir and tar", c.Format)
+	}
+	return nil
+}
+
 //////////////////////////////////////////////////////////////////////
 // Ledger id persistence related code
 ///////////////////////////////////////////////////////////////////////
@@ -322,20 +456,27 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 }
 
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
+	gbBytes, err := proto.Marshal(gb)
+	if err != nil {
+		return err
+	}
+	return s.addLedgerID(ledgerID, gbBytes)
+}
+
+// addLedgerID adds the ledger id to the list of created ledgers and unsets the under construction flag (atomically).
+// The metadata is the genesis block for a ledger created from a genesis block and the signable metadata of the
+// snapshot for a ledger created from a snapshot
+func (s *idStore) addLedgerID(ledgerID string, metadata []byte) error {
 	key := s.encodeLedgerKey(ledgerID)
-	var val []byte
-	var err error
-	if val, err = s.db.Get(key); err != nil {
+	val, err := s.db.Get(key)
+	if err != nil {
 		return err
 	}
 	if val != nil {
 		return ErrLedgerIDExists
 	}
-	if val, err = proto.Marshal(gb); err != nil {
-		return err
-	}
 	batch := &leveldb.Batch{}
-	batch.Put(key, val)
+	batch.Put(key, metadata)
 	batch.Delete(underConstructionLedgerKey)
 	return s.db.WriteBatch(batch, true)
 }
@@ -350,6 +491,15 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 	return val != nil, nil
 }
 
+// getLedgerMetadata returns the metadata that was supplied while adding the ledger id, or nil if the ledger id does not exist
+func (s *idStore) getLedgerMetadata(ledgerID string) ([]byte, error) {
+	return s.db.Get(s.encodeLedgerKey(ledgerID))
+}
+
+func (s *idStore) deleteLedgerID(ledgerID string) error {
+	return s.db.Delete(s.encodeLedgerKey(ledgerID), true)
+}
+
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
 	itr := s.db.GetIterator(nil, nil)
@@ -357,6 +507,7 @@ func (s *idStore) getAllLedgerIds() ([]string, error) {
 	itr.First()
 	for itr.Valid() {
 		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
+			itr.Next()
 			continue
 		}
 		id := string(s.decodeLedgerID(itr.Key()))
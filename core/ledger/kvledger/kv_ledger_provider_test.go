@@ -229,6 +229,19 @@ func TestRecoveryHistoryDBDisabled(t *testing.T) {
 
 }
 
+func TestInvalidBlockArchiveConfig(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+
+	conf.BlockArchiveConfig = &lgr.BlockArchiveConfig{Enabled: true, Format: "dir"}
+	_, err := NewProvider(&lgr.Initializer{Config: conf})
+	assert.EqualError(t, err, "root dir for the block archive is not specified")
+
+	conf.BlockArchiveConfig = &lgr.BlockArchiveConfig{Enabled: true, RootDir: "/archive", Format: "zip"}
+	_, err = NewProvider(&lgr.Initializer{Config: conf})
+	assert.EqualError(t, err, "unsupported block archive format [zip], the supported formats are dir and tar")
+}
+
 func TestMultipleLedgerBasicRW(t *testing.T) {
 	conf, cleanup := testConfig(t)
 	defer cleanup()
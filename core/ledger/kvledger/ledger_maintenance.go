@@ -0,0 +1,281 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/pkg/errors"
+)
+
+// The functions in this file maintain the ledgers while the peer is not running. The state database,
+// the history database, and the config history of a ledger are derived from the blocks of the ledger
+// and hence these are dropped whenever the blocks are changed. The dropped databases are rebuilt by the
+// recovery path (see recovery.go) when the ledger is opened next time, by recommitting the blocks from
+// the genesis block. Consequently, these functions are not supported for a ledger that is created from
+// a snapshot, as the blocks before the snapshot are not available in such a ledger
+
+// RollbackKVLedger rolls back the ledger of the given channel to the given block number
+func RollbackKVLedger(config *ledger.Config, ledgerID string, blockNum uint64) error {
+	s, err := openOfflineStores(config)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	if err := s.checkRebuildable(ledgerID); err != nil {
+		return err
+	}
+	if err := s.ledgerStoreProvider.ValidateRollback(ledgerID, blockNum); err != nil {
+		return err
+	}
+	if err := s.dropDerivedDBs(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Rolling back the block store of ledger [%s] to block number [%d]", ledgerID, blockNum)
+	if err := s.ledgerStoreProvider.Rollback(ledgerID, blockNum); err != nil {
+		return err
+	}
+	logger.Infof("Ledger [%s] has been rolled back to block number [%d]", ledgerID, blockNum)
+	return nil
+}
+
+// ResetAllKVLedgers rolls back the ledgers of all the channels to their genesis blocks
+func ResetAllKVLedgers(config *ledger.Config) error {
+	s, err := openOfflineStores(config)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	ledgerIDs, err := s.idStore.getAllLedgerIds()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := s.checkRebuildable(ledgerID); err != nil {
+			return err
+		}
+		if err := s.ledgerStoreProvider.ValidateRollback(ledgerID, 0); err != nil {
+			return err
+		}
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := s.dropDerivedDBs(ledgerID); err != nil {
+			return err
+		}
+		logger.Infof("Resetting the block store of ledger [%s] to the genesis block", ledgerID)
+		if err := s.ledgerStoreProvider.Rollback(ledgerID, 0); err != nil {
+			return err
+		}
+	}
+	logger.Infof("All the ledgers have been reset to the genesis block")
+	return nil
+}
+
+// RebuildDBs drops the state database, the history database, and the config history of all the ledgers,
+// so that these are rebuilt from the blocks when the peer is started next time
+func RebuildDBs(config *ledger.Config) error {
+	s, err := openOfflineStores(config)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	ledgerIDs, err := s.idStore.getAllLedgerIds()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := s.checkRebuildable(ledgerID); err != nil {
+			return err
+		}
+	}
+	for _, ledgerID := range ledgerIDs {
+		if err := s.dropDerivedDBs(ledgerID); err != nil {
+			return err
+		}
+	}
+	logger.Infof("The databases of all the ledgers have been dropped and will be rebuilt when the peer is started")
+	return nil
+}
+
+// UnjoinChannel removes the ledger of the given channel. The ledger is first removed from the list of the
+// created ledgers and then all the data of the ledger is dropped. If this function fails midway, the data of
+// the ledger that is left behind is dropped when this function is invoked again for the same channel
+func UnjoinChannel(config *ledger.Config, ledgerID string) error {
+	s, err := openOfflineStores(config)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	exists, err := s.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		blockStoreExists, err := s.ledgerStoreProvider.Exists(ledgerID)
+		if err != nil {
+			return err
+		}
+		if !blockStoreExists {
+			return errors.Errorf("ledger [%s] does not exist", ledgerID)
+		}
+		logger.Infof("Ledger [%s] is not in the list of created ledgers, dropping the data left behind", ledgerID)
+	}
+	if err := s.idStore.deleteLedgerID(ledgerID); err != nil {
+		return err
+	}
+	if err := s.dropDerivedDBs(ledgerID); err != nil {
+		return err
+	}
+	if err := s.bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.SnapshotRequest).DeleteAll(); err != nil {
+		return errors.WithMessagef(err, "error while dropping the snapshot requests of [%s]", ledgerID)
+	}
+	if err := s.ledgerStoreProvider.Drop(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Ledger [%s] has been removed", ledgerID)
+	return nil
+}
+
+// offlineStores encapsulates the stores of all the ledgers, for maintaining the ledgers while the peer is not running
+type offlineStores struct {
+	idStore             *idStore
+	ledgerStoreProvider *ledgerstorage.Provider
+	vdbProvider         privacyenabledstate.DBProvider
+	historydbProvider   *historyleveldb.HistoryDBProvider
+	configHistoryMgr    confighistory.Mgr
+	bookkeepingProvider bookkeeping.Provider
+}
+
+// openOfflineStores opens the stores of all the ledgers. The ID store is opened first and fails to open if the
+// peer is running, which prevents the other stores from being modified while the peer is using them
+func openOfflineStores(config *ledger.Config) (*offlineStores, error) {
+	idStore, err := tryOpenIDStore(filepath.Join(config.RootFSPath, "ledgerProvider"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the ledger data, make sure that the peer is stopped")
+	}
+	s := &offlineStores{idStore: idStore}
+	s.ledgerStoreProvider = ledgerstorage.NewProvider(
+		config.RootFSPath,
+		&pvtdatastorage.PrivateDataConfig{
+			PrivateDataConfig: config.PrivateDataConfig,
+			StorePath:         filepath.Join(config.RootFSPath, "pvtdataStore"),
+		},
+		config.BlockArchiveConfig,
+		config.BlockIndexConfig,
+		&disabled.Provider{},
+	)
+	// the history database may be present from a time when it was enabled
+	historyDBPath := filepath.Join(config.RootFSPath, "historyLeveldb")
+	if _, err := os.Stat(historyDBPath); err == nil {
+		s.historydbProvider = historyleveldb.NewHistoryDBProvider(historyDBPath, config.HistoryDBConfig)
+	}
+	s.configHistoryMgr = confighistory.NewMgr(filepath.Join(config.RootFSPath, "configHistory"), nil)
+	s.bookkeepingProvider = bookkeeping.NewProvider(filepath.Join(config.RootFSPath, "bookkeeper"))
+	s.vdbProvider, err = privacyenabledstate.NewCommonStorageDBProvider(
+		s.bookkeepingProvider,
+		&disabled.Provider{},
+		&noopHealthCheckRegistry{},
+		&privacyenabledstate.StateDBConfig{
+			StateDBConfig: config.StateDBConfig,
+			LevelDBPath:   filepath.Join(config.RootFSPath, "stateLeveldb"),
+			BoltDBPath:    filepath.Join(config.RootFSPath, "stateBoltdb"),
+		},
+	)
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkRebuildable returns an error if the given ledger does not exist or if the ledger is created from a snapshot
+func (s *offlineStores) checkRebuildable(ledgerID string) error {
+	metadata, err := s.idStore.getLedgerMetadata(ledgerID)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		return errors.Errorf("ledger [%s] does not exist", ledgerID)
+	}
+	if isCreatedFromSnapshot(metadata) {
+		return errors.Errorf("ledger [%s] is created from a snapshot and its databases cannot be rebuilt from the blocks", ledgerID)
+	}
+	return nil
+}
+
+// dropDerivedDBs drops the data of the given ledger that is derived from the blocks of the ledger.
+// The private data store is not dropped, as the private data is not available in the blocks
+func (s *offlineStores) dropDerivedDBs(ledgerID string) error {
+	logger.Infof("Dropping the databases of ledger [%s]", ledgerID)
+	if err := s.vdbProvider.Drop(ledgerID); err != nil {
+		return err
+	}
+	if s.historydbProvider != nil {
+		if err := s.historydbProvider.Drop(ledgerID); err != nil {
+			return err
+		}
+	}
+	if err := s.configHistoryMgr.Drop(ledgerID); err != nil {
+		return err
+	}
+	return errors.WithMessagef(
+		s.bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.PvtdataExpiry).DeleteAll(),
+		"error while dropping the pvtdata expiry bookkeeping of [%s]", ledgerID,
+	)
+}
+
+func (s *offlineStores) close() {
+	if s.vdbProvider != nil {
+		s.vdbProvider.Close()
+	}
+	if s.bookkeepingProvider != nil {
+		s.bookkeepingProvider.Close()
+	}
+	if s.configHistoryMgr != nil {
+		s.configHistoryMgr.Close()
+	}
+	if s.historydbProvider != nil {
+		s.historydbProvider.Close()
+	}
+	if s.ledgerStoreProvider != nil {
+		s.ledgerStoreProvider.Close()
+	}
+	s.idStore.close()
+}
+
+// isCreatedFromSnapshot returns true if the metadata of a ledger, as maintained in the ID store,
+// is the signable metadata of a snapshot rather than the genesis block
+func isCreatedFromSnapshot(metadata []byte) bool {
+	snapshotMetadata := &snapshotSignableMetadata{}
+	return json.Unmarshal(metadata, snapshotMetadata) == nil && snapshotMetadata.ChannelName != ""
+}
+
+type noopHealthCheckRegistry struct{}
+
+func (*noopHealthCheckRegistry) RegisterChecker(string, healthz.HealthChecker) error {
+	return nil
+}
+
+func tryOpenIDStore(path string) (*idStore, error) {
+	db := leveldbhelper.CreateDB(&leveldbhelper.Conf{DBPath: path})
+	if err := db.TryOpen(); err != nil {
+		return nil, err
+	}
+	return &idStore{db}, nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackKVLedger(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1", "ledger2"}, 5)
+
+	provider := testutilNewProvider(conf, t)
+	l, err := provider.Open("ledger1")
+	require.NoError(t, err)
+	err = RollbackKVLedger(conf, "ledger1", 2)
+	require.EqualError(t, err, "error while opening the ledger data, make sure that the peer is stopped: "+
+		"Error opening leveldb: resource temporarily unavailable")
+	l.Close()
+	provider.Close()
+
+	require.EqualError(t, RollbackKVLedger(conf, "ledger1", 6),
+		"target block number [6] should not be greater than the last block number [5] of ledger [ledger1]")
+	require.EqualError(t, RollbackKVLedger(conf, "nonExistingLedger", 2), "ledger [nonExistingLedger] does not exist")
+	require.NoError(t, RollbackKVLedger(conf, "ledger1", 2))
+
+	provider = testutilNewProvider(conf, t)
+	defer provider.Close()
+	verifyTestLedger(t, provider, "ledger1", 2)
+	verifyTestLedger(t, provider, "ledger2", 5)
+}
+
+func TestResetAllKVLedgers(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1", "ledger2"}, 5)
+	populateTestLedgers(t, conf, []string{"ledger3"}, 0)
+
+	require.NoError(t, ResetAllKVLedgers(conf))
+	// resetting again is a no-op for the block stores
+	require.NoError(t, ResetAllKVLedgers(conf))
+
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+	for _, ledgerID := range []string{"ledger1", "ledger2", "ledger3"} {
+		verifyTestLedger(t, provider, ledgerID, 0)
+	}
+}
+
+func TestRebuildDBs(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1", "ledger2"}, 5)
+
+	require.NoError(t, RebuildDBs(conf))
+	s, err := openOfflineStores(conf)
+	require.NoError(t, err)
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		db, err := s.vdbProvider.GetDBHandle(ledgerID)
+		require.NoError(t, err)
+		savepoint, err := db.GetLatestSavePoint()
+		require.NoError(t, err)
+		require.Nil(t, savepoint)
+	}
+	s.close()
+
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+	verifyTestLedger(t, provider, "ledger1", 5)
+	verifyTestLedger(t, provider, "ledger2", 5)
+}
+
+func TestUnjoinChannel(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1", "ledger2"}, 5)
+
+	provider := testutilNewProvider(conf, t)
+	l, err := provider.Open("ledger1")
+	require.NoError(t, err)
+	require.NoError(t, l.SubmitSnapshotRequest(10))
+	l.Close()
+	provider.Close()
+
+	require.NoError(t, UnjoinChannel(conf, "ledger1"))
+	require.EqualError(t, UnjoinChannel(conf, "ledger1"), "ledger [ledger1] does not exist")
+
+	s, err := openOfflineStores(conf)
+	require.NoError(t, err)
+	exists, err := s.ledgerStoreProvider.Exists("ledger1")
+	require.NoError(t, err)
+	require.False(t, exists)
+	itr := s.bookkeepingProvider.GetDBHandle("ledger1", bookkeeping.SnapshotRequest).GetIterator(nil, nil)
+	require.False(t, itr.Next())
+	itr.Release()
+	s.close()
+
+	provider = testutilNewProvider(conf, t)
+	defer provider.Close()
+	ledgerIDs, err := provider.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ledger2"}, ledgerIDs)
+	verifyTestLedger(t, provider, "ledger2", 5)
+
+	// the ledger can be created again after unjoining
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	l, err = provider.Create(gb)
+	require.NoError(t, err)
+	l.Close()
+}
+
+func TestMaintenanceOfLedgerCreatedFromSnapshot(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1"}, 2)
+
+	// simulate a ledger created from a snapshot
+	provider := testutilNewProvider(conf, t)
+	metadata, err := json.Marshal(&snapshotSignableMetadata{ChannelName: "ledger2", LastBlockNumber: 10})
+	require.NoError(t, err)
+	require.NoError(t, provider.idStore.addLedgerID("ledger2", metadata))
+	provider.Close()
+
+	expectedErr := "ledger [ledger2] is created from a snapshot and its databases cannot be rebuilt from the blocks"
+	require.EqualError(t, RollbackKVLedger(conf, "ledger2", 1), expectedErr)
+	require.EqualError(t, ResetAllKVLedgers(conf), expectedErr)
+	require.EqualError(t, RebuildDBs(conf), expectedErr)
+
+	// none of the ledgers is changed by the failed commands
+	provider = testutilNewProvider(conf, t)
+	defer provider.Close()
+	verifyTestLedger(t, provider, "ledger1", 2)
+}
+
+func TestGetAllLedgerIdsWithUnderConstructionFlag(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	populateTestLedgers(t, conf, []string{"ledger1", "ledger2"}, 0)
+
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+	require.NoError(t, provider.idStore.setUnderConstructionFlag("ledger3"))
+	ledgerIDs, err := provider.idStore.getAllLedgerIds()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ledger1", "ledger2"}, ledgerIDs)
+}
+
+// populateTestLedgers creates the given ledgers with the given number of blocks after the genesis block.
+// The block number `i` sets the key `key` to the value `value-i` and the key `key-i` to the value `i`
+func populateTestLedgers(t *testing.T, conf *lgr.Config, ledgerIDs []string, numBlocks int) {
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+	for _, ledgerID := range ledgerIDs {
+		bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+		l, err := provider.Create(gb)
+		require.NoError(t, err)
+		for i := 1; i <= numBlocks; i++ {
+			simulator, err := l.NewTxSimulator(util.GenerateUUID())
+			require.NoError(t, err)
+			require.NoError(t, simulator.SetState("ns", "key", []byte(fmt.Sprintf("value-%d", i))))
+			require.NoError(t, simulator.SetState("ns", fmt.Sprintf("key-%d", i), []byte(fmt.Sprintf("%d", i))))
+			simulator.Done()
+			simRes, err := simulator.GetTxSimulationResults()
+			require.NoError(t, err)
+			pubSimBytes, err := simRes.GetPubSimulationBytes()
+			require.NoError(t, err)
+			require.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+		}
+		l.Close()
+	}
+}
+
+// verifyTestLedger verifies that the given ledger, populated by the function `populateTestLedgers`,
+// contains the blocks up to the given block number and that the state and the history reflect these blocks
+func verifyTestLedger(t *testing.T, provider *Provider, ledgerID string, lastBlockNum int) {
+	l, err := provider.Open(ledgerID)
+	require.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(lastBlockNum+1), bcInfo.Height)
+	_, err = l.GetBlockByNumber(uint64(lastBlockNum + 1))
+	require.Error(t, err)
+
+	qe, err := l.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState("ns", "key")
+	require.NoError(t, err)
+	if lastBlockNum == 0 {
+		require.Nil(t, val)
+	} else {
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", lastBlockNum)), val)
+	}
+	val, err = qe.GetState("ns", fmt.Sprintf("key-%d", lastBlockNum+1))
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	hqe, err := l.NewHistoryQueryExecutor()
+	require.NoError(t, err)
+	itr, err := hqe.GetHistoryForKey("ns", "key")
+	require.NoError(t, err)
+	defer itr.Close()
+	numHistoryEntries := 0
+	for {
+		kmod, err := itr.Next()
+		require.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		numHistoryEntries++
+	}
+	require.Equal(t, lastBlockNum, numHistoryEntries)
+}
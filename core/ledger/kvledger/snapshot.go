@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotSignableMetadataFileName   = "_snapshot_signable_metadata.json"
+	snapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
+)
+
+// snapshotSignableMetadata is the metadata of a snapshot that is expected to be same across the peers
+// that generate the snapshot of a channel at the same height. The hash of this metadata can be compared
+// across peers for verifying the consistency of a snapshot before using it for bootstrapping a peer
+type snapshotSignableMetadata struct {
+	ChannelName            string            `json:"channel_name"`
+	LastBlockNumber        uint64            `json:"last_block_number"`
+	LastBlockHashInHex     string            `json:"last_block_hash"`
+	PreviousBlockHashInHex string            `json:"previous_block_hash"`
+	FilesAndHashes         map[string]string `json:"snapshot_files_raw_hashes"`
+}
+
+// snapshotAdditionalMetadata is the metadata of a snapshot that is local to the peer that generates the snapshot
+type snapshotAdditionalMetadata struct {
+	SnapshotHashInHex string `json:"snapshot_hash"`
+}
+
+// SnapshotsTempDirPath returns the dir path that is used temporarily during the generation of the snapshots
+func SnapshotsTempDirPath(snapshotsRootDir string) string {
+	return filepath.Join(snapshotsRootDir, "temp")
+}
+
+// CompletedSnapshotsPath returns the absolute path that is used for persisting the snapshots
+func CompletedSnapshotsPath(snapshotsRootDir string) string {
+	return filepath.Join(snapshotsRootDir, "completed")
+}
+
+// SnapshotDirForLedgerBlockNum returns the absolute path for a particular snapshot for a ledger
+func SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID string, blockNumber uint64) string {
+	return filepath.Join(CompletedSnapshotsPath(snapshotsRootDir), ledgerID, strconv.FormatUint(blockNumber, 10))
+}
+
+// ListCompletedSnapshots returns the block numbers, in increasing order, of the snapshots that
+// have been generated for the given ledger
+func ListCompletedSnapshots(snapshotsRootDir, ledgerID string) ([]uint64, error) {
+	ledgerSnapshotsDir := filepath.Join(CompletedSnapshotsPath(snapshotsRootDir), ledgerID)
+	exists, _, err := util.FileExists(ledgerSnapshotsDir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	subdirs, err := util.ListSubdirs(ledgerSnapshotsDir)
+	if err != nil {
+		return nil, err
+	}
+	var blockNums []uint64
+	for _, subdir := range subdirs {
+		blockNum, err := strconv.ParseUint(subdir, 10, 64)
+		if err != nil {
+			logger.Warningf("Ignoring the dir [%s] in the snapshots dir [%s]", subdir, ledgerSnapshotsDir)
+			continue
+		}
+		blockNums = append(blockNums, blockNum)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
+	return blockNums, nil
+}
+
+// generateSnapshot generates a snapshot of the ledger at the last committed block. This function is expected
+// to be invoked while holding the blockAPIsRWLock so that no new block gets committed during the generation
+// of the snapshot. The snapshot files are first created in a temporary dir and the dir is moved to its final
+// location only after all the files and the metadata have been generated
+func (l *kvLedger) generateSnapshot() error {
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	lastBlockNum := bcInfo.Height - 1
+	snapshotsRootDir := l.snapshotsConfig.RootDir
+
+	tempSnapshotsDir := SnapshotsTempDirPath(snapshotsRootDir)
+	if _, err := util.CreateDirIfMissing(tempSnapshotsDir); err != nil {
+		return err
+	}
+	snapshotTempDir, err := ioutil.TempDir(tempSnapshotsDir, fmt.Sprintf("%s-%d-", l.ledgerID, lastBlockNum))
+	if err != nil {
+		return errors.Wrapf(err, "error while creating temp dir [%s]", tempSnapshotsDir)
+	}
+	defer os.RemoveAll(snapshotTempDir)
+
+	filesAndHashes := map[string]string{}
+	addToFilesAndHashes := func(exportSummary map[string][]byte, err error) error {
+		if err != nil {
+			return err
+		}
+		for fileName, hash := range exportSummary {
+			filesAndHashes[fileName] = hex.EncodeToString(hash)
+		}
+		return nil
+	}
+	if err := addToFilesAndHashes(l.blockStore.ExportTxIds(snapshotTempDir)); err != nil {
+		return err
+	}
+	if err := addToFilesAndHashes(l.configHistoryMgr.ExportConfigHistory(l.ledgerID, snapshotTempDir)); err != nil {
+		return err
+	}
+	if err := addToFilesAndHashes(l.vdb.ExportPubStateAndPvtStateHashes(snapshotTempDir)); err != nil {
+		return err
+	}
+
+	signableMetadata := &snapshotSignableMetadata{
+		ChannelName:            l.ledgerID,
+		LastBlockNumber:        lastBlockNum,
+		LastBlockHashInHex:     hex.EncodeToString(bcInfo.CurrentBlockHash),
+		PreviousBlockHashInHex: hex.EncodeToString(bcInfo.PreviousBlockHash),
+		FilesAndHashes:         filesAndHashes,
+	}
+	signableMetadataBytes, err := json.Marshal(signableMetadata)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling snapshot metadata")
+	}
+	if err := writeSnapshotMetadataFile(snapshotTempDir, snapshotSignableMetadataFileName, signableMetadataBytes); err != nil {
+		return err
+	}
+
+	signableMetadataHash := sha256.Sum256(signableMetadataBytes)
+	additionalMetadataBytes, err := json.Marshal(&snapshotAdditionalMetadata{
+		SnapshotHashInHex: hex.EncodeToString(signableMetadataHash[:]),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling snapshot additional metadata")
+	}
+	if err := writeSnapshotMetadataFile(snapshotTempDir, snapshotAdditionalMetadataFileName, additionalMetadataBytes); err != nil {
+		return err
+	}
+
+	finalSnapshotDir := SnapshotDirForLedgerBlockNum(snapshotsRootDir, l.ledgerID, lastBlockNum)
+	if _, err := util.CreateDirIfMissing(filepath.Dir(finalSnapshotDir)); err != nil {
+		return err
+	}
+	if err := os.Rename(snapshotTempDir, finalSnapshotDir); err != nil {
+		return errors.Wrapf(err, "error while renaming dir [%s] to [%s]", snapshotTempDir, finalSnapshotDir)
+	}
+	logger.Infof("Generated snapshot for ledger [%s] at block number [%d] in dir [%s]", l.ledgerID, lastBlockNum, finalSnapshotDir)
+	return nil
+}
+
+func writeSnapshotMetadataFile(dir, fileName string, content []byte) error {
+	filePath := filepath.Join(dir, fileName)
+	if err := ioutil.WriteFile(filePath, content, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the snapshot metadata file [%s]", filePath)
+	}
+	return nil
+}
+
+// loadAndVerifySnapshotMetadata loads the metadata of the snapshot present in the given dir and verifies
+// the hash of the metadata and the hashes of all the snapshot files listed in the metadata
+func loadAndVerifySnapshotMetadata(snapshotDir string) (*snapshotSignableMetadata, []byte, error) {
+	signableMetadataFilePath := filepath.Join(snapshotDir, snapshotSignableMetadataFileName)
+	signableMetadataBytes, err := ioutil.ReadFile(signableMetadataFilePath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the snapshot metadata file [%s]", signableMetadataFilePath)
+	}
+	signableMetadata := &snapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return nil, nil, errors.Wrapf(err, "error while unmarshaling the snapshot metadata file [%s]", signableMetadataFilePath)
+	}
+
+	additionalMetadataFilePath := filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName)
+	additionalMetadataBytes, err := ioutil.ReadFile(additionalMetadataFilePath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the snapshot metadata file [%s]", additionalMetadataFilePath)
+	}
+	additionalMetadata := &snapshotAdditionalMetadata{}
+	if err := json.Unmarshal(additionalMetadataBytes, additionalMetadata); err != nil {
+		return nil, nil, errors.Wrapf(err, "error while unmarshaling the snapshot metadata file [%s]", additionalMetadataFilePath)
+	}
+
+	signableMetadataHash := sha256.Sum256(signableMetadataBytes)
+	if hex.EncodeToString(signableMetadataHash[:]) != additionalMetadata.SnapshotHashInHex {
+		return nil, nil, errors.Errorf(
+			"hash mismatch for the snapshot metadata file [%s]: expected [%s], computed [%x]",
+			signableMetadataFilePath, additionalMetadata.SnapshotHashInHex, signableMetadataHash,
+		)
+	}
+
+	for fileName, expectedHashInHex := range signableMetadata.FilesAndHashes {
+		filePath := filepath.Join(snapshotDir, fileName)
+		expectedHash, err := hex.DecodeString(expectedHashInHex)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error while decoding the hash for the snapshot file [%s]", filePath)
+		}
+		computedHash, err := computeFileHash(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !bytes.Equal(expectedHash, computedHash) {
+			return nil, nil, errors.Errorf(
+				"hash mismatch for the snapshot file [%s]: expected [%s], computed [%x]",
+				filePath, expectedHashInHex, computedHash,
+			)
+		}
+	}
+	return signableMetadata, signableMetadataBytes, nil
+}
+
+func computeFileHash(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file [%s]", filePath)
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file [%s]", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/pkg/errors"
+)
+
+// snapshotRequestBookkeeper persists the pending snapshot requests of a ledger so that
+// the requests survive a peer restart
+type snapshotRequestBookkeeper struct {
+	dbHandle *leveldbhelper.DBHandle
+}
+
+func newSnapshotRequestBookkeeper(ledgerID string, bookkeeperProvider bookkeeping.Provider) *snapshotRequestBookkeeper {
+	return &snapshotRequestBookkeeper{
+		dbHandle: bookkeeperProvider.GetDBHandle(ledgerID, bookkeeping.SnapshotRequest),
+	}
+}
+
+func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
+	exists, err := k.exist(blockNumber)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("duplicate snapshot request for block number %d", blockNumber)
+	}
+	return k.dbHandle.Put(encodeSnapshotRequestKey(blockNumber), []byte{}, true)
+}
+
+func (k *snapshotRequestBookkeeper) delete(blockNumber uint64) error {
+	exists, err := k.exist(blockNumber)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no snapshot request exists for block number %d", blockNumber)
+	}
+	return k.dbHandle.Delete(encodeSnapshotRequestKey(blockNumber), true)
+}
+
+func (k *snapshotRequestBookkeeper) exist(blockNumber uint64) (bool, error) {
+	val, err := k.dbHandle.Get(encodeSnapshotRequestKey(blockNumber))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (k *snapshotRequestBookkeeper) list() ([]uint64, error) {
+	itr := k.dbHandle.GetIterator(nil, nil)
+	defer itr.Release()
+	var blockNumbers []uint64
+	for itr.Next() {
+		if err := itr.Error(); err != nil {
+			return nil, errors.Wrap(err, "internal leveldb error while iterating for snapshot requests")
+		}
+		blockNumber, _, err := util.DecodeOrderPreservingVarUint64(itr.Key())
+		if err != nil {
+			return nil, err
+		}
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	return blockNumbers, nil
+}
+
+func encodeSnapshotRequestKey(blockNumber uint64) []byte {
+	return util.EncodeOrderPreservingVarUint64(blockNumber)
+}
+
+// SubmitSnapshotRequest implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	lastCommittedBlock := bcInfo.Height - 1
+	if blockNumber == 0 {
+		blockNumber = lastCommittedBlock
+	}
+	if blockNumber < lastCommittedBlock {
+		return errors.Errorf(
+			"requested snapshot for block number %d cannot be less than the last committed block number %d",
+			blockNumber, lastCommittedBlock,
+		)
+	}
+	if blockNumber == lastCommittedBlock {
+		exists, _, err := util.FileExists(SnapshotDirForLedgerBlockNum(l.snapshotsConfig.RootDir, l.ledgerID, blockNumber))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Errorf("snapshot already exists for block number %d", blockNumber)
+		}
+		return l.generateSnapshot()
+	}
+	return l.snapshotRequestBookkeeper.add(blockNumber)
+}
+
+// CancelSnapshotRequest implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+	return l.snapshotRequestBookkeeper.delete(blockNumber)
+}
+
+// PendingSnapshotRequests implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	return l.snapshotRequestBookkeeper.list()
+}
+
+// processSnapshotRequest generates the snapshot if a request is pending for the given block number,
+// which is expected to be the last committed block. This function is invoked while holding the
+// blockAPIsRWLock. A failure in generating the snapshot does not fail the block commit, the error
+// is logged and the request is removed
+func (l *kvLedger) processSnapshotRequest(blockNumber uint64) {
+	exists, err := l.snapshotRequestBookkeeper.exist(blockNumber)
+	if err != nil {
+		logger.Errorf("[%s] Error while checking for the snapshot request for block number [%d]: %s", l.ledgerID, blockNumber, err)
+		return
+	}
+	if !exists {
+		return
+	}
+	if err := l.generateSnapshot(); err != nil {
+		logger.Errorf("[%s] Error while generating the snapshot for block number [%d]: %+v", l.ledgerID, blockNumber, err)
+	}
+	if err := l.snapshotRequestBookkeeper.delete(blockNumber); err != nil {
+		logger.Errorf("[%s] Error while removing the snapshot request for block number [%d]: %s", l.ledgerID, blockNumber, err)
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotGenerationAndBootstrap(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0}, conf)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	lgr, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer lgr.Close()
+
+	blk1 := prepareNextBlockForTest(t, lgr, bg, "txid1", map[string]string{"key1": "value1"}, map[string]string{"key1": "pvtValue1"})
+	require.NoError(t, lgr.CommitWithPvtData(blk1))
+	blk2 := prepareNextBlockForTest(t, lgr, bg, "txid2", map[string]string{"key2": "value2"}, map[string]string{"key2": "pvtValue2"})
+	require.NoError(t, lgr.CommitWithPvtData(blk2))
+
+	// a request for the last committed block generates the snapshot immediately
+	require.NoError(t, lgr.SubmitSnapshotRequest(0))
+	completed, err := ListCompletedSnapshots(filepath.Join(conf.RootFSPath, "snapshots"), "testLedger")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, completed)
+
+	// a request for a future block is generated on the commit of the block
+	require.NoError(t, lgr.SubmitSnapshotRequest(3))
+	pending, err := lgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, pending)
+
+	blk3 := prepareNextBlockForTest(t, lgr, bg, "txid3", map[string]string{"key3": "value3"}, map[string]string{"key3": "pvtValue3"})
+	require.NoError(t, lgr.CommitWithPvtData(blk3))
+	pending, err = lgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	completed, err = ListCompletedSnapshots(filepath.Join(conf.RootFSPath, "snapshots"), "testLedger")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, completed)
+
+	snapshotDir := SnapshotDirForLedgerBlockNum(filepath.Join(conf.RootFSPath, "snapshots"), "testLedger", 2)
+	for _, fileName := range []string{
+		snapshotSignableMetadataFileName,
+		snapshotAdditionalMetadataFileName,
+		confighistory.SnapshotDataFileName,
+		confighistory.SnapshotMetadataFileName,
+	} {
+		_, err := os.Stat(filepath.Join(snapshotDir, fileName))
+		require.NoError(t, err)
+	}
+
+	// bootstrap another peer from the snapshot at block 2
+	destConf, destCleanup := testConfig(t)
+	defer destCleanup()
+	destProvider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0}, destConf)
+	defer destProvider.Close()
+
+	destLgr, ledgerID, err := destProvider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer destLgr.Close()
+	require.Equal(t, "testLedger", ledgerID)
+
+	bcInfo, err := destLgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, &common.BlockchainInfo{
+		Height:            3,
+		CurrentBlockHash:  protoutil.BlockHeaderHash(blk2.Block.Header),
+		PreviousBlockHash: protoutil.BlockHeaderHash(blk1.Block.Header),
+	}, bcInfo)
+
+	qe, err := destLgr.NewQueryExecutor()
+	require.NoError(t, err)
+	val, err := qe.GetState("ns", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+	hash, err := qe.GetPrivateDataHash("ns", "coll", "key2")
+	require.NoError(t, err)
+	require.Equal(t, util.ComputeStringHash("pvtValue2"), hash)
+	qe.Done()
+
+	// the txids present in the snapshot are known but their details are not available
+	_, err = destLgr.GetTransactionByID(txIDFromBlock(t, blk1.Block))
+	require.IsType(t, ledger.TxDetailsNotAvailableErr(""), err)
+
+	// the bootstrapped ledger continues from the height of the snapshot
+	require.NoError(t, destLgr.CommitWithPvtData(blk3))
+	qe, err = destLgr.NewQueryExecutor()
+	require.NoError(t, err)
+	val, err = qe.GetState("ns", "key3")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), val)
+	qe.Done()
+	processedTx, err := destLgr.GetTransactionByID(txIDFromBlock(t, blk3.Block))
+	require.NoError(t, err)
+	require.NotNil(t, processedTx)
+
+	ledgerIDs, err := destProvider.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"testLedger"}, ledgerIDs)
+
+	_, _, err = destProvider.CreateFromSnapshot(snapshotDir)
+	require.Equal(t, ErrLedgerIDExists, err)
+}
+
+func TestSnapshotRequests(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	lgr, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer lgr.Close()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: bg.NextBlock([][]byte{})}))
+	}
+
+	err = lgr.SubmitSnapshotRequest(2)
+	require.EqualError(t, err, "requested snapshot for block number 2 cannot be less than the last committed block number 3")
+
+	require.NoError(t, lgr.SubmitSnapshotRequest(3))
+	err = lgr.SubmitSnapshotRequest(3)
+	require.EqualError(t, err, "snapshot already exists for block number 3")
+
+	require.NoError(t, lgr.SubmitSnapshotRequest(10))
+	require.NoError(t, lgr.SubmitSnapshotRequest(5))
+	err = lgr.SubmitSnapshotRequest(10)
+	require.EqualError(t, err, "duplicate snapshot request for block number 10")
+	pending, err := lgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 10}, pending)
+
+	require.NoError(t, lgr.CancelSnapshotRequest(10))
+	err = lgr.CancelSnapshotRequest(10)
+	require.EqualError(t, err, "no snapshot request exists for block number 10")
+
+	// pending requests survive a restart
+	lgr.Close()
+	provider.Close()
+	provider = testutilNewProvider(conf, t)
+	lgr, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	pending, err = lgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5}, pending)
+}
+
+func TestSnapshotMetadataVerification(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	lgr, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer lgr.Close()
+	require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: bg.NextBlock([][]byte{})}))
+	require.NoError(t, lgr.SubmitSnapshotRequest(0))
+
+	snapshotDir := SnapshotDirForLedgerBlockNum(filepath.Join(conf.RootFSPath, "snapshots"), "testLedger", 1)
+	metadata, _, err := loadAndVerifySnapshotMetadata(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, "testLedger", metadata.ChannelName)
+	require.Equal(t, uint64(1), metadata.LastBlockNumber)
+
+	t.Run("tampered-snapshot-file", func(t *testing.T) {
+		tamperedDir := copySnapshotDir(t, snapshotDir)
+		defer os.RemoveAll(tamperedDir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tamperedDir, confighistory.SnapshotDataFileName), []byte("junk"), 0644))
+		_, _, err := loadAndVerifySnapshotMetadata(tamperedDir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot file")
+	})
+
+	t.Run("tampered-metadata-file", func(t *testing.T) {
+		tamperedDir := copySnapshotDir(t, snapshotDir)
+		defer os.RemoveAll(tamperedDir)
+		metadataFile := filepath.Join(tamperedDir, snapshotSignableMetadataFileName)
+		metadataBytes, err := ioutil.ReadFile(metadataFile)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(metadataFile, append(metadataBytes, ' '), 0644))
+		_, _, err = loadAndVerifySnapshotMetadata(tamperedDir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot metadata file")
+	})
+
+	t.Run("missing-metadata-file", func(t *testing.T) {
+		emptyDir, err := ioutil.TempDir("", "snapshot")
+		require.NoError(t, err)
+		defer os.RemoveAll(emptyDir)
+		_, _, err = provider.CreateFromSnapshot(emptyDir)
+		require.Contains(t, err.Error(), "error while reading the snapshot metadata file")
+	})
+}
+
+func copySnapshotDir(t *testing.T, srcDir string) string {
+	destDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	files, err := ioutil.ReadDir(srcDir)
+	require.NoError(t, err)
+	for _, f := range files {
+		content, err := ioutil.ReadFile(filepath.Join(srcDir, f.Name()))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(destDir, f.Name()), content, 0644))
+	}
+	return destDir
+}
+
+func txIDFromBlock(t *testing.T, block *common.Block) string {
+	env, err := protoutil.GetEnvelopeFromBlock(block.Data.Data[0])
+	require.NoError(t, err)
+	payload, err := protoutil.GetPayload(env)
+	require.NoError(t, err)
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	return chdr.TxId
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateboltdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -32,6 +33,7 @@ const (
 	pvtDataPrefix  = "p"
 	hashDataPrefix = "h"
 	couchDB        = "CouchDB"
+	boltDB         = "boltdb"
 )
 
 // StateDBConfig encapsulates the configuration for stateDB on the ledger.
@@ -42,6 +44,9 @@ type StateDBConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.StateDBConfig and not exposed to other components.
 	LevelDBPath string
+	// BoltDBPath is the filesystem path when statedb type is "boltdb".
+	// Like LevelDBPath, it is internally computed by the ledger component.
+	BoltDBPath string
 }
 
 // CommonStorageDBProvider implements interface DBProvider
@@ -60,11 +65,14 @@ func NewCommonStorageDBProvider(
 ) (DBProvider, error) {
 	var vdbProvider statedb.VersionedDBProvider
 	var err error
-	if stateDBConf != nil && stateDBConf.StateDatabase == couchDB {
+	switch {
+	case stateDBConf != nil && stateDBConf.StateDatabase == couchDB:
 		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(stateDBConf.CouchDB, metricsProvider); err != nil {
 			return nil, err
 		}
-	} else {
+	case stateDBConf != nil && stateDBConf.StateDatabase == boltDB:
+		vdbProvider = stateboltdb.NewVersionedDBProvider(stateDBConf.BoltDBPath)
+	default:
 		vdbProvider = stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath)
 	}
 
@@ -96,6 +104,18 @@ func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	return NewCommonStorageDB(vdb, id, metadataHint)
 }
 
+// Drop implements function from interface DBProvider. This also removes the bookkeeping about the
+// presence of metadata, as the bookkeeping is maintained along with the state
+func (p *CommonStorageDBProvider) Drop(id string) error {
+	if err := p.VersionedDBProvider.Drop(id); err != nil {
+		return err
+	}
+	return errors.WithMessagef(
+		p.bookkeepingProvider.GetDBHandle(id, bookkeeping.MetadataPresenceIndicator).DeleteAll(),
+		"error while dropping the metadata hint of [%s]", id,
+	)
+}
+
 // Close implements function from interface DBProvider
 func (p *CommonStorageDBProvider) Close() {
 	p.VersionedDBProvider.Close()
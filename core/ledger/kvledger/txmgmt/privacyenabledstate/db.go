@@ -18,6 +18,9 @@ import (
 type DBProvider interface {
 	// GetDBHandle returns a handle to a PvtVersionedDB
 	GetDBHandle(id string) (DB, error)
+	// Drop removes the public, hashed and private data of the PvtVersionedDB with the given id.
+	// The PvtVersionedDB should not be in use
+	Drop(id string) error
 	// Close closes all the PvtVersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -40,6 +43,8 @@ type DB interface {
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error)
+	ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
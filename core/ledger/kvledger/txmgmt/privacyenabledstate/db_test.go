@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
@@ -572,6 +573,37 @@ func testMetadataRetrieval(t *testing.T, env TestEnv) {
 	assert.Nil(t, vm)
 }
 
+func TestDrop(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	ledgerID := generateLedgerID(t)
+	db := env.GetDBHandle(ledgerID)
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 2)))
+	assert.NoError(t, env.provider.Drop(ledgerID))
+
+	db = env.GetDBHandle(ledgerID)
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	vv, err = db.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	vv, err = db.GetPrivateDataHash("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	savepoint, err := db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	itr := env.bookkeeperTestEnv.TestProvider.GetDBHandle(ledgerID, bookkeeping.MetadataPresenceIndicator).GetIterator(nil, nil)
+	defer itr.Release()
+	assert.False(t, itr.Next())
+}
+
 func putPvtUpdates(t *testing.T, updates *UpdateBatch, ns, coll, key string, value []byte, ver *version.Height) {
 	updates.PvtUpdates.Put(ns, coll, key, value, ver)
 	updates.HashUpdates.Put(ns, coll, util.ComputeStringHash(key), util.ComputeHash(value), ver)
@@ -0,0 +1,332 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// PubStateDataFileName is the name of the file that contains the public state in a snapshot
+	PubStateDataFileName = "public_state.data"
+	// PubStateMetadataFileName is the name of the file that contains the number of entries in the public state file
+	PubStateMetadataFileName = "public_state.metadata"
+	// PvtStateHashesFileName is the name of the file that contains the hashes of the private state in a snapshot
+	PvtStateHashesFileName = "private_state_hashes.data"
+	// PvtStateHashesMetadataFileName is the name of the file that contains the number of entries in the private state hashes file
+	PvtStateHashesMetadataFileName = "private_state_hashes.metadata"
+
+	maxEntriesInImportBatch = 10000
+)
+
+// ExportPubStateAndPvtStateHashes generates four files in the specified dir. The files, public_state.data and public_state.metadata
+// contains the exported public state and the files private_state_hashes.data and private_state_hashes.metadata contain the exported
+// private state hashes. The file format for public state and the private state hashes are the same. The data files contains a series
+// of tuple <key,value> and the metadata files contains the number of tuples in the corresponding data file.
+// The private data itself is never exported. This function returns the mapping between the names of the files and their hashes
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, errors.New("exporting the state is not supported for the configured state database")
+	}
+	itr, err := fullScannable.GetFullScanIterator(isPvtdataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := newSnapshotWriter(
+		filepath.Join(dir, PubStateDataFileName),
+		filepath.Join(dir, PubStateMetadataFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.close()
+
+	pvtStateHashesWriter, err := newSnapshotWriter(
+		filepath.Join(dir, PvtStateHashesFileName),
+		filepath.Join(dir, PvtStateHashesMetadataFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.close()
+
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		ns, coll, isHashedDataNs := decodeHashedDataNs(kv.Namespace)
+		if !isHashedDataNs {
+			if err := pubStateWriter.addData(kv.Namespace, "", []byte(kv.Key), &kv.VersionedValue); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		keyHash := []byte(kv.Key)
+		if !s.BytesKeySupported() {
+			if keyHash, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+				return nil, errors.Wrapf(err, "error while decoding the key hash [%s]", kv.Key)
+			}
+		}
+		if err := pvtStateHashesWriter.addData(ns, coll, keyHash, &kv.VersionedValue); err != nil {
+			return nil, err
+		}
+	}
+
+	pubStateDataHash, pubStateMetadataHash, err := pubStateWriter.done()
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesDataHash, pvtStateHashesMetadataHash, err := pvtStateHashesWriter.done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		PubStateDataFileName:           pubStateDataHash,
+		PubStateMetadataFileName:       pubStateMetadataHash,
+		PvtStateHashesFileName:         pvtStateHashesDataHash,
+		PvtStateHashesMetadataFileName: pvtStateHashesMetadataHash,
+	}, nil
+}
+
+// ImportPubStateAndPvtStateHashes loads the public state and the private state hashes from the snapshot
+// files present in the specified dir into the db. The savepoint is recorded along with the last batch of data
+func (s *CommonStorageDB) ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error {
+	pubStateReader, err := newSnapshotReader(
+		filepath.Join(dir, PubStateDataFileName),
+		filepath.Join(dir, PubStateMetadataFileName),
+	)
+	if err != nil {
+		return err
+	}
+	defer pubStateReader.close()
+
+	batch := NewUpdateBatch()
+	numEntriesInBatch := 0
+	for pubStateReader.hasMore() {
+		ns, _, key, vv, err := pubStateReader.next()
+		if err != nil {
+			return err
+		}
+		batch.PubUpdates.Update(ns, string(key), vv)
+		if numEntriesInBatch++; numEntriesInBatch == maxEntriesInImportBatch {
+			if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+				return err
+			}
+			batch, numEntriesInBatch = NewUpdateBatch(), 0
+		}
+	}
+	if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+		return err
+	}
+
+	err = LoadPvtStateHashesFromSnapshot(dir, func(hashedUpdates *HashedUpdateBatch) error {
+		return s.ApplyPrivacyAwareUpdates(
+			&UpdateBatch{
+				PubUpdates:  NewPubUpdateBatch(),
+				HashUpdates: hashedUpdates,
+				PvtUpdates:  NewPvtUpdateBatch(),
+			},
+			nil,
+		)
+	})
+	if err != nil {
+		return err
+	}
+	// record the savepoint only after all the data has been loaded
+	return s.ApplyPrivacyAwareUpdates(NewUpdateBatch(), savepoint)
+}
+
+// LoadPvtStateHashesFromSnapshot reads the private state hashes from the snapshot files present in the
+// specified dir and invokes the function `processBatch` for each batch of the private state hashes read
+func LoadPvtStateHashesFromSnapshot(dir string, processBatch func(*HashedUpdateBatch) error) error {
+	pvtStateHashesReader, err := newSnapshotReader(
+		filepath.Join(dir, PvtStateHashesFileName),
+		filepath.Join(dir, PvtStateHashesMetadataFileName),
+	)
+	if err != nil {
+		return err
+	}
+	defer pvtStateHashesReader.close()
+
+	batch := NewHashedUpdateBatch()
+	numEntriesInBatch := 0
+	for pvtStateHashesReader.hasMore() {
+		ns, coll, keyHash, vv, err := pvtStateHashesReader.next()
+		if err != nil {
+			return err
+		}
+		batch.PutValHashAndMetadata(ns, coll, keyHash, vv.Value, vv.Metadata, vv.Version)
+		if numEntriesInBatch++; numEntriesInBatch == maxEntriesInImportBatch {
+			if err := processBatch(batch); err != nil {
+				return err
+			}
+			batch, numEntriesInBatch = NewHashedUpdateBatch(), 0
+		}
+	}
+	if batch.IsEmpty() {
+		return nil
+	}
+	return processBatch(batch)
+}
+
+func isPvtdataNs(namespace string) bool {
+	return strings.Contains(namespace, nsJoiner+pvtDataPrefix)
+}
+
+func decodeHashedDataNs(namespace string) (string, string, bool) {
+	tokens := strings.SplitN(namespace, nsJoiner+hashDataPrefix, 2)
+	if len(tokens) != 2 {
+		return "", "", false
+	}
+	return tokens[0], tokens[1], true
+}
+
+// snapshotWriter writes the entries for one kind of the state into a data file and the count of the entries
+// into the corresponding metadata file
+type snapshotWriter struct {
+	dataFile         *snapshot.FileWriter
+	metadataFilePath string
+	numEntries       uint64
+}
+
+func newSnapshotWriter(dataFilePath, metadataFilePath string) (*snapshotWriter, error) {
+	dataFile, err := snapshot.CreateFile(dataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotWriter{
+		dataFile:         dataFile,
+		metadataFilePath: metadataFilePath,
+	}, nil
+}
+
+func (w *snapshotWriter) addData(ns, coll string, key []byte, vv *statedb.VersionedValue) error {
+	w.numEntries++
+	if err := w.dataFile.EncodeString(ns); err != nil {
+		return err
+	}
+	if err := w.dataFile.EncodeString(coll); err != nil {
+		return err
+	}
+	if err := w.dataFile.EncodeBytes(key); err != nil {
+		return err
+	}
+	if err := w.dataFile.EncodeBytes(vv.Value); err != nil {
+		return err
+	}
+	if err := w.dataFile.EncodeBytes(vv.Metadata); err != nil {
+		return err
+	}
+	return w.dataFile.EncodeBytes(vv.Version.ToBytes())
+}
+
+func (w *snapshotWriter) done() ([]byte, []byte, error) {
+	dataHash, err := w.dataFile.Done()
+	if err != nil {
+		return nil, nil, err
+	}
+	metadataFile, err := snapshot.CreateFile(w.metadataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer metadataFile.Close()
+	if err := metadataFile.EncodeUVarint(w.numEntries); err != nil {
+		return nil, nil, err
+	}
+	metadataHash, err := metadataFile.Done()
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataHash, metadataHash, nil
+}
+
+func (w *snapshotWriter) close() {
+	w.dataFile.Close()
+}
+
+// snapshotReader reads the entries written by the snapshotWriter
+type snapshotReader struct {
+	dataFile   *snapshot.FileReader
+	numEntries uint64
+	numRead    uint64
+}
+
+func newSnapshotReader(dataFilePath, metadataFilePath string) (*snapshotReader, error) {
+	metadataFile, err := snapshot.OpenFile(metadataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer metadataFile.Close()
+	numEntries, err := metadataFile.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	dataFile, err := snapshot.OpenFile(dataFilePath, snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotReader{
+		dataFile:   dataFile,
+		numEntries: numEntries,
+	}, nil
+}
+
+func (r *snapshotReader) hasMore() bool {
+	return r.numRead < r.numEntries
+}
+
+func (r *snapshotReader) next() (string, string, []byte, *statedb.VersionedValue, error) {
+	r.numRead++
+	ns, err := r.dataFile.DecodeString()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	coll, err := r.dataFile.DecodeString()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	key, err := r.dataFile.DecodeBytes()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	value, err := r.dataFile.DecodeBytes()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	metadata, err := r.dataFile.DecodeBytes()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	versionBytes, err := r.dataFile.DecodeBytes()
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	version, _ := version.NewHeightFromBytes(versionBytes)
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return ns, coll, key, &statedb.VersionedValue{Value: value, Metadata: metadata, Version: version}, nil
+}
+
+func (r *snapshotReader) close() {
+	r.dataFile.Close()
+}
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotExportAndImport(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	sourceDB := env.GetDBHandle(generateLedgerID(t))
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	updates.PubUpdates.Put("ns2", "key3", []byte("value3"), version.NewHeight(1, 3))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 4))
+	putPvtUpdatesWithMetadata(t, updates, "ns1", "coll1", "key2", []byte("pvt_value2"), []byte("pvt_metadata2"), version.NewHeight(1, 5))
+	putPvtUpdates(t, updates, "ns2", "coll2", "key3", []byte("pvt_value3"), version.NewHeight(1, 6))
+	require.NoError(t, sourceDB.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 6)))
+
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	fileHashes, err := sourceDB.ExportPubStateAndPvtStateHashes(snapshotDir)
+	require.NoError(t, err)
+	require.Len(t, fileHashes, 4)
+	for fileName, hash := range fileHashes {
+		fileContent, err := ioutil.ReadFile(filepath.Join(snapshotDir, fileName))
+		require.NoError(t, err)
+		expectedHash := sha256.Sum256(fileContent)
+		require.Equal(t, expectedHash[:], hash)
+	}
+
+	destinationDB := env.GetDBHandle(generateLedgerID(t))
+	require.NoError(t, destinationDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(5, 0)))
+
+	savepoint, err := destinationDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	require.Equal(t, version.NewHeight(5, 0), savepoint)
+
+	vv, err := destinationDB.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+
+	vv, err = destinationDB.GetState("ns1", "key2")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)}, vv)
+
+	vv, err = destinationDB.GetState("ns2", "key3")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}, vv)
+
+	vv, err = destinationDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: util.ComputeStringHash("pvt_value1"), Version: version.NewHeight(1, 4)}, vv)
+
+	vv, err = destinationDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key2"))
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: util.ComputeStringHash("pvt_value2"), Metadata: []byte("pvt_metadata2"), Version: version.NewHeight(1, 5)}, vv)
+
+	vv, err = destinationDB.GetValueHash("ns2", "coll2", util.ComputeStringHash("key3"))
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: util.ComputeStringHash("pvt_value3"), Version: version.NewHeight(1, 6)}, vv)
+
+	// private data itself is never exported
+	vv, err = destinationDB.GetPrivateData("ns1", "coll1", "key1")
+	require.NoError(t, err)
+	require.Nil(t, vv)
+}
+
+func TestSnapshotExportErrorCases(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	db := env.GetDBHandle(generateLedgerID(t))
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, PubStateDataFileName), []byte("junk"), 0644))
+	_, err = db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	require.Contains(t, err.Error(), "error while creating the snapshot file")
+}
+
+func TestSnapshotImportErrorCases(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	db := env.GetDBHandle(generateLedgerID(t))
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	err = db.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(5, 0))
+	require.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, PubStateMetadataFileName), []byte{2}, 0644))
+	err = db.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(5, 0))
+	require.Contains(t, err.Error(), "unexpected data format in the snapshot file")
+}
@@ -34,7 +34,7 @@ type TestEnv interface {
 // Tests will be run against each environment in this array
 // For example, to skip CouchDB tests, remove &couchDBLockBasedEnv{}
 //var testEnvs = []testEnv{&levelDBCommonStorageTestEnv{}, &couchDBCommonStorageTestEnv{}}
-var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
+var testEnvs = []TestEnv{&LevelDBCommonStorageTestEnv{}, &BoltDBCommonStorageTestEnv{}, &CouchDBCommonStorageTestEnv{}}
 
 ///////////// LevelDB Environment //////////////
 
@@ -58,8 +58,8 @@ func (env *LevelDBCommonStorageTestEnv) Init(t testing.TB) {
 		&disabled.Provider{},
 		&mock.HealthCheckRegistry{},
 		&StateDBConfig{
-			&ledger.StateDBConfig{},
-			dbPath,
+			StateDBConfig: &ledger.StateDBConfig{},
+			LevelDBPath:   dbPath,
 		},
 	)
 	assert.NoError(t, err)
@@ -87,6 +87,57 @@ func (env *LevelDBCommonStorageTestEnv) Cleanup() {
 	os.RemoveAll(env.dbPath)
 }
 
+///////////// BoltDB Environment //////////////
+
+// BoltDBCommonStorageTestEnv implements TestEnv interface for boltdb based storage
+type BoltDBCommonStorageTestEnv struct {
+	t                 testing.TB
+	provider          DBProvider
+	bookkeeperTestEnv *bookkeeping.TestEnv
+	dbPath            string
+}
+
+// Init implements corresponding function from interface TestEnv
+func (env *BoltDBCommonStorageTestEnv) Init(t testing.TB) {
+	dbPath, err := ioutil.TempDir("", "cstestenv")
+	if err != nil {
+		t.Fatalf("Failed to create bolt db storage directory: %s", err)
+	}
+	env.bookkeeperTestEnv = bookkeeping.NewTestEnv(t)
+	dbProvider, err := NewCommonStorageDBProvider(
+		env.bookkeeperTestEnv.TestProvider,
+		&disabled.Provider{},
+		&mock.HealthCheckRegistry{},
+		&StateDBConfig{
+			StateDBConfig: &ledger.StateDBConfig{StateDatabase: "boltdb"},
+			BoltDBPath:    dbPath,
+		},
+	)
+	assert.NoError(t, err)
+	env.t = t
+	env.provider = dbProvider
+	env.dbPath = dbPath
+}
+
+// GetDBHandle implements corresponding function from interface TestEnv
+func (env *BoltDBCommonStorageTestEnv) GetDBHandle(id string) DB {
+	db, err := env.provider.GetDBHandle(id)
+	assert.NoError(env.t, err)
+	return db
+}
+
+// GetName implements corresponding function from interface TestEnv
+func (env *BoltDBCommonStorageTestEnv) GetName() string {
+	return "boltDBCommonStorageTestEnv"
+}
+
+// Cleanup implements corresponding function from interface TestEnv
+func (env *BoltDBCommonStorageTestEnv) Cleanup() {
+	env.provider.Close()
+	env.bookkeeperTestEnv.Cleanup()
+	os.RemoveAll(env.dbPath)
+}
+
 ///////////// CouchDB Environment //////////////
 
 // CouchDBCommonStorageTestEnv implements TestEnv interface for couchdb based storage
@@ -30,6 +30,9 @@ type PurgeMgr interface {
 		hashedUpdates *privacyenabledstate.HashedUpdateBatch) error
 	// UpdateBookkeepingForPvtDataOfOldBlocks updates the existing expiry entries in the bookkeeper with the given pvtUpdates
 	UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates *privacyenabledstate.PvtUpdateBatch) error
+	// UpdateBookkeepingForImportedHashes adds the expiry entries in the bookkeeper for the private data hashes
+	// that are loaded into the state db from a snapshot
+	UpdateBookkeepingForImportedHashes(hashedUpdates *privacyenabledstate.HashedUpdateBatch) error
 	// BlockCommitDone is a callback to the PurgeMgr when the block is committed to the ledger
 	BlockCommitDone() error
 }
@@ -119,6 +122,29 @@ func (p *purgeMgr) UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates *privacyena
 	return p.expKeeper.updateBookkeeping(updatedList, nil)
 }
 
+// UpdateBookkeepingForImportedHashes implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) UpdateBookkeepingForImportedHashes(hashedUpdates *privacyenabledstate.HashedUpdateBatch) error {
+	builder := newExpiryScheduleBuilder(p.btlPolicy)
+	for k, vv := range hashedUpdates.ToCompositeKeyMap() {
+		if err := builder.add(k.Namespace, k.CollectionName, "", []byte(k.KeyHash), vv); err != nil {
+			return err
+		}
+	}
+
+	var updatedList []*expiryInfo
+	for _, toAdd := range builder.getExpiryInfo() {
+		// the hashes are imported in batches and hence, an expiry entry for the same
+		// committing and expiring block may already exist from a previous batch
+		existing, err := p.expKeeper.retrieveByExpiryKey(toAdd.expiryInfoKey)
+		if err != nil {
+			return err
+		}
+		toAdd.pvtdataKeys.addAll(existing.pvtdataKeys)
+		updatedList = append(updatedList, toAdd)
+	}
+	return p.expKeeper.updateBookkeeping(updatedList, nil)
+}
+
 func (p *purgeMgr) addMissingPvtDataToWorkingSet(pvtKeys privacyenabledstate.PvtdataCompositeKeyMap) {
 	if p.workingset == nil || len(p.workingset.toPurge) == 0 {
 		return
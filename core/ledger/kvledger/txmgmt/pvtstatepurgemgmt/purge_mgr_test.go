@@ -272,6 +272,44 @@ func TestOnlyHashDeleteBeforeExpiryBlock(t *testing.T) {
 	testHelper.checkPvtdataDoesNotExist("ns", "coll", "pvtkey")
 }
 
+func TestPurgeMgrForImportedHashes(t *testing.T) {
+	dbEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	ledgerid := "testledger-purge-mgr-imported-hashes"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns1", "coll1"}: 1,
+			{"ns1", "coll2"}: 2,
+		},
+	)
+	testHelper := &testHelper{}
+	testHelper.init(t, ledgerid, btlPolicy, dbEnv)
+	defer testHelper.cleanup()
+
+	// load the hashes in two batches, as is the case while bootstrapping a ledger from a snapshot
+	// the entries for the same committing and expiring blocks should get merged in the bookkeeping
+	importBatch1 := privacyenabledstate.NewUpdateBatch()
+	putHashUpdates(importBatch1, "ns1", "coll1", "pvtkey1", []byte("pvtvalue1"), version.NewHeight(5, 1))
+	putHashUpdates(importBatch1, "ns1", "coll2", "pvtkey2", []byte("pvtvalue2"), version.NewHeight(5, 1))
+	assert.NoError(t, testHelper.purgeMgr.UpdateBookkeepingForImportedHashes(importBatch1.HashUpdates))
+	assert.NoError(t, testHelper.db.ApplyPrivacyAwareUpdates(importBatch1, nil))
+
+	importBatch2 := privacyenabledstate.NewUpdateBatch()
+	putHashUpdates(importBatch2, "ns1", "coll1", "pvtkey3", []byte("pvtvalue3"), version.NewHeight(5, 2))
+	assert.NoError(t, testHelper.purgeMgr.UpdateBookkeepingForImportedHashes(importBatch2.HashUpdates))
+	assert.NoError(t, testHelper.db.ApplyPrivacyAwareUpdates(importBatch2, version.NewHeight(6, 0)))
+
+	testHelper.checkExpiryEntryExistsForBlockNum(7, 1)
+	testHelper.checkExpiryEntryExistsForBlockNum(8, 1)
+
+	testHelper.commitUpdatesForTesting(7, privacyenabledstate.NewUpdateBatch())
+	testHelper.checkPvtdataDoesNotExist("ns1", "coll1", "pvtkey1")
+	testHelper.checkPvtdataDoesNotExist("ns1", "coll1", "pvtkey3")
+	testHelper.checkOnlyKeyHashExists("ns1", "coll2", "pvtkey2")
+
+	testHelper.commitUpdatesForTesting(8, privacyenabledstate.NewUpdateBatch())
+	testHelper.checkPvtdataDoesNotExist("ns1", "coll2", "pvtkey2")
+}
+
 type testHelper struct {
 	t              *testing.T
 	bookkeepingEnv *bookkeeping.TestEnv
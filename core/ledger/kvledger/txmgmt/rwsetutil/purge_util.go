@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// GetPurgedPvtKeys returns the private data keys purged by the valid transactions in the block.
+// The validation flags of the transactions are expected to be set in the block metadata
+func GetPurgedPvtKeys(block *common.Block) ([]*ledger.PurgedPvtKey, error) {
+	var purgedKeys []*ledger.PurgedPvtKey
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(txNum) {
+			continue
+		}
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := protoutil.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		respPayload, err := protoutil.GetActionFromEnvelopeMsg(env)
+		if err != nil {
+			return nil, err
+		}
+		txRWSet := &TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			return nil, errors.WithMessagef(err, "error while extracting the rwset of txNum [%d] in block [%d]",
+				txNum, block.Header.Number)
+		}
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+					if !kvWriteHash.IsPurge {
+						continue
+					}
+					purgedKeys = append(purgedKeys, &ledger.PurgedPvtKey{
+						BlockNum:   block.Header.Number,
+						TxNum:      uint64(txNum),
+						Namespace:  nsRWSet.NameSpace,
+						Collection: collHashedRWSet.CollectionName,
+						KeyHash:    kvWriteHash.KeyHash,
+					})
+				}
+			}
+		}
+	}
+	return purgedKeys, nil
+}
+
+// RemoveKeyFromCollPvtRwSet removes the writes of the key with the given hash from the
+// private write set of a collection. It returns false if the write set does not contain the key
+func RemoveKeyFromCollPvtRwSet(collPvtRwSet *rwset.CollectionPvtReadWriteSet, keyHash []byte) (bool, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtRwSet.Rwset, kvRWSet); err != nil {
+		return false, errors.Wrap(err, "error unmarshalling the private write set")
+	}
+	removed := false
+	var writes []*kvrwset.KVWrite
+	for _, w := range kvRWSet.Writes {
+		if bytes.Equal(util.ComputeStringHash(w.Key), keyHash) {
+			removed = true
+			continue
+		}
+		writes = append(writes, w)
+	}
+	var metadataWrites []*kvrwset.KVMetadataWrite
+	for _, w := range kvRWSet.MetadataWrites {
+		if bytes.Equal(util.ComputeStringHash(w.Key), keyHash) {
+			removed = true
+			continue
+		}
+		metadataWrites = append(metadataWrites, w)
+	}
+	if !removed {
+		return false, nil
+	}
+	kvRWSet.Writes = writes
+	kvRWSet.MetadataWrites = metadataWrites
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	if err != nil {
+		return false, errors.Wrap(err, "error marshalling the private write set")
+	}
+	collPvtRwSet.Rwset = rwsetBytes
+	return true, nil
+}
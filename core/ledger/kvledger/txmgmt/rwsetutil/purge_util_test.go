@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPurgedPvtKeys(t *testing.T) {
+	simulationResults := func(purgedKeys ...string) []byte {
+		rwSetBuilder := NewRWSetBuilder()
+		rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+		for _, key := range purgedKeys {
+			rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll2", key)
+		}
+		simRes, err := rwSetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		return pubSimResBytes
+	}
+
+	block := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum: 5,
+		Txs: []*testutil.TxDetails{
+			{TxID: "tx0", ChaincodeName: "ns1", Type: common.HeaderType_ENDORSER_TRANSACTION, SimulationResults: simulationResults("key2")},
+			{TxID: "tx1", ChaincodeName: "ns1", Type: common.HeaderType_ENDORSER_TRANSACTION, SimulationResults: simulationResults()},
+			{TxID: "tx2", ChaincodeName: "ns1", Type: common.HeaderType_ENDORSER_TRANSACTION, SimulationResults: simulationResults("key3")},
+			{TxID: "tx3", ChaincodeName: "ns1", Type: common.HeaderType_ENDORSER_TRANSACTION, SimulationResults: simulationResults("key4", "key5")},
+		},
+	}, false)
+	txsFilter := util.NewTxValidationFlagsSetValue(len(block.Data.Data), peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(2, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	purgedKeys, err := GetPurgedPvtKeys(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []*ledger.PurgedPvtKey{
+		{BlockNum: 5, TxNum: 0, Namespace: "ns1", Collection: "coll2", KeyHash: util.ComputeStringHash("key2")},
+		{BlockNum: 5, TxNum: 3, Namespace: "ns1", Collection: "coll2", KeyHash: util.ComputeStringHash("key4")},
+		{BlockNum: 5, TxNum: 3, Namespace: "ns1", Collection: "coll2", KeyHash: util.ComputeStringHash("key5")},
+	}, purgedKeys)
+
+	block.Data.Data[1] = []byte("garbage")
+	_, err = GetPurgedPvtKeys(block)
+	assert.Error(t, err)
+}
+
+func TestRemoveKeyFromCollPvtRwSet(t *testing.T) {
+	kvRWSet := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			{Key: "key1", Value: []byte("value1")},
+			{Key: "key2", Value: []byte("value2")},
+		},
+		MetadataWrites: []*kvrwset.KVMetadataWrite{
+			{Key: "key1"},
+		},
+	}
+	kvRWSetBytes, err := proto.Marshal(kvRWSet)
+	assert.NoError(t, err)
+	collPvtRwSet := &rwset.CollectionPvtReadWriteSet{CollectionName: "coll1", Rwset: kvRWSetBytes}
+
+	removed, err := RemoveKeyFromCollPvtRwSet(collPvtRwSet, util.ComputeStringHash("key3"))
+	assert.NoError(t, err)
+	assert.False(t, removed)
+	assert.Equal(t, kvRWSetBytes, collPvtRwSet.Rwset)
+
+	removed, err = RemoveKeyFromCollPvtRwSet(collPvtRwSet, util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	updatedKVRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(collPvtRwSet.Rwset, updatedKVRWSet))
+	assert.True(t, proto.Equal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "key2", Value: []byte("value2")}},
+	}, updatedKVRWSet))
+
+	_, err = RemoveKeyFromCollPvtRwSet(&rwset.CollectionPvtReadWriteSet{Rwset: []byte("garbage")}, util.ComputeStringHash("key1"))
+	assert.Contains(t, err.Error(), "error unmarshalling the private write set")
+}
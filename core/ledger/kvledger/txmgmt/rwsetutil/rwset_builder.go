@@ -112,6 +112,21 @@ func (b *RWSetBuilder) AddToHashedReadSet(ns string, coll string, key string, ve
 // AddToPvtAndHashedWriteSet adds a key and value to the private and hashed write-set
 func (b *RWSetBuilder) AddToPvtAndHashedWriteSet(ns string, coll string, key string, value []byte) {
 	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, value)
+	collHashedRwBuilder := b.getOrCreateCollHashedRwBuilder(ns, coll)
+	if existing, ok := collHashedRwBuilder.writeMap[key]; ok {
+		// a write following a purge of the key in the same transaction
+		// does not undo the purge of the earlier versions of the key
+		kvWriteHash.IsPurge = existing.IsPurge
+	}
+	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
+	collHashedRwBuilder.writeMap[key] = kvWriteHash
+}
+
+// AddToPvtAndHashedWriteSetForPurge adds the delete of a key to the private write-set and
+// the purge of the key to the hashed write-set
+func (b *RWSetBuilder) AddToPvtAndHashedWriteSetForPurge(ns string, coll string, key string) {
+	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, nil)
+	kvWriteHash.IsPurge = true
 	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
 	b.getOrCreateCollHashedRwBuilder(ns, coll).writeMap[key] = kvWriteHash
 }
@@ -342,6 +342,47 @@ func TestTxSimulationResultWithMetadata(t *testing.T) {
 	assert.Equal(t, expectedPubRWSet, actualSimRes.PubSimulationResults)
 }
 
+func TestTxSimulationResultWithPurge(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key1")
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key2")
+	// a write following the purge retains the purge
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key2", []byte("value2"))
+	// a purge following a write overrides the write
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key3", []byte("value3"))
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key3")
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	expectedPvtRWSet := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			{Key: "key1", IsDelete: true},
+			{Key: "key2", Value: []byte("value2")},
+			{Key: "key3", IsDelete: true},
+		},
+	}
+	actualPvtRWSet := &kvrwset.KVRWSet{}
+	assert.NoError(t, proto.Unmarshal(actualSimRes.PvtSimulationResults.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, actualPvtRWSet))
+	assert.True(t, proto.Equal(expectedPvtRWSet, actualPvtRWSet))
+
+	purgeWriteHash := func(key string, value []byte) *kvrwset.KVWriteHash {
+		kvWriteHash := constructTestPvtKVWriteHash(t, key, value)
+		kvWriteHash.IsPurge = true
+		return kvWriteHash
+	}
+	expectedHashedRWSet := &kvrwset.HashedRWSet{
+		HashedWrites: []*kvrwset.KVWriteHash{
+			purgeWriteHash("key1", nil),
+			purgeWriteHash("key2", []byte("value2")),
+			purgeWriteHash("key3", nil),
+		},
+	}
+	txRWSet := &TxRwSet{}
+	assert.NoError(t, txRWSet.FromProtoBytes(serializeTestProtoMsg(t, actualSimRes.PubSimulationResults)))
+	assert.True(t, proto.Equal(expectedHashedRWSet, txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet))
+}
+
 func constructTestPvtKVReadHash(t *testing.T, key string, version *version.Height) *kvrwset.KVReadHash {
 	kvReadHash := newPvtKVReadHash(key, version)
 	return kvReadHash
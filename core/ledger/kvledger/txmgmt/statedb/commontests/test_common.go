@@ -949,3 +949,67 @@ func TestApplyUpdatesWithNilHeight(t *testing.T, dbProvider statedb.VersionedDBP
 	assert.Equal(t, savePoint, ht) // savepoint should still be what was set with batch1
 	// (because batch2 calls ApplyUpdates with savepoint as nil)
 }
+
+// TestFullScanIterator tests the iterator over all the keys of a db that implements FullScannable
+func TestFullScanIterator(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testfullscaniterator")
+	assert.NoError(t, err)
+	fullScannable, ok := db.(statedb.FullScannable)
+	assert.True(t, ok)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 2))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 3))
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 4))
+	batch.Put("ns3", "key1", []byte("value1"), version.NewHeight(1, 5))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 5)))
+
+	collectResults := func(skipNamespace func(string) bool) []*statedb.VersionedKV {
+		itr, err := fullScannable.GetFullScanIterator(skipNamespace)
+		assert.NoError(t, err)
+		defer itr.Close()
+		results := []*statedb.VersionedKV{}
+		for {
+			kv, err := itr.Next()
+			assert.NoError(t, err)
+			if kv == nil {
+				break
+			}
+			results = append(results, kv)
+		}
+		return results
+	}
+
+	results := collectResults(func(string) bool { return false })
+	assert.Equal(t,
+		[]*statedb.VersionedKV{
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 2)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 3)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 4)},
+			},
+			{
+				CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key1"},
+				VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 5)},
+			},
+		},
+		results,
+	)
+
+	results = collectResults(func(ns string) bool { return ns == "ns1" || ns == "ns3" })
+	assert.Len(t, results, 2)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "", Key: "key1"}, results[0].CompositeKey)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns2", Key: "key1"}, results[1].CompositeKey)
+}
@@ -0,0 +1,561 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateboltdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+var logger = flogging.MustGetLogger("stateboltdb")
+
+// The state of a namespace is maintained in a separate bucket so that a range query seeks directly
+// within the bucket of the namespace. The name of a namespace bucket is the namespace prefixed with
+// nsBucketPrefix, which keeps the empty namespace a valid bucket name and keeps the namespace buckets
+// apart from the metadata bucket that holds the savepoint
+var (
+	nsBucketPrefix = []byte{0x00}
+	metadataBucket = []byte("metadata")
+	savePointKey   = []byte("savepoint")
+)
+
+const (
+	dbFileSuffix = ".db"
+	// openTimeout is the time to wait for the file lock of a db, which is held by another process using the db
+	openTimeout = time.Second
+	// initialMmapSize is the initial size of the memory map of a db. A commit that grows the db beyond the
+	// size of the memory map waits for all the open read transactions (i.e., iterators) to finish before
+	// remapping, so a large initial size avoids the commits from waiting for the readers
+	initialMmapSize = 1 << 30
+	optionLimit     = "limit"
+)
+
+// VersionedDBProvider implements interface VersionedDBProvider. Each db is maintained in a separate
+// bbolt file under the dbPath
+type VersionedDBProvider struct {
+	dbPath string
+	mux    sync.Mutex
+	dbs    map[string]*versionedDB
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider(dbPath string) *VersionedDBProvider {
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	return &VersionedDBProvider{
+		dbPath: dbPath,
+		dbs:    map[string]*versionedDB{},
+	}
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	if vdb, ok := provider.dbs[dbName]; ok {
+		return vdb, nil
+	}
+	if err := os.MkdirAll(provider.dbPath, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error while creating the directory [%s] for the state", provider.dbPath)
+	}
+	db, err := bolt.Open(provider.dbFilePath(dbName), 0600, &bolt.Options{
+		Timeout:         openTimeout,
+		InitialMmapSize: initialMmapSize,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the state of [%s]", dbName)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(metadataBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "error while initializing the state of [%s]", dbName)
+	}
+	vdb := newVersionedDB(db, dbName)
+	provider.dbs[dbName] = vdb
+	return vdb, nil
+}
+
+// Drop drops all the data of the named database. The database should not be in use
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	if vdb, ok := provider.dbs[dbName]; ok {
+		if err := vdb.close(); err != nil {
+			return errors.Wrapf(err, "error while closing the state of [%s]", dbName)
+		}
+		delete(provider.dbs, dbName)
+	}
+	if err := os.Remove(provider.dbFilePath(dbName)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error while dropping the state of [%s]", dbName)
+	}
+	return nil
+}
+
+// Close closes all the underlying dbs
+func (provider *VersionedDBProvider) Close() {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	for dbName, vdb := range provider.dbs {
+		if err := vdb.close(); err != nil {
+			logger.Warningf("Error while closing the state of [%s]: %s", dbName, err)
+		}
+		delete(provider.dbs, dbName)
+	}
+}
+
+func (provider *VersionedDBProvider) dbFilePath(dbName string) string {
+	return filepath.Join(provider.dbPath, dbName+dbFileSuffix)
+}
+
+// versionedDB implements VersionedDB interface
+type versionedDB struct {
+	db     *bolt.DB
+	dbName string
+	// committedVersions is used as a local cache of the versions during the validation of a block
+	committedVersions map[statedb.CompositeKey]*version.Height
+	verCacheLock      sync.RWMutex
+	// readTxs are the read transactions held by the open iterators
+	readTxs     map[*bolt.Tx]struct{}
+	readTxsLock sync.Mutex
+}
+
+// newVersionedDB constructs an instance of VersionedDB
+func newVersionedDB(db *bolt.DB, dbName string) *versionedDB {
+	return &versionedDB{
+		db:                db,
+		dbName:            dbName,
+		committedVersions: map[statedb.CompositeKey]*version.Height{},
+		readTxs:           map[*bolt.Tx]struct{}{},
+	}
+}
+
+// beginReadTx starts a read transaction for an iterator. The transaction should be
+// released via the function `releaseReadTx` when the iterator is closed
+func (vdb *versionedDB) beginReadTx() (*bolt.Tx, error) {
+	tx, err := vdb.db.Begin(false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while starting a read transaction on the state of [%s]", vdb.dbName)
+	}
+	vdb.readTxsLock.Lock()
+	defer vdb.readTxsLock.Unlock()
+	vdb.readTxs[tx] = struct{}{}
+	return tx, nil
+}
+
+func (vdb *versionedDB) releaseReadTx(tx *bolt.Tx) {
+	vdb.readTxsLock.Lock()
+	defer vdb.readTxsLock.Unlock()
+	if _, ok := vdb.readTxs[tx]; !ok {
+		return
+	}
+	delete(vdb.readTxs, tx)
+	tx.Rollback()
+}
+
+// close closes the underlying db. Closing a bbolt db waits for all the read transactions to finish,
+// hence the transactions held by the iterators that are not closed yet are released first
+func (vdb *versionedDB) close() error {
+	vdb.readTxsLock.Lock()
+	if len(vdb.readTxs) > 0 {
+		logger.Warningf("Releasing [%d] iterators on the state of [%s] that are not closed", len(vdb.readTxs), vdb.dbName)
+	}
+	for tx := range vdb.readTxs {
+		delete(vdb.readTxs, tx)
+		tx.Rollback()
+	}
+	vdb.readTxsLock.Unlock()
+	return vdb.db.Close()
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because the db is managed by the provider
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because the db is managed by the provider
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	if key == "" {
+		return errors.New("invalid key. Empty keys are not supported by boltdb")
+	}
+	if len(key) > bolt.MaxKeySize {
+		return errors.Errorf("invalid key. The length of the key [%d] exceeds the maximum length [%d] supported by boltdb",
+			len(key), bolt.MaxKeySize)
+	}
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	vals, err := vdb.GetStateMultipleKeys(namespace, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	return vals[0], nil
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	if ver, ok := vdb.GetCachedVersion(namespace, key); ok {
+		return ver, nil
+	}
+	versionedValue, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if versionedValue == nil {
+		return nil, nil
+	}
+	return versionedValue.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface. All the keys are read
+// from the same snapshot of the db
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	err := vdb.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(nsBucketName(namespace))
+		if bucket == nil {
+			return nil
+		}
+		for i, key := range keys {
+			dbVal := bucket.Get([]byte(key))
+			if dbVal == nil {
+				continue
+			}
+			val, err := decodeValue(dbVal)
+			if err != nil {
+				return err
+			}
+			vals[i] = val
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while reading the state of [%s]", vdb.dbName)
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface. The returned
+// iterator reads from a snapshot of the db taken at the time of this call and holds a read transaction
+// on the db until the iterator is closed
+func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit := int32(0)
+	// if metadata is provided, validate and apply options
+	if metadata != nil {
+		if err := statedb.ValidateRangeMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+	}
+	tx, err := vdb.beginReadTx()
+	if err != nil {
+		return nil, err
+	}
+	scanner := &kvScanner{
+		vdb:            vdb,
+		namespace:      namespace,
+		tx:             tx,
+		requestedLimit: requestedLimit,
+	}
+	if endKey != "" {
+		scanner.endKey = []byte(endKey)
+	}
+	if bucket := tx.Bucket(nsBucketName(namespace)); bucket != nil {
+		scanner.itr = &cursorItr{cursor: bucket.Cursor(), startKey: []byte(startKey)}
+	}
+	return scanner, nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return nil, errors.New("ExecuteQuery not supported for boltdb")
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	return nil, errors.New("ExecuteQueryWithMetadata not supported for boltdb")
+}
+
+// ApplyUpdates implements method in VersionedDB interface. The updates are applied in a single
+// bbolt transaction, which is synced to the disk on commit
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	err := vdb.db.Update(func(tx *bolt.Tx) error {
+		for _, ns := range batch.GetUpdatedNamespaces() {
+			bucket, err := tx.CreateBucketIfNotExists(nsBucketName(ns))
+			if err != nil {
+				return errors.Wrapf(err, "error while creating the bucket for namespace [%s]", ns)
+			}
+			for k, vv := range batch.GetUpdates(ns) {
+				logger.Debugf("Channel [%s]: Applying ns=[%s] key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, ns, k, []byte(k))
+				if vv.Value == nil {
+					if err := bucket.Delete([]byte(k)); err != nil {
+						return errors.Wrapf(err, "error while deleting key [%s] of namespace [%s]", k, ns)
+					}
+					continue
+				}
+				encodedVal, err := encodeValue(vv)
+				if err != nil {
+					return err
+				}
+				if err := bucket.Put([]byte(k), encodedVal); err != nil {
+					return errors.Wrapf(err, "error while writing key [%s] of namespace [%s]", k, ns)
+				}
+			}
+		}
+		// Record a savepoint at a given height
+		// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+		// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+		// in the pvtstore acts as a savepoint for pvt data.
+		if height != nil {
+			return tx.Bucket(metadataBucket).Put(savePointKey, height.ToBytes())
+		}
+		return nil
+	})
+	return errors.WithMessagef(err, "error while applying the updates to the state of [%s]", vdb.dbName)
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	var savepoint *version.Height
+	err := vdb.db.View(func(tx *bolt.Tx) error {
+		if versionBytes := tx.Bucket(metadataBucket).Get(savePointKey); versionBytes != nil {
+			savepoint, _ = version.NewHeightFromBytes(versionBytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the savepoint of [%s]", vdb.dbName)
+	}
+	return savepoint, nil
+}
+
+// LoadCommittedVersions implements method in BulkOptimizable interface. The versions of all the
+// keys are read from the same snapshot of the db and the keys that are not present in the db are
+// cached with a nil version
+func (vdb *versionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) error {
+	committedVersions := map[statedb.CompositeKey]*version.Height{}
+	err := vdb.db.View(func(tx *bolt.Tx) error {
+		for _, key := range keys {
+			committedVersions[*key] = nil
+			bucket := tx.Bucket(nsBucketName(key.Namespace))
+			if bucket == nil {
+				continue
+			}
+			dbVal := bucket.Get([]byte(key.Key))
+			if dbVal == nil {
+				continue
+			}
+			vv, err := decodeValue(dbVal)
+			if err != nil {
+				return err
+			}
+			committedVersions[*key] = vv.Version
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "error while loading the committed versions from the state of [%s]", vdb.dbName)
+	}
+	vdb.verCacheLock.Lock()
+	defer vdb.verCacheLock.Unlock()
+	vdb.committedVersions = committedVersions
+	return nil
+}
+
+// GetCachedVersion implements method in BulkOptimizable interface
+func (vdb *versionedDB) GetCachedVersion(namespace, key string) (*version.Height, bool) {
+	vdb.verCacheLock.RLock()
+	defer vdb.verCacheLock.RUnlock()
+	ver, ok := vdb.committedVersions[statedb.CompositeKey{Namespace: namespace, Key: key}]
+	return ver, ok
+}
+
+// ClearCachedVersions implements method in BulkOptimizable interface
+func (vdb *versionedDB) ClearCachedVersions() {
+	vdb.verCacheLock.Lock()
+	defer vdb.verCacheLock.Unlock()
+	vdb.committedVersions = map[statedb.CompositeKey]*version.Height{}
+}
+
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, error) {
+	tx, err := vdb.beginReadTx()
+	if err != nil {
+		return nil, err
+	}
+	return &fullDBScanner{
+		vdb:           vdb,
+		tx:            tx,
+		bucketItr:     &cursorItr{cursor: tx.Cursor()},
+		skipNamespace: skipNamespace,
+	}, nil
+}
+
+func nsBucketName(ns string) []byte {
+	return append(append([]byte{}, nsBucketPrefix...), ns...)
+}
+
+// namespaceOf returns the namespace for the given bucket name and false if the bucket is not a namespace bucket
+func namespaceOf(bucketName []byte) (string, bool) {
+	if !bytes.HasPrefix(bucketName, nsBucketPrefix) {
+		return "", false
+	}
+	return string(bucketName[len(nsBucketPrefix):]), true
+}
+
+// cursorItr iterates over a bbolt cursor starting from the startKey
+type cursorItr struct {
+	cursor   *bolt.Cursor
+	startKey []byte
+	started  bool
+}
+
+func (itr *cursorItr) next() ([]byte, []byte) {
+	if !itr.started {
+		itr.started = true
+		return itr.cursor.Seek(itr.startKey)
+	}
+	return itr.cursor.Next()
+}
+
+type kvScanner struct {
+	vdb       *versionedDB
+	namespace string
+	tx        *bolt.Tx
+	// itr is nil if the namespace does not exist or the iterator is exhausted
+	itr                  *cursorItr
+	endKey               []byte
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func (scanner *kvScanner) nextKV() ([]byte, []byte) {
+	if scanner.itr == nil {
+		return nil, nil
+	}
+	k, v := scanner.itr.next()
+	if k == nil || (scanner.endKey != nil && bytes.Compare(k, scanner.endKey) >= 0) {
+		scanner.itr = nil
+		return nil, nil
+	}
+	return k, v
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	k, v := scanner.nextKV()
+	if k == nil {
+		return nil, nil
+	}
+	vv, err := decodeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: string(k)},
+		VersionedValue: *vv,
+	}, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.itr = nil
+	if scanner.tx != nil {
+		scanner.vdb.releaseReadTx(scanner.tx)
+		scanner.tx = nil
+	}
+}
+
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if k, _ := scanner.nextKV(); k != nil {
+		retval = string(k)
+	}
+	scanner.Close()
+	return retval
+}
+
+type fullDBScanner struct {
+	vdb           *versionedDB
+	tx            *bolt.Tx
+	bucketItr     *cursorItr
+	namespace     string
+	keyItr        *cursorItr
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (*statedb.VersionedKV, error) {
+	if s.tx == nil {
+		return nil, nil
+	}
+	for {
+		if s.keyItr != nil {
+			if k, v := s.keyItr.next(); k != nil {
+				vv, err := decodeValue(v)
+				if err != nil {
+					return nil, err
+				}
+				return &statedb.VersionedKV{
+					CompositeKey:   statedb.CompositeKey{Namespace: s.namespace, Key: string(k)},
+					VersionedValue: *vv,
+				}, nil
+			}
+			s.keyItr = nil
+		}
+		bucketName, _ := s.bucketItr.next()
+		if bucketName == nil {
+			return nil, nil
+		}
+		ns, ok := namespaceOf(bucketName)
+		if !ok || s.skipNamespace(ns) {
+			continue
+		}
+		s.namespace = ns
+		s.keyItr = &cursorItr{cursor: s.tx.Bucket(bucketName).Cursor()}
+	}
+}
+
+func (s *fullDBScanner) Close() {
+	if s.tx != nil {
+		s.vdb.releaseReadTx(s.tx)
+		s.tx = nil
+	}
+}
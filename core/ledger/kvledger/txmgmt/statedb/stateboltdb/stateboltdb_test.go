@@ -0,0 +1,283 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateboltdb
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	flogging.ActivateSpec("stateboltdb=debug")
+	os.Exit(m.Run())
+}
+
+func TestGetStateMultipleKeys(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetStateMultipleKeys(t, env.DBProvider)
+}
+
+func TestBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBasicRW(t, env.DBProvider)
+}
+
+func TestMultiDBBasicRW(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestMultiDBBasicRW(t, env.DBProvider)
+}
+
+func TestDeletes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDeletes(t, env.DBProvider)
+}
+
+func TestIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestIterator(t, env.DBProvider)
+}
+
+func TestGetVersion(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestGetVersion(t, env.DBProvider)
+}
+
+func TestSmallBatchSize(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestSmallBatchSize(t, env.DBProvider)
+}
+
+func TestBatchWithIndividualRetry(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBatchWithIndividualRetry(t, env.DBProvider)
+}
+
+func TestValueAndMetadataWrites(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
+}
+
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
+func TestApplyUpdatesWithNilHeight(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
+}
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestFullScanIterator(t, env.DBProvider)
+}
+
+func TestQueryNotSupported(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquery")
+	require.NoError(t, err)
+
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
+	assert.EqualError(t, err, "ExecuteQuery not supported for boltdb")
+	assert.Nil(t, itr)
+	qitr, err := db.ExecuteQueryWithMetadata("ns1", `{"selector":{"owner":"jerry"}}`, nil)
+	assert.EqualError(t, err, "ExecuteQueryWithMetadata not supported for boltdb")
+	assert.Nil(t, qitr)
+}
+
+func TestUtilityFunctions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testutilityfunctions")
+	require.NoError(t, err)
+
+	assert.True(t, db.BytesKeySupported())
+	assert.NoError(t, db.ValidateKeyValue("testKey", []byte("testValue")))
+	assert.NoError(t, db.ValidateKeyValue(string([]byte{0x00, 0xff}), nil))
+	assert.EqualError(t, db.ValidateKeyValue("", []byte("testValue")),
+		"invalid key. Empty keys are not supported by boltdb")
+	assert.EqualError(t, db.ValidateKeyValue(strings.Repeat("k", 32769), []byte("testValue")),
+		"invalid key. The length of the key [32769] exceeds the maximum length [32768] supported by boltdb")
+}
+
+func TestIteratorSnapshotIsolation(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testsnapshotisolation")
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns", "key2", []byte("value2"), version.NewHeight(1, 2))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	itr, err := db.GetStateRangeScanIterator("ns", "", "")
+	require.NoError(t, err)
+	defer itr.Close()
+
+	// the updates committed after creating the iterator are not visible to the iterator
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1-updated"), version.NewHeight(2, 1))
+	batch.Delete("ns", "key2", version.NewHeight(2, 2))
+	batch.Put("ns", "key3", []byte("value3"), version.NewHeight(2, 3))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+
+	expected := []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)},
+		},
+	}
+	for _, e := range expected {
+		kv, err := itr.Next()
+		require.NoError(t, err)
+		require.Equal(t, e, kv)
+	}
+	kv, err := itr.Next()
+	require.NoError(t, err)
+	require.Nil(t, kv)
+
+	// a new iterator sees the latest state
+	itr2, err := db.GetStateRangeScanIterator("ns", "", "")
+	require.NoError(t, err)
+	defer itr2.Close()
+	commontests.TestItrWithoutClose(t, itr2, []string{"key1", "key3"})
+}
+
+func TestRangeScanOnMissingNamespace(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testmissingnamespace")
+	require.NoError(t, err)
+
+	itr, err := db.GetStateRangeScanIteratorWithMetadata("ns", "", "", map[string]interface{}{"limit": int32(10)})
+	require.NoError(t, err)
+	kv, err := itr.Next()
+	require.NoError(t, err)
+	require.Nil(t, kv)
+	require.Equal(t, "", itr.GetBookmarkAndClose())
+	// closing again is a no-op
+	itr.Close()
+}
+
+func TestLoadCommittedVersions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testloadcommittedversions")
+	require.NoError(t, err)
+	bulkdb, ok := db.(statedb.BulkOptimizable)
+	require.True(t, ok)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1"), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	require.NoError(t, bulkdb.LoadCommittedVersions([]*statedb.CompositeKey{
+		{Namespace: "ns", Key: "key1"},
+		{Namespace: "ns", Key: "key2"},
+		{Namespace: "ns2", Key: "key1"},
+	}))
+	ver, ok := bulkdb.GetCachedVersion("ns", "key1")
+	require.True(t, ok)
+	require.Equal(t, version.NewHeight(1, 1), ver)
+	for _, key := range []statedb.CompositeKey{{Namespace: "ns", Key: "key2"}, {Namespace: "ns2", Key: "key1"}} {
+		ver, ok := bulkdb.GetCachedVersion(key.Namespace, key.Key)
+		require.True(t, ok)
+		require.Nil(t, ver)
+	}
+	_, ok = bulkdb.GetCachedVersion("ns", "key3")
+	require.False(t, ok)
+
+	// the version is served from the cache until the cache is cleared
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns", "key2", []byte("value2"), version.NewHeight(2, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	ver, err = db.GetVersion("ns", "key2")
+	require.NoError(t, err)
+	require.Nil(t, ver)
+	bulkdb.ClearCachedVersions()
+	ver, err = db.GetVersion("ns", "key2")
+	require.NoError(t, err)
+	require.Equal(t, version.NewHeight(2, 1), ver)
+}
+
+func TestReopen(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testreopen")
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1"), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	env.DBProvider.Close()
+
+	env.DBProvider = NewVersionedDBProvider(env.dbPath)
+	db, err = env.DBProvider.GetDBHandle("testreopen")
+	require.NoError(t, err)
+	vv, err := db.GetState("ns", "key1")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	savepoint, err := db.GetLatestSavePoint()
+	require.NoError(t, err)
+	require.Equal(t, version.NewHeight(1, 1), savepoint)
+}
+
+func TestDrop(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+
+	for _, dbName := range []string{"testdrop1", "testdrop2"} {
+		db, err := env.DBProvider.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns", "key", []byte("value"), version.NewHeight(1, 1))
+		assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	}
+	assert.NoError(t, env.DBProvider.Drop("testdrop1"))
+	// dropping a db that does not exist is a no-op
+	assert.NoError(t, env.DBProvider.Drop("testdrop3"))
+
+	db1, err := env.DBProvider.GetDBHandle("testdrop1")
+	assert.NoError(t, err)
+	vv, err := db1.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	savepoint, err := db1.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+
+	db2, err := env.DBProvider.GetDBHandle("testdrop2")
+	assert.NoError(t, err)
+	vv, err = db2.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), vv.Value)
+}
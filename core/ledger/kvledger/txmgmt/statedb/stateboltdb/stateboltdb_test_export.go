@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateboltdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// TestVDBEnv provides a boltdb backed versioned db for testing
+type TestVDBEnv struct {
+	t          testing.TB
+	DBProvider statedb.VersionedDBProvider
+	dbPath     string
+}
+
+// NewTestVDBEnv instantiates and new boltdb backed TestVDB
+func NewTestVDBEnv(t testing.TB) *TestVDBEnv {
+	t.Logf("Creating new TestVDBEnv")
+	dbPath, err := ioutil.TempDir("", "stateboltdb")
+	if err != nil {
+		t.Fatalf("Failed to create boltdb directory: %s", err)
+	}
+	dbProvider := NewVersionedDBProvider(dbPath)
+	return &TestVDBEnv{t, dbProvider, dbPath}
+}
+
+// Cleanup closes the db and removes the db folder
+func (env *TestVDBEnv) Cleanup() {
+	env.t.Logf("Cleaningup TestVDBEnv")
+	env.DBProvider.Close()
+	os.RemoveAll(env.dbPath)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateboltdb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb/msgs"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+// encodeValue encodes the versioned value. Unlike the leveldb encoding, there is no need for a format
+// marker, as the boltdb state has never contained the pre-v1.3 format
+func encodeValue(v *statedb.VersionedValue) ([]byte, error) {
+	vvMsg := &msgs.VersionedValueProto{
+		VersionBytes: v.Version.ToBytes(),
+		Value:        v.Value,
+		Metadata:     v.Metadata,
+	}
+	encodedValue, err := proto.Marshal(vvMsg)
+	return encodedValue, errors.Wrap(err, "error while encoding the value")
+}
+
+// decodeValue decodes the value bytes read from the db. The bytes returned by bbolt are valid only
+// for the life of the read transaction, hence these are copied before decoding
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	encodedValueCopy := make([]byte, len(encodedValue))
+	copy(encodedValueCopy, encodedValue)
+	msg := &msgs.VersionedValueProto{}
+	if err := proto.Unmarshal(encodedValueCopy, msg); err != nil {
+		return nil, errors.Wrap(err, "error while decoding the value")
+	}
+	ver, _ := version.NewHeightFromBytes(msg.VersionBytes)
+	val := msg.Value
+	// protobuf always makes an empty byte array as nil
+	if val == nil {
+		val = []byte{}
+	}
+	return &statedb.VersionedValue{Version: ver, Value: val, Metadata: msg.Metadata}, nil
+}
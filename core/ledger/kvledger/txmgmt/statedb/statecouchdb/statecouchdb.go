@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -72,6 +73,34 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return vdb, nil
 }
 
+// Drop drops the metadataDB and the namespaceDBs of the named database along with its redo log.
+// The database should not be in use
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	delete(provider.databases, dbName)
+	prefix, err := couchdb.ChainDBNamePrefix(dbName)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot identify the couch databases of [%s]", dbName)
+	}
+	couchDBNames, err := provider.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	for _, couchDBName := range couchDBNames {
+		if !strings.HasPrefix(couchDBName, prefix) {
+			continue
+		}
+		couchDB := &couchdb.CouchDatabase{CouchInstance: provider.couchInstance, DBName: couchDBName}
+		if _, err := couchDB.DropDatabase(); err != nil {
+			return errors.WithMessagef(err, "error while dropping the couch database [%s]", couchDBName)
+		}
+		logger.Infof("Dropped the couch database [%s]", couchDBName)
+	}
+	return errors.WithMessagef(provider.redoLoggerProvider.leveldbProvider.GetDBHandle(dbName).DeleteAll(),
+		"error while dropping the redo log of [%s]", dbName)
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
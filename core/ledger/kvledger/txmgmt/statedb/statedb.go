@@ -22,6 +22,8 @@ import (
 type VersionedDBProvider interface {
 	// GetDBHandle returns a handle to a VersionedDB
 	GetDBHandle(id string) (VersionedDB, error)
+	// Drop removes all the data of the VersionedDB with the given id. The VersionedDB should not be in use
+	Drop(id string) error
 	// Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -82,12 +84,32 @@ type BulkOptimizable interface {
 }
 
 //IndexCapable interface provides additional functions for
-//databases capable of index operations
+//databases capable of index operations. GetDBType returns the
+//directory under "META-INF/statedb" in the chaincode package from
+//where the index definitions are read
 type IndexCapable interface {
 	GetDBType() string
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScannable interface provides additional functions for
+//databases capable of iterating over all the keys, such as for
+//exporting the data in a snapshot
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the keys of the db, in the
+	// order of the namespace and then the key. The namespaces for which `skipNamespace`
+	// returns true are excluded from the results
+	GetFullScanIterator(skipNamespace func(namespace string) bool) (FullScanIterator, error)
+}
+
+// FullScanIterator iterates over all the keys of a db
+type FullScanIterator interface {
+	// Next returns the next key-value. A nil value indicates that the iterator is exhausted
+	Next() (*VersionedKV, error)
+	// Close releases any resources held by the iterator
+	Close()
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
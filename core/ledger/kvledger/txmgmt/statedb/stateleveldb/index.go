@@ -0,0 +1,378 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// The secondary indexes that serve the rich queries are maintained in a separate db handle, with the name
+// of the state db suffixed by indexDBNameSuffix. The index db contains two types of keys
+// 1) An index definition - indexDefinitionKeyPrefix + <namespace> + <index name>
+// 2) An index entry - indexEntryKeyPrefix + <namespace> + <index name> + <values of the indexed fields> + docKeySep + <key>
+// The namespace, the index name, and the values of the indexed fields are encoded such that the byte order of
+// the encoded values follows the order of the values (see function `encodeIndexValue`). Hence, the index entries
+// of an index are ordered by the values of the indexed fields and a query iterates over a range of the entries
+const (
+	// indexDefinitionsDBType is the database directory under "META-INF/statedb" in the chaincode package from
+	// where the index definitions are read. The leveldb indexes are declared in the same format as the couchdb
+	// indexes, which is validated by the ccmetadata package on chaincode install
+	indexDefinitionsDBType = "couchdb"
+	indexDBNameSuffix      = "$$index"
+	indexBuildBatchSize    = 1000
+)
+
+var (
+	indexDefinitionKeyPrefix = []byte{0x01}
+	indexEntryKeyPrefix      = []byte{0x02}
+	docKeySep                = []byte{0x00}
+	// rangeEndIndicator is larger than the first byte of any encoded value and of the docKeySep,
+	// hence the key `prefix + rangeEndIndicator` is larger than any index key that begins with the prefix
+	rangeEndIndicator = []byte{0xff}
+)
+
+// type tags of the encoded values, in the order of the JSON types in the CouchDB collation
+const (
+	nullTag byte = iota + 0x01
+	falseTag
+	trueTag
+	numberTag
+	stringTag
+)
+
+// indexDefinition is a secondary index over the given fields of the JSON values of a namespace
+type indexDefinition struct {
+	DDoc   string   `json:"ddoc,omitempty"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+func (d *indexDefinition) sameFields(other *indexDefinition) bool {
+	return reflect.DeepEqual(d.Fields, other.Fields)
+}
+
+// couchdbIndexDefinition is the definition of an index file as packaged in the chaincode, for instance,
+// {"index":{"fields":["docType",{"owner":"desc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type couchdbIndexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	DDoc string `json:"ddoc"`
+	Name string `json:"name"`
+}
+
+// parseIndexDefinition parses an index file. As the entries of an index can be iterated in both the
+// directions, the sort order of the indexed fields, if specified in the file, is ignored
+func parseIndexDefinition(fileName string, content []byte) (*indexDefinition, error) {
+	couchdbDef := &couchdbIndexDefinition{}
+	if err := json.Unmarshal(content, couchdbDef); err != nil {
+		return nil, errors.Wrapf(err, "index definition in file [%s] is not a valid JSON", fileName)
+	}
+	def := &indexDefinition{DDoc: couchdbDef.DDoc, Name: couchdbDef.Name}
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	for _, field := range couchdbDef.Index.Fields {
+		switch f := field.(type) {
+		case string:
+			def.Fields = append(def.Fields, f)
+		case map[string]interface{}:
+			if len(f) != 1 {
+				return nil, errors.Errorf("index definition in file [%s] has an invalid field [%v]", fileName, f)
+			}
+			for fieldName := range f {
+				def.Fields = append(def.Fields, fieldName)
+			}
+		default:
+			return nil, errors.Errorf("index definition in file [%s] has an invalid field [%v]", fileName, f)
+		}
+	}
+	if len(def.Fields) == 0 {
+		return nil, errors.Errorf("index definition in file [%s] does not contain any fields", fileName)
+	}
+	return def, nil
+}
+
+// GetDBType implements method in IndexCapable interface. The leveldb indexes are read from the
+// couchdb directory in the chaincode package (see `indexDefinitionsDBType`)
+func (vdb *versionedDB) GetDBType() string {
+	return indexDefinitionsDBType
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface. An index is populated
+// with the existing data of the namespace before the index is made available to the queries. An index
+// with the same name as an existing index replaces the existing index, if the indexed fields are different
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	for _, fileEntry := range fileEntries {
+		def, err := parseIndexDefinition(fileEntry.FileHeader.Name, fileEntry.FileContent)
+		if err != nil {
+			return err
+		}
+		if err := vdb.createIndex(namespace, def); err != nil {
+			return errors.WithMessagef(err, "error creating index from file [%s] for namespace [%s]", fileEntry.FileHeader.Name, namespace)
+		}
+	}
+	return nil
+}
+
+func (vdb *versionedDB) createIndex(namespace string, def *indexDefinition) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+
+	defs, err := vdb.getIndexDefinitions(namespace)
+	if err != nil {
+		return err
+	}
+	for _, existing := range defs {
+		if existing.Name != def.Name {
+			continue
+		}
+		if existing.sameFields(def) {
+			logger.Debugf("Index [%s] on namespace [%s] already exists", def.Name, namespace)
+			return nil
+		}
+		logger.Infof("Replacing index [%s] on namespace [%s]", def.Name, namespace)
+		if err := vdb.deleteIndexEntries(namespace, existing.Name); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("Building index [%s] on fields %s of namespace [%s]", def.Name, def.Fields, namespace)
+	compositeStartKey := constructCompositeKey(namespace, "")
+	compositeEndKey := constructCompositeKey(namespace, "")
+	compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	dbItr := vdb.db.GetIterator(compositeStartKey, compositeEndKey)
+	defer dbItr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		_, key := splitCompositeKey(dbItr.Key())
+		vv, err := decodeValue(append([]byte(nil), dbItr.Value()...))
+		if err != nil {
+			return err
+		}
+		if entryKey := indexEntryKey(namespace, def, key, vv.Value); entryKey != nil {
+			batch.Put(entryKey, []byte(key))
+		}
+		if batch.Len() >= indexBuildBatchSize {
+			if err := vdb.indexDB.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while iterating over the namespace [%s]", namespace)
+	}
+	// the definition is written last so that the index is used by the queries only after it is fully populated
+	defBytes, err := json.Marshal(def)
+	if err != nil {
+		return errors.Wrap(err, "error while marshalling the index definition")
+	}
+	batch.Put(indexDefinitionKey(namespace, def.Name), defBytes)
+	if err := vdb.indexDB.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	delete(vdb.indexDefinitions, namespace)
+	return nil
+}
+
+func (vdb *versionedDB) deleteIndexEntries(namespace, indexName string) error {
+	prefix := indexEntryKeyPrefixForIndex(namespace, indexName)
+	itr := vdb.indexDB.GetIterator(prefix, rangeEnd(prefix))
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Delete(indexDefinitionKey(namespace, indexName))
+	for itr.Next() {
+		batch.Delete(append([]byte(nil), itr.Key()...))
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while iterating over the index [%s]", indexName)
+	}
+	delete(vdb.indexDefinitions, namespace)
+	return vdb.indexDB.WriteBatch(batch, true)
+}
+
+// getIndexDefinitions returns the definitions of the indexes of the given namespace. The definitions are loaded
+// from the index db on the first access and cached. The caller is expected to hold the indexLock
+func (vdb *versionedDB) getIndexDefinitions(namespace string) ([]*indexDefinition, error) {
+	if defs, ok := vdb.indexDefinitions[namespace]; ok {
+		return defs, nil
+	}
+	prefix := append(append([]byte{}, indexDefinitionKeyPrefix...), encodeString(namespace)...)
+	itr := vdb.indexDB.GetIterator(prefix, rangeEnd(prefix))
+	defer itr.Release()
+	defs := []*indexDefinition{}
+	for itr.Next() {
+		def := &indexDefinition{}
+		if err := json.Unmarshal(itr.Value(), def); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshalling the index definition")
+		}
+		defs = append(defs, def)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "internal leveldb error while loading the indexes of namespace [%s]", namespace)
+	}
+	vdb.indexDefinitions[namespace] = defs
+	return defs, nil
+}
+
+// prepareIndexUpdates computes the changes to the index entries for the given updates. The old values of the
+// updated keys are read from the state, hence this is expected to be invoked before the updates are applied.
+// The caller is expected to hold the indexLock
+func (vdb *versionedDB) prepareIndexUpdates(batch *statedb.UpdateBatch) (*leveldbhelper.UpdateBatch, error) {
+	indexBatch := leveldbhelper.NewUpdateBatch()
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		defs, err := vdb.getIndexDefinitions(ns)
+		if err != nil {
+			return nil, err
+		}
+		if len(defs) == 0 {
+			continue
+		}
+		newEntries := map[string]string{}
+		for key, vv := range batch.GetUpdates(ns) {
+			oldVV, err := vdb.GetState(ns, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, def := range defs {
+				if oldVV != nil {
+					if entryKey := indexEntryKey(ns, def, key, oldVV.Value); entryKey != nil {
+						indexBatch.Delete(entryKey)
+					}
+				}
+				if entryKey := indexEntryKey(ns, def, key, vv.Value); entryKey != nil {
+					newEntries[string(entryKey)] = key
+				}
+			}
+		}
+		// the new entries are added after the deletes so that an unchanged entry is retained
+		for entryKey, key := range newEntries {
+			indexBatch.Put([]byte(entryKey), []byte(key))
+		}
+	}
+	return indexBatch, nil
+}
+
+func indexDefinitionKey(namespace, indexName string) []byte {
+	k := append([]byte{}, indexDefinitionKeyPrefix...)
+	k = append(k, encodeString(namespace)...)
+	return append(k, encodeString(indexName)...)
+}
+
+func indexEntryKeyPrefixForIndex(namespace, indexName string) []byte {
+	k := append([]byte{}, indexEntryKeyPrefix...)
+	k = append(k, encodeString(namespace)...)
+	return append(k, encodeString(indexName)...)
+}
+
+// indexEntryKey returns the key of the index entry for the given value. A nil key is returned if the value is
+// not a JSON object or if any of the indexed fields is missing in the value or is an array or an object
+func indexEntryKey(namespace string, def *indexDefinition, key string, value []byte) []byte {
+	if len(value) == 0 {
+		return nil
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil
+	}
+	entryKey := indexEntryKeyPrefixForIndex(namespace, def.Name)
+	for _, field := range def.Fields {
+		fieldValue, ok := lookupField(doc, key, splitFieldPath(field))
+		if !ok {
+			return nil
+		}
+		encodedValue, ok := encodeIndexValue(fieldValue)
+		if !ok {
+			return nil
+		}
+		entryKey = append(entryKey, encodedValue...)
+	}
+	entryKey = append(entryKey, docKeySep...)
+	return append(entryKey, key...)
+}
+
+// encodeIndexValue encodes a JSON scalar such that the byte order of the encodings follows the order of
+// the values. The values of different types are ordered by the type (null, false, true, numbers, strings).
+// Numbers are ordered numerically and strings are ordered by their UTF-8 bytes. Arrays and objects are not
+// supported and false is returned for these
+func encodeIndexValue(v interface{}) ([]byte, bool) {
+	switch val := v.(type) {
+	case nil:
+		return []byte{nullTag}, true
+	case bool:
+		if val {
+			return []byte{trueTag}, true
+		}
+		return []byte{falseTag}, true
+	case float64:
+		bits := math.Float64bits(val)
+		if val < 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		encoded := make([]byte, 9)
+		encoded[0] = numberTag
+		binary.BigEndian.PutUint64(encoded[1:], bits)
+		return encoded, true
+	case string:
+		return append([]byte{stringTag}, encodeString(val)...), true
+	default:
+		return nil, false
+	}
+}
+
+// encodeString encodes a string such that the encoding is self delimiting and follows the byte order of the
+// string. The 0x00 bytes are escaped as 0x00 0xff and the encoding is terminated by 0x00 0x01
+func encodeString(s string) []byte {
+	encoded := make([]byte, 0, len(s)+2)
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x00 {
+			encoded = append(encoded, 0x00, 0xff)
+			continue
+		}
+		encoded = append(encoded, s[i])
+	}
+	return append(encoded, 0x00, 0x01)
+}
+
+func splitFieldPath(field string) []string {
+	return strings.Split(field, ".")
+}
+
+// lookupField returns the value of the field at the given path in the doc. The field "_id" refers to the key
+func lookupField(doc map[string]interface{}, key string, path []string) (interface{}, bool) {
+	if len(path) == 1 && path[0] == "_id" {
+		return key, true
+	}
+	var current interface{} = doc
+	for _, p := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[p]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func rangeEnd(prefix []byte) []byte {
+	return append(append([]byte{}, prefix...), rangeEndIndicator...)
+}
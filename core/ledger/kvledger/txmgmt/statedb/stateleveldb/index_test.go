@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"bytes"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestIndex(t *testing.T, db statedb.VersionedDB, namespace, indexDef string) {
+	indexCapable, ok := db.(statedb.IndexCapable)
+	require.True(t, ok)
+	require.Equal(t, "couchdb", indexCapable.GetDBType())
+	fileEntries := []*ccprovider.TarFileEntry{
+		{
+			FileHeader:  &tar.Header{Name: "META-INF/statedb/couchdb/indexes/index.json"},
+			FileContent: []byte(indexDef),
+		},
+	}
+	require.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy(namespace, fileEntries))
+}
+
+func TestEncodeIndexValueOrder(t *testing.T) {
+	// the values in the expected order
+	values := []interface{}{
+		nil,
+		false,
+		true,
+		math.Inf(-1),
+		-1000.5,
+		-1.0,
+		-0.25,
+		0.0,
+		0.25,
+		1.0,
+		2.0,
+		1000.5,
+		math.Inf(1),
+		"",
+		"\x00",
+		"\x00a",
+		"a",
+		"a\x00",
+		"a\x00b",
+		"aa",
+		"ab",
+		"b",
+	}
+	encodedValues := [][]byte{}
+	for _, v := range values {
+		encodedValue, ok := encodeIndexValue(v)
+		require.True(t, ok)
+		// a value followed by the separator of the key and a key sorts before the next value
+		encodedValues = append(encodedValues, append(encodedValue, docKeySep...))
+	}
+	assert.True(t, sort.SliceIsSorted(encodedValues, func(i, j int) bool {
+		return bytes.Compare(encodedValues[i], encodedValues[j]) < 0
+	}))
+	for i := 1; i < len(encodedValues); i++ {
+		assert.True(t, bytes.Compare(encodedValues[i-1], encodedValues[i]) < 0, "%v should sort before %v", values[i-1], values[i])
+	}
+
+	for _, v := range []interface{}{[]interface{}{"a"}, map[string]interface{}{"a": "b"}} {
+		_, ok := encodeIndexValue(v)
+		assert.False(t, ok)
+	}
+}
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition("META-INF/statedb/couchdb/indexes/indexSize.json",
+		[]byte(`{"index":{"fields":["docType",{"size":"desc"}]},"type":"json"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &indexDefinition{Name: "indexSize", Fields: []string{"docType", "size"}}, def)
+
+	def, err = parseIndexDefinition("index.json",
+		[]byte(`{"index":{"fields":["owner.name"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &indexDefinition{DDoc: "indexOwnerDoc", Name: "indexOwner", Fields: []string{"owner.name"}}, def)
+
+	_, err = parseIndexDefinition("index.json", []byte(`not json`))
+	assert.Contains(t, err.Error(), "index definition in file [index.json] is not a valid JSON")
+	_, err = parseIndexDefinition("index.json", []byte(`{"index":{"fields":[]},"name":"indexOwner"}`))
+	assert.EqualError(t, err, "index definition in file [index.json] does not contain any fields")
+	_, err = parseIndexDefinition("index.json", []byte(`{"index":{"fields":[1]},"name":"indexOwner"}`))
+	assert.EqualError(t, err, "index definition in file [index.json] has an invalid field [1]")
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmaintenance")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns", "key2", []byte(`{"owner":"jerry","size":2}`), version.NewHeight(1, 2))
+	batch.Put("ns", "key3", []byte(`not json`), version.NewHeight(1, 3))
+	batch.Put("ns", "key4", []byte(`{"size":4}`), version.NewHeight(1, 4))
+	batch.Put("ns2", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 5))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 5)))
+
+	// the index is built from the existing data
+	createTestIndex(t, db, "ns", `{"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}`)
+	assert.Equal(t, []string{"jerry/key2", "tom/key1"}, indexEntries(t, vdb, "ns", "indexOwner"))
+	assert.Empty(t, indexEntries(t, vdb, "ns2", "indexOwner"))
+
+	// the index is maintained on commit
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte(`{"owner":"spike","size":1}`), version.NewHeight(2, 1))
+	batch.Delete("ns", "key2", version.NewHeight(2, 2))
+	batch.Put("ns", "key3", []byte(`{"owner":"tom"}`), version.NewHeight(2, 3))
+	batch.Put("ns", "key4", []byte(`{"size":5}`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	assert.Equal(t, []string{"spike/key1", "tom/key3"}, indexEntries(t, vdb, "ns", "indexOwner"))
+
+	// deploying the same index again is a no-op and an index with different fields replaces the existing index
+	createTestIndex(t, db, "ns", `{"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}`)
+	assert.Equal(t, []string{"spike/key1", "tom/key3"}, indexEntries(t, vdb, "ns", "indexOwner"))
+	createTestIndex(t, db, "ns", `{"index":{"fields":["size"]},"name":"indexOwner","type":"json"}`)
+	defs, err := vdb.getIndexDefinitions("ns")
+	require.NoError(t, err)
+	assert.Equal(t, []*indexDefinition{{Name: "indexOwner", Fields: []string{"size"}}}, defs)
+	assert.Len(t, indexEntries(t, vdb, "ns", "indexOwner"), 2)
+
+	// the index definitions survive the reopening of the db
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider(env.dbPath)
+	db, err = env.DBProvider.GetDBHandle("testindexmaintenance")
+	require.NoError(t, err)
+	defs, err = db.(*versionedDB).getIndexDefinitions("ns")
+	require.NoError(t, err)
+	assert.Equal(t, []*indexDefinition{{Name: "indexOwner", Fields: []string{"size"}}}, defs)
+
+	// dropping the db drops the indexes
+	require.NoError(t, env.DBProvider.Drop("testindexmaintenance"))
+	db, err = env.DBProvider.GetDBHandle("testindexmaintenance")
+	require.NoError(t, err)
+	defs, err = db.(*versionedDB).getIndexDefinitions("ns")
+	require.NoError(t, err)
+	assert.Empty(t, defs)
+	assert.Empty(t, indexEntries(t, db.(*versionedDB), "ns", "indexOwner"))
+}
+
+func TestProcessIndexesForChaincodeDeployErrors(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexerrors")
+	require.NoError(t, err)
+
+	fileEntries := []*ccprovider.TarFileEntry{
+		{
+			FileHeader:  &tar.Header{Name: "META-INF/statedb/couchdb/indexes/index.json"},
+			FileContent: []byte(`{"index":{"fields":[]}}`),
+		},
+	}
+	err = db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns", fileEntries)
+	assert.EqualError(t, err, "index definition in file [META-INF/statedb/couchdb/indexes/index.json] does not contain any fields")
+}
+
+// indexEntries returns the entries of the index as "<value of the first field>/<key>"
+func indexEntries(t *testing.T, vdb *versionedDB, namespace, indexName string) []string {
+	prefix := indexEntryKeyPrefixForIndex(namespace, indexName)
+	itr := vdb.indexDB.GetIterator(prefix, rangeEnd(prefix))
+	defer itr.Release()
+	entries := []string{}
+	for itr.Next() {
+		encodedValue := itr.Key()[len(prefix):]
+		var value string
+		switch encodedValue[0] {
+		case stringTag:
+			value = string(encodedValue[1:bytes.Index(encodedValue, []byte{0x00, 0x01})])
+		default:
+			value = "<non-string>"
+		}
+		entries = append(entries, value+"/"+string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+	return entries
+}
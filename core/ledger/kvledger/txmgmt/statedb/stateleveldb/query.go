@@ -0,0 +1,710 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// The rich queries on leveldb support a subset of the CouchDB query language (Mango). A query is a JSON object
+// with the following fields
+//   selector  - the conditions on the fields of the JSON values. A field is compared to a value for equality,
+//               either implicitly ({"owner":"tom"}) or with the operator $eq, or with the range operators $gt,
+//               $gte, $lt, and $lte. The conditions are combined with the operators $and and $or. Nested fields are
+//               referred either with the dot notation or with nested objects and the field "_id" refers to the key.
+//               Unlike CouchDB, a range operator matches only the values of the same type as the operand
+//   sort      - an array of fields, optionally with a direction ("asc" or "desc"). All the fields must have the
+//               same direction
+//   limit     - the maximum number of results
+//   skip      - the number of results to skip
+//   fields    - the fields of the values to be returned
+//   use_index - the design document and optionally the name of the index to be used
+// A query is served by one of the indexes of the namespace (see index.go) and a query that cannot be served by
+// any of the indexes is rejected. An index serves a query if the selector constrains the leading fields of the
+// index, or if the sort fields are the leading fields of the index
+
+const (
+	optionBookmark = "bookmark"
+	ascending      = "asc"
+	descending     = "desc"
+)
+
+type query struct {
+	selector   selector
+	sortFields []string
+	descending bool
+	limit      int32
+	skip       int32
+	fields     []string
+	useIndex   []string
+}
+
+func parseQuery(queryString string) (*query, error) {
+	jsonQuery := map[string]interface{}{}
+	decoder := json.NewDecoder(strings.NewReader(queryString))
+	if err := decoder.Decode(&jsonQuery); err != nil {
+		return nil, errors.Wrap(err, "invalid query, the query must be a JSON object")
+	}
+	q := &query{}
+	for field, value := range jsonQuery {
+		var err error
+		switch field {
+		case "selector":
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid query, the selector must be a JSON object")
+			}
+			q.selector, err = parseSelector(m, nil)
+		case "sort":
+			q.sortFields, q.descending, err = parseSort(value)
+		case "limit":
+			q.limit, err = parseNonNegativeInt(field, value)
+		case "skip":
+			q.skip, err = parseNonNegativeInt(field, value)
+		case "fields":
+			q.fields, err = parseStringArray(field, value)
+		case "use_index":
+			if s, ok := value.(string); ok {
+				q.useIndex = []string{s}
+			} else {
+				q.useIndex, err = parseStringArray(field, value)
+			}
+			if err == nil && (len(q.useIndex) == 0 || len(q.useIndex) > 2) {
+				err = errors.New("invalid query, use_index must contain a design document and optionally an index name")
+			}
+		default:
+			err = errors.Errorf("invalid query, the field [%s] is not supported", field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if q.selector == nil {
+		return nil, errors.New("invalid query, the query must contain a selector")
+	}
+	return q, nil
+}
+
+func parseSort(value interface{}) ([]string, bool, error) {
+	sortSpecs, ok := value.([]interface{})
+	if !ok {
+		return nil, false, errors.New("invalid query, sort must be an array")
+	}
+	fields := []string{}
+	directions := map[string]struct{}{}
+	for _, spec := range sortSpecs {
+		switch s := spec.(type) {
+		case string:
+			fields = append(fields, s)
+			directions[ascending] = struct{}{}
+		case map[string]interface{}:
+			if len(s) != 1 {
+				return nil, false, errors.New("invalid query, each sort field must be a string or a single field object")
+			}
+			for field, dir := range s {
+				dirStr, ok := dir.(string)
+				if !ok || (dirStr != ascending && dirStr != descending) {
+					return nil, false, errors.Errorf("invalid query, the sort direction of field [%s] must be \"asc\" or \"desc\"", field)
+				}
+				fields = append(fields, field)
+				directions[dirStr] = struct{}{}
+			}
+		default:
+			return nil, false, errors.New("invalid query, each sort field must be a string or a single field object")
+		}
+	}
+	if len(directions) > 1 {
+		return nil, false, errors.New("invalid query, all the sort fields must have the same direction")
+	}
+	_, desc := directions[descending]
+	return fields, desc, nil
+}
+
+func parseNonNegativeInt(field string, value interface{}) (int32, error) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != float64(int32(n)) {
+		return 0, errors.Errorf("invalid query, %s must be a non-negative integer", field)
+	}
+	return int32(n), nil
+}
+
+func parseStringArray(field string, value interface{}) ([]string, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid query, %s must be an array of strings", field)
+	}
+	strs := []string{}
+	for _, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			return nil, errors.Errorf("invalid query, %s must be an array of strings", field)
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+// selector is a parsed condition of a query
+type selector interface {
+	matches(doc map[string]interface{}, key string) bool
+}
+
+type andSelector []selector
+
+func (s andSelector) matches(doc map[string]interface{}, key string) bool {
+	for _, sub := range s {
+		if !sub.matches(doc, key) {
+			return false
+		}
+	}
+	return true
+}
+
+type orSelector []selector
+
+func (s orSelector) matches(doc map[string]interface{}, key string) bool {
+	for _, sub := range s {
+		if sub.matches(doc, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldCondition compares the value of a field with the operand
+type fieldCondition struct {
+	path     []string
+	operator string
+	operand  interface{}
+}
+
+func (c *fieldCondition) matches(doc map[string]interface{}, key string) bool {
+	value, ok := lookupField(doc, key, c.path)
+	if !ok {
+		return false
+	}
+	if c.operator == "$eq" {
+		return reflect.DeepEqual(value, c.operand)
+	}
+	cmp, ok := compareSameType(value, c.operand)
+	if !ok {
+		return false
+	}
+	switch c.operator {
+	case "$gt":
+		return cmp > 0
+	case "$gte":
+		return cmp >= 0
+	case "$lt":
+		return cmp < 0
+	default: // $lte
+		return cmp <= 0
+	}
+}
+
+// compareSameType compares two numbers or two strings. False is returned for the other values
+func compareSameType(a, b interface{}) (int, bool) {
+	switch aVal := a.(type) {
+	case float64:
+		bVal, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case aVal < bVal:
+			return -1, true
+		case aVal > bVal:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		bVal, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(aVal, bVal), true
+	}
+	return 0, false
+}
+
+// parseSelector parses the conditions in the given JSON object, which applies to the field at the given path
+func parseSelector(jsonSelector map[string]interface{}, path []string) (selector, error) {
+	conditions := andSelector{}
+	// the keys are sorted so that the parsed selector is deterministic
+	keys := make([]string, 0, len(jsonSelector))
+	for k := range jsonSelector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := jsonSelector[k]
+		switch k {
+		case "$and", "$or":
+			subSelectors, err := parseSubSelectors(k, value, path)
+			if err != nil {
+				return nil, err
+			}
+			if k == "$and" {
+				conditions = append(conditions, andSelector(subSelectors))
+			} else {
+				conditions = append(conditions, orSelector(subSelectors))
+			}
+		case "$eq", "$gt", "$gte", "$lt", "$lte":
+			if len(path) == 0 {
+				return nil, errors.Errorf("invalid query, the operator [%s] must be applied to a field", k)
+			}
+			if k != "$eq" {
+				if _, ok := compareSameType(value, value); !ok {
+					return nil, errors.Errorf("invalid query, the operand of [%s] must be a number or a string", k)
+				}
+			}
+			conditions = append(conditions, &fieldCondition{path: path, operator: k, operand: value})
+		default:
+			if strings.HasPrefix(k, "$") {
+				return nil, errors.Errorf("invalid query, the operator [%s] is not supported", k)
+			}
+			fieldPath := append(append([]string{}, path...), splitFieldPath(k)...)
+			// a non-empty object contains either the operators on the field or the conditions on the
+			// nested fields, for instance, {"owner":{"name":"tom"}}
+			if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+				sub, err := parseSelector(m, fieldPath)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, sub)
+				continue
+			}
+			conditions = append(conditions, &fieldCondition{path: fieldPath, operator: "$eq", operand: value})
+		}
+	}
+	return conditions, nil
+}
+
+func parseSubSelectors(operator string, value interface{}, path []string) ([]selector, error) {
+	arr, ok := value.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil, errors.Errorf("invalid query, the operator [%s] requires a non-empty array", operator)
+	}
+	subSelectors := []selector{}
+	for _, e := range arr {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid query, the operator [%s] requires an array of JSON objects", operator)
+		}
+		sub, err := parseSelector(m, path)
+		if err != nil {
+			return nil, err
+		}
+		subSelectors = append(subSelectors, sub)
+	}
+	return subSelectors, nil
+}
+
+// fieldConstraint is the combination of the conditions on a field that must hold for every matching value.
+// These are derived from the conditions that are not under an $or operator and are used for selecting an index
+// and the range of the index entries to iterate over
+type fieldConstraint struct {
+	eq                   interface{}
+	hasEq                bool
+	lower, upper         interface{}
+	lowerExcl, upperExcl bool
+}
+
+func collectConstraints(s selector, constraints map[string]*fieldConstraint) {
+	switch sel := s.(type) {
+	case andSelector:
+		for _, sub := range sel {
+			collectConstraints(sub, constraints)
+		}
+	case *fieldCondition:
+		field := strings.Join(sel.path, ".")
+		c, ok := constraints[field]
+		if !ok {
+			c = &fieldConstraint{}
+			constraints[field] = c
+		}
+		switch sel.operator {
+		case "$eq":
+			if _, ok := encodeIndexValue(sel.operand); ok {
+				c.eq, c.hasEq = sel.operand, true
+			}
+		case "$gt", "$gte":
+			if c.lower == nil {
+				c.lower, c.lowerExcl = sel.operand, sel.operator == "$gt"
+			}
+		case "$lt", "$lte":
+			if c.upper == nil {
+				c.upper, c.upperExcl = sel.operand, sel.operator == "$lt"
+			}
+		}
+	}
+}
+
+// queryPlan is the range of the entries of an index to iterate over for a query
+type queryPlan struct {
+	index      *indexDefinition
+	startKey   []byte
+	endKey     []byte
+	descending bool
+}
+
+// planQuery selects the index that serves the query. If more than one index serves the query, the index
+// with the most constrained leading fields is preferred, followed by the index with the fewest fields
+func planQuery(namespace string, q *query, defs []*indexDefinition) (*queryPlan, error) {
+	constraints := map[string]*fieldConstraint{}
+	collectConstraints(q.selector, constraints)
+
+	var best *queryPlan
+	bestScore := -1
+	for _, def := range defs {
+		if !indexRequested(q.useIndex, def) {
+			continue
+		}
+		plan, score := planForIndex(namespace, q, def, constraints)
+		if plan == nil {
+			continue
+		}
+		if score > bestScore || (score == bestScore && len(def.Fields) < len(best.index.Fields)) {
+			best, bestScore = plan, score
+		}
+	}
+	if best == nil {
+		if len(q.useIndex) > 0 {
+			return nil, errors.Errorf("the index %s cannot serve the query on namespace [%s]", q.useIndex, namespace)
+		}
+		return nil, errors.Errorf("no index on namespace [%s] can serve the query, "+
+			"the query must constrain or sort on the leading fields of an index", namespace)
+	}
+	return best, nil
+}
+
+func indexRequested(useIndex []string, def *indexDefinition) bool {
+	if len(useIndex) == 0 {
+		return true
+	}
+	ddoc := strings.TrimPrefix(useIndex[0], "_design/")
+	if strings.TrimPrefix(def.DDoc, "_design/") != ddoc {
+		return false
+	}
+	return len(useIndex) == 1 || def.Name == useIndex[1]
+}
+
+// planForIndex returns the plan for serving the query with the given index and a score for the plan.
+// A nil plan is returned if the index cannot serve the query
+func planForIndex(namespace string, q *query, def *indexDefinition, constraints map[string]*fieldConstraint) (*queryPlan, int) {
+	prefix := indexEntryKeyPrefixForIndex(namespace, def.Name)
+	numEqFields := 0
+	for _, field := range def.Fields {
+		c, ok := constraints[field]
+		if !ok || !c.hasEq {
+			break
+		}
+		encodedValue, _ := encodeIndexValue(c.eq)
+		prefix = append(prefix, encodedValue...)
+		numEqFields++
+	}
+	var rangeConstraint *fieldConstraint
+	if numEqFields < len(def.Fields) {
+		if c, ok := constraints[def.Fields[numEqFields]]; ok && (c.lower != nil || c.upper != nil) {
+			rangeConstraint = c
+		}
+	}
+	sortSupported := sortSupportedByIndex(q.sortFields, def.Fields, numEqFields)
+	if !sortSupported {
+		return nil, 0
+	}
+	if numEqFields == 0 && rangeConstraint == nil && len(q.sortFields) == 0 {
+		return nil, 0
+	}
+
+	plan := &queryPlan{index: def, descending: q.descending}
+	score := 2 * numEqFields
+	if rangeConstraint == nil {
+		plan.startKey, plan.endKey = prefix, rangeEnd(prefix)
+		return plan, score
+	}
+	score++
+	plan.startKey, plan.endKey = rangeForConstraint(prefix, rangeConstraint)
+	return plan, score
+}
+
+// sortSupportedByIndex returns true if the order of the index entries, after fixing the values of the leading
+// fields with equality constraints, is the requested sort order
+func sortSupportedByIndex(sortFields, indexFields []string, numEqFields int) bool {
+	if len(sortFields) == 0 {
+		return true
+	}
+	for i := 0; i <= numEqFields && i+len(sortFields) <= len(indexFields); i++ {
+		if reflect.DeepEqual(sortFields, indexFields[i:i+len(sortFields)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeForConstraint returns the range of the index entries, under the given prefix, that satisfy the range
+// constraint. As the range operators match the values of the same type as the operand, the range is limited
+// to the encodings of the type of the operand
+func rangeForConstraint(prefix []byte, c *fieldConstraint) ([]byte, []byte) {
+	var startKey, endKey []byte
+	if c.lower != nil {
+		encodedValue, _ := encodeIndexValue(c.lower)
+		startKey = append(append([]byte{}, prefix...), encodedValue...)
+		if c.lowerExcl {
+			startKey = rangeEnd(startKey)
+		}
+	}
+	if c.upper != nil {
+		encodedValue, _ := encodeIndexValue(c.upper)
+		endKey = append(append([]byte{}, prefix...), encodedValue...)
+		if !c.upperExcl {
+			endKey = rangeEnd(endKey)
+		}
+	}
+	if startKey == nil {
+		encodedValue, _ := encodeIndexValue(c.upper)
+		startKey = append(append([]byte{}, prefix...), encodedValue[0])
+	}
+	if endKey == nil {
+		encodedValue, _ := encodeIndexValue(c.lower)
+		endKey = append(append([]byte{}, prefix...), encodedValue[0]+1)
+	}
+	return startKey, endKey
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, query, metadata)
+	requestedLimit := int32(0)
+	bookmark := ""
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	vdb.indexLock.Lock()
+	defs, err := vdb.getIndexDefinitions(namespace)
+	vdb.indexLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planQuery(namespace, q, defs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("Query on namespace [%s] is served by index [%s]", namespace, plan.index.Name)
+
+	startKey, endKey := plan.startKey, plan.endKey
+	skip := q.skip
+	if bookmark != "" {
+		bookmarkKey, err := base64.StdEncoding.DecodeString(bookmark)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid bookmark")
+		}
+		// the bookmark is the index entry of the next result
+		if plan.descending {
+			if bookmarkEnd := append(bookmarkKey, 0x00); bytes.Compare(bookmarkEnd, endKey) < 0 {
+				endKey = bookmarkEnd
+			}
+		} else if bytes.Compare(bookmarkKey, startKey) > 0 {
+			startKey = bookmarkKey
+		}
+		skip = 0
+	}
+	limit := q.limit
+	if requestedLimit > 0 && (limit == 0 || requestedLimit < limit) {
+		limit = requestedLimit
+	}
+	scanner := &queryScanner{
+		vdb:        vdb,
+		namespace:  namespace,
+		query:      q,
+		descending: plan.descending,
+		limit:      limit,
+		skip:       skip,
+	}
+	if bytes.Compare(startKey, endKey) < 0 {
+		scanner.dbItr = vdb.indexDB.GetIterator(startKey, endKey)
+	}
+	return scanner, nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			//Verify the bookmark is a string
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			//Verify the limit is an integer
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return errors.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
+
+// queryScanner iterates over the index entries in the range of the query plan and returns the values that match
+// the selector. As the index entries are maintained along with the state, the selector is evaluated on the current
+// value of each key in the range
+type queryScanner struct {
+	vdb        *versionedDB
+	namespace  string
+	query      *query
+	dbItr      *leveldbhelper.Iterator
+	started    bool
+	descending bool
+	limit      int32
+	skip       int32
+
+	totalRecordsReturned int32
+}
+
+// nextMatch returns the next index entry whose value matches the selector, along with the value
+func (scanner *queryScanner) nextMatch() ([]byte, *statedb.VersionedKV, error) {
+	if scanner.dbItr == nil {
+		return nil, nil, nil
+	}
+	for scanner.advance() {
+		key := string(scanner.dbItr.Value())
+		vv, err := scanner.vdb.GetState(scanner.namespace, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if vv == nil {
+			continue
+		}
+		doc := map[string]interface{}{}
+		if err := json.Unmarshal(vv.Value, &doc); err != nil || !scanner.query.selector.matches(doc, key) {
+			continue
+		}
+		if len(scanner.query.fields) > 0 {
+			if vv.Value, err = projectFields(doc, key, scanner.query.fields); err != nil {
+				return nil, nil, err
+			}
+		}
+		entryKey := append([]byte(nil), scanner.dbItr.Key()...)
+		return entryKey, &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	if err := scanner.dbItr.Error(); err != nil {
+		return nil, nil, errors.Wrap(err, "internal leveldb error while iterating over the index")
+	}
+	return nil, nil, nil
+}
+
+func (scanner *queryScanner) advance() bool {
+	if !scanner.descending {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
+
+// Next implements method in ResultsIterator interface
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.limit > 0 && scanner.totalRecordsReturned >= scanner.limit {
+		return nil, nil
+	}
+	for {
+		_, kv, err := scanner.nextMatch()
+		if err != nil || kv == nil {
+			return nil, err
+		}
+		if scanner.skip > 0 {
+			scanner.skip--
+			continue
+		}
+		scanner.totalRecordsReturned++
+		return kv, nil
+	}
+}
+
+// Close implements method in ResultsIterator interface
+func (scanner *queryScanner) Close() {
+	if scanner.dbItr != nil {
+		scanner.dbItr.Release()
+		scanner.dbItr = nil
+	}
+}
+
+// GetBookmarkAndClose implements method in QueryResultsIterator interface. The bookmark is the index
+// entry of the next matching value, if any
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	retval := ""
+	entryKey, kv, err := scanner.nextMatch()
+	if err != nil {
+		logger.Warningf("Error while computing the bookmark of the query on namespace [%s]: %s", scanner.namespace, err)
+	}
+	if kv != nil {
+		retval = base64.StdEncoding.EncodeToString(entryKey)
+	}
+	scanner.Close()
+	return retval
+}
+
+// projectFields returns the JSON object with only the given fields of the doc
+func projectFields(doc map[string]interface{}, key string, fields []string) ([]byte, error) {
+	projected := map[string]interface{}{}
+	for _, field := range fields {
+		path := splitFieldPath(field)
+		value, ok := lookupField(doc, key, path)
+		if !ok {
+			continue
+		}
+		m := projected
+		for _, p := range path[:len(path)-1] {
+			sub, ok := m[p].(map[string]interface{})
+			if !ok {
+				sub = map[string]interface{}{}
+				m[p] = sub
+			}
+			m = sub
+		}
+		m[path[len(path)-1]] = value
+	}
+	projectedBytes, err := json.Marshal(projected)
+	return projectedBytes, errors.Wrap(err, "error while marshalling the projected fields")
+}
@@ -0,0 +1,278 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupQueryTestDB(t *testing.T, env *TestVDBEnv) statedb.VersionedDB {
+	db, err := env.DBProvider.GetDBHandle("testrichquery")
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	owners := []string{"tom", "jerry", "spike"}
+	for i := 1; i <= 9; i++ {
+		value := fmt.Sprintf(`{"docType":"marble","color":"%s","size":%d,"owner":{"name":"%s"}}`,
+			[]string{"blue", "red", "green"}[i%3], i, owners[i%3])
+		batch.Put("ns", fmt.Sprintf("key%d", i), []byte(value), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns", "key10", []byte(`{"docType":"marble","color":"blue","size":"large","owner":{"name":"tom"}}`), version.NewHeight(1, 10))
+	batch.Put("ns", "key11", []byte(`{"docType":"car","color":"blue","size":100}`), version.NewHeight(1, 11))
+	batch.Put("ns", "key12", []byte(`not json`), version.NewHeight(1, 12))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 12)))
+
+	createTestIndex(t, db, "ns", `{"index":{"fields":["docType","size"]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`)
+	createTestIndex(t, db, "ns", `{"index":{"fields":["owner.name"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`)
+	createTestIndex(t, db, "ns", `{"index":{"fields":["color"]},"ddoc":"indexColorDoc","name":"indexColor","type":"json"}`)
+	return db
+}
+
+func TestExecuteQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db := setupQueryTestDB(t, env)
+
+	testCases := []struct {
+		name         string
+		query        string
+		expectedKeys []string
+	}{
+		{
+			name:         "equality",
+			query:        `{"selector":{"owner.name":"jerry"}}`,
+			expectedKeys: []string{"key1", "key4", "key7"},
+		},
+		{
+			name:         "nested-fields",
+			query:        `{"selector":{"owner":{"name":{"$eq":"jerry"}}}}`,
+			expectedKeys: []string{"key1", "key4", "key7"},
+		},
+		{
+			name:         "equality-and-range",
+			query:        `{"selector":{"docType":"marble","size":{"$gt":2,"$lte":5}}}`,
+			expectedKeys: []string{"key3", "key4", "key5"},
+		},
+		{
+			name:         "range-matches-same-type-only",
+			query:        `{"selector":{"docType":"marble","size":{"$gte":8}}}`,
+			expectedKeys: []string{"key8", "key9"},
+		},
+		{
+			name:         "string-range",
+			query:        `{"selector":{"docType":"marble","size":{"$lt":"m"}}}`,
+			expectedKeys: []string{"key10"},
+		},
+		{
+			name:         "and-with-additional-conditions",
+			query:        `{"selector":{"$and":[{"docType":"marble"},{"size":{"$lt":7}},{"color":"blue"}]}}`,
+			expectedKeys: []string{"key3", "key6"},
+		},
+		{
+			name:         "or-evaluated-on-the-values",
+			query:        `{"selector":{"docType":"marble","$or":[{"color":"red"},{"size":9}]}}`,
+			expectedKeys: []string{"key1", "key4", "key7", "key9"},
+		},
+		{
+			name:         "sort-descending",
+			query:        `{"selector":{"docType":"marble","size":{"$gt":5}},"sort":[{"size":"desc"}]}`,
+			expectedKeys: []string{"key9", "key8", "key7", "key6"},
+		},
+		{
+			name:         "sort-on-the-leading-fields",
+			query:        `{"selector":{"color":"blue"},"sort":["docType","size"]}`,
+			expectedKeys: []string{"key11", "key3", "key6", "key9", "key10"},
+		},
+		{
+			name:         "limit-and-skip",
+			query:        `{"selector":{"docType":"marble"},"sort":["size"],"skip":2,"limit":3}`,
+			expectedKeys: []string{"key3", "key4", "key5"},
+		},
+		{
+			name:         "use-index",
+			query:        `{"selector":{"docType":"marble","color":"green"},"use_index":["_design/indexColorDoc","indexColor"]}`,
+			expectedKeys: []string{"key2", "key5", "key8"},
+		},
+		{
+			name:         "key",
+			query:        `{"selector":{"owner.name":"tom","_id":{"$gt":"key5"}}}`,
+			expectedKeys: []string{"key6", "key9"},
+		},
+		{
+			name:         "no-match",
+			query:        `{"selector":{"owner.name":"tyke"}}`,
+			expectedKeys: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			itr, err := db.ExecuteQuery("ns", tc.query)
+			require.NoError(t, err)
+			defer itr.Close()
+			assert.Equal(t, tc.expectedKeys, queryResultKeys(t, itr))
+		})
+	}
+}
+
+func TestExecuteQueryFields(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db := setupQueryTestDB(t, env)
+
+	itr, err := db.ExecuteQuery("ns", `{"selector":{"owner.name":"jerry"},"fields":["_id","owner.name","size"],"limit":1}`)
+	require.NoError(t, err)
+	defer itr.Close()
+	queryResult, err := itr.Next()
+	require.NoError(t, err)
+	kv := queryResult.(*statedb.VersionedKV)
+	assert.Equal(t, "key1", kv.Key)
+	assert.Equal(t, version.NewHeight(1, 1), kv.Version)
+	assert.JSONEq(t, `{"_id":"key1","owner":{"name":"jerry"},"size":1}`, string(kv.Value))
+	queryResult, err = itr.Next()
+	require.NoError(t, err)
+	assert.Nil(t, queryResult)
+}
+
+func TestExecuteQueryWithMetadataPagination(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db := setupQueryTestDB(t, env)
+
+	testCases := []struct {
+		query         string
+		expectedPages [][]string
+	}{
+		{
+			query:         `{"selector":{"docType":"marble","size":{"$gte":1}}}`,
+			expectedPages: [][]string{{"key1", "key2", "key3", "key4"}, {"key5", "key6", "key7", "key8"}, {"key9"}},
+		},
+		{
+			query:         `{"selector":{"docType":"marble","size":{"$gte":1}},"sort":[{"size":"desc"}]}`,
+			expectedPages: [][]string{{"key9", "key8", "key7", "key6"}, {"key5", "key4", "key3", "key2"}, {"key1"}},
+		},
+	}
+	for _, tc := range testCases {
+		pages := [][]string{}
+		bookmark := ""
+		for {
+			itr, err := db.ExecuteQueryWithMetadata("ns", tc.query, map[string]interface{}{"limit": int32(4), "bookmark": bookmark})
+			require.NoError(t, err)
+			pages = append(pages, queryResultKeys(t, itr))
+			bookmark = itr.GetBookmarkAndClose()
+			if bookmark == "" {
+				break
+			}
+		}
+		assert.Equal(t, tc.expectedPages, pages, tc.query)
+	}
+
+	// an update between the pages is reflected in the next page
+	itr, err := db.ExecuteQueryWithMetadata("ns", `{"selector":{"owner.name":"tom"}}`, map[string]interface{}{"limit": int32(2)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key10", "key3"}, queryResultKeys(t, itr))
+	bookmark := itr.GetBookmarkAndClose()
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key6", []byte(`{"owner":{"name":"jerry"}}`), version.NewHeight(2, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	itr, err = db.ExecuteQueryWithMetadata("ns", `{"selector":{"owner.name":"tom"}}`, map[string]interface{}{"limit": int32(2), "bookmark": bookmark})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key9"}, queryResultKeys(t, itr))
+	assert.Equal(t, "", itr.GetBookmarkAndClose())
+}
+
+func TestExecuteQueryErrors(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db := setupQueryTestDB(t, env)
+
+	testCases := []struct {
+		query       string
+		metadata    map[string]interface{}
+		expectedErr string
+	}{
+		{
+			query:       `{"selector":{"size":3}}`,
+			expectedErr: "no index on namespace [ns] can serve the query, the query must constrain or sort on the leading fields of an index",
+		},
+		{
+			query:       `{"selector":{"$or":[{"owner.name":"tom"},{"color":"red"}]}}`,
+			expectedErr: "no index on namespace [ns] can serve the query, the query must constrain or sort on the leading fields of an index",
+		},
+		{
+			query:       `{"selector":{"size":{"$gt":1}},"sort":["size"]}`,
+			expectedErr: "no index on namespace [ns] can serve the query, the query must constrain or sort on the leading fields of an index",
+		},
+		{
+			query:       `{"selector":{"owner.name":"tom"},"use_index":"indexColorDoc"}`,
+			expectedErr: "the index [indexColorDoc] cannot serve the query on namespace [ns]",
+		},
+		{
+			query:       `{"selector":{"color":"blue"},"sort":[{"docType":"asc"},{"size":"desc"}]}`,
+			expectedErr: "invalid query, all the sort fields must have the same direction",
+		},
+		{
+			query:       `{"selector":{"color":{"$regex":"bl.*"}}}`,
+			expectedErr: "invalid query, the operator [$regex] is not supported",
+		},
+		{
+			query:       `{"selector":{"color":{"$gt":["blue"]}}}`,
+			expectedErr: "invalid query, the operand of [$gt] must be a number or a string",
+		},
+		{
+			query:       `{"selector":{"color":"blue"},"execution_stats":true}`,
+			expectedErr: "invalid query, the field [execution_stats] is not supported",
+		},
+		{
+			query:       `{"sort":["color"]}`,
+			expectedErr: "invalid query, the query must contain a selector",
+		},
+		{
+			query:       `{"selector":{"color":"blue"},"limit":-1}`,
+			expectedErr: "invalid query, limit must be a non-negative integer",
+		},
+		{
+			query:       `{"selector":{"color":"blue"}}`,
+			metadata:    map[string]interface{}{"limit": 10},
+			expectedErr: "Invalid entry, \"limit\" must be an int32",
+		},
+		{
+			query:       `{"selector":{"color":"blue"}}`,
+			metadata:    map[string]interface{}{"bookmark": 10},
+			expectedErr: "Invalid entry, \"bookmark\" must be a string",
+		},
+		{
+			query:       `{"selector":{"color":"blue"}}`,
+			metadata:    map[string]interface{}{"skip": int32(10)},
+			expectedErr: "Invalid entry, option skip not recognized",
+		},
+	}
+	for _, tc := range testCases {
+		itr, err := db.ExecuteQueryWithMetadata("ns", tc.query, tc.metadata)
+		assert.EqualError(t, err, tc.expectedErr, tc.query)
+		assert.Nil(t, itr)
+	}
+
+	_, err := db.ExecuteQuery("ns", `not json`)
+	assert.Contains(t, err.Error(), "invalid query, the query must be a JSON object")
+}
+
+func queryResultKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	keys := []string{}
+	for {
+		queryResult, err := itr.Next()
+		require.NoError(t, err)
+		if queryResult == nil {
+			return keys
+		}
+		keys = append(keys, queryResult.(*statedb.VersionedKV).Key)
+	}
+}
@@ -7,6 +7,7 @@ package stateleveldb
 
 import (
 	"bytes"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -25,18 +26,47 @@ var savePointKey = []byte{0x00}
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	mux        sync.Mutex
+	vdbs       map[string]*versionedDB
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
 func NewVersionedDBProvider(dbPath string) *VersionedDBProvider {
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{
+		dbProvider: dbProvider,
+		vdbs:       make(map[string]*versionedDB),
+	}
 }
 
-// GetDBHandle gets the handle to a named database
+// GetDBHandle gets the handle to a named database. The same handle is returned for a given name
+// so that the cached index definitions are shared by all the users of the database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	vdb, ok := provider.vdbs[dbName]
+	if !ok {
+		vdb = newVersionedDB(
+			provider.dbProvider.GetDBHandle(dbName),
+			provider.dbProvider.GetDBHandle(dbName+indexDBNameSuffix),
+			dbName,
+		)
+		provider.vdbs[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Drop drops all the data of the named database, including the indexes. The database should not be in use
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	delete(provider.vdbs, dbName)
+	if err := provider.dbProvider.GetDBHandle(dbName + indexDBNameSuffix).DeleteAll(); err != nil {
+		return errors.WithMessagef(err, "error while dropping the indexes of [%s]", dbName)
+	}
+	return errors.WithMessagef(provider.dbProvider.GetDBHandle(dbName).DeleteAll(),
+		"error while dropping the state of [%s]", dbName)
 }
 
 // Close closes the underlying db
@@ -48,11 +78,23 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+
+	// indexDB contains the secondary indexes that serve the rich queries (see index.go)
+	indexDB *leveldbhelper.DBHandle
+	// indexLock serializes the maintenance of the indexes on commit with the creation of the indexes
+	// on chaincode deploy and guards the indexDefinitions cache
+	indexLock        sync.Mutex
+	indexDefinitions map[string][]*indexDefinition
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db, indexDB *leveldbhelper.DBHandle, dbName string) *versionedDB {
+	return &versionedDB{
+		db:               db,
+		dbName:           dbName,
+		indexDB:          indexDB,
+		indexDefinitions: make(map[string][]*indexDefinition),
+	}
 }
 
 // Open implements method in VersionedDB interface
@@ -152,18 +194,22 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
-// ExecuteQuery implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
-}
-
-// ExecuteQueryWithMetadata implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
-}
-
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	// The index updates are written before the state updates. If the peer crashes in between, the
+	// block is recommitted on restart and the index updates are recomputed, which leaves at worst a
+	// few stale index entries that are filtered out by the queries on reading the values
+	indexBatch, err := vdb.prepareIndexUpdates(batch)
+	if err != nil {
+		return err
+	}
+	if indexBatch.Len() > 0 {
+		if err := vdb.indexDB.WriteBatch(indexBatch, true); err != nil {
+			return err
+		}
+	}
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
@@ -210,6 +256,14 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, error) {
+	return &fullDBScanner{
+		dbItr:         vdb.db.GetIterator(nil, nil),
+		skipNamespace: skipNamespace,
+	}, nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -273,3 +327,37 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (*statedb.VersionedKV, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if bytes.Equal(dbKey, savePointKey) {
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skipNamespace(ns) {
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while iterating over the state db")
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
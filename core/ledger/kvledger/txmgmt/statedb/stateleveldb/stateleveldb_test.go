@@ -49,6 +49,12 @@ func TestIterator(t *testing.T) {
 	commontests.TestIterator(t, env.DBProvider)
 }
 
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestFullScanIterator(t, env.DBProvider)
+}
+
 func TestCompositeKey(t *testing.T) {
 	testCompositeKey(t, "ledger1", "ns", "key")
 	testCompositeKey(t, "ledger2", "ns", "")
@@ -78,11 +84,24 @@ func TestQueryOnLevelDB(t *testing.T) {
 	db.ApplyUpdates(batch, savePoint)
 
 	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
+	// As there is no index on the namespace, call to ExecuteQuery()
 	// should return a error message
 	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
+	assert.EqualError(t, err, "no index on namespace [ns1] can serve the query, "+
+		"the query must constrain or sort on the leading fields of an index")
 	assert.Nil(t, itr)
+
+	// after an index is created on the field "owner", the query is served by the index
+	createTestIndex(t, db, "ns1", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`)
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"tom"}}`)
+	assert.NoError(t, err)
+	defer itr.Close()
+	queryResult, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", queryResult.(*statedb.VersionedKV).Key)
+	queryResult, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, queryResult)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -129,3 +148,32 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestDrop(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+
+	for _, dbName := range []string{"testdrop1", "testdrop2"} {
+		db, err := env.DBProvider.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns", "key", []byte("value"), version.NewHeight(1, 1))
+		assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+	}
+	assert.NoError(t, env.DBProvider.Drop("testdrop1"))
+
+	db1, err := env.DBProvider.GetDBHandle("testdrop1")
+	assert.NoError(t, err)
+	vv, err := db1.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	savepoint, err := db1.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+
+	db2, err := env.DBProvider.GetDBHandle("testdrop2")
+	assert.NoError(t, err)
+	vv, err = db2.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), vv.Value)
+}
@@ -50,6 +50,7 @@ type testEnv interface {
 const (
 	levelDBtestEnvName = "levelDB_LockBasedTxMgr"
 	couchDBtestEnvName = "couchDB_LockBasedTxMgr"
+	boltDBtestEnvName  = "boltDB_LockBasedTxMgr"
 )
 
 // Tests will be run against each environment in this array
@@ -57,11 +58,13 @@ const (
 var testEnvs = []testEnv{
 	&lockBasedEnv{name: levelDBtestEnvName, testDBEnv: &privacyenabledstate.LevelDBCommonStorageTestEnv{}},
 	&lockBasedEnv{name: couchDBtestEnvName, testDBEnv: &privacyenabledstate.CouchDBCommonStorageTestEnv{}},
+	&lockBasedEnv{name: boltDBtestEnvName, testDBEnv: &privacyenabledstate.BoltDBCommonStorageTestEnv{}},
 }
 
 var testEnvsMap = map[string]testEnv{
 	levelDBtestEnvName: testEnvs[0],
 	couchDBtestEnvName: testEnvs[1],
+	boltDBtestEnvName:  testEnvs[2],
 }
 
 ///////////// LevelDB Environment //////////////
@@ -333,6 +333,19 @@ type TxSimulator struct {
 	deletePrivateDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PurgePrivateDataStub        func(namespace, collection, key string) error
+	purgePrivateDataMutex       sync.RWMutex
+	purgePrivateDataArgsForCall []struct {
+		namespace  string
+		collection string
+		key        string
+	}
+	purgePrivateDataReturns struct {
+		result1 error
+	}
+	purgePrivateDataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivateDataMetadataStub        func(namespace, collection, key string, metadata map[string][]byte) error
 	setPrivateDataMetadataMutex       sync.RWMutex
 	setPrivateDataMetadataArgsForCall []struct {
@@ -1602,6 +1615,56 @@ func (fake *TxSimulator) DeletePrivateDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *TxSimulator) PurgePrivateData(namespace string, collection string, key string) error {
+	fake.purgePrivateDataMutex.Lock()
+	ret, specificReturn := fake.purgePrivateDataReturnsOnCall[len(fake.purgePrivateDataArgsForCall)]
+	fake.purgePrivateDataArgsForCall = append(fake.purgePrivateDataArgsForCall, struct {
+		namespace  string
+		collection string
+		key        string
+	}{namespace, collection, key})
+	fake.recordInvocation("PurgePrivateData", []interface{}{namespace, collection, key})
+	fake.purgePrivateDataMutex.Unlock()
+	if fake.PurgePrivateDataStub != nil {
+		return fake.PurgePrivateDataStub(namespace, collection, key)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.purgePrivateDataReturns.result1
+}
+
+func (fake *TxSimulator) PurgePrivateDataCallCount() int {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return len(fake.purgePrivateDataArgsForCall)
+}
+
+func (fake *TxSimulator) PurgePrivateDataArgsForCall(i int) (string, string, string) {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return fake.purgePrivateDataArgsForCall[i].namespace, fake.purgePrivateDataArgsForCall[i].collection, fake.purgePrivateDataArgsForCall[i].key
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturns(result1 error) {
+	fake.PurgePrivateDataStub = nil
+	fake.purgePrivateDataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturnsOnCall(i int, result1 error) {
+	fake.PurgePrivateDataStub = nil
+	if fake.purgePrivateDataReturnsOnCall == nil {
+		fake.purgePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgePrivateDataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TxSimulator) SetPrivateDataMetadata(namespace string, collection string, key string, metadata map[string][]byte) error {
 	fake.setPrivateDataMetadataMutex.Lock()
 	ret, specificReturn := fake.setPrivateDataMetadataReturnsOnCall[len(fake.setPrivateDataMetadataArgsForCall)]
@@ -1797,6 +1860,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.setPrivateDataMultipleKeysMutex.RUnlock()
 	fake.deletePrivateDataMutex.RLock()
 	defer fake.deletePrivateDataMutex.RUnlock()
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
 	fake.setPrivateDataMetadataMutex.RLock()
 	defer fake.setPrivateDataMetadataMutex.RUnlock()
 	fake.deletePrivateDataMetadataMutex.RLock()
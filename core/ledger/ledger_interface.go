@@ -8,6 +8,7 @@ package ledger
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-lib-go/healthz"
@@ -41,12 +42,18 @@ type Config struct {
 	PrivateDataConfig *PrivateDataConfig
 	// HistoryDBConfig holds the configuration parameters for the transaction history database.
 	HistoryDBConfig *HistoryDBConfig
+	// SnapshotsConfig holds the configuration parameters for the snapshots.
+	SnapshotsConfig *SnapshotsConfig
+	// BlockArchiveConfig holds the configuration parameters for archiving the block files.
+	BlockArchiveConfig *BlockArchiveConfig
+	// BlockIndexConfig holds the configuration parameters for the optional indexes of the block store.
+	BlockIndexConfig *BlockIndexConfig
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
 type StateDBConfig struct {
 	// StateDatabase is the database to use for storing last known state.  The
-	// two supported options are "goleveldb" and "CouchDB".
+	// three supported options are "goleveldb", "boltdb", and "CouchDB".
 	StateDatabase string
 	// CouchDB is the configuration for CouchDB.  It is used when StateDatabase
 	// is set to "CouchDB".
@@ -69,6 +76,42 @@ type PrivateDataConfig struct {
 // HistoryDBConfig is a structure used to configure the transaction history database.
 type HistoryDBConfig struct {
 	Enabled bool
+	// PvtDataHashHistoryEnabled indicates whether the history of the hashed private data
+	// writes is also maintained, so that it can be queried by the hash of a private data key.
+	PvtDataHashHistoryEnabled bool
+}
+
+// BlockArchiveConfig is a structure used to configure the archiving of the block files.
+// A block file is archived once all the blocks in the file fall outside both the
+// retention criteria, i.e., RetainBlocks and RetainPeriod.
+type BlockArchiveConfig struct {
+	Enabled bool
+	// RootDir is the directory where the archived block files are stored.
+	RootDir string
+	// Format is the format in which the block files are archived. The
+	// two supported options are "dir" (plain copy) and "tar" (compressed tar).
+	Format string
+	// RetainBlocks is the number of latest blocks that are not archived.
+	RetainBlocks uint64
+	// RetainPeriod is the duration since their persistence for which the blocks are not archived.
+	RetainPeriod time.Duration
+}
+
+// BlockIndexConfig is a structure used to configure the optional indexes of the block store.
+// An index that is enabled for a ledger that already has blocks is built for these blocks
+// when the ledger is opened.
+type BlockIndexConfig struct {
+	// ChaincodeNamespace indicates whether the transactions are indexed by the chaincode
+	// namespaces they touch.
+	ChaincodeNamespace bool
+	// Creator indicates whether the transactions are indexed by the identity that created them.
+	Creator bool
+}
+
+// SnapshotsConfig is a structure used to configure the generation of the snapshots.
+type SnapshotsConfig struct {
+	// RootDir is the top-level directory for the snapshots.
+	RootDir string
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -77,6 +120,10 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot and returns the ledger and the id of the ledger.
+	// This function guarantees that the creation of ledger and loading the data from the snapshot would be an atomic action
+	// The snapshot files are verified against the hashes recorded in the metadata of the snapshot before loading any data
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -99,6 +146,13 @@ type PeerLedger interface {
 	GetBlockByTxID(txID string) (*common.Block, error)
 	// GetTxValidationCodeByTxID returns reason code of transaction validation
 	GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// GetTransactionsByChaincodeNamespace returns, in the order of the chain, at most pageSize transactions that touched
+	// the given chaincode namespace, starting at the bookmark returned along with the previous page. An empty bookmark
+	// starts at the first transaction and an empty bookmark is returned along with the last page
+	GetTransactionsByChaincodeNamespace(namespace string, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error)
+	// GetTransactionsByCreator returns, in the order of the chain, at most pageSize transactions that were created by
+	// the given serialized identity. The bookmark is used as in GetTransactionsByChaincodeNamespace
+	GetTransactionsByCreator(creator []byte, bookmark string, pageSize int) ([]*peer.ProcessedTransaction, string, error)
 	// NewTxSimulator gives handle to a transaction simulator.
 	// A client can obtain more than one 'TxSimulator's for parallel execution.
 	// Any snapshoting/synchronization should be performed at the implementation level if required
@@ -130,6 +184,18 @@ type PeerLedger interface {
 	CommitPvtDataOfOldBlocks(blockPvtData []*BlockPvtData) ([]*PvtdataHashMismatch, error)
 	// GetMissingPvtDataTracker return the MissingPvtDataTracker
 	GetMissingPvtDataTracker() (MissingPvtDataTracker, error)
+	// GetPurgeRecords returns the records of the private data keys purged by the
+	// transactions of the blocks within the given range (both inclusive)
+	GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*PurgedPvtKey, error)
+	// SubmitSnapshotRequest submits a request for generating a snapshot of the ledger when the block with the given number
+	// is committed. A blockNumber of zero refers to the last committed block, in which case, the snapshot is generated
+	// immediately. Similarly, if the given block number is same as the last committed block, the snapshot is generated
+	// immediately. Submitting a request for a block number lower than the last committed block returns an error
+	SubmitSnapshotRequest(blockNumber uint64) error
+	// CancelSnapshotRequest cancels a previously submitted request for the given block number
+	CancelSnapshotRequest(blockNumber uint64) error
+	// PendingSnapshotRequests returns the block numbers of the pending snapshot requests, in increasing order
+	PendingSnapshotRequests() ([]uint64, error)
 }
 
 // SimpleQueryExecutor encapsulates basic functions
@@ -206,6 +272,17 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithMetadata retrieves the history of values for a key, as restricted and ordered by the given metadata.
+	// metadata is a map of additional query parameters. The supported parameters are "startBlock" and "endBlock" (uint64)
+	// that restrict the history to the given range of blocks (both inclusive), "startTime" and "endTime" (*timestamp.Timestamp)
+	// that restrict the history to the transactions with a timestamp in the given range (endTime exclusive), "newestFirst" (bool)
+	// that returns the most recent modification first, and "limit" (int32) and "bookmark" (string) for paginating the results.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetPrivateDataHashHistory retrieves the history of the hashed writes for a private data key, identified by the hash of the key.
+	// The history is available only if the private data hash history is enabled in the HistoryDBConfig.
+	// The returned ResultsIterator contains results of type *KeyHashModification which is defined in protos/ledger/queryresult.
+	GetPrivateDataHashHistory(namespace, collection string, keyHash []byte) (commonledger.ResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -230,6 +307,9 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData deletes the given tuple <namespace, collection, key> from private data and, when the
+	// transaction is committed, removes the earlier versions of the key from the private data stores
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -413,6 +493,26 @@ type ConfigHistoryRetriever interface {
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information of eligible
+	// collections for the blocks in the range [startBlk, endBlk]
+	GetMissingPvtDataInfoForBlockRange(startBlk, endBlk uint64) (MissingPvtDataInfo, error)
+	// GetMissingPvtDataStats returns, for each collection, statistics about both the eligible and
+	// the ineligible private data that is missing on the peer
+	GetMissingPvtDataStats() ([]*MissingCollectionPvtDataStats, error)
+}
+
+// MissingCollectionPvtDataStats summarizes the private data of a collection that is missing on the peer.
+// `Eligible` refers to the missing private data of a collection for which this peer is a member, which
+// is fetched from other peers by the reconciler; `Ineligible` refers to the missing private data of a
+// collection for which this peer is not a member
+type MissingCollectionPvtDataStats struct {
+	Namespace, Collection string
+	EligibleBlocks        uint64
+	EligibleTxs           uint64
+	IneligibleBlocks      uint64
+	IneligibleTxs         uint64
+	// OldestMissingBlock is the lowest block number that misses either eligible or ineligible private data
+	OldestMissingBlock uint64
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
@@ -469,6 +569,15 @@ func (NotFoundInIndexErr) Error() string {
 	return "Entry not found in index"
 }
 
+// TxDetailsNotAvailableErr is used to indicate that a transaction with the given txid
+// has been committed in the ledger but the details of the transaction are not available
+// because the ledger was bootstrapped from a snapshot taken at a later height
+type TxDetailsNotAvailableErr string
+
+func (TxDetailsNotAvailableErr) Error() string {
+	return "Details for the transaction are not available. Ledger was bootstrapped from a snapshot"
+}
+
 // CollConfigNotDefinedError is returned whenever an operation
 // is requested on a collection whose config has not been defined
 type CollConfigNotDefinedError struct {
@@ -498,6 +607,14 @@ type PvtdataHashMismatch struct {
 	ExpectedHash          []byte
 }
 
+// PurgedPvtKey identifies a private data key purged by a valid transaction.
+// The key is identified by its hash, as only the hash of the key is present in the block
+type PurgedPvtKey struct {
+	BlockNum, TxNum       uint64
+	Namespace, Collection string
+	KeyHash               []byte
+}
+
 // DeployedChaincodeInfoProvider is a dependency that is used by ledger to build collection config history
 // LSCC module is expected to provide an implementation for this dependencys
 type DeployedChaincodeInfoProvider interface {
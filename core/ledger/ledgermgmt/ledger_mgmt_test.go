@@ -8,6 +8,7 @@ package ledgermgmt
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/configtx/test"
@@ -16,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +38,10 @@ func TestLedgerMgmt(t *testing.T) {
 	assert.Nil(t, ids)
 	assert.Equal(t, ErrLedgerMgmtNotInitialized, err)
 
+	l, _, err = CreateLedgerFromSnapshot("non-existing-dir")
+	assert.Nil(t, l)
+	assert.Equal(t, ErrLedgerMgmtNotInitialized, err)
+
 	Close()
 
 	rootPath, err := ioutil.TempDir("", "lgrmgmt")
@@ -95,6 +101,54 @@ func TestLedgerMgmt(t *testing.T) {
 	Close()
 }
 
+func TestCreateLedgerFromSnapshot(t *testing.T) {
+	snapshotsRootDir, err := ioutil.TempDir("", "lgrmgmt-snapshots")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotsRootDir)
+	snapshotsConfig := &ledger.SnapshotsConfig{RootDir: snapshotsRootDir}
+
+	sourceRootPath, err := ioutil.TempDir("", "lgrmgmt")
+	assert.NoError(t, err)
+	cleanup, err := InitializeTestEnvWithInitializer(&Initializer{
+		Config: &ledger.Config{
+			RootFSPath:      sourceRootPath,
+			StateDBConfig:   &ledger.StateDBConfig{},
+			SnapshotsConfig: snapshotsConfig,
+		},
+	})
+	assert.NoError(t, err)
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.SubmitSnapshotRequest(0))
+	cleanup()
+
+	destRootPath, err := ioutil.TempDir("", "lgrmgmt")
+	assert.NoError(t, err)
+	cleanup, err = InitializeTestEnvWithInitializer(&Initializer{
+		Config: &ledger.Config{
+			RootFSPath:      destRootPath,
+			StateDBConfig:   &ledger.StateDBConfig{},
+			SnapshotsConfig: snapshotsConfig,
+		},
+	})
+	assert.NoError(t, err)
+	defer cleanup()
+
+	l, ledgerID, err := CreateLedgerFromSnapshot(kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, "ledger1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "ledger1", ledgerID)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+
+	_, err = OpenLedger("ledger1")
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger1"}, ids)
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	cleanup := InitializeTestEnv(t)
 	defer cleanup()
@@ -39,8 +39,16 @@ type Store struct {
 	rwlock       *sync.RWMutex
 }
 
-// NewProvider returns the handle to the provider
-func NewProvider(storeDir string, conf *pvtdatastorage.PrivateDataConfig, metricsProvider metrics.Provider) *Provider {
+// NewProvider returns the handle to the provider. The block files are archived only if
+// the supplied blkArchiveConf is non-nil and enabled. The optional indexes of the block store
+// are maintained as per the supplied blkIndexConf, if non-nil
+func NewProvider(
+	storeDir string,
+	conf *pvtdatastorage.PrivateDataConfig,
+	blkArchiveConf *ledger.BlockArchiveConfig,
+	blkIndexConf *ledger.BlockIndexConfig,
+	metricsProvider metrics.Provider,
+) *Provider {
 	// Initialize the block storage
 	attrsToIndex := []blkstorage.IndexableAttr{
 		blkstorage.IndexableAttrBlockHash,
@@ -50,11 +58,18 @@ func NewProvider(storeDir string, conf *pvtdatastorage.PrivateDataConfig, metric
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
 	}
+	if blkIndexConf != nil && blkIndexConf.ChaincodeNamespace {
+		attrsToIndex = append(attrsToIndex, blkstorage.IndexableAttrChaincodeNamespace)
+	}
+	if blkIndexConf != nil && blkIndexConf.Creator {
+		attrsToIndex = append(attrsToIndex, blkstorage.IndexableAttrCreator)
+	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(
+		fsblkstorage.NewConfWithArchiving(
 			filepath.Join(storeDir, "chains"),
 			maxBlockFileSize,
+			newBlockArchiveConf(blkArchiveConf),
 		),
 		indexConfig,
 		metricsProvider,
@@ -64,16 +79,53 @@ func NewProvider(storeDir string, conf *pvtdatastorage.PrivateDataConfig, metric
 	return &Provider{blockStoreProvider, pvtStoreProvider}
 }
 
+func newBlockArchiveConf(c *ledger.BlockArchiveConfig) *fsblkstorage.ArchiveConf {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	var archive interface {
+		fsblkstorage.ArchiveWriter
+		fsblkstorage.ArchiveReader
+	}
+	switch c.Format {
+	case "tar":
+		archive = fsblkstorage.NewTarArchive(c.RootDir)
+	default:
+		archive = fsblkstorage.NewDirArchive(c.RootDir)
+	}
+	return &fsblkstorage.ArchiveConf{
+		PrunePolicy: &fsblkstorage.RetentionPolicy{
+			RetainBlocks: c.RetainBlocks,
+			RetainPeriod: c.RetainPeriod,
+		},
+		Writer: archive,
+		Reader: archive,
+	}
+}
+
 // Open opens the store
 func (p *Provider) Open(ledgerid string) (*Store, error) {
-	var blockStore blkstorage.BlockStore
-	var pvtdataStore pvtdatastorage.Store
-	var err error
+	blockStore, err := p.blkStoreProvider.OpenBlockStore(ledgerid)
+	if err != nil {
+		return nil, err
+	}
+	return p.open(ledgerid, blockStore)
+}
 
-	if blockStore, err = p.blkStoreProvider.OpenBlockStore(ledgerid); err != nil {
+// BootstrapFromSnapshot bootstraps the block store from the txids present in the snapshot
+// and opens the store. The pvtdata store starts as if it has processed the blocks included
+// in the snapshot with no pvt data
+func (p *Provider) BootstrapFromSnapshot(snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo, ledgerid string) (*Store, error) {
+	blockStore, err := p.blkStoreProvider.BootstrapFromSnapshottedTxIDs(snapshotDir, snapshotInfo, ledgerid)
+	if err != nil {
 		return nil, err
 	}
-	if pvtdataStore, err = p.pvtdataStoreProvider.OpenStore(ledgerid); err != nil {
+	return p.open(ledgerid, blockStore)
+}
+
+func (p *Provider) open(ledgerid string, blockStore blkstorage.BlockStore) (*Store, error) {
+	pvtdataStore, err := p.pvtdataStoreProvider.OpenStore(ledgerid)
+	if err != nil {
 		return nil, err
 	}
 	store := &Store{blockStore, pvtdataStore, &sync.RWMutex{}}
@@ -83,6 +135,31 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// Exists returns true if the block store of the given ledger exists
+func (p *Provider) Exists(ledgerid string) (bool, error) {
+	return p.blkStoreProvider.Exists(ledgerid)
+}
+
+// Rollback removes the blocks after the given block number from the block store of a ledger that is not open.
+// The pvtdata store is not rolled back, as the pvtdata of a block is not written again while committing a block
+// below the height of the pvtdata store
+func (p *Provider) Rollback(ledgerid string, blockNum uint64) error {
+	return p.blkStoreProvider.Rollback(ledgerid, blockNum)
+}
+
+// ValidateRollback returns an error if the block store of the given ledger cannot be rolled back to the given block number
+func (p *Provider) ValidateRollback(ledgerid string, blockNum uint64) error {
+	return p.blkStoreProvider.ValidateRollback(ledgerid, blockNum)
+}
+
+// Drop removes the block store and the pvtdata store of a ledger that is not open
+func (p *Provider) Drop(ledgerid string) error {
+	if err := p.blkStoreProvider.Drop(ledgerid); err != nil {
+		return err
+	}
+	return p.pvtdataStoreProvider.Drop(ledgerid)
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -234,6 +311,16 @@ func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return s.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange invokes the function on underlying pvtdata store
+func (s *Store) GetMissingPvtDataInfoForBlockRange(startBlk, endBlk uint64) (ledger.MissingPvtDataInfo, error) {
+	return s.pvtdataStore.GetMissingPvtDataInfoForBlockRange(startBlk, endBlk)
+}
+
+// GetMissingPvtDataStats invokes the function on underlying pvtdata store
+func (s *Store) GetMissingPvtDataStats() ([]*ledger.MissingCollectionPvtDataStats, error) {
+	return s.pvtdataStore.GetMissingPvtDataStats()
+}
+
 // ProcessCollsEligibilityEnabled invokes the function on underlying pvtdata store
 func (s *Store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap map[string][]string) error {
 	return s.pvtdataStore.ProcessCollsEligibilityEnabled(committingBlk, nsCollMap)
@@ -244,6 +331,16 @@ func (s *Store) GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData
 	return s.pvtdataStore.GetLastUpdatedOldBlocksPvtData()
 }
 
+// PurgePvtKeys invokes the function on underlying pvtdata store
+func (s *Store) PurgePvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	return s.pvtdataStore.PurgePvtKeys(purgedKeys)
+}
+
+// GetPurgeRecords invokes the function on underlying pvtdata store
+func (s *Store) GetPurgeRecords(startBlkNum, endBlkNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	return s.pvtdataStore.GetPurgeRecords(startBlkNum, endBlkNum)
+}
+
 // ResetLastUpdatedOldBlocksList invokes the function on underlying pvtdata store
 func (s *Store) ResetLastUpdatedOldBlocksList() error {
 	return s.pvtdataStore.ResetLastUpdatedOldBlocksList()
@@ -25,6 +25,7 @@ import (
 	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
@@ -43,7 +44,7 @@ func TestStore(t *testing.T) {
 	}
 	defer os.RemoveAll(storeDir)
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -153,7 +154,7 @@ func TestStoreWithExistingBlockchain(t *testing.T) {
 	// Simulating the upgrade from 1.0 situation:
 	// Open the ledger storage - pvtdata store is opened for the first time with an existing block storage
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open(testLedgerid)
 	store.Init(btlPolicyForSampleData())
@@ -179,7 +180,7 @@ func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 	}
 	defer os.RemoveAll(storeDir)
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -202,7 +203,7 @@ func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil)
 	store.Shutdown()
 	provider.Close()
-	provider = NewProvider(storeDir, conf, metricsProvider)
+	provider = NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	store, err = provider.Open("testLedger")
 	assert.NoError(t, err)
 	store.Init(btlPolicyForSampleData())
@@ -238,7 +239,7 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	}
 	defer os.RemoveAll(storeDir)
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -264,7 +265,7 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	store.BlockStore.AddBlock(dataAtCrash.Block)
 	store.Shutdown()
 	provider.Close()
-	provider = NewProvider(storeDir, conf, metricsProvider)
+	provider = NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	store, err = provider.Open("testLedger")
 	assert.NoError(t, err)
 	store.Init(btlPolicyForSampleData())
@@ -281,7 +282,7 @@ func TestAddAfterPvtdataStoreError(t *testing.T) {
 	}
 	defer os.RemoveAll(storeDir)
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -322,7 +323,7 @@ func TestAddAfterBlkStoreError(t *testing.T) {
 	}
 	defer os.RemoveAll(storeDir)
 	conf := buildPrivateDataConfig(storeDir)
-	provider := NewProvider(storeDir, conf, metricsProvider)
+	provider := NewProvider(storeDir, conf, nil, nil, metricsProvider)
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -402,11 +403,11 @@ func samplePvtData(t *testing.T, txNums []uint64) map[uint64]*ledger.TxPvtData {
 			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
 				{
 					CollectionName: "coll-1",
-					Rwset:          []byte("RandomBytes-PvtRWSet-ns1-coll1"),
+					Rwset:          samplePvtKVRWSetBytes(t, "key-1"),
 				},
 				{
 					CollectionName: "coll-2",
-					Rwset:          []byte("RandomBytes-PvtRWSet-ns1-coll2"),
+					Rwset:          samplePvtKVRWSetBytes(t, "key-2"),
 				},
 			},
 		},
@@ -418,6 +419,14 @@ func samplePvtData(t *testing.T, txNums []uint64) map[uint64]*ledger.TxPvtData {
 	return constructPvtdataMap(pvtData)
 }
 
+func samplePvtKVRWSetBytes(t *testing.T, key string) []byte {
+	kvRWSetBytes, err := proto.Marshal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: key, Value: []byte("value-of-" + key)}},
+	})
+	assert.NoError(t, err)
+	return kvRWSetBytes
+}
+
 func btlPolicyForSampleData() pvtdatapolicy.BTLPolicy {
 	return btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
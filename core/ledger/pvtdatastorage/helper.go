@@ -9,9 +9,13 @@ package pvtdatastorage
 import (
 	"math"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
 	"github.com/willf/bitset"
 )
 
@@ -158,6 +162,49 @@ func deriveKeys(expiryEntry *expiryEntry) (dataKeys []*dataKey, missingDataKeys
 	return
 }
 
+// deriveWrittenKeyHashes returns the hashes of the keys written by a data entry
+func deriveWrittenKeyHashes(key *dataKey, value *rwset.CollectionPvtReadWriteSet) ([][]byte, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(value.Rwset, kvRWSet); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling the private write set of [ns=%s, coll=%s] in block [%d], tx [%d]",
+			key.ns, key.coll, key.blkNum, key.txNum)
+	}
+	var keyHashes [][]byte
+	added := make(map[string]bool)
+	addKeyHash := func(pvtKey string) {
+		if added[pvtKey] {
+			return
+		}
+		added[pvtKey] = true
+		keyHashes = append(keyHashes, util.ComputeStringHash(pvtKey))
+	}
+	for _, w := range kvRWSet.Writes {
+		addKeyHash(w.Key)
+	}
+	for _, w := range kvRWSet.MetadataWrites {
+		addKeyHash(w.Key)
+	}
+	return keyHashes, nil
+}
+
+func deriveHashedIndexKeys(key *dataKey, value *rwset.CollectionPvtReadWriteSet) ([][]byte, error) {
+	keyHashes, err := deriveWrittenKeyHashes(key, value)
+	if err != nil {
+		return nil, err
+	}
+	var indexKeys [][]byte
+	for _, keyHash := range keyHashes {
+		indexKeys = append(indexKeys, encodeHashedIndexKey(&hashedIndexKey{
+			ns:      key.ns,
+			coll:    key.coll,
+			keyHash: keyHash,
+			blkNum:  key.blkNum,
+			txNum:   key.txNum,
+		}))
+	}
+	return indexKeys, nil
+}
+
 func passesFilter(dataKey *dataKey, filter ledger.PvtNsCollFilter) bool {
 	return filter == nil || filter.Has(dataKey.ns, dataKey.coll)
 }
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -27,6 +28,10 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	hashedIndexKeyPrefix           = []byte{8}
+	purgeMarkerKeyPrefix           = []byte{9}
+	purgeRecordKeyPrefix           = []byte{10}
+	hashedIndexBuiltKey            = []byte{11}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -38,6 +43,10 @@ func getDataKeysForRangeScanByBlockNum(blockNum uint64) (startKey, endKey []byte
 	return
 }
 
+func getDataKeysForRangeScan() (startKey, endKey []byte) {
+	return pvtDataKeyPrefix, expiryKeyPrefix
+}
+
 func getExpiryKeysForRangeScan(minBlkNum, maxBlkNum uint64) (startKey, endKey []byte) {
 	startKey = append(expiryKeyPrefix, version.NewHeight(minBlkNum, 0).ToBytes()...)
 	endKey = append(expiryKeyPrefix, version.NewHeight(maxBlkNum+1, 0).ToBytes()...)
@@ -177,6 +186,22 @@ func createRangeScanKeysForEligibleMissingDataEntries(blkNum uint64) (startKey,
 	return startKey, endKey
 }
 
+func createRangeScanKeysForEligibleMissingDataEntriesInRange(startBlkNum, endBlkNum uint64) (startKey, endKey []byte) {
+	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(endBlkNum)...)
+	if startBlkNum == 0 {
+		endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(0)...)
+		return startKey, endKey
+	}
+	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(startBlkNum-1)...)
+	return startKey, endKey
+}
+
+func createRangeScanKeysForAllIneligibleMissingData() (startKey, endKey []byte) {
+	startKey = ineligibleMissingDataKeyPrefix
+	endKey = []byte{ineligibleMissingDataKeyPrefix[0] + 1}
+	return startKey, endKey
+}
+
 func createRangeScanKeysForIneligibleMissingData(maxBlkNum uint64, ns, coll string) (startKey, endKey []byte) {
 	startKey = encodeMissingDataKey(
 		&missingDataKey{
@@ -209,3 +234,75 @@ func eligibleMissingdatakeyRange(blkNum uint64) (startKey, endKey []byte) {
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
 	return
 }
+
+func encodeHashedIndexKey(key *hashedIndexKey) []byte {
+	keyBytes := encodePvtKeyHashPrefix(hashedIndexKeyPrefix, key.ns, key.coll, key.keyHash)
+	return append(keyBytes, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
+}
+
+// decodeHeightFromHashedIndexKey decodes the height of a hashed index key that
+// starts with the prefix returned by the function hashedIndexKeyRange
+func decodeHeightFromHashedIndexKey(keyBytes []byte, prefixLen int) *version.Height {
+	height, _ := version.NewHeightFromBytes(keyBytes[prefixLen:])
+	return height
+}
+
+// hashedIndexKeyRange returns the range of the hashed index keys for the versions of a purged key
+// committed before the purge, along with the length of the prefix shared by the keys in the range
+func hashedIndexKeyRange(purgedKey *ledger.PurgedPvtKey) (startKey, endKey []byte, prefixLen int) {
+	prefix := encodePvtKeyHashPrefix(hashedIndexKeyPrefix, purgedKey.Namespace, purgedKey.Collection, purgedKey.KeyHash)
+	startKey = append(prefix, version.NewHeight(0, 0).ToBytes()...)
+	endKey = append(append([]byte{}, prefix...), version.NewHeight(purgedKey.BlockNum, purgedKey.TxNum).ToBytes()...)
+	return startKey, endKey, len(prefix)
+}
+
+func encodePurgeMarkerKey(ns, coll string, keyHash []byte) []byte {
+	return encodePvtKeyHashPrefix(purgeMarkerKeyPrefix, ns, coll, keyHash)
+}
+
+func encodePurgeMarkerValue(purgedKey *ledger.PurgedPvtKey) []byte {
+	return version.NewHeight(purgedKey.BlockNum, purgedKey.TxNum).ToBytes()
+}
+
+func decodePurgeMarkerValue(b []byte) *version.Height {
+	height, _ := version.NewHeightFromBytes(b)
+	return height
+}
+
+func encodePurgeRecordKey(purgedKey *ledger.PurgedPvtKey) []byte {
+	keyBytes := append(purgeRecordKeyPrefix, version.NewHeight(purgedKey.BlockNum, purgedKey.TxNum).ToBytes()...)
+	keyBytes = append(keyBytes, []byte(purgedKey.Namespace)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(purgedKey.Collection)...)
+	keyBytes = append(keyBytes, nilByte)
+	return append(keyBytes, purgedKey.KeyHash...)
+}
+
+func decodePurgeRecordKey(keyBytes []byte) *ledger.PurgedPvtKey {
+	height, n := version.NewHeightFromBytes(keyBytes[1:])
+	splittedKey := bytes.SplitN(keyBytes[n+1:], []byte{nilByte}, 3) // the key hash may contain nil bytes
+	keyHash := make([]byte, len(splittedKey[2]))
+	copy(keyHash, splittedKey[2])
+	return &ledger.PurgedPvtKey{
+		BlockNum:   height.BlockNum,
+		TxNum:      height.TxNum,
+		Namespace:  string(splittedKey[0]),
+		Collection: string(splittedKey[1]),
+		KeyHash:    keyHash,
+	}
+}
+
+func purgeRecordKeyRange(startBlkNum, endBlkNum uint64) (startKey, endKey []byte) {
+	startKey = append(purgeRecordKeyPrefix, version.NewHeight(startBlkNum, 0).ToBytes()...)
+	endKey = append(purgeRecordKeyPrefix, version.NewHeight(endBlkNum+1, 0).ToBytes()...)
+	return
+}
+
+func encodePvtKeyHashPrefix(prefix []byte, ns, coll string, keyHash []byte) []byte {
+	keyBytes := append([]byte{}, prefix...)
+	keyBytes = append(keyBytes, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	return append(keyBytes, keyHash...)
+}
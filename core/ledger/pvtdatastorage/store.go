@@ -15,6 +15,8 @@ import (
 // private write sets for a ledger
 type Provider interface {
 	OpenStore(id string) (Store, error)
+	// Drop removes the pvt data of a ledger whose store is not open
+	Drop(id string) error
 	Close()
 }
 
@@ -46,6 +48,12 @@ type Store interface {
 	// GetMissingPvtDataInfoForMostRecentBlocks returns the missing private data information for the
 	// most recent `maxBlock` blocks which miss at least a private data of a eligible collection.
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information of eligible
+	// collections for the blocks in the range [startBlk, endBlk]
+	GetMissingPvtDataInfoForBlockRange(startBlk, endBlk uint64) (ledger.MissingPvtDataInfo, error)
+	// GetMissingPvtDataStats returns, for each collection, statistics about both the eligible and
+	// the ineligible missing private data
+	GetMissingPvtDataStats() ([]*ledger.MissingCollectionPvtDataStats, error)
 	// Prepare prepares the Store for committing the pvt data and storing both eligible and ineligible
 	// missing private data --- `eligible` denotes that the missing private data belongs to a collection
 	// for which this peer is a member; `ineligible` denotes that the missing private data belong to a
@@ -76,6 +84,12 @@ type Store interface {
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
 	ResetLastUpdatedOldBlocksList() error
+	// PurgePvtKeys removes the purged keys from the pvt data committed before the corresponding purge
+	// transactions, and records the purges. The store also drops a purged key from the pvt data of an
+	// older block that is committed later via the function `CommitPvtDataOfOldBlocks`
+	PurgePvtKeys(purgedKeys []*ledger.PurgedPvtKey) error
+	// GetPurgeRecords returns the records of the keys purged by the blocks within the given range (both inclusive)
+	GetPurgeRecords(startBlkNum, endBlkNum uint64) ([]*ledger.PurgedPvtKey, error)
 	// IsEmpty returns true if the store does not have any block committed yet
 	IsEmpty() (bool, error)
 	// LastCommittedBlockHeight returns the height of the last committed block
@@ -17,13 +17,19 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
 	"github.com/willf/bitset"
 )
 
 var logger = flogging.MustGetLogger("pvtdatastorage")
 
+// hashedIndexBuildBatchSize is the number of data entries indexed in a batch when the hashed index is built
+const hashedIndexBuildBatchSize = 1000
+
 type provider struct {
 	dbProvider *leveldbhelper.Provider
 	pvtData    *PrivateDataConfig
@@ -81,6 +87,14 @@ type dataKey struct {
 	txNum uint64
 }
 
+// hashedIndexKey indexes the data entries by the hashes of the keys they write,
+// so that the past versions of a purged key can be located
+type hashedIndexKey struct {
+	ns, coll      string
+	keyHash       []byte
+	blkNum, txNum uint64
+}
+
 type missingDataKey struct {
 	nsCollBlk
 	isEligible bool
@@ -134,12 +148,21 @@ func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	if err := s.initState(); err != nil {
 		return nil, err
 	}
+	if err := s.buildHashedIndex(); err != nil {
+		return nil, err
+	}
 	s.launchCollElgProc()
 	logger.Debugf("Pvtdata store opened. Initial state: isEmpty [%t], lastCommittedBlock [%d], batchPending [%t]",
 		s.isEmpty, s.lastCommittedBlock, s.batchPending)
 	return s, nil
 }
 
+// Drop removes all the data of the given ledger from the store
+func (p *provider) Drop(ledgerid string) error {
+	return errors.WithMessagef(p.dbProvider.GetDBHandle(ledgerid).DeleteAll(),
+		"error while deleting the pvtdata store of ledger [%s]", ledgerid)
+}
+
 // Close closes the store
 func (p *provider) Close() {
 	p.dbProvider.Close()
@@ -167,6 +190,57 @@ func (s *store) initState() error {
 	return nil
 }
 
+// buildHashedIndex indexes the data entries committed before the store maintained the hashed
+// index, which the purges rely on to find the versions of a key. The index is built only once,
+// as the data entries committed afterwards are indexed when they are committed
+func (s *store) buildHashedIndex() error {
+	built, err := s.db.Get(hashedIndexBuiltKey)
+	if err != nil {
+		return err
+	}
+	if built != nil {
+		return nil
+	}
+
+	logger.Infof("[%s] Building the hashed index of the private data entries", s.ledgerid)
+	itr := s.db.GetIterator(getDataKeysForRangeScan())
+	defer itr.Release()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	numEntries := 0
+	for itr.Next() {
+		if v11Format(itr.Key()) {
+			// the data entries in the v11 format are not indexed, as they are not purged in place
+			continue
+		}
+		key := decodeDatakey(itr.Key())
+		value, err := decodeDataValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		if err := addHashedIndexKeysToUpdateBatch(batch, key, value); err != nil {
+			return err
+		}
+		numEntries++
+		if numEntries%hashedIndexBuildBatchSize == 0 {
+			if err := s.db.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the private data entries")
+	}
+
+	batch.Put(hashedIndexBuiltKey, emptyValue)
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Infof("[%s] Built the hashed index of [%d] private data entries", s.ledgerid, numEntries)
+	return nil
+}
+
 func (s *store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 	s.btlPolicy = btlPolicy
 }
@@ -197,6 +271,9 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvt
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		if err := addHashedIndexKeysToUpdateBatch(batch, dataEntry.key, dataEntry.value); err != nil {
+			return err
+		}
 	}
 
 	for _, expiryEntry := range storeEntries.expiryEntries {
@@ -261,7 +338,12 @@ func (s *store) Rollback() error {
 	batch := leveldbhelper.NewUpdateBatch()
 	itr := s.db.GetIterator(datakeyRange(blkNum))
 	for itr.Next() {
-		batch.Delete(itr.Key())
+		dataKeyBytes := itr.Key()
+		if err := deleteHashedIndexKeysFromUpdateBatch(batch, decodeDatakey(dataKeyBytes), itr.Value()); err != nil {
+			itr.Release()
+			return err
+		}
+		batch.Delete(dataKeyBytes)
 	}
 	itr.Release()
 	itr = s.db.GetIterator(eligibleMissingdatakeyRange(blkNum))
@@ -283,7 +365,9 @@ func (s *store) Rollback() error {
 // operations
 // (1) construct dataEntries for all pvtData
 // (2) construct update entries (i.e., dataEntries, expiryEntries, missingDataEntries, and
-//     lastUpdatedOldBlocksList) from the above created data entries
+//
+//	lastUpdatedOldBlocksList) from the above created data entries
+//
 // (3) create a db update batch from the update entries
 // (4) commit the update entries to the pvtStore
 func (s *store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error {
@@ -339,6 +423,11 @@ func (s *store) constructUpdateEntriesFromDataEntries(dataEntries []*dataEntry)
 	// Second, update the expiryData and missingData as per the data entry. Finally, add
 	// the data entry along with the updated expiryData and missingData to the update entries
 	for _, dataEntry := range dataEntries {
+		// drop the keys that were purged after the data entry was committed
+		if err := s.removePurgedKeysFromDataEntry(dataEntry); err != nil {
+			return nil, err
+		}
+
 		// get the expiryBlk number to construct the expiryKey
 		expiryKey, err := s.constructExpiryKeyFromDataEntry(dataEntry)
 		if err != nil {
@@ -380,6 +469,38 @@ func (s *store) constructUpdateEntriesFromDataEntries(dataEntries []*dataEntry)
 	return updateEntries, nil
 }
 
+func (s *store) removePurgedKeysFromDataEntry(dataEntry *dataEntry) error {
+	keyHashes, err := deriveWrittenKeyHashes(dataEntry.key, dataEntry.value)
+	if err != nil {
+		return err
+	}
+	cloned := false
+	for _, keyHash := range keyHashes {
+		v, err := s.db.Get(encodePurgeMarkerKey(dataEntry.key.ns, dataEntry.key.coll, keyHash))
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		purgeHeight := decodePurgeMarkerValue(v)
+		if purgeHeight.Compare(version.NewHeight(dataEntry.key.blkNum, dataEntry.key.txNum)) <= 0 {
+			continue
+		}
+		if !cloned {
+			// the pvtData passed by the caller is not modified
+			dataEntry.value = proto.Clone(dataEntry.value).(*rwset.CollectionPvtReadWriteSet)
+			cloned = true
+		}
+		if _, err := rwsetutil.RemoveKeyFromCollPvtRwSet(dataEntry.value, keyHash); err != nil {
+			return err
+		}
+		logger.Infof("[%s] Dropped the private data of a key purged at block [%d], tx [%d] from the pvtdata of old block [%d], tx [%d], [ns=%s, coll=%s]",
+			s.ledgerid, purgeHeight.BlockNum, purgeHeight.TxNum, dataEntry.key.blkNum, dataEntry.key.txNum, dataEntry.key.ns, dataEntry.key.coll)
+	}
+	return nil
+}
+
 func (s *store) constructExpiryKeyFromDataEntry(dataEntry *dataEntry) (expiryKey, error) {
 	// get the expiryBlk number to construct the expiryKey
 	nsCollBlk := dataEntry.key.nsCollBlk
@@ -481,6 +602,35 @@ func addNewDataEntriesToUpdateBatch(batch *leveldbhelper.UpdateBatch, entries *e
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		if err := addHashedIndexKeysToUpdateBatch(batch, &dataKey, pvtData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addHashedIndexKeysToUpdateBatch(batch *leveldbhelper.UpdateBatch, key *dataKey, value *rwset.CollectionPvtReadWriteSet) error {
+	indexKeys, err := deriveHashedIndexKeys(key, value)
+	if err != nil {
+		return err
+	}
+	for _, indexKey := range indexKeys {
+		batch.Put(indexKey, emptyValue)
+	}
+	return nil
+}
+
+func deleteHashedIndexKeysFromUpdateBatch(batch *leveldbhelper.UpdateBatch, key *dataKey, valueBytes []byte) error {
+	value, err := decodeDataValue(valueBytes)
+	if err != nil {
+		return err
+	}
+	indexKeys, err := deriveHashedIndexKeys(key, value)
+	if err != nil {
+		return err
+	}
+	for _, indexKey := range indexKeys {
+		batch.Delete(indexKey)
 	}
 	return nil
 }
@@ -764,6 +914,114 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return missingPvtDataInfo, nil
 }
 
+// GetMissingPvtDataInfoForBlockRange implements the function in the interface `Store`
+func (s *store) GetMissingPvtDataInfoForBlockRange(startBlk, endBlk uint64) (ledger.MissingPvtDataInfo, error) {
+	if startBlk > endBlk {
+		return nil, errors.Errorf("start block [%d] is greater than end block [%d]", startBlk, endBlk)
+	}
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	if endBlk > lastCommittedBlock {
+		endBlk = lastCommittedBlock
+	}
+	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	if startBlk > endBlk {
+		return missingPvtDataInfo, nil
+	}
+
+	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntriesInRange(startBlk, endBlk)
+	dbItr := s.db.GetIterator(startKey, endKey)
+	defer dbItr.Release()
+
+	for dbItr.Next() {
+		missingDataKey := decodeMissingDataKey(dbItr.Key())
+		expired, err := isExpired(missingDataKey.nsCollBlk, s.btlPolicy, lastCommittedBlock)
+		if err != nil {
+			return nil, err
+		}
+		if expired {
+			continue
+		}
+
+		bitmap, err := decodeMissingDataValue(dbItr.Value())
+		if err != nil {
+			return nil, err
+		}
+		for index, isSet := bitmap.NextSet(0); isSet; index, isSet = bitmap.NextSet(index + 1) {
+			missingPvtDataInfo.Add(missingDataKey.blkNum, uint64(index), missingDataKey.ns, missingDataKey.coll)
+		}
+	}
+
+	return missingPvtDataInfo, nil
+}
+
+// GetMissingPvtDataStats implements the function in the interface `Store`
+func (s *store) GetMissingPvtDataStats() ([]*ledger.MissingCollectionPvtDataStats, error) {
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	statsByNsColl := map[ledger.MissingCollectionPvtDataInfo]*ledger.MissingCollectionPvtDataStats{}
+
+	addMissingDataEntries := func(startKey, endKey []byte) error {
+		dbItr := s.db.GetIterator(startKey, endKey)
+		defer dbItr.Release()
+
+		for dbItr.Next() {
+			missingDataKey := decodeMissingDataKey(dbItr.Key())
+			expired, err := isExpired(missingDataKey.nsCollBlk, s.btlPolicy, lastCommittedBlock)
+			if err != nil {
+				return err
+			}
+			if expired {
+				continue
+			}
+
+			bitmap, err := decodeMissingDataValue(dbItr.Value())
+			if err != nil {
+				return err
+			}
+
+			key := ledger.MissingCollectionPvtDataInfo{Namespace: missingDataKey.ns, Collection: missingDataKey.coll}
+			stats, ok := statsByNsColl[key]
+			if !ok {
+				stats = &ledger.MissingCollectionPvtDataStats{
+					Namespace:          missingDataKey.ns,
+					Collection:         missingDataKey.coll,
+					OldestMissingBlock: missingDataKey.blkNum,
+				}
+				statsByNsColl[key] = stats
+			}
+			if missingDataKey.isEligible {
+				stats.EligibleBlocks++
+				stats.EligibleTxs += uint64(bitmap.Count())
+			} else {
+				stats.IneligibleBlocks++
+				stats.IneligibleTxs += uint64(bitmap.Count())
+			}
+			if missingDataKey.blkNum < stats.OldestMissingBlock {
+				stats.OldestMissingBlock = missingDataKey.blkNum
+			}
+		}
+		return nil
+	}
+
+	if err := addMissingDataEntries(createRangeScanKeysForEligibleMissingDataEntries(lastCommittedBlock)); err != nil {
+		return nil, err
+	}
+	if err := addMissingDataEntries(createRangeScanKeysForAllIneligibleMissingData()); err != nil {
+		return nil, err
+	}
+
+	stats := make([]*ledger.MissingCollectionPvtDataStats, 0, len(statsByNsColl))
+	for _, collStats := range statsByNsColl {
+		stats = append(stats, collStats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Namespace != stats[j].Namespace {
+			return stats[i].Namespace < stats[j].Namespace
+		}
+		return stats[i].Collection < stats[j].Collection
+	})
+	return stats, nil
+}
+
 // ProcessCollsEligibilityEnabled implements the function in the interface `Store`
 func (s *store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap map[string][]string) error {
 	key := encodeCollElgKey(committingBlk)
@@ -781,6 +1039,94 @@ func (s *store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap m
 	return nil
 }
 
+// PurgePvtKeys implements the function in the interface `Store`
+func (s *store) PurgePvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	if len(purgedKeys) == 0 {
+		return nil
+	}
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	updatedDataEntries := make(map[dataKey]*rwset.CollectionPvtReadWriteSet)
+	for _, purgedKey := range purgedKeys {
+		numPurged, err := s.purgePvtKey(batch, purgedKey, updatedDataEntries)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodePurgeMarkerKey(purgedKey.Namespace, purgedKey.Collection, purgedKey.KeyHash), encodePurgeMarkerValue(purgedKey))
+		batch.Put(encodePurgeRecordKey(purgedKey), emptyValue)
+		logger.Infof("[%s] Purged [%d] versions of the private data key with hash [%x] in [ns=%s, coll=%s], as requested by block [%d], tx [%d]",
+			s.ledgerid, numPurged, purgedKey.KeyHash, purgedKey.Namespace, purgedKey.Collection, purgedKey.BlockNum, purgedKey.TxNum)
+	}
+
+	for dataKey, pvtData := range updatedDataEntries {
+		valBytes, err := encodeDataValue(pvtData)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeDataKey(&dataKey), valBytes)
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
+// purgePvtKey removes the key from the data entries committed before the purge and returns
+// the number of data entries that contained the key. The data entries are accumulated in
+// the `updatedDataEntries`, so that the multiple keys purged from a data entry are all removed
+func (s *store) purgePvtKey(batch *leveldbhelper.UpdateBatch, purgedKey *ledger.PurgedPvtKey,
+	updatedDataEntries map[dataKey]*rwset.CollectionPvtReadWriteSet) (int, error) {
+	startKey, endKey, prefixLen := hashedIndexKeyRange(purgedKey)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	numPurged := 0
+	for itr.Next() {
+		// the iterator reuses the key buffer
+		indexKey := append([]byte{}, itr.Key()...)
+		batch.Delete(indexKey)
+		height := decodeHeightFromHashedIndexKey(indexKey, prefixLen)
+		key := dataKey{nsCollBlk{purgedKey.Namespace, purgedKey.Collection, height.BlockNum}, height.TxNum}
+		pvtData, ok := updatedDataEntries[key]
+		if !ok {
+			valBytes, err := s.db.Get(encodeDataKey(&key))
+			if err != nil {
+				return 0, err
+			}
+			if valBytes == nil {
+				// data entry is already expired and purged
+				continue
+			}
+			if pvtData, err = decodeDataValue(valBytes); err != nil {
+				return 0, err
+			}
+		}
+		removed, err := rwsetutil.RemoveKeyFromCollPvtRwSet(pvtData, purgedKey.KeyHash)
+		if err != nil {
+			return 0, err
+		}
+		if removed {
+			updatedDataEntries[key] = pvtData
+			numPurged++
+		}
+	}
+	return numPurged, nil
+}
+
+// GetPurgeRecords implements the function in the interface `Store`
+func (s *store) GetPurgeRecords(startBlkNum, endBlkNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	itr := s.db.GetIterator(purgeRecordKeyRange(startBlkNum, endBlkNum))
+	defer itr.Release()
+
+	var purgeRecords []*ledger.PurgedPvtKey
+	for itr.Next() {
+		purgeRecords = append(purgeRecords, decodePurgeRecordKey(itr.Key()))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the purge records")
+	}
+	return purgeRecords, nil
+}
+
 func (s *store) performPurgeIfScheduled(latestCommittedBlk uint64) {
 	if latestCommittedBlk%s.purgeInterval != 0 {
 		return
@@ -809,7 +1155,17 @@ func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		batch.Delete(encodeExpiryKey(expiryEntry.key))
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 		for _, dataKey := range dataKeys {
-			batch.Delete(encodeDataKey(dataKey))
+			keyBytes := encodeDataKey(dataKey)
+			valBytes, err := s.db.Get(keyBytes)
+			if err != nil {
+				return err
+			}
+			if valBytes != nil {
+				if err := deleteHashedIndexKeysFromUpdateBatch(batch, dataKey, valBytes); err != nil {
+					return err
+				}
+			}
+			batch.Delete(keyBytes)
 		}
 		for _, missingDataKey := range missingDataKeys {
 			batch.Delete(encodeMissingDataKey(missingDataKey))
@@ -15,9 +15,12 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -647,6 +650,40 @@ func TestStoreState(t *testing.T) {
 	assert.True(ok)
 }
 
+func TestDrop(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestDrop", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	testData := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1"}),
+	}
+	assert.NoError(env.TestStore.Prepare(0, testData, nil))
+	assert.NoError(env.TestStore.Commit())
+
+	anotherStore, err := env.TestStoreProvider.OpenStore("anotherLedger")
+	assert.NoError(err)
+	anotherStore.Init(btlPolicy)
+	assert.NoError(anotherStore.Prepare(0, testData, nil))
+	assert.NoError(anotherStore.Commit())
+
+	assert.NoError(env.TestStoreProvider.Drop("TestDrop"))
+	env.CloseAndReopen()
+	testEmpty(true, assert, env.TestStore)
+
+	anotherStore, err = env.TestStoreProvider.OpenStore("anotherLedger")
+	assert.NoError(err)
+	anotherStore.Init(btlPolicy)
+	testEmpty(false, assert, anotherStore)
+	pvtdata, err := anotherStore.GetPvtDataByBlockNum(0, nil)
+	assert.NoError(err)
+	assert.Len(pvtdata, 1)
+}
+
 func TestInitLastCommittedBlock(t *testing.T) {
 	env := NewTestStoreEnv(t, "TestStoreState", nil, pvtDataConf())
 	defer env.Cleanup()
@@ -669,6 +706,78 @@ func TestInitLastCommittedBlock(t *testing.T) {
 	assert.True(ok)
 }
 
+func TestMissingPvtDataInfoForBlockRangeAndStats(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+			{"ns-2", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestMissingPvtDataInfoForBlockRangeAndStats", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	stats, err := store.GetMissingPvtDataStats()
+	assert.NoError(err)
+	assert.Empty(stats)
+
+	// no pvt data with block 0
+	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Commit())
+
+	// block 1: eligible missing data in tx1 and tx2, ineligible missing data in tx3
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(1, "ns-1", "coll-1", true)
+	blk1MissingData.Add(2, "ns-1", "coll-1", true)
+	blk1MissingData.Add(3, "ns-2", "coll-1", false)
+	assert.NoError(store.Prepare(1, nil, blk1MissingData))
+	assert.NoError(store.Commit())
+
+	// block 2: eligible missing data in tx1
+	blk2MissingData := make(ledger.TxMissingPvtDataMap)
+	blk2MissingData.Add(1, "ns-1", "coll-2", true)
+	assert.NoError(store.Prepare(2, nil, blk2MissingData))
+	assert.NoError(store.Commit())
+
+	// block 3: eligible missing data in tx0, ineligible missing data in tx1
+	blk3MissingData := make(ledger.TxMissingPvtDataMap)
+	blk3MissingData.Add(0, "ns-1", "coll-1", true)
+	blk3MissingData.Add(1, "ns-2", "coll-1", false)
+	assert.NoError(store.Prepare(3, nil, blk3MissingData))
+	assert.NoError(store.Commit())
+
+	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(2, 1, "ns-1", "coll-2")
+	expectedMissingPvtDataInfo.Add(3, 0, "ns-1", "coll-1")
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(2, 10)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	expectedMissingPvtDataInfo = make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(1, 1, "ns-1", "coll-1")
+	expectedMissingPvtDataInfo.Add(1, 2, "ns-1", "coll-1")
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(0, 1)
+	assert.NoError(err)
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(4, 10)
+	assert.NoError(err)
+	assert.Empty(missingPvtDataInfo)
+
+	_, err = store.GetMissingPvtDataInfoForBlockRange(3, 2)
+	assert.EqualError(err, "start block [3] is greater than end block [2]")
+
+	stats, err = store.GetMissingPvtDataStats()
+	assert.NoError(err)
+	assert.Equal([]*ledger.MissingCollectionPvtDataStats{
+		{Namespace: "ns-1", Collection: "coll-1", EligibleBlocks: 2, EligibleTxs: 3, OldestMissingBlock: 1},
+		{Namespace: "ns-1", Collection: "coll-2", EligibleBlocks: 1, EligibleTxs: 1, OldestMissingBlock: 2},
+		{Namespace: "ns-2", Collection: "coll-1", IneligibleBlocks: 2, IneligibleTxs: 2, OldestMissingBlock: 1},
+	}, stats)
+}
+
 func TestCollElgEnabled(t *testing.T) {
 	conf := pvtDataConf()
 	testCollElgEnabled(t, conf)
@@ -839,6 +948,173 @@ func TestRollBack(t *testing.T) {
 	}
 }
 
+func TestPurgePvtKeys(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgePvtKeys", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(4, "ns-1", "coll-1", true)
+
+	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Commit())
+	assert.NoError(store.Prepare(1, []*ledger.TxPvtData{produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"})}, blk1MissingData))
+	assert.NoError(store.Commit())
+	assert.NoError(store.Prepare(2, []*ledger.TxPvtData{produceSamplePvtdata(t, 1, []string{"ns-1:coll-1", "ns-1:coll-2"})}, nil))
+	assert.NoError(store.Commit())
+	assert.NoError(store.Prepare(3, []*ledger.TxPvtData{produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"})}, nil))
+	assert.NoError(store.Commit())
+
+	purgedKey := &ledger.PurgedPvtKey{
+		BlockNum:   2,
+		TxNum:      3,
+		Namespace:  "ns-1",
+		Collection: "coll-1",
+		KeyHash:    util.ComputeStringHash("key-ns-1-coll-1"),
+	}
+	assert.True(testHashedIndexKeysExist(store, purgedKey))
+	assert.NoError(store.PurgePvtKeys([]*ledger.PurgedPvtKey{purgedKey}))
+	// purging is idempotent
+	assert.NoError(store.PurgePvtKeys([]*ledger.PurgedPvtKey{purgedKey}))
+
+	// the versions of the key committed before the purge are removed
+	for _, blkNum := range []uint64{1, 2} {
+		assert.Equal(
+			map[string][]string{"ns-1:coll-1": nil, "ns-1:coll-2": {"key-ns-1-coll-2"}},
+			testRetrieveWrittenKeys(t, store, blkNum),
+		)
+	}
+	// the version of the key committed after the purge is retained
+	assert.Equal(
+		map[string][]string{"ns-1:coll-1": {"key-ns-1-coll-1"}, "ns-1:coll-2": {"key-ns-1-coll-2"}},
+		testRetrieveWrittenKeys(t, store, 3),
+	)
+
+	// the purge is recorded
+	purgeRecords, err := store.GetPurgeRecords(0, 3)
+	assert.NoError(err)
+	assert.Equal([]*ledger.PurgedPvtKey{purgedKey}, purgeRecords)
+	purgeRecords, err = store.GetPurgeRecords(3, 10)
+	assert.NoError(err)
+	assert.Nil(purgeRecords)
+
+	// the purged key is dropped from the missing pvtdata of an older block
+	oldBlkPvtData := produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"})
+	oldBlkPvtDataCopy := proto.Clone(oldBlkPvtData.WriteSet)
+	assert.NoError(store.CommitPvtDataOfOldBlocks(map[uint64][]*ledger.TxPvtData{1: {oldBlkPvtData}}))
+	assert.True(proto.Equal(oldBlkPvtDataCopy, oldBlkPvtData.WriteSet))
+	assert.NoError(store.ResetLastUpdatedOldBlocksList())
+	assert.False(testMissingDataKeyExists(t, store, &missingDataKey{nsCollBlk{"ns-1", "coll-1", 1}, true}))
+	retrievedData, err := store.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Len(retrievedData, 2)
+	assert.Equal(uint64(4), retrievedData[1].SeqInBlock)
+	assert.Equal(
+		map[string][]string{"ns-1:coll-1": nil},
+		testWrittenKeys(t, retrievedData[1:]),
+	)
+
+	// the hashed index entries of the purged versions are removed
+	assert.False(testHashedIndexKeysExist(store, purgedKey))
+
+	// an iteration error is returned
+	env.TestStoreProvider.Close()
+	_, err = store.GetPurgeRecords(0, 3)
+	assert.EqualError(err, "error while iterating over the purge records: leveldb: closed")
+}
+
+func TestPurgePvtKeysOfDataCommittedBeforeHashedIndex(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgePvtKeysOfDataCommittedBeforeHashedIndex", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Commit())
+	assert.NoError(s.Prepare(1, []*ledger.TxPvtData{produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"})}, nil))
+	assert.NoError(s.Commit())
+
+	// simulate a store populated before the hashed index was maintained
+	purgedKey := &ledger.PurgedPvtKey{
+		BlockNum:   2,
+		TxNum:      0,
+		Namespace:  "ns-1",
+		Collection: "coll-1",
+		KeyHash:    util.ComputeStringHash("key-ns-1-coll-1"),
+	}
+	db := s.(*store).db
+	batch := leveldbhelper.NewUpdateBatch()
+	itr := db.GetIterator(hashedIndexKeyPrefix, purgeMarkerKeyPrefix)
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	batch.Delete(hashedIndexBuiltKey)
+	assert.NoError(db.WriteBatch(batch, true))
+	assert.False(testHashedIndexKeysExist(s, purgedKey))
+
+	// the index is built when the store is opened
+	env.CloseAndReopen()
+	s = env.TestStore
+	assert.True(testHashedIndexKeysExist(s, purgedKey))
+
+	assert.NoError(s.PurgePvtKeys([]*ledger.PurgedPvtKey{purgedKey}))
+	assert.Equal(
+		map[string][]string{"ns-1:coll-1": nil, "ns-1:coll-2": {"key-ns-1-coll-2"}},
+		testRetrieveWrittenKeys(t, s, 1),
+	)
+
+	// the index is built only once
+	built, err := s.(*store).db.Get(hashedIndexBuiltKey)
+	assert.NoError(err)
+	assert.NotNil(built)
+}
+
+func testHashedIndexKeysExist(s Store, purgedKey *ledger.PurgedPvtKey) bool {
+	startKey, endKey, _ := hashedIndexKeyRange(purgedKey)
+	itr := s.(*store).db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	return itr.Next()
+}
+
+func testRetrieveWrittenKeys(t *testing.T, s Store, blkNum uint64) map[string][]string {
+	retrievedData, err := s.GetPvtDataByBlockNum(blkNum, nil)
+	assert.NoError(t, err)
+	return testWrittenKeys(t, retrievedData)
+}
+
+func testWrittenKeys(t *testing.T, pvtData []*ledger.TxPvtData) map[string][]string {
+	writtenKeys := make(map[string][]string)
+	for _, txPvtData := range pvtData {
+		for _, nsPvtRwset := range txPvtData.WriteSet.NsPvtRwset {
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				kvRWSet := &kvrwset.KVRWSet{}
+				assert.NoError(t, proto.Unmarshal(collPvtRwset.Rwset, kvRWSet))
+				nsColl := nsPvtRwset.Namespace + ":" + collPvtRwset.CollectionName
+				keys := writtenKeys[nsColl]
+				for _, w := range kvRWSet.Writes {
+					keys = append(keys, w.Key)
+				}
+				writtenKeys[nsColl] = keys
+			}
+		}
+	}
+	return writtenKeys
+}
+
 // TODO Add tests for simulating a crash between calls `Prepare` and `Commit`/`Rollback` - [FAB-13099]
 
 func testEmpty(expectedEmpty bool, assert *assert.Assertions, store Store) {
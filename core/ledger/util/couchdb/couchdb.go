@@ -507,6 +507,36 @@ func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 
 }
 
+// RetrieveApplicationDBNames returns the names of all the databases in the couch instance, except the
+// system databases, whose names start with an underscore
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+	connectURL, err := url.Parse(couchInstance.URL())
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", couchInstance.URL())
+	}
+	connectURL.Path = "/_all_dbs"
+	maxRetries := couchInstance.conf.MaxRetries
+	resp, _, err := couchInstance.handleRequest(context.Background(), http.MethodGet, "", "RetrieveApplicationDBNames",
+		connectURL, nil, "", "", maxRetries, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	if err := json.NewDecoder(resp.Body).Decode(&dbNames); err != nil {
+		return nil, errors.Wrap(err, "error decoding response body")
+	}
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	return applicationDBNames, nil
+}
+
 // EnsureFullCommit calls _ensure_full_commit for explicit fsync
 func (dbclient *CouchDatabase) EnsureFullCommit() (*DBOperationResponse, error) {
 	dbName := dbclient.DBName
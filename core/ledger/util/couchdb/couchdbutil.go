@@ -251,6 +251,16 @@ func ConstructNamespaceDBName(chainName, namespace string) string {
 	return namespaceDBName
 }
 
+// ChainDBNamePrefix returns the prefix that is common to the names of the metadataDB and the namespaceDBs of
+// the given chain. An error is returned if the chain name is longer than chainNameAllowedLength, as the names of
+// the namespaceDBs may then contain a truncated chain name that may be common to more than one chain
+func ChainDBNamePrefix(chainName string) (string, error) {
+	if len(chainName) > chainNameAllowedLength {
+		return "", errors.Errorf("chain name [%s] is longer than the allowed length %d", chainName, chainNameAllowedLength)
+	}
+	return mapAndValidateDatabaseName(chainName + "_")
+}
+
 //mapAndValidateDatabaseName checks to see if the database name contains illegal characters
 //CouchDB Rules: Only lowercase characters (a-z), digits (0-9), and any of the characters
 //_, $, (, ), +, -, and / are allowed. Must begin with a letter.
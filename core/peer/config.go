@@ -98,6 +98,24 @@ type Config struct {
 	// after overpopulation purge.
 	DiscoveryAuthCachePurgeRetentionRatio float64
 
+	// ----- Gateway -----
+
+	// The gateway service evaluates and endorses proposals, submits transactions
+	// to the ordering service and reports their commit status on behalf of clients.
+	// TODO: create separate sub-struct for Gateway config.
+
+	// GatewayEnabled is used to enable the gateway service.
+	GatewayEnabled bool
+	// GatewayEndorsementTimeout bounds the time spent collecting the endorsements
+	// of a proposal.
+	GatewayEndorsementTimeout time.Duration
+	// GatewayBroadcastTimeout bounds the time spent submitting a transaction to an
+	// ordering service node.
+	GatewayBroadcastTimeout time.Duration
+	// GatewayDialTimeout bounds the time spent connecting to another peer or to an
+	// ordering service node.
+	GatewayDialTimeout time.Duration
+
 	// ----- Limits -----
 	// Limits is used to configure some internal resource limits.
 	// TODO: create separate sub-struct for Limits config.
@@ -139,6 +157,9 @@ type Config struct {
 
 	// ChaincodePull enables/disables force pulling of the base docker image.
 	ChaincodePull bool
+	// ExternalBuilders are the external chaincode builders, tried in order before
+	// falling back to building chaincode in docker.
+	ExternalBuilders []ExternalBuilder
 
 	// ----- Operations config -----
 	// TODO: create separate sub-struct for Operations config.
@@ -187,6 +208,18 @@ type Config struct {
 	DockerCA string
 }
 
+// ExternalBuilder describes an external chaincode builder, a directory with
+// a bin directory holding the detect, build, release and run executables.
+type ExternalBuilder struct {
+	// EnvironmentWhitelist lists the environment variables of the peer
+	// that are passed to the builder.
+	EnvironmentWhitelist []string `yaml:"environmentWhitelist"`
+	// Name is the name of the builder, used in logs.
+	Name string `yaml:"name"`
+	// Path is the directory of the builder.
+	Path string `yaml:"path"`
+}
+
 // GlobalConfig obtains a set of configuration from viper, build and returns
 // the config struct.
 func GlobalConfig() (*Config, error) {
@@ -224,6 +257,19 @@ func (c *Config) load() error {
 	c.DiscoveryAuthCacheEnabled = viper.GetBool("peer.discovery.authCacheEnabled")
 	c.DiscoveryAuthCacheMaxSize = viper.GetInt("peer.discovery.authCacheMaxSize")
 	c.DiscoveryAuthCachePurgeRetentionRatio = viper.GetFloat64("peer.discovery.authCachePurgeRetentionRatio")
+	c.GatewayEnabled = viper.GetBool("peer.gateway.enabled")
+	c.GatewayEndorsementTimeout = viper.GetDuration("peer.gateway.endorsementTimeout")
+	if c.GatewayEndorsementTimeout <= 0 {
+		c.GatewayEndorsementTimeout = 30 * time.Second
+	}
+	c.GatewayBroadcastTimeout = viper.GetDuration("peer.gateway.broadcastTimeout")
+	if c.GatewayBroadcastTimeout <= 0 {
+		c.GatewayBroadcastTimeout = 30 * time.Second
+	}
+	c.GatewayDialTimeout = viper.GetDuration("peer.gateway.dialTimeout")
+	if c.GatewayDialTimeout <= 0 {
+		c.GatewayDialTimeout = 2 * time.Minute
+	}
 	c.ChaincodeListenAddress = viper.GetString("peer.chaincodeListenAddress")
 	c.ChaincodeAddress = viper.GetString("peer.chaincodeAddress")
 
@@ -250,6 +296,14 @@ func (c *Config) load() error {
 	}
 
 	c.ChaincodePull = viper.GetBool("chaincode.pull")
+	if err := viper.UnmarshalKey("chaincode.externalBuilders", &c.ExternalBuilders); err != nil {
+		return errors.Wrap(err, "could not unmarshal chaincode.externalBuilders")
+	}
+	for _, builder := range c.ExternalBuilders {
+		if builder.Path == "" {
+			return errors.New("invalid external builder configuration, path attribute missing in one or more builders")
+		}
+	}
 
 	c.OperationsListenAddress = viper.GetString("operations.listenAddress")
 	c.OperationsTLSEnabled = viper.GetBool("operations.tls.enabled")
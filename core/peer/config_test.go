@@ -246,6 +246,9 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.discovery.authCacheEnabled", true)
 	viper.Set("peer.discovery.authCacheMaxSize", 1000)
 	viper.Set("peer.discovery.authCachePurgeRetentionRatio", 0.75)
+	viper.Set("peer.gateway.enabled", true)
+	viper.Set("peer.gateway.endorsementTimeout", "10s")
+	viper.Set("peer.gateway.broadcastTimeout", "20s")
 	viper.Set("peer.chaincodeListenAddress", "0.0.0.0:7052")
 	viper.Set("peer.chaincodeAddress", "0.0.0.0:7052")
 	viper.Set("peer.validatorPoolSize", 1)
@@ -269,6 +272,16 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("metrics.statsd.prefix", "testPrefix")
 
 	viper.Set("chaincode.pull", false)
+	viper.Set("chaincode.externalBuilders", []interface{}{
+		map[string]interface{}{
+			"path": "relative/plugin_dir",
+		},
+		map[string]interface{}{
+			"name":                 "builder",
+			"path":                 "/absolute/plugin_dir",
+			"environmentWhitelist": []string{"GOPROXY"},
+		},
+	})
 
 	coreConfig, err := GlobalConfig()
 	assert.NoError(t, err)
@@ -289,6 +302,10 @@ func TestGlobalConfig(t *testing.T) {
 		DiscoveryAuthCacheEnabled:             true,
 		DiscoveryAuthCacheMaxSize:             1000,
 		DiscoveryAuthCachePurgeRetentionRatio: 0.75,
+		GatewayEnabled:                        true,
+		GatewayEndorsementTimeout:             10 * time.Second,
+		GatewayBroadcastTimeout:               20 * time.Second,
+		GatewayDialTimeout:                    2 * time.Minute,
 		ChaincodeListenAddress:                "0.0.0.0:7052",
 		ChaincodeAddress:                      "0.0.0.0:7052",
 		ValidatorPoolSize:                     1,
@@ -300,6 +317,16 @@ func TestGlobalConfig(t *testing.T) {
 		VMNetworkMode:        "TestingHost",
 
 		ChaincodePull: false,
+		ExternalBuilders: []ExternalBuilder{
+			{
+				Path: "relative/plugin_dir",
+			},
+			{
+				Name:                 "builder",
+				Path:                 "/absolute/plugin_dir",
+				EnvironmentWhitelist: []string{"GOPROXY"},
+			},
+		},
 
 		OperationsListenAddress:         "127.0.0.1:9443",
 		OperationsTLSEnabled:            false,
@@ -318,6 +345,19 @@ func TestGlobalConfig(t *testing.T) {
 	assert.Equal(t, coreConfig, expectedConfig)
 }
 
+func TestGlobalConfigExternalBuilderMissingPath(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("chaincode.externalBuilders", []interface{}{
+		map[string]interface{}{
+			"name": "builder",
+		},
+	})
+
+	_, err := GlobalConfig()
+	assert.EqualError(t, err, "invalid external builder configuration, path attribute missing in one or more builders")
+}
+
 func TestGlobalConfigDefault(t *testing.T) {
 	defer viper.Reset()
 	viper.Set("peer.address", "localhost:8080")
@@ -331,6 +371,9 @@ func TestGlobalConfigDefault(t *testing.T) {
 		ValidatorPoolSize:             runtime.NumCPU(),
 		VMNetworkMode:                 "host",
 		DeliverClientKeepaliveOptions: comm.DefaultKeepaliveOptions,
+		GatewayEndorsementTimeout:     30 * time.Second,
+		GatewayBroadcastTimeout:       30 * time.Second,
+		GatewayDialTimeout:            2 * time.Minute,
 	}
 
 	assert.Equal(t, expectedConfig, coreConfig)
@@ -12,8 +12,11 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -25,10 +28,24 @@ var logger = flogging.MustGetLogger("common.deliverevents")
 // given resource name
 type PolicyCheckerProvider func(resourceName string) deliver.PolicyCheckerFunc
 
+// CollectionPolicyChecker checks whether an identity is a member of a collection
+// as the collection was configured when a given block was committed
+type CollectionPolicyChecker interface {
+	CheckCollectionPolicy(blockNum uint64, ccName string, collName string, cfgHistoryRetriever ledger.ConfigHistoryRetriever, deserializer msp.IdentityDeserializer, signedData *protoutil.SignedData) (bool, error)
+}
+
+// Chain adds access to the ledger and the MSPs of a channel to deliver.Chain
+type Chain interface {
+	deliver.Chain
+	Ledger() ledger.PeerLedger
+	MSPManager() msp.MSPManager
+}
+
 // Server holds the dependencies necessary to create a deliver server
 type DeliverServer struct {
-	DeliverHandler        *deliver.Handler
-	PolicyCheckerProvider PolicyCheckerProvider
+	DeliverHandler          *deliver.Handler
+	PolicyCheckerProvider   PolicyCheckerProvider
+	CollectionPolicyChecker CollectionPolicyChecker
 }
 
 // blockResponseSender structure used to send block responses
@@ -45,7 +62,7 @@ func (brs *blockResponseSender) SendStatusResponse(status common.Status) error {
 }
 
 // SendBlockResponse generates deliver response with block message
-func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
+func (brs *blockResponseSender) SendBlockResponse(block *common.Block, _ string, _ deliver.Chain, _ *protoutil.SignedData) error {
 	response := &peer.DeliverResponse{
 		Type: &peer.DeliverResponse_Block{Block: block},
 	}
@@ -72,7 +89,7 @@ func (fbrs *filteredBlockResponseSender) IsFiltered() bool {
 }
 
 // SendBlockResponse generates deliver response with block message
-func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
+func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block, channelID string, _ deliver.Chain, _ *protoutil.SignedData) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
 	filteredBlock, err := b.toFilteredBlock()
@@ -80,12 +97,109 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
 	}
+	// blocks whose transactions were all filtered out still identify their channel
+	if filteredBlock.ChannelId == "" {
+		filteredBlock.ChannelId = channelID
+	}
 	response := &peer.DeliverResponse{
 		Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
 	}
 	return fbrs.Send(response)
 }
 
+// blockAndPrivateDataResponseSender structure used to send block responses
+// along with the private data the requester is eligible to receive
+type blockAndPrivateDataResponseSender struct {
+	peer.Deliver_DeliverWithPrivateDataServer
+	CollectionPolicyChecker
+}
+
+// SendStatusResponse generates status reply proto message
+func (bprs *blockAndPrivateDataResponseSender) SendStatusResponse(status common.Status) error {
+	reply := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return bprs.Send(reply)
+}
+
+// SendBlockResponse generates deliver response with the block and the
+// private data of its transactions the requester is a member of
+func (bprs *blockAndPrivateDataResponseSender) SendBlockResponse(block *common.Block, channelID string, chain deliver.Chain, signedData *protoutil.SignedData) error {
+	blockAndPvtData := &peer.BlockAndPrivateData{
+		Block: block,
+	}
+	// blocks delivered without their data carry no private data either
+	if block.Data != nil {
+		pvtData, err := bprs.privateData(block, channelID, chain, signedData)
+		if err != nil {
+			return err
+		}
+		blockAndPvtData.PrivateDataMap = pvtData
+	}
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_BlockAndPrivateData{BlockAndPrivateData: blockAndPvtData},
+	}
+	return bprs.Send(response)
+}
+
+// privateData returns the private write sets of the transactions of the block,
+// restricted to the collections the requester is a member of
+func (bprs *blockAndPrivateDataResponseSender) privateData(block *common.Block, channelID string, chain deliver.Chain, signedData *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	channel, ok := chain.(Chain)
+	if !ok {
+		return nil, errors.Errorf("channel %s does not provide access to private data", channelID)
+	}
+
+	pvtData, err := channel.Ledger().GetPvtDataByNum(block.Header.Number, nil)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed retrieving the private data of block %d of channel %s", block.Header.Number, channelID)
+	}
+	configHistoryRetriever, err := channel.Ledger().GetConfigHistoryRetriever()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed retrieving the config history retriever of channel %s", channelID)
+	}
+
+	pvtDataMap := map[uint64]*rwset.TxPvtReadWriteSet{}
+	for _, txPvtData := range pvtData {
+		if txPvtData.WriteSet == nil {
+			continue
+		}
+		// the envelopes of the transactions that do not match the filter
+		// of the request are removed, and so is their private data
+		if txPvtData.SeqInBlock >= uint64(len(block.Data.Data)) || block.Data.Data[txPvtData.SeqInBlock] == nil {
+			continue
+		}
+		var nsPvtRwsets []*rwset.NsPvtReadWriteSet
+		for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+			var collPvtRwsets []*rwset.CollectionPvtReadWriteSet
+			for _, coll := range ns.CollectionPvtRwset {
+				eligible, err := bprs.CheckCollectionPolicy(block.Header.Number, ns.Namespace, coll.CollectionName, configHistoryRetriever, channel.MSPManager(), signedData)
+				if err != nil {
+					return nil, err
+				}
+				if !eligible {
+					logger.Debugf("Requester is not a member of collection %s of namespace %s, skipping its private data in block %d", coll.CollectionName, ns.Namespace, block.Header.Number)
+					continue
+				}
+				collPvtRwsets = append(collPvtRwsets, coll)
+			}
+			if len(collPvtRwsets) > 0 {
+				nsPvtRwsets = append(nsPvtRwsets, &rwset.NsPvtReadWriteSet{
+					Namespace:          ns.Namespace,
+					CollectionPvtRwset: collPvtRwsets,
+				})
+			}
+		}
+		if len(nsPvtRwsets) > 0 {
+			pvtDataMap[txPvtData.SeqInBlock] = &rwset.TxPvtReadWriteSet{
+				DataModel:  txPvtData.WriteSet.DataModel,
+				NsPvtRwset: nsPvtRwsets,
+			}
+		}
+	}
+	return pvtDataMap, nil
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -123,6 +237,23 @@ func (s *DeliverServer) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	return s.DeliverHandler.Handle(srv.Context(), deliverServer)
 }
 
+// DeliverWithPrivateData sends a stream of blocks and the private data the
+// client is a member of to a client after commitment
+func (s *DeliverServer) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPrivateDataServer) error {
+	logger.Debugf("Starting new DeliverWithPrivateData handler")
+	defer dumpStacktraceOnPanic()
+	// getting policy checker based on resources.Event_Block resource name
+	deliverServer := &deliver.Server{
+		PolicyChecker: s.PolicyCheckerProvider(resources.Event_Block),
+		Receiver:      srv,
+		ResponseSender: &blockAndPrivateDataResponseSender{
+			Deliver_DeliverWithPrivateDataServer: srv,
+			CollectionPolicyChecker:              s.CollectionPolicyChecker,
+		},
+	}
+	return s.DeliverHandler.Handle(srv.Context(), deliverServer)
+}
+
 func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
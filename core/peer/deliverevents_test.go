@@ -19,10 +19,14 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/metadata"
@@ -94,6 +98,45 @@ func (*mockChainSupport) Errored() <-chan struct{} {
 	return make(chan struct{})
 }
 
+func (m *mockChainSupport) Ledger() ledger.PeerLedger {
+	return m.Called().Get(0).(ledger.PeerLedger)
+}
+
+func (m *mockChainSupport) MSPManager() msp.MSPManager {
+	return m.Called().Get(0).(msp.MSPManager)
+}
+
+// mockLedger mock implementation of the private data
+// retrieval of the ledger.PeerLedger interface
+type mockLedger struct {
+	ledger.PeerLedger
+	mock.Mock
+}
+
+func (m *mockLedger) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	args := m.Called(blockNum, filter)
+	return args.Get(0).([]*ledger.TxPvtData), args.Error(1)
+}
+
+func (m *mockLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
+	args := m.Called()
+	return args.Get(0).(ledger.ConfigHistoryRetriever), args.Error(1)
+}
+
+type mockConfigHistoryRetriever struct {
+	ledger.ConfigHistoryRetriever
+}
+
+// mockCollectionPolicyChecker mock implementation of the CollectionPolicyChecker interface
+type mockCollectionPolicyChecker struct {
+	mock.Mock
+}
+
+func (m *mockCollectionPolicyChecker) CheckCollectionPolicy(blockNum uint64, ccName string, collName string, cfgHistoryRetriever ledger.ConfigHistoryRetriever, deserializer msp.IdentityDeserializer, signedData *protoutil.SignedData) (bool, error) {
+	args := m.Called(blockNum, ccName, collName, cfgHistoryRetriever, deserializer, signedData)
+	return args.Bool(0), args.Error(1)
+}
+
 // mockChainManager mock implementation of the ChainManager interface
 type mockChainManager struct {
 	mock.Mock
@@ -391,6 +434,138 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 		})
 	}
 }
+func TestEventsServer_DeliverWithPrivateData(t *testing.T) {
+	seekPayload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: "testChainID",
+				Timestamp: util.CreateUtcTimestamp(),
+			}),
+			SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+		},
+		Data: protoutil.MarshalOrPanic(&orderer.SeekInfo{
+			Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+			Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		}),
+	}
+	envelope := &common.Envelope{
+		Payload:   protoutil.MarshalOrPanic(seekPayload),
+		Signature: []byte("signature"),
+	}
+	signedData := &protoutil.SignedData{
+		Data:      envelope.Payload,
+		Identity:  []byte("creator"),
+		Signature: []byte("signature"),
+	}
+
+	collPvtRwset := func(name string) *rwset.CollectionPvtReadWriteSet {
+		return &rwset.CollectionPvtReadWriteSet{CollectionName: name, Rwset: []byte(name + "-rwset")}
+	}
+	pvtData := []*ledger.TxPvtData{
+		{
+			SeqInBlock: 0,
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				DataModel: rwset.TxReadWriteSet_KV,
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("coll1"), collPvtRwset("coll2")}},
+					{Namespace: "othercc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("coll2")}},
+				},
+			},
+		},
+		{
+			SeqInBlock: 1,
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				DataModel: rwset.TxReadWriteSet_KV,
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("coll2")}},
+				},
+			},
+		},
+		{
+			SeqInBlock: 2,
+		},
+	}
+
+	block, err := createTestBlock([]*common.Envelope{{Payload: []byte{}}, {Payload: []byte{}}, {Payload: []byte{}}})
+	assert.NoError(t, err)
+
+	setup := func(pvtDataErr error) (*mockChainManager, *mockCollectionPolicyChecker, *mockDeliverServer, *[]*peer.DeliverResponse) {
+		iter := &mockIterator{}
+		iter.On("Next").Return(block, common.Status_SUCCESS)
+		reader := &mockReader{}
+		reader.On("Iterator", mock.Anything).Return(iter, uint64(0))
+		reader.On("Height").Return(uint64(1))
+
+		peerLedger := &mockLedger{}
+		peerLedger.On("GetPvtDataByNum", uint64(0), ledger.PvtNsCollFilter(nil)).Return(pvtData, pvtDataErr)
+		peerLedger.On("GetConfigHistoryRetriever").Return(&mockConfigHistoryRetriever{}, nil)
+
+		chain := &mockChainSupport{}
+		chain.On("Sequence").Return(uint64(0))
+		chain.On("Reader").Return(reader)
+		chain.On("Ledger").Return(peerLedger)
+		chain.On("MSPManager").Return(msp.NewMSPManager())
+		chainManager := &mockChainManager{}
+		chainManager.On("GetChain", "testChainID").Return(chain)
+
+		collectionPolicyChecker := &mockCollectionPolicyChecker{}
+		collectionPolicyChecker.On("CheckCollectionPolicy", uint64(0), mock.Anything, "coll1", mock.Anything, mock.Anything, signedData).Return(true, nil)
+		collectionPolicyChecker.On("CheckCollectionPolicy", uint64(0), mock.Anything, "coll2", mock.Anything, mock.Anything, signedData).Return(false, nil)
+
+		var responses []*peer.DeliverResponse
+		deliverServer := &mockDeliverServer{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), &peer2.Peer{}))
+		deliverServer.On("Recv").Return(envelope, nil).Once()
+		deliverServer.On("Recv").Return(nil, io.EOF)
+		deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			responses = append(responses, args.Get(0).(*peer.DeliverResponse))
+		}).Return(nil)
+
+		return chainManager, collectionPolicyChecker, deliverServer, &responses
+	}
+
+	t.Run("Sends the private data of the collections the requester is a member of", func(t *testing.T) {
+		chainManager, collectionPolicyChecker, deliverServer, responses := setup(nil)
+		server := &DeliverServer{
+			DeliverHandler:          deliver.NewHandler(chainManager, time.Second, false, deliver.NewMetrics(&disabled.Provider{})),
+			PolicyCheckerProvider:   defaultPolicyCheckerProvider,
+			CollectionPolicyChecker: collectionPolicyChecker,
+		}
+
+		err := server.DeliverWithPrivateData(deliverServer)
+		assert.NoError(t, err)
+
+		assert.Len(t, *responses, 2)
+		blockAndPvtData := (*responses)[0].GetBlockAndPrivateData()
+		assert.NotNil(t, blockAndPvtData)
+		assert.True(t, proto.Equal(block, blockAndPvtData.Block))
+		assert.Equal(t, map[uint64]*rwset.TxPvtReadWriteSet{
+			0: {
+				DataModel: rwset.TxReadWriteSet_KV,
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("coll1")}},
+				},
+			},
+		}, blockAndPvtData.PrivateDataMap)
+		assert.Equal(t, common.Status_SUCCESS, (*responses)[1].GetStatus())
+		collectionPolicyChecker.AssertNumberOfCalls(t, "CheckCollectionPolicy", 4)
+	})
+
+	t.Run("Fails when the private data cannot be retrieved", func(t *testing.T) {
+		chainManager, collectionPolicyChecker, deliverServer, responses := setup(errors.New("ledger closed"))
+		server := &DeliverServer{
+			DeliverHandler:          deliver.NewHandler(chainManager, time.Second, false, deliver.NewMetrics(&disabled.Provider{})),
+			PolicyCheckerProvider:   defaultPolicyCheckerProvider,
+			CollectionPolicyChecker: collectionPolicyChecker,
+		}
+
+		err := server.DeliverWithPrivateData(deliverServer)
+		assert.EqualError(t, err, "failed retrieving the private data of block 0 of channel testChainID: ledger closed")
+		assert.Empty(t, *responses)
+	})
+}
+
 func createDefaultSupportMamangerMock(config testConfig, chaincodeActionPayload *peer.ChaincodeActionPayload) *mockChainManager {
 	chainManager := &mockChainManager{}
 	iter := &mockIterator{}
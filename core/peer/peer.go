@@ -233,6 +233,11 @@ func retrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, e
 	return retrievePersistedConf(qe, channelConfigKey)
 }
 
+func ledgerHasPersistedChannelConfig(ledger ledger.PeerLedger) bool {
+	chanConf, err := retrievePersistedChannelConfig(ledger)
+	return err == nil && chanConf != nil
+}
+
 // createChannel creates a new channel object and insert it into the channels slice.
 func (p *Peer) createChannel(
 	cid string,
@@ -492,6 +497,12 @@ func (p *Peer) Initialize(
 			continue
 		}
 		cb, err := ConfigBlockFromLedger(ledger)
+		if err != nil && ledgerHasPersistedChannelConfig(ledger) {
+			// A ledger bootstrapped from a snapshot does not contain the blocks committed before
+			// the snapshot, the channel config is loaded from the statedb instead
+			peerLogger.Infof("Config block not available on ledger %s, using the channel config persisted in the statedb", cid)
+			cb, err = nil, nil
+		}
 		if err != nil {
 			peerLogger.Errorf("Failed to find config block on ledger %s(%s)", cid, err)
 			peerLogger.Debugf("Error while looking for config block on ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
@@ -56,7 +56,7 @@ func NewTestPeer(t *testing.T) (*Peer, func()) {
 
 	// Initialize gossip service
 	signer := mgmt.GetLocalSigningIdentityOrPanic()
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(), nil, peergossip.PolicyQuorum)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	defaultSecureDialOpts := func() []grpc.DialOption { return []grpc.DialOption{grpc.WithInsecure()} }
 	defaultDeliverClientDialOpts := []grpc.DialOption{grpc.WithBlock()}
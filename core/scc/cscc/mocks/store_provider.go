@@ -12,6 +12,17 @@ type StoreProvider struct {
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
 	}
+	DropStub        func(string) error
+	dropMutex       sync.RWMutex
+	dropArgsForCall []struct {
+		arg1 string
+	}
+	dropReturns struct {
+		result1 error
+	}
+	dropReturnsOnCall map[int]struct {
+		result1 error
+	}
 	OpenStoreStub        func(string) (transientstore.Store, error)
 	openStoreMutex       sync.RWMutex
 	openStoreArgsForCall []struct {
@@ -52,6 +63,66 @@ func (fake *StoreProvider) CloseCalls(stub func()) {
 	fake.CloseStub = stub
 }
 
+func (fake *StoreProvider) Drop(arg1 string) error {
+	fake.dropMutex.Lock()
+	ret, specificReturn := fake.dropReturnsOnCall[len(fake.dropArgsForCall)]
+	fake.dropArgsForCall = append(fake.dropArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Drop", []interface{}{arg1})
+	fake.dropMutex.Unlock()
+	if fake.DropStub != nil {
+		return fake.DropStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.dropReturns
+	return fakeReturns.result1
+}
+
+func (fake *StoreProvider) DropCallCount() int {
+	fake.dropMutex.RLock()
+	defer fake.dropMutex.RUnlock()
+	return len(fake.dropArgsForCall)
+}
+
+func (fake *StoreProvider) DropCalls(stub func(string) error) {
+	fake.dropMutex.Lock()
+	defer fake.dropMutex.Unlock()
+	fake.DropStub = stub
+}
+
+func (fake *StoreProvider) DropArgsForCall(i int) string {
+	fake.dropMutex.RLock()
+	defer fake.dropMutex.RUnlock()
+	argsForCall := fake.dropArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *StoreProvider) DropReturns(result1 error) {
+	fake.dropMutex.Lock()
+	defer fake.dropMutex.Unlock()
+	fake.DropStub = nil
+	fake.dropReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *StoreProvider) DropReturnsOnCall(i int, result1 error) {
+	fake.dropMutex.Lock()
+	defer fake.dropMutex.Unlock()
+	fake.DropStub = nil
+	if fake.dropReturnsOnCall == nil {
+		fake.dropReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.dropReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *StoreProvider) OpenStore(arg1 string) (transientstore.Store, error) {
 	fake.openStoreMutex.Lock()
 	ret, specificReturn := fake.openStoreReturnsOnCall[len(fake.openStoreArgsForCall)]
@@ -120,6 +191,8 @@ func (fake *StoreProvider) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
+	fake.dropMutex.RLock()
+	defer fake.dropMutex.RUnlock()
 	fake.openStoreMutex.RLock()
 	defer fake.openStoreMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
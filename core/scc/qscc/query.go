@@ -45,6 +45,9 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetTransactionsByChaincodeNamespace returns a page of transactions
+// - GetTransactionsByCreator returns a page of transactions
+// - GetPurgeRecords returns the records of the purged private data keys
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 	ledgers     LedgerGetter
@@ -59,6 +62,21 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetTransactionsByChaincodeNamespace string = "GetTransactionsByChaincodeNamespace"
+	GetTransactionsByCreator            string = "GetTransactionsByCreator"
+	GetPurgeRecords                     string = "GetPurgeRecords"
+)
+
+const (
+	// defaultPageSize is the number of transactions returned when the page size is not supplied
+	defaultPageSize = 100
+	// maxPageSize is the largest page size accepted, it bounds the number of transactions
+	// a single query reads from the ledger and holds in memory
+	maxPageSize = 1000
+	// maxPurgeRecordsBlocks is the largest number of blocks whose
+	// purge records are returned by a single query
+	maxPurgeRecordsBlocks = 1000
 )
 
 // Init is called once per chain when the chain is created.
@@ -77,6 +95,10 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetTransactionsByChaincodeNamespace: Return a page of the transactions that touched the namespace in args[2]
+// # GetTransactionsByCreator: Return a page of the transactions created by the serialized identity in args[2]
+// # GetPurgeRecords: Return the private data keys purged by the blocks from the number in args[2] to the number in args[3] (both inclusive)
+// The functions that return a page take the optional bookmark in args[3] and the optional page size in args[4]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -121,6 +143,12 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetTransactionsByChaincodeNamespace:
+		return getTransactionsByChaincodeNamespace(targetLedger, args[2:])
+	case GetTransactionsByCreator:
+		return getTransactionsByCreator(targetLedger, args[2:])
+	case GetPurgeRecords:
+		return getPurgeRecords(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -220,6 +248,112 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getTransactionsByChaincodeNamespace(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	namespace := string(args[0])
+	if namespace == "" {
+		return shim.Error("Chaincode namespace must not be empty.")
+	}
+	bookmark, pageSize, err := pagingArgs(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txs, nextBookmark, err := vledger.GetTransactionsByChaincodeNamespace(namespace, bookmark, pageSize)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for chaincode namespace %s, error %s", namespace, err))
+	}
+	return transactionsPageResponse(txs, nextBookmark)
+}
+
+func getTransactionsByCreator(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	creator := args[0]
+	if len(creator) == 0 {
+		return shim.Error("Creator must not be empty.")
+	}
+	bookmark, pageSize, err := pagingArgs(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txs, nextBookmark, err := vledger.GetTransactionsByCreator(creator, bookmark, pageSize)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for creator, error %s", err))
+	}
+	return transactionsPageResponse(txs, nextBookmark)
+}
+
+func getPurgeRecords(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 {
+		return shim.Error("Start and end block numbers must be supplied.")
+	}
+	startBlockNum, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+	}
+	endBlockNum, err := strconv.ParseUint(string(args[1]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+	}
+	if endBlockNum < startBlockNum {
+		return shim.Error(fmt.Sprintf("Invalid block range, end block %d is lower than start block %d", endBlockNum, startBlockNum))
+	}
+	if endBlockNum-startBlockNum >= maxPurgeRecordsBlocks {
+		return shim.Error(fmt.Sprintf("Invalid block range, it should not span more than %d blocks", maxPurgeRecordsBlocks))
+	}
+	purgedKeys, err := vledger.GetPurgeRecords(startBlockNum, endBlockNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get purge records of blocks %d to %d, error %s", startBlockNum, endBlockNum, err))
+	}
+
+	records := &pb.PurgeRecords{}
+	for _, purgedKey := range purgedKeys {
+		records.Records = append(records.Records, &pb.PurgeRecord{
+			BlockNum:   purgedKey.BlockNum,
+			TxNum:      purgedKey.TxNum,
+			Namespace:  purgedKey.Namespace,
+			Collection: purgedKey.Collection,
+			KeyHash:    purgedKey.KeyHash,
+		})
+	}
+	bytes, err := protoutil.Marshal(records)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+// pagingArgs returns the optional bookmark and page size arguments, the page
+// size defaults to defaultPageSize and is rejected above maxPageSize
+func pagingArgs(args [][]byte) (string, int, error) {
+	bookmark := ""
+	if len(args) > 0 {
+		bookmark = string(args[0])
+	}
+	pageSize := defaultPageSize
+	if len(args) > 1 && len(args[1]) > 0 {
+		size, err := strconv.ParseInt(string(args[1]), 10, 32)
+		if err != nil || size <= 0 {
+			return "", 0, fmt.Errorf("Invalid page size %s, it should be a positive number", string(args[1]))
+		}
+		if size > maxPageSize {
+			return "", 0, fmt.Errorf("Invalid page size %s, it should not exceed %d", string(args[1]), maxPageSize)
+		}
+		pageSize = int(size)
+	}
+	return bookmark, pageSize, nil
+}
+
+func transactionsPageResponse(txs []*pb.ProcessedTransaction, bookmark string) pb.Response {
+	bytes, err := protoutil.Marshal(&pb.ProcessedTransactionsPage{
+		Transactions: txs,
+		Bookmark:     bookmark,
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -10,8 +10,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -31,10 +33,14 @@ import (
 )
 
 func setupTestLedger(chainid string, path string) (*shimtest.MockStub, *peer.Peer, func(), error) {
+	return setupTestLedgerWithInitializer(chainid, path, nil)
+}
+
+func setupTestLedgerWithInitializer(chainid string, path string, initializer *ledgermgmt.Initializer) (*shimtest.MockStub, *peer.Peer, func(), error) {
 	mockAclProvider.Reset()
 
 	viper.Set("peer.fileSystemPath", path)
-	cleanup, err := ledgermgmt.InitializeTestEnvWithInitializer(nil)
+	cleanup, err := ledgermgmt.InitializeTestEnvWithInitializer(initializer)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -336,6 +342,165 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetTransactionsByChaincodeNamespaceAndCreator(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedgerWithInitializer(chainid, path, &ledgermgmt.Initializer{
+		Config: &ledger2.Config{
+			RootFSPath:       filepath.Join(path, "ledgersData"),
+			StateDBConfig:    &ledger2.StateDBConfig{},
+			BlockIndexConfig: &ledger2.BlockIndexConfig{ChaincodeNamespace: true, Creator: true},
+		},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+	env, err := protoutil.GetEnvelopeFromBlock(block1.Data.Data[0])
+	require.NoError(t, err)
+	shdr, err := protoutil.GetSignatureHeader(protoutil.UnmarshalPayloadOrPanic(env.Payload).Header.SignatureHeader)
+	require.NoError(t, err)
+
+	invoke := func(fname, resource string, args ...[]byte) *peer2.ProcessedTransactionsPage {
+		prop := resetProvider(resource, chainid, &peer2.SignedProposal{}, nil)
+		res := stub.MockInvokeWithSignedProposal("1", append([][]byte{[]byte(fname), []byte(chainid)}, args...), prop)
+		require.Equal(t, int32(shim.OK), res.Status, "%s failed with err: %s", fname, res.Message)
+		page := &peer2.ProcessedTransactionsPage{}
+		require.NoError(t, proto.Unmarshal(res.Payload, page))
+		return page
+	}
+
+	// both transactions of the block invoke the chaincode 'foo' and each one writes to its own namespace
+	page := invoke(GetTransactionsByChaincodeNamespace, resources.Qscc_GetTransactionsByChaincodeNamespace, []byte("ns1"))
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, "", page.Bookmark)
+
+	page = invoke(GetTransactionsByChaincodeNamespace, resources.Qscc_GetTransactionsByChaincodeNamespace, []byte("foo"), nil, []byte("1"))
+	assert.Len(t, page.Transactions, 1)
+	assert.NotEqual(t, "", page.Bookmark)
+	page = invoke(GetTransactionsByChaincodeNamespace, resources.Qscc_GetTransactionsByChaincodeNamespace, []byte("foo"), []byte(page.Bookmark), []byte("1"))
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, "", page.Bookmark)
+
+	page = invoke(GetTransactionsByCreator, resources.Qscc_GetTransactionsByCreator, shdr.Creator)
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, int32(peer2.TxValidationCode_VALID), page.Transactions[0].ValidationCode)
+
+	args := [][]byte{[]byte(GetTransactionsByCreator), []byte(chainid), shdr.Creator, nil, []byte("0")}
+	prop := resetProvider(resources.Qscc_GetTransactionsByCreator, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByCreator should have failed with an invalid page size")
+	assert.Equal(t, "Invalid page size 0, it should be a positive number", res.Message)
+
+	args = [][]byte{[]byte(GetTransactionsByCreator), []byte(chainid), shdr.Creator, nil, []byte("1001")}
+	prop = resetProvider(resources.Qscc_GetTransactionsByCreator, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByCreator should have failed with a page size above the maximum")
+	assert.Equal(t, "Invalid page size 1001, it should not exceed 1000", res.Message)
+
+	page = invoke(GetTransactionsByCreator, resources.Qscc_GetTransactionsByCreator, shdr.Creator, nil, []byte("1000"))
+	assert.Len(t, page.Transactions, 2)
+
+	args = [][]byte{[]byte(GetTransactionsByChaincodeNamespace), []byte(chainid), []byte("")}
+	prop = resetProvider(resources.Qscc_GetTransactionsByChaincodeNamespace, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByChaincodeNamespace should have failed with an empty namespace")
+}
+
+func TestQueryGetTransactionsByChaincodeNamespaceNotIndexed(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, _, cleanup, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	defer cleanup()
+
+	args := [][]byte{[]byte(GetTransactionsByChaincodeNamespace), []byte(chainid), []byte("ns1")}
+	prop := resetProvider(resources.Qscc_GetTransactionsByChaincodeNamespace, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Failed to get transactions for chaincode namespace ns1, error attribute not indexed", res.Message)
+}
+
+type purgeRecordsLedger struct {
+	ledger2.PeerLedger
+	purgedKeys []*ledger2.PurgedPvtKey
+	err        error
+
+	startBlockNum, endBlockNum uint64
+}
+
+func (l *purgeRecordsLedger) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger2.PurgedPvtKey, error) {
+	l.startBlockNum, l.endBlockNum = startBlockNum, endBlockNum
+	return l.purgedKeys, l.err
+}
+
+type purgeRecordsLedgerGetter struct {
+	ledger *purgeRecordsLedger
+}
+
+func (g *purgeRecordsLedgerGetter) GetLedger(cid string) ledger2.PeerLedger {
+	return g.ledger
+}
+
+func TestQueryGetPurgeRecords(t *testing.T) {
+	chainid := "mytestchainid11"
+	purgeLedger := &purgeRecordsLedger{
+		purgedKeys: []*ledger2.PurgedPvtKey{
+			{BlockNum: 3, TxNum: 1, Namespace: "ns1", Collection: "coll1", KeyHash: []byte("key1-hash")},
+			{BlockNum: 5, TxNum: 0, Namespace: "ns2", Collection: "coll2", KeyHash: []byte("key2-hash")},
+		},
+	}
+	stub := shimtest.NewMockStub("LedgerQuerier", New(mockAclProvider, &purgeRecordsLedgerGetter{ledger: purgeLedger}))
+
+	invoke := func(args ...string) peer2.Response {
+		prop := resetProvider(resources.Qscc_GetPurgeRecords, chainid, &peer2.SignedProposal{}, nil)
+		invokeArgs := [][]byte{[]byte(GetPurgeRecords), []byte(chainid)}
+		for _, arg := range args {
+			invokeArgs = append(invokeArgs, []byte(arg))
+		}
+		return stub.MockInvokeWithSignedProposal("1", invokeArgs, prop)
+	}
+
+	res := invoke("2", "1001")
+	require.Equal(t, int32(shim.OK), res.Status, "GetPurgeRecords failed with err: %s", res.Message)
+	assert.Equal(t, uint64(2), purgeLedger.startBlockNum)
+	assert.Equal(t, uint64(1001), purgeLedger.endBlockNum)
+	records := &peer2.PurgeRecords{}
+	require.NoError(t, proto.Unmarshal(res.Payload, records))
+	assert.True(t, proto.Equal(&peer2.PurgeRecords{
+		Records: []*peer2.PurgeRecord{
+			{BlockNum: 3, TxNum: 1, Namespace: "ns1", Collection: "coll1", KeyHash: []byte("key1-hash")},
+			{BlockNum: 5, TxNum: 0, Namespace: "ns2", Collection: "coll2", KeyHash: []byte("key2-hash")},
+		},
+	}, records))
+
+	tests := []struct {
+		name        string
+		args        []string
+		ledgerErr   error
+		expectedErr string
+	}{
+		{name: "missing end block", args: []string{"2"}, expectedErr: "Start and end block numbers must be supplied."},
+		{name: "invalid start block", args: []string{"two", "5"}, expectedErr: `Failed to parse start block number with error strconv.ParseUint: parsing "two": invalid syntax`},
+		{name: "invalid end block", args: []string{"2", "-5"}, expectedErr: `Failed to parse end block number with error strconv.ParseUint: parsing "-5": invalid syntax`},
+		{name: "end before start", args: []string{"5", "2"}, expectedErr: "Invalid block range, end block 2 is lower than start block 5"},
+		{name: "range too large", args: []string{"2", "1002"}, expectedErr: "Invalid block range, it should not span more than 1000 blocks"},
+		{name: "ledger error", args: []string{"2", "5"}, ledgerErr: errors.New("leveldb: closed"), expectedErr: "Failed to get purge records of blocks 2 to 5, error leveldb: closed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purgeLedger.err = tt.ledgerErr
+			res := invoke(tt.args...)
+			assert.Equal(t, int32(shim.ERROR), res.Status)
+			assert.Equal(t, tt.expectedErr, res.Message)
+		})
+	}
+}
+
 func addBlockForTesting(t *testing.T, chainid string, p *peer.Peer) *common.Block {
 	ledger := p.GetLedger(chainid)
 	defer ledger.Close()
@@ -7,8 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package transientstore
 
 import (
-	"errors"
-
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -16,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -34,6 +33,8 @@ var ErrStoreEmpty = errors.New("Transient store is empty")
 // StoreProvider provides an instance of a TransientStore
 type StoreProvider interface {
 	OpenStore(ledgerID string) (Store, error)
+	// Drop removes all the private write sets of the given ledger. The store of the ledger should not be in use
+	Drop(ledgerID string) error
 	Close()
 }
 
@@ -78,6 +79,9 @@ type Store interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+	// PurgeByPvtKeys removes the purged keys from the private write sets that were received
+	// at a block height not greater than the number of the block that purged the keys
+	PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error
 	// GetMinTransientBlkHt returns the lowest block height remaining in transient store
 	GetMinTransientBlkHt() (uint64, error)
 	Shutdown()
@@ -131,6 +135,12 @@ func (provider *storeProvider) OpenStore(ledgerID string) (Store, error) {
 	return &store{db: dbHandle, ledgerID: ledgerID}, nil
 }
 
+// Drop implements the function in the interface `StoreProvider`
+func (provider *storeProvider) Drop(ledgerID string) error {
+	return errors.WithMessagef(provider.dbProvider.GetDBHandle(ledgerID).DeleteAll(),
+		"error while dropping the transient store of [%s]", ledgerID)
+}
+
 // Close closes the TransientStoreProvider
 func (provider *storeProvider) Close() {
 	provider.dbProvider.Close()
@@ -337,6 +347,79 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return s.db.WriteBatch(dbBatch, true)
 }
 
+// PurgeByPvtKeys removes the purged keys from the private write sets that were received
+// at a block height not greater than the number of the block that purged the keys, as these
+// private write sets may contain the values of the keys prior to the purge. PurgeByPvtKeys()
+// is expected to be called by coordinator after committing a block to ledger.
+func (s *store) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	if len(purgedKeys) == 0 {
+		return nil
+	}
+	logger.Debugf("Purging [%d] private data keys from transient store", len(purgedKeys))
+
+	iter := s.db.GetIterator(createPvtRWSetRangeStartKey(), createPvtRWSetRangeEndKey())
+	defer iter.Release()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for iter.Next() {
+		compositeKeyPvtRWSet := iter.Key()
+		_, blockHeight := splitCompositeKeyOfPvtRWSet(compositeKeyPvtRWSet)
+		var applicableKeys []*ledger.PurgedPvtKey
+		for _, purgedKey := range purgedKeys {
+			if blockHeight <= purgedKey.BlockNum {
+				applicableKeys = append(applicableKeys, purgedKey)
+			}
+		}
+		if len(applicableKeys) == 0 {
+			continue
+		}
+
+		value, removed, err := removePurgedKeysFromValue(iter.Value(), applicableKeys)
+		if err != nil {
+			return err
+		}
+		if removed {
+			// the iterator reuses the key buffer
+			dbBatch.Put(append([]byte{}, compositeKeyPvtRWSet...), value)
+		}
+	}
+	return s.db.WriteBatch(dbBatch, true)
+}
+
+// removePurgedKeysFromValue removes the purged keys from a private write set persisted in
+// either the old proto (TxPvtReadWriteSet) or the new proto (TxPvtReadWriteSetWithConfigInfo)
+func removePurgedKeysFromValue(dbVal []byte, purgedKeys []*ledger.PurgedPvtKey) ([]byte, bool, error) {
+	if dbVal[0] == nilByte {
+		txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+			return nil, false, err
+		}
+		removed, err := removePurgedKeys(txPvtRWSetWithConfig.PvtRwset, purgedKeys)
+		if err != nil || !removed {
+			return nil, false, err
+		}
+		valBytes, err := proto.Marshal(txPvtRWSetWithConfig)
+		if err != nil {
+			return nil, false, err
+		}
+		return append([]byte{nilByte}, valBytes...), true, nil
+	}
+
+	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+	if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+		return nil, false, err
+	}
+	removed, err := removePurgedKeys(txPvtRWSet, purgedKeys)
+	if err != nil || !removed {
+		return nil, false, err
+	}
+	valBytes, err := proto.Marshal(txPvtRWSet)
+	if err != nil {
+		return nil, false, err
+	}
+	return valBytes, true, nil
+}
+
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
 func (s *store) GetMinTransientBlkHt() (uint64, error) {
 	// Current approach performs a range query on purgeIndex with startKey
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 )
@@ -130,6 +131,16 @@ func createTxidRangeEndKey(txid string) []byte {
 	return endKey
 }
 
+// createPvtRWSetRangeStartKey returns a startKey to do a range query on all the private write sets in transient store
+func createPvtRWSetRangeStartKey() []byte {
+	return []byte{prwsetPrefix, compositeKeySep}
+}
+
+// createPvtRWSetRangeEndKey returns a endKey to do a range query on all the private write sets in transient store
+func createPvtRWSetRangeEndKey() []byte {
+	return []byte{prwsetPrefix, byte(0xff)}
+}
+
 // createPurgeIndexByHeightRangeStartKey returns a startKey to do a range query on index stored in transient store
 // using blockHeight
 func createPurgeIndexByHeightRangeStartKey(blockHeight uint64) []byte {
@@ -210,6 +221,27 @@ func trimPvtWSet(pvtWSet *rwset.TxPvtReadWriteSet, filter ledger.PvtNsCollFilter
 	return filteredTxPvtRwSet
 }
 
+// removePurgedKeys removes the writes of the purged keys from the private write set and
+// returns true if the private write set contained any of the purged keys
+func removePurgedKeys(pvtWSet *rwset.TxPvtReadWriteSet, purgedKeys []*ledger.PurgedPvtKey) (bool, error) {
+	removed := false
+	for _, ns := range pvtWSet.GetNsPvtRwset() {
+		for _, coll := range ns.CollectionPvtRwset {
+			for _, purgedKey := range purgedKeys {
+				if purgedKey.Namespace != ns.Namespace || purgedKey.Collection != coll.CollectionName {
+					continue
+				}
+				collRemoved, err := rwsetutil.RemoveKeyFromCollPvtRwSet(coll, purgedKey.KeyHash)
+				if err != nil {
+					return false, err
+				}
+				removed = removed || collRemoved
+			}
+		}
+	}
+	return removed, nil
+}
+
 func trimPvtCollectionConfigs(configs map[string]*common.CollectionConfigPackage,
 	filter ledger.PvtNsCollFilter) (map[string]*common.CollectionConfigPackage, error) {
 	if filter == nil {
@@ -16,9 +16,11 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/stretchr/testify/assert"
 )
@@ -483,6 +485,60 @@ func TestTransientStorePurgeByHeight(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestTransientStorePurgeByPvtKeys(t *testing.T) {
+	testStore, cleanup := testStore(t)
+	defer cleanup()
+	assert := assert.New(t)
+
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", "key-1", []byte("value-1"))
+	builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", "key-2", []byte("value-2"))
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(err)
+	pvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: simRes.PvtSimulationResults}
+
+	// private write sets received before and after the block that purges key-1
+	assert.NoError(testStore.PersistWithConfig("txid-1", 10, pvtRWSetWithConfig))
+	assert.NoError(testStore.Persist("txid-2", 11, simRes.PvtSimulationResults))
+	assert.NoError(testStore.PersistWithConfig("txid-3", 12, pvtRWSetWithConfig))
+
+	purgedKeys := []*ledger.PurgedPvtKey{
+		{
+			BlockNum:   11,
+			TxNum:      0,
+			Namespace:  "ns-1",
+			Collection: "coll-1",
+			KeyHash:    util.ComputeStringHash("key-1"),
+		},
+		{
+			BlockNum:   11,
+			TxNum:      1,
+			Namespace:  "ns-1",
+			Collection: "coll-2",
+			KeyHash:    util.ComputeStringHash("key-2"),
+		},
+	}
+	assert.NoError(testStore.PurgeByPvtKeys(purgedKeys))
+
+	writtenKeys := func(txid string) []string {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		assert.NoError(err)
+		defer iter.Close()
+		result, err := iter.NextWithConfig()
+		assert.NoError(err)
+		kvRWSet := &kvrwset.KVRWSet{}
+		assert.NoError(proto.Unmarshal(result.PvtSimulationResultsWithConfig.PvtRwset.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, kvRWSet))
+		var keys []string
+		for _, w := range kvRWSet.Writes {
+			keys = append(keys, w.Key)
+		}
+		return keys
+	}
+	assert.Equal([]string{"key-2"}, writtenKeys("txid-1"))
+	assert.Equal([]string{"key-2"}, writtenKeys("txid-2"))
+	assert.Equal([]string{"key-1", "key-2"}, writtenKeys("txid-3"))
+}
+
 func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	testStore, cleanup := testStore(t)
 	defer cleanup()
@@ -647,3 +703,31 @@ func sampleCollectionConfigPackage(colName string) *common.CollectionConfig {
 
 	return createCollectionConfig(colName, policyEnvelope, requiredPeerCount, maximumPeerCount)
 }
+
+func TestDrop(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "ts")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	provider := NewStoreProvider(tempdir)
+	defer provider.Close()
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		store, err := provider.OpenStore(ledgerID)
+		assert.NoError(t, err)
+		assert.NoError(t, store.PersistWithConfig("txid-1", 10, samplePvtRWSetWithConfig))
+	}
+	assert.NoError(t, provider.Drop("ledger1"))
+
+	store1, err := provider.OpenStore("ledger1")
+	assert.NoError(t, err)
+	_, err = store1.GetMinTransientBlkHt()
+	assert.Equal(t, ErrStoreEmpty, err)
+	store2, err := provider.OpenStore("ledger2")
+	assert.NoError(t, err)
+	minBlkHt, err := store2.GetMinTransientBlkHt()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), minBlkHt)
+}
@@ -9,6 +9,7 @@ package endorsement
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/graph"
@@ -17,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	. "github.com/hyperledger/fabric/gossip/discovery"
+	common2 "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
@@ -77,7 +79,7 @@ type peerPrincipalEvaluator func(member NetworkMember, principal *msp.MSPPrincip
 
 // PeersForEndorsement returns an EndorsementDescriptor for a given set of peers, channel, and chaincode
 func (ea *endorsementAnalyzer) PeersForEndorsement(channelID common.ChannelID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
-	chanMembership, err := ea.PeersAuthorizedByCriteria(channelID, interest)
+	chanMembership, metadata, err := ea.peersAuthorizedByCriteria(channelID, interest)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -87,7 +89,7 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(channelID common.ChannelID, i
 	membersById := aliveMembership.ByID()
 	// Compute a mapping between the PKI-IDs of members to their identities
 	identitiesOfMembers := computeIdentitiesOfMembers(ea.IdentityInfo(), membersById)
-	principalsSets, err := ea.computePrincipalSets(channelID, interest)
+	principalsSets, err := ea.computePrincipalSets(channelID, interest, metadata)
 	if err != nil {
 		logger.Warningf("Principal set computation failed: %v", err)
 		return nil, errors.WithStack(err)
@@ -104,9 +106,16 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(channelID common.ChannelID, i
 }
 
 func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(channelID common.ChannelID, interest *discovery.ChaincodeInterest) (Members, error) {
+	peers, _, err := ea.peersAuthorizedByCriteria(channelID, interest)
+	return peers, err
+}
+
+// peersAuthorizedByCriteria returns the peers authorized by the given interest,
+// along with the metadata of the chaincodes of the interest
+func (ea *endorsementAnalyzer) peersAuthorizedByCriteria(channelID common.ChannelID, interest *discovery.ChaincodeInterest) (Members, []*chaincode.Metadata, error) {
 	peersOfChannel := ea.PeersOfChannel(channelID)
 	if interest == nil || len(interest.Chaincodes) == 0 {
-		return peersOfChannel, nil
+		return peersOfChannel, nil, nil
 	}
 	identities := ea.IdentityInfo()
 	identitiesByID := identities.ByID()
@@ -118,13 +127,13 @@ func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(channelID common.Channe
 		fetch:            ea,
 	})
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, nil, errors.WithStack(err)
 	}
 	metadata := metadataAndCollectionFilters.md
 	// Filter out peers that don't have the chaincode installed on them
 	chanMembership := peersOfChannel.Filter(peersWithChaincode(metadata...))
 	// Filter out peers that aren't authorized by the collection configs of the chaincode invocation chain
-	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), nil
+	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), metadata, nil
 }
 
 type context struct {
@@ -167,15 +176,23 @@ func (ea *endorsementAnalyzer) computeEndorsementResponse(ctx *context) (*discov
 	}, nil
 }
 
-func (ea *endorsementAnalyzer) computePrincipalSets(channelID common.ChannelID, interest *discovery.ChaincodeInterest) (policies.PrincipalSets, error) {
+func (ea *endorsementAnalyzer) computePrincipalSets(channelID common.ChannelID, interest *discovery.ChaincodeInterest, metadata []*chaincode.Metadata) (policies.PrincipalSets, error) {
 	var inquireablePolicies []policies.InquireablePolicy
-	for _, chaincode := range interest.Chaincodes {
+	for i, chaincode := range interest.Chaincodes {
 		pol := ea.PolicyByChaincode(string(channelID), chaincode.Name)
 		if pol == nil {
 			logger.Debug("Policy for chaincode '", chaincode, "'doesn't exist")
 			return nil, errors.New("policy not found")
 		}
 		inquireablePolicies = append(inquireablePolicies, pol)
+		if i >= len(metadata) {
+			continue
+		}
+		collPolicies, err := collectionPolicies(chaincode, metadata[i])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		inquireablePolicies = append(inquireablePolicies, collPolicies...)
 	}
 
 	var cpss []inquire.ComparablePrincipalSets
@@ -203,6 +220,29 @@ func (ea *endorsementAnalyzer) computePrincipalSets(channelID common.ChannelID,
 	return cps.ToPrincipalSets(), nil
 }
 
+// collectionPolicies returns the endorsement policies of the collections the given
+// chaincode call writes to. Since a transaction may also write to the public state
+// or to collections without an endorsement policy, these policies are required to be
+// satisfied in addition to the chaincode endorsement policy.
+func collectionPolicies(ccCall *discovery.ChaincodeCall, ccMD *chaincode.Metadata) ([]policies.InquireablePolicy, error) {
+	if len(ccCall.CollectionNames) == 0 || ccMD == nil {
+		return nil, nil
+	}
+	var res []policies.InquireablePolicy
+	for _, col := range ccCall.CollectionNames {
+		policyBytes, exists := ccMD.CollectionPolicies[col]
+		if !exists {
+			continue
+		}
+		spe := &common2.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policyBytes, spe); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling endorsement policy of collection %s of chaincode %s", col, ccCall.Name)
+		}
+		res = append(res, inquire.NewInquireableSignaturePolicy(spe))
+	}
+	return res, nil
+}
+
 type metadataAndFilterContext struct {
 	chainID          common.ChannelID
 	interest         *discovery.ChaincodeInterest
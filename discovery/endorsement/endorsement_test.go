@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
@@ -308,6 +309,58 @@ func TestPeersForEndorsement(t *testing.T) {
 		}, extractPeers(desc))
 	})
 
+	t.Run("Collection endorsement policy", func(t *testing.T) {
+		// Scenario VIII-b: Policy is found and there are enough peers to satisfy
+		// 2 principal combinations: p0, or p12.
+		// The query contains a collection whose members are p0, p6 and p12,
+		// and the collection has an endorsement policy that requires p6.
+		// Thus, the layouts are p0 and p6, or p12 and p6.
+		col2principals := map[string][]*msp.MSPPrincipal{
+			"collection": {peerRole("p0"), peerRole("p6"), peerRole("p12")},
+		}
+		mf := &metadataFetcher{}
+		pf := &policyFetcherMock{}
+		g := &gossipMock{}
+		g.On("Peers").Return(alivePeers.toMembers())
+		g.On("IdentityInfo").Return(identities)
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name:              cc,
+			Version:           "1.0",
+			CollectionsConfig: buildCollectionConfig(col2principals),
+			CollectionPolicies: map[string][]byte{
+				"collection": protoutil.MarshalOrPanic(&cb.SignaturePolicyEnvelope{
+					Rule:       cauthdsl.SignedBy(0),
+					Identities: []*msp.MSPPrincipal{peerRole("p6")},
+				}),
+			},
+		}).Once()
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).
+			newSet().addPrincipal(peerRole("p12")).buildPolicy()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{
+				{
+					Name:            cc,
+					CollectionNames: []string{"collection"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, desc)
+		assert.Len(t, desc.Layouts, 2)
+		for _, layout := range desc.Layouts {
+			assert.Len(t, layout.QuantitiesByGroup, 2)
+		}
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p12"): {},
+		}, extractPeers(desc))
+	})
+
 	t.Run("Chaincode2Chaincode I", func(t *testing.T) {
 		// Scenario IX: A chaincode-to-chaincode query is made.
 		// Total organizations are 0, 2, 4, 6, 10, 12
@@ -594,7 +647,7 @@ func TestComputePrincipalSetsNoPolicies(t *testing.T) {
 		Chaincodes: []*discoveryprotos.ChaincodeCall{},
 	}
 	ea := &endorsementAnalyzer{}
-	_, err := ea.computePrincipalSets(common.ChannelID("mychannel"), interest)
+	_, err := ea.computePrincipalSets(common.ChannelID("mychannel"), interest, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no principal sets remained after filtering")
 }
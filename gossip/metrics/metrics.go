@@ -15,6 +15,7 @@ type GossipMetrics struct {
 	CommMetrics       *CommMetrics
 	MembershipMetrics *MembershipMetrics
 	PrivdataMetrics   *PrivdataMetrics
+	DeliverMetrics    *DeliverMetrics
 }
 
 func NewGossipMetrics(p metrics.Provider) *GossipMetrics {
@@ -24,6 +25,7 @@ func NewGossipMetrics(p metrics.Provider) *GossipMetrics {
 		CommMetrics:       newCommMetrics(p),
 		MembershipMetrics: newMembershipMetrics(p),
 		PrivdataMetrics:   newPrivdataMetrics(p),
+		DeliverMetrics:    newDeliverMetrics(p),
 	}
 }
 
@@ -265,3 +267,36 @@ var (
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
 )
+
+// DeliverMetrics encapsulates the metrics of the delivery of blocks from the ordering service
+type DeliverMetrics struct {
+	BlocksWithheld    metrics.Counter
+	BlocksEquivocated metrics.Counter
+}
+
+func newDeliverMetrics(p metrics.Provider) *DeliverMetrics {
+	return &DeliverMetrics{
+		BlocksWithheld:    p.NewCounter(BlocksWithheldOpts),
+		BlocksEquivocated: p.NewCounter(BlocksEquivocatedOpts),
+	}
+}
+
+var (
+	BlocksWithheldOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "deliver",
+		Name:         "blocks_withheld",
+		Help:         "Number of times the ordering service node blocks are pulled from was detected withholding blocks",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	BlocksEquivocatedOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "deliver",
+		Name:         "blocks_equivocated",
+		Help:         "Number of times the ordering service node blocks are pulled from was detected equivocating on blocks",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
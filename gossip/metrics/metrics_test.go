@@ -56,4 +56,8 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.ReconciliationDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PullDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.RetrieveDuration)
+
+	assert.NotNil(t, gossipMetrics.DeliverMetrics)
+	assert.NotNil(t, gossipMetrics.DeliverMetrics.BlocksWithheld)
+	assert.NotNil(t, gossipMetrics.DeliverMetrics.BlocksEquivocated)
 }
@@ -9,12 +9,14 @@ package privdata
 import (
 	"time"
 
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/spf13/viper"
 )
 
 const (
-	reconcileSleepIntervalDefault = time.Minute
-	reconcileBatchSizeDefault     = 10
+	reconcileSleepIntervalDefault         = time.Minute
+	reconcileBatchSizeDefault             = 10
+	implicitCollectionMaxPeerCountDefault = 1
 )
 
 // PrivdataConfig is the struct that defines the Gossip Privdata configurations.
@@ -26,6 +28,8 @@ type PrivdataConfig struct {
 	ReconcileBatchSize int
 	// ReconciliationEnabled is a flag that indicates whether private data reconciliation is enabled or not.
 	ReconciliationEnabled bool
+	// ImplicitCollDisseminationPolicy specifies the dissemination policy for the peer's own implicit collection.
+	ImplicitCollDisseminationPolicy privdata.ImplicitCollectionDisseminationPolicy
 }
 
 // GlobalConfig obtains a set of configuration from viper, build and returns the config struct.
@@ -50,4 +54,23 @@ func (c *PrivdataConfig) loadPrivDataConfig() {
 
 	c.ReconciliationEnabled = viper.GetBool("peer.gossip.pvtData.reconciliationEnabled")
 
+	requiredPeerCount := viper.GetInt("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount")
+	if requiredPeerCount < 0 {
+		logger.Warning("Configuration key peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount is negative, defaulting to 0")
+		requiredPeerCount = 0
+	}
+
+	maxPeerCount := implicitCollectionMaxPeerCountDefault
+	if viper.IsSet("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount") {
+		maxPeerCount = viper.GetInt("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount")
+	}
+	if maxPeerCount < requiredPeerCount {
+		logger.Warning("Configuration key peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount is less than requiredPeerCount, defaulting to", requiredPeerCount)
+		maxPeerCount = requiredPeerCount
+	}
+
+	c.ImplicitCollDisseminationPolicy = privdata.ImplicitCollectionDisseminationPolicy{
+		RequiredPeerCount: requiredPeerCount,
+		MaxPeerCount:      maxPeerCount,
+	}
 }
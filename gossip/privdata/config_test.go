@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	coreprivdata "github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,8 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.pvtData.reconcileSleepInterval", "10s")
 	viper.Set("peer.gossip.pvtData.reconcileBatchSize", 10)
 	viper.Set("peer.gossip.pvtData.reconciliationEnabled", true)
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", 2)
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", 3)
 
 	coreConfig := privdata.GlobalConfig()
 
@@ -28,6 +31,10 @@ func TestGlobalConfig(t *testing.T) {
 		ReconcileSleepInterval: 10 * time.Second,
 		ReconcileBatchSize:     10,
 		ReconciliationEnabled:  true,
+		ImplicitCollDisseminationPolicy: coreprivdata.ImplicitCollectionDisseminationPolicy{
+			RequiredPeerCount: 2,
+			MaxPeerCount:      3,
+		},
 	}
 
 	assert.Equal(t, coreConfig, expectedConfig)
@@ -42,7 +49,32 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		ReconcileSleepInterval: time.Minute,
 		ReconcileBatchSize:     10,
 		ReconciliationEnabled:  false,
+		ImplicitCollDisseminationPolicy: coreprivdata.ImplicitCollectionDisseminationPolicy{
+			RequiredPeerCount: 0,
+			MaxPeerCount:      1,
+		},
 	}
 
 	assert.Equal(t, coreConfig, expectedConfig)
 }
+
+func TestGlobalConfigInvalidImplicitCollDisseminationPolicy(t *testing.T) {
+	viper.Reset()
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", -1)
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", -1)
+
+	coreConfig := privdata.GlobalConfig()
+	assert.Equal(t, coreprivdata.ImplicitCollectionDisseminationPolicy{
+		RequiredPeerCount: 0,
+		MaxPeerCount:      0,
+	}, coreConfig.ImplicitCollDisseminationPolicy)
+
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", 3)
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", 2)
+
+	coreConfig = privdata.GlobalConfig()
+	assert.Equal(t, coreprivdata.ImplicitCollectionDisseminationPolicy{
+		RequiredPeerCount: 3,
+		MaxPeerCount:      3,
+	}, coreConfig.ImplicitCollDisseminationPolicy)
+}
@@ -74,6 +74,10 @@ type TransientStore interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+
+	// PurgeByPvtKeys removes the purged keys from the private write sets that were received
+	// at a block height not greater than the number of the block that purged the keys
+	PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error
 }
 
 // Coordinator orchestrates the flow of the new
@@ -266,6 +270,16 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		}
 	}
 
+	// Remove the past versions of the private data keys purged by the block
+	purgedKeys, err := rwsetutil.GetPurgedPvtKeys(block)
+	if err != nil {
+		logger.Error("Failed extracting the purged private data keys of block", block.Header.Number, ":", err)
+	} else if len(purgedKeys) > 0 {
+		if err := c.PurgeByPvtKeys(purgedKeys); err != nil {
+			logger.Error("Failed purging private data keys from transient store at block", block.Header.Number, ":", err)
+		}
+	}
+
 	seq := block.Header.Number
 	if seq%c.transientBlockRetention == 0 && seq > c.transientBlockRetention {
 		err := c.PurgeByHeight(seq - c.transientBlockRetention)
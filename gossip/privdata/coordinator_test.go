@@ -90,6 +90,7 @@ type mockTransientStore struct {
 	persists      map[rwsTriplet]struct{}
 	lastReqTxID   string
 	lastReqFilter map[string]ledger.PvtCollFilter
+	purgedPvtKeys []*ledger.PurgedPvtKey
 }
 
 func (store *mockTransientStore) On(methodName string, arguments ...interface{}) *persistCall {
@@ -135,6 +136,11 @@ func (store *mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error
 	return store.Called(maxBlockNumToRetain).Error(0)
 }
 
+func (store *mockTransientStore) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	store.purgedPvtKeys = append(store.purgedPvtKeys, purgedKeys...)
+	return nil
+}
+
 func (store *mockTransientStore) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (transientstore.RWSetScanner, error) {
 	store.lastReqTxID = txid
 	store.lastReqFilter = filter
@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/privdata/common"
 	"github.com/hyperledger/fabric/gossip/util"
+	protoscommon "github.com/hyperledger/fabric/protos/common"
 	protosgossip "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/pkg/errors"
@@ -138,26 +139,9 @@ func (dr *dataRetriever) fromLedger(digests []*protosgossip.PvtDataDigest, block
 			pvtRWSetWithConfig.RWSet = append(pvtRWSetWithConfig.RWSet, pvtRWSet...)
 		}
 
-		confHistoryRetriever, err := dr.store.GetConfigHistoryRetriever()
+		configs, err := dr.retrieveCollectionConfig(dig)
 		if err != nil {
-			return nil, errors.Errorf("cannot obtain configuration history retriever, for collection <%s>"+
-				" txID <%s> block sequence number <%d> due to <%s>", dig.Collection, dig.TxId, dig.BlockSeq, err)
-		}
-
-		configInfo, err := confHistoryRetriever.MostRecentCollectionConfigBelow(dig.BlockSeq, dig.Namespace)
-		if err != nil {
-			return nil, errors.Errorf("cannot find recent collection config update below block sequence = %d,"+
-				" collection name = <%s> for chaincode <%s>", dig.BlockSeq, dig.Collection, dig.Namespace)
-		}
-
-		if configInfo == nil {
-			return nil, errors.Errorf("no collection config update below block sequence = <%d>"+
-				" collection name = <%s> for chaincode <%s> is available ", dig.BlockSeq, dig.Collection, dig.Namespace)
-		}
-		configs := extractCollectionConfig(configInfo.CollectionConfig, dig.Collection)
-		if configs == nil {
-			return nil, errors.Errorf("no collection config was found for collection <%s>"+
-				" namespace <%s> txID <%s>", dig.Collection, dig.Namespace, dig.TxId)
+			return nil, err
 		}
 		pvtRWSetWithConfig.CollectionConfig = configs
 		results[common.DigKey{
@@ -172,6 +156,39 @@ func (dr *dataRetriever) fromLedger(digests []*protosgossip.PvtDataDigest, block
 	return results, nil
 }
 
+// retrieveCollectionConfig returns the config of the collection of the given digest that was
+// in effect when the private data of the digest was committed
+func (dr *dataRetriever) retrieveCollectionConfig(dig *protosgossip.PvtDataDigest) (*protoscommon.CollectionConfig, error) {
+	// implicit collections are derived from the channel orgs and are
+	// therefore not recorded in the collection config history
+	if configs := implicitCollectionConfig(dig.Collection); configs != nil {
+		return configs, nil
+	}
+
+	confHistoryRetriever, err := dr.store.GetConfigHistoryRetriever()
+	if err != nil {
+		return nil, errors.Errorf("cannot obtain configuration history retriever, for collection <%s>"+
+			" txID <%s> block sequence number <%d> due to <%s>", dig.Collection, dig.TxId, dig.BlockSeq, err)
+	}
+
+	configInfo, err := confHistoryRetriever.MostRecentCollectionConfigBelow(dig.BlockSeq, dig.Namespace)
+	if err != nil {
+		return nil, errors.Errorf("cannot find recent collection config update below block sequence = %d,"+
+			" collection name = <%s> for chaincode <%s>", dig.BlockSeq, dig.Collection, dig.Namespace)
+	}
+
+	if configInfo == nil {
+		return nil, errors.Errorf("no collection config update below block sequence = <%d>"+
+			" collection name = <%s> for chaincode <%s> is available ", dig.BlockSeq, dig.Collection, dig.Namespace)
+	}
+	configs := extractCollectionConfig(configInfo.CollectionConfig, dig.Collection)
+	if configs == nil {
+		return nil, errors.Errorf("no collection config was found for collection <%s>"+
+			" namespace <%s> txID <%s>", dig.Collection, dig.Namespace, dig.TxId)
+	}
+	return configs, nil
+}
+
 func (dr *dataRetriever) fromTransientStore(dig *protosgossip.PvtDataDigest, filter map[string]ledger.PvtCollFilter) (*util.PrivateRWSetWithConfig, error) {
 	results := &util.PrivateRWSetWithConfig{}
 	it, err := dr.store.GetTxPvtRWSetByTxid(dig.TxId, filter)
@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+)
+
+// URLBaseV1 is the path under which the handler is expected to be registered
+const URLBaseV1 = "/pvtdata"
+
+//go:generate counterfeiter -o fakes/pvt_data_service.go -fake-name PvtDataService . PvtDataService
+
+// PvtDataService provides access to the private data components of the channels the peer has joined
+type PvtDataService interface {
+	PrivateDataChannels() []string
+	MissingPvtDataTracker(channelID string) (ledger.MissingPvtDataTracker, error)
+	PvtDataReconciler(channelID string) (privdata.PvtDataReconciler, error)
+}
+
+//go:generate counterfeiter -o fakes/missing_pvt_data_tracker.go -fake-name MissingPvtDataTracker . MissingPvtDataTracker
+
+// MissingPvtDataTracker is the local interface used to generate fakes for foreign interface.
+type MissingPvtDataTracker interface {
+	ledger.MissingPvtDataTracker
+}
+
+//go:generate counterfeiter -o fakes/pvt_data_reconciler.go -fake-name PvtDataReconciler . PvtDataReconciler
+
+// PvtDataReconciler is the local interface used to generate fakes for foreign interface.
+type PvtDataReconciler interface {
+	privdata.PvtDataReconciler
+}
+
+// CollectionReport describes the private data of a collection that is missing on the peer
+type CollectionReport struct {
+	Namespace          string `json:"namespace"`
+	Collection         string `json:"collection"`
+	EligibleBlocks     uint64 `json:"eligible_blocks"`
+	EligibleTxs        uint64 `json:"eligible_txs"`
+	IneligibleBlocks   uint64 `json:"ineligible_blocks"`
+	IneligibleTxs      uint64 `json:"ineligible_txs"`
+	OldestMissingBlock uint64 `json:"oldest_missing_block"`
+}
+
+// ChannelReport describes the missing private data and the reconciliation progress of a channel
+type ChannelReport struct {
+	Channel     string             `json:"channel"`
+	Collections []CollectionReport `json:"collections"`
+	// OldestMissingBlock is omitted when no private data is missing on the channel
+	OldestMissingBlock *uint64                       `json:"oldest_missing_block,omitempty"`
+	Reconciliation     privdata.ReconciliationStatus `json:"reconciliation"`
+}
+
+// Report is the response to a request for all channels
+type Report struct {
+	Channels []ChannelReport `json:"channels"`
+}
+
+// ReconcileRequest is the payload of a request to reconcile a block range
+type ReconcileRequest struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+}
+
+// ReconcileResponse is the response to a request to reconcile a block range
+type ReconcileResponse struct {
+	Reconciled int `json:"reconciled"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewMissingPvtDataHandler(pvtDataService PvtDataService) *MissingPvtDataHandler {
+	return &MissingPvtDataHandler{
+		PvtDataService: pvtDataService,
+		Logger:         flogging.MustGetLogger("privdata.httpadmin"),
+	}
+}
+
+// MissingPvtDataHandler reports the missing private data of all channels on GET /pvtdata
+// and of a single channel on GET /pvtdata/{channel}. A POST to /pvtdata/{channel}/reconcile
+// triggers an immediate reconciliation pass for the block range in the request.
+type MissingPvtDataHandler struct {
+	PvtDataService PvtDataService
+	Logger         *flogging.FabricLogger
+}
+
+func (h *MissingPvtDataHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, URLBaseV1), "/")
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	switch {
+	case len(segments) == 0 && req.Method == http.MethodGet:
+		h.serveReport(resp)
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		h.serveChannelReport(resp, segments[0])
+	case len(segments) == 2 && segments[1] == "reconcile" && req.Method == http.MethodPost:
+		h.serveReconcile(resp, req, segments[0])
+	case len(segments) > 2 || (len(segments) == 2 && segments[1] != "reconcile"):
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("invalid path: %s", req.URL.Path))
+	default:
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *MissingPvtDataHandler) serveReport(resp http.ResponseWriter) {
+	report := &Report{Channels: []ChannelReport{}}
+	for _, channelID := range h.PvtDataService.PrivateDataChannels() {
+		channelReport, err := h.channelReport(channelID)
+		if err != nil {
+			h.sendResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		report.Channels = append(report.Channels, *channelReport)
+	}
+	h.sendResponse(resp, http.StatusOK, report)
+}
+
+func (h *MissingPvtDataHandler) serveChannelReport(resp http.ResponseWriter, channelID string) {
+	if !h.channelExists(channelID) {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+		return
+	}
+	channelReport, err := h.channelReport(channelID)
+	if err != nil {
+		h.sendResponse(resp, http.StatusInternalServerError, err)
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, channelReport)
+}
+
+func (h *MissingPvtDataHandler) serveReconcile(resp http.ResponseWriter, req *http.Request, channelID string) {
+	if !h.channelExists(channelID) {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+		return
+	}
+
+	var reconcileReq ReconcileRequest
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&reconcileReq); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	req.Body.Close()
+
+	if reconcileReq.StartBlock > reconcileReq.EndBlock {
+		err := fmt.Errorf("start block [%d] is greater than end block [%d]", reconcileReq.StartBlock, reconcileReq.EndBlock)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	reconciler, err := h.PvtDataService.PvtDataReconciler(channelID)
+	if err != nil {
+		h.sendResponse(resp, http.StatusInternalServerError, err)
+		return
+	}
+	reconciled, err := reconciler.ReconcileBlockRange(reconcileReq.StartBlock, reconcileReq.EndBlock)
+	if err != nil {
+		h.sendResponse(resp, http.StatusInternalServerError, err)
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, &ReconcileResponse{Reconciled: reconciled})
+}
+
+func (h *MissingPvtDataHandler) channelExists(channelID string) bool {
+	for _, ch := range h.PvtDataService.PrivateDataChannels() {
+		if ch == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *MissingPvtDataHandler) channelReport(channelID string) (*ChannelReport, error) {
+	tracker, err := h.PvtDataService.MissingPvtDataTracker(channelID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := tracker.GetMissingPvtDataStats()
+	if err != nil {
+		return nil, err
+	}
+	reconciler, err := h.PvtDataService.PvtDataReconciler(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ChannelReport{
+		Channel:        channelID,
+		Collections:    []CollectionReport{},
+		Reconciliation: reconciler.Status(),
+	}
+	for _, s := range stats {
+		report.Collections = append(report.Collections, CollectionReport{
+			Namespace:          s.Namespace,
+			Collection:         s.Collection,
+			EligibleBlocks:     s.EligibleBlocks,
+			EligibleTxs:        s.EligibleTxs,
+			IneligibleBlocks:   s.IneligibleBlocks,
+			IneligibleTxs:      s.IneligibleTxs,
+			OldestMissingBlock: s.OldestMissingBlock,
+		})
+		if report.OldestMissingBlock == nil || s.OldestMissingBlock < *report.OldestMissingBlock {
+			oldest := s.OldestMissingBlock
+			report.OldestMissingBlock = &oldest
+		}
+	}
+	return report, nil
+}
+
+func (h *MissingPvtDataHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin"
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MissingPvtDataHandler", func() {
+	var (
+		fakePvtDataService *fakes.PvtDataService
+		fakeTracker        *fakes.MissingPvtDataTracker
+		fakeReconciler     *fakes.PvtDataReconciler
+		handler            *httpadmin.MissingPvtDataHandler
+		resp               *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeTracker = &fakes.MissingPvtDataTracker{}
+		fakeTracker.GetMissingPvtDataStatsReturns([]*ledger.MissingCollectionPvtDataStats{
+			{Namespace: "ns1", Collection: "coll1", EligibleBlocks: 2, EligibleTxs: 3, OldestMissingBlock: 7},
+			{Namespace: "ns1", Collection: "coll2", IneligibleBlocks: 1, IneligibleTxs: 1, OldestMissingBlock: 4},
+		}, nil)
+
+		fakeReconciler = &fakes.PvtDataReconciler{}
+		fakeReconciler.StatusReturns(privdata.ReconciliationStatus{
+			Enabled:            true,
+			LastReconciliation: time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC),
+			TotalReconciled:    42,
+			ReconciliationRate: 1.5,
+			RecentFailures: []privdata.ReconciliationFailure{
+				{Time: time.Date(2019, 5, 1, 9, 0, 0, 0, time.UTC), Error: "peers unavailable"},
+			},
+		})
+		fakeReconciler.ReconcileBlockRangeReturns(5, nil)
+
+		fakePvtDataService = &fakes.PvtDataService{}
+		fakePvtDataService.PrivateDataChannelsReturns([]string{"mychannel"})
+		fakePvtDataService.MissingPvtDataTrackerReturns(fakeTracker, nil)
+		fakePvtDataService.PvtDataReconcilerReturns(fakeReconciler, nil)
+
+		handler = httpadmin.NewMissingPvtDataHandler(fakePvtDataService)
+		resp = httptest.NewRecorder()
+	})
+
+	channelReport := `{
+		"channel": "mychannel",
+		"collections": [
+			{"namespace": "ns1", "collection": "coll1", "eligible_blocks": 2, "eligible_txs": 3, "ineligible_blocks": 0, "ineligible_txs": 0, "oldest_missing_block": 7},
+			{"namespace": "ns1", "collection": "coll2", "eligible_blocks": 0, "eligible_txs": 0, "ineligible_blocks": 1, "ineligible_txs": 1, "oldest_missing_block": 4}
+		],
+		"oldest_missing_block": 4,
+		"reconciliation": {
+			"enabled": true,
+			"last_reconciliation": "2019-05-01T10:00:00Z",
+			"total_reconciled": 42,
+			"reconciliation_rate": 1.5,
+			"recent_failures": [{"time": "2019-05-01T09:00:00Z", "error": "peers unavailable"}]
+		}
+	}`
+
+	It("reports on all channels", func() {
+		req := httptest.NewRequest("GET", "/pvtdata", nil)
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body).To(MatchJSON(`{"channels": [` + channelReport + `]}`))
+		Expect(fakePvtDataService.MissingPvtDataTrackerArgsForCall(0)).To(Equal("mychannel"))
+	})
+
+	It("reports on a single channel", func() {
+		req := httptest.NewRequest("GET", "/pvtdata/mychannel", nil)
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(channelReport))
+	})
+
+	It("omits the oldest missing block when nothing is missing", func() {
+		fakeTracker.GetMissingPvtDataStatsReturns(nil, nil)
+		fakeReconciler.StatusReturns(privdata.ReconciliationStatus{})
+
+		req := httptest.NewRequest("GET", "/pvtdata/mychannel", nil)
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"channel": "mychannel",
+			"collections": [],
+			"reconciliation": {"enabled": false, "last_reconciliation": "0001-01-01T00:00:00Z", "total_reconciled": 0, "reconciliation_rate": 0}
+		}`))
+	})
+
+	It("reconciles a block range", func() {
+		req := httptest.NewRequest("POST", "/pvtdata/mychannel/reconcile", strings.NewReader(`{"start_block": 3, "end_block": 10}`))
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{"reconciled": 5}`))
+		Expect(fakeReconciler.ReconcileBlockRangeCallCount()).To(Equal(1))
+		startBlk, endBlk := fakeReconciler.ReconcileBlockRangeArgsForCall(0)
+		Expect(startBlk).To(Equal(uint64(3)))
+		Expect(endBlk).To(Equal(uint64(10)))
+	})
+
+	Context("when the channel does not exist", func() {
+		It("responds with not found", func() {
+			req := httptest.NewRequest("GET", "/pvtdata/unknown", nil)
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel unknown not found"}`))
+
+			resp = httptest.NewRecorder()
+			req = httptest.NewRequest("POST", "/pvtdata/unknown/reconcile", strings.NewReader(`{}`))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(fakeReconciler.ReconcileBlockRangeCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the missing private data stats cannot be retrieved", func() {
+		It("responds with an error payload", func() {
+			fakeTracker.GetMissingPvtDataStatsReturns(nil, errors.New("ledger closed"))
+
+			req := httptest.NewRequest("GET", "/pvtdata", nil)
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body).To(MatchJSON(`{"error": "ledger closed"}`))
+		})
+	})
+
+	Context("when the reconcile payload cannot be decoded", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("POST", "/pvtdata/mychannel/reconcile", strings.NewReader(`goo`))
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid character 'g' looking for beginning of value"}`))
+		})
+	})
+
+	Context("when the start block is greater than the end block", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("POST", "/pvtdata/mychannel/reconcile", strings.NewReader(`{"start_block": 10, "end_block": 3}`))
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "start block [10] is greater than end block [3]"}`))
+			Expect(fakeReconciler.ReconcileBlockRangeCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when reconciliation fails", func() {
+		It("responds with an error payload", func() {
+			fakeReconciler.ReconcileBlockRangeReturns(0, errors.New("private data reconciliation is disabled"))
+
+			req := httptest.NewRequest("POST", "/pvtdata/mychannel/reconcile", strings.NewReader(`{"start_block": 3, "end_block": 10}`))
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body).To(MatchJSON(`{"error": "private data reconciliation is disabled"}`))
+		})
+	})
+
+	Context("when the request method is not supported", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("DELETE", "/pvtdata/mychannel", nil)
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: DELETE"}`))
+		})
+	})
+
+	Context("when the path is not supported", func() {
+		It("responds with not found", func() {
+			req := httptest.NewRequest("GET", "/pvtdata/mychannel/other", nil)
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid path: /pvtdata/mychannel/other"}`))
+		})
+	})
+})
@@ -10,6 +10,29 @@ type MissingPvtDataTracker struct {
 	mock.Mock
 }
 
+// GetMissingPvtDataInfoForBlockRange provides a mock function with given fields: startBlk, endBlk
+func (_m *MissingPvtDataTracker) GetMissingPvtDataInfoForBlockRange(startBlk uint64, endBlk uint64) (ledger.MissingPvtDataInfo, error) {
+	ret := _m.Called(startBlk, endBlk)
+
+	var r0 ledger.MissingPvtDataInfo
+	if rf, ok := ret.Get(0).(func(uint64, uint64) ledger.MissingPvtDataInfo); ok {
+		r0 = rf(startBlk, endBlk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ledger.MissingPvtDataInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(startBlk, endBlk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMissingPvtDataInfoForMostRecentBlocks provides a mock function with given fields: maxBlocks
 func (_m *MissingPvtDataTracker) GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (ledger.MissingPvtDataInfo, error) {
 	ret := _m.Called(maxBlocks)
@@ -32,3 +55,26 @@ func (_m *MissingPvtDataTracker) GetMissingPvtDataInfoForMostRecentBlocks(maxBlo
 
 	return r0, r1
 }
+
+// GetMissingPvtDataStats provides a mock function with given fields:
+func (_m *MissingPvtDataTracker) GetMissingPvtDataStats() ([]*ledger.MissingCollectionPvtDataStats, error) {
+	ret := _m.Called()
+
+	var r0 []*ledger.MissingCollectionPvtDataStats
+	if rf, ok := ret.Get(0).(func() []*ledger.MissingCollectionPvtDataStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ledger.MissingCollectionPvtDataStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -52,6 +53,34 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Status returns a snapshot of the reconciliation progress
+	Status() ReconciliationStatus
+	// ReconcileBlockRange runs an immediate reconciliation pass over the missing private data
+	// of blocks in the range [startBlk, endBlk] and returns the number of reconciled elements
+	ReconcileBlockRange(startBlk, endBlk uint64) (int, error)
+}
+
+// maxRecentReconciliationFailures is the number of reconciliation failures retained for reporting
+const maxRecentReconciliationFailures = 10
+
+// ReconciliationFailure records a failed reconciliation pass
+type ReconciliationFailure struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+// ReconciliationStatus describes the progress of private data reconciliation on a channel
+type ReconciliationStatus struct {
+	// Enabled is false when reconciliation has been disabled in the peer configuration
+	Enabled bool `json:"enabled"`
+	// LastReconciliation is the time at which the last reconciliation pass finished
+	LastReconciliation time.Time `json:"last_reconciliation"`
+	// TotalReconciled is the number of private data elements reconciled since the peer started
+	TotalReconciled uint64 `json:"total_reconciled"`
+	// ReconciliationRate is the number of elements per second reconciled by the last pass
+	ReconciliationRate float64 `json:"reconciliation_rate"`
+	// RecentFailures holds the most recent failed passes, oldest first
+	RecentFailures []ReconciliationFailure `json:"recent_failures,omitempty"`
 }
 
 type Reconciler struct {
@@ -62,6 +91,10 @@ type Reconciler struct {
 	stopChan               chan struct{}
 	startOnce              sync.Once
 	stopOnce               sync.Once
+	// reconcileLock prevents the periodic and the on-demand passes from running concurrently
+	reconcileLock sync.Mutex
+	statusLock    sync.RWMutex
+	status        ReconciliationStatus
 	ReconciliationFetcher
 	committer.Committer
 }
@@ -80,6 +113,16 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+// Status reports reconciliation as disabled
+func (*NoOpReconciler) Status() ReconciliationStatus {
+	return ReconciliationStatus{}
+}
+
+// ReconcileBlockRange always fails since reconciliation has been disabled
+func (*NoOpReconciler) ReconcileBlockRange(startBlk, endBlk uint64) (int, error) {
+	return 0, errors.New("private data reconciliation is disabled")
+}
+
 // NewReconciler creates a new instance of reconciler
 func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer.Committer,
 	fetcher ReconciliationFetcher, config *PrivdataConfig) *Reconciler {
@@ -122,16 +165,95 @@ func (r *Reconciler) run() {
 	}
 }
 
+// Status returns a snapshot of the reconciliation progress of the channel
+func (r *Reconciler) Status() ReconciliationStatus {
+	r.statusLock.RLock()
+	defer r.statusLock.RUnlock()
+	status := r.status
+	status.Enabled = true
+	status.RecentFailures = append([]ReconciliationFailure(nil), r.status.RecentFailures...)
+	return status
+}
+
+// ReconcileBlockRange runs an immediate reconciliation pass over the missing private data
+// of blocks in the range [startBlk, endBlk]. Blocks are processed in batches of ReconcileBatchSize.
+func (r *Reconciler) ReconcileBlockRange(startBlk, endBlk uint64) (int, error) {
+	if startBlk > endBlk {
+		return 0, errors.Errorf("start block [%d] is greater than end block [%d]", startBlk, endBlk)
+	}
+
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	startTime := time.Now()
+	totalReconciled, err := r.reconcileBlockRange(startBlk, endBlk)
+	r.recordPass(startTime, totalReconciled, err)
+	return totalReconciled, err
+}
+
+func (r *Reconciler) reconcileBlockRange(startBlk, endBlk uint64) (int, error) {
+	missingPvtDataTracker, err := r.missingPvtDataTracker()
+	if err != nil {
+		return 0, err
+	}
+
+	defer r.reportReconciliationDuration(time.Now())
+
+	missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(startBlk, endBlk)
+	if err != nil {
+		logger.Error("reconciliation error when trying to get missing pvt data info for block range:", err)
+		return 0, err
+	}
+
+	blockNums := make([]uint64, 0, len(missingPvtDataInfo))
+	for blkNum := range missingPvtDataInfo {
+		blockNums = append(blockNums, blkNum)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] > blockNums[j] })
+
+	batchSize := r.ReconcileBatchSize
+	if batchSize <= 0 {
+		batchSize = len(blockNums)
+	}
+
+	totalReconciled := 0
+	for len(blockNums) > 0 {
+		n := batchSize
+		if n > len(blockNums) {
+			n = len(blockNums)
+		}
+		batch := make(ledger.MissingPvtDataInfo, n)
+		for _, blkNum := range blockNums[:n] {
+			batch[blkNum] = missingPvtDataInfo[blkNum]
+		}
+		blockNums = blockNums[n:]
+
+		reconciled, _, _, err := r.reconcileMissingPvtData(batch)
+		totalReconciled += reconciled
+		if err != nil {
+			return totalReconciled, err
+		}
+	}
+
+	logger.Infof("Reconciliation of blocks range [%d - %d] finished. reconciled %d private data keys", startBlk, endBlk, totalReconciled)
+	return totalReconciled, nil
+}
+
 // returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
 func (r *Reconciler) reconcile() error {
-	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	startTime := time.Now()
+	totalReconciled, err := r.reconcileMostRecentBlocks()
+	r.recordPass(startTime, totalReconciled, err)
+	return err
+}
+
+func (r *Reconciler) reconcileMostRecentBlocks() (int, error) {
+	missingPvtDataTracker, err := r.missingPvtDataTracker()
 	if err != nil {
-		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
-		return err
-	}
-	if missingPvtDataTracker == nil {
-		logger.Error("got nil as MissingPvtDataTracker, exiting...")
-		return errors.New("got nil as MissingPvtDataTracker, exiting...")
+		return 0, err
 	}
 	totalReconciled, minBlock, maxBlock := 0, uint64(math.MaxUint64), uint64(0)
 
@@ -141,7 +263,7 @@ func (r *Reconciler) reconcile() error {
 		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.ReconcileBatchSize)
 		if err != nil {
 			logger.Error("reconciliation error when trying to get missing pvt data info recent blocks:", err)
-			return err
+			return totalReconciled, err
 		}
 		// if missingPvtDataInfo is nil, len will return 0
 		if len(missingPvtDataInfo) == 0 {
@@ -150,36 +272,80 @@ func (r *Reconciler) reconcile() error {
 			} else {
 				logger.Debug("Reconciliation cycle finished successfully. no items to reconcile")
 			}
-			return nil
+			return totalReconciled, nil
 		}
 
 		logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
 
-		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
-		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
-		if err != nil {
-			logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
-			return err
-		}
-		if len(fetchedData.AvailableElements) == 0 {
-			logger.Warning("missing private data is not available on other peers")
-			return nil
+		reconciled, minB, maxB, err := r.reconcileMissingPvtData(missingPvtDataInfo)
+		totalReconciled += reconciled
+		if err != nil || reconciled == 0 {
+			return totalReconciled, err
 		}
-
-		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
-		// commit missing private data that was reconciled and log mismatched
-		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit)
-		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
-		}
-		r.logMismatched(pvtdataHashMismatch)
 		if minB < minBlock {
 			minBlock = minB
 		}
 		if maxB > maxBlock {
 			maxBlock = maxB
 		}
-		totalReconciled += len(fetchedData.AvailableElements)
+	}
+}
+
+func (r *Reconciler) missingPvtDataTracker() (ledger.MissingPvtDataTracker, error) {
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
+		return nil, err
+	}
+	if missingPvtDataTracker == nil {
+		logger.Error("got nil as MissingPvtDataTracker, exiting...")
+		return nil, errors.New("got nil as MissingPvtDataTracker, exiting...")
+	}
+	return missingPvtDataTracker, nil
+}
+
+// reconcileMissingPvtData fetches the given missing private data from other peers and commits it.
+// It returns the number of reconciled items and the blocks range they were taken from.
+func (r *Reconciler) reconcileMissingPvtData(missingPvtDataInfo ledger.MissingPvtDataInfo) (int, uint64, uint64, error) {
+	dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
+	fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+	if err != nil {
+		logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
+		return 0, 0, 0, err
+	}
+	if len(fetchedData.AvailableElements) == 0 {
+		logger.Warning("missing private data is not available on other peers")
+		return 0, 0, 0, nil
+	}
+
+	pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
+	// commit missing private data that was reconciled and log mismatched
+	pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit)
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "failed to commit private data")
+	}
+	r.logMismatched(pvtdataHashMismatch)
+	return len(fetchedData.AvailableElements), minB, maxB, nil
+}
+
+// recordPass updates the reconciliation status with the outcome of a pass
+func (r *Reconciler) recordPass(startTime time.Time, reconciled int, err error) {
+	now := time.Now()
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+
+	r.status.LastReconciliation = now
+	r.status.TotalReconciled += uint64(reconciled)
+	r.status.ReconciliationRate = 0
+	if elapsed := now.Sub(startTime).Seconds(); elapsed > 0 {
+		r.status.ReconciliationRate = float64(reconciled) / elapsed
+	}
+	if err == nil {
+		return
+	}
+	r.status.RecentFailures = append(r.status.RecentFailures, ReconciliationFailure{Time: now, Error: err.Error()})
+	if len(r.status.RecentFailures) > maxRecentReconciliationFailures {
+		r.status.RecentFailures = r.status.RecentFailures[len(r.status.RecentFailures)-maxRecentReconciliationFailures:]
 	}
 }
 
@@ -234,6 +400,12 @@ func (r *Reconciler) getDig2CollectionConfig(missingPvtDataInfo ledger.MissingPv
 }
 
 func (r *Reconciler) getMostRecentCollectionConfig(chaincodeName string, collectionName string, blockNum uint64) (*common.StaticCollectionConfig, error) {
+	// implicit collections are derived from the channel orgs and are
+	// therefore not recorded in the collection config history
+	if collectionConfig := implicitCollectionConfig(collectionName); collectionConfig != nil {
+		return collectionConfig.GetStaticCollectionConfig(), nil
+	}
+
 	configHistoryRetriever, err := r.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, errors.Wrap(err, "configHistoryRetriever is not available")
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	gmetricsmocks "github.com/hyperledger/fabric/gossip/metrics/mocks"
@@ -96,6 +97,55 @@ func TestNotReconcilingWhenCollectionConfigNotAvailable(t *testing.T) {
 	assert.True(t, fetchCalled)
 }
 
+func TestReconcilingImplicitCollection(t *testing.T) {
+	// Scenario: the missing private data belongs to an implicit collection, which is not recorded
+	// in the collection config history. The reconciler derives the collection config from the
+	// collection name and pulls the missing private data.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	var missingInfo ledger.MissingPvtDataInfo
+
+	missingInfo = map[uint64]ledger.MissingBlockPvtdataInfo{
+		1: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			1: {{Collection: "_implicit_org_Org1MSP", Namespace: "chain1"}},
+		},
+	}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(missingInfo, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(nil, errors.New("no collection config"))
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var fetchCalled bool
+	fetcher.On("FetchReconciledItems", mock.Anything).Run(func(args mock.Arguments) {
+		var dig2CollectionConfig = args.Get(0).(privdatacommon.Dig2CollectionConfig)
+		assert.Equal(t, privdatacommon.Dig2CollectionConfig{
+			privdatacommon.DigKey{
+				Namespace:  "chain1",
+				Collection: "_implicit_org_Org1MSP",
+				BlockSeq:   1,
+				SeqInBlock: 1,
+			}: privdata.GenerateImplicitCollectionForOrg("Org1MSP"),
+		}, dig2CollectionConfig)
+		fetchCalled = true
+	}).Return(nil, errors.New("stop reconciling"))
+
+	r := &Reconciler{
+		channel:                "",
+		metrics:                metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		ReconcileSleepInterval: time.Minute,
+		ReconcileBatchSize:     1,
+		ReconciliationFetcher:  fetcher, Committer: committer,
+	}
+	err := r.reconcile()
+
+	assert.EqualError(t, err, "stop reconciling")
+	assert.True(t, fetchCalled)
+	configHistoryRetriever.AssertNotCalled(t, "MostRecentCollectionConfigBelow", mock.Anything, mock.Anything)
+}
+
 func TestReconciliationHappyPathWithoutScheduler(t *testing.T) {
 	// Scenario: happy path when trying to reconcile missing private data.
 	committer := &mocks.Committer{}
@@ -517,3 +567,110 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
+
+func TestReconcileBlockRange(t *testing.T) {
+	// Scenario: an on-demand reconciliation pass over a block range reconciles the missing
+	// private data in batches of ReconcileBatchSize blocks and updates the reconciliation status.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := ledger.MissingPvtDataInfo{
+		3: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			1: {{Collection: "col1", Namespace: "ns1"}},
+		},
+		5: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			2: {{Collection: "col1", Namespace: "ns1"}},
+		},
+	}
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{
+				{Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name: "col1",
+					},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(6)).Return(missingInfo, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var fetchedBlocks []uint64
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		result := &privdatacommon.FetchedPvtDataContainer{}
+		for digest := range dig2CollectionConfig {
+			fetchedBlocks = append(fetchedBlocks, digest.BlockSeq)
+			result.AvailableElements = append(result.AvailableElements, &gossip2.PvtDataElement{
+				Digest: &gossip2.PvtDataDigest{
+					TxId:       digest.TxId,
+					BlockSeq:   digest.BlockSeq,
+					Collection: digest.Collection,
+					Namespace:  digest.Namespace,
+					SeqInBlock: digest.SeqInBlock,
+				},
+				Payload: [][]byte{util2.ComputeSHA256([]byte("rws-pre-image"))},
+			})
+		}
+		return result
+	}, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Return([]*ledger.PvtdataHashMismatch{}, nil)
+
+	r := &Reconciler{
+		channel:                "mychannel",
+		metrics:                metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		ReconcileSleepInterval: time.Minute,
+		ReconcileBatchSize:     1,
+		ReconciliationFetcher:  fetcher, Committer: committer,
+	}
+
+	_, err := r.ReconcileBlockRange(6, 2)
+	assert.EqualError(t, err, "start block [6] is greater than end block [2]")
+
+	reconciled, err := r.ReconcileBlockRange(2, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reconciled)
+	assert.Equal(t, []uint64{5, 3}, fetchedBlocks)
+	committer.AssertNumberOfCalls(t, "CommitPvtDataOfOldBlocks", 2)
+
+	status := r.Status()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, uint64(2), status.TotalReconciled)
+	assert.False(t, status.LastReconciliation.IsZero())
+	assert.Empty(t, status.RecentFailures)
+}
+
+func TestReconciliationStatusRecordsFailures(t *testing.T) {
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("failed to obtain missing pvt data tracker"))
+
+	r := NewReconciler("", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	for i := 0; i < maxRecentReconciliationFailures+2; i++ {
+		assert.Error(t, r.reconcile())
+	}
+	_, err := r.ReconcileBlockRange(0, 10)
+	assert.EqualError(t, err, "failed to obtain missing pvt data tracker")
+
+	status := r.Status()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, uint64(0), status.TotalReconciled)
+	assert.Len(t, status.RecentFailures, maxRecentReconciliationFailures)
+	for _, failure := range status.RecentFailures {
+		assert.Equal(t, "failed to obtain missing pvt data tracker", failure.Error)
+	}
+
+	noop := &NoOpReconciler{}
+	assert.False(t, noop.Status().Enabled)
+	_, err = noop.ReconcileBlockRange(0, 10)
+	assert.EqualError(t, err, "private data reconciliation is disabled")
+}
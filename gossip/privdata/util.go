@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
@@ -226,6 +227,20 @@ func sampleCollHashedRwSet(collectionName string, hash []byte, hasWrites bool) *
 	return collHashedRwSet
 }
 
+// implicitCollectionConfig returns the config of the collection with the given
+// name if it is an implicit collection, and nil otherwise
+func implicitCollectionConfig(collectionName string) *common.CollectionConfig {
+	isImplicit, mspID := privdata.MspIDIfImplicitCollection(collectionName)
+	if !isImplicit {
+		return nil
+	}
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+		},
+	}
+}
+
 func extractCollectionConfig(configPackage *common.CollectionConfigPackage, collectionName string) *common.CollectionConfig {
 	for _, config := range configPackage.Config {
 		switch cconf := config.Payload.(type) {
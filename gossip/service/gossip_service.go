@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"sort"
 	"sync"
 
 	corecomm "github.com/hyperledger/fabric/core/comm"
@@ -15,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -129,6 +131,7 @@ type deliveryFactoryImpl struct {
 	credentialSupport     *corecomm.CredentialSupport
 	deliverClientDialOpts []grpc.DialOption
 	deliverServiceConfig  *deliverservice.DeliverServiceConfig
+	metrics               *gossipmetrics.DeliverMetrics
 }
 
 // Returns an instance of delivery client
@@ -147,6 +150,7 @@ func (df *deliveryFactoryImpl) Service(g GossipServiceAdapter, endpoints []strin
 		Signer:                df.signer,
 		DeliverClientDialOpts: df.deliverClientDialOpts,
 		DeliverServiceConfig:  df.deliverServiceConfig,
+		Metrics:               df.metrics,
 	})
 }
 
@@ -247,6 +251,7 @@ func New(
 			credentialSupport:     credSupport,
 			deliverClientDialOpts: deliverClientDialOpts,
 			deliverServiceConfig:  deliverServiceConfig,
+			metrics:               gossipMetrics.DeliverMetrics,
 		},
 		peerIdentity:  serializedIdentity,
 		secAdv:        secAdv,
@@ -277,6 +282,40 @@ func (g *GossipService) DistributePrivateData(channelID string, txID string, pri
 	return nil
 }
 
+// PrivateDataChannels returns the sorted IDs of the channels that have private data handlers
+func (g *GossipService) PrivateDataChannels() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	channels := make([]string, 0, len(g.privateHandlers))
+	for channelID := range g.privateHandlers {
+		channels = append(channels, channelID)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// MissingPvtDataTracker returns the tracker of missing private data of the given channel
+func (g *GossipService) MissingPvtDataTracker(channelID string) (ledger.MissingPvtDataTracker, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("No private data handler for %s", channelID)
+	}
+	return handler.support.Committer.GetMissingPvtDataTracker()
+}
+
+// PvtDataReconciler returns the private data reconciler of the given channel
+func (g *GossipService) PvtDataReconciler(channelID string) (gossipprivdata.PvtDataReconciler, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("No private data handler for %s", channelID)
+	}
+	return handler.reconciler, nil
+}
+
 // NewConfigEventer creates a ConfigProcessor which the channelconfig.BundleSource can ultimately route config updates to
 func (g *GossipService) NewConfigEventer() ConfigProcessor {
 	return newConfigEventer(g)
@@ -62,6 +62,10 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	return nil
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -85,7 +89,7 @@ func TestInitGossipService(t *testing.T) {
 	msptesttools.LoadMSPSetupForTesting()
 	signer := mgmt.GetLocalSigningIdentityOrPanic()
 
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(), nil, peergossip.PolicyQuorum)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	dialOpts := defaultDeliverClientDialOpts()
 	gossipConfig, err := gossip.GlobalConfig(endpoint, nil)
@@ -379,6 +383,22 @@ func (li *mockLedgerInfo) GetMissingPvtDataTracker() (ledger.MissingPvtDataTrack
 	panic("implement me")
 }
 
+func (li *mockLedgerInfo) SubmitSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (li *mockLedgerInfo) CancelSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (li *mockLedgerInfo) PendingSnapshotRequests() ([]uint64, error) {
+	panic("implement me")
+}
+
+func (li *mockLedgerInfo) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	panic("implement me")
+}
+
 func (li *mockLedgerInfo) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
 	panic("implement me")
 }
@@ -32,6 +32,10 @@ func (*transientStoreMock) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*transientStoreMock) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	return nil
+}
+
 func (*transientStoreMock) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
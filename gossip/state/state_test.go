@@ -192,6 +192,10 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) PurgeByPvtKeys(purgedKeys []*ledger.PurgedPvtKey) error {
+	return nil
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -274,6 +278,22 @@ func (mock *ramLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker,
 	panic("implement me")
 }
 
+func (mock *ramLedger) SubmitSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (mock *ramLedger) CancelSnapshotRequest(blockNumber uint64) error {
+	panic("implement me")
+}
+
+func (mock *ramLedger) PendingSnapshotRequests() ([]uint64, error) {
+	panic("implement me")
+}
+
+func (mock *ramLedger) GetPurgeRecords(startBlockNum, endBlockNum uint64) ([]*ledger.PurgedPvtKey, error) {
+	panic("implement me")
+}
+
 func (mock *ramLedger) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
 	panic("implement me")
 }
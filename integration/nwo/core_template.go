@@ -65,6 +65,9 @@ peer:
       reconcileBatchSize: 10
       reconcileSleepInterval: 10s
       reconciliationEnabled: true
+      implicitCollectionDisseminationPolicy:
+        requiredPeerCount: 0
+        maxPeerCount: 1
   events:
     address: 127.0.0.1:{{ .PeerPort Peer "Events" }}
     buffersize: 100
@@ -121,6 +124,11 @@ peer:
     authCacheMaxSize: 1000
     authCachePurgeRetentionRatio: 0.75
     orgMembersAllowedAccess: false
+  gateway:
+    enabled: true
+    endorsementTimeout: 30s
+    broadcastTimeout: 30s
+    dialTimeout: 2m
   limits:
     concurrency:
       qscc: 500
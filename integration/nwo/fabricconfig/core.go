@@ -49,6 +49,7 @@ type Peer struct {
 	Handlers               *Handlers       `yaml:"handlers,omitempty"`
 	ValidatorPoolSize      int             `yaml:"validatorPoolSize,omitempty"`
 	Discovery              *Discovery      `yaml:"discovery,omitempty"`
+	Gateway                *Gateway        `yaml:"gateway,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
@@ -181,6 +182,13 @@ type Discovery struct {
 	OrgMembersAllowedAccess      bool    `yaml:"orgMembersAllowedAccess"`
 }
 
+type Gateway struct {
+	Enabled            bool          `yaml:"enabled"`
+	EndorsementTimeout time.Duration `yaml:"endorsementTimeout,omitempty"`
+	BroadcastTimeout   time.Duration `yaml:"broadcastTimeout,omitempty"`
+	DialTimeout        time.Duration `yaml:"dialTimeout,omitempty"`
+}
+
 type VM struct {
 	Endpoint string  `yaml:"endpoint,omitempty"`
 	Docker   *Docker `yaml:"docker,omitempty"`
@@ -14,6 +14,9 @@ type Orderer struct {
 	RAMLedger  *RAMLedger         `yaml:"RAMLedger,omitempty"`
 	Kafka      *Kafka             `yaml:"Kafka,omitempty"`
 	Operations *OrdererOperations `yaml:"Operations,omitempty"`
+	Broadcast  *OrdererBroadcast  `yaml:"Broadcast,omitempty"`
+
+	ChannelParticipation *ChannelParticipation `yaml:"ChannelParticipation,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
@@ -126,3 +129,21 @@ type OrdererStatsd struct {
 	WriteInterval time.Duration `yaml:"WriteInterval,omitempty"`
 	Prefix        string        `yaml:"Prefix,omitempty"`
 }
+
+type ChannelParticipation struct {
+	Enabled            bool   `yaml:"Enabled"`
+	MaxRequestBodySize string `yaml:"MaxRequestBodySize,omitempty"`
+}
+
+type OrdererBroadcast struct {
+	MaxInFlight int           `yaml:"MaxInFlight,omitempty"`
+	RetryAfter  time.Duration `yaml:"RetryAfter,omitempty"`
+	Client      *RateLimit    `yaml:"Client,omitempty"`
+	MSP         *RateLimit    `yaml:"MSP,omitempty"`
+	Channel     *RateLimit    `yaml:"Channel,omitempty"`
+}
+
+type RateLimit struct {
+	Rate  float64 `yaml:"Rate"`
+	Burst int     `yaml:"Burst"`
+}
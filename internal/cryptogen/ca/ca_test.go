@@ -7,6 +7,8 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"crypto/x509"
 	"io/ioutil"
 	"net"
@@ -48,7 +50,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -63,6 +65,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	assert.NoError(t, err, "Error generating CA")
 
@@ -71,7 +74,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testName3,
 		nil,
 		nil,
-		&priv.PublicKey,
+		priv.Public(),
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -136,6 +139,7 @@ func TestNewCA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	assert.NoError(t, err, "Error generating CA")
 	assert.NotNil(t, rootCA, "Failed to return CA")
@@ -175,7 +179,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -190,6 +194,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	assert.NoError(t, err, "Error generating CA")
 
@@ -198,7 +203,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		priv.Public(),
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -213,7 +218,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		priv.Public(),
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -222,14 +227,14 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// make sure ous are correctly set
 	ous := []string{"TestOU", "PeerOU"}
-	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, priv.Public(),
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	assert.Contains(t, cert.Subject.OrganizationalUnit, ous[0])
 	assert.Contains(t, cert.Subject.OrganizationalUnit, ous[1])
 
 	// make sure sans are correctly set
 	sans := []string{testName2, testIP}
-	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, priv.Public(),
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	assert.Contains(t, cert.DNSNames, testName2)
 	assert.Contains(t, cert.IPAddresses, net.ParseIP(testIP).To4())
@@ -239,7 +244,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	assert.Equal(t, true, checkForFile(pemFile),
 		"Expected to find file "+pemFile)
 
-	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, &priv.PublicKey,
+	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, priv.Public(),
 		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
 	assert.Error(t, err, "Bad name should fail")
 
@@ -254,6 +259,55 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestGenerateSignCertificateED25519(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	require.NoError(t, err, "failed to create test directory")
+	defer os.RemoveAll(testDir)
+
+	certDir := filepath.Join(testDir, "certs")
+	require.NoError(t, os.Mkdir(certDir, 0755))
+
+	rootCA, err := ca.NewCA(
+		filepath.Join(testDir, "ca"),
+		testCAName,
+		testCAName,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		csp.ED25519,
+	)
+	require.NoError(t, err, "Error generating CA")
+	assert.Equal(t, x509.Ed25519, rootCA.SignCert.PublicKeyAlgorithm)
+	assert.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+	assert.NoError(t, rootCA.SignCert.CheckSignatureFrom(rootCA.SignCert))
+
+	ski := sha256.Sum256(rootCA.SignCert.PublicKey.(ed25519.PublicKey))
+	assert.Equal(t, ski[:], rootCA.SignCert.SubjectKeyId)
+
+	// an Ed25519 CA can certify both Ed25519 and EC keys
+	for _, keyAlg := range []string{csp.ED25519, csp.ECDSA} {
+		priv, err := csp.GeneratePrivateKey(certDir, keyAlg)
+		require.NoError(t, err)
+
+		cert, err := rootCA.SignCertificate(
+			certDir,
+			testName,
+			nil,
+			nil,
+			priv.Public(),
+			x509.KeyUsageDigitalSignature,
+			[]x509.ExtKeyUsage{},
+		)
+		require.NoError(t, err, "Failed to generate signed certificate")
+		assert.Equal(t, priv.Public(), cert.PublicKey)
+		assert.Equal(t, x509.PureEd25519, cert.SignatureAlgorithm)
+		assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+	}
+}
+
 func checkForFile(file string) bool {
 	if _, err := os.Stat(file); os.IsNotExist(err) {
 		return false
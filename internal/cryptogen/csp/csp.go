@@ -8,6 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -23,10 +24,17 @@ import (
 	"github.com/pkg/errors"
 )
 
+// The public key algorithms of the keys generated by GeneratePrivateKey.
+const (
+	ECDSA   = "ecdsa"
+	ED25519 = "ed25519"
+)
+
 // LoadPrivateKey loads a private key from a file in keystorePath.  It looks
-// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC private key.
-func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-	var priv *ecdsa.PrivateKey
+// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC or Ed25519
+// private key.
+func LoadPrivateKey(keystorePath string) (crypto.Signer, error) {
+	var priv crypto.Signer
 
 	walkFunc := func(path string, info os.FileInfo, pathErr error) error {
 
@@ -55,7 +63,7 @@ func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
-func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
+func parsePrivateKeyPEM(rawKey []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(rawKey)
 	if block == nil {
 		return nil, errors.New("bytes are not PEM encoded")
@@ -66,18 +74,31 @@ func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
 		return nil, errors.WithMessage(err, "pem bytes are not PKCS8 encoded ")
 	}
 
-	priv, ok := key.(*ecdsa.PrivateKey)
-	if !ok {
-		return nil, errors.New("pem bytes do not contain an EC private key")
+	switch priv := key.(type) {
+	case *ecdsa.PrivateKey:
+		return priv, nil
+	case ed25519.PrivateKey:
+		return priv, nil
+	default:
+		return nil, errors.New("pem bytes do not contain an EC or Ed25519 private key")
 	}
-	return priv, nil
 }
 
-// GeneratePrivateKey creates an EC private key using a P-256 curve and stores
-// it in keystorePath.
-func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-
-	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// GeneratePrivateKey creates a private key using the given public key
+// algorithm and stores it in keystorePath. EC keys use a P-256 curve, an
+// empty algorithm defaults to ECDSA.
+func GeneratePrivateKey(keystorePath, keyAlg string) (crypto.Signer, error) {
+	var priv crypto.Signer
+	var err error
+
+	switch keyAlg {
+	case "", ECDSA:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ED25519:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported public key algorithm: %s", keyAlg)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to generate private key")
 	}
@@ -98,6 +119,16 @@ func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
+// NewSigner returns the crypto.Signer to use with the given private key.
+// EC keys are wrapped in an ECDSASigner so that their signatures use the
+// Low S value, the other keys sign on their own.
+func NewSigner(priv crypto.Signer) crypto.Signer {
+	if ecdsaPriv, ok := priv.(*ecdsa.PrivateKey); ok {
+		return &ECDSASigner{PrivateKey: ecdsaPriv}
+	}
+	return priv
+}
+
 /**
 ECDSA signer implements the crypto.Signer interface for ECDSA keys.  The
 Sign method ensures signatures are created with Low S values since Fabric
@@ -7,6 +7,7 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -25,22 +26,26 @@ import (
 )
 
 func TestLoadPrivateKey(t *testing.T) {
-	testDir, err := ioutil.TempDir("", "csp-test")
-	if err != nil {
-		t.Fatalf("Failed to create test directory: %s", err)
-	}
-	defer os.RemoveAll(testDir)
-	priv, err := csp.GeneratePrivateKey(testDir)
-	if err != nil {
-		t.Fatalf("Failed to generate private key: %s", err)
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		t.Run(keyAlg, func(t *testing.T) {
+			testDir, err := ioutil.TempDir("", "csp-test")
+			if err != nil {
+				t.Fatalf("Failed to create test directory: %s", err)
+			}
+			defer os.RemoveAll(testDir)
+			priv, err := csp.GeneratePrivateKey(testDir, keyAlg)
+			if err != nil {
+				t.Fatalf("Failed to generate private key: %s", err)
+			}
+			pkFile := filepath.Join(testDir, "priv_sk")
+			assert.Equal(t, true, checkForFile(pkFile),
+				"Expected to find private key file")
+			loadedPriv, err := csp.LoadPrivateKey(testDir)
+			assert.NoError(t, err, "Failed to load private key")
+			assert.NotNil(t, loadedPriv, "Should have returned a private key")
+			assert.Equal(t, priv, loadedPriv, "Expected private keys to match")
+		})
 	}
-	pkFile := filepath.Join(testDir, "priv_sk")
-	assert.Equal(t, true, checkForFile(pkFile),
-		"Expected to find private key file")
-	loadedPriv, err := csp.LoadPrivateKey(testDir)
-	assert.NoError(t, err, "Failed to load private key")
-	assert.NotNil(t, loadedPriv, "Should have returned an *ecdsa.PrivateKey")
-	assert.Equal(t, priv, loadedPriv, "Expected private keys to match")
 }
 
 func TestLoadPrivateKey_BadPEM(t *testing.T) {
@@ -80,9 +85,9 @@ func TestLoadPrivateKey_BadPEM(t *testing.T) {
 			errMsg: fmt.Sprintf("%s: bytes are not PEM encoded", badPEMFile),
 		},
 		{
-			name:   "not EC key",
+			name:   "not EC or Ed25519 key",
 			data:   pkcs8RSAPem,
-			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC private key", badPEMFile),
+			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC or Ed25519 private key", badPEMFile),
 		},
 		{
 			name:   "not PKCS8 encoded",
@@ -114,16 +119,33 @@ func TestGeneratePrivateKey(t *testing.T) {
 	defer os.RemoveAll(testDir)
 
 	expectedFile := filepath.Join(testDir, "priv_sk")
-	priv, err := csp.GeneratePrivateKey(testDir)
+	priv, err := csp.GeneratePrivateKey(testDir, "")
 	assert.NoError(t, err, "Failed to generate private key")
-	assert.NotNil(t, priv, "Should have returned an *ecdsa.Key")
+	assert.IsType(t, &ecdsa.PrivateKey{}, priv, "Should have returned an *ecdsa.PrivateKey")
 	assert.Equal(t, true, checkForFile(expectedFile),
 		"Expected to find private key file")
 
-	priv, err = csp.GeneratePrivateKey("notExist")
+	priv, err = csp.GeneratePrivateKey(testDir, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate private key")
+	assert.IsType(t, ed25519.PrivateKey{}, priv, "Should have returned an ed25519.PrivateKey")
+
+	priv, err = csp.GeneratePrivateKey(testDir, "rsa")
+	assert.EqualError(t, err, "unsupported public key algorithm: rsa")
+
+	priv, err = csp.GeneratePrivateKey("notExist", csp.ECDSA)
 	assert.Contains(t, err.Error(), "no such file or directory")
 }
 
+func TestNewSigner(t *testing.T) {
+	ecdsaPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, &csp.ECDSASigner{PrivateKey: ecdsaPriv}, csp.NewSigner(ecdsaPriv))
+
+	_, ed25519Priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, ed25519Priv, csp.NewSigner(ed25519Priv))
+}
+
 func TestECDSASigner(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
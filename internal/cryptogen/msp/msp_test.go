@@ -6,12 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 package msp_test
 
 import (
+	"crypto/ed25519"
+	"crypto/tls"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/hyperledger/fabric/internal/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
 	"github.com/stretchr/testify/assert"
@@ -36,7 +39,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 	cleanup(testDir)
 
-	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, true)
+	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, msp.PEER, true, csp.ECDSA)
 	assert.Error(t, err, "Empty CA should have failed")
 
 	caDir := filepath.Join(testDir, "ca")
@@ -45,10 +48,10 @@ func TestGenerateLocalMSP(t *testing.T) {
 	tlsDir := filepath.Join(testDir, "tls")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	assert.NoError(t, err, "Error generating CA")
 
 	assert.NotEmpty(t, signCA.SignCert.Subject.Country, "country cannot be empty.")
@@ -65,7 +68,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 	assert.Equal(t, testPostalCode, signCA.SignCert.Subject.PostalCode[0], "Failed to match postalCode")
 
 	// generate local MSP for nodeType=PEER
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, true, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate local MSP")
 
 	// check to see that the right files were generated/saved
@@ -93,7 +96,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 	}
 
 	// generate local MSP for nodeType=CLIENT
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate local MSP")
 	//only need to check for the TLS certs
 	tlsFiles = []string{
@@ -108,10 +111,10 @@ func TestGenerateLocalMSP(t *testing.T) {
 	}
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.CLIENT, true, csp.ECDSA)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, true)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.ORDERER, true, csp.ECDSA)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
@@ -124,13 +127,13 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
 	assert.NoError(t, err, "Error generating CA")
 
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true, csp.ECDSA)
 	assert.NoError(t, err, "Failed to generate verifying MSP")
 
 	// check to see that the right files were generated/saved
@@ -147,15 +150,50 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	}
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true, csp.ECDSA)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
+	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true, csp.ECDSA)
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
 }
 
+func TestGenerateLocalMSPED25519(t *testing.T) {
+	dir, err := ioutil.TempDir("", "msp-test-ed25519")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(dir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+
+	nodeDir := filepath.Join(dir, testName)
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, signCA, tlsCA, msp.PEER, false, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	// the TLS key pair is Ed25519
+	tlsCert, err := tls.LoadX509KeyPair(filepath.Join(nodeDir, "tls", "server.crt"), filepath.Join(nodeDir, "tls", "server.key"))
+	assert.NoError(t, err)
+	assert.IsType(t, ed25519.PrivateKey{}, tlsCert.PrivateKey)
+
+	// the local MSP can be loaded, and its signing identity is valid
+	mspConf, err := fabricmsp.GetLocalMspConfig(filepath.Join(nodeDir, "msp"), nil, "ED25519MSP")
+	assert.NoError(t, err, "Failed to load local MSP config")
+	localMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_3}})
+	assert.NoError(t, err)
+	err = localMSP.Setup(mspConf)
+	assert.NoError(t, err, "Failed to set up local MSP")
+
+	id, err := localMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	sig, err := id.Sign([]byte("foo"))
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify([]byte("foo"), sig))
+}
+
 func TestExportConfig(t *testing.T) {
 	path := filepath.Join(testDir, "export-test")
 	configFile := filepath.Join(path, "config.yaml")
@@ -178,14 +178,20 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 	return nil
 }
 
+type endorsementPolicyJson struct {
+	SignaturePolicy     string `json:"signaturePolicy,omitempty"`
+	ChannelConfigPolicy string `json:"channelConfigPolicy,omitempty"`
+}
+
 type collectionConfigJson struct {
-	Name            string `json:"name"`
-	Policy          string `json:"policy"`
-	RequiredCount   int32  `json:"requiredPeerCount"`
-	MaxPeerCount    int32  `json:"maxPeerCount"`
-	BlockToLive     uint64 `json:"blockToLive"`
-	MemberOnlyRead  bool   `json:"memberOnlyRead"`
-	MemberOnlyWrite bool   `json:"memberOnlyWrite"`
+	Name              string                 `json:"name"`
+	Policy            string                 `json:"policy"`
+	RequiredCount     int32                  `json:"requiredPeerCount"`
+	MaxPeerCount      int32                  `json:"maxPeerCount"`
+	BlockToLive       uint64                 `json:"blockToLive"`
+	MemberOnlyRead    bool                   `json:"memberOnlyRead"`
+	MemberOnlyWrite   bool                   `json:"memberOnlyWrite"`
+	EndorsementPolicy *endorsementPolicyJson `json:"endorsementPolicy,omitempty"`
 }
 
 // GetCollectionConfigFromFile retrieves the collection configuration
@@ -223,6 +229,14 @@ func getCollectionConfigFromBytes(cconfBytes []byte) (*pcommon.CollectionConfigP
 			},
 		}
 
+		var ep *pcommon.CollectionEndorsementPolicy
+		if cconfitem.EndorsementPolicy != nil {
+			ep, err = getCollectionEndorsementPolicy(cconfitem.EndorsementPolicy)
+			if err != nil {
+				return nil, nil, errors.WithMessagef(err, "invalid endorsement policy for collection %s", cconfitem.Name)
+			}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -233,6 +247,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) (*pcommon.CollectionConfigP
 					BlockToLive:       cconfitem.BlockToLive,
 					MemberOnlyRead:    cconfitem.MemberOnlyRead,
 					MemberOnlyWrite:   cconfitem.MemberOnlyWrite,
+					EndorsementPolicy: ep,
 				},
 			},
 		}
@@ -245,6 +260,37 @@ func getCollectionConfigFromBytes(cconfBytes []byte) (*pcommon.CollectionConfigP
 	return ccp, ccpBytes, err
 }
 
+// getCollectionEndorsementPolicy converts the endorsement policy of a collection
+// as found in the collection configuration file into its protobuf representation;
+// exactly one of the signature policy and the channel config policy must be set
+func getCollectionEndorsementPolicy(epJson *endorsementPolicyJson) (*pcommon.CollectionEndorsementPolicy, error) {
+	if epJson.SignaturePolicy != "" && epJson.ChannelConfigPolicy != "" {
+		return nil, errors.New("cannot specify both a signature policy and a channel config policy")
+	}
+
+	if epJson.SignaturePolicy != "" {
+		p, err := cauthdsl.FromString(epJson.SignaturePolicy)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid signature policy %s", epJson.SignaturePolicy)
+		}
+		return &pcommon.CollectionEndorsementPolicy{
+			Type: &pcommon.CollectionEndorsementPolicy_SignaturePolicy{
+				SignaturePolicy: p,
+			},
+		}, nil
+	}
+
+	if epJson.ChannelConfigPolicy != "" {
+		return &pcommon.CollectionEndorsementPolicy{
+			Type: &pcommon.CollectionEndorsementPolicy_ChannelConfigPolicyReference{
+				ChannelConfigPolicyReference: epJson.ChannelConfigPolicy,
+			},
+		}, nil
+	}
+
+	return nil, errors.New("either a signature policy or a channel config policy must be specified")
+}
+
 func checkChaincodeCmdParams(cmd *cobra.Command) error {
 	// we need chaincode name for everything, including deploy
 	if chaincodeName == common.UndefinedParamValue {
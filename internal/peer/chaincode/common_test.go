@@ -240,6 +240,53 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Nil(t, ccpBytes)
 }
 
+const sampleCollectionConfigWithEndorsementPolicy = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": {
+			"signaturePolicy": "AND('A.peer', 'B.peer')"
+		}
+	},
+	{
+		"name": "bar",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": {
+			"channelConfigPolicy": "/Channel/Application/Endorsement"
+		}
+	}
+]`
+
+func TestCollectionEndorsementPolicyParsing(t *testing.T) {
+	ccp, _, err := getCollectionConfigFromBytes([]byte(sampleCollectionConfigWithEndorsementPolicy))
+	assert.NoError(t, err)
+	pol, _ := cauthdsl.FromString("AND('A.peer', 'B.peer')")
+	assert.True(t, proto.Equal(pol, ccp.Config[0].GetStaticCollectionConfig().EndorsementPolicy.GetSignaturePolicy()))
+	assert.Equal(t, "/Channel/Application/Endorsement", ccp.Config[1].GetStaticCollectionConfig().EndorsementPolicy.GetChannelConfigPolicyReference())
+
+	ccp, _, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigGood))
+	assert.NoError(t, err)
+	assert.Nil(t, ccp.Config[0].GetStaticCollectionConfig().EndorsementPolicy)
+
+	for _, testCase := range []struct {
+		endorsementPolicy string
+		expectedErr       string
+	}{
+		{`{}`, "invalid endorsement policy for collection foo: either a signature policy or a channel config policy must be specified"},
+		{`{"signaturePolicy": "barf"}`, "invalid endorsement policy for collection foo: invalid signature policy barf"},
+		{`{"signaturePolicy": "OR('A.peer')", "channelConfigPolicy": "/Channel/Application/Endorsement"}`, "invalid endorsement policy for collection foo: cannot specify both a signature policy and a channel config policy"},
+	} {
+		collConfig := `[{"name": "foo", "policy": "OR('A.member')", "endorsementPolicy": ` + testCase.endorsementPolicy + `}]`
+		_, _, err = getCollectionConfigFromBytes([]byte(collConfig))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), testCase.expectedErr)
+	}
+}
+
 func TestValidatePeerConnectionParams(t *testing.T) {
 	defer resetFlags()
 	defer viper.Reset()
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -43,6 +44,16 @@ var (
 
 	// fetch related variables
 	bestEffort bool
+
+	// missingpvtdata related variables
+	opsAddress  string
+	opsTLS      bool
+	opsCAFile   string
+	opsCertFile string
+	opsKeyFile  string
+	reconcile   bool
+	startBlock  uint64
+	endBlock    uint64
 )
 
 // Cmd returns the cobra command for Node
@@ -56,6 +67,7 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(missingPvtDataCmd(cf))
 
 	return channelCmd
 }
@@ -81,6 +93,14 @@ func resetFlags() {
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 10*time.Second, "Channel creation timeout")
 	flags.BoolVarP(&bestEffort, "bestEffort", "", false, "Whether fetch requests should ignore errors and return blocks on a best effort basis")
+	flags.StringVarP(&opsAddress, "opsAddress", "", "", "Address of the peer operations service (default operations.listenAddress)")
+	flags.BoolVarP(&opsTLS, "opsTLS", "", false, "Use TLS when communicating with the peer operations service")
+	flags.StringVarP(&opsCAFile, "opsCAFile", "", "", "Path to file containing PEM-encoded trusted certificate(s) for the peer operations service")
+	flags.StringVarP(&opsCertFile, "opsCertFile", "", "", "Path to file containing PEM-encoded X509 client certificate for the peer operations service")
+	flags.StringVarP(&opsKeyFile, "opsKeyFile", "", "", "Path to file containing PEM-encoded private key for the peer operations service client certificate")
+	flags.BoolVarP(&reconcile, "reconcile", "", false, "Trigger an immediate reconciliation pass of the missing private data of the channel")
+	flags.Uint64VarP(&startBlock, "startBlock", "", 0, "First block of the range to reconcile")
+	flags.Uint64VarP(&endBlock, "endBlock", "", math.MaxUint64, "Last block of the range to reconcile (default the last committed block)")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -96,8 +116,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|missingpvtdata.",
+	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|missingpvtdata.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// opsTimeout bounds requests to the operations service; it matches the
+// write timeout of the operations server since a reconciliation pass can be long
+const opsTimeout = 2 * time.Minute
+
+func missingPvtDataCmd(cf *ChannelCmdFactory) *cobra.Command {
+	missingPvtDataCmd := &cobra.Command{
+		Use:   "missingpvtdata",
+		Short: "Reports the private data missing on the peer.",
+		Long: "Reports, per channel, namespace and collection, the private data missing on the peer along with " +
+			"the reconciliation progress. Reports on all channels unless '-c' is given. With '--reconcile', triggers " +
+			"an immediate reconciliation pass for the blocks in the range ['--startBlock', '--endBlock'] of the channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return missingPvtData(cmd)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"opsAddress",
+		"opsTLS",
+		"opsCAFile",
+		"opsCertFile",
+		"opsKeyFile",
+		"reconcile",
+		"startBlock",
+		"endBlock",
+	}
+	attachFlags(missingPvtDataCmd, flagList)
+
+	return missingPvtDataCmd
+}
+
+func missingPvtData(cmd *cobra.Command) error {
+	if reconcile && channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID to reconcile")
+	}
+	if reconcile && startBlock > endBlock {
+		return errors.Errorf("start block [%d] is greater than end block [%d]", startBlock, endBlock)
+	}
+	address := opsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	if address == "" {
+		return errors.New("Must supply the address of the peer operations service")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	client, scheme, err := opsHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s://%s%s", scheme, address, httpadmin.URLBaseV1)
+	if channelID != common.UndefinedParamValue {
+		url = fmt.Sprintf("%s/%s", url, channelID)
+	}
+
+	var resp *http.Response
+	if reconcile {
+		payload, err := json.Marshal(&httpadmin.ReconcileRequest{StartBlock: startBlock, EndBlock: endBlock})
+		if err != nil {
+			return err
+		}
+		resp, err = client.Post(url+"/reconcile", "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return errors.Wrap(err, "failed to reach the peer operations service")
+		}
+	} else {
+		resp, err = client.Get(url)
+		if err != nil {
+			return errors.Wrap(err, "failed to reach the peer operations service")
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the response of the peer operations service")
+	}
+	if resp.StatusCode != http.StatusOK {
+		errResp := &httpadmin.ErrorResponse{}
+		if err := json.Unmarshal(body, errResp); err != nil || errResp.Error == "" {
+			return errors.Errorf("received bad response, status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+		}
+		return errors.Errorf("received bad response, status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	return printIndentedJSON(cmd.OutOrStdout(), body)
+}
+
+func opsHTTPClient() (*http.Client, string, error) {
+	client := &http.Client{Timeout: opsTimeout}
+	if !opsTLS {
+		return client, "http", nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if opsCAFile != "" {
+		caPEM, err := ioutil.ReadFile(opsCAFile)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to read operations CA file")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, "", errors.Errorf("no certificates found in operations CA file %s", opsCAFile)
+		}
+	}
+	if opsCertFile != "" || opsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opsCertFile, opsKeyFile)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to load operations client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, "https", nil
+}
+
+func printIndentedJSON(w io.Writer, body []byte) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, bytes.TrimSpace(body), "", "  "); err != nil {
+		return errors.Wrap(err, "received malformed response from the peer operations service")
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/privdata/httpadmin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingPvtData(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		switch r.URL.Path {
+		case "/pvtdata", "/pvtdata/mychannel":
+			w.Write([]byte(`{"channel":"mychannel","collections":[]}`))
+		case "/pvtdata/mychannel/reconcile":
+			w.Write([]byte(`{"reconciled":3}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"channel unknown not found"}`))
+		}
+	}))
+	defer server.Close()
+	opsAddr := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("all channels", func(t *testing.T) {
+		resetFlags()
+		requests, bodies = nil, nil
+		cmd := missingPvtDataCmd(nil)
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"--opsAddress", opsAddr})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Len(t, requests, 1)
+		assert.Equal(t, http.MethodGet, requests[0].Method)
+		assert.Equal(t, "/pvtdata", requests[0].URL.Path)
+		assert.Equal(t, "{\n  \"channel\": \"mychannel\",\n  \"collections\": []\n}\n", out.String())
+	})
+
+	t.Run("single channel", func(t *testing.T) {
+		resetFlags()
+		requests, bodies = nil, nil
+		cmd := missingPvtDataCmd(nil)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel", "--opsAddress", opsAddr})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "/pvtdata/mychannel", requests[0].URL.Path)
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		resetFlags()
+		requests, bodies = nil, nil
+		cmd := missingPvtDataCmd(nil)
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"-c", "mychannel", "--opsAddress", opsAddr, "--reconcile", "--startBlock", "5", "--endBlock", "20"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Len(t, requests, 1)
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Equal(t, "/pvtdata/mychannel/reconcile", requests[0].URL.Path)
+		reconcileReq := &httpadmin.ReconcileRequest{}
+		assert.NoError(t, json.Unmarshal([]byte(bodies[0]), reconcileReq))
+		assert.Equal(t, &httpadmin.ReconcileRequest{StartBlock: 5, EndBlock: 20}, reconcileReq)
+		assert.Equal(t, "{\n  \"reconciled\": 3\n}\n", out.String())
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		resetFlags()
+		cmd := missingPvtDataCmd(nil)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "unknown", "--opsAddress", opsAddr})
+
+		assert.EqualError(t, cmd.Execute(), "received bad response, status 404: channel unknown not found")
+	})
+
+	t.Run("reconcile without channel", func(t *testing.T) {
+		resetFlags()
+		cmd := missingPvtDataCmd(nil)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--opsAddress", opsAddr, "--reconcile"})
+
+		assert.EqualError(t, cmd.Execute(), "Must supply channel ID to reconcile")
+	})
+
+	t.Run("invalid block range", func(t *testing.T) {
+		resetFlags()
+		cmd := missingPvtDataCmd(nil)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel", "--opsAddress", opsAddr, "--reconcile", "--startBlock", "20", "--endBlock", "5"})
+
+		assert.EqualError(t, cmd.Execute(), "start block [20] is greater than end block [5]")
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		resetFlags()
+		cmd := missingPvtDataCmd(nil)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--opsAddress", opsAddr, "--opsTLS", "--opsCAFile", "/does/not/exist"})
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read operations CA file")
+	})
+}
@@ -8,11 +8,19 @@ package gossip
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -22,12 +30,49 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	pcommon "github.com/hyperledger/fabric/protos/common"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
 var mcsLogger = flogging.MustGetLogger("peer.gossip.mcs")
 
+// ConsenterQuorum is the number of distinct consenters of a BFT ordering service
+// that must have signed a block, on top of the block validation policy, for the
+// block to be considered valid.
+type ConsenterQuorum int
+
+const (
+	// PolicyQuorum only requires the block validation policy to be satisfied.
+	PolicyQuorum ConsenterQuorum = iota
+	// FPlusOneQuorum requires f+1 consenters to sign a block, so that at
+	// least one correct consenter vouches for it.
+	FPlusOneQuorum
+	// TwoFPlusOneQuorum requires 2f+1 consenters to sign a block, which is
+	// the quorum the consenters need in order to decide on it.
+	TwoFPlusOneQuorum
+)
+
+// ParseConsenterQuorum converts the textual representation of a consenter
+// quorum, as it appears in the peer configuration, to a ConsenterQuorum.
+func ParseConsenterQuorum(quorum string) (ConsenterQuorum, error) {
+	switch quorum {
+	case "", "policy":
+		return PolicyQuorum, nil
+	case "f+1":
+		return FPlusOneQuorum, nil
+	case "2f+1":
+		return TwoFPlusOneQuorum, nil
+	default:
+		return PolicyQuorum, errors.Errorf("unknown consenter quorum %s, expected one of policy, f+1 or 2f+1", quorum)
+	}
+}
+
+// ChannelConfigGetter returns the configuration of a channel,
+// or nil if the channel does not exist.
+type ChannelConfigGetter func(channelID string) channelconfig.Resources
+
 // MSPMessageCryptoService implements the MessageCryptoService interface
 // using the peer MSPs (local and channel-related)
 //
@@ -41,6 +86,8 @@ type MSPMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                identity.SignerSerializer
 	deserializer               mgmt.DeserializersManager
+	channelConfigGetter        ChannelConfigGetter
+	consenterQuorum            ConsenterQuorum
 }
 
 // NewMCS creates a new instance of MSPMessageCryptoService
@@ -49,15 +96,21 @@ type MSPMessageCryptoService struct {
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of identity.SignerSerializer
 // 3. an identity deserializer manager
+// 4. a ChannelConfigGetter that gives access to the consenters of BFT channels
+// 5. the number of distinct consenters that must sign blocks of BFT channels
 func NewMCS(
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter,
 	localSigner identity.SignerSerializer,
 	deserializer mgmt.DeserializersManager,
+	channelConfigGetter ChannelConfigGetter,
+	consenterQuorum ConsenterQuorum,
 ) *MSPMessageCryptoService {
 	return &MSPMessageCryptoService{
 		channelPolicyManagerGetter: channelPolicyManagerGetter,
 		localSigner:                localSigner,
 		deserializer:               deserializer,
+		channelConfigGetter:        channelConfigGetter,
+		consenterQuorum:            consenterQuorum,
 	}
 }
 
@@ -141,9 +194,46 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 		return fmt.Errorf("Invalid block's channel id. Expected [%s]. Given [%s]", chainID, channelID)
 	}
 
-	// - Unmarshal medatada
+	// - Verify that Header.DataHash is equal to the hash of block.Data
+	// This is to ensure that the header is consistent with the data carried by this block
+	if !bytes.Equal(protoutil.BlockDataHash(block.Data), block.Header.DataHash) {
+		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
+	}
+
+	return s.verifyHeaderSignatures(channelID, block)
+}
+
+// VerifyHeader returns nil if the header of the block is signed by the
+// consenter quorum of a BFT ordering service. Unlike VerifyBlock, it does
+// not require the block to carry its data, and can therefore verify blocks
+// that were delivered with only their header and signatures.
+// Since a header that satisfies only the block validation policy may have
+// been forged by a single ordering service node, VerifyHeader fails if no
+// consenter quorum is configured, or if the channel is not ordered by a BFT
+// ordering service.
+func (s *MSPMessageCryptoService) VerifyHeader(chainID string, block *pcommon.Block) error {
+	if block == nil || block.Header == nil {
+		return fmt.Errorf("Invalid Block on channel [%s]. Header must be different from nil.", chainID)
+	}
+
+	if s.consenterQuorum == PolicyQuorum {
+		return errors.Errorf("cannot verify the header of block [%d] of channel [%s] without a consenter quorum", block.Header.Number, chainID)
+	}
+	consenters, err := s.consentersOf(chainID)
+	if err != nil {
+		return err
+	}
+	if len(consenters) == 0 {
+		return errors.Errorf("cannot verify the header of block [%d] of channel [%s], which is not ordered by a BFT ordering service", block.Header.Number, chainID)
+	}
+
+	return s.verifyHeaderSignatures(chainID, block)
+}
+
+func (s *MSPMessageCryptoService) verifyHeaderSignatures(channelID string, block *pcommon.Block) error {
+	// - Unmarshal medatata
 	if block.Metadata == nil || len(block.Metadata.Metadata) == 0 {
-		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, chainID)
+		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, channelID)
 	}
 
 	metadata, err := protoutil.GetMetadataFromBlock(block, pcommon.BlockMetadataIndex_SIGNATURES)
@@ -151,12 +241,6 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 		return fmt.Errorf("Failed unmarshalling medatata for signatures [%s]", err)
 	}
 
-	// - Verify that Header.DataHash is equal to the hash of block.Data
-	// This is to ensure that the header is consistent with the data carried by this block
-	if !bytes.Equal(protoutil.BlockDataHash(block.Data), block.Header.DataHash) {
-		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
-	}
-
 	// - Get Policy for block validation
 
 	// Get the policy manager for channelID
@@ -176,7 +260,7 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 	for _, metadataSignature := range metadata.Signatures {
 		shdr, err := protoutil.GetSignatureHeader(metadataSignature.SignatureHeader)
 		if err != nil {
-			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, chainID, err)
+			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, channelID, err)
 		}
 		signatureSet = append(
 			signatureSet,
@@ -189,7 +273,121 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 	}
 
 	// - Evaluate policy
-	return policy.Evaluate(signatureSet)
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return err
+	}
+
+	return s.verifyConsenterSignatures(channelID, block.Header.Number, signatureSet)
+}
+
+// verifyConsenterSignatures checks that enough distinct consenters of a BFT
+// ordering service have signed the block. Blocks of channels that are not
+// ordered by a BFT ordering service are not subject to this check.
+func (s *MSPMessageCryptoService) verifyConsenterSignatures(channelID string, blockNum uint64, signatureSet []*protoutil.SignedData) error {
+	if s.consenterQuorum == PolicyQuorum {
+		return nil
+	}
+
+	consenters, err := s.consentersOf(channelID)
+	if err != nil {
+		return err
+	}
+	if len(consenters) == 0 {
+		mcsLogger.Debugf("Channel [%s] is not ordered by a BFT ordering service, skipping consenter signatures verification", channelID)
+		return nil
+	}
+
+	n := len(consenters)
+	f := (n - 1) / 3
+	required := f + 1
+	if s.consenterQuorum == TwoFPlusOneQuorum {
+		// For n = 3f+1 this is 2f+1, and in general it is
+		// the smallest number of nodes that any two quorums intersect in a correct node
+		required = (n + f + 2) / 2
+	}
+
+	signers := make(map[uint64]struct{})
+	for _, sd := range signatureSet {
+		consenter := consenterOf(consenters, sd.Identity)
+		if consenter == nil {
+			continue
+		}
+		if _, exists := signers[consenter.Id]; exists {
+			continue
+		}
+		if err := verifyConsenterSignature(consenter, sd); err != nil {
+			mcsLogger.Warningf("Invalid signature of consenter %d on block [%d] of channel [%s]: %s", consenter.Id, blockNum, channelID, err)
+			continue
+		}
+		signers[consenter.Id] = struct{}{}
+	}
+
+	if len(signers) < required {
+		return errors.Errorf("block [%d] of channel [%s] is signed by %d consenters out of %d, but %d are required", blockNum, channelID, len(signers), n, required)
+	}
+	return nil
+}
+
+func (s *MSPMessageCryptoService) consentersOf(channelID string) ([]*bft.Consenter, error) {
+	if s.channelConfigGetter == nil {
+		return nil, nil
+	}
+	resources := s.channelConfigGetter(channelID)
+	if resources == nil {
+		return nil, errors.Errorf("could not acquire the configuration of channel %s", channelID)
+	}
+	ordererConfig, ok := resources.OrdererConfig()
+	if !ok || ordererConfig.ConsensusType() != "BFT" {
+		return nil, nil
+	}
+	metadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(ordererConfig.ConsensusMetadata(), metadata); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the BFT consensus metadata of channel %s", channelID)
+	}
+	return metadata.Consenters, nil
+}
+
+func consenterOf(consenters []*bft.Consenter, serializedIdentity []byte) *bft.Consenter {
+	sID := &pmsp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return nil
+	}
+	for _, consenter := range consenters {
+		if consenter.MspId == sID.Mspid && bytes.Equal(consenter.Identity, sID.IdBytes) {
+			return consenter
+		}
+	}
+	return nil
+}
+
+func verifyConsenterSignature(consenter *bft.Consenter, sd *protoutil.SignedData) error {
+	bl, _ := pem.Decode(consenter.Identity)
+	if bl == nil {
+		return errors.New("identity is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing identity")
+	}
+	switch publicKey := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		r, sigS, err := utils.UnmarshalECDSASignature(sd.Signature)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256(sd.Data)
+		if !ecdsa.Verify(publicKey, digest[:], r, sigS) {
+			return errors.New("signature does not verify")
+		}
+	case ed25519.PublicKey:
+		// Ed25519 identities sign the data itself rather than its digest
+		if !ed25519.Verify(publicKey, sd.Data, sd.Signature) {
+			return errors.New("signature does not verify")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+	return nil
 }
 
 // Sign signs msg with this peer's signing key and outputs
@@ -7,7 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package gossip
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
@@ -16,15 +23,21 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/internal/peer/gossip/mocks"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
 	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	protospeer "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +60,8 @@ func TestPKIidOfCert(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		signer,
 		deserializersManager,
+		nil,
+		PolicyQuorum,
 	)
 
 	peerIdentity := []byte("Alice")
@@ -77,7 +92,7 @@ func TestPKIidOfCert(t *testing.T) {
 
 func TestPKIidOfNil(t *testing.T) {
 	signer := &mocks.SignerSerializer{}
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager())
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(), nil, PolicyQuorum)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -97,6 +112,8 @@ func TestValidateIdentity(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		signer,
 		deserializersManager,
+		nil,
+		PolicyQuorum,
 	)
 
 	err := msgCryptoService.ValidateIdentity([]byte("Alice"))
@@ -130,6 +147,8 @@ func TestSign(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetter{},
 		signer,
 		mgmt.NewDeserializersManager(),
+		nil,
+		PolicyQuorum,
 	)
 
 	msg := []byte("Hello World!!!")
@@ -163,6 +182,8 @@ func TestVerify(t *testing.T) {
 				"C": &mocks.IdentityDeserializer{Identity: []byte("Dave"), Msg: []byte("msg4"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
+		PolicyQuorum,
 	)
 
 	msg := []byte("msg1")
@@ -218,6 +239,8 @@ func TestVerifyBlock(t *testing.T) {
 				"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
+		PolicyQuorum,
 	)
 
 	// - Prepare testing valid block, Alice signs it.
@@ -297,6 +320,207 @@ func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner *mocks.S
 	return blockRaw, msg
 }
 
+func TestParseConsenterQuorum(t *testing.T) {
+	for value, expected := range map[string]ConsenterQuorum{
+		"":       PolicyQuorum,
+		"policy": PolicyQuorum,
+		"f+1":    FPlusOneQuorum,
+		"2f+1":   TwoFPlusOneQuorum,
+	} {
+		quorum, err := ParseConsenterQuorum(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, quorum)
+	}
+
+	_, err := ParseConsenterQuorum("majority")
+	assert.EqualError(t, err, "unknown consenter quorum majority, expected one of policy, f+1 or 2f+1")
+}
+
+type consenterSigner struct {
+	consenter *bft.Consenter
+	key       *ecdsa.PrivateKey
+}
+
+func (cs *consenterSigner) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, cs.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return utils.MarshalECDSASignature(r, s)
+}
+
+func (cs *consenterSigner) Serialize() ([]byte, error) {
+	return proto.Marshal(&pmsp.SerializedIdentity{Mspid: cs.consenter.MspId, IdBytes: cs.consenter.Identity})
+}
+
+func newConsenterSigners(t *testing.T, n int) []*consenterSigner {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+
+	var signers []*consenterSigner
+	for id := 1; id <= n; id++ {
+		kp, err := ca.NewClientCertKeyPair()
+		assert.NoError(t, err)
+		bl, _ := pem.Decode(kp.Key)
+		key, err := x509.ParsePKCS8PrivateKey(bl.Bytes)
+		assert.NoError(t, err)
+		signers = append(signers, &consenterSigner{
+			consenter: &bft.Consenter{Id: uint64(id), MspId: "OrdererMSP", Identity: kp.Cert},
+			key:       key.(*ecdsa.PrivateKey),
+		})
+	}
+	return signers
+}
+
+func consenterSignedBlock(t *testing.T, channel string, seqNum uint64, signers ...identity.SignerSerializer) *common.Block {
+	block := protoutil.NewBlock(seqNum, nil)
+	sProp, _ := protoutil.MockSignedEndorserProposalOrPanic(channel, &protospeer.ChaincodeSpec{}, []byte("transactor"), []byte("transactor's signature"))
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(sProp)}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+
+	metadata := &common.Metadata{Value: []byte("value")}
+	for _, signer := range signers {
+		shdr, err := protoutil.NewSignatureHeader(signer)
+		assert.NoError(t, err)
+		signatureHeader := protoutil.MarshalOrPanic(shdr)
+		signature, err := signer.Sign(util.ConcatenateBytes(metadata.Value, signatureHeader, protoutil.BlockHeaderBytes(block.Header)))
+		assert.NoError(t, err)
+		metadata.Signatures = append(metadata.Signatures, &common.MetadataSignature{
+			SignatureHeader: signatureHeader,
+			Signature:       signature,
+		})
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(metadata)
+	return block
+}
+
+func TestVerifyBlockConsenterQuorum(t *testing.T) {
+	signers := newConsenterSigners(t, 5)
+	consenters := []*bft.Consenter{signers[0].consenter, signers[1].consenter, signers[2].consenter, signers[3].consenter}
+	outsider := signers[4]
+
+	channelConfig := func(channelID string) channelconfig.Resources {
+		switch channelID {
+		case "bft":
+			return &mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{
+				ConsensusTypeVal:     "BFT",
+				ConsensusMetadataVal: protoutil.MarshalOrPanic(&bft.ConfigMetadata{Consenters: consenters}),
+			}}
+		case "raft":
+			return &mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{ConsensusTypeVal: "etcdraft"}}
+		default:
+			return nil
+		}
+	}
+
+	newMCS := func(quorum ConsenterQuorum) *MSPMessageCryptoService {
+		return NewMCS(&mocks.ChannelPolicyManagerGetter{}, &mocks.SignerSerializer{}, mgmt.NewDeserializersManager(), channelConfig, quorum)
+	}
+
+	verify := func(mcs *MSPMessageCryptoService, channel string, signers ...identity.SignerSerializer) error {
+		block := consenterSignedBlock(t, channel, 42, signers...)
+		return mcs.VerifyBlock(gcommon.ChannelID(channel), 42, protoutil.MarshalOrPanic(block))
+	}
+
+	t.Run("f+1", func(t *testing.T) {
+		mcs := newMCS(FPlusOneQuorum)
+		assert.NoError(t, verify(mcs, "bft", signers[0], signers[3]))
+		err := verify(mcs, "bft", signers[0])
+		assert.EqualError(t, err, "block [42] of channel [bft] is signed by 1 consenters out of 4, but 2 are required")
+	})
+
+	t.Run("2f+1", func(t *testing.T) {
+		mcs := newMCS(TwoFPlusOneQuorum)
+		assert.NoError(t, verify(mcs, "bft", signers[0], signers[1], signers[2]))
+		err := verify(mcs, "bft", signers[0], signers[1])
+		assert.EqualError(t, err, "block [42] of channel [bft] is signed by 2 consenters out of 4, but 3 are required")
+	})
+
+	t.Run("signatures are counted once per consenter", func(t *testing.T) {
+		err := verify(newMCS(TwoFPlusOneQuorum), "bft", signers[0], signers[0], signers[1])
+		assert.EqualError(t, err, "block [42] of channel [bft] is signed by 2 consenters out of 4, but 3 are required")
+	})
+
+	t.Run("signatures of non consenters are not counted", func(t *testing.T) {
+		err := verify(newMCS(FPlusOneQuorum), "bft", signers[0], outsider)
+		assert.EqualError(t, err, "block [42] of channel [bft] is signed by 1 consenters out of 4, but 2 are required")
+	})
+
+	t.Run("invalid signatures are not counted", func(t *testing.T) {
+		block := consenterSignedBlock(t, "bft", 42, signers[0], signers[1])
+		metadata, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+		assert.NoError(t, err)
+		metadata.Signatures[1].Signature = metadata.Signatures[0].Signature
+		block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(metadata)
+
+		err = newMCS(FPlusOneQuorum).VerifyBlock(gcommon.ChannelID("bft"), 42, protoutil.MarshalOrPanic(block))
+		assert.EqualError(t, err, "block [42] of channel [bft] is signed by 1 consenters out of 4, but 2 are required")
+	})
+
+	t.Run("channels not ordered by BFT are only subject to the policy", func(t *testing.T) {
+		assert.NoError(t, verify(newMCS(TwoFPlusOneQuorum), "raft", outsider))
+		assert.NoError(t, verify(newMCS(PolicyQuorum), "bft", outsider))
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		err := verify(newMCS(FPlusOneQuorum), "unknown", signers[0])
+		assert.EqualError(t, err, "could not acquire the configuration of channel unknown")
+	})
+}
+
+func TestVerifyConsenterSignatureED25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	assert.NoError(t, err)
+	consenter := &bft.Consenter{Identity: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+
+	data := []byte("block header")
+	assert.NoError(t, verifyConsenterSignature(consenter, &protoutil.SignedData{Data: data, Signature: ed25519.Sign(priv, data)}))
+
+	// the data is signed as is rather than its digest
+	digest := sha256.Sum256(data)
+	err = verifyConsenterSignature(consenter, &protoutil.SignedData{Data: data, Signature: ed25519.Sign(priv, digest[:])})
+	assert.EqualError(t, err, "signature does not verify")
+}
+
+func TestVerifyHeader(t *testing.T) {
+	signers := newConsenterSigners(t, 4)
+	var consenters []*bft.Consenter
+	for _, signer := range signers {
+		consenters = append(consenters, signer.consenter)
+	}
+	channelConfig := func(channelID string) channelconfig.Resources {
+		return &mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{
+			ConsensusTypeVal:     "BFT",
+			ConsensusMetadataVal: protoutil.MarshalOrPanic(&bft.ConfigMetadata{Consenters: consenters}),
+		}}
+	}
+	mcs := NewMCS(&mocks.ChannelPolicyManagerGetter{}, &mocks.SignerSerializer{}, mgmt.NewDeserializersManager(), channelConfig, TwoFPlusOneQuorum)
+
+	block := consenterSignedBlock(t, "bft", 42, signers[0], signers[1], signers[2])
+	header := &common.Block{Header: block.Header, Metadata: block.Metadata}
+	assert.NoError(t, mcs.VerifyHeader("bft", header))
+	assert.Error(t, mcs.VerifyBlock(gcommon.ChannelID("bft"), 42, protoutil.MarshalOrPanic(header)))
+
+	header.Header.Number = 43
+	assert.EqualError(t, mcs.VerifyHeader("bft", header), "block [43] of channel [bft] is signed by 0 consenters out of 4, but 3 are required")
+
+	assert.EqualError(t, mcs.VerifyHeader("bft", &common.Block{}), "Invalid Block on channel [bft]. Header must be different from nil.")
+
+	header.Header.Number = 42
+	policyOnly := NewMCS(&mocks.ChannelPolicyManagerGetter{}, &mocks.SignerSerializer{}, mgmt.NewDeserializersManager(), channelConfig, PolicyQuorum)
+	assert.EqualError(t, policyOnly.VerifyHeader("bft", header), "cannot verify the header of block [42] of channel [bft] without a consenter quorum")
+
+	raftConfig := func(channelID string) channelconfig.Resources {
+		return &mockconfig.Resources{OrdererConfigVal: &mockconfig.Orderer{ConsensusTypeVal: "etcdraft"}}
+	}
+	raft := NewMCS(&mocks.ChannelPolicyManagerGetter{}, &mocks.SignerSerializer{}, mgmt.NewDeserializersManager(), raftConfig, TwoFPlusOneQuorum)
+	assert.EqualError(t, raft.VerifyHeader("raft", header), "cannot verify the header of block [42] of channel [raft], which is not ordered by a BFT ordering service")
+}
+
 func TestExpiration(t *testing.T) {
 	expirationDate := time.Now().Add(time.Minute)
 	id1 := &pmsp.SerializedIdentity{
@@ -336,6 +560,8 @@ func TestExpiration(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mocks.SignerSerializer{},
 		deserializersManager,
+		nil,
+		PolicyQuorum,
 	)
 
 	// Green path I check the expiration date is as expected
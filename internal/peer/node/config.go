@@ -44,6 +44,18 @@ func ledgerConfig() *ledger.Config {
 	}
 
 	rootFSPath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "ledgersData")
+	snapshotsRootDir := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "snapshots")
+	if viper.IsSet("ledger.snapshots.rootDir") && viper.GetString("ledger.snapshots.rootDir") != "" {
+		snapshotsRootDir = coreconfig.GetPath("ledger.snapshots.rootDir")
+	}
+	blockArchiveRootDir := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "blockArchive")
+	if viper.IsSet("ledger.blockchain.archive.rootDir") && viper.GetString("ledger.blockchain.archive.rootDir") != "" {
+		blockArchiveRootDir = coreconfig.GetPath("ledger.blockchain.archive.rootDir")
+	}
+	blockArchiveFormat := "dir"
+	if viper.IsSet("ledger.blockchain.archive.format") && viper.GetString("ledger.blockchain.archive.format") != "" {
+		blockArchiveFormat = viper.GetString("ledger.blockchain.archive.format")
+	}
 	conf := &ledger.Config{
 		RootFSPath: rootFSPath,
 		StateDBConfig: &ledger.StateDBConfig{
@@ -56,7 +68,22 @@ func ledgerConfig() *ledger.Config {
 			PurgeInterval:   purgeInterval,
 		},
 		HistoryDBConfig: &ledger.HistoryDBConfig{
-			Enabled: viper.GetBool("ledger.history.enableHistoryDatabase"),
+			Enabled:                   viper.GetBool("ledger.history.enableHistoryDatabase"),
+			PvtDataHashHistoryEnabled: viper.GetBool("ledger.history.enablePrivateDataHashHistory"),
+		},
+		SnapshotsConfig: &ledger.SnapshotsConfig{
+			RootDir: snapshotsRootDir,
+		},
+		BlockArchiveConfig: &ledger.BlockArchiveConfig{
+			Enabled:      viper.GetBool("ledger.blockchain.archive.enabled"),
+			RootDir:      blockArchiveRootDir,
+			Format:       blockArchiveFormat,
+			RetainBlocks: uint64(viper.GetInt("ledger.blockchain.archive.retainBlocks")),
+			RetainPeriod: viper.GetDuration("ledger.blockchain.archive.retainPeriod"),
+		},
+		BlockIndexConfig: &ledger.BlockIndexConfig{
+			ChaincodeNamespace: viper.GetBool("ledger.blockchain.index.chaincodeNamespace"),
+			Creator:            viper.GetBool("ledger.blockchain.index.creator"),
 		},
 	}
 
@@ -18,6 +18,7 @@ import (
 
 func TestLedgerConfig(t *testing.T) {
 	defer viper.Set("ledger.state.stateDatabase", "goleveldb")
+	defer viper.Set("ledger.snapshots.rootDir", "")
 	var tests = []struct {
 		name     string
 		config   map[string]interface{}
@@ -43,6 +44,15 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled: false,
+					RootDir: "/peerfs/blockArchive",
+					Format:  "dir",
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{},
 			},
 		},
 		{
@@ -84,6 +94,15 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled: false,
+					RootDir: "/peerfs/blockArchive",
+					Format:  "dir",
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{},
 			},
 		},
 		{
@@ -105,6 +124,7 @@ func TestLedgerConfig(t *testing.T) {
 				"ledger.pvtdataStore.collElgProcDbBatchesInterval":   10000,
 				"ledger.pvtdataStore.purgeInterval":                  1000,
 				"ledger.history.enableHistoryDatabase":               true,
+				"ledger.history.enablePrivateDataHashHistory":        true,
 			},
 			expected: &ledger.Config{
 				RootFSPath: "/peerfs/ledgersData",
@@ -130,7 +150,136 @@ func TestLedgerConfig(t *testing.T) {
 					PurgeInterval:   1000,
 				},
 				HistoryDBConfig: &ledger.HistoryDBConfig{
-					Enabled: true,
+					Enabled:                   true,
+					PvtDataHashHistoryEnabled: true,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled: false,
+					RootDir: "/peerfs/blockArchive",
+					Format:  "dir",
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{},
+			},
+		},
+		{
+			name: "Custom snapshots root dir",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                         "/peerfs",
+				"ledger.state.stateDatabase":                  "goleveldb",
+				"ledger.history.enableHistoryDatabase":        false,
+				"ledger.history.enablePrivateDataHashHistory": false,
+				"ledger.snapshots.rootDir":                    "/snapshots",
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "goleveldb",
+					CouchDB:       &couchdb.Config{},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    50000,
+					BatchesInterval: 10000,
+					PurgeInterval:   1000,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled: false,
+					RootDir: "/peerfs/blockArchive",
+					Format:  "dir",
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{},
+			},
+		},
+		{
+			name: "Block archive enabled",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                         "/peerfs",
+				"ledger.state.stateDatabase":                  "goleveldb",
+				"ledger.history.enableHistoryDatabase":        false,
+				"ledger.history.enablePrivateDataHashHistory": false,
+				"ledger.snapshots.rootDir":                    "",
+				"ledger.blockchain.archive.enabled":           true,
+				"ledger.blockchain.archive.rootDir":           "/archive",
+				"ledger.blockchain.archive.format":            "tar",
+				"ledger.blockchain.archive.retainBlocks":      1000,
+				"ledger.blockchain.archive.retainPeriod":      "24h",
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "goleveldb",
+					CouchDB:       &couchdb.Config{},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    50000,
+					BatchesInterval: 10000,
+					PurgeInterval:   1000,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled:      true,
+					RootDir:      "/archive",
+					Format:       "tar",
+					RetainBlocks: 1000,
+					RetainPeriod: 24 * time.Hour,
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{},
+			},
+		},
+		{
+			name: "Block indexes enabled",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                         "/peerfs",
+				"ledger.state.stateDatabase":                  "goleveldb",
+				"ledger.history.enableHistoryDatabase":        false,
+				"ledger.history.enablePrivateDataHashHistory": false,
+				"ledger.snapshots.rootDir":                    "",
+				"ledger.blockchain.archive.enabled":           false,
+				"ledger.blockchain.archive.rootDir":           "",
+				"ledger.blockchain.archive.format":            "",
+				"ledger.blockchain.archive.retainBlocks":      0,
+				"ledger.blockchain.archive.retainPeriod":      "0s",
+				"ledger.blockchain.index.chaincodeNamespace":  true,
+				"ledger.blockchain.index.creator":             true,
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "goleveldb",
+					CouchDB:       &couchdb.Config{},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    50000,
+					BatchesInterval: 10000,
+					PurgeInterval:   1000,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Enabled: false,
+					RootDir: "/peerfs/blockArchive",
+					Format:  "dir",
+				},
+				BlockIndexConfig: &ledger.BlockIndexConfig{
+					ChaincodeNamespace: true,
+					Creator:            true,
 				},
 			},
 		},
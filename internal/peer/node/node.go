@@ -16,7 +16,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|status."
+	nodeCmdDes   = "Operate a peer node: start|snapshot|rollback|reset|rebuild-dbs|unjoin."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -24,6 +24,11 @@ var logger = flogging.MustGetLogger("nodeCmd")
 // Cmd returns the cobra command for Node
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
+	nodeCmd.AddCommand(snapshotCmd())
+	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(resetCmd())
+	nodeCmd.AddCommand(rebuildDBsCmd())
+	nodeCmd.AddCommand(unjoinCmd())
 
 	return nodeCmd
 }
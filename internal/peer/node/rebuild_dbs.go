@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+func rebuildDBsCmd() *cobra.Command {
+	return nodeRebuildDBsCmd
+}
+
+var nodeRebuildDBsCmd = &cobra.Command{
+	Use:   "rebuild-dbs",
+	Short: "Rebuilds the databases of all channels.",
+	Long: "Drops the state, history and config history databases of all channels. The databases are rebuilt " +
+		"from the blocks when the peer is started next time. The peer must be stopped while running this command.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return kvledger.RebuildDBs(ledgerConfig())
+	},
+}
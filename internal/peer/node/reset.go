@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+func resetCmd() *cobra.Command {
+	return nodeResetCmd
+}
+
+var nodeResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Resets all channels to the genesis block.",
+	Long: "Resets all channels to the genesis block. The state, history and config history databases of the " +
+		"channels are rebuilt from the genesis blocks when the peer is started next time, and the peer fetches " +
+		"the subsequent blocks again. The peer must be stopped while running this command.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return kvledger.ResetAllKVLedgers(ledgerConfig())
+	},
+}
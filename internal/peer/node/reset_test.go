@@ -0,0 +1,19 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetCmdTrailingArgs(t *testing.T) {
+	cmd := resetCmd()
+	cmd.SetArgs([]string{"mychannel"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
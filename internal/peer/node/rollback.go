@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackChannelID   string
+	rollbackBlockNumber uint64
+)
+
+func rollbackCmd() *cobra.Command {
+	nodeRollbackCmd.Flags().StringVarP(&rollbackChannelID, "channelID", "c", "", "Channel to rollback.")
+	nodeRollbackCmd.Flags().Uint64VarP(&rollbackBlockNumber, "blockNumber", "b", 0, "Block number to which the channel needs to be rolled back to.")
+	return nodeRollbackCmd
+}
+
+var nodeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rolls back a channel.",
+	Long: "Rolls back a channel to a specified block number. The state, history and config history databases " +
+		"of the channel are rebuilt from the remaining blocks when the peer is started next time. " +
+		"The peer must be stopped while running this command.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rollbackChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return kvledger.RollbackKVLedger(ledgerConfig(), rollbackChannelID, rollbackBlockNumber)
+	},
+}
@@ -0,0 +1,20 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmdMissingArgs(t *testing.T) {
+	cmd := rollbackCmd()
+	rollbackChannelID, rollbackBlockNumber = "", 0
+	cmd.SetArgs([]string{"-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotChannelID   string
+	snapshotBlockNumber uint64
+	snapshotPath        string
+)
+
+func snapshotCmd() *cobra.Command {
+	nodeSnapshotCmd.AddCommand(snapshotRequestCmd)
+	nodeSnapshotCmd.AddCommand(snapshotCancelCmd)
+	nodeSnapshotCmd.AddCommand(snapshotListCmd)
+	nodeSnapshotCmd.AddCommand(snapshotRestoreCmd)
+
+	for _, cmd := range []*cobra.Command{snapshotRequestCmd, snapshotCancelCmd, snapshotListCmd} {
+		cmd.Flags().StringVarP(&snapshotChannelID, "channelID", "c", "", "Channel for which the snapshot is managed.")
+	}
+	for _, cmd := range []*cobra.Command{snapshotRequestCmd, snapshotCancelCmd} {
+		cmd.Flags().Uint64VarP(&snapshotBlockNumber, "blockNumber", "b", 0, "Block number at which the snapshot is generated. A value of 0 (the default) denotes the last committed block.")
+	}
+	snapshotRestoreCmd.Flags().StringVarP(&snapshotPath, "snapshotPath", "", "", "Path to the dir that contains the snapshot to be restored.")
+	return nodeSnapshotCmd
+}
+
+var nodeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manages the ledger snapshots.",
+	Long:  "Manages the ledger snapshots: request|cancel|list|restore. The peer must be stopped while running these commands.",
+}
+
+var snapshotRequestCmd = &cobra.Command{
+	Use:   "request",
+	Short: "Requests a snapshot of a channel's ledger.",
+	Long: "Requests a snapshot of a channel's ledger at the given block number. If the block is already committed, " +
+		"the snapshot is generated immediately, otherwise the snapshot is generated when the peer commits the block.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if snapshotChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return withOfflineLedger(snapshotChannelID, func(l ledger.PeerLedger) error {
+			return l.SubmitSnapshotRequest(snapshotBlockNumber)
+		})
+	},
+}
+
+var snapshotCancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "Cancels a pending snapshot request of a channel's ledger.",
+	Long:  "Cancels a pending snapshot request of a channel's ledger for the given block number.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if snapshotChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if snapshotBlockNumber == 0 {
+			return errors.New("Must supply block number")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return withOfflineLedger(snapshotChannelID, func(l ledger.PeerLedger) error {
+			return l.CancelSnapshotRequest(snapshotBlockNumber)
+		})
+	},
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the pending requests and the generated snapshots of a channel's ledger.",
+	Long:  "Lists the block numbers of the pending snapshot requests and of the generated snapshots of a channel's ledger.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if snapshotChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return withOfflineLedger(snapshotChannelID, func(l ledger.PeerLedger) error {
+			pending, err := l.PendingSnapshotRequests()
+			if err != nil {
+				return err
+			}
+			completed, err := kvledger.ListCompletedSnapshots(ledgerConfig().SnapshotsConfig.RootDir, snapshotChannelID)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Pending snapshot requests: %v\n", pending)
+			fmt.Printf("Generated snapshots: %v\n", completed)
+			return nil
+		})
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Creates a channel's ledger from a snapshot.",
+	Long: "Creates a channel's ledger from a snapshot after verifying the hashes of the snapshot files. " +
+		"The peer continues from the block height of the snapshot after the peer is started and joined to the channel.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if snapshotPath == "" {
+			return errors.New("Must supply snapshot path")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtOffline()
+		defer ledgermgmt.Close()
+		l, channelID, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotPath)
+		if err != nil {
+			return err
+		}
+		bcInfo, err := l.GetBlockchainInfo()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created ledger for channel [%s] at height [%d]\n", channelID, bcInfo.Height)
+		return nil
+	},
+}
+
+func withOfflineLedger(channelID string, f func(ledger.PeerLedger) error) error {
+	initLedgerMgmtOffline()
+	defer ledgermgmt.Close()
+	l, err := ledgermgmt.OpenLedger(channelID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to open the ledger for channel [%s]", channelID)
+	}
+	return f(l)
+}
+
+// initLedgerMgmtOffline initializes the ledger mgmt with the dependencies that are sufficient
+// for managing the ledgers while the peer is not running
+func initLedgerMgmtOffline() {
+	ledgermgmt.Initialize(&ledgermgmt.Initializer{
+		DeployedChaincodeInfoProvider: &lifecycle.ValidatorCommitter{
+			Resources:                    &lifecycle.Resources{Serializer: &lifecycle.Serializer{}},
+			LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{},
+		},
+		MembershipInfoProvider:          &offlineMembershipInfoProvider{},
+		ChaincodeLifecycleEventProvider: &offlineChaincodeLifecycleEventProvider{},
+		MetricsProvider:                 &disabled.Provider{},
+		HealthCheckRegistry:             &offlineHealthCheckRegistry{},
+		Config:                          ledgerConfig(),
+	})
+}
+
+// offlineMembershipInfoProvider is used only while the peer is not running, when no block
+// or private data is committed to the ledger
+type offlineMembershipInfoProvider struct{}
+
+func (*offlineMembershipInfoProvider) AmMemberOf(string, *common.CollectionPolicyConfig) (bool, error) {
+	return false, errors.New("membership information is not available while the peer is not running")
+}
+
+type offlineChaincodeLifecycleEventProvider struct{}
+
+func (*offlineChaincodeLifecycleEventProvider) RegisterListener(string, ledger.ChaincodeLifecycleEventListener) {
+}
+
+type offlineHealthCheckRegistry struct{}
+
+func (*offlineHealthCheckRegistry) RegisterChecker(string, healthz.HealthChecker) error {
+	return nil
+}
@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCmdMissingArgs(t *testing.T) {
+	cmd := snapshotCmd()
+	var tests = []struct {
+		args          []string
+		expectedError string
+	}{
+		{args: []string{"request"}, expectedError: "Must supply channel ID"},
+		{args: []string{"cancel", "-b", "10"}, expectedError: "Must supply channel ID"},
+		{args: []string{"cancel", "-c", "mychannel"}, expectedError: "Must supply block number"},
+		{args: []string{"list"}, expectedError: "Must supply channel ID"},
+		{args: []string{"restore"}, expectedError: "Must supply snapshot path"},
+	}
+	for _, test := range tests {
+		snapshotChannelID, snapshotBlockNumber, snapshotPath = "", 0, ""
+		cmd.SetArgs(test.args)
+		assert.EqualError(t, cmd.Execute(), test.expectedError)
+	}
+}
@@ -49,6 +49,7 @@ import (
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/endorser"
@@ -79,15 +80,19 @@ import (
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
 	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	pvtdatahttpadmin "github.com/hyperledger/fabric/gossip/privdata/httpadmin"
 	"github.com/hyperledger/fabric/gossip/service"
 	gossipservice "github.com/hyperledger/fabric/gossip/service"
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
+	"github.com/hyperledger/fabric/internal/pkg/gateway"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common"
 	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gatewayprotos "github.com/hyperledger/fabric/protos/gateway"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	pt "github.com/hyperledger/fabric/protos/transientstore"
@@ -243,6 +248,7 @@ func serve(args []string) error {
 
 	gossipService, err := initGossipService(
 		policyMgr,
+		peerInstance.GetStableChannelConfig,
 		metricsProvider,
 		peerServer,
 		signingIdentity,
@@ -258,6 +264,10 @@ func serve(args []string) error {
 
 	peerInstance.GossipService = gossipService
 
+	pvtDataHandler := pvtdatahttpadmin.NewMissingPvtDataHandler(gossipService)
+	opsSystem.RegisterHandler(pvtdatahttpadmin.URLBaseV1, pvtDataHandler)
+	opsSystem.RegisterHandler(pvtdatahttpadmin.URLBaseV1+"/", pvtDataHandler)
+
 	policyChecker := policy.NewPolicyChecker(
 		policies.PolicyManagerGetterFunc(peerInstance.GetPolicyManager),
 		mgmt.GetLocalMSP(),
@@ -287,8 +297,10 @@ func serve(args []string) error {
 	}
 
 	lifecycleValidatorCommitter := &lifecycle.ValidatorCommitter{
-		Resources:                    lifecycleResources,
-		LegacyDeployedCCInfoProvider: &lscc.DeployedCCInfoProvider{},
+		Resources:                       lifecycleResources,
+		LegacyDeployedCCInfoProvider:    &lscc.DeployedCCInfoProvider{},
+		LocalMSPID:                      mspID,
+		ImplicitCollDisseminationPolicy: gossipprivdata.GlobalConfig().ImplicitCollDisseminationPolicy,
 	}
 
 	packageProvider := &persistence.PackageProvider{
@@ -388,7 +400,8 @@ func serve(args []string) error {
 			mutualTLS,
 			metrics,
 		),
-		PolicyCheckerProvider: policyCheckerProvider,
+		PolicyCheckerProvider:   policyCheckerProvider,
+		CollectionPolicyChecker: &privdata.CollectionPolicyChecker{},
 	}
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
@@ -475,9 +488,15 @@ func serve(args []string) error {
 
 	chaincodeConfig := chaincode.GlobalConfig()
 
+	var externalBuilders []*externalbuilder.Builder
+	for _, builder := range coreConfig.ExternalBuilders {
+		externalBuilders = append(externalBuilders, externalbuilder.NewBuilder(builder.Name, builder.Path, builder.EnvironmentWhitelist))
+	}
+	externalProvider := externalbuilder.NewProvider(externalBuilders, ccEndpoint, dockerProvider)
+
 	chaincodeVMController := container.NewVMController(
 		map[string]container.VMProvider{
-			dockercontroller.ContainerType: dockerProvider,
+			dockercontroller.ContainerType: externalProvider,
 			inproccontroller.ContainerType: ipRegistry,
 		},
 	)
@@ -527,6 +546,7 @@ func serve(args []string) error {
 	}
 
 	ipRegistry.ChaincodeSupport = chaincodeSupport
+	externalProvider.ChaincodeSupport = chaincodeSupport
 
 	ccSupSrv := pb.ChaincodeSupportServer(chaincodeSupport)
 	if tlsEnabled {
@@ -662,19 +682,37 @@ func serve(args []string) error {
 		coreConfig.ValidatorPoolSize,
 	)
 
+	metadataProvider := lifecycle.NewMetadataProvider(
+		lifecycleCache,
+		legacyMetadataManager,
+		peerInstance,
+	)
 	if coreConfig.DiscoveryEnabled {
 		registerDiscoveryService(
 			coreConfig,
 			peerInstance,
 			peerServer,
 			policyMgr,
-			lifecycle.NewMetadataProvider(
-				lifecycleCache,
-				legacyMetadataManager,
-				peerInstance,
-			),
+			metadataProvider,
+			gossipService,
+		)
+	}
+
+	if coreConfig.GatewayEnabled {
+		err = registerGatewayService(
+			coreConfig,
+			peerInstance,
+			peerServer,
+			policyMgr,
+			metadataProvider,
 			gossipService,
+			auth,
+			signingIdentity,
+			aclProvider,
 		)
+		if err != nil {
+			return err
+		}
 	}
 
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]", coreConfig.PeerID, coreConfig.NetworkID, coreConfig.PeerAddress)
@@ -803,6 +841,55 @@ func registerDiscoveryService(
 	discprotos.RegisterDiscoveryServer(peerServer.Server(), svc)
 }
 
+func registerGatewayService(
+	coreConfig *peer.Config,
+	peerInstance *peer.Peer,
+	peerServer *comm.GRPCServer,
+	polMgr policies.ChannelPolicyManagerGetter,
+	metadataProvider *lifecycle.MetadataProvider,
+	gossipService *gossipservice.GossipService,
+	localEndorser pb.EndorserServer,
+	signingIdentity msp.SigningIdentity,
+	aclProvider aclmgmt.ACLProvider,
+) error {
+	localIdentity, err := signingIdentity.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing the identity of the peer")
+	}
+	// the endorsement plans are computed the same way as the ones served by discovery
+	channelVerifier := discacl.NewChannelVerifier(policies.ChannelApplicationWriters, polMgr)
+	acl := discacl.NewDiscoverySupport(
+		channelVerifier,
+		localPolicy(cauthdsl.SignedByAnyMember([]string{coreConfig.LocalMSPID})),
+		discacl.ChannelConfigGetterFunc(peerInstance.GetStableChannelConfig),
+	)
+	ea := endorsement.NewEndorsementAnalyzer(
+		gossip.NewDiscoverySupport(gossipService),
+		ccsupport.NewDiscoverySupport(metadataProvider),
+		acl,
+		metadataProvider,
+	)
+	gatewayServer, err := gateway.CreateServer(
+		localEndorser,
+		localIdentity,
+		ea,
+		gossipService,
+		&gateway.PeerChannelSupport{Peer: peerInstance},
+		aclProvider,
+		gateway.NewConnector(secureDialOpts(peerInstance.CredentialSupport), coreConfig.GatewayDialTimeout),
+		gateway.Options{
+			EndorsementTimeout: coreConfig.GatewayEndorsementTimeout,
+			BroadcastTimeout:   coreConfig.GatewayBroadcastTimeout,
+		},
+	)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the gateway service")
+	}
+	logger.Info("Gateway service activated")
+	gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
+	return nil
+}
+
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
 func createChaincodeServer(coreConfig *peer.Config, ca tlsgen.CA, peerHostname string) (srv *comm.GRPCServer, ccEndpoint string, err error) {
 	// before potentially setting chaincodeListenAddress, compute chaincode endpoint at first
@@ -1016,6 +1103,7 @@ func deliverClientDialOpts(coreConfig *peer.Config) []grpc.DialOption {
 // 4. Init gossip related struct.
 func initGossipService(
 	policyMgr policies.ChannelPolicyManagerGetter,
+	channelConfigGetter peergossip.ChannelConfigGetter,
 	metricsProvider metrics.Provider,
 	peerServer *comm.GRPCServer,
 	signer msp.SigningIdentity,
@@ -1037,10 +1125,17 @@ func initGossipService(
 		certs.TLSClientCert.Store(&clientCert)
 	}
 
+	consenterQuorum, err := peergossip.ParseConsenterQuorum(deliverServiceConfig.ConsenterSignatures)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid block verification configuration")
+	}
+
 	messageCryptoService := peergossip.NewMCS(
 		policyMgr,
 		signer,
 		mgmt.NewDeserializersManager(),
+		channelConfigGetter,
+		consenterQuorum,
 	)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"path/filepath"
+
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var unjoinChannelID string
+
+func unjoinCmd() *cobra.Command {
+	nodeUnjoinCmd.Flags().StringVarP(&unjoinChannelID, "channelID", "c", "", "Channel to unjoin.")
+	return nodeUnjoinCmd
+}
+
+var nodeUnjoinCmd = &cobra.Command{
+	Use:   "unjoin",
+	Short: "Unjoins the peer from a channel.",
+	Long: "Unjoins the peer from a channel by removing the ledger and the transient store of the channel. " +
+		"The peer must be stopped while running this command.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if unjoinChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return unjoinChannel(unjoinChannelID)
+	},
+}
+
+// unjoinChannel removes the ledger of the channel first, which fails if the peer is running,
+// and then removes the transient store of the channel
+func unjoinChannel(channelID string) error {
+	if err := kvledger.UnjoinChannel(ledgerConfig(), channelID); err != nil {
+		return err
+	}
+	transientStoreProvider := transientstore.NewStoreProvider(
+		filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore"),
+	)
+	defer transientStoreProvider.Close()
+	return transientStoreProvider.Drop(channelID)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnjoinCmdMissingArgs(t *testing.T) {
+	cmd := unjoinCmd()
+	unjoinChannelID = ""
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+}
+
+func TestUnjoinChannel(t *testing.T) {
+	defer viper.Reset()
+	tempDir, err := ioutil.TempDir("", "unjoin")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+
+	ledgerProvider, err := kvledger.NewProvider(&ledger.Initializer{
+		DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+		MetricsProvider:               &disabled.Provider{},
+		HealthCheckRegistry:           &offlineHealthCheckRegistry{},
+		Config:                        ledgerConfig(),
+	})
+	require.NoError(t, err)
+	for _, channelID := range []string{"channel1", "channel2"} {
+		_, gb := testutil.NewBlockGenerator(t, channelID, false)
+		l, err := ledgerProvider.Create(gb)
+		require.NoError(t, err)
+		l.Close()
+	}
+	ledgerProvider.Close()
+	transientStoreProvider := transientstore.NewStoreProvider(filepath.Join(tempDir, "transientstore"))
+	for _, channelID := range []string{"channel1", "channel2"} {
+		store, err := transientStoreProvider.OpenStore(channelID)
+		require.NoError(t, err)
+		require.NoError(t, store.Persist("txid-1", 1, &rwset.TxPvtReadWriteSet{}))
+	}
+	transientStoreProvider.Close()
+
+	require.NoError(t, unjoinChannel("channel1"))
+
+	assert.EqualError(t, unjoinChannel("channel1"), "ledger [channel1] does not exist")
+	ledgerProvider, err = kvledger.NewProvider(&ledger.Initializer{
+		DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+		MetricsProvider:               &disabled.Provider{},
+		HealthCheckRegistry:           &offlineHealthCheckRegistry{},
+		Config:                        ledgerConfig(),
+	})
+	require.NoError(t, err)
+	ledgerIDs, err := ledgerProvider.List()
+	ledgerProvider.Close()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"channel2"}, ledgerIDs)
+	transientStoreProvider = transientstore.NewStoreProvider(filepath.Join(tempDir, "transientstore"))
+	defer transientStoreProvider.Close()
+	store, err := transientStoreProvider.OpenStore("channel1")
+	require.NoError(t, err)
+	_, err = store.GetMinTransientBlkHt()
+	assert.Equal(t, transientstore.ErrStoreEmpty, err)
+	store, err = transientStoreProvider.OpenStore("channel2")
+	require.NoError(t, err)
+	minBlkHt, err := store.GetMinTransientBlkHt()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), minBlkHt)
+}
@@ -0,0 +1,389 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	peercommon "github.com/hyperledger/fabric/internal/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Evaluate runs the proposal on a single peer and returns the chaincode
+// response without submitting it for ordering. The local peer is used unless
+// target organizations are given, in which case the first responsive peer of
+// those organizations is used.
+func (s *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*gp.EvaluateResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "an evaluate request is required")
+	}
+	proposal, err := s.validateProposal(request.ChannelId, request.TransactionId, request.ProposedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*endorser{s.registry.local}
+	if len(request.TargetOrganizations) > 0 {
+		l, err := s.registry.endorsersForOrgs(proposal.channelID, request.TargetOrganizations)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		candidates = nil
+		for _, g := range l {
+			candidates = append(candidates, g.endorsers...)
+		}
+	}
+
+	ctx, cancel := s.withTimeout(ctx, s.options.EndorsementTimeout)
+	defer cancel()
+
+	var failures []string
+	for _, e := range candidates {
+		resp, err := s.processProposal(ctx, e, request.ProposedTransaction)
+		if err != nil {
+			logger.Warningf("Failed evaluating transaction %s on %s: %s", proposal.txID, e, err)
+			failures = append(failures, fmt.Sprintf("%s: %s", e, err))
+			continue
+		}
+		if resp.Response.Status < 200 || resp.Response.Status >= 400 {
+			return nil, status.Errorf(codes.Aborted, "evaluation of transaction %s failed on %s with status %d: %s", proposal.txID, e, resp.Response.Status, resp.Response.Message)
+		}
+		return &gp.EvaluateResponse{Result: resp.Response}, nil
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "failed evaluating transaction %s: %s", proposal.txID, strings.Join(failures, "; "))
+}
+
+// Endorse collects endorsements for the proposal, either from the
+// organizations given in the request or from peers satisfying the endorsement
+// plan of the chaincode, and assembles them into a transaction envelope that
+// the client signs before submitting it.
+func (s *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.EndorseResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "an endorse request is required")
+	}
+	proposal, err := s.validateProposal(request.ChannelId, request.TransactionId, request.ProposedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var layouts []layout
+	if len(request.EndorsingOrganizations) > 0 {
+		l, err := s.registry.endorsersForOrgs(proposal.channelID, request.EndorsingOrganizations)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		layouts = []layout{l}
+	} else {
+		layouts, err = s.registry.endorsementPlan(proposal.channelID, proposal.chaincodeName)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	ctx, cancel := s.withTimeout(ctx, s.options.EndorsementTimeout)
+	defer cancel()
+
+	endorsements := &endorsements{
+		server:     s,
+		signedProp: request.ProposedTransaction,
+		results:    map[*endorser]*endorsement{},
+	}
+	var failures []string
+	for _, l := range layouts {
+		responses, err := endorsements.collect(ctx, l)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		env, err := protoutil.CreateTx(proposal.proposal, responses...)
+		if err != nil {
+			return nil, status.Errorf(codes.Aborted, "failed assembling transaction %s: %s", proposal.txID, err)
+		}
+		return &gp.EndorseResponse{PreparedTransaction: env}, nil
+	}
+
+	if len(failures) == 0 {
+		failures = append(failures, "no endorsement layout is available")
+	}
+	return nil, status.Errorf(codes.Aborted, "failed collecting enough endorsements for transaction %s: %s", proposal.txID, strings.Join(failures, "; "))
+}
+
+// Submit sends the signed transaction envelope to the ordering service nodes
+// of the channel, in random order, until one of them accepts it.
+func (s *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.SubmitResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "a submit request is required")
+	}
+	env := request.PreparedTransaction
+	if env == nil || len(env.Payload) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a prepared transaction is required")
+	}
+	if len(env.Signature) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the prepared transaction must be signed")
+	}
+	chdr, err := envelopeChannelHeader(env)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid prepared transaction: %s", err)
+	}
+	if chdr.ChannelId != request.ChannelId {
+		return nil, status.Errorf(codes.InvalidArgument, "the prepared transaction is for channel %s, not %s", chdr.ChannelId, request.ChannelId)
+	}
+	if request.TransactionId != "" && chdr.TxId != request.TransactionId {
+		return nil, status.Errorf(codes.InvalidArgument, "the prepared transaction has ID %s, not %s", chdr.TxId, request.TransactionId)
+	}
+
+	addresses, err := s.channels.OrdererAddresses(request.ChannelId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if len(addresses) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "no ordering service endpoints are configured for channel %s", request.ChannelId)
+	}
+
+	var failures []string
+	for _, i := range rand.Perm(len(addresses)) {
+		address := addresses[i]
+		if err := s.broadcast(ctx, address, env); err != nil {
+			logger.Warningf("Failed submitting transaction %s to %s: %s", chdr.TxId, address, err)
+			failures = append(failures, fmt.Sprintf("%s: %s", address, err))
+			continue
+		}
+		return &gp.SubmitResponse{}, nil
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "failed submitting transaction %s: %s", chdr.TxId, strings.Join(failures, "; "))
+}
+
+// CommitStatus waits until the transaction is committed to the ledger of the
+// peer and returns its validation code and block number. The request must be
+// signed by an identity allowed to receive the filtered block events of the
+// channel.
+func (s *Server) CommitStatus(ctx context.Context, signedRequest *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
+	if signedRequest == nil || len(signedRequest.Request) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a commit status request is required")
+	}
+	request := &gp.CommitStatusRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid commit status request: %s", err)
+	}
+	if request.TransactionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "a transaction ID is required")
+	}
+
+	signedData := []*protoutil.SignedData{{
+		Data:      signedRequest.Request,
+		Identity:  request.Identity,
+		Signature: signedRequest.Signature,
+	}}
+	if err := s.aclChecker.CheckACL(resources.Event_FilteredBlock, request.ChannelId, signedData); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "access denied for commit status of transaction %s: %s", request.TransactionId, err)
+	}
+
+	ledger, err := s.channels.Ledger(request.ChannelId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	code, blockNumber, err := commitStatus(ctx, ledger, request.TransactionId)
+	switch {
+	case err == context.DeadlineExceeded:
+		return nil, status.Errorf(codes.DeadlineExceeded, "timed out waiting for transaction %s to commit", request.TransactionId)
+	case err == context.Canceled:
+		return nil, status.Errorf(codes.Canceled, "canceled waiting for transaction %s to commit", request.TransactionId)
+	case err != nil:
+		return nil, status.Errorf(codes.Unavailable, "failed obtaining the commit status of transaction %s: %s", request.TransactionId, err)
+	}
+
+	return &gp.CommitStatusResponse{Result: code, BlockNumber: blockNumber}, nil
+}
+
+// proposalInfo holds the fields of a signed proposal the gateway relies on
+type proposalInfo struct {
+	proposal      *pb.Proposal
+	channelID     string
+	txID          string
+	chaincodeName string
+}
+
+// validateProposal checks that the signed proposal matches the channel and
+// transaction of the request and extracts the fields the gateway relies on
+func (s *Server) validateProposal(channelID, txID string, signedProp *pb.SignedProposal) (*proposalInfo, error) {
+	if signedProp == nil || len(signedProp.ProposalBytes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a signed proposal is required")
+	}
+	proposal, err := protoutil.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal: %s", err)
+	}
+	hdr, err := protoutil.GetHeader(proposal.Header)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal header: %s", err)
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal channel header: %s", err)
+	}
+	ext, err := protoutil.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal header extension: %s", err)
+	}
+	if ext.ChaincodeId == nil || ext.ChaincodeId.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "the proposal does not name a chaincode")
+	}
+	if chdr.ChannelId != channelID {
+		return nil, status.Errorf(codes.InvalidArgument, "the proposal is for channel %s, not %s", chdr.ChannelId, channelID)
+	}
+	if txID != "" && chdr.TxId != txID {
+		return nil, status.Errorf(codes.InvalidArgument, "the proposal has transaction ID %s, not %s", chdr.TxId, txID)
+	}
+
+	return &proposalInfo{
+		proposal:      proposal,
+		channelID:     chdr.ChannelId,
+		txID:          chdr.TxId,
+		chaincodeName: ext.ChaincodeId.Name,
+	}, nil
+}
+
+func (s *Server) processProposal(ctx context.Context, e *endorser, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	client, err := s.registry.client(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ProcessProposal(ctx, signedProp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Response == nil {
+		return nil, fmt.Errorf("received a proposal response without a response")
+	}
+	return resp, nil
+}
+
+// broadcast sends the envelope to a single ordering service node and waits for its acknowledgement
+func (s *Server) broadcast(ctx context.Context, address string, env *cb.Envelope) error {
+	ctx, cancel := s.withTimeout(ctx, s.options.BroadcastTimeout)
+	defer cancel()
+
+	client, err := s.connector.Broadcaster(ctx, address)
+	if err != nil {
+		return err
+	}
+	stream, err := client.Broadcast(ctx)
+	if err != nil {
+		return err
+	}
+	bc := &peercommon.BroadcastGRPCClient{Client: stream}
+	defer bc.Close()
+
+	return bc.Send(env)
+}
+
+func (s *Server) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// endorsement is the outcome of sending a proposal to an endorser
+type endorsement struct {
+	done     chan struct{}
+	response *pb.ProposalResponse
+	err      error
+}
+
+// endorsements collects the endorsements of a proposal, sending it at most
+// once to each endorser even when the endorser appears in several layouts
+type endorsements struct {
+	server     *Server
+	signedProp *pb.SignedProposal
+
+	mutex   sync.Mutex
+	results map[*endorser]*endorsement
+}
+
+// endorse returns the endorsement of the endorser, sending it the proposal
+// unless it has been sent already
+func (es *endorsements) endorse(ctx context.Context, e *endorser) (*pb.ProposalResponse, error) {
+	es.mutex.Lock()
+	result, ok := es.results[e]
+	if !ok {
+		result = &endorsement{done: make(chan struct{})}
+		es.results[e] = result
+	}
+	es.mutex.Unlock()
+
+	if ok {
+		<-result.done
+		return result.response, result.err
+	}
+
+	defer close(result.done)
+	result.response, result.err = es.server.processProposal(ctx, e, es.signedProp)
+	if result.err == nil && (result.response.Response.Status < 200 || result.response.Response.Status >= 400) {
+		result.err = fmt.Errorf("endorsement failed with status %d: %s", result.response.Response.Status, result.response.Response.Message)
+	}
+	if result.err != nil {
+		logger.Warningf("Failed obtaining endorsement from %s: %s", e, result.err)
+	}
+	return result.response, result.err
+}
+
+// collect gathers the endorsements required by every group of the layout,
+// querying the groups concurrently
+func (es *endorsements) collect(ctx context.Context, l layout) ([]*pb.ProposalResponse, error) {
+	responses := make([][]*pb.ProposalResponse, len(l))
+	errs := make([]error, len(l))
+
+	var wg sync.WaitGroup
+	for i, g := range l {
+		wg.Add(1)
+		go func(i int, g *group) {
+			defer wg.Done()
+			var failures []string
+			for _, e := range g.endorsers {
+				if len(responses[i]) == g.quantity {
+					return
+				}
+				resp, err := es.endorse(ctx, e)
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("%s: %s", e, err))
+					continue
+				}
+				responses[i] = append(responses[i], resp)
+			}
+			if len(responses[i]) < g.quantity {
+				errs[i] = fmt.Errorf("obtained %d of %d required endorsements [%s]", len(responses[i]), g.quantity, strings.Join(failures, "; "))
+			}
+		}(i, g)
+	}
+	wg.Wait()
+
+	var all []*pb.ProposalResponse
+	for i := range l {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, responses[i]...)
+	}
+	return all, nil
+}
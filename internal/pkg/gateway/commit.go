@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mocks/channel_ledger.go --fake-name ChannelLedger . ChannelLedger
+
+// ChannelLedger gives access to the committed transactions and blocks of a channel
+type ChannelLedger interface {
+	GetTransactionByID(txID string) (*pb.ProcessedTransaction, error)
+	GetBlockByTxID(txID string) (*cb.Block, error)
+	blockledger.Reader
+}
+
+// commitStatus returns the validation code and block number of the
+// transaction, waiting for it to be committed if it is not in the ledger yet.
+// It returns the context error if the context is done first.
+func commitStatus(ctx context.Context, l ChannelLedger, txID string) (pb.TxValidationCode, uint64, error) {
+	// the iterator is opened before looking up the transaction so that a
+	// block committed in between is not missed
+	itr, _ := l.Iterator(&ab.SeekPosition{
+		Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: l.Height()}},
+	})
+	defer itr.Close()
+
+	code, blockNumber, found, err := committedTransaction(l, txID)
+	if err != nil || found {
+		return code, blockNumber, err
+	}
+
+	type result struct {
+		code        pb.TxValidationCode
+		blockNumber uint64
+		err         error
+	}
+	results := make(chan result, 1)
+	go func() {
+		code, blockNumber, err := waitForTransaction(itr, txID)
+		results <- result{code: code, blockNumber: blockNumber, err: err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.code, r.blockNumber, r.err
+	case <-ctx.Done():
+		return 0, 0, ctx.Err()
+	}
+}
+
+// committedTransaction looks the transaction up in the ledger
+func committedTransaction(l ChannelLedger, txID string) (pb.TxValidationCode, uint64, bool, error) {
+	tx, err := l.GetTransactionByID(txID)
+	switch err.(type) {
+	case nil:
+	case ledger.NotFoundInIndexErr:
+		return 0, 0, false, nil
+	default:
+		return 0, 0, false, errors.WithMessagef(err, "failed retrieving transaction %s", txID)
+	}
+
+	block, err := l.GetBlockByTxID(txID)
+	if err != nil {
+		return 0, 0, false, errors.WithMessagef(err, "failed retrieving the block of transaction %s", txID)
+	}
+	return pb.TxValidationCode(tx.ValidationCode), block.Header.Number, true, nil
+}
+
+// waitForTransaction reads the blocks returned by the iterator until one
+// contains the transaction
+func waitForTransaction(itr blockledger.Iterator, txID string) (pb.TxValidationCode, uint64, error) {
+	for {
+		block, status := itr.Next()
+		if status != cb.Status_SUCCESS {
+			return 0, 0, errors.Errorf("failed reading the next block: %s", status)
+		}
+
+		txFilter := util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		for txIndex, data := range block.Data.Data {
+			env, err := protoutil.GetEnvelopeFromBlock(data)
+			if err != nil {
+				continue
+			}
+			chdr, err := envelopeChannelHeader(env)
+			if err != nil || chdr.TxId != txID {
+				continue
+			}
+			if txIndex >= len(txFilter) {
+				return 0, 0, errors.Errorf("block %d has no validation code for transaction %s", block.Header.Number, txID)
+			}
+			return txFilter.Flag(txIndex), block.Header.Number, nil
+		}
+	}
+}
+
+func envelopeChannelHeader(env *cb.Envelope) (*cb.ChannelHeader, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	return protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
+	dp "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("gateway")
+
+//go:generate counterfeiter -o mocks/endorsement_planner.go --fake-name EndorsementPlanner . EndorsementPlanner
+
+// EndorsementPlanner computes the layouts of peers whose endorsements satisfy
+// the endorsement policy of a chaincode
+type EndorsementPlanner interface {
+	PeersForEndorsement(channel gcommon.ChannelID, interest *dp.ChaincodeInterest) (*dp.EndorsementDescriptor, error)
+}
+
+//go:generate counterfeiter -o mocks/channel_membership.go --fake-name ChannelMembership . ChannelMembership
+
+// ChannelMembership provides the alive peers of a channel and their identities,
+// as known through gossip
+type ChannelMembership interface {
+	PeersOfChannel(gcommon.ChannelID) []gdiscovery.NetworkMember
+	IdentityInfo() api.PeerIdentitySet
+}
+
+//go:generate counterfeiter -o mocks/channel_support.go --fake-name ChannelSupport . ChannelSupport
+
+// ChannelSupport provides access to the channels the peer has joined
+type ChannelSupport interface {
+	// OrdererAddresses returns the addresses of the ordering service nodes of the channel
+	OrdererAddresses(channelID string) ([]string, error)
+	// Ledger returns the ledger of the channel
+	Ledger(channelID string) (ChannelLedger, error)
+}
+
+//go:generate counterfeiter -o mocks/acl_checker.go --fake-name ACLChecker . ACLChecker
+
+// ACLChecker checks whether the given identity information satisfies the
+// access control policy of a resource on a channel
+type ACLChecker interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+//go:generate counterfeiter -o mocks/connector.go --fake-name Connector . Connector
+
+// Connector provides clients for the remote peers and ordering service nodes
+// the gateway talks to
+type Connector interface {
+	Endorser(ctx context.Context, address string) (pb.EndorserClient, error)
+	Broadcaster(ctx context.Context, address string) (ab.AtomicBroadcastClient, error)
+}
+
+// Options are the tunable parameters of the gateway
+type Options struct {
+	// EndorsementTimeout bounds the time spent collecting the endorsements of a proposal
+	EndorsementTimeout time.Duration
+	// BroadcastTimeout bounds the time spent submitting a transaction to an ordering service node
+	BroadcastTimeout time.Duration
+}
+
+// Server is the gateway service hosted by the peer. It evaluates and endorses
+// proposals, submits transactions to the ordering service and reports their
+// commit status on behalf of clients.
+type Server struct {
+	registry   *registry
+	channels   ChannelSupport
+	aclChecker ACLChecker
+	connector  Connector
+	options    Options
+}
+
+// CreateServer creates a gateway server that uses the local endorser of the
+// peer, identified by localIdentity, and reaches the other peers and the
+// ordering service through the connector.
+func CreateServer(
+	localEndorser pb.EndorserServer,
+	localIdentity []byte,
+	planner EndorsementPlanner,
+	membership ChannelMembership,
+	channels ChannelSupport,
+	aclChecker ACLChecker,
+	connector Connector,
+	options Options,
+) (*Server, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(localIdentity, sID); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling local identity")
+	}
+
+	return &Server{
+		registry: &registry{
+			local: &endorser{
+				mspID:    sID.Mspid,
+				identity: localIdentity,
+				client:   &localEndorserClient{EndorserServer: localEndorser},
+			},
+			planner:    planner,
+			membership: membership,
+			connector:  connector,
+		},
+		channels:   channels,
+		aclChecker: aclChecker,
+		connector:  connector,
+		options:    options,
+	}, nil
+}
@@ -0,0 +1,614 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	cb "github.com/hyperledger/fabric/protos/common"
+	dp "github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mocks/endorser_server.go --fake-name EndorserServer . endorserServer
+
+type endorserServer interface {
+	pb.EndorserServer
+}
+
+//go:generate counterfeiter -o mocks/endorser_client.go --fake-name EndorserClient . endorserClient
+
+type endorserClient interface {
+	pb.EndorserClient
+}
+
+//go:generate counterfeiter -o mocks/broadcaster.go --fake-name Broadcaster . broadcaster
+
+type broadcaster interface {
+	ab.AtomicBroadcastClient
+}
+
+//go:generate counterfeiter -o mocks/broadcast_stream.go --fake-name BroadcastStream . broadcastStream
+
+type broadcastStream interface {
+	ab.AtomicBroadcast_BroadcastClient
+}
+
+//go:generate counterfeiter -o mocks/block_iterator.go --fake-name BlockIterator . blockIterator
+
+type blockIterator interface {
+	blockledger.Iterator
+}
+
+type testPeer struct {
+	address  string
+	identity []byte
+	client   *mocks.EndorserClient
+}
+
+type testSetup struct {
+	server        *gateway.Server
+	localIdentity []byte
+	localEndorser *mocks.EndorserServer
+	planner       *mocks.EndorsementPlanner
+	membership    *mocks.ChannelMembership
+	channels      *mocks.ChannelSupport
+	aclChecker    *mocks.ACLChecker
+	connector     *mocks.Connector
+	peers         map[string]*testPeer
+}
+
+func serializedIdentity(t *testing.T, mspID, id string) []byte {
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(id)})
+	require.NoError(t, err)
+	return identity
+}
+
+func proposalResponse(identity []byte, status int32, payload string) *pb.ProposalResponse {
+	return &pb.ProposalResponse{
+		Payload:     []byte(payload),
+		Endorsement: &pb.Endorsement{Endorser: identity, Signature: []byte("signature")},
+		Response:    &pb.Response{Status: status, Payload: []byte("result")},
+	}
+}
+
+// newTestSetup creates a gateway hosted by peer0 of Org1, on a channel also
+// joined by peer1 of Org1 and peer0 of Org2
+func newTestSetup(t *testing.T) *testSetup {
+	ts := &testSetup{
+		localIdentity: serializedIdentity(t, "Org1MSP", "peer0.org1"),
+		localEndorser: &mocks.EndorserServer{},
+		planner:       &mocks.EndorsementPlanner{},
+		membership:    &mocks.ChannelMembership{},
+		channels:      &mocks.ChannelSupport{},
+		aclChecker:    &mocks.ACLChecker{},
+		connector:     &mocks.Connector{},
+		peers:         map[string]*testPeer{},
+	}
+	ts.localEndorser.ProcessProposalReturns(proposalResponse(ts.localIdentity, 200, "payload"), nil)
+
+	var members []gdiscovery.NetworkMember
+	var identities api.PeerIdentitySet
+	for _, p := range []struct{ name, mspID, address string }{
+		{"peer1.org1", "Org1MSP", "peer1.org1:7051"},
+		{"peer0.org2", "Org2MSP", "peer0.org2:7051"},
+	} {
+		identity := serializedIdentity(t, p.mspID, p.name)
+		client := &mocks.EndorserClient{}
+		client.ProcessProposalReturns(proposalResponse(identity, 200, "payload"), nil)
+		ts.peers[p.name] = &testPeer{address: p.address, identity: identity, client: client}
+
+		members = append(members, gdiscovery.NetworkMember{Endpoint: p.address, PKIid: gcommon.PKIidType(p.name)})
+		identities = append(identities, api.PeerIdentityInfo{
+			PKIId:        gcommon.PKIidType(p.name),
+			Identity:     identity,
+			Organization: api.OrgIdentityType(p.mspID),
+		})
+	}
+	ts.membership.PeersOfChannelReturns(members)
+	ts.membership.IdentityInfoReturns(identities)
+	ts.connector.EndorserStub = func(_ context.Context, address string) (pb.EndorserClient, error) {
+		for _, p := range ts.peers {
+			if p.address == address {
+				return p.client, nil
+			}
+		}
+		return nil, errors.Errorf("unknown peer %s", address)
+	}
+
+	server, err := gateway.CreateServer(
+		ts.localEndorser,
+		ts.localIdentity,
+		ts.planner,
+		ts.membership,
+		ts.channels,
+		ts.aclChecker,
+		ts.connector,
+		gateway.Options{EndorsementTimeout: time.Second, BroadcastTimeout: time.Second},
+	)
+	require.NoError(t, err)
+	ts.server = server
+	return ts
+}
+
+func signedProposal(t *testing.T, channelID string) (*pb.SignedProposal, string) {
+	prop, txID, err := protoutil.CreateChaincodeProposal(
+		cb.HeaderType_ENDORSER_TRANSACTION,
+		channelID,
+		&pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}},
+		[]byte("client"),
+	)
+	require.NoError(t, err)
+	propBytes, err := proto.Marshal(prop)
+	require.NoError(t, err)
+	return &pb.SignedProposal{ProposalBytes: propBytes, Signature: []byte("signature")}, txID
+}
+
+func endorsers(t *testing.T, env *cb.Envelope) [][]byte {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	tx, err := protoutil.GetTransaction(payload.Data)
+	require.NoError(t, err)
+	cap, err := protoutil.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+	var identities [][]byte
+	for _, e := range cap.Action.Endorsements {
+		identities = append(identities, e.Endorser)
+	}
+	return identities
+}
+
+func requireStatus(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	s, ok := status.FromError(err)
+	require.True(t, ok, "not a gRPC status: %s", err)
+	require.Equal(t, code, s.Code(), s.Message())
+}
+
+func TestServerImplementsGatewayServer(t *testing.T) {
+	var _ gp.GatewayServer = &gateway.Server{}
+}
+
+func TestCreateServerBadIdentity(t *testing.T) {
+	_, err := gateway.CreateServer(nil, []byte("garbage"), nil, nil, nil, nil, nil, gateway.Options{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshaling local identity")
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Run("local peer", func(t *testing.T) {
+		ts := newTestSetup(t)
+		signedProp, txID := signedProposal(t, "mychannel")
+
+		resp, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{
+			TransactionId:       txID,
+			ChannelId:           "mychannel",
+			ProposedTransaction: signedProp,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("result"), resp.Result.Payload)
+		assert.Equal(t, 1, ts.localEndorser.ProcessProposalCallCount())
+		assert.Equal(t, 0, ts.connector.EndorserCallCount())
+	})
+
+	t.Run("target organization", func(t *testing.T) {
+		ts := newTestSetup(t)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		resp, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{
+			ChannelId:           "mychannel",
+			ProposedTransaction: signedProp,
+			TargetOrganizations: []string{"Org2MSP"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("result"), resp.Result.Payload)
+		assert.Equal(t, 0, ts.localEndorser.ProcessProposalCallCount())
+		assert.Equal(t, 1, ts.peers["peer0.org2"].client.ProcessProposalCallCount())
+	})
+
+	t.Run("unreachable peers are skipped", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.localEndorser.ProcessProposalReturns(nil, errors.New("boom"))
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{
+			ChannelId:           "mychannel",
+			ProposedTransaction: signedProp,
+			TargetOrganizations: []string{"Org1MSP"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, ts.localEndorser.ProcessProposalCallCount())
+		assert.Equal(t, 1, ts.peers["peer1.org1"].client.ProcessProposalCallCount())
+	})
+
+	t.Run("chaincode error", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.localEndorser.ProcessProposalReturns(&pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}, nil)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: "mychannel", ProposedTransaction: signedProp})
+		requireStatus(t, codes.Aborted, err)
+		assert.Contains(t, err.Error(), "chaincode failed")
+	})
+
+	t.Run("all peers unavailable", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.localEndorser.ProcessProposalReturns(nil, errors.New("boom"))
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: "mychannel", ProposedTransaction: signedProp})
+		requireStatus(t, codes.Unavailable, err)
+		assert.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("unknown organization", func(t *testing.T) {
+		ts := newTestSetup(t)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{
+			ChannelId:           "mychannel",
+			ProposedTransaction: signedProp,
+			TargetOrganizations: []string{"Org3MSP"},
+		})
+		requireStatus(t, codes.Unavailable, err)
+		assert.Contains(t, err.Error(), "no peers of organization Org3MSP are available on channel mychannel")
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		ts := newTestSetup(t)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: "mychannel"})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		_, err = ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: "otherchannel", ProposedTransaction: signedProp})
+		requireStatus(t, codes.InvalidArgument, err)
+		assert.Contains(t, err.Error(), "the proposal is for channel mychannel, not otherchannel")
+
+		_, err = ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: "mychannel", TransactionId: "txid", ProposedTransaction: signedProp})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		_, err = ts.server.Evaluate(context.Background(), &gp.EvaluateRequest{
+			ChannelId:           "mychannel",
+			ProposedTransaction: &pb.SignedProposal{ProposalBytes: []byte("garbage")},
+		})
+		requireStatus(t, codes.InvalidArgument, err)
+	})
+}
+
+func TestEndorse(t *testing.T) {
+	plan := func(ts *testSetup) *dp.EndorsementDescriptor {
+		return &dp.EndorsementDescriptor{
+			Chaincode: "mycc",
+			EndorsersByGroups: map[string]*dp.Peers{
+				"G1": {Peers: []*dp.Peer{{Identity: ts.peers["peer1.org1"].identity}, {Identity: ts.localIdentity}}},
+				"G2": {Peers: []*dp.Peer{{Identity: ts.peers["peer0.org2"].identity}}},
+			},
+			Layouts: []*dp.Layout{
+				{QuantitiesByGroup: map[string]uint32{"G1": 1, "G2": 1}},
+				{QuantitiesByGroup: map[string]uint32{"G1": 2}},
+			},
+		}
+	}
+
+	t.Run("endorsement plan", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.planner.PeersForEndorsementReturns(plan(ts), nil)
+		signedProp, txID := signedProposal(t, "mychannel")
+
+		resp, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{
+			TransactionId:       txID,
+			ChannelId:           "mychannel",
+			ProposedTransaction: signedProp,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp.PreparedTransaction.Signature)
+		assert.Equal(t, [][]byte{ts.localIdentity, ts.peers["peer0.org2"].identity}, endorsers(t, resp.PreparedTransaction))
+
+		channel, interest := ts.planner.PeersForEndorsementArgsForCall(0)
+		assert.Equal(t, gcommon.ChannelID("mychannel"), channel)
+		assert.True(t, proto.Equal(&dp.ChaincodeInterest{Chaincodes: []*dp.ChaincodeCall{{Name: "mycc"}}}, interest))
+		assert.Equal(t, 0, ts.peers["peer1.org1"].client.ProcessProposalCallCount())
+	})
+
+	t.Run("falls back to the next layout", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.planner.PeersForEndorsementReturns(plan(ts), nil)
+		ts.peers["peer0.org2"].client.ProcessProposalReturns(nil, errors.New("unreachable"))
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		resp, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{ChannelId: "mychannel", ProposedTransaction: signedProp})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{ts.localIdentity, ts.peers["peer1.org1"].identity}, endorsers(t, resp.PreparedTransaction))
+		// the endorsement of the local peer is reused across layouts
+		assert.Equal(t, 1, ts.localEndorser.ProcessProposalCallCount())
+	})
+
+	t.Run("endorsing organizations", func(t *testing.T) {
+		ts := newTestSetup(t)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		resp, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{
+			ChannelId:              "mychannel",
+			ProposedTransaction:    signedProp,
+			EndorsingOrganizations: []string{"Org2MSP", "Org1MSP"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{ts.peers["peer0.org2"].identity, ts.localIdentity}, endorsers(t, resp.PreparedTransaction))
+		assert.Equal(t, 0, ts.planner.PeersForEndorsementCallCount())
+	})
+
+	t.Run("not enough endorsements", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.planner.PeersForEndorsementReturns(plan(ts), nil)
+		ts.peers["peer0.org2"].client.ProcessProposalReturns(nil, errors.New("unreachable"))
+		ts.peers["peer1.org1"].client.ProcessProposalReturns(&pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}, nil)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{ChannelId: "mychannel", ProposedTransaction: signedProp})
+		requireStatus(t, codes.Aborted, err)
+		assert.Contains(t, err.Error(), "unreachable")
+		assert.Contains(t, err.Error(), "chaincode failed")
+	})
+
+	t.Run("mismatched responses", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.peers["peer0.org2"].client.ProcessProposalReturns(proposalResponse(ts.peers["peer0.org2"].identity, 200, "other payload"), nil)
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{
+			ChannelId:              "mychannel",
+			ProposedTransaction:    signedProp,
+			EndorsingOrganizations: []string{"Org1MSP", "Org2MSP"},
+		})
+		requireStatus(t, codes.Aborted, err)
+		assert.Contains(t, err.Error(), "ProposalResponsePayloads do not match")
+	})
+
+	t.Run("planner failure", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.planner.PeersForEndorsementReturns(nil, errors.New("no peer combination can satisfy the endorsement policy"))
+		signedProp, _ := signedProposal(t, "mychannel")
+
+		_, err := ts.server.Endorse(context.Background(), &gp.EndorseRequest{ChannelId: "mychannel", ProposedTransaction: signedProp})
+		requireStatus(t, codes.FailedPrecondition, err)
+		assert.Contains(t, err.Error(), "failed computing the endorsement plan of chaincode mycc on channel mychannel")
+	})
+}
+
+func signedTransaction(t *testing.T, channelID string) (*cb.Envelope, string) {
+	signedProp, txID := signedProposal(t, channelID)
+	prop, err := protoutil.GetProposal(signedProp.ProposalBytes)
+	require.NoError(t, err)
+	env, err := protoutil.CreateTx(prop, proposalResponse([]byte("endorser"), 200, "payload"))
+	require.NoError(t, err)
+	env.Signature = []byte("signature")
+	return env, txID
+}
+
+func TestSubmit(t *testing.T) {
+	newOrderer := func(ts *testSetup, status cb.Status) *mocks.BroadcastStream {
+		stream := &mocks.BroadcastStream{}
+		stream.RecvReturns(&ab.BroadcastResponse{Status: status}, nil)
+		client := &mocks.Broadcaster{}
+		client.BroadcastReturns(stream, nil)
+		ts.connector.BroadcasterReturns(client, nil)
+		return stream
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.channels.OrdererAddressesReturns([]string{"orderer:7050"}, nil)
+		stream := newOrderer(ts, cb.Status_SUCCESS)
+		env, txID := signedTransaction(t, "mychannel")
+
+		_, err := ts.server.Submit(context.Background(), &gp.SubmitRequest{TransactionId: txID, ChannelId: "mychannel", PreparedTransaction: env})
+		require.NoError(t, err)
+		assert.Equal(t, "mychannel", ts.channels.OrdererAddressesArgsForCall(0))
+		_, address := ts.connector.BroadcasterArgsForCall(0)
+		assert.Equal(t, "orderer:7050", address)
+		require.Equal(t, 1, stream.SendCallCount())
+		assert.True(t, proto.Equal(env, stream.SendArgsForCall(0)))
+		assert.Equal(t, 1, stream.CloseSendCallCount())
+	})
+
+	t.Run("tries the other orderers", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.channels.OrdererAddressesReturns([]string{"orderer1:7050", "orderer2:7050"}, nil)
+		stream := newOrderer(ts, cb.Status_SUCCESS)
+		stream.RecvReturnsOnCall(0, &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE}, nil)
+		env, _ := signedTransaction(t, "mychannel")
+
+		_, err := ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "mychannel", PreparedTransaction: env})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stream.SendCallCount())
+	})
+
+	t.Run("all orderers fail", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.channels.OrdererAddressesReturns([]string{"orderer1:7050", "orderer2:7050"}, nil)
+		ts.connector.BroadcasterReturns(nil, errors.New("connection refused"))
+		env, _ := signedTransaction(t, "mychannel")
+
+		_, err := ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "mychannel", PreparedTransaction: env})
+		requireStatus(t, codes.Unavailable, err)
+		assert.Contains(t, err.Error(), "orderer1:7050: connection refused")
+		assert.Contains(t, err.Error(), "orderer2:7050: connection refused")
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.channels.OrdererAddressesReturns(nil, errors.New("channel mychannel not found"))
+		env, _ := signedTransaction(t, "mychannel")
+
+		_, err := ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "mychannel", PreparedTransaction: env})
+		requireStatus(t, codes.NotFound, err)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		ts := newTestSetup(t)
+		env, _ := signedTransaction(t, "mychannel")
+
+		_, err := ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "mychannel"})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		_, err = ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "otherchannel", PreparedTransaction: env})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		env.Signature = nil
+		_, err = ts.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: "mychannel", PreparedTransaction: env})
+		requireStatus(t, codes.InvalidArgument, err)
+		assert.Contains(t, err.Error(), "the prepared transaction must be signed")
+		assert.Equal(t, 0, ts.connector.BroadcasterCallCount())
+	})
+}
+
+func blockWithTransaction(t *testing.T, number uint64, txID string, code pb.TxValidationCode) *cb.Block {
+	payload, err := proto.Marshal(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "mychannel", TxId: txID})},
+	})
+	require.NoError(t, err)
+	block := protoutil.NewBlock(number, nil)
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(&cb.Envelope{Payload: payload})}
+	txFilter := util.NewTxValidationFlags(1)
+	txFilter.SetFlag(0, code)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = txFilter
+	return block
+}
+
+func TestCommitStatus(t *testing.T) {
+	signedRequest := func(t *testing.T, txID string) *gp.SignedCommitStatusRequest {
+		request, err := proto.Marshal(&gp.CommitStatusRequest{TransactionId: txID, ChannelId: "mychannel", Identity: []byte("client")})
+		require.NoError(t, err)
+		return &gp.SignedCommitStatusRequest{Request: request, Signature: []byte("signature")}
+	}
+	newLedger := func(ts *testSetup) (*mocks.ChannelLedger, *mocks.BlockIterator) {
+		l := &mocks.ChannelLedger{}
+		l.HeightReturns(5)
+		l.GetTransactionByIDReturns(nil, ledger.NotFoundInIndexErr("txid"))
+		itr := &mocks.BlockIterator{}
+		l.IteratorReturns(itr, 5)
+		ts.channels.LedgerReturns(l, nil)
+		return l, itr
+	}
+
+	t.Run("already committed", func(t *testing.T) {
+		ts := newTestSetup(t)
+		l, itr := newLedger(ts)
+		l.GetTransactionByIDReturns(&pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}, nil)
+		l.GetBlockByTxIDReturns(protoutil.NewBlock(3, nil), nil)
+
+		resp, err := ts.server.CommitStatus(context.Background(), signedRequest(t, "txid"))
+		require.NoError(t, err)
+		assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, resp.Result)
+		assert.Equal(t, uint64(3), resp.BlockNumber)
+		assert.Equal(t, 0, itr.NextCallCount())
+		assert.Equal(t, 1, itr.CloseCallCount())
+
+		resName, channelID, idinfo := ts.aclChecker.CheckACLArgsForCall(0)
+		assert.Equal(t, resources.Event_FilteredBlock, resName)
+		assert.Equal(t, "mychannel", channelID)
+		assert.Equal(t, []*protoutil.SignedData{{
+			Data:      signedRequest(t, "txid").Request,
+			Identity:  []byte("client"),
+			Signature: []byte("signature"),
+		}}, idinfo)
+	})
+
+	t.Run("waits for the commit", func(t *testing.T) {
+		ts := newTestSetup(t)
+		l, itr := newLedger(ts)
+		itr.NextReturnsOnCall(0, blockWithTransaction(t, 5, "othertx", pb.TxValidationCode_VALID), cb.Status_SUCCESS)
+		itr.NextReturnsOnCall(1, blockWithTransaction(t, 6, "txid", pb.TxValidationCode_VALID), cb.Status_SUCCESS)
+
+		resp, err := ts.server.CommitStatus(context.Background(), signedRequest(t, "txid"))
+		require.NoError(t, err)
+		assert.Equal(t, pb.TxValidationCode_VALID, resp.Result)
+		assert.Equal(t, uint64(6), resp.BlockNumber)
+
+		seek := l.IteratorArgsForCall(0)
+		assert.Equal(t, uint64(5), seek.GetSpecified().Number)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ts := newTestSetup(t)
+		_, itr := newLedger(ts)
+		closed := make(chan struct{})
+		itr.NextStub = func() (*cb.Block, cb.Status) {
+			<-closed
+			return nil, cb.Status_SERVICE_UNAVAILABLE
+		}
+		itr.CloseStub = func() { close(closed) }
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := ts.server.CommitStatus(ctx, signedRequest(t, "txid"))
+		requireStatus(t, codes.DeadlineExceeded, err)
+		assert.Equal(t, 1, itr.CloseCallCount())
+	})
+
+	t.Run("ledger failure", func(t *testing.T) {
+		ts := newTestSetup(t)
+		l, _ := newLedger(ts)
+		l.GetTransactionByIDReturns(nil, errors.New("disk on fire"))
+
+		_, err := ts.server.CommitStatus(context.Background(), signedRequest(t, "txid"))
+		requireStatus(t, codes.Unavailable, err)
+		assert.Contains(t, err.Error(), "disk on fire")
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.aclChecker.CheckACLReturns(errors.New("not a reader"))
+
+		_, err := ts.server.CommitStatus(context.Background(), signedRequest(t, "txid"))
+		requireStatus(t, codes.PermissionDenied, err)
+		assert.Equal(t, 0, ts.channels.LedgerCallCount())
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		ts := newTestSetup(t)
+		ts.channels.LedgerReturns(nil, errors.New("channel mychannel not found"))
+
+		_, err := ts.server.CommitStatus(context.Background(), signedRequest(t, "txid"))
+		requireStatus(t, codes.NotFound, err)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		ts := newTestSetup(t)
+
+		_, err := ts.server.CommitStatus(context.Background(), &gp.SignedCommitStatusRequest{})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		_, err = ts.server.CommitStatus(context.Background(), &gp.SignedCommitStatusRequest{Request: []byte("garbage")})
+		requireStatus(t, codes.InvalidArgument, err)
+
+		_, err = ts.server.CommitStatus(context.Background(), signedRequest(t, ""))
+		requireStatus(t, codes.InvalidArgument, err)
+	})
+}
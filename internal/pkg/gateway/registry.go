@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"sort"
+
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	dp "github.com/hyperledger/fabric/protos/discovery"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// endorser is a peer of a channel that can be asked to endorse proposals
+type endorser struct {
+	address  string
+	mspID    string
+	identity []byte
+	// client is only set for the local peer; remote peers are reached through the connector
+	client pb.EndorserClient
+}
+
+func (e *endorser) String() string {
+	if e.address == "" {
+		return "local peer (" + e.mspID + ")"
+	}
+	return e.address + " (" + e.mspID + ")"
+}
+
+// group is a set of endorsers of which quantity must endorse a proposal
+type group struct {
+	endorsers []*endorser
+	quantity  int
+}
+
+// layout is a combination of groups whose endorsements satisfy an endorsement policy
+type layout []*group
+
+// localEndorserClient adapts the endorser of the local peer to an EndorserClient
+type localEndorserClient struct {
+	pb.EndorserServer
+}
+
+func (l *localEndorserClient) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal, _ ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	return l.EndorserServer.ProcessProposal(ctx, signedProp)
+}
+
+// registry resolves the peers of a channel and the endorsement plans of its chaincodes
+type registry struct {
+	local      *endorser
+	planner    EndorsementPlanner
+	membership ChannelMembership
+	connector  Connector
+}
+
+// client returns an EndorserClient connected to the given endorser
+func (r *registry) client(ctx context.Context, e *endorser) (pb.EndorserClient, error) {
+	if e.client != nil {
+		return e.client, nil
+	}
+	return r.connector.Endorser(ctx, e.address)
+}
+
+// channelEndorsers returns the local peer followed by the alive peers of the
+// channel whose identity is known
+func (r *registry) channelEndorsers(channelID string) []*endorser {
+	endorsers := []*endorser{r.local}
+	identities := r.membership.IdentityInfo().ByID()
+	for _, member := range r.membership.PeersOfChannel(gcommon.ChannelID(channelID)) {
+		identity, ok := identities[string(member.PKIid)]
+		if !ok {
+			logger.Debugf("No identity found for peer %s, skipping it", member.PreferredEndpoint())
+			continue
+		}
+		address := member.PreferredEndpoint()
+		if address == "" {
+			continue
+		}
+		endorsers = append(endorsers, &endorser{
+			address:  address,
+			mspID:    string(identity.Organization),
+			identity: identity.Identity,
+		})
+	}
+	return endorsers
+}
+
+// endorsersForOrgs returns a layout made of a group per organization, each
+// of which must provide a single endorsement
+func (r *registry) endorsersForOrgs(channelID string, mspIDs []string) (layout, error) {
+	byOrg := map[string][]*endorser{}
+	for _, e := range r.channelEndorsers(channelID) {
+		byOrg[e.mspID] = append(byOrg[e.mspID], e)
+	}
+
+	var l layout
+	for _, mspID := range mspIDs {
+		endorsers := byOrg[mspID]
+		if len(endorsers) == 0 {
+			return nil, errors.Errorf("no peers of organization %s are available on channel %s", mspID, channelID)
+		}
+		l = append(l, &group{endorsers: endorsers, quantity: 1})
+	}
+	return l, nil
+}
+
+// endorsementPlan returns the layouts satisfying the endorsement policy of the
+// chaincode, as computed by the planner
+func (r *registry) endorsementPlan(channelID, chaincodeName string) ([]layout, error) {
+	desc, err := r.planner.PeersForEndorsement(gcommon.ChannelID(channelID), &dp.ChaincodeInterest{
+		Chaincodes: []*dp.ChaincodeCall{{Name: chaincodeName}},
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed computing the endorsement plan of chaincode %s on channel %s", chaincodeName, channelID)
+	}
+
+	byIdentity := map[string]*endorser{}
+	for _, e := range r.channelEndorsers(channelID) {
+		byIdentity[string(e.identity)] = e
+	}
+
+	var layouts []layout
+	for _, l := range desc.Layouts {
+		var names []string
+		for name := range l.QuantitiesByGroup {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var groups layout
+		for _, name := range names {
+			g := &group{quantity: int(l.QuantitiesByGroup[name])}
+			for _, p := range desc.EndorsersByGroups[name].GetPeers() {
+				e, ok := byIdentity[string(p.Identity)]
+				if !ok {
+					continue
+				}
+				// the local peer is always tried first as it does not need a connection
+				if e == r.local {
+					g.endorsers = append([]*endorser{e}, g.endorsers...)
+					continue
+				}
+				g.endorsers = append(g.endorsers, e)
+			}
+			groups = append(groups, g)
+		}
+		layouts = append(layouts, groups)
+	}
+	return layouts, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// PeerChannelSupport provides access to the channels the peer has joined
+type PeerChannelSupport struct {
+	Peer *peer.Peer
+}
+
+// OrdererAddresses returns the ordering service endpoints in the config of the channel
+func (p *PeerChannelSupport) OrdererAddresses(channelID string) ([]string, error) {
+	channel := p.Peer.Channel(channelID)
+	if channel == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	return channel.Resources().ChannelConfig().OrdererAddresses(), nil
+}
+
+// Ledger returns the ledger of the channel
+func (p *PeerChannelSupport) Ledger(channelID string) (ChannelLedger, error) {
+	channel := p.Peer.Channel(channelID)
+	if channel == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	return &channelLedger{PeerLedger: channel.Ledger(), Reader: channel.Reader()}, nil
+}
+
+type channelLedger struct {
+	ledger.PeerLedger
+	blockledger.Reader
+}
+
+// NewConnector creates a Connector that dials remote endpoints with the
+// options returned by dialOpts and reuses the connections it establishes
+func NewConnector(dialOpts func() []grpc.DialOption, dialTimeout time.Duration) Connector {
+	return &connector{
+		dialOpts:    dialOpts,
+		dialTimeout: dialTimeout,
+		conns:       map[string]*grpc.ClientConn{},
+	}
+}
+
+type connector struct {
+	dialOpts    func() []grpc.DialOption
+	dialTimeout time.Duration
+
+	mutex sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func (c *connector) Endorser(ctx context.Context, address string) (pb.EndorserClient, error) {
+	conn, err := c.connection(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewEndorserClient(conn), nil
+}
+
+func (c *connector) Broadcaster(ctx context.Context, address string) (ab.AtomicBroadcastClient, error) {
+	conn, err := c.connection(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return ab.NewAtomicBroadcastClient(conn), nil
+}
+
+func (c *connector) connection(ctx context.Context, address string) (*grpc.ClientConn, error) {
+	c.mutex.Lock()
+	conn, ok := c.conns[address]
+	c.mutex.Unlock()
+	if ok && conn.GetState() != connectivity.Shutdown {
+		return conn, nil
+	}
+
+	if c.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.dialTimeout)
+		defer cancel()
+	}
+	conn, err := grpc.DialContext(ctx, address, append(c.dialOpts(), grpc.WithBlock())...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed connecting to %s", address)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if existing, ok := c.conns[address]; ok && existing.GetState() != connectivity.Shutdown {
+		// another request connected in the meantime
+		conn.Close()
+		return existing, nil
+	}
+	c.conns[address] = conn
+	return conn, nil
+}
@@ -81,7 +81,12 @@ func sanitizeECDSASignedCert(cert *x509.Certificate, parentCert *x509.Certificat
 		return nil, errors.New("parent certificate must be different from nil")
 	}
 
-	expectedSig, err := utils.SignatureToLowS(parentCert.PublicKey.(*ecdsa.PublicKey), cert.Signature)
+	parentPK, ok := parentCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("parent certificate must have an ECDSA public key")
+	}
+
+	expectedSig, err := utils.SignatureToLowS(parentPK, cert.Signature)
 	if err != nil {
 		return nil, err
 	}
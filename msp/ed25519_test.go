@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestED25519Identities(t *testing.T) {
+	dir, err := ioutil.TempDir("", "msp-ed25519")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, d := range []string{cacerts, admincerts, signcerts, keystore} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), "example.com", "ca.example.com", "", "", "", "", "", "", csp.ED25519)
+	require.NoError(t, err)
+	writeCertPEM(t, filepath.Join(dir, cacerts, "ca.pem"), signCA.SignCert)
+
+	priv, err := csp.GeneratePrivateKey(filepath.Join(dir, keystore), csp.ED25519)
+	require.NoError(t, err)
+	cert, err := signCA.SignCertificate(filepath.Join(dir, signcerts), "peer0", nil, nil, priv.Public(), x509.KeyUsageDigitalSignature, nil)
+	require.NoError(t, err)
+	writeCertPEM(t, filepath.Join(dir, admincerts, "admin.pem"), cert)
+
+	certPEM, err := ioutil.ReadFile(filepath.Join(dir, signcerts, "peer0-cert.pem"))
+	require.NoError(t, err)
+	keyPEM, err := ioutil.ReadFile(filepath.Join(dir, keystore, "priv_sk"))
+	require.NoError(t, err)
+
+	// the private key is not in the BCCSP key store, it is imported from the key material
+	sigid := &msp.SigningIdentityInfo{
+		PublicSigner:  certPEM,
+		PrivateSigner: &msp.KeyInfo{KeyIdentifier: "PEER", KeyMaterial: keyPEM},
+	}
+	mspConf, err := getMspConfig(dir, "ED25519MSP", sigid)
+	require.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_3)
+	require.NoError(t, err)
+	require.NoError(t, thisMSP.Setup(mspConf))
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	msg := []byte("foo")
+	sig, err := id.Sign(msg)
+	require.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Error(t, id.Verify([]byte("bar"), sig))
+
+	// the message itself is signed rather than its digest, as Ed25519 specifies
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), msg, sig))
+	assert.NoError(t, id.Verify(msg, ed25519.Sign(priv.(ed25519.PrivateKey), msg)))
+
+	serialized, err := id.Serialize()
+	require.NoError(t, err)
+	deserialized, err := thisMSP.DeserializeIdentity(serialized)
+	require.NoError(t, err)
+	assert.NoError(t, deserialized.Validate())
+	assert.NoError(t, deserialized.Verify(msg, sig))
+
+	principal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoMarshal(t, &msp.MSPRole{Role: msp.MSPRole_ADMIN, MspIdentifier: "ED25519MSP"}),
+	}
+	assert.NoError(t, deserialized.SatisfiesPrincipal(principal))
+
+	// an ECDSA identity issued by the Ed25519 CA is valid as well
+	ecdsaPriv, err := csp.GeneratePrivateKey(filepath.Join(dir, keystore), csp.ECDSA)
+	require.NoError(t, err)
+	ecdsaCert, err := signCA.SignCertificate(filepath.Join(dir, signcerts), "peer1", nil, nil, ecdsaPriv.Public(), x509.KeyUsageDigitalSignature, nil)
+	require.NoError(t, err)
+	ecdsaID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ecdsaCert.Raw}))
+	require.NoError(t, err)
+	assert.NoError(t, thisMSP.Validate(ecdsaID))
+}
+
+func writeCertPEM(t *testing.T, path string, cert *x509.Certificate) {
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0644)
+	require.NoError(t, err)
+}
+
+func protoMarshal(t *testing.T, m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
@@ -8,6 +8,7 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
@@ -214,9 +215,9 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		if pemKey == nil {
 			return nil, errors.Errorf("%s: wrong PEM encoding", sidInfo.PrivateSigner.KeyIdentifier)
 		}
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, privateKeyImportOpts(idPub.(*identity).cert))
 		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import private key")
 		}
 	}
 
@@ -229,6 +230,15 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 	return newSigningIdentity(idPub.(*identity).cert, idPub.(*identity).pk, peerSigner, msp)
 }
 
+// privateKeyImportOpts returns the options to import the private key
+// matching the public key of the given certificate.
+func privateKeyImportOpts(cert *x509.Certificate) bccsp.KeyImportOpts {
+	if _, isED25519 := cert.PublicKey.(ed25519.PublicKey); isED25519 {
+		return &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+	}
+	return &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+}
+
 // Setup sets up the internal data structures
 // for this MSP, given an MSPConfig ref; it
 // returns nil in case of success or an error otherwise
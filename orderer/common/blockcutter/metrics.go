@@ -16,14 +16,32 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	laneBlockFill = metrics.HistogramOpts{
+		Namespace:    "blockcutter",
+		Name:         "lane_block_fill",
+		Help:         "The number of transactions of a priority lane in each block cut by the priority block cutter.",
+		LabelNames:   []string{"channel", "lane"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{lane}",
+	}
+	laneWaitDuration = metrics.HistogramOpts{
+		Namespace:    "blockcutter",
+		Name:         "lane_wait_duration",
+		Help:         "The time from the enqueing of the oldest transaction of a priority lane in a block to the block being cut in seconds.",
+		LabelNames:   []string{"channel", "lane"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{lane}",
+	}
 )
 
 type Metrics struct {
 	BlockFillDuration metrics.Histogram
+	LaneBlockFill     metrics.Histogram
+	LaneWaitDuration  metrics.Histogram
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		LaneBlockFill:     p.NewHistogram(laneBlockFill),
+		LaneWaitDuration:  p.NewHistogram(laneWaitDuration),
 	}
 }
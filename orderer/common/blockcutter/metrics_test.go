@@ -29,8 +29,10 @@ var _ = Describe("Metrics", func() {
 			metrics := blockcutter.NewMetrics(fakeProvider)
 			Expect(metrics).NotTo(BeNil())
 			Expect(metrics.BlockFillDuration).To(Equal(&mock.MetricsHistogram{}))
+			Expect(metrics.LaneBlockFill).To(Equal(&mock.MetricsHistogram{}))
+			Expect(metrics.LaneWaitDuration).To(Equal(&mock.MetricsHistogram{}))
 
-			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(3))
 		})
 	})
 })
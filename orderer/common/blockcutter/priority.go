@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// PriorityLane is a lane of the messages ordered by the priority receiver
+type PriorityLane struct {
+	// Name of the lane, used as the label of the lane metrics
+	Name string
+	// MaxWait is the longest time that a message of the lane stays pending. Once a message of
+	// the lane has been pending for longer, the pending batch is cut when the next message is
+	// ordered. The batch timeout of the consenter still applies when no message is ordered.
+	// Zero means that the messages of the lane have no deadline
+	MaxWait time.Duration
+}
+
+// LaneSelector returns the index, in the lanes of the priority receiver, of the lane of the message.
+// An index out of the range of the lanes selects the lane with the lowest priority
+type LaneSelector func(msg *cb.Envelope) int
+
+// Indexes of the lanes selected by the LaneSelector returned by NewMSPLaneSelector
+const (
+	HighPriorityLane = iota
+	NormalPriorityLane
+)
+
+// NewMSPLaneSelector returns a LaneSelector for two lanes. The configuration messages and the
+// messages created by the given MSPs are assigned to the HighPriorityLane and the rest to the
+// NormalPriorityLane
+func NewMSPLaneSelector(highPriorityMSPs ...string) LaneSelector {
+	msps := map[string]struct{}{}
+	for _, mspID := range highPriorityMSPs {
+		msps[mspID] = struct{}{}
+	}
+	return func(msg *cb.Envelope) int {
+		payload, err := protoutil.UnmarshalPayload(msg.Payload)
+		if err != nil || payload.Header == nil {
+			return NormalPriorityLane
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return NormalPriorityLane
+		}
+		switch cb.HeaderType(chdr.Type) {
+		case cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_ORDERER_TRANSACTION:
+			return HighPriorityLane
+		}
+		shdr, err := protoutil.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return NormalPriorityLane
+		}
+		creator := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(shdr.Creator, creator); err != nil {
+			return NormalPriorityLane
+		}
+		if _, ok := msps[creator.Mspid]; ok {
+			return HighPriorityLane
+		}
+		return NormalPriorityLane
+	}
+}
+
+type pendingMessage struct {
+	msg        *cb.Envelope
+	sizeBytes  uint32
+	enqueuedAt time.Time
+}
+
+type priorityReceiver struct {
+	sharedConfigFetcher OrdererConfigFetcher
+	lanes               []PriorityLane
+	selectLane          LaneSelector
+
+	pendingLanes          [][]*pendingMessage
+	pendingCount          uint32
+	pendingBatchSizeBytes uint32
+	pendingBatchStartTime time.Time
+
+	channelID string
+	metrics   *Metrics
+}
+
+// NewPriorityReceiver creates a Receiver that keeps the pending messages in priority lanes. A batch is
+// filled with the pending messages in the order of the lanes, the first lane having the highest priority,
+// and in the order in which the messages were ordered within a lane. When the pending messages exceed the
+// BatchSize.MaxMessageCount or the BatchSize.PreferredMaxBytes, a batch is cut with the messages of the
+// higher priority lanes and the messages of the lower priority lanes that do not fit in the batch remain
+// pending. Hence, a message of a higher priority lane is never left pending behind a full batch of the
+// messages of the lower priority lanes. Unlike the Receiver returned by NewReceiverImpl, more than two
+// batches may be returned by Ordered
+func NewPriorityReceiver(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics, lanes []PriorityLane, selectLane LaneSelector) Receiver {
+	if len(lanes) == 0 {
+		logger.Panicf("At least one priority lane is required")
+	}
+	return &priorityReceiver{
+		sharedConfigFetcher: sharedConfigFetcher,
+		lanes:               lanes,
+		selectLane:          selectLane,
+		pendingLanes:        make([][]*pendingMessage, len(lanes)),
+		channelID:           channelID,
+		metrics:             metrics,
+	}
+}
+
+// Ordered should be invoked sequentially as messages are ordered
+func (r *priorityReceiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
+	now := time.Now()
+	if r.pendingCount == 0 {
+		r.pendingBatchStartTime = now
+	}
+
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+	batchSize := ordererConfig.BatchSize()
+
+	lane := r.selectLane(msg)
+	if lane < 0 || lane >= len(r.lanes) {
+		lane = len(r.lanes) - 1
+	}
+
+	messageSizeBytes := messageSizeBytes(msg)
+	if messageSizeBytes > batchSize.PreferredMaxBytes {
+		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
+
+		if r.pendingCount > 0 {
+			messageBatches = append(messageBatches, r.Cut())
+		}
+		messageBatches = append(messageBatches, []*cb.Envelope{msg})
+
+		// Record that this batch took no time to fill
+		r.metrics.BlockFillDuration.With("channel", r.channelID).Observe(0)
+		r.observeLanes(map[int]int{lane: 1}, map[int]time.Duration{lane: 0})
+		return messageBatches, false
+	}
+
+	logger.Debugf("Enqueuing message into lane %s", r.lanes[lane].Name)
+	r.pendingLanes[lane] = append(r.pendingLanes[lane], &pendingMessage{msg: msg, sizeBytes: messageSizeBytes, enqueuedAt: now})
+	r.pendingCount++
+	r.pendingBatchSizeBytes += messageSizeBytes
+
+	for r.pendingCount > 0 && (r.pendingCount >= batchSize.MaxMessageCount || r.pendingBatchSizeBytes > batchSize.PreferredMaxBytes) {
+		logger.Debugf("Pending messages fill a batch, cutting batch with the messages of the highest priority")
+		messageBatches = append(messageBatches, r.cutBatch(batchSize.MaxMessageCount, batchSize.PreferredMaxBytes))
+	}
+
+	if lane, ok := r.expiredLane(now); ok {
+		logger.Debugf("A message of lane %s has been pending for longer than %s, cutting batch", r.lanes[lane].Name, r.lanes[lane].MaxWait)
+		messageBatches = append(messageBatches, r.Cut())
+	}
+
+	return messageBatches, r.pendingCount > 0
+}
+
+// Cut returns the current batch and starts a new one
+func (r *priorityReceiver) Cut() []*cb.Envelope {
+	if r.pendingCount == 0 {
+		r.pendingBatchStartTime = time.Time{}
+		return nil
+	}
+	return r.cutBatch(r.pendingCount, r.pendingBatchSizeBytes)
+}
+
+// cutBatch returns a batch of at most maxCount messages and maxBytes bytes, taken from the pending messages
+// in the order of the lanes. At least one message is taken
+func (r *priorityReceiver) cutBatch(maxCount, maxBytes uint32) []*cb.Envelope {
+	now := time.Now()
+	r.metrics.BlockFillDuration.With("channel", r.channelID).Observe(now.Sub(r.pendingBatchStartTime).Seconds())
+
+	var batch []*cb.Envelope
+	var batchSizeBytes uint32
+	laneCounts := map[int]int{}
+	laneWaits := map[int]time.Duration{}
+	full := false
+	for lane := range r.pendingLanes {
+		for !full && len(r.pendingLanes[lane]) > 0 {
+			next := r.pendingLanes[lane][0]
+			if len(batch) > 0 && (uint32(len(batch)) >= maxCount || batchSizeBytes+next.sizeBytes > maxBytes) {
+				full = true
+				break
+			}
+			if laneCounts[lane] == 0 {
+				laneWaits[lane] = now.Sub(next.enqueuedAt)
+			}
+			batch = append(batch, next.msg)
+			batchSizeBytes += next.sizeBytes
+			laneCounts[lane]++
+			r.pendingLanes[lane][0] = nil
+			r.pendingLanes[lane] = r.pendingLanes[lane][1:]
+		}
+	}
+	r.pendingCount -= uint32(len(batch))
+	r.pendingBatchSizeBytes -= batchSizeBytes
+	r.observeLanes(laneCounts, laneWaits)
+
+	// the remaining messages start the next batch
+	r.pendingBatchStartTime = time.Time{}
+	for _, pendingLane := range r.pendingLanes {
+		if len(pendingLane) > 0 && (r.pendingBatchStartTime.IsZero() || pendingLane[0].enqueuedAt.Before(r.pendingBatchStartTime)) {
+			r.pendingBatchStartTime = pendingLane[0].enqueuedAt
+		}
+	}
+	return batch
+}
+
+// expiredLane returns a lane whose oldest pending message has been pending for longer than the MaxWait of the lane
+func (r *priorityReceiver) expiredLane(now time.Time) (int, bool) {
+	for lane, pendingLane := range r.pendingLanes {
+		maxWait := r.lanes[lane].MaxWait
+		if maxWait > 0 && len(pendingLane) > 0 && now.Sub(pendingLane[0].enqueuedAt) >= maxWait {
+			return lane, true
+		}
+	}
+	return 0, false
+}
+
+func (r *priorityReceiver) observeLanes(laneCounts map[int]int, laneWaits map[int]time.Duration) {
+	for lane, l := range r.lanes {
+		r.metrics.LaneBlockFill.With("channel", r.channelID, "lane", l.Name).Observe(float64(laneCounts[lane]))
+		if laneCounts[lane] > 0 {
+			r.metrics.LaneWaitDuration.With("channel", r.channelID, "lane", l.Name).Observe(laneWaits[lane].Seconds())
+		}
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+var _ = Describe("PriorityReceiver", func() {
+	var (
+		bc                blockcutter.Receiver
+		fakeConfig        *mock.OrdererConfig
+		fakeConfigFetcher *mock.OrdererConfigFetcher
+		lanes             []blockcutter.PriorityLane
+
+		metrics               *blockcutter.Metrics
+		fakeBlockFillDuration *mock.MetricsHistogram
+		fakeLaneBlockFill     *mock.MetricsHistogram
+		fakeLaneWaitDuration  *mock.MetricsHistogram
+
+		highMessage   *cb.Envelope
+		normalMessage *cb.Envelope
+	)
+
+	// the messages with a payload beginning with "high" are assigned to the first lane
+	selectLane := func(msg *cb.Envelope) int {
+		if bytes.HasPrefix(msg.Payload, []byte("high")) {
+			return 0
+		}
+		return 1
+	}
+
+	BeforeEach(func() {
+		fakeConfig = &mock.OrdererConfig{}
+		fakeConfigFetcher = &mock.OrdererConfigFetcher{}
+		fakeConfigFetcher.OrdererConfigReturns(fakeConfig, true)
+		fakeConfig.BatchSizeReturns(&ab.BatchSize{
+			MaxMessageCount:   3,
+			PreferredMaxBytes: 100,
+		})
+
+		fakeBlockFillDuration = &mock.MetricsHistogram{}
+		fakeBlockFillDuration.WithReturns(fakeBlockFillDuration)
+		fakeLaneBlockFill = &mock.MetricsHistogram{}
+		fakeLaneBlockFill.WithReturns(fakeLaneBlockFill)
+		fakeLaneWaitDuration = &mock.MetricsHistogram{}
+		fakeLaneWaitDuration.WithReturns(fakeLaneWaitDuration)
+		metrics = &blockcutter.Metrics{
+			BlockFillDuration: fakeBlockFillDuration,
+			LaneBlockFill:     fakeLaneBlockFill,
+			LaneWaitDuration:  fakeLaneWaitDuration,
+		}
+
+		lanes = []blockcutter.PriorityLane{{Name: "high"}, {Name: "normal"}}
+		highMessage = &cb.Envelope{Payload: []byte("high priority"), Signature: []byte("-signature")}
+		normalMessage = &cb.Envelope{Payload: []byte("normal priority"), Signature: []byte("signature")}
+	})
+
+	JustBeforeEach(func() {
+		bc = blockcutter.NewPriorityReceiver("mychannel", fakeConfigFetcher, metrics, lanes, selectLane)
+	})
+
+	Describe("Ordered", func() {
+		It("adds the message to the pending batch", func() {
+			batches, pending := bc.Ordered(normalMessage)
+			Expect(batches).To(BeEmpty())
+			Expect(pending).To(BeTrue())
+			Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(0))
+		})
+
+		Context("when enough messages to fill the max message count are enqueued", func() {
+			It("cuts the batch with the messages of the higher priority lanes first", func() {
+				bc.Ordered(normalMessage)
+				bc.Ordered(normalMessage)
+				batches, pending := bc.Ordered(highMessage)
+				Expect(batches).To(Equal([][]*cb.Envelope{{highMessage, normalMessage, normalMessage}}))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(1))
+				Expect(fakeBlockFillDuration.ObserveArgsForCall(0)).To(BeNumerically(">", 0))
+				Expect(fakeBlockFillDuration.ObserveArgsForCall(0)).To(BeNumerically("<", 1))
+
+				Expect(fakeLaneBlockFill.WithCallCount()).To(Equal(2))
+				Expect(fakeLaneBlockFill.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "lane", "high"}))
+				Expect(fakeLaneBlockFill.ObserveArgsForCall(0)).To(Equal(float64(1)))
+				Expect(fakeLaneBlockFill.WithArgsForCall(1)).To(Equal([]string{"channel", "mychannel", "lane", "normal"}))
+				Expect(fakeLaneBlockFill.ObserveArgsForCall(1)).To(Equal(float64(2)))
+
+				Expect(fakeLaneWaitDuration.WithCallCount()).To(Equal(2))
+				Expect(fakeLaneWaitDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "lane", "high"}))
+				Expect(fakeLaneWaitDuration.WithArgsForCall(1)).To(Equal([]string{"channel", "mychannel", "lane", "normal"}))
+				Expect(fakeLaneWaitDuration.ObserveArgsForCall(1)).To(BeNumerically(">=", fakeLaneWaitDuration.ObserveArgsForCall(0)))
+			})
+		})
+
+		Context("when the message causes the pending messages to exceed the preferred max bytes", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: 50,
+				})
+			})
+
+			It("cuts a batch with the higher priority messages and leaves the lower priority messages pending", func() {
+				bc.Ordered(normalMessage)
+				bc.Ordered(normalMessage)
+				batches, pending := bc.Ordered(highMessage)
+				Expect(batches).To(Equal([][]*cb.Envelope{{highMessage, normalMessage}}))
+				Expect(pending).To(BeTrue())
+
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{normalMessage}))
+				Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(2))
+			})
+
+			It("keeps the messages of a lane in order", func() {
+				otherHighMessage := &cb.Envelope{Payload: []byte("high priority 2"), Signature: []byte("signature")}
+				bc.Ordered(highMessage)
+				bc.Ordered(normalMessage)
+				batches, pending := bc.Ordered(otherHighMessage)
+				Expect(batches).To(Equal([][]*cb.Envelope{{highMessage, otherHighMessage}}))
+				Expect(pending).To(BeTrue())
+				Expect(bc.Cut()).To(Equal([]*cb.Envelope{normalMessage}))
+			})
+		})
+
+		Context("when the message is larger than the preferred max bytes", func() {
+			var bigMessage *cb.Envelope
+
+			BeforeEach(func() {
+				bigMessage = &cb.Envelope{Payload: make([]byte, 1000)}
+			})
+
+			It("cuts the pending batch and isolates the message", func() {
+				bc.Ordered(normalMessage)
+				batches, pending := bc.Ordered(bigMessage)
+				Expect(batches).To(Equal([][]*cb.Envelope{{normalMessage}, {bigMessage}}))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(2))
+				Expect(fakeBlockFillDuration.ObserveArgsForCall(1)).To(Equal(float64(0)))
+				Expect(fakeLaneBlockFill.ObserveCallCount()).To(Equal(4))
+				Expect(fakeLaneBlockFill.ObserveArgsForCall(2)).To(Equal(float64(0)))
+				Expect(fakeLaneBlockFill.ObserveArgsForCall(3)).To(Equal(float64(1)))
+			})
+		})
+
+		Context("when a message of a lane has been pending for longer than the max wait of the lane", func() {
+			BeforeEach(func() {
+				lanes[0].MaxWait = 10 * time.Millisecond
+			})
+
+			It("cuts the pending batch on the next message", func() {
+				batches, pending := bc.Ordered(highMessage)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				time.Sleep(20 * time.Millisecond)
+				batches, pending = bc.Ordered(normalMessage)
+				Expect(batches).To(Equal([][]*cb.Envelope{{highMessage, normalMessage}}))
+				Expect(pending).To(BeFalse())
+				Expect(fakeLaneWaitDuration.ObserveArgsForCall(0)).To(BeNumerically(">=", 0.02))
+			})
+
+			It("does not cut the pending batch for the messages of the other lanes", func() {
+				bc.Ordered(normalMessage)
+				time.Sleep(20 * time.Millisecond)
+				batches, pending := bc.Ordered(normalMessage)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+			})
+		})
+
+		Context("when the selected lane does not exist", func() {
+			It("assigns the message to the lowest priority lane", func() {
+				bc = blockcutter.NewPriorityReceiver("mychannel", fakeConfigFetcher, metrics, lanes, func(*cb.Envelope) int { return 5 })
+				bc.Ordered(normalMessage)
+				bc.Cut()
+				Expect(fakeLaneBlockFill.ObserveArgsForCall(1)).To(Equal(float64(1)))
+			})
+		})
+
+		Context("when the orderer config cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeConfigFetcher.OrdererConfigReturns(nil, false)
+			})
+
+			It("panics", func() {
+				Expect(func() { bc.Ordered(normalMessage) }).To(Panic())
+			})
+		})
+	})
+
+	Describe("Cut", func() {
+		It("cuts an empty batch", func() {
+			Expect(bc.Cut()).To(BeNil())
+			Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(0))
+		})
+
+		It("cuts all the pending messages in the order of the lanes", func() {
+			bc.Ordered(normalMessage)
+			bc.Ordered(highMessage)
+			Expect(bc.Cut()).To(Equal([]*cb.Envelope{highMessage, normalMessage}))
+			Expect(bc.Cut()).To(BeNil())
+		})
+	})
+
+	It("panics without lanes", func() {
+		Expect(func() {
+			blockcutter.NewPriorityReceiver("mychannel", fakeConfigFetcher, metrics, nil, selectLane)
+		}).To(Panic())
+	})
+})
+
+var _ = Describe("MSPLaneSelector", func() {
+	envelope := func(headerType cb.HeaderType, mspID string) *cb.Envelope {
+		return &cb.Envelope{
+			Payload: protoutil.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType)}),
+					SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+						Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID}),
+					}),
+				},
+			}),
+		}
+	}
+
+	It("assigns the config messages and the messages of the given MSPs to the high priority lane", func() {
+		selectLane := blockcutter.NewMSPLaneSelector("Org1MSP", "Org2MSP")
+		Expect(selectLane(envelope(cb.HeaderType_ENDORSER_TRANSACTION, "Org1MSP"))).To(Equal(blockcutter.HighPriorityLane))
+		Expect(selectLane(envelope(cb.HeaderType_ENDORSER_TRANSACTION, "Org2MSP"))).To(Equal(blockcutter.HighPriorityLane))
+		Expect(selectLane(envelope(cb.HeaderType_ENDORSER_TRANSACTION, "Org3MSP"))).To(Equal(blockcutter.NormalPriorityLane))
+		Expect(selectLane(envelope(cb.HeaderType_CONFIG, "Org3MSP"))).To(Equal(blockcutter.HighPriorityLane))
+		Expect(selectLane(envelope(cb.HeaderType_CONFIG_UPDATE, "Org3MSP"))).To(Equal(blockcutter.HighPriorityLane))
+		Expect(selectLane(envelope(cb.HeaderType_ORDERER_TRANSACTION, "Org3MSP"))).To(Equal(blockcutter.HighPriorityLane))
+	})
+
+	It("assigns the malformed messages to the normal priority lane", func() {
+		selectLane := blockcutter.NewMSPLaneSelector("Org1MSP")
+		Expect(selectLane(&cb.Envelope{Payload: []byte("garbage")})).To(Equal(blockcutter.NormalPriorityLane))
+		Expect(selectLane(&cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{})})).To(Equal(blockcutter.NormalPriorityLane))
+	})
+})
@@ -60,6 +60,8 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Limiter enforces the admission control, when nil every message is admitted
+	Limiter *Limiter
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -157,6 +159,29 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
 	}
 
+	// admitted is set once the message passed validation and the limits of its
+	// creator, the token of the channel is given back otherwise.
+	admitted := false
+	if bh.Limiter != nil {
+		// The channel is limited before validating the message, as validation is
+		// the expensive part of processing it. The creator is only limited once
+		// the message has been validated, since it cannot be trusted before.
+		release, err := bh.Limiter.Acquire()
+		if err != nil {
+			return bh.throttled(err, tracker, addr)
+		}
+		defer release()
+
+		if err := bh.Limiter.AdmitChannel(chdr.ChannelId); err != nil {
+			return bh.throttled(err, tracker, addr)
+		}
+		defer func() {
+			if !admitted {
+				bh.Limiter.RefundChannel(chdr.ChannelId)
+			}
+		}()
+	}
+
 	if !isConfig {
 		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
@@ -165,6 +190,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
+		if resp := bh.admitCreator(msg, tracker, addr); resp != nil {
+			return resp
+		}
+		admitted = true
 		tracker.EndValidate()
 
 		tracker.BeginEnqueue()
@@ -186,6 +215,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
+		if resp := bh.admitCreator(msg, tracker, addr); resp != nil {
+			return resp
+		}
+		admitted = true
 		tracker.EndValidate()
 
 		tracker.BeginEnqueue()
@@ -206,6 +239,36 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+func (bh *Handler) admitCreator(msg *cb.Envelope, tracker *MetricsTracker, addr string) *ab.BroadcastResponse {
+	if bh.Limiter == nil {
+		return nil
+	}
+	err := bh.Limiter.AdmitCreator(msg)
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ErrThrottled); ok {
+		return bh.throttled(err, tracker, addr)
+	}
+	logger.Warningf("[channel: %s] Rejecting broadcast of message from %s because its creator could not be extracted: %s", tracker.ChannelID, addr, err)
+	return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+}
+
+func (bh *Handler) throttled(err error, tracker *MetricsTracker, addr string) *ab.BroadcastResponse {
+	limit := "unknown"
+	if throttled, ok := err.(*ErrThrottled); ok {
+		limit = throttled.Limit
+	}
+	bh.Metrics.ThrottledCount.With(
+		"channel", tracker.ChannelID,
+		"type", tracker.TxType,
+		"limit", limit,
+	).Add(1)
+
+	logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: %s", tracker.ChannelID, addr, err)
+	return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
 	switch errors.Cause(err) {
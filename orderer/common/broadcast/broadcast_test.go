@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protoutil"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -29,6 +31,7 @@ var _ = Describe("Broadcast", func() {
 		fakeValidateHistogram *mock.MetricsHistogram
 		fakeEnqueueHistogram  *mock.MetricsHistogram
 		fakeProcessedCounter  *mock.MetricsCounter
+		fakeThrottledCounter  *mock.MetricsCounter
 	)
 
 	BeforeEach(func() {
@@ -43,12 +46,16 @@ var _ = Describe("Broadcast", func() {
 		fakeProcessedCounter = &mock.MetricsCounter{}
 		fakeProcessedCounter.WithReturns(fakeProcessedCounter)
 
+		fakeThrottledCounter = &mock.MetricsCounter{}
+		fakeThrottledCounter.WithReturns(fakeThrottledCounter)
+
 		handler = &broadcast.Handler{
 			SupportRegistrar: fakeSupportRegistrar,
 			Metrics: &broadcast.Metrics{
 				ValidateDuration: fakeValidateHistogram,
 				EnqueueDuration:  fakeEnqueueHistogram,
 				ProcessedCount:   fakeProcessedCounter,
+				ThrottledCount:   fakeThrottledCounter,
 			},
 		}
 	})
@@ -276,6 +283,132 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when admission control is enabled", func() {
+			BeforeEach(func() {
+				handler.Limiter = broadcast.NewLimiter(broadcast.LimiterConfig{
+					Channel: broadcast.RateLimit{Rate: 0.001, Burst: 1},
+				})
+			})
+
+			It("rejects the messages over the channel limit with a service unavailable status", func() {
+				resp := handler.ProcessMessage(fakeMsg, "addr")
+				Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+
+				resp = handler.ProcessMessage(fakeMsg, "addr")
+				Expect(resp.Status).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				Expect(resp.Info).To(HavePrefix("channel limit exceeded for fake-channel, retry after "))
+
+				Expect(fakeSupport.ProcessNormalMsgCallCount()).To(Equal(1))
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+
+				Expect(fakeThrottledCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeThrottledCounter.WithArgsForCall(0)).To(Equal([]string{
+					"channel", "fake-channel",
+					"type", "ENDORSER_TRANSACTION",
+					"limit", "channel",
+				}))
+				Expect(fakeThrottledCounter.AddCallCount()).To(Equal(1))
+				Expect(fakeProcessedCounter.WithArgsForCall(1)).To(Equal([]string{
+					"status", "SERVICE_UNAVAILABLE",
+					"channel", "fake-channel",
+					"type", "ENDORSER_TRANSACTION",
+				}))
+			})
+
+			Context("when the in flight cap is reached", func() {
+				var innerResp *ab.BroadcastResponse
+
+				BeforeEach(func() {
+					handler.Limiter = broadcast.NewLimiter(broadcast.LimiterConfig{MaxInFlight: 1})
+					fakeSupport.WaitReadyStub = func() error {
+						innerResp = handler.ProcessMessage(fakeMsg, "other-addr")
+						return nil
+					}
+				})
+
+				It("rejects the messages over the cap", func() {
+					resp := handler.ProcessMessage(fakeMsg, "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					Expect(proto.Equal(innerResp, &ab.BroadcastResponse{
+						Status: cb.Status_SERVICE_UNAVAILABLE,
+						Info:   "in_flight limit exceeded, retry after 100ms",
+					})).To(BeTrue())
+
+					fakeSupport.WaitReadyStub = nil
+					resp = handler.ProcessMessage(fakeMsg, "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+
+			Context("when the creator is limited", func() {
+				BeforeEach(func() {
+					handler.Limiter = broadcast.NewLimiter(broadcast.LimiterConfig{
+						MSP: broadcast.RateLimit{Rate: 0.001, Burst: 2},
+					})
+				})
+
+				It("limits the MSP of the creator once the message has been validated", func() {
+					resp := handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					resp = handler.ProcessMessage(envelopeFrom("Org1MSP", "cert2"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					resp = handler.ProcessMessage(envelopeFrom("Org2MSP", "cert3"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+
+					resp = handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+					Expect(resp.Info).To(HavePrefix("msp limit exceeded for Org1MSP, retry after "))
+
+					Expect(fakeSupport.ProcessNormalMsgCallCount()).To(Equal(4))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(3))
+				})
+
+				It("rejects the messages without a creator with a bad request status", func() {
+					resp := handler.ProcessMessage(&cb.Envelope{Payload: []byte("garbage")}, "addr")
+					Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+					Expect(fakeThrottledCounter.WithCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the channel and the clients are limited", func() {
+				BeforeEach(func() {
+					handler.Limiter = broadcast.NewLimiter(broadcast.LimiterConfig{
+						Channel: broadcast.RateLimit{Rate: 0.001, Burst: 2},
+						MSP:     broadcast.RateLimit{Rate: 0.001, Burst: 2},
+						Client:  broadcast.RateLimit{Rate: 0.001, Burst: 1},
+					})
+				})
+
+				It("does not let a client over its limit exhaust the channel for the others", func() {
+					resp := handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					for i := 0; i < 5; i++ {
+						resp = handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+						Expect(resp.Status).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+						Expect(resp.Info).To(HavePrefix("client limit exceeded, retry after "))
+					}
+
+					resp = handler.ProcessMessage(envelopeFrom("Org1MSP", "cert2"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(2))
+				})
+
+				It("does not let invalid messages exhaust the channel", func() {
+					fakeSupport.ProcessNormalMsgReturns(0, fmt.Errorf("bad-signature"))
+					for i := 0; i < 5; i++ {
+						resp := handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+						Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+					}
+
+					fakeSupport.ProcessNormalMsgReturns(0, nil)
+					resp := handler.ProcessMessage(envelopeFrom("Org1MSP", "cert1"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					resp = handler.ProcessMessage(envelopeFrom("Org2MSP", "cert2"), "addr")
+					Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+		})
+
 		Context("when the message is a config message", func() {
 			var (
 				fakeConfig *cb.Envelope
@@ -399,3 +532,15 @@ var _ = Describe("Broadcast", func() {
 		})
 	})
 })
+
+func envelopeFrom(mspID, cert string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+					Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)}),
+				}),
+			},
+		}),
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// The kinds of limits enforced by the Limiter, used to label the throttled metric.
+const (
+	InFlightLimit = "in_flight"
+	ChannelLimit  = "channel"
+	MSPLimit      = "msp"
+	ClientLimit   = "client"
+)
+
+// idleBucketSweepInterval is how often the buckets which have been refilled
+// to their burst size are discarded, to bound the memory held for clients
+// which no longer submit.
+const idleBucketSweepInterval = time.Minute
+
+// RateLimit configures a token bucket which is refilled with Rate tokens per
+// second, up to Burst tokens. A non positive rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// LimiterConfig configures the admission control of the Broadcast service.
+type LimiterConfig struct {
+	// MaxInFlight is the maximum number of messages processed concurrently
+	// across all the channels, zero means unbounded.
+	MaxInFlight int
+	// Client limits the submissions of every client certificate.
+	Client RateLimit
+	// MSP limits the submissions of the members of every MSP.
+	MSP RateLimit
+	// Channel limits the submissions to every channel.
+	Channel RateLimit
+}
+
+// ErrThrottled is returned by the Limiter when a submission is over limit.
+type ErrThrottled struct {
+	Limit      string
+	Key        string
+	RetryAfter time.Duration
+}
+
+func (e *ErrThrottled) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s limit exceeded, retry after %s", e.Limit, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s limit exceeded for %s, retry after %s", e.Limit, e.Key, e.RetryAfter)
+}
+
+// Limiter enforces the admission control of the Broadcast service.
+type Limiter struct {
+	inFlight chan struct{}
+	channel  *rateLimiter
+	msp      *rateLimiter
+	client   *rateLimiter
+
+	// RetryAfter is the retry hint returned when the in flight cap is reached.
+	RetryAfter time.Duration
+}
+
+// NewLimiter creates a Limiter enforcing the given configuration.
+func NewLimiter(config LimiterConfig) *Limiter {
+	l := &Limiter{
+		channel:    newRateLimiter(config.Channel),
+		msp:        newRateLimiter(config.MSP),
+		client:     newRateLimiter(config.Client),
+		RetryAfter: 100 * time.Millisecond,
+	}
+	if config.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+	return l
+}
+
+// Acquire reserves an in flight slot, the returned function releases it.
+func (l *Limiter) Acquire() (func(), error) {
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	default:
+		return nil, &ErrThrottled{Limit: InFlightLimit, RetryAfter: l.RetryAfter}
+	}
+}
+
+// AdmitChannel consumes a token of the bucket of the channel.
+func (l *Limiter) AdmitChannel(channelID string) error {
+	return l.channel.admit(ChannelLimit, channelID, channelID)
+}
+
+// RefundChannel gives back the token consumed by AdmitChannel, when the message
+// is rejected afterwards, so that the clients which submit invalid messages or are
+// over their own limits do not exhaust the bucket of the channel for the others.
+func (l *Limiter) RefundChannel(channelID string) {
+	l.channel.refund(channelID)
+}
+
+// AdmitCreator consumes a token of the buckets of the client certificate and of
+// the MSP of the creator of the message. It must only be called once the
+// signature of the message has been validated, as the creator is otherwise
+// untrusted and could be used to exhaust the buckets of someone else.
+// The client is limited first, so that a client over its own limit does not
+// exhaust the bucket of its MSP.
+func (l *Limiter) AdmitCreator(msg *cb.Envelope) error {
+	if l.msp == nil && l.client == nil {
+		return nil
+	}
+
+	creator, err := creatorOf(msg)
+	if err != nil {
+		return err
+	}
+
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, sID); err != nil {
+		return errors.Wrap(err, "could not unmarshal creator")
+	}
+
+	clientKey := fmt.Sprintf("%x", sha256.Sum256(creator))
+	// the client is not named in the retry hint, as its certificate does not fit there
+	if err := l.client.admit(ClientLimit, clientKey, ""); err != nil {
+		return err
+	}
+	if err := l.msp.admit(MSPLimit, sID.Mspid, sID.Mspid); err != nil {
+		l.client.refund(clientKey)
+		return err
+	}
+
+	return nil
+}
+
+func creatorOf(msg *cb.Envelope) ([]byte, error) {
+	payload, err := protoutil.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header in payload")
+	}
+	shdr, err := protoutil.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	return shdr.Creator, nil
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket for every key.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:      limit.Rate,
+		burst:     burst,
+		now:       time.Now,
+		buckets:   map[string]*tokenBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (rl *rateLimiter) admit(limit, key, name string) error {
+	if rl == nil {
+		return nil
+	}
+	if ok, retryAfter := rl.take(key); !ok {
+		return &ErrThrottled{Limit: limit, Key: name, RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// take consumes a token of the bucket of the key, if there is none it returns
+// how long it takes for the bucket to be refilled with one.
+func (rl *rateLimiter) take(key string) (bool, time.Duration) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.now()
+	if now.Sub(rl.lastSweep) > idleBucketSweepInterval {
+		rl.sweep(now)
+	}
+
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: rl.burst, last: now}
+		rl.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(rl.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rl.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := math.Ceil((1 - bucket.tokens) / rl.rate * 1000)
+		return false, time.Duration(wait) * time.Millisecond
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// refund gives back a token consumed from the bucket of the key.
+func (rl *rateLimiter) refund(key string) {
+	if rl == nil {
+		return
+	}
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	if bucket, ok := rl.buckets[key]; ok {
+		bucket.tokens = math.Min(rl.burst, bucket.tokens+1)
+	}
+}
+
+func (rl *rateLimiter) sweep(now time.Time) {
+	for key, bucket := range rl.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*rl.rate >= rl.burst {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.lastSweep = now
+}
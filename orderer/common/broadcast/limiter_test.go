@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+)
+
+var _ = Describe("Limiter", func() {
+	It("admits everything when no limit is configured", func() {
+		limiter := broadcast.NewLimiter(broadcast.LimiterConfig{})
+		for i := 0; i < 100; i++ {
+			release, err := limiter.Acquire()
+			Expect(err).NotTo(HaveOccurred())
+			defer release()
+			Expect(limiter.AdmitChannel("mychannel")).To(Succeed())
+			Expect(limiter.AdmitCreator(envelopeFrom("Org1MSP", "cert"))).To(Succeed())
+		}
+	})
+
+	It("releases the in flight slots", func() {
+		limiter := broadcast.NewLimiter(broadcast.LimiterConfig{MaxInFlight: 2})
+		release1, err := limiter.Acquire()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = limiter.Acquire()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = limiter.Acquire()
+		Expect(err).To(Equal(&broadcast.ErrThrottled{Limit: broadcast.InFlightLimit, RetryAfter: 100 * time.Millisecond}))
+
+		release1()
+		_, err = limiter.Acquire()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("keeps a bucket per channel which is refilled over time", func() {
+		limiter := broadcast.NewLimiter(broadcast.LimiterConfig{
+			Channel: broadcast.RateLimit{Rate: 20, Burst: 2},
+		})
+
+		Expect(limiter.AdmitChannel("channel1")).To(Succeed())
+		Expect(limiter.AdmitChannel("channel1")).To(Succeed())
+		Expect(limiter.AdmitChannel("channel2")).To(Succeed())
+
+		err := limiter.AdmitChannel("channel1")
+		Expect(err).To(BeAssignableToTypeOf(&broadcast.ErrThrottled{}))
+		throttled := err.(*broadcast.ErrThrottled)
+		Expect(throttled.Limit).To(Equal(broadcast.ChannelLimit))
+		Expect(throttled.Key).To(Equal("channel1"))
+		Expect(throttled.RetryAfter).To(BeNumerically(">", 0))
+		Expect(throttled.RetryAfter).To(BeNumerically("<=", 50*time.Millisecond))
+
+		Eventually(func() error { return limiter.AdmitChannel("channel1") }).Should(Succeed())
+	})
+
+	It("keeps a bucket per client certificate", func() {
+		limiter := broadcast.NewLimiter(broadcast.LimiterConfig{
+			Client: broadcast.RateLimit{Rate: 0.001, Burst: 1},
+		})
+
+		Expect(limiter.AdmitCreator(envelopeFrom("Org1MSP", "cert1"))).To(Succeed())
+		Expect(limiter.AdmitCreator(envelopeFrom("Org1MSP", "cert2"))).To(Succeed())
+
+		err := limiter.AdmitCreator(envelopeFrom("Org1MSP", "cert1"))
+		Expect(err).To(BeAssignableToTypeOf(&broadcast.ErrThrottled{}))
+		Expect(err.Error()).To(HavePrefix("client limit exceeded, retry after "))
+	})
+	It("gives back the refunded channel tokens up to the burst", func() {
+		limiter := broadcast.NewLimiter(broadcast.LimiterConfig{
+			Channel: broadcast.RateLimit{Rate: 0.001, Burst: 1},
+		})
+
+		limiter.RefundChannel("channel1")
+		Expect(limiter.AdmitChannel("channel1")).To(Succeed())
+		limiter.RefundChannel("channel1")
+		limiter.RefundChannel("channel1")
+		Expect(limiter.AdmitChannel("channel1")).To(Succeed())
+		Expect(limiter.AdmitChannel("channel1")).To(BeAssignableToTypeOf(&broadcast.ErrThrottled{}))
+	})
+})
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// URLBaseV1Channels is the path under which the handler is expected to be registered
+const URLBaseV1Channels = "/participation/v1/channels"
+
+//go:generate counterfeiter -o mocks/channel_management.go -fake-name ChannelManagement . ChannelManagement
+
+// ChannelManagement joins, lists and removes the channels of the orderer
+type ChannelManagement interface {
+	ChannelList() types.ChannelList
+	ChannelInfo(channelID string) (types.ChannelInfo, error)
+	JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error)
+	RemoveChannel(channelID string) error
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelManagement) *HTTPHandler {
+	return &HTTPHandler{
+		Config:    config,
+		Registrar: registrar,
+		Logger:    flogging.MustGetLogger("orderer.common.channelparticipation"),
+	}
+}
+
+// HTTPHandler lists the channels of the orderer on GET /participation/v1/channels
+// and describes a single channel on GET /participation/v1/channels/{channel}.
+// A POST to /participation/v1/channels with a marshaled config block as body
+// joins the channel of the block, and a DELETE to /participation/v1/channels/{channel}
+// removes the channel.
+type HTTPHandler struct {
+	Config    localconfig.ChannelParticipation
+	Registrar ChannelManagement
+	Logger    *flogging.FabricLogger
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !h.Config.Enabled {
+		h.sendResponse(resp, http.StatusServiceUnavailable, errors.New("channel participation API is disabled"))
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, URLBaseV1Channels), "/")
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	switch {
+	case len(segments) == 0 && req.Method == http.MethodGet:
+		h.serveListAll(resp)
+	case len(segments) == 0 && req.Method == http.MethodPost:
+		h.serveJoin(resp, req)
+	case len(segments) == 0:
+		resp.Header().Set("Allow", "GET, POST")
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		h.serveListOne(resp, segments[0])
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		h.serveRemove(resp, segments[0])
+	case len(segments) == 1:
+		resp.Header().Set("Allow", "GET, DELETE")
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	default:
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("invalid path: %s", req.URL.Path))
+	}
+}
+
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter) {
+	list := h.Registrar.ChannelList()
+	if list.SystemChannel != nil {
+		list.SystemChannel.URL = channelURL(list.SystemChannel.Name)
+	}
+	for i := range list.Channels {
+		list.Channels[i].URL = channelURL(list.Channels[i].Name)
+	}
+	h.sendResponse(resp, http.StatusOK, list)
+}
+
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, channelID string) {
+	info, err := h.Registrar.ChannelInfo(channelID)
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+	info.URL = channelURL(channelID)
+	h.sendResponse(resp, http.StatusOK, info)
+}
+
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(resp, req.Body, int64(h.Config.MaxRequestBodySize)))
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "failed reading the request body"))
+		return
+	}
+	req.Body.Close()
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(body, block); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "failed unmarshaling the config block"))
+		return
+	}
+	channelID, err := protoutil.GetChainIDFromBlock(block)
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.WithMessage(err, "failed extracting the channel ID from the config block"))
+		return
+	}
+
+	info, err := h.Registrar.JoinChannel(channelID, block)
+	if err != nil {
+		h.Logger.Warningf("Failed joining channel %s: %s", channelID, err)
+		h.sendResponse(resp, statusCode(err, http.StatusBadRequest), errors.WithMessagef(err, "cannot join channel %s", channelID))
+		return
+	}
+	info.URL = channelURL(channelID)
+	resp.Header().Set("Location", info.URL)
+	h.sendResponse(resp, http.StatusCreated, info)
+}
+
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, channelID string) {
+	if err := h.Registrar.RemoveChannel(channelID); err != nil {
+		h.Logger.Warningf("Failed removing channel %s: %s", channelID, err)
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), errors.WithMessagef(err, "cannot remove channel %s", channelID))
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// statusCode maps the errors of the registrar to HTTP status codes
+func statusCode(err error, defaultCode int) int {
+	switch errors.Cause(err) {
+	case types.ErrChannelNotExist:
+		return http.StatusNotFound
+	case types.ErrChannelAlreadyExists:
+		return http.StatusConflict
+	case types.ErrSystemChannelExists:
+		return http.StatusMethodNotAllowed
+	default:
+		return defaultCode
+	}
+}
+
+func channelURL(channelID string) string {
+	return URLBaseV1Channels + "/" + channelID
+}
+
+func (h *HTTPHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protoutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPHandler", func() {
+	var (
+		fakeRegistrar *mocks.ChannelManagement
+		handler       *channelparticipation.HTTPHandler
+		resp          *httptest.ResponseRecorder
+		configBlock   *cb.Block
+	)
+
+	BeforeEach(func() {
+		fakeRegistrar = &mocks.ChannelManagement{}
+		handler = channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{
+			Enabled:            true,
+			MaxRequestBodySize: 1024 * 1024,
+		}, fakeRegistrar)
+		resp = httptest.NewRecorder()
+
+		env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG, "mychannel", nil, &cb.ConfigEnvelope{}, 0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		configBlock = protoutil.NewBlock(0, nil)
+		configBlock.Data.Data = [][]byte{protoutil.MarshalOrPanic(env)}
+	})
+
+	It("rejects requests when the API is disabled", func() {
+		handler.Config.Enabled = false
+		req := httptest.NewRequest("GET", "/participation/v1/channels", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(resp.Body).To(MatchJSON(`{"error": "channel participation API is disabled"}`))
+		Expect(fakeRegistrar.ChannelListCallCount()).To(Equal(0))
+	})
+
+	Describe("listing channels", func() {
+		It("lists all channels", func() {
+			fakeRegistrar.ChannelListReturns(types.ChannelList{
+				SystemChannel: &types.ChannelInfoShort{Name: "system"},
+				Channels:      []types.ChannelInfoShort{{Name: "app1"}, {Name: "app2"}},
+			})
+
+			req := httptest.NewRequest("GET", "/participation/v1/channels", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(resp.Body).To(MatchJSON(`{
+				"systemChannel": {"name": "system", "url": "/participation/v1/channels/system"},
+				"channels": [
+					{"name": "app1", "url": "/participation/v1/channels/app1"},
+					{"name": "app2", "url": "/participation/v1/channels/app2"}
+				]
+			}`))
+		})
+
+		It("describes a single channel", func() {
+			fakeRegistrar.ChannelInfoReturns(types.ChannelInfo{Name: "app1", Status: types.StatusActive, Height: 7}, nil)
+
+			req := httptest.NewRequest("GET", "/participation/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{"name": "app1", "url": "/participation/v1/channels/app1", "status": "active", "height": 7}`))
+			Expect(fakeRegistrar.ChannelInfoArgsForCall(0)).To(Equal("app1"))
+		})
+
+		It("returns not found for an unknown channel", func() {
+			fakeRegistrar.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+
+			req := httptest.NewRequest("GET", "/participation/v1/channels/unknown", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel does not exist"}`))
+		})
+	})
+
+	Describe("joining a channel", func() {
+		It("joins the channel of the config block", func() {
+			fakeRegistrar.JoinChannelReturns(types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 1}, nil)
+
+			req := httptest.NewRequest("POST", "/participation/v1/channels", bytes.NewReader(protoutil.MarshalOrPanic(configBlock)))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusCreated))
+			Expect(resp.Header().Get("Location")).To(Equal("/participation/v1/channels/mychannel"))
+			Expect(resp.Body).To(MatchJSON(`{"name": "mychannel", "url": "/participation/v1/channels/mychannel", "status": "active", "height": 1}`))
+
+			Expect(fakeRegistrar.JoinChannelCallCount()).To(Equal(1))
+			channelID, block := fakeRegistrar.JoinChannelArgsForCall(0)
+			Expect(channelID).To(Equal("mychannel"))
+			Expect(protoutil.MarshalOrPanic(block)).To(Equal(protoutil.MarshalOrPanic(configBlock)))
+		})
+
+		It("rejects a body that is not a block", func() {
+			req := httptest.NewRequest("POST", "/participation/v1/channels", bytes.NewReader([]byte("not a block")))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(ContainSubstring("failed unmarshaling the config block"))
+			Expect(fakeRegistrar.JoinChannelCallCount()).To(Equal(0))
+		})
+
+		It("rejects a body that exceeds the maximum size", func() {
+			handler.Config.MaxRequestBodySize = 10
+			req := httptest.NewRequest("POST", "/participation/v1/channels", bytes.NewReader(protoutil.MarshalOrPanic(configBlock)))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "failed reading the request body: http: request body too large"}`))
+			Expect(fakeRegistrar.JoinChannelCallCount()).To(Equal(0))
+		})
+
+		It("maps the errors of the registrar", func() {
+			for err, code := range map[error]int{
+				types.ErrSystemChannelExists:              http.StatusMethodNotAllowed,
+				types.ErrChannelAlreadyExists:             http.StatusConflict,
+				errors.New("block is not a config block"): http.StatusBadRequest,
+			} {
+				fakeRegistrar.JoinChannelReturns(types.ChannelInfo{}, err)
+				resp = httptest.NewRecorder()
+				req := httptest.NewRequest("POST", "/participation/v1/channels", bytes.NewReader(protoutil.MarshalOrPanic(configBlock)))
+				handler.ServeHTTP(resp, req)
+				Expect(resp.Code).To(Equal(code))
+				Expect(resp.Body).To(MatchJSON(`{"error": "cannot join channel mychannel: ` + err.Error() + `"}`))
+			}
+		})
+	})
+
+	Describe("removing a channel", func() {
+		It("removes the channel", func() {
+			req := httptest.NewRequest("DELETE", "/participation/v1/channels/mychannel", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNoContent))
+			Expect(resp.Body.Len()).To(Equal(0))
+			Expect(fakeRegistrar.RemoveChannelArgsForCall(0)).To(Equal("mychannel"))
+		})
+
+		It("returns not found for an unknown channel", func() {
+			fakeRegistrar.RemoveChannelReturns(types.ErrChannelNotExist)
+			req := httptest.NewRequest("DELETE", "/participation/v1/channels/unknown", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "cannot remove channel unknown: channel does not exist"}`))
+		})
+
+		It("rejects removal when a system channel exists", func() {
+			fakeRegistrar.RemoveChannelReturns(types.ErrSystemChannelExists)
+			req := httptest.NewRequest("DELETE", "/participation/v1/channels/mychannel", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	It("rejects unsupported methods", func() {
+		req := httptest.NewRequest("PUT", "/participation/v1/channels", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(resp.Header().Get("Allow")).To(Equal("GET, POST"))
+
+		resp = httptest.NewRecorder()
+		req = httptest.NewRequest("POST", "/participation/v1/channels/mychannel", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(resp.Header().Get("Allow")).To(Equal("GET, DELETE"))
+	})
+
+	It("rejects unknown paths", func() {
+		req := httptest.NewRequest("GET", "/participation/v1/channels/mychannel/blocks", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusNotFound))
+		Expect(resp.Body).To(MatchJSON(`{"error": "invalid path: /participation/v1/channels/mychannel/blocks"}`))
+	})
+})
@@ -230,7 +230,9 @@ func (r *Replicator) pullChannelBlocks(channel string, puller *BlockPuller, late
 		}
 		actualPrevHash = protoutil.BlockHeaderHash(block.Header)
 		if channel == r.SystemChannel && block.Header.Number == r.BootBlock.Header.Number {
-			r.compareBootBlockWithSystemChannelLastConfigBlock(block)
+			if err := r.compareBootBlockWithSystemChannelLastConfigBlock(block); err != nil {
+				return err
+			}
 			r.appendBlock(block, ledger, channel)
 			// No need to pull further blocks from the system channel
 			return nil
@@ -252,16 +254,16 @@ func (r *Replicator) appendBlock(block *common.Block, ledger LedgerWriter, chann
 	r.Logger.Infof("Committed block [%d] for channel %s", block.Header.Number, channel)
 }
 
-func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block) {
+func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block) error {
 	// Overwrite the received block's data hash
 	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
 
 	bootBlockHash := protoutil.BlockHeaderHash(r.BootBlock.Header)
 	retrievedBlockHash := protoutil.BlockHeaderHash(block.Header)
 	if bytes.Equal(bootBlockHash, retrievedBlockHash) {
-		return
+		return nil
 	}
-	r.Logger.Panicf("Block header mismatch on last system channel block, expected %s, got %s",
+	return errors.Errorf("block header mismatch on last system channel block, expected %s, got %s",
 		hex.EncodeToString(bootBlockHash), hex.EncodeToString(retrievedBlockHash))
 }
 
@@ -137,7 +137,7 @@ func TestReplicateChainsFailures(t *testing.T) {
 		},
 		{
 			name: "last pulled block doesn't match the boot block",
-			expectedPanic: "Block header mismatch on last system channel block," +
+			expectedPanic: "Failed pulling system channel: block header mismatch on last system channel block," +
 				" expected 8ec93b2ef5ffdc302f0c0e24611be04ad2b17b099a1aeafd7cfb76a95923f146," +
 				" got e428decfc78f8e4c97b26da9c16f9d0b73f886dafa80477a0dd9bac7eb14fe7a",
 			latestBlockSeqInOrderer: 21,
@@ -28,14 +28,19 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General     General
+	FileLedger  FileLedger
+	RAMLedger   RAMLedger
+	Kafka       Kafka
+	Debug       Debug
+	Broadcast   Broadcast
+	BlockCutter BlockCutter
+	Consensus   interface{}
+	Operations  Operations
+	Metrics     Metrics
+
+	ChannelParticipation ChannelParticipation
+	RaftAdmin            RaftAdmin
 }
 
 // General contains config which should be common among all orderer types.
@@ -187,6 +192,38 @@ type Debug struct {
 	DeliverTraceDir   string
 }
 
+// Broadcast contains configuration for the admission control of the Broadcast
+// service.
+type Broadcast struct {
+	MaxInFlight int
+	RetryAfter  time.Duration
+	Client      RateLimit
+	MSP         RateLimit
+	Channel     RateLimit
+}
+
+// RateLimit configures a token bucket refilled with Rate tokens per second,
+// up to Burst tokens. A zero rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// BlockCutter contains configuration for the block cutter of the channels.
+type BlockCutter struct {
+	PriorityLanes PriorityLanes
+}
+
+// PriorityLanes configures the priority block cutter, which fills the blocks
+// with the config messages and the messages of the high priority MSPs first.
+// It applies to the channels ordered by solo, etcdraft and BFT.
+type PriorityLanes struct {
+	Enabled               bool
+	HighPriorityMSPs      []string
+	HighPriorityMaxWait   time.Duration
+	NormalPriorityMaxWait time.Duration
+}
+
 // Operations configures the operations endpont for the orderer.
 type Operations struct {
 	ListenAddress string
@@ -199,6 +236,19 @@ type Metrics struct {
 	Statsd   Statsd
 }
 
+// ChannelParticipation configures the channel participation API of the orderer,
+// which is served by the operations endpoint.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
+// RaftAdmin configures the Raft administration API of the orderer, which is
+// served by the operations endpoint.
+type RaftAdmin struct {
+	Enabled bool
+}
+
 // Statsd provides the configuration required to emit statsd metrics from the orderer.
 type Statsd struct {
 	Network       string
@@ -282,12 +332,22 @@ var Defaults = TopLevel{
 		BroadcastTraceDir: "",
 		DeliverTraceDir:   "",
 	},
+	Broadcast: Broadcast{
+		RetryAfter: 100 * time.Millisecond,
+	},
 	Operations: Operations{
 		ListenAddress: "127.0.0.1:0",
 	},
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
+	RaftAdmin: RaftAdmin{
+		Enabled: false,
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -445,6 +505,14 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Retry.Consumer.RetryBackoff unset, setting to %v", Defaults.Kafka.Retry.Consumer.RetryBackoff)
 			c.Kafka.Retry.Consumer.RetryBackoff = Defaults.Kafka.Retry.Consumer.RetryBackoff
 
+		case c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+
+		case c.Broadcast.RetryAfter == 0:
+			logger.Infof("Broadcast.RetryAfter unset, setting to %v", Defaults.Broadcast.RetryAfter)
+			c.Broadcast.RetryAfter = Defaults.Broadcast.RetryAfter
+
 		case c.Kafka.Version == sarama.KafkaVersion{}:
 			logger.Infof("Kafka.Version unset, setting to %v", Defaults.Kafka.Version)
 			c.Kafka.Version = Defaults.Kafka.Version
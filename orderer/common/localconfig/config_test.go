@@ -141,6 +141,46 @@ func TestClusterDefaults(t *testing.T) {
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
 }
 
+func TestChannelParticipationDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.False(t, cfg.ChannelParticipation.Enabled)
+	assert.Equal(t, uint32(1024*1024), cfg.ChannelParticipation.MaxRequestBodySize)
+}
+
+func TestRaftAdminDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.False(t, cfg.RaftAdmin.Enabled)
+}
+
+func TestBroadcastDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Broadcast{RetryAfter: 100 * time.Millisecond}, cfg.Broadcast)
+}
+
+func TestBlockCutterDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.False(t, cfg.BlockCutter.PriorityLanes.Enabled)
+	assert.Empty(t, cfg.BlockCutter.PriorityLanes.HighPriorityMSPs)
+	assert.Zero(t, cfg.BlockCutter.PriorityLanes.HighPriorityMaxWait)
+	assert.Zero(t, cfg.BlockCutter.PriorityLanes.NormalPriorityMaxWait)
+}
+
 func TestSystemChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -12,8 +12,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	protobft "github.com/hyperledger/fabric/protos/orderer/bft"
 	protoetcdraft "github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -45,6 +47,7 @@ func NewMaintenanceFilter(support MaintenanceFilterSupport) *MaintenanceFilter {
 	mf.permittedTargetConsensusTypes["etcdraft"] = true
 	mf.permittedTargetConsensusTypes["solo"] = true
 	mf.permittedTargetConsensusTypes["kafka"] = true
+	mf.permittedTargetConsensusTypes["BFT"] = true
 	return mf
 }
 
@@ -137,6 +140,21 @@ func (mf *MaintenanceFilter) inspect(configEnvelope *cb.ConfigEnvelope, ordererC
 			}
 		}
 
+		// BFT communicates over the cluster infrastructure of etcdraft, hence a channel migrates to BFT from etcdraft only.
+		if nextOrdererConfig.ConsensusType() == "BFT" {
+			if ordererConfig.ConsensusType() != "etcdraft" {
+				return errors.Errorf("attempted to change consensus type from %s to %s, transition not supported",
+					ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
+			}
+			updatedMetadata := &protobft.ConfigMetadata{}
+			if err := proto.Unmarshal(nextOrdererConfig.ConsensusMetadata(), updatedMetadata); err != nil {
+				return errors.Wrap(err, "failed to unmarshal BFT metadata configuration")
+			}
+			if err := bftconfig.CheckConfigMetadata(updatedMetadata); err != nil {
+				return errors.WithMessage(err, "invalid BFT metadata configuration")
+			}
+		}
+
 		logger.Infof("[channel: %s] consensus-type migration: about to change from %s to %s",
 			mf.support.ChainID(), ordererConfig.ConsensusType(), nextOrdererConfig.ConsensusType())
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/internal/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/internal/configtxlator/update"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/assert"
@@ -244,6 +246,94 @@ func TestMaintenanceInspectChange(t *testing.T) {
 	})
 }
 
+func TestMaintenanceInspectChangeToBFT(t *testing.T) {
+	msActive := &mockSystemChannelFilterSupport{
+		OrdererConfigVal: &mockconfig.Orderer{
+			CapabilitiesVal:       &mockconfig.OrdererCapabilities{ConsensusTypeMigrationVal: true},
+			ConsensusTypeVal:      "etcdraft",
+			ConsensusTypeStateVal: orderer.ConsensusType_STATE_MAINTENANCE,
+		},
+	}
+	mf := NewMaintenanceFilter(msActive)
+	require.NotNil(t, mf)
+	raftMetadata := protoutil.MarshalOrPanic(&etcdraft.ConfigMetadata{})
+	validMetadata := protoutil.MarshalOrPanic(newBFTMetadata(t))
+	current := consensusTypeInfo{ordererType: "etcdraft", metadata: raftMetadata, state: orderer.ConsensusType_STATE_MAINTENANCE}
+
+	t.Run("Good type change", func(t *testing.T) {
+		next := consensusTypeInfo{ordererType: "BFT", metadata: validMetadata, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Bad: BFT metadata", func(t *testing.T) {
+		next := consensusTypeInfo{ordererType: "BFT", metadata: []byte{1, 2, 3, 4}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"config transaction inspection failed: failed to unmarshal BFT metadata configuration")
+	})
+
+	t.Run("Bad: no consenters", func(t *testing.T) {
+		metadata := newBFTMetadata(t)
+		metadata.Consenters = nil
+		next := consensusTypeInfo{ordererType: "BFT", metadata: protoutil.MarshalOrPanic(metadata), state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		assert.EqualError(t, err,
+			"config transaction inspection failed: invalid BFT metadata configuration: empty consenter set")
+	})
+
+	t.Run("Bad: no options", func(t *testing.T) {
+		metadata := newBFTMetadata(t)
+		metadata.Options = nil
+		next := consensusTypeInfo{ordererType: "BFT", metadata: protoutil.MarshalOrPanic(metadata), state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		assert.EqualError(t, err,
+			"config transaction inspection failed: invalid BFT metadata configuration: BFT options have not been provided")
+	})
+
+	t.Run("Bad: duplicate consenter ids", func(t *testing.T) {
+		metadata := newBFTMetadata(t)
+		metadata.Consenters[1].Id = metadata.Consenters[0].Id
+		next := consensusTypeInfo{ordererType: "BFT", metadata: protoutil.MarshalOrPanic(metadata), state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		assert.EqualError(t, err,
+			"config transaction inspection failed: invalid BFT metadata configuration: duplicate consenter id 1")
+	})
+
+	t.Run("Bad: invalid identity", func(t *testing.T) {
+		metadata := newBFTMetadata(t)
+		metadata.Consenters[0].Identity = []byte("not a certificate")
+		next := consensusTypeInfo{ordererType: "BFT", metadata: protoutil.MarshalOrPanic(metadata), state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := mf.Apply(configTx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"config transaction inspection failed: invalid BFT metadata configuration: consenter 1 has an invalid identity")
+	})
+
+	t.Run("Bad: change from kafka", func(t *testing.T) {
+		msKafka := &mockSystemChannelFilterSupport{
+			OrdererConfigVal: &mockconfig.Orderer{
+				CapabilitiesVal:       &mockconfig.OrdererCapabilities{ConsensusTypeMigrationVal: true},
+				ConsensusTypeVal:      "kafka",
+				ConsensusTypeStateVal: orderer.ConsensusType_STATE_MAINTENANCE,
+			},
+		}
+		current := consensusTypeInfo{ordererType: "kafka", metadata: []byte{}, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		next := consensusTypeInfo{ordererType: "BFT", metadata: validMetadata, state: orderer.ConsensusType_STATE_MAINTENANCE}
+		configTx := makeConfigEnvelope(t, current, next)
+		err := NewMaintenanceFilter(msKafka).Apply(configTx)
+		assert.EqualError(t, err,
+			"config transaction inspection failed: attempted to change consensus type from kafka to BFT, transition not supported")
+	})
+}
+
 func TestMaintenanceInspectExit(t *testing.T) {
 	validMetadata := protoutil.MarshalOrPanic(&etcdraft.ConfigMetadata{})
 	msActive := &mockSystemChannelFilterSupport{
@@ -470,3 +560,29 @@ func makeBaseConfig(t *testing.T) *common.Config {
 	}
 	return original
 }
+
+func newBFTMetadata(t *testing.T) *bft.ConfigMetadata {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	metadata := &bft.ConfigMetadata{
+		Options: &bft.Options{RequestTimeout: "10s", ViewChangeTimeout: "20s"},
+	}
+	for id := uint64(1); id <= 4; id++ {
+		identity, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		serverCert, err := ca.NewServerCertKeyPair("127.0.0.1")
+		require.NoError(t, err)
+		clientCert, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		metadata.Consenters = append(metadata.Consenters, &bft.Consenter{
+			Id:            id,
+			Host:          "127.0.0.1",
+			Port:          uint32(7050 + id),
+			MspId:         "OrdererMSP",
+			Identity:      identity.Cert,
+			ServerTlsCert: serverCert.Cert,
+			ClientTlsCert: clientCert.Cert,
+		})
+	}
+	return metadata
+}
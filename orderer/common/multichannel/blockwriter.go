@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
@@ -194,15 +195,22 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 }
 
 func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
-	blockSignature := &cb.MetadataSignature{
-		SignatureHeader: protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(bw.support)),
-	}
-
 	blockSignatureValue := protoutil.MarshalOrPanic(&cb.OrdererBlockMetadata{
 		LastConfig:        &cb.LastConfig{Index: bw.lastConfigBlockNum},
 		ConsenterMetadata: bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER],
 	})
 
+	// Consenters that collect the signatures of several orderers on a block, such as BFT,
+	// write the block with these signatures, which are kept as long as they sign the same value.
+	if existing, err := protoutil.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES); err == nil &&
+		len(existing.Signatures) > 0 && bytes.Equal(existing.Value, blockSignatureValue) {
+		return
+	}
+
+	blockSignature := &cb.MetadataSignature{
+		SignatureHeader: protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(bw.support)),
+	}
+
 	blockSignature.Signature = protoutil.SignOrPanic(
 		bw.support,
 		util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, protoutil.BlockHeaderBytes(block.Header)),
@@ -94,6 +94,65 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureCollectedByConsenter(t *testing.T) {
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := protoutil.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	consensusMetadata := []byte("bar")
+	signatureValue := protoutil.MarshalOrPanic(&cb.OrdererBlockMetadata{
+		LastConfig:        &cb.LastConfig{Index: 42},
+		ConsenterMetadata: protoutil.MarshalOrPanic(&cb.Metadata{Value: consensusMetadata}),
+	})
+	signatures := []*cb.MetadataSignature{
+		{SignatureHeader: []byte("header1"), Signature: []byte("signature1")},
+		{SignatureHeader: []byte("header2"), Signature: []byte("signature2")},
+	}
+
+	t.Run("signatures of the value are kept", func(t *testing.T) {
+		block := protoutil.NewBlock(1, protoutil.BlockHeaderHash(lastBlock.Header))
+		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = protoutil.MarshalOrPanic(&cb.Metadata{Value: consensusMetadata})
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&cb.Metadata{
+			Value:      signatureValue,
+			Signatures: signatures,
+		})
+
+		bw := &BlockWriter{lastConfigBlockNum: 42, lastBlock: block}
+		bw.addBlockSignature(block)
+
+		md := protoutil.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES)
+		assert.Equal(t, signatureValue, md.Value)
+		assert.Len(t, md.Signatures, 2)
+	})
+
+	t.Run("signatures of another value are replaced", func(t *testing.T) {
+		block := protoutil.NewBlock(1, protoutil.BlockHeaderHash(lastBlock.Header))
+		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = protoutil.MarshalOrPanic(&cb.Metadata{Value: consensusMetadata})
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&cb.Metadata{
+			Value:      []byte("another value"),
+			Signatures: signatures,
+		})
+
+		bw := &BlockWriter{
+			lastConfigBlockNum: 42,
+			support: &mockBlockWriterSupport{
+				SignerSerializer: mockCrypto(),
+				Validator:        &mockconfigtx.Validator{},
+				ReadWriter:       l,
+			},
+			lastBlock: block,
+		}
+		bw.addBlockSignature(block)
+
+		md := protoutil.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES)
+		assert.Equal(t, signatureValue, md.Value)
+		assert.Len(t, md.Signatures, 1)
+		assert.NotEqual(t, signatures[0], md.Signatures[0])
+	})
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
@@ -54,8 +54,9 @@ func newChainSupport(
 	cs := &ChainSupport{
 		ledgerResources:  ledgerResources,
 		SignerSerializer: signer,
-		cutter: blockcutter.NewReceiverImpl(
+		cutter: registrar.newBlockCutter(
 			ledgerResources.ConfigtxValidator().ChainID(),
+			ledgerResources.SharedConfig().ConsensusType(),
 			ledgerResources,
 			blockcutterMetrics,
 		),
@@ -156,6 +157,12 @@ func (cs *ChainSupport) ProposeConfigUpdate(configtx *cb.Envelope) (*cb.ConfigEn
 	}
 	newMetadata := newOrdererConfig.ConsensusMetadata()
 
+	// A consensus-type migration replaces the metadata of the current consensus type with the metadata of
+	// the next one, which is validated by the maintenance filter rather than by the current consenter.
+	if oldOrdererConfig.ConsensusType() != newOrdererConfig.ConsensusType() {
+		return env, nil
+	}
+
 	if err = cs.ValidateConsensusMetadata(oldMetadata, newMetadata, false); err != nil {
 		return nil, errors.Wrap(err, "consensus metadata update for channel config update is invalid")
 	}
@@ -148,6 +148,15 @@ func TestConsensusMetadataValidation(t *testing.T) {
 	mv.ValidateConsensusMetadataReturns(errors.New("bananas"))
 	_, err = cs.ProposeConfigUpdate(&common.Envelope{})
 	assert.EqualError(t, err, "consensus metadata update for channel config update is invalid: bananas")
+
+	// case 3: consensus-type migration, the metadata of the next type is not validated by the current consenter
+	ms.OrdererConfigVal = &config.Orderer{
+		ConsensusTypeVal:     "etcdraft",
+		ConsensusMetadataVal: oldConsensusMetadata,
+	}
+	_, err = cs.ProposeConfigUpdate(&common.Envelope{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mv.ValidateConsensusMetadataCallCount())
 }
 
 func testConfigEnvelope(t *testing.T) *common.ConfigEnvelope {
@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -21,7 +22,9 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protoutil"
@@ -89,15 +92,30 @@ type ledgerResources struct {
 	blockledger.ReadWriter
 }
 
+//go:generate counterfeiter -o mocks/channel_replicator.go --fake-name ChannelReplicator . ChannelReplicator
+
+// ChannelReplicator pulls the blocks of a channel from other ordering nodes
+type ChannelReplicator interface {
+	// ReplicateChannel pulls the blocks of the channel of the given config
+	// block, up to and including it, from the ordering nodes listed in it.
+	ReplicateChannel(configBlock *cb.Block) error
+}
+
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
 	lock   sync.RWMutex
 	chains map[string]*ChainSupport
 
+	// joinLock serializes the channels joined and removed through the channel participation API
+	joinLock          sync.Mutex
+	channelReplicator ChannelReplicator
+
 	consenters         map[string]consensus.Consenter
 	ledgerFactory      blockledger.Factory
 	signer             identity.SignerSerializer
 	blockcutterMetrics *blockcutter.Metrics
+	priorityLanes      []blockcutter.PriorityLane
+	selectLane         blockcutter.LaneSelector
 	systemChannelID    string
 	systemChannel      *ChainSupport
 	templator          msgprocessor.ChannelConfigTemplator
@@ -211,10 +229,42 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		logger.Infof("No system channel found, channels are managed through the channel participation API")
 	}
 }
 
+// SetChannelReplicator sets the replicator used to onboard channels joined
+// from a config block other than their genesis block.
+func (r *Registrar) SetChannelReplicator(channelReplicator ChannelReplicator) {
+	r.joinLock.Lock()
+	defer r.joinLock.Unlock()
+
+	r.channelReplicator = channelReplicator
+}
+
+// SetPriorityLanes makes the channels ordered by solo, etcdraft and BFT cut
+// their blocks with a priority receiver of the given lanes, rather than with
+// the default receiver. It must be called before Initialize.
+func (r *Registrar) SetPriorityLanes(lanes []blockcutter.PriorityLane, selectLane blockcutter.LaneSelector) {
+	r.priorityLanes = lanes
+	r.selectLane = selectLane
+}
+
+// newBlockCutter returns the block cutter of a channel ordered by the given
+// consensus type. The kafka chain expects at most two batches to be cut at
+// once, so it always uses the default receiver.
+func (r *Registrar) newBlockCutter(
+	channelID string,
+	consensusType string,
+	sharedConfigFetcher blockcutter.OrdererConfigFetcher,
+	metrics *blockcutter.Metrics,
+) blockcutter.Receiver {
+	if len(r.priorityLanes) == 0 || consensusType == "kafka" {
+		return blockcutter.NewReceiverImpl(channelID, sharedConfigFetcher, metrics)
+	}
+	return blockcutter.NewPriorityReceiver(channelID, sharedConfigFetcher, metrics, r.priorityLanes, r.selectLane)
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -232,6 +282,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 	cs := r.GetChain(chdr.ChannelId)
 	// New channel creation
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.Errorf("channel %s does not exist and channels cannot be created without a system channel", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -337,6 +390,183 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	r.chains = newChains
 }
 
+// ChannelList returns the system channel, if any, and the other channels of the orderer.
+func (r *Registrar) ChannelList() types.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := types.ChannelList{Channels: []types.ChannelInfoShort{}}
+	if r.systemChannelID != "" {
+		list.SystemChannel = &types.ChannelInfoShort{Name: r.systemChannelID}
+	}
+	for chainID := range r.chains {
+		if chainID == r.systemChannelID {
+			continue
+		}
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: chainID})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+
+	return list
+}
+
+// ChannelInfo returns the status and height of a channel.
+func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
+	cs := r.GetChain(channelID)
+	if cs == nil {
+		return types.ChannelInfo{}, types.ErrChannelNotExist
+	}
+
+	status := types.StatusActive
+	if _, ok := cs.Chain.(*inactive.Chain); ok {
+		status = types.StatusInactive
+	}
+	return types.ChannelInfo{
+		Name:   channelID,
+		Status: status,
+		Height: cs.Height(),
+	}, nil
+}
+
+// JoinChannel makes the orderer join a channel from its genesis block or from
+// its latest config block. In the latter case the blocks preceding the config
+// block are replicated from the ordering nodes of the channel first.
+// Channels can only be joined when the orderer has no system channel.
+func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error) {
+	r.joinLock.Lock()
+	defer r.joinLock.Unlock()
+
+	if r.SystemChannelID() != "" {
+		return types.ChannelInfo{}, types.ErrSystemChannelExists
+	}
+	if r.GetChain(channelID) != nil {
+		return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+	}
+	for _, chainID := range r.ledgerFactory.ChainIDs() {
+		if chainID == channelID {
+			return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+		}
+	}
+
+	configEnv, err := r.validateJoinBlock(channelID, configBlock)
+	if err != nil {
+		return types.ChannelInfo{}, err
+	}
+
+	if err := r.createJoinedLedger(channelID, configBlock); err != nil {
+		if removeErr := r.ledgerFactory.Remove(channelID); removeErr != nil {
+			logger.Warningf("Failed removing the ledger of channel %s after a failed join: %s", channelID, removeErr)
+		}
+		return types.ChannelInfo{}, err
+	}
+
+	logger.Infof("Joining channel %s from config block %d", channelID, configBlock.Header.Number)
+	r.newChain(configEnv)
+
+	return r.ChannelInfo(channelID)
+}
+
+// validateJoinBlock checks that the block is a config block of the channel
+// that this orderer can serve, and returns its config envelope.
+func (r *Registrar) validateJoinBlock(channelID string, configBlock *cb.Block) (*cb.Envelope, error) {
+	if configBlock == nil || configBlock.Header == nil || configBlock.Data == nil || len(configBlock.Data.Data) == 0 {
+		return nil, errors.New("block is empty")
+	}
+	if !protoutil.IsConfigBlock(configBlock) {
+		return nil, errors.New("block is not a config block")
+	}
+
+	configEnv, err := protoutil.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed extracting the config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(configEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating the channel config")
+	}
+	if bundleChannelID := bundle.ConfigtxValidator().ChainID(); bundleChannelID != channelID {
+		return nil, errors.Errorf("config block is of channel %s, not %s", bundleChannelID, channelID)
+	}
+	if _, ok := bundle.ConsortiumsConfig(); ok {
+		return nil, errors.New("the config block of a system channel cannot be joined")
+	}
+	if err := checkResources(bundle); err != nil {
+		return nil, err
+	}
+	oc, _ := bundle.OrdererConfig()
+	if _, ok := r.consenters[oc.ConsensusType()]; !ok {
+		return nil, errors.Errorf("consensus type %s is not supported by this orderer", oc.ConsensusType())
+	}
+
+	return configEnv, nil
+}
+
+// createJoinedLedger creates the ledger of a joined channel, made of the
+// blocks up to and including the config block
+func (r *Registrar) createJoinedLedger(channelID string, configBlock *cb.Block) error {
+	if configBlock.Header.Number > 0 {
+		if r.channelReplicator == nil {
+			return errors.Errorf("cannot join channel %s from block %d: replication is not available", channelID, configBlock.Header.Number)
+		}
+		return errors.WithMessagef(r.channelReplicator.ReplicateChannel(configBlock), "failed replicating channel %s", channelID)
+	}
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating the ledger of channel %s", channelID)
+	}
+	return errors.WithMessagef(ledger.Append(configBlock), "failed appending the genesis block of channel %s", channelID)
+}
+
+// RemoveChannel halts a channel and removes its ledger, along with the storage
+// the consenters keep for the channel, so that the channel can be joined again.
+// Channels can only be removed when the orderer has no system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	r.joinLock.Lock()
+	defer r.joinLock.Unlock()
+
+	if r.SystemChannelID() != "" {
+		return types.ErrSystemChannelExists
+	}
+
+	r.lock.Lock()
+	cs, ok := r.chains[channelID]
+	if !ok {
+		r.lock.Unlock()
+		return types.ErrChannelNotExist
+	}
+	// Copy the map to allow concurrent reads from broadcast/deliver
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+	r.lock.Unlock()
+
+	logger.Infof("Removing channel %s", channelID)
+	cs.Halt()
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		return errors.WithMessagef(err, "failed removing the ledger of channel %s", channelID)
+	}
+
+	// The consensus type may have been migrated, hence all the consenters remove
+	// the storage they may have kept for the channel
+	for consensusType, consenter := range r.consenters {
+		remover, ok := consenter.(consensus.StorageRemover)
+		if !ok {
+			continue
+		}
+		if err := remover.RemoveStorage(channelID); err != nil {
+			return errors.WithMessagef(err, "failed removing the %s storage of channel %s", consensusType, channelID)
+		}
+	}
+	return nil
+}
+
 // ChannelsCount returns the count of the current total number of channels.
 func (r *Registrar) ChannelsCount() int {
 	r.lock.RLock()
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/ledger/blockledger/ramledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/multichannel/mocks"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -118,16 +120,20 @@ func TestNewRegistrar(t *testing.T) {
 	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
 	genesisBlockSys := encoder.New(confSys).GenesisBlock()
 
-	// This test checks to make sure the orderer refuses to come up if it cannot find a system channel
-	t.Run("No system chain - failure", func(t *testing.T) {
+	// This test checks to make sure the orderer comes up without a system channel
+	t.Run("No system chain - success", func(t *testing.T) {
 		lf := ramledger.New(10)
 
 		consenters := make(map[string]consensus.Consenter)
 		consenters[confSys.Orderer.OrdererType] = &mockConsenter{}
 
-		assert.Panics(t, func() {
-			NewRegistrar(lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
-		}, "Should have panicked when starting without a system chain")
+		manager := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		assert.NotPanics(t, func() { manager.Initialize(consenters) })
+		assert.Empty(t, manager.SystemChannelID())
+		assert.Equal(t, 0, manager.ChannelsCount())
+
+		_, _, _, err := manager.BroadcastChannelSupport(makeNormalTx("mychannel", 1))
+		assert.EqualError(t, err, "channel mychannel does not exist and channels cannot be created without a system channel")
 	})
 
 	// This test checks to make sure that the orderer refuses to come up if there are multiple system channels
@@ -392,3 +398,210 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 		assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 	})
 }
+
+func appChannelGenesisBlock(channelID string) *cb.Block {
+	conf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	conf.Consortiums = nil
+	conf.Application = configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile).Application
+	return encoder.New(conf).GenesisBlockForChannel(channelID)
+}
+
+func TestChannelParticipation(t *testing.T) {
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+	genesisBlock := appChannelGenesisBlock("mychannel")
+
+	newRegistrar := func(lf blockledger.Factory) *Registrar {
+		consenters := map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}}
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		return registrar
+	}
+
+	t.Run("Join from genesis block, list and remove", func(t *testing.T) {
+		lf := ramledger.New(10)
+		registrar := newRegistrar(lf)
+
+		info, err := registrar.JoinChannel("mychannel", genesisBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 1}, info)
+		assert.NotNil(t, registrar.GetChain("mychannel"))
+		assert.Equal(t, types.ChannelList{Channels: []types.ChannelInfoShort{{Name: "mychannel"}}}, registrar.ChannelList())
+
+		_, err = registrar.JoinChannel("mychannel", genesisBlock)
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+
+		chain := registrar.GetChain("mychannel")
+		err = registrar.RemoveChannel("mychannel")
+		assert.NoError(t, err)
+		assert.Nil(t, registrar.GetChain("mychannel"))
+		assert.Empty(t, lf.ChainIDs())
+		// The removed chain is halted
+		_, ok := <-chain.Chain.(*mockChain).queue
+		assert.False(t, ok)
+
+		_, err = registrar.ChannelInfo("mychannel")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+		assert.Equal(t, types.ErrChannelNotExist, registrar.RemoveChannel("mychannel"))
+	})
+
+	t.Run("Remove and re-join", func(t *testing.T) {
+		lf := ramledger.New(10)
+		consenter := &storageRemovingConsenter{}
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(map[string]consensus.Consenter{
+			confSys.Orderer.OrdererType: &mockConsenter{},
+			"etcdraft":                  consenter,
+		})
+
+		_, err := registrar.JoinChannel("mychannel", genesisBlock)
+		assert.NoError(t, err)
+		assert.NoError(t, registrar.RemoveChannel("mychannel"))
+		// The consenters remove their storage of the channel, whatever the consensus type of the channel
+		assert.Equal(t, []string{"mychannel"}, consenter.removed)
+
+		info, err := registrar.JoinChannel("mychannel", genesisBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 1}, info)
+
+		consenter.err = errors.New("permission denied")
+		assert.EqualError(t, registrar.RemoveChannel("mychannel"), "failed removing the etcdraft storage of channel mychannel: permission denied")
+	})
+
+	t.Run("Join from a later config block", func(t *testing.T) {
+		tmpLedger := ramledger.New(10)
+		rl, err := tmpLedger.GetOrCreate("mychannel")
+		assert.NoError(t, err)
+		assert.NoError(t, rl.Append(genesisBlock))
+		configBlock := blockledger.CreateNextBlock(rl, []*cb.Envelope{protoutil.ExtractEnvelopeOrPanic(genesisBlock, 0)})
+		configBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = protoutil.MarshalOrPanic(&cb.Metadata{Value: protoutil.MarshalOrPanic(&cb.LastConfig{Index: 1})})
+
+		lf := ramledger.New(10)
+		registrar := newRegistrar(lf)
+
+		_, err = registrar.JoinChannel("mychannel", configBlock)
+		assert.EqualError(t, err, "cannot join channel mychannel from block 1: replication is not available")
+
+		replicator := &mocks.ChannelReplicator{}
+		replicator.ReplicateChannelStub = func(block *cb.Block) error {
+			ledger, err := lf.GetOrCreate("mychannel")
+			if err != nil {
+				return err
+			}
+			if err := ledger.Append(genesisBlock); err != nil {
+				return err
+			}
+			return ledger.Append(block)
+		}
+		registrar.SetChannelReplicator(replicator)
+
+		info, err := registrar.JoinChannel("mychannel", configBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 2}, info)
+		assert.Equal(t, 1, replicator.ReplicateChannelCallCount())
+		assert.Equal(t, configBlock, replicator.ReplicateChannelArgsForCall(0))
+	})
+
+	t.Run("Replication failure", func(t *testing.T) {
+		configBlock := proto.Clone(genesisBlock).(*cb.Block)
+		configBlock.Header.Number = 5
+
+		lf := ramledger.New(10)
+		registrar := newRegistrar(lf)
+		replicator := &mocks.ChannelReplicator{}
+		replicator.ReplicateChannelStub = func(block *cb.Block) error {
+			lf.GetOrCreate("mychannel")
+			return errors.New("no orderer reachable")
+		}
+		registrar.SetChannelReplicator(replicator)
+
+		_, err := registrar.JoinChannel("mychannel", configBlock)
+		assert.EqualError(t, err, "failed replicating channel mychannel: no orderer reachable")
+		assert.Nil(t, registrar.GetChain("mychannel"))
+		assert.Empty(t, lf.ChainIDs(), "The partially replicated ledger should have been removed")
+	})
+
+	t.Run("Invalid join blocks", func(t *testing.T) {
+		registrar := newRegistrar(ramledger.New(10))
+
+		_, err := registrar.JoinChannel("mychannel", nil)
+		assert.EqualError(t, err, "block is empty")
+
+		normalBlock := protoutil.NewBlock(0, nil)
+		normalBlock.Data.Data = [][]byte{protoutil.MarshalOrPanic(makeNormalTx("mychannel", 0))}
+		_, err = registrar.JoinChannel("mychannel", normalBlock)
+		assert.EqualError(t, err, "block is not a config block")
+
+		_, err = registrar.JoinChannel("otherchannel", genesisBlock)
+		assert.EqualError(t, err, "config block is of channel mychannel, not otherchannel")
+
+		_, err = registrar.JoinChannel(genesisconfig.TestChainID, genesisBlockSys)
+		assert.EqualError(t, err, "the config block of a system channel cannot be joined")
+	})
+
+	t.Run("System channel exists", func(t *testing.T) {
+		lf, _ := newRAMLedgerAndFactory(10, genesisconfig.TestChainID, genesisBlockSys)
+		registrar := newRegistrar(lf)
+
+		_, err := registrar.JoinChannel("mychannel", genesisBlock)
+		assert.Equal(t, types.ErrSystemChannelExists, err)
+		assert.Equal(t, types.ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+		assert.Equal(t, types.ChannelList{
+			SystemChannel: &types.ChannelInfoShort{Name: genesisconfig.TestChainID},
+			Channels:      []types.ChannelInfoShort{},
+		}, registrar.ChannelList())
+
+		info, err := registrar.ChannelInfo(genesisconfig.TestChainID)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: genesisconfig.TestChainID, Status: types.StatusActive, Height: 1}, info)
+	})
+}
+
+type storageRemovingConsenter struct {
+	mockConsenter
+	removed []string
+	err     error
+}
+
+func (c *storageRemovingConsenter) RemoveStorage(channelID string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.removed = append(c.removed, channelID)
+	return nil
+}
+
+type batchSizeFetcher struct{}
+
+func (batchSizeFetcher) OrdererConfig() (channelconfig.Orderer, bool) {
+	return &mockchannelconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: 2, PreferredMaxBytes: 1024}}, true
+}
+
+func TestNewBlockCutter(t *testing.T) {
+	envelope := func(headerType cb.HeaderType) *cb.Envelope {
+		return &cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType)})},
+		})}
+	}
+	normal := envelope(cb.HeaderType_ENDORSER_TRANSACTION)
+	config := envelope(cb.HeaderType_CONFIG)
+	metrics := blockcutter.NewMetrics(&disabled.Provider{})
+
+	cutBatch := func(r *Registrar, consensusType string) []*cb.Envelope {
+		cutter := r.newBlockCutter("mychannel", consensusType, batchSizeFetcher{}, metrics)
+		batches, _ := cutter.Ordered(normal)
+		assert.Empty(t, batches)
+		batches, _ = cutter.Ordered(config)
+		assert.Len(t, batches, 1)
+		return batches[0]
+	}
+
+	registrar := NewRegistrar(nil, mockCrypto(), &disabled.Provider{})
+	assert.Equal(t, []*cb.Envelope{normal, config}, cutBatch(registrar, "etcdraft"))
+
+	registrar.SetPriorityLanes([]blockcutter.PriorityLane{{Name: "high"}, {Name: "normal"}}, blockcutter.NewMSPLaneSelector())
+	for _, consensusType := range []string{"solo", "etcdraft", "BFT"} {
+		assert.Equal(t, []*cb.Envelope{config, normal}, cutBatch(registrar, consensusType), consensusType)
+	}
+	assert.Equal(t, []*cb.Envelope{normal, config}, cutBatch(registrar, "kafka"))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package raftadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/pkg/errors"
+)
+
+// URLBaseV1Channels is the path under which the handler is expected to be registered
+const URLBaseV1Channels = "/raft/v1/channels"
+
+// maxRequestBodySize bounds the body of leadership transfer requests
+const maxRequestBodySize = 1024
+
+//go:generate counterfeiter -o mocks/chain_getter.go -fake-name ChainGetter . ChainGetter
+
+// ChainGetter obtains instances of ChainSupport for the given channel
+type ChainGetter interface {
+	GetChain(chainID string) *multichannel.ChainSupport
+}
+
+//go:generate counterfeiter -o mocks/raft_chain.go -fake-name RaftChain . RaftChain
+
+// RaftChain is a chain ordered by etcdraft
+type RaftChain interface {
+	RaftStatus() (types.RaftStatus, error)
+	TransferLeadership(transferee uint64) (uint64, error)
+}
+
+// TransferRequest is the body of a leadership transfer request. A zero
+// transferee transfers leadership away from the local node to any consenter.
+type TransferRequest struct {
+	Transferee uint64 `json:"transferee"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewHTTPHandler(config localconfig.RaftAdmin, chains ChainGetter) *HTTPHandler {
+	return &HTTPHandler{
+		Config: config,
+		Chains: chains,
+		Logger: flogging.MustGetLogger("orderer.common.raftadmin"),
+	}
+}
+
+// HTTPHandler describes the Raft node of a channel on GET /raft/v1/channels/{channel}.
+// A POST to /raft/v1/channels/{channel}/transfer with a TransferRequest as body
+// transfers the leadership of the channel, and describes the Raft node once the
+// new leader is elected. Leadership transfers are only accepted from clients
+// authenticated with a TLS client certificate verified by the operations endpoint.
+type HTTPHandler struct {
+	Config localconfig.RaftAdmin
+	Chains ChainGetter
+	Logger *flogging.FabricLogger
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !h.Config.Enabled {
+		h.sendResponse(resp, http.StatusServiceUnavailable, errors.New("Raft administration API is disabled"))
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, URLBaseV1Channels), "/")
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	switch {
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		h.serveStatus(resp, segments[0])
+	case len(segments) == 1:
+		resp.Header().Set("Allow", "GET")
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	case len(segments) == 2 && segments[1] == "transfer" && req.Method == http.MethodPost:
+		h.serveTransfer(resp, req, segments[0])
+	case len(segments) == 2 && segments[1] == "transfer":
+		resp.Header().Set("Allow", "POST")
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	default:
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("invalid path: %s", req.URL.Path))
+	}
+}
+
+func (h *HTTPHandler) serveStatus(resp http.ResponseWriter, channelID string) {
+	chain, err := h.raftChain(channelID)
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+
+	status, err := chain.RaftStatus()
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusServiceUnavailable), errors.WithMessagef(err, "cannot get Raft status of channel %s", channelID))
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, status)
+}
+
+func (h *HTTPHandler) serveTransfer(resp http.ResponseWriter, req *http.Request, channelID string) {
+	if !clientCertVerified(req) {
+		h.sendResponse(resp, http.StatusForbidden, errors.New("leadership transfer requires a client certificate verified by the operations TLS"))
+		return
+	}
+
+	transferReq := &TransferRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(resp, req.Body, maxRequestBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(transferReq); err != nil && err != io.EOF {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "failed decoding the transfer request"))
+		return
+	}
+	req.Body.Close()
+
+	chain, err := h.raftChain(channelID)
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+
+	if _, err := chain.TransferLeadership(transferReq.Transferee); err != nil {
+		h.Logger.Warningf("Failed transferring leadership of channel %s: %s", channelID, err)
+		h.sendResponse(resp, statusCode(err, http.StatusServiceUnavailable), errors.WithMessagef(err, "cannot transfer leadership of channel %s", channelID))
+		return
+	}
+
+	status, err := chain.RaftStatus()
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusServiceUnavailable), errors.WithMessagef(err, "cannot get Raft status of channel %s", channelID))
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, status)
+}
+
+// clientCertVerified tells whether the client authenticated with a TLS client
+// certificate, which is only the case when the operations endpoint has TLS enabled
+func clientCertVerified(req *http.Request) bool {
+	return req.TLS != nil && len(req.TLS.VerifiedChains) > 0 && len(req.TLS.VerifiedChains[0]) > 0
+}
+
+func (h *HTTPHandler) raftChain(channelID string) (RaftChain, error) {
+	cs := h.Chains.GetChain(channelID)
+	if cs == nil {
+		return nil, errors.WithMessagef(types.ErrChannelNotExist, "cannot find channel %s", channelID)
+	}
+	chain, ok := cs.Chain.(RaftChain)
+	if !ok {
+		return nil, errors.WithMessagef(types.ErrNotRaftChannel, "cannot find Raft node of channel %s", channelID)
+	}
+	return chain, nil
+}
+
+// statusCode maps the errors of the chains to HTTP status codes
+func statusCode(err error, defaultCode int) int {
+	switch errors.Cause(err) {
+	case types.ErrChannelNotExist, types.ErrNotRaftChannel:
+		return http.StatusNotFound
+	case types.ErrNotLeader:
+		return http.StatusConflict
+	case types.ErrInvalidTransferee:
+		return http.StatusBadRequest
+	default:
+		return defaultCode
+	}
+}
+
+func (h *HTTPHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
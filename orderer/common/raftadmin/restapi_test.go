@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package raftadmin_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin/mocks"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// authenticated marks the request as sent by a client authenticated with a
+// TLS client certificate
+func authenticated(req *http.Request) *http.Request {
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+	return req
+}
+
+// raftChain is a consensus.Chain ordered by etcdraft
+type raftChain struct {
+	consensus.Chain
+	*mocks.RaftChain
+}
+
+var _ = Describe("HTTPHandler", func() {
+	var (
+		fakeChainGetter *mocks.ChainGetter
+		fakeRaftChain   *mocks.RaftChain
+		handler         *raftadmin.HTTPHandler
+		resp            *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeRaftChain = &mocks.RaftChain{}
+		fakeChainGetter = &mocks.ChainGetter{}
+		fakeChainGetter.GetChainReturns(&multichannel.ChainSupport{Chain: &raftChain{RaftChain: fakeRaftChain}})
+		handler = raftadmin.NewHTTPHandler(localconfig.RaftAdmin{Enabled: true}, fakeChainGetter)
+		resp = httptest.NewRecorder()
+
+		fakeRaftChain.RaftStatusReturns(types.RaftStatus{
+			Channel: "app1",
+			ID:      1,
+			Leader:  2,
+			State:   "StateFollower",
+			Term:    3,
+			Commit:  10,
+			Applied: 9,
+			Snapshot: types.RaftSnapshotInfo{
+				Index:       8,
+				Term:        2,
+				BlockNumber: 4,
+			},
+		}, nil)
+	})
+
+	It("returns service unavailable when the API is disabled", func() {
+		handler = raftadmin.NewHTTPHandler(localconfig.RaftAdmin{}, fakeChainGetter)
+		req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(resp.Body).To(MatchJSON(`{"error": "Raft administration API is disabled"}`))
+		Expect(fakeChainGetter.GetChainCallCount()).To(Equal(0))
+	})
+
+	Describe("describing the Raft node of a channel", func() {
+		It("returns the status of the node", func() {
+			req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(resp.Body).To(MatchJSON(`{
+				"channel": "app1",
+				"id": 1,
+				"leader": 2,
+				"state": "StateFollower",
+				"term": 3,
+				"commit": 10,
+				"applied": 9,
+				"snapshot": {"index": 8, "term": 2, "blockNumber": 4}
+			}`))
+			Expect(fakeChainGetter.GetChainArgsForCall(0)).To(Equal("app1"))
+		})
+
+		It("includes the progress of the followers when the node is the leader", func() {
+			fakeRaftChain.RaftStatusReturns(types.RaftStatus{
+				Channel: "app1",
+				ID:      1,
+				Leader:  1,
+				State:   "StateLeader",
+				Progress: []types.RaftNodeProgress{
+					{ID: 1, Match: 10, Next: 11, State: "ProgressStateReplicate", RecentActive: true},
+					{ID: 2, Match: 5, Next: 6, State: "ProgressStateProbe", Paused: true, IsLearner: true},
+				},
+			}, nil)
+
+			req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{
+				"channel": "app1",
+				"id": 1,
+				"leader": 1,
+				"state": "StateLeader",
+				"term": 0,
+				"commit": 0,
+				"applied": 0,
+				"snapshot": {"index": 0, "term": 0, "blockNumber": 0},
+				"progress": [
+					{"id": 1, "match": 10, "next": 11, "state": "ProgressStateReplicate", "recentActive": true, "paused": false, "isLearner": false},
+					{"id": 2, "match": 5, "next": 6, "state": "ProgressStateProbe", "recentActive": false, "paused": true, "isLearner": true}
+				]
+			}`))
+		})
+
+		It("returns not found when the channel does not exist", func() {
+			fakeChainGetter.GetChainReturns(nil)
+			req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "cannot find channel app1: channel does not exist"}`))
+		})
+
+		It("returns not found when the channel is not ordered by etcdraft", func() {
+			fakeChainGetter.GetChainReturns(&multichannel.ChainSupport{})
+			req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "cannot find Raft node of channel app1: channel is not ordered by etcdraft on this orderer"}`))
+		})
+
+		It("returns service unavailable when the chain is not running", func() {
+			fakeRaftChain.RaftStatusReturns(types.RaftStatus{}, errors.New("chain is stopped"))
+			req := httptest.NewRequest("GET", "/raft/v1/channels/app1", nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body).To(MatchJSON(`{"error": "cannot get Raft status of channel app1: chain is stopped"}`))
+		})
+	})
+
+	Describe("transferring leadership", func() {
+		It("transfers leadership to the requested consenter", func() {
+			fakeRaftChain.TransferLeadershipReturns(2, nil)
+			req := authenticated(httptest.NewRequest("POST", "/raft/v1/channels/app1/transfer", strings.NewReader(`{"transferee": 2}`)))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(ContainSubstring(`"leader":2`))
+			Expect(fakeRaftChain.TransferLeadershipCallCount()).To(Equal(1))
+			Expect(fakeRaftChain.TransferLeadershipArgsForCall(0)).To(Equal(uint64(2)))
+			Expect(fakeRaftChain.RaftStatusCallCount()).To(Equal(1))
+		})
+
+		It("transfers leadership away from the local node without a body", func() {
+			fakeRaftChain.TransferLeadershipReturns(2, nil)
+			req := authenticated(httptest.NewRequest("POST", "/raft/v1/channels/app1/transfer", nil))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(fakeRaftChain.TransferLeadershipArgsForCall(0)).To(Equal(uint64(0)))
+		})
+
+		It("rejects requests without a verified client certificate", func() {
+			req := httptest.NewRequest("POST", "/raft/v1/channels/app1/transfer", strings.NewReader(`{"transferee": 2}`))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body).To(MatchJSON(`{"error": "leadership transfer requires a client certificate verified by the operations TLS"}`))
+
+			// TLS without a client certificate
+			req.TLS = &tls.ConnectionState{}
+			resp = httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+
+			Expect(fakeChainGetter.GetChainCallCount()).To(Equal(0))
+			Expect(fakeRaftChain.TransferLeadershipCallCount()).To(Equal(0))
+		})
+
+		It("rejects malformed requests", func() {
+			req := authenticated(httptest.NewRequest("POST", "/raft/v1/channels/app1/transfer", strings.NewReader(`{"to": 2}`)))
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(ContainSubstring("failed decoding the transfer request"))
+			Expect(fakeRaftChain.TransferLeadershipCallCount()).To(Equal(0))
+		})
+
+		DescribeTable("maps the errors of the chain to status codes",
+			func(err error, expectedCode int) {
+				fakeRaftChain.TransferLeadershipReturns(0, err)
+				req := authenticated(httptest.NewRequest("POST", "/raft/v1/channels/app1/transfer", strings.NewReader(`{"transferee": 3}`)))
+				handler.ServeHTTP(resp, req)
+				Expect(resp.Code).To(Equal(expectedCode))
+				Expect(resp.Body).To(ContainSubstring("cannot transfer leadership of channel app1"))
+				Expect(fakeRaftChain.RaftStatusCallCount()).To(Equal(0))
+			},
+			Entry("not the leader", pkgerrors.WithMessage(types.ErrNotLeader, "leader is 2"), http.StatusConflict),
+			Entry("invalid transferee", pkgerrors.WithMessage(types.ErrInvalidTransferee, "node 3 is a learner"), http.StatusBadRequest),
+			Entry("timeout", errors.New("leader transfer timeout"), http.StatusServiceUnavailable),
+		)
+	})
+
+	DescribeTable("rejects invalid requests",
+		func(method, path string, expectedCode int, expectedAllow string) {
+			req := httptest.NewRequest(method, path, nil)
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(expectedCode))
+			Expect(resp.Header().Get("Allow")).To(Equal(expectedAllow))
+			Expect(fakeChainGetter.GetChainCallCount()).To(Equal(0))
+		},
+		Entry("no channel", "GET", "/raft/v1/channels", http.StatusNotFound, ""),
+		Entry("unknown resource", "GET", "/raft/v1/channels/app1/leader", http.StatusNotFound, ""),
+		Entry("status method", "DELETE", "/raft/v1/channels/app1", http.StatusMethodNotAllowed, "GET"),
+		Entry("transfer method", "GET", "/raft/v1/channels/app1/transfer", http.StatusMethodNotAllowed, "POST"),
+	)
+})
@@ -37,12 +37,16 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/internal/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -64,7 +68,7 @@ var (
 	_       = app.Command("benchmark", "Run orderer in benchmark mode")
 	version = app.Command("version", "Show version information")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "BFT": {}}
 )
 
 // Main is the entry point of orderer process
@@ -87,7 +91,11 @@ func Main() {
 	prettyPrintStruct(conf)
 
 	bootstrapBlock := extractBootstrapBlock(conf)
-	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
+	if bootstrapBlock == nil {
+		if !conf.ChannelParticipation.Enabled {
+			logger.Panicf("The channel participation API must be enabled when the orderer has no system channel (General.GenesisMethod is none)")
+		}
+	} else if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
 		logger.Panicf("Failed validating bootstrap block: %v", err)
 	}
 
@@ -172,8 +180,14 @@ func Main() {
 		tlsCallback,
 	)
 
+	manager.SetChannelReplicator(r)
+	participationHandler := channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager)
+	opsSystem.RegisterHandler(channelparticipation.URLBaseV1Channels, participationHandler)
+	opsSystem.RegisterHandler(channelparticipation.URLBaseV1Channels+"/", participationHandler)
+	opsSystem.RegisterHandler(raftadmin.URLBaseV1Channels+"/", raftadmin.NewHTTPHandler(conf.RaftAdmin, manager))
+
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	server := NewServer(manager, metricsProvider, &conf.Debug, &conf.Broadcast, conf.General.Authentication.TimeWindow, mutualTLS)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -198,6 +212,11 @@ func Main() {
 
 // Extract system channel last config block
 func extractSysChanLastConfig(lf blockledger.Factory, bootstrapBlock *cb.Block) *cb.Block {
+	if bootstrapBlock == nil {
+		logger.Info("Not bootstrapping because there is no system channel")
+		return nil
+	}
+
 	// Are we bootstrapping?
 	chainCount := len(lf.ChainIDs())
 	if chainCount == 0 {
@@ -257,15 +276,16 @@ func createReplicator(
 		logger:        logger,
 	}
 
-	systemChannelName, err := protoutil.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-
-	// System channel is not verified because we trust the bootstrap block
-	// and use backward hash chain verification.
 	verifiersByChannel := vl.loadVerifiers()
-	verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	if bootstrapBlock != nil {
+		systemChannelName, err := protoutil.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+		// System channel is not verified because we trust the bootstrap block
+		// and use backward hash chain verification.
+		verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	}
 
 	vr := &cluster.VerificationRegistry{
 		LoadVerifier:       vl.loadVerifier,
@@ -395,6 +415,9 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 
 func initializeClusterClientConfig(conf *localconfig.TopLevel, clusterType bool, bootstrapBlock *cb.Block) comm.ClientConfig {
 	if clusterType && !conf.General.TLS.Enabled {
+		if bootstrapBlock == nil {
+			logger.Panicf("TLS is required for running ordering nodes without a system channel.")
+		}
 		logger.Panicf("TLS is required for running ordering nodes of type %s.", consensusType(bootstrapBlock))
 	}
 	cc := comm.ClientConfig{
@@ -538,6 +561,8 @@ func extractBootstrapBlock(conf *localconfig.TopLevel) *cb.Block {
 		bootstrapBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		bootstrapBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		logger.Info("Starting without a system channel")
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -561,6 +586,11 @@ func initializeBootstrapChannel(genesisBlock *cb.Block, lf blockledger.Factory)
 }
 
 func isClusterType(genesisBlock *cb.Block) bool {
+	// Without a system channel the orderer may join channels of any type,
+	// so the cluster consenters are always initialized
+	if genesisBlock == nil {
+		return true
+	}
 	_, exists := clusterTypes[consensusType(genesisBlock)]
 	return exists
 }
@@ -647,7 +677,9 @@ func initializeMultichannelRegistrar(
 ) *multichannel.Registrar {
 	genesisBlock := extractBootstrapBlock(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	if genesisBlock == nil {
+		logger.Info("Not bootstrapping because there is no system channel")
+	} else if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(genesisBlock, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing channels")
@@ -656,6 +688,10 @@ func initializeMultichannelRegistrar(
 	consenters := make(map[string]consensus.Consenter)
 
 	registrar := multichannel.NewRegistrar(lf, signer, metricsProvider, callbacks...)
+	if conf.BlockCutter.PriorityLanes.Enabled {
+		lanes, selectLane := priorityLanes(conf.BlockCutter.PriorityLanes)
+		registrar.SetPriorityLanes(lanes, selectLane)
+	}
 
 	consenters["solo"] = solo.New()
 	var kafkaMetrics *kafka.Metrics
@@ -670,6 +706,17 @@ func initializeMultichannelRegistrar(
 	return registrar
 }
 
+// priorityLanes returns the lanes of the priority block cutter and the selector
+// that assigns the messages of the high priority MSPs to the first one.
+func priorityLanes(conf localconfig.PriorityLanes) ([]blockcutter.PriorityLane, blockcutter.LaneSelector) {
+	logger.Infof("Cutting blocks with priority lanes, high priority MSPs: %v", conf.HighPriorityMSPs)
+	lanes := []blockcutter.PriorityLane{
+		blockcutter.HighPriorityLane:   {Name: "high", MaxWait: conf.HighPriorityMaxWait},
+		blockcutter.NormalPriorityLane: {Name: "normal", MaxWait: conf.NormalPriorityMaxWait},
+	}
+	return lanes, blockcutter.NewMSPLaneSelector(conf.HighPriorityMSPs...)
+}
+
 func initializeEtcdraftConsenter(
 	consenters map[string]consensus.Consenter,
 	conf *localconfig.TopLevel,
@@ -682,6 +729,14 @@ func initializeEtcdraftConsenter(
 	registrar *multichannel.Registrar,
 	metricsProvider metrics.Provider,
 ) {
+	if bootstrapBlock == nil {
+		icr := &untrackedChainRegistry{logger: logger}
+		raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider)
+		consenters["etcdraft"] = raftConsenter
+		consenters["BFT"] = bft.New(clusterDialer, conf, srvConf, registrar, icr, raftConsenter.Communication, metricsProvider)
+		return
+	}
+
 	replicationRefreshInterval := conf.General.Cluster.ReplicationBackgroundRefreshInterval
 	if replicationRefreshInterval == 0 {
 		replicationRefreshInterval = defaultReplicationBackgroundRefreshInterval
@@ -721,6 +776,8 @@ func initializeEtcdraftConsenter(
 	go icr.run()
 	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider)
 	consenters["etcdraft"] = raftConsenter
+	// BFT chains receive their messages through the cluster communication of the etcdraft consenter
+	consenters["BFT"] = bft.New(clusterDialer, conf, srvConf, registrar, icr, raftConsenter.Communication, metricsProvider)
 }
 
 func newOperationsSystem(ops localconfig.Operations, metrics localconfig.Metrics) *operations.System {
@@ -35,12 +35,14 @@ import (
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/internal/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	server_mocks "github.com/hyperledger/fabric/orderer/common/server/mocks"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -240,6 +242,15 @@ func TestInitializeBootstrapChannel(t *testing.T) {
 	}
 }
 
+func TestExtractBootstrapBlockNone(t *testing.T) {
+	bootstrapConfig := &localconfig.TopLevel{
+		General: localconfig.General{
+			GenesisMethod: "none",
+		},
+	}
+	assert.Nil(t, extractBootstrapBlock(bootstrapConfig))
+}
+
 func TestExtractSysChanLastConfig(t *testing.T) {
 	rlf := ramledger.New(10)
 	conf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
@@ -258,9 +269,7 @@ func TestExtractSysChanLastConfig(t *testing.T) {
 	assert.NotNil(t, lastConf)
 	assert.Equal(t, uint64(0), lastConf.Header.Number)
 
-	assert.Panics(t, func() {
-		_ = extractSysChanLastConfig(rlf, nil)
-	})
+	assert.Nil(t, extractSysChanLastConfig(rlf, nil))
 
 	configTx, err := protoutil.CreateSignedEnvelope(common.HeaderType_CONFIG, genesisconfig.TestChainID, nil, &common.ConfigEnvelope{}, 0, 0)
 	require.NoError(t, err)
@@ -721,6 +730,28 @@ func TestInitializeEtcdraftConsenter(t *testing.T) {
 			},
 		}, srv, &multichannel.Registrar{}, &disabled.Provider{})
 	assert.NotNil(t, consenters["etcdraft"])
+	assert.NotNil(t, consenters["BFT"])
+
+	t.Run("No system channel", func(t *testing.T) {
+		consenters := make(map[string]consensus.Consenter)
+		srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+		assert.NoError(t, err)
+
+		initializeEtcdraftConsenter(consenters,
+			&localconfig.TopLevel{},
+			rlf,
+			&cluster.PredicateDialer{},
+			nil, &replicationInitiator{},
+			comm.ServerConfig{
+				SecOpts: comm.SecureOptions{
+					Certificate: crt.Cert,
+					Key:         crt.Key,
+					UseTLS:      true,
+				},
+			}, srv, &multichannel.Registrar{}, &disabled.Provider{})
+		assert.NotNil(t, consenters["etcdraft"])
+		assert.NotNil(t, consenters["BFT"])
+	})
 }
 
 func genesisConfig(t *testing.T) *localconfig.TopLevel {
@@ -788,3 +819,25 @@ func TestCreateReplicator(t *testing.T) {
 	err = r.verifierRetriever.RetrieveVerifier("system").VerifyBlockSignature(nil, nil)
 	assert.NoError(t, err)
 }
+
+func TestPriorityLanes(t *testing.T) {
+	lanes, selectLane := priorityLanes(localconfig.PriorityLanes{
+		Enabled:             true,
+		HighPriorityMSPs:    []string{"Org1MSP"},
+		HighPriorityMaxWait: time.Second,
+	})
+	assert.Equal(t, []blockcutter.PriorityLane{{Name: "high", MaxWait: time.Second}, {Name: "normal"}}, lanes)
+
+	envelope := func(mspID string) *common.Envelope {
+		return &common.Envelope{Payload: protoutil.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION)}),
+				SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{
+					Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID}),
+				}),
+			},
+		})}
+	}
+	assert.Equal(t, blockcutter.HighPriorityLane, selectLane(envelope("Org1MSP")))
+	assert.Equal(t, blockcutter.NormalPriorityLane, selectLane(envelope("Org2MSP")))
+}
@@ -120,6 +120,46 @@ func (ri *replicationInitiator) ReplicateChains(lastConfigBlock *common.Block, c
 	return replicator.ReplicateChains()
 }
 
+// ReplicateChannel pulls the blocks of the channel of the given config block,
+// up to and including it, from the ordering nodes listed in the block.
+// The pulled blocks are not verified against the config of the channel,
+// instead their hash chain is verified to lead to the config block.
+func (ri *replicationInitiator) ReplicateChannel(configBlock *common.Block) error {
+	channel, err := protoutil.GetChainIDFromBlock(configBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting the channel name from the config block")
+	}
+	pullerConfig := cluster.PullerConfigFromTopLevelConfig(channel, ri.conf, ri.secOpts.Key, ri.secOpts.Certificate, ri.signer)
+	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, configBlock, &trustedChainVerifierRetriever{})
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating a block puller for channel %s", channel)
+	}
+	defer puller.Close()
+	puller.MaxPullBlockRetries = uint64(ri.conf.General.Cluster.ReplicationMaxRetries)
+	puller.RetryTimeout = ri.conf.General.Cluster.ReplicationRetryTimeout
+
+	replicator := &cluster.Replicator{
+		Filter:        cluster.AnyChannel,
+		LedgerFactory: ri.lf,
+		// The channel is treated as a system channel so that the replicator
+		// stops at the config block and compares it with the pulled one
+		SystemChannel: channel,
+		BootBlock:     configBlock,
+		Logger:        ri.logger,
+		Puller:        puller,
+	}
+	ri.logger.Infof("Replicating channel %s up to config block %d", channel, configBlock.Header.Number)
+	return replicator.PullChannel(channel)
+}
+
+// trustedChainVerifierRetriever retrieves verifiers that skip the signature
+// verification of blocks whose hash chain leads to a trusted block
+type trustedChainVerifierRetriever struct{}
+
+func (*trustedChainVerifierRetriever) RetrieveVerifier(channel string) cluster.BlockVerifier {
+	return &cluster.NoopBlockVerifier{}
+}
+
 type ledgerFactory struct {
 	blockledger.Factory
 	onBlockCommit cluster.BlockCommitFunc
@@ -246,6 +286,17 @@ func (dc *inactiveChainReplicator) listInactiveChains() []string {
 	return chains
 }
 
+// untrackedChainRegistry is the inactive chain registry of orderers without a
+// system channel, from which inactive chains would be replicated. Such chains
+// stay inactive until they are removed through the channel participation API.
+type untrackedChainRegistry struct {
+	logger *flogging.FabricLogger
+}
+
+func (u *untrackedChainRegistry) TrackChain(chain string, _ *common.Block, _ etcdraft.CreateChainCallback) {
+	u.logger.Warningf("This node is not a consenter of channel %s, the channel will stay inactive", chain)
+}
+
 //go:generate mockery -dir . -name Factory -case underscore  -output mocks/
 
 // Factory retrieves or creates new ledgers by chainID
@@ -257,6 +308,9 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove closes the ledger of the given chain and deletes its blocks
+	Remove(chainID string) error
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
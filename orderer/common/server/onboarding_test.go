@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx"
 	deliver_mocks "github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	ledger_mocks "github.com/hyperledger/fabric/common/ledger/blockledger/mocks"
 	"github.com/hyperledger/fabric/common/ledger/blockledger/ramledger"
 	"github.com/hyperledger/fabric/core/comm"
@@ -716,6 +717,129 @@ func TestReplicate(t *testing.T) {
 	}
 }
 
+func TestReplicateChannel(t *testing.T) {
+	t.Parallel()
+
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	blockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "genesis.block"))
+	assert.NoError(t, err)
+
+	caCert := loadPEM("ca.crt", t)
+	key := loadPEM("server.key", t)
+	cert := loadPEM("server.crt", t)
+
+	// prepareChain serves the blocks of a channel whose last block is a copy of
+	// the genesis block at sequence 10, and returns that last block
+	prepareChain := func(deliverServer *deliverServer) *common.Block {
+		joinBlock := &common.Block{}
+		assert.NoError(t, proto.Unmarshal(blockBytes, joinBlock))
+		joinBlock.Header.Number = 10
+		injectOrdererEndpoint(t, joinBlock, deliverServer.srv.Address())
+
+		blocks := make([]*common.Block, 11)
+		for seq := uint64(0); seq <= uint64(10); seq++ {
+			block := proto.Clone(joinBlock).(*common.Block)
+			block.Header.Number = seq
+			if seq > 0 {
+				block.Header.PreviousHash = protoutil.BlockHeaderHash(blocks[seq-1].Header)
+			}
+			blocks[seq] = block
+		}
+		joinBlock = blocks[10]
+
+		// The latest block is probed once for the height of the channel,
+		// and once again when connecting to pull the blocks
+		for i := 0; i < 2; i++ {
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: joinBlock},
+			}
+		}
+		for _, block := range blocks {
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: block},
+			}
+		}
+		close(deliverServer.blockResponses)
+		return proto.Clone(joinBlock).(*common.Block)
+	}
+
+	newReplicationInitiator := func(rlf blockledger.Factory) *replicationInitiator {
+		return &replicationInitiator{
+			lf:     &ledgerFactory{Factory: rlf, onBlockCommit: func(*common.Block, string) {}},
+			logger: flogging.MustGetLogger("testReplicateChannel"),
+			conf: &localconfig.TopLevel{
+				General: localconfig.General{
+					Cluster: localconfig.Cluster{
+						ReplicationPullTimeout:  time.Hour,
+						DialTimeout:             time.Hour,
+						RPCTimeout:              time.Hour,
+						ReplicationRetryTimeout: time.Hour,
+						ReplicationBufferSize:   1,
+					},
+				},
+			},
+			secOpts: comm.SecureOptions{
+				Certificate:   cert,
+				Key:           key,
+				UseTLS:        true,
+				ServerRootCAs: [][]byte{caCert},
+			},
+		}
+	}
+
+	t.Run("Replicates up to the config block", func(t *testing.T) {
+		deliverServer := newServerNode(t, key, cert)
+		defer deliverServer.srv.Stop()
+		joinBlock := prepareChain(deliverServer)
+
+		rlf := ramledger.New(20)
+		err := newReplicationInitiator(rlf).ReplicateChannel(joinBlock)
+		assert.NoError(t, err)
+
+		ledger, err := rlf.GetOrCreate("testchainid")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(11), ledger.Height())
+		assert.Equal(t, protoutil.BlockHeaderHash(joinBlock.Header), protoutil.BlockHeaderHash(blockledger.GetBlock(ledger, 10).Header))
+	})
+
+	t.Run("Config block does not match the replicated chain", func(t *testing.T) {
+		deliverServer := newServerNode(t, key, cert)
+		defer deliverServer.srv.Stop()
+		joinBlock := prepareChain(deliverServer)
+		joinBlock.Header.PreviousHash = []byte{1, 2, 3}
+
+		rlf := ramledger.New(20)
+		err := newReplicationInitiator(rlf).ReplicateChannel(joinBlock)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "block header mismatch on last system channel block")
+
+		ledger, err := rlf.GetOrCreate("testchainid")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), ledger.Height(), "The mismatching block should not have been committed")
+	})
+
+	t.Run("Invalid config block", func(t *testing.T) {
+		err := newReplicationInitiator(ramledger.New(20)).ReplicateChannel(&common.Block{Header: &common.BlockHeader{Number: 10}})
+		assert.EqualError(t, err, "failed extracting the channel name from the config block: failed to retrieve channel id - block is empty")
+	})
+}
+
+func TestUntrackedChainRegistry(t *testing.T) {
+	var logged []string
+	logger := flogging.MustGetLogger("testUntrackedChainRegistry").WithOptions(zap.Hooks(func(entry zapcore.Entry) error {
+		logged = append(logged, entry.Message)
+		return nil
+	}))
+
+	registry := &untrackedChainRegistry{logger: logger}
+	registry.TrackChain("mychannel", &common.Block{}, func() {
+		t.Fatal("inactive chains without a system channel should not be created")
+	})
+	assert.Equal(t, []string{"This node is not a consenter of channel mychannel, the channel will stay inactive"}, logged)
+}
+
 func TestInactiveChainReplicator(t *testing.T) {
 	for _, testCase := range []struct {
 		description                          string
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -64,7 +65,7 @@ func (rs *responseSender) SendStatusResponse(status cb.Status) error {
 	return rs.Send(reply)
 }
 
-func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
+func (rs *responseSender) SendBlockResponse(block *cb.Block, _ string, _ deliver.Chain, _ *protoutil.SignedData) error {
 	response := &ab.DeliverResponse{
 		Type: &ab.DeliverResponse_Block{Block: block},
 	}
@@ -72,12 +73,13 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 }
 
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, limits *localconfig.Broadcast, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
 	s := &server{
 		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider)),
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
 			Metrics:          broadcast.NewMetrics(metricsProvider),
+			Limiter:          newBroadcastLimiter(limits),
 		},
 		debug:     debug,
 		Registrar: r,
@@ -85,6 +87,22 @@ func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debu
 	return s
 }
 
+func newBroadcastLimiter(limits *localconfig.Broadcast) *broadcast.Limiter {
+	if limits == nil {
+		return nil
+	}
+	limiter := broadcast.NewLimiter(broadcast.LimiterConfig{
+		MaxInFlight: limits.MaxInFlight,
+		Client:      broadcast.RateLimit{Rate: limits.Client.Rate, Burst: limits.Client.Burst},
+		MSP:         broadcast.RateLimit{Rate: limits.MSP.Rate, Burst: limits.MSP.Burst},
+		Channel:     broadcast.RateLimit{Rate: limits.Channel.Rate, Burst: limits.Channel.Burst},
+	})
+	if limits.RetryAfter > 0 {
+		limiter.RetryAfter = limits.RetryAfter
+	}
+	return limiter
+}
+
 type msgTracer struct {
 	function string
 	debug    *localconfig.Debug
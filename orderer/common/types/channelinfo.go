@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package types holds the types exchanged by the orderer channel participation
+// and Raft administration APIs
+package types
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrSystemChannelExists is returned when channels are joined or removed
+	// while the orderer is managed by a system channel
+	ErrSystemChannelExists = errors.New("system channel exists")
+
+	// ErrChannelAlreadyExists is returned when joining a channel the orderer already has
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+
+	// ErrChannelNotExist is returned when a channel the orderer does not have is requested
+	ErrChannelNotExist = errors.New("channel does not exist")
+)
+
+// ChannelStatus is the status of a channel on the orderer
+type ChannelStatus string
+
+const (
+	// StatusActive means the orderer is a consenter of the channel and orders its transactions
+	StatusActive ChannelStatus = "active"
+	// StatusInactive means the orderer has the ledger of the channel but is not one of its consenters
+	StatusInactive ChannelStatus = "inactive"
+)
+
+// ChannelInfoShort identifies a channel in a channel list
+type ChannelInfoShort struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ChannelList is the list of channels of the orderer. SystemChannel is nil
+// when the orderer has no system channel.
+type ChannelList struct {
+	SystemChannel *ChannelInfoShort  `json:"systemChannel"`
+	Channels      []ChannelInfoShort `json:"channels"`
+}
+
+// ChannelInfo describes a channel of the orderer
+type ChannelInfo struct {
+	Name   string        `json:"name"`
+	URL    string        `json:"url"`
+	Status ChannelStatus `json:"status"`
+	Height uint64        `json:"height"`
+}
@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrNotRaftChannel is returned when the Raft status of a channel which
+	// is not ordered by etcdraft on this orderer is requested
+	ErrNotRaftChannel = errors.New("channel is not ordered by etcdraft on this orderer")
+
+	// ErrNotLeader is returned when leadership is transferred to a specific
+	// consenter by a node which is not the Raft leader
+	ErrNotLeader = errors.New("not the Raft leader")
+
+	// ErrInvalidTransferee is returned when leadership is transferred to a node
+	// which cannot be elected
+	ErrInvalidTransferee = errors.New("invalid transferee")
+)
+
+// RaftStatus describes the Raft node of a channel
+type RaftStatus struct {
+	Channel  string             `json:"channel"`
+	ID       uint64             `json:"id"`
+	Leader   uint64             `json:"leader"`
+	State    string             `json:"state"`
+	Term     uint64             `json:"term"`
+	Commit   uint64             `json:"commit"`
+	Applied  uint64             `json:"applied"`
+	Snapshot RaftSnapshotInfo   `json:"snapshot"`
+	Progress []RaftNodeProgress `json:"progress,omitempty"`
+}
+
+// RaftSnapshotInfo describes the latest snapshot of a Raft node
+type RaftSnapshotInfo struct {
+	Index       uint64 `json:"index"`
+	Term        uint64 `json:"term"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// RaftNodeProgress is the replication progress of a node as seen by the
+// leader, it is only known by the leader
+type RaftNodeProgress struct {
+	ID           uint64 `json:"id"`
+	Match        uint64 `json:"match"`
+	Next         uint64 `json:"next"`
+	State        string `json:"state"`
+	RecentActive bool   `json:"recentActive"`
+	Paused       bool   `json:"paused"`
+	IsLearner    bool   `json:"isLearner"`
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bftconfig validates the BFT consensus metadata of a channel config.
+// It has no dependencies on the orderer, so that the message processors can
+// validate a migration to BFT without depending on the BFT consenter.
+package bftconfig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/pkg/errors"
+)
+
+// CheckConfigMetadata validates BFT config metadata
+func CheckConfigMetadata(metadata *bft.ConfigMetadata) error {
+	if metadata == nil {
+		return errors.Errorf("nil BFT config metadata")
+	}
+
+	if metadata.Options == nil {
+		return errors.Errorf("BFT options have not been provided")
+	}
+
+	if d, err := time.ParseDuration(metadata.Options.RequestTimeout); err != nil {
+		return errors.Errorf("failed to parse RequestTimeout (%s) to time duration: %s", metadata.Options.RequestTimeout, err)
+	} else if d <= 0 {
+		return errors.Errorf("RequestTimeout must be positive")
+	}
+
+	if d, err := time.ParseDuration(metadata.Options.ViewChangeTimeout); err != nil {
+		return errors.Errorf("failed to parse ViewChangeTimeout (%s) to time duration: %s", metadata.Options.ViewChangeTimeout, err)
+	} else if d <= 0 {
+		return errors.Errorf("ViewChangeTimeout must be positive")
+	}
+
+	if len(metadata.Consenters) == 0 {
+		return errors.Errorf("empty consenter set")
+	}
+
+	ids := make(map[uint64]struct{})
+	certs := make(map[string]struct{})
+	for _, consenter := range metadata.Consenters {
+		if consenter == nil {
+			return errors.Errorf("metadata has nil consenter")
+		}
+		if consenter.Id == 0 {
+			return errors.Errorf("consenter %s:%d has a zero id", consenter.Host, consenter.Port)
+		}
+		if _, exists := ids[consenter.Id]; exists {
+			return errors.Errorf("duplicate consenter id %d", consenter.Id)
+		}
+		ids[consenter.Id] = struct{}{}
+
+		if consenter.MspId == "" {
+			return errors.Errorf("consenter %d has no MSP ID", consenter.Id)
+		}
+		if _, err := ParseIdentity(consenter.Identity); err != nil {
+			return errors.WithMessagef(err, "consenter %d has an invalid identity", consenter.Id)
+		}
+		for role, cert := range map[string][]byte{"server": consenter.ServerTlsCert, "client": consenter.ClientTlsCert} {
+			if _, err := PEMToDER(cert); err != nil {
+				return errors.WithMessagef(err, "consenter %d has an invalid %s TLS certificate", consenter.Id, role)
+			}
+			if _, exists := certs[string(cert)]; exists {
+				return errors.Errorf("consenter %d has a duplicate %s TLS certificate", consenter.Id, role)
+			}
+			certs[string(cert)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// ParseIdentity returns the public key of the PEM encoded certificate of a
+// consenter identity
+func ParseIdentity(identity []byte) (crypto.PublicKey, error) {
+	der, err := PEMToDER(identity)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "certificate has invalid ASN1 structure")
+	}
+	switch publicKey := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, errors.Errorf("certificate does not have an ECDSA or Ed25519 public key")
+	}
+}
+
+// PEMToDER returns the DER bytes of a PEM encoded certificate
+func PEMToDER(pemBytes []byte) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.Errorf("certificate is not PEM encoded: %s", string(pemBytes))
+	}
+	return bl.Bytes, nil
+}
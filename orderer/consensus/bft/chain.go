@@ -0,0 +1,1265 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// maxSeqsAhead is the number of sequences beyond the next one for which the votes of the
+// other nodes are kept, so that a node that falls behind can use them once it catches up
+const maxSeqsAhead = 10
+
+//go:generate counterfeiter -o mocks/rpc.go --fake-name RPC . RPC
+
+// RPC is used to send messages to the other nodes of the channel
+type RPC interface {
+	// SendConsensus sends a consensus message to the given node
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	// SendSubmit forwards a transaction to the given node
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+//go:generate counterfeiter -o mocks/configurator.go --fake-name Configurator . Configurator
+
+// Configurator configures the communication layer
+// when the chain starts and when the consenters change
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+// CreateBlockPuller is a function to create BlockPuller on demand.
+// It is passed into chain initializer so that tests could mock this.
+type CreateBlockPuller func() (etcdraft.BlockPuller, error)
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	SelfID     uint64
+	Consenters map[uint64]*bft.Consenter
+	// View is the view in which the last block was decided
+	View uint64
+
+	RequestTimeout    time.Duration
+	ViewChangeTimeout time.Duration
+
+	Clock   clock.Clock
+	Logger  *flogging.FabricLogger
+	Metrics *Metrics
+}
+
+type submit struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+	leader chan uint64
+}
+
+type message struct {
+	msg    *bft.ConsensusMessage
+	sender uint64
+}
+
+// slot identifies the votes on the proposals of a sequence in a view
+type slot struct {
+	view uint64
+	seq  uint64
+}
+
+type prepareVote struct {
+	prepare *bft.Prepare
+	signed  *bft.SignedPrepare
+}
+
+type commitVote struct {
+	commit   *bft.Commit
+	verified bool
+}
+
+type viewChangeVote struct {
+	viewChange *bft.ViewChange
+	signed     *bft.SignedViewChange
+}
+
+// proposal is the block accepted by the node for the next sequence in the current view
+type proposal struct {
+	view       uint64
+	block      *common.Block
+	digest     []byte
+	value      []byte // the value of the block signatures
+	acceptedAt time.Time
+	committed  bool
+}
+
+type trackedRequest struct {
+	req       *orderer.SubmitRequest
+	isConfig  bool
+	since     time.Time
+	order     uint64
+	forwarded bool // whether the request was forwarded to all the nodes
+}
+
+// Chain implements consensus.Chain interface with a Byzantine fault tolerant protocol of the PBFT family.
+//
+// The leader of a view proposes the next block in a pre-prepare message. The nodes that accept the proposal
+// send a signed prepare to the others, and the nodes that receive a quorum of prepares sign the metadata of the
+// block and send their signature in a commit. A node writes the block once it receives a quorum of commits,
+// with all the signatures of the quorum. The nodes that suspect the leader, because a request they received
+// or a proposal they accepted is not decided in time, start a view change to the view of the next leader.
+type Chain struct {
+	support      consensus.ConsenterSupport
+	rpc          RPC
+	configurator Configurator
+	createPuller CreateBlockPuller
+
+	channelID string
+	selfID    uint64
+	opts      Options
+	clock     clock.Clock
+	logger    *flogging.FabricLogger
+	Metrics   *Metrics
+
+	submitC chan *submit
+	msgC    chan *message
+	haltC   chan struct{}
+	doneC   chan struct{}
+	startC  chan struct{}
+
+	// The fields below are only accessed by the run go routine
+	consenters map[uint64]*bft.Consenter
+	publicKeys map[uint64]crypto.PublicKey
+	nodes      []uint64
+
+	view            uint64
+	viewChanging    bool
+	nextView        uint64
+	viewChangeStart time.Time
+	viewChanges     map[uint64]*viewChangeVote
+	higherViews     map[uint64]uint64
+	lastNewView     *bft.NewView
+
+	lastBlock          *common.Block
+	lastConfigBlockNum uint64
+
+	proposal   *proposal
+	prepared   *bft.Prepared
+	reproposal *common.Block
+	prepares   map[slot]map[uint64]*prepareVote
+	commits    map[slot]map[uint64]*commitVote
+	aheadSeqs  map[uint64]uint64
+
+	pendingBatches [][]*common.Envelope
+	batchPending   bool
+	configInflight bool
+	deferred       []*orderer.SubmitRequest
+
+	requests     map[string]*trackedRequest
+	requestCount uint64
+
+	stopped bool
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+	f CreateBlockPuller,
+) (*Chain, error) {
+	lg := opts.Logger.With("channel", support.ChainID(), "node", opts.SelfID)
+
+	if opts.Clock == nil {
+		opts.Clock = clock.NewClock()
+	}
+
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve block [%d]", support.Height()-1)
+	}
+
+	var lastConfigBlockNum uint64
+	if lastBlock.Header.Number != 0 {
+		var err error
+		lastConfigBlockNum, err = protoutil.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read the last config index of the last block")
+		}
+	}
+
+	c := &Chain{
+		support:      support,
+		rpc:          rpc,
+		configurator: conf,
+		createPuller: f,
+		channelID:    support.ChainID(),
+		selfID:       opts.SelfID,
+		opts:         opts,
+		clock:        opts.Clock,
+		logger:       lg,
+		Metrics: &Metrics{
+			ClusterSize:          opts.Metrics.ClusterSize.With("channel", support.ChainID()),
+			IsLeader:             opts.Metrics.IsLeader.With("channel", support.ChainID()),
+			View:                 opts.Metrics.View.With("channel", support.ChainID()),
+			CommittedBlockNumber: opts.Metrics.CommittedBlockNumber.With("channel", support.ChainID()),
+			ViewChanges:          opts.Metrics.ViewChanges.With("channel", support.ChainID()),
+			ProposalFailures:     opts.Metrics.ProposalFailures.With("channel", support.ChainID()),
+		},
+
+		submitC: make(chan *submit),
+		msgC:    make(chan *message),
+		haltC:   make(chan struct{}),
+		doneC:   make(chan struct{}),
+		startC:  make(chan struct{}),
+
+		view:               opts.View,
+		viewChanges:        make(map[uint64]*viewChangeVote),
+		higherViews:        make(map[uint64]uint64),
+		lastBlock:          lastBlock,
+		lastConfigBlockNum: lastConfigBlockNum,
+		prepares:           make(map[slot]map[uint64]*prepareVote),
+		commits:            make(map[slot]map[uint64]*commitVote),
+		aheadSeqs:          make(map[uint64]uint64),
+		requests:           make(map[string]*trackedRequest),
+	}
+
+	if err := c.setConsenters(opts.Consenters); err != nil {
+		return nil, err
+	}
+
+	c.Metrics.View.Set(float64(c.view))
+	c.Metrics.IsLeader.Set(0)
+	c.Metrics.CommittedBlockNumber.Set(float64(lastBlock.Header.Number))
+
+	return c, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d at block [%d]", c.view, c.lastBlock.Header.Number)
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
+		close(c.doneC)
+		return
+	}
+
+	close(c.startC)
+	go c.run()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// WaitReady blocks when the chain is catching up with the other nodes.
+// In any other case, it returns right away.
+func (c *Chain) WaitReady() error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	select {
+	case c.submitC <- nil:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.doneC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Consensus passes the given ConsensusRequest message, sent by the given node, to the chain
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bft.ConsensusMessage{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Errorf("failed to unmarshal ConsensusRequest payload to BFT message: %s", err)
+	}
+
+	select {
+	case c.msgC <- &message{msg: msg, sender: sender}:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+// Submit orders the incoming request if this node is the leader of the current view,
+// or forwards it to the leader via the transport mechanism otherwise.
+// A request received during a view change is forwarded to the leader of the next view.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		c.Metrics.ProposalFailures.Add(1)
+		return err
+	}
+
+	leadC := make(chan uint64, 1)
+	select {
+	case c.submitC <- &submit{req: req, sender: sender, leader: leadC}:
+		lead := <-leadC
+		if lead != 0 && lead != c.selfID {
+			if err := c.rpc.SendSubmit(lead, req); err != nil {
+				c.Metrics.ProposalFailures.Add(1)
+				return err
+			}
+		}
+	case <-c.doneC:
+		c.Metrics.ProposalFailures.Add(1)
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+func (c *Chain) run() {
+	defer close(c.doneC)
+
+	ticker := c.clock.NewTicker(c.checkInterval())
+	defer ticker.Stop()
+
+	ticking := false
+	timer := c.clock.NewTimer(time.Second)
+	// we need a stopped timer rather than nil,
+	// because we will be select waiting on timer.C()
+	if !timer.Stop() {
+		<-timer.C()
+	}
+
+	for {
+		select {
+		case s := <-c.submitC:
+			if s == nil {
+				// polled by `WaitReady`
+				continue
+			}
+			s.leader <- c.onSubmit(s.req, s.sender)
+
+		case m := <-c.msgC:
+			c.onMessage(m.sender, m.msg)
+
+		case <-timer.C():
+			ticking = false
+			batch := c.support.BlockCutter().Cut()
+			c.batchPending = false
+			if len(batch) == 0 {
+				c.logger.Warningf("Batch timer expired with no pending requests, this might indicate a bug")
+				continue
+			}
+			c.logger.Debugf("Batch timer expired, creating block")
+			c.pendingBatches = append(c.pendingBatches, batch)
+			c.proposeNext()
+
+		case now := <-ticker.C():
+			c.checkTimeouts(now)
+
+		case <-c.haltC:
+			timer.Stop()
+			c.logger.Infof("Stop serving requests")
+			return
+		}
+
+		if c.stopped {
+			timer.Stop()
+			return
+		}
+
+		// the batch timer runs while the leader has requests pending in the block cutter
+		if c.batchPending && c.isLeader() && !c.viewChanging {
+			if !ticking {
+				ticking = true
+				timer.Reset(c.support.SharedConfig().BatchTimeout())
+			}
+		} else if ticking {
+			ticking = false
+			if !timer.Stop() {
+				<-timer.C()
+			}
+		}
+	}
+}
+
+func (c *Chain) checkInterval() time.Duration {
+	interval := c.opts.RequestTimeout
+	if c.opts.ViewChangeTimeout < interval {
+		interval = c.opts.ViewChangeTimeout
+	}
+	interval /= 10
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+	return interval
+}
+
+// onSubmit handles a request and returns the node the request should be forwarded to, if any.
+// Every node keeps track of the requests it receives until they are decided, and the leader
+// orders each request once, whether it receives it from a client or from another node.
+func (c *Chain) onSubmit(req *orderer.SubmitRequest, sender uint64) uint64 {
+	isNew := c.track(req, sender != 0)
+
+	if c.viewChanging {
+		// the request is forwarded to the leader of the next view once the view change completes
+		return 0
+	}
+
+	if !c.isLeader() {
+		if sender != 0 {
+			return 0
+		}
+		return c.leader()
+	}
+
+	if isNew {
+		c.order(req)
+	}
+	return c.selfID
+}
+
+func (c *Chain) order(req *orderer.SubmitRequest) {
+	if c.configInflight {
+		c.logger.Debugf("A config block is in flight, deferring the request until it is decided")
+		c.deferred = append(c.deferred, req)
+		return
+	}
+
+	batches, pending, isConfig, err := c.ordered(req)
+	if err != nil {
+		c.logger.Errorf("Failed to order message: %s", err)
+		return
+	}
+	c.batchPending = pending
+	c.pendingBatches = append(c.pendingBatches, batches...)
+	if isConfig {
+		c.logger.Info("Received config transaction, pause ordering transactions till it is decided")
+		c.configInflight = true
+	}
+	c.proposeNext()
+}
+
+// Orders the envelope in the `msg` content. SubmitRequest.
+// Returns
+//   -- batches [][]*common.Envelope; the batches cut,
+//   -- pending bool; if there are envelopes pending to be ordered,
+//   -- isConfig bool; if the last batch is a config batch,
+//   -- err error; the error encountered, if any.
+func (c *Chain) ordered(msg *orderer.SubmitRequest) (batches [][]*common.Envelope, pending bool, isConfig bool, err error) {
+	seq := c.support.Sequence()
+
+	isConfig, err = isConfigEnvelope(msg.Payload)
+	if err != nil {
+		c.Metrics.ProposalFailures.Add(1)
+		return nil, false, false, errors.Errorf("bad message: %s", err)
+	}
+
+	if isConfig {
+		// ConfigMsg
+		if msg.LastValidationSeq < seq {
+			c.logger.Warnf("Config message was validated against %d, although current config seq has advanced (%d)", msg.LastValidationSeq, seq)
+			msg.Payload, _, err = c.support.ProcessConfigMsg(msg.Payload)
+			if err != nil {
+				c.Metrics.ProposalFailures.Add(1)
+				return nil, false, false, errors.Errorf("bad config message: %s", err)
+			}
+		}
+
+		batch := c.support.BlockCutter().Cut()
+		batches = [][]*common.Envelope{}
+		if len(batch) != 0 {
+			batches = append(batches, batch)
+		}
+		batches = append(batches, []*common.Envelope{msg.Payload})
+		return batches, false, true, nil
+	}
+	// it is a normal message
+	if msg.LastValidationSeq < seq {
+		c.logger.Warnf("Normal message was validated against %d, although current config seq has advanced (%d)", msg.LastValidationSeq, seq)
+		if _, err := c.support.ProcessNormalMsg(msg.Payload); err != nil {
+			c.Metrics.ProposalFailures.Add(1)
+			return nil, false, false, errors.Errorf("bad normal message: %s", err)
+		}
+	}
+	batches, pending = c.support.BlockCutter().Ordered(msg.Payload)
+	return batches, pending, false, nil
+}
+
+// proposeNext proposes the next block, if this node is the leader and no proposal is in flight
+func (c *Chain) proposeNext() {
+	if c.viewChanging || !c.isLeader() || c.proposal != nil {
+		return
+	}
+
+	var block *common.Block
+	switch {
+	case c.reproposal != nil:
+		block = &common.Block{
+			Header:   c.reproposal.Header,
+			Data:     c.reproposal.Data,
+			Metadata: newBlockMetadata(),
+		}
+		c.logger.Infof("Proposing again block [%d], which was prepared in a previous view", block.Header.Number)
+	case len(c.pendingBatches) > 0:
+		block = c.support.CreateNextBlock(c.pendingBatches[0])
+		c.pendingBatches = c.pendingBatches[1:]
+		c.logger.Debugf("Proposing block [%d] with %d transactions", block.Header.Number, len(block.Data.Data))
+	default:
+		return
+	}
+
+	if err := c.checkChaining(block); err != nil {
+		c.logger.Errorf("Failed to propose block: %s", err)
+		c.reproposal = nil
+		return
+	}
+
+	c.broadcast(&bft.ConsensusMessage{
+		Payload: &bft.ConsensusMessage_PrePrepare{
+			PrePrepare: &bft.PrePrepare{View: c.view, Seq: block.Header.Number, Block: block},
+		},
+	})
+	c.accept(block)
+}
+
+func (c *Chain) onMessage(sender uint64, msg *bft.ConsensusMessage) {
+	if _, exists := c.consenters[sender]; !exists {
+		c.logger.Warningf("Dropping message from node %d, which is not a consenter of the channel", sender)
+		return
+	}
+
+	switch payload := msg.Payload.(type) {
+	case *bft.ConsensusMessage_PrePrepare:
+		c.onPrePrepare(sender, payload.PrePrepare)
+	case *bft.ConsensusMessage_Prepare:
+		c.onPrepare(sender, payload.Prepare)
+	case *bft.ConsensusMessage_Commit:
+		c.onCommit(sender, payload.Commit)
+	case *bft.ConsensusMessage_ViewChange:
+		c.onViewChange(sender, payload.ViewChange)
+	case *bft.ConsensusMessage_NewView:
+		c.onNewView(sender, payload.NewView)
+	default:
+		c.logger.Warningf("Dropping message of unknown type %T from node %d", msg.Payload, sender)
+	}
+}
+
+func (c *Chain) onPrePrepare(sender uint64, pp *bft.PrePrepare) {
+	if !c.acceptsVote(sender, pp.View, pp.Seq) || pp.Seq != c.nextSeq() {
+		return
+	}
+
+	if sender != c.leaderOf(pp.View) {
+		c.logger.Warningf("Node %d sent a proposal for view %d, of which it is not the leader", sender, pp.View)
+		return
+	}
+
+	if c.proposal != nil {
+		c.logger.Debugf("Ignoring proposal from node %d, a proposal for sequence %d was already accepted in view %d", sender, pp.Seq, pp.View)
+		return
+	}
+
+	if err := c.validateProposal(pp.Block); err != nil {
+		c.logger.Warningf("Rejecting the proposal of block [%d] from the leader %d: %s", pp.Seq, sender, err)
+		c.Metrics.ProposalFailures.Add(1)
+		return
+	}
+
+	c.accept(pp.Block)
+}
+
+// accept accepts the block as the proposal of the next sequence in the current view and
+// sends a prepare to the other nodes
+func (c *Chain) accept(block *common.Block) {
+	c.proposal = &proposal{
+		view:       c.view,
+		block:      block,
+		digest:     protoutil.BlockHeaderHash(block.Header),
+		value:      c.blockSignatureValue(block, c.view),
+		acceptedAt: c.clock.Now(),
+	}
+
+	prepare := &bft.Prepare{View: c.view, Seq: block.Header.Number, Digest: c.proposal.digest}
+	rawPrepare := protoutil.MarshalOrPanic(prepare)
+	signature, err := c.support.Sign(rawPrepare)
+	if err != nil {
+		c.logger.Panicf("Failed to sign prepare: %s", err)
+	}
+	signed := &bft.SignedPrepare{Prepare: rawPrepare, Signer: c.selfID, Signature: signature}
+	c.addPrepare(c.selfID, prepare, signed)
+	c.broadcast(&bft.ConsensusMessage{Payload: &bft.ConsensusMessage_Prepare{Prepare: signed}})
+
+	c.checkProgress()
+}
+
+func (c *Chain) onPrepare(sender uint64, signed *bft.SignedPrepare) {
+	if signed.Signer != sender {
+		c.logger.Warningf("Node %d sent a prepare signed by node %d", sender, signed.Signer)
+		return
+	}
+
+	prepare, err := c.verifyPrepare(signed)
+	if err != nil {
+		c.logger.Warningf("Invalid prepare from node %d: %s", sender, err)
+		return
+	}
+
+	if !c.acceptsVote(sender, prepare.View, prepare.Seq) {
+		return
+	}
+
+	c.addPrepare(sender, prepare, signed)
+	c.checkProgress()
+}
+
+func (c *Chain) addPrepare(sender uint64, prepare *bft.Prepare, signed *bft.SignedPrepare) {
+	s := slot{view: prepare.View, seq: prepare.Seq}
+	if c.prepares[s] == nil {
+		c.prepares[s] = make(map[uint64]*prepareVote)
+	}
+	if _, exists := c.prepares[s][sender]; exists {
+		return
+	}
+	c.prepares[s][sender] = &prepareVote{prepare: prepare, signed: signed}
+}
+
+func (c *Chain) onCommit(sender uint64, commit *bft.Commit) {
+	if commit.Signature == nil {
+		c.logger.Warningf("Node %d sent a commit without a signature", sender)
+		return
+	}
+
+	if !c.acceptsVote(sender, commit.View, commit.Seq) {
+		return
+	}
+
+	c.addCommit(sender, commit, false)
+	c.checkProgress()
+}
+
+func (c *Chain) addCommit(sender uint64, commit *bft.Commit, verified bool) {
+	s := slot{view: commit.View, seq: commit.Seq}
+	if c.commits[s] == nil {
+		c.commits[s] = make(map[uint64]*commitVote)
+	}
+	if _, exists := c.commits[s][sender]; exists {
+		return
+	}
+	c.commits[s][sender] = &commitVote{commit: commit, verified: verified}
+}
+
+// acceptsVote returns whether the messages of the normal operation of the protocol, for the given view
+// and sequence, are processed. It keeps track of the nodes that are ahead of this node, and triggers
+// a view change or a synchronization with the other nodes if enough of them are ahead.
+func (c *Chain) acceptsVote(sender, view, seq uint64) bool {
+	if view > c.view {
+		c.observeHigherView(sender, view)
+		return false
+	}
+
+	if view < c.view {
+		return false
+	}
+
+	next := c.nextSeq()
+	if seq > next {
+		// a node that is changing view still catches up with the blocks decided in the current view
+		c.observeAhead(sender, seq)
+	}
+	if c.viewChanging || seq < c.nextSeq() {
+		return false
+	}
+	return seq <= c.nextSeq()+maxSeqsAhead
+}
+
+// checkProgress sends a commit once the proposal is prepared,
+// and decides the proposal once a quorum of nodes committed it
+func (c *Chain) checkProgress() {
+	p := c.proposal
+	if p == nil || c.viewChanging {
+		return
+	}
+
+	s := slot{view: p.view, seq: p.block.Header.Number}
+
+	if !p.committed {
+		var prepares []*bft.SignedPrepare
+		for _, vote := range c.prepares[s] {
+			if bytes.Equal(vote.prepare.Digest, p.digest) {
+				prepares = append(prepares, vote.signed)
+			}
+		}
+		if len(prepares) >= c.quorum() {
+			sort.Slice(prepares, func(i, j int) bool { return prepares[i].Signer < prepares[j].Signer })
+			c.prepared = &bft.Prepared{View: p.view, Block: p.block, Prepares: prepares[:c.quorum()]}
+			p.committed = true
+
+			commit := &bft.Commit{View: p.view, Seq: s.seq, Digest: p.digest, Signature: c.signBlock(p)}
+			c.addCommit(c.selfID, commit, true)
+			c.broadcast(&bft.ConsensusMessage{Payload: &bft.ConsensusMessage_Commit{Commit: commit}})
+		}
+	}
+
+	var signatures []*common.MetadataSignature
+	var signers []uint64
+	for sender, vote := range c.commits[s] {
+		if !vote.verified {
+			if err := c.verifyCommit(sender, vote.commit, p); err != nil {
+				c.logger.Warningf("Invalid commit from node %d: %s", sender, err)
+				delete(c.commits[s], sender)
+				continue
+			}
+			vote.verified = true
+		}
+		signers = append(signers, sender)
+	}
+	if len(signers) < c.quorum() {
+		return
+	}
+
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	for _, signer := range signers {
+		signatures = append(signatures, c.commits[s][signer].commit.Signature)
+	}
+	c.decide(p, signatures)
+}
+
+func (c *Chain) decide(p *proposal, signatures []*common.MetadataSignature) {
+	block := p.block
+	encodedMetadataValue := protoutil.MarshalOrPanic(&bft.BlockMetadata{View: p.view})
+	block.Metadata = newBlockMetadata()
+	block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = protoutil.MarshalOrPanic(&common.Metadata{Value: encodedMetadataValue})
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&common.Metadata{
+		Value:      p.value,
+		Signatures: signatures,
+	})
+
+	c.logger.Infof("Decided block [%d] in view %d with the signatures of %d nodes", block.Header.Number, p.view, len(signatures))
+	c.proposal = nil
+	c.writeBlock(block, encodedMetadataValue)
+	c.proposeNext()
+}
+
+// writeBlock writes a block that was decided by the nodes of the channel
+func (c *Chain) writeBlock(block *common.Block, encodedMetadataValue []byte) {
+	if protoutil.IsConfigBlock(block) {
+		c.support.WriteConfigBlock(block, encodedMetadataValue)
+	} else {
+		c.support.WriteBlock(block, encodedMetadataValue)
+	}
+
+	c.lastBlock = block
+	c.Metrics.CommittedBlockNumber.Set(float64(block.Header.Number))
+
+	if c.reproposal != nil && c.reproposal.Header.Number <= block.Header.Number {
+		c.reproposal = nil
+	}
+	if c.proposal != nil && c.proposal.block.Header.Number <= block.Header.Number {
+		c.proposal = nil
+	}
+	c.pruneVotes()
+	c.untrack(block)
+
+	if isReconfigBlock(block) {
+		c.lastConfigBlockNum = block.Header.Number
+		c.reconfigure()
+	}
+
+	if protoutil.IsConfigBlock(block) && c.configInflight {
+		c.configInflight = false
+		deferred := c.deferred
+		c.deferred = nil
+		for _, req := range deferred {
+			if !c.isLeader() || c.viewChanging {
+				break
+			}
+			c.order(req)
+		}
+	}
+}
+
+// reconfigure applies the config of the channel after a config block is written
+func (c *Chain) reconfigure() {
+	if consensusType := c.support.SharedConfig().ConsensusType(); consensusType != "BFT" {
+		c.logger.Infof("Consensus type of the channel changed to %s, the chain will be replaced after a restart", consensusType)
+		return
+	}
+
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(c.support.SharedConfig().ConsensusMetadata(), m); err != nil {
+		c.logger.Panicf("Failed to unmarshal the BFT metadata of the config: %s", err)
+	}
+	if err := bftconfig.CheckConfigMetadata(m); err != nil {
+		c.logger.Panicf("Invalid BFT metadata in the config: %s", err)
+	}
+
+	if requestTimeout, err := time.ParseDuration(m.Options.RequestTimeout); err == nil {
+		c.opts.RequestTimeout = requestTimeout
+	}
+	if viewChangeTimeout, err := time.ParseDuration(m.Options.ViewChangeTimeout); err == nil {
+		c.opts.ViewChangeTimeout = viewChangeTimeout
+	}
+
+	consenters := ConsentersToMap(m.Consenters)
+	if _, exists := consenters[c.selfID]; !exists {
+		c.logger.Warningf("This node was removed from the consenters of the channel, halting the chain")
+		c.stopped = true
+		return
+	}
+	if err := c.setConsenters(consenters); err != nil {
+		c.logger.Panicf("Failed to apply the consenters of the config: %s", err)
+	}
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure communication: %s", err)
+	}
+}
+
+func (c *Chain) setConsenters(consenters map[uint64]*bft.Consenter) error {
+	publicKeys := make(map[uint64]crypto.PublicKey, len(consenters))
+	for id, consenter := range consenters {
+		publicKey, err := bftconfig.ParseIdentity(consenter.Identity)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid identity of node %d", id)
+		}
+		publicKeys[id] = publicKey
+	}
+
+	c.consenters = consenters
+	c.publicKeys = publicKeys
+	c.nodes = sortedIDs(consenters)
+	c.Metrics.ClusterSize.Set(float64(len(c.nodes)))
+	return nil
+}
+
+func (c *Chain) configureComm() error {
+	nodes, err := remoteNodes(c.consenters, c.selfID)
+	if err != nil {
+		return err
+	}
+
+	c.configurator.Configure(c.channelID, nodes)
+	return nil
+}
+
+// validateProposal checks that the proposed block extends the chain, and that the transactions of the
+// block are valid, unless the block is re-proposed after a view change, in which case it must be the
+// block prepared by a quorum of nodes in a previous view
+func (c *Chain) validateProposal(block *common.Block) error {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("block is empty")
+	}
+
+	if err := c.checkChaining(block); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return errors.New("data hash does not match the data of the block")
+	}
+
+	if c.reproposal != nil && c.reproposal.Header.Number == block.Header.Number {
+		if !bytes.Equal(protoutil.BlockHeaderHash(block.Header), protoutil.BlockHeaderHash(c.reproposal.Header)) {
+			return errors.New("block differs from the block prepared in a previous view")
+		}
+		return nil
+	}
+
+	if len(block.Data.Data) == 0 {
+		return errors.New("block contains no transactions")
+	}
+
+	for i, data := range block.Data.Data {
+		env, err := protoutil.UnmarshalEnvelope(data)
+		if err != nil {
+			return errors.WithMessagef(err, "transaction %d is not an envelope", i)
+		}
+		isConfig, err := isConfigEnvelope(env)
+		if err != nil {
+			return errors.WithMessagef(err, "transaction %d is malformed", i)
+		}
+		if !isConfig {
+			if _, err := c.support.ProcessNormalMsg(env); err != nil {
+				return errors.WithMessagef(err, "transaction %d is invalid", i)
+			}
+			continue
+		}
+		if len(block.Data.Data) != 1 {
+			return errors.New("config transaction is not alone in its block")
+		}
+		if err := c.validateConfig(env); err != nil {
+			return errors.WithMessage(err, "config transaction is invalid")
+		}
+	}
+
+	return nil
+}
+
+// validateConfig re-processes the config update of a config transaction and checks
+// that the config of the transaction is the config that results from the update
+func (c *Chain) validateConfig(env *common.Envelope) error {
+	processed, _, err := c.support.ProcessConfigMsg(env)
+	if err != nil {
+		return err
+	}
+
+	proposed := &common.ConfigEnvelope{}
+	chdr, err := protoutil.UnmarshalEnvelopeOfType(env, common.HeaderType_CONFIG, proposed)
+	if err != nil {
+		// transactions that create channels carry the config of the new channel, which was validated
+		return nil
+	}
+
+	expected := &common.ConfigEnvelope{}
+	if _, err := protoutil.UnmarshalEnvelopeOfType(processed, common.HeaderType_CONFIG, expected); err != nil {
+		return err
+	}
+	if !proto.Equal(proposed.Config, expected.Config) {
+		return errors.Errorf("config of channel %s does not result from its config update", chdr.ChannelId)
+	}
+	return nil
+}
+
+// checkChaining checks that the block is the next block of the chain
+func (c *Chain) checkChaining(block *common.Block) error {
+	if block.Header.Number != c.nextSeq() {
+		return errors.Errorf("block number %d is not the next block number %d", block.Header.Number, c.nextSeq())
+	}
+	if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(c.lastBlock.Header)) {
+		return errors.Errorf("previous hash of block [%d] is not the hash of block [%d]", block.Header.Number, c.lastBlock.Header.Number)
+	}
+	return nil
+}
+
+// blockSignatureValue returns the value that the nodes sign to sign the block, as the block writer does,
+// which depends on the view in which the block is decided
+func (c *Chain) blockSignatureValue(block *common.Block, view uint64) []byte {
+	lastConfig := c.lastConfigBlockNum
+	if isReconfigBlock(block) {
+		lastConfig = block.Header.Number
+	}
+	return protoutil.MarshalOrPanic(&common.OrdererBlockMetadata{
+		LastConfig: &common.LastConfig{Index: lastConfig},
+		ConsenterMetadata: protoutil.MarshalOrPanic(&common.Metadata{
+			Value: protoutil.MarshalOrPanic(&bft.BlockMetadata{View: view}),
+		}),
+	})
+}
+
+func (c *Chain) signBlock(p *proposal) *common.MetadataSignature {
+	signature := &common.MetadataSignature{
+		SignatureHeader: protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(c.support)),
+	}
+	signature.Signature = protoutil.SignOrPanic(
+		c.support,
+		util.ConcatenateBytes(p.value, signature.SignatureHeader, protoutil.BlockHeaderBytes(p.block.Header)),
+	)
+	return signature
+}
+
+func (c *Chain) verifyPrepare(signed *bft.SignedPrepare) (*bft.Prepare, error) {
+	publicKey, exists := c.publicKeys[signed.Signer]
+	if !exists {
+		return nil, errors.Errorf("node %d is not a consenter", signed.Signer)
+	}
+	if err := verifySignature(publicKey, signed.Prepare, signed.Signature); err != nil {
+		return nil, err
+	}
+	prepare := &bft.Prepare{}
+	if err := proto.Unmarshal(signed.Prepare, prepare); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal prepare")
+	}
+	return prepare, nil
+}
+
+func (c *Chain) verifyCommit(sender uint64, commit *bft.Commit, p *proposal) error {
+	if !bytes.Equal(commit.Digest, p.digest) {
+		return errors.Errorf("commit is for another block [%d]", commit.Seq)
+	}
+
+	sigHdr, err := protoutil.GetSignatureHeader(commit.Signature.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sigHdr.Creator, SerializedIdentity(c.consenters[sender])) {
+		return errors.New("block signature is not created by the identity of the node")
+	}
+
+	return verifySignature(
+		c.publicKeys[sender],
+		util.ConcatenateBytes(p.value, commit.Signature.SignatureHeader, protoutil.BlockHeaderBytes(p.block.Header)),
+		commit.Signature.Signature,
+	)
+}
+
+// verifyBlockSignatures verifies that a block pulled from another node is signed by a quorum of consenters,
+// as a block decided by the nodes is, since a single faulty node could otherwise make the node write any block
+func (c *Chain) verifyBlockSignatures(block *common.Block) error {
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to read the signatures of block [%d]", block.Header.Number)
+	}
+
+	headerBytes := protoutil.BlockHeaderBytes(block.Header)
+	signers := make(map[uint64]struct{})
+	for _, signature := range md.Signatures {
+		sigHdr, err := protoutil.GetSignatureHeader(signature.SignatureHeader)
+		if err != nil {
+			continue
+		}
+		for id, consenter := range c.consenters {
+			if _, signed := signers[id]; signed || !bytes.Equal(sigHdr.Creator, SerializedIdentity(consenter)) {
+				continue
+			}
+			value := util.ConcatenateBytes(md.Value, signature.SignatureHeader, headerBytes)
+			if err := verifySignature(c.publicKeys[id], value, signature.Signature); err != nil {
+				c.logger.Warningf("Invalid signature of node %d on block [%d]: %s", id, block.Header.Number, err)
+				continue
+			}
+			signers[id] = struct{}{}
+		}
+	}
+
+	if len(signers) < c.quorum() {
+		return errors.Errorf("block [%d] has %d valid consenter signatures, but %d are required", block.Header.Number, len(signers), c.quorum())
+	}
+	return nil
+}
+
+func (c *Chain) broadcast(msg *bft.ConsensusMessage) {
+	payload := protoutil.MarshalOrPanic(msg)
+	for _, id := range c.nodes {
+		if id == c.selfID {
+			continue
+		}
+		if err := c.rpc.SendConsensus(id, &orderer.ConsensusRequest{Channel: c.channelID, Payload: payload}); err != nil {
+			c.logger.Debugf("Failed to send message to node %d: %s", id, err)
+		}
+	}
+}
+
+func (c *Chain) send(dest uint64, msg *bft.ConsensusMessage) {
+	payload := protoutil.MarshalOrPanic(msg)
+	if err := c.rpc.SendConsensus(dest, &orderer.ConsensusRequest{Channel: c.channelID, Payload: payload}); err != nil {
+		c.logger.Debugf("Failed to send message to node %d: %s", dest, err)
+	}
+}
+
+func (c *Chain) pruneVotes() {
+	next := c.nextSeq()
+	for s := range c.prepares {
+		if s.seq < next || s.view < c.view {
+			delete(c.prepares, s)
+		}
+	}
+	for s := range c.commits {
+		if s.seq < next || s.view < c.view {
+			delete(c.commits, s)
+		}
+	}
+	for sender, seq := range c.aheadSeqs {
+		if seq <= next {
+			delete(c.aheadSeqs, sender)
+		}
+	}
+}
+
+// observeAhead records that the sender is deciding a sequence beyond the next one. When enough nodes are
+// ahead for at least one of them to be correct, this node missed blocks and pulls them from the other nodes
+func (c *Chain) observeAhead(sender, seq uint64) {
+	if seq > c.aheadSeqs[sender] {
+		c.aheadSeqs[sender] = seq
+	}
+	if len(c.aheadSeqs) < c.maxFaulty()+1 {
+		return
+	}
+
+	var seqs []uint64
+	for _, s := range c.aheadSeqs {
+		seqs = append(seqs, s)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] > seqs[j] })
+	target := seqs[c.maxFaulty()] - 1
+	if target < c.nextSeq() {
+		return
+	}
+
+	c.logger.Infof("%d nodes are deciding blocks beyond block [%d], catching up to block [%d]", len(seqs), c.nextSeq(), target)
+	c.sync(target)
+}
+
+// sync pulls the blocks up to the target from the other nodes, and returns whether the target was reached
+func (c *Chain) sync(target uint64) bool {
+	puller, err := c.createPuller()
+	if err != nil {
+		c.logger.Errorf("Failed to create block puller: %s", err)
+		return false
+	}
+	defer puller.Close()
+
+	for next := c.nextSeq(); next <= target; next++ {
+		block := puller.PullBlock(next)
+		if block == nil {
+			c.logger.Warningf("Failed to fetch block [%d] from cluster", next)
+			return false
+		}
+		if err := c.checkChaining(block); err != nil {
+			c.logger.Warningf("Fetched an invalid block: %s", err)
+			return false
+		}
+		if err := c.verifyBlockSignatures(block); err != nil {
+			c.logger.Warningf("Fetched a block that was not decided by a quorum: %s", err)
+			return false
+		}
+		c.writeBlock(block, nil)
+		if c.stopped {
+			return false
+		}
+	}
+
+	c.logger.Infof("Finished syncing with cluster up to and including block [%d]", target)
+	c.checkProgress()
+	return true
+}
+
+// track keeps track of a request until it is decided, and returns whether the request was not tracked already.
+// A request forwarded by another node was not ordered in time by the leader, as far as the node knows.
+func (c *Chain) track(req *orderer.SubmitRequest, forwarded bool) bool {
+	key := requestKey(req.Payload)
+	if _, exists := c.requests[key]; exists {
+		return false
+	}
+	isConfig, _ := isConfigEnvelope(req.Payload)
+	c.requestCount++
+	c.requests[key] = &trackedRequest{
+		req:       req,
+		isConfig:  isConfig,
+		since:     c.clock.Now(),
+		order:     c.requestCount,
+		forwarded: forwarded,
+	}
+	return true
+}
+
+func (c *Chain) untrack(block *common.Block) {
+	for _, data := range block.Data.Data {
+		env, err := protoutil.UnmarshalEnvelope(data)
+		if err != nil {
+			continue
+		}
+		delete(c.requests, requestKey(env))
+	}
+
+	// The leader re-processes the config transactions validated against an older config,
+	// hence the config transactions tracked are either decided or invalidated by a new config
+	if isReconfigBlock(block) {
+		for key, r := range c.requests {
+			if r.isConfig {
+				delete(c.requests, key)
+			}
+		}
+	}
+}
+
+func (c *Chain) trackedRequests() []*trackedRequest {
+	requests := make([]*trackedRequest, 0, len(c.requests))
+	for _, r := range c.requests {
+		requests = append(requests, r)
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].order < requests[j].order })
+	return requests
+}
+
+func requestKey(env *common.Envelope) string {
+	return string(util.ComputeSHA256(protoutil.MarshalOrPanic(env)))
+}
+
+func (c *Chain) nextSeq() uint64 {
+	return c.lastBlock.Header.Number + 1
+}
+
+func (c *Chain) maxFaulty() int {
+	return MaxFaulty(len(c.nodes))
+}
+
+func (c *Chain) quorum() int {
+	return Quorum(len(c.nodes))
+}
+
+func (c *Chain) leaderOf(view uint64) uint64 {
+	return c.nodes[view%uint64(len(c.nodes))]
+}
+
+func (c *Chain) leader() uint64 {
+	return c.leaderOf(c.view)
+}
+
+func (c *Chain) isLeader() bool {
+	return c.leader() == c.selfID
+}
+
+func newBlockMetadata() *common.BlockMetadata {
+	return &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))}
+}
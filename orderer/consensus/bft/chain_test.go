@@ -0,0 +1,557 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/mocks"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	protosbft "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+const (
+	channelID         = "test-channel"
+	requestTimeout    = 10 * time.Second
+	viewChangeTimeout = 20 * time.Second
+)
+
+var _ = Describe("Chain", func() {
+	var (
+		network *network
+		clock   *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		clock = fakeclock.NewFakeClock(time.Now())
+		network = newNetwork(4, clock)
+		network.start()
+	})
+
+	AfterEach(func() {
+		network.stop()
+	})
+
+	// tick advances the time of the nodes, and is used to poll the nodes while they wait for timeouts
+	tick := func() {
+		clock.Increment(time.Second)
+	}
+
+	It("decides blocks signed by a quorum of nodes", func() {
+		err := network.nodes[2].chain.Order(envelope("tx1"), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, n := range network.nodes {
+			Eventually(n.height).Should(Equal(uint64(2)))
+		}
+
+		for _, n := range network.nodes {
+			block := n.block(1)
+			Expect(network.signers(block)).To(HaveLen(bft.Quorum(4)))
+			Expect(view(block)).To(Equal(uint64(0)))
+			Expect(protoutil.BlockHeaderHash(block.Header)).To(Equal(protoutil.BlockHeaderHash(network.nodes[1].block(1).Header)))
+		}
+	})
+
+	It("orders requests submitted to any node in sequence", func() {
+		for i := 1; i <= 3; i++ {
+			err := network.nodes[uint64(i)].chain.Order(envelope(fmt.Sprintf("tx%d", i)), 0)
+			Expect(err).NotTo(HaveOccurred())
+			for _, n := range network.nodes {
+				Eventually(n.height).Should(Equal(uint64(i + 1)))
+			}
+		}
+
+		for _, n := range network.nodes {
+			for i := uint64(1); i <= 3; i++ {
+				Expect(n.block(i).Header.PreviousHash).To(Equal(protoutil.BlockHeaderHash(n.block(i - 1).Header)))
+			}
+		}
+	})
+
+	It("tolerates a crashed follower", func() {
+		network.disconnect(4)
+
+		err := network.nodes[2].chain.Order(envelope("tx1"), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, id := range []uint64{1, 2, 3} {
+			Eventually(network.nodes[id].height).Should(Equal(uint64(2)))
+			Expect(network.signers(network.nodes[id].block(1))).To(ConsistOf(uint64(1), uint64(2), uint64(3)))
+		}
+		Consistently(network.nodes[4].height).Should(Equal(uint64(1)))
+	})
+
+	It("changes view when the leader crashes", func() {
+		network.disconnect(1)
+
+		// the request cannot be forwarded to the leader
+		network.nodes[3].chain.Order(envelope("tx1"), 0)
+
+		for _, id := range []uint64{2, 3, 4} {
+			Eventually(func() uint64 {
+				tick()
+				return network.nodes[id].height()
+			}).Should(Equal(uint64(2)))
+		}
+
+		for _, id := range []uint64{2, 3, 4} {
+			block := network.nodes[id].block(1)
+			Expect(view(block)).To(Equal(uint64(1)))
+			Expect(network.signers(block)).To(ConsistOf(uint64(2), uint64(3), uint64(4)))
+		}
+
+		By("ordering requests in the new view")
+		err := network.nodes[4].chain.Order(envelope("tx2"), 0)
+		Expect(err).NotTo(HaveOccurred())
+		for _, id := range []uint64{2, 3, 4} {
+			Eventually(network.nodes[id].height).Should(Equal(uint64(3)))
+			Expect(view(network.nodes[id].block(2))).To(Equal(uint64(1)))
+		}
+	})
+
+	It("catches up with the other nodes after missing blocks", func() {
+		network.disconnect(4)
+
+		for i := 1; i <= 3; i++ {
+			err := network.nodes[1].chain.Order(envelope(fmt.Sprintf("tx%d", i)), 0)
+			Expect(err).NotTo(HaveOccurred())
+			for _, id := range []uint64{1, 2, 3} {
+				Eventually(network.nodes[id].height).Should(Equal(uint64(i + 1)))
+			}
+		}
+		Expect(network.nodes[4].height()).To(Equal(uint64(1)))
+
+		network.connect(4)
+
+		err := network.nodes[1].chain.Order(envelope("tx4"), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(network.nodes[4].height).Should(BeNumerically(">=", uint64(4)))
+		for i := uint64(1); i <= 3; i++ {
+			Expect(network.nodes[4].block(i)).To(Equal(network.nodes[1].block(i)))
+		}
+	})
+
+	It("does not write pulled blocks that are not signed by a quorum", func() {
+		network.disconnect(4)
+
+		for i := 1; i <= 3; i++ {
+			err := network.nodes[1].chain.Order(envelope(fmt.Sprintf("tx%d", i)), 0)
+			Expect(err).NotTo(HaveOccurred())
+			for _, id := range []uint64{1, 2, 3} {
+				Eventually(network.nodes[id].height).Should(Equal(uint64(i + 1)))
+			}
+		}
+
+		// a faulty node serves blocks with only its own signature
+		network.tamper = func(block *common.Block) *common.Block {
+			md := &common.Metadata{}
+			Expect(proto.Unmarshal(block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES], md)).To(Succeed())
+			md.Signatures = md.Signatures[:1]
+			block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(md)
+			return block
+		}
+		network.connect(4)
+
+		err := network.nodes[1].chain.Order(envelope("tx4"), 0)
+		Expect(err).NotTo(HaveOccurred())
+		for _, id := range []uint64{1, 2, 3} {
+			Eventually(network.nodes[id].height).Should(Equal(uint64(5)))
+		}
+		Consistently(network.nodes[4].height).Should(Equal(uint64(1)))
+	})
+
+	It("drops consensus messages from nodes that are not consenters", func() {
+		payload := protoutil.MarshalOrPanic(&protosbft.ConsensusMessage{
+			Payload: &protosbft.ConsensusMessage_PrePrepare{
+				PrePrepare: &protosbft.PrePrepare{View: 0, Seq: 1, Block: network.nodes[1].block(0)},
+			},
+		})
+		err := network.nodes[2].chain.Consensus(&orderer.ConsensusRequest{Channel: channelID, Payload: payload}, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(network.nodes[2].support.Invocations).ShouldNot(HaveKey("ProcessNormalMsg"))
+	})
+
+	It("stops when halted", func() {
+		n := network.nodes[1]
+		n.chain.Halt()
+		Eventually(n.chain.Errored()).Should(BeClosed())
+		Expect(n.chain.Order(envelope("tx1"), 0)).To(MatchError("chain is stopped"))
+		Expect(n.chain.WaitReady()).To(MatchError("chain is stopped"))
+	})
+})
+
+type node struct {
+	id         uint64
+	consenter  *protosbft.Consenter
+	key        *ecdsa.PrivateKey
+	chain      *bft.Chain
+	support    *consensusmocks.FakeConsenterSupport
+	rpc        *mocks.RPC
+	configurer *mocks.Configurator
+	inbox      *inbox
+
+	lock   sync.Mutex
+	ledger []*common.Block
+}
+
+func (n *node) height() uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return uint64(len(n.ledger))
+}
+
+func (n *node) block(number uint64) *common.Block {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if number >= uint64(len(n.ledger)) {
+		return nil
+	}
+	return proto.Clone(n.ledger[number]).(*common.Block)
+}
+
+func (n *node) write(block *common.Block, encodedMetadataValue []byte) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if encodedMetadataValue != nil {
+		block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = protoutil.MarshalOrPanic(&common.Metadata{Value: encodedMetadataValue})
+	}
+	n.ledger = append(n.ledger, proto.Clone(block).(*common.Block))
+}
+
+func (n *node) sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, n.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return utils.MarshalECDSASignature(r, s)
+}
+
+type network struct {
+	nodes map[uint64]*node
+	// tamper, when set, alters the blocks pulled from the ledgers of the nodes
+	tamper func(*common.Block) *common.Block
+
+	lock         sync.RWMutex
+	disconnected map[uint64]bool
+}
+
+func newNetwork(size int, clock *fakeclock.FakeClock) *network {
+	ca, err := tlsgen.NewCA()
+	Expect(err).NotTo(HaveOccurred())
+
+	genesis := protoutil.NewBlock(0, nil)
+	genesis.Header.DataHash = protoutil.BlockDataHash(genesis.Data)
+
+	net := &network{
+		nodes:        make(map[uint64]*node),
+		disconnected: make(map[uint64]bool),
+	}
+
+	var consenters []*protosbft.Consenter
+	for id := uint64(1); id <= uint64(size); id++ {
+		identity, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+		serverCert, err := ca.NewServerCertKeyPair("127.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		clientCert, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+
+		bl, _ := pem.Decode(identity.Key)
+		key, err := x509.ParsePKCS8PrivateKey(bl.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+
+		consenter := &protosbft.Consenter{
+			Id:            id,
+			Host:          "127.0.0.1",
+			Port:          uint32(7050 + id),
+			MspId:         "OrdererMSP",
+			Identity:      identity.Cert,
+			ServerTlsCert: serverCert.Cert,
+			ClientTlsCert: clientCert.Cert,
+		}
+		consenters = append(consenters, consenter)
+		net.nodes[id] = &node{
+			id:        id,
+			consenter: consenter,
+			key:       key.(*ecdsa.PrivateKey),
+			ledger:    []*common.Block{proto.Clone(genesis).(*common.Block)},
+			inbox:     newInbox(),
+		}
+	}
+	Expect(bftconfig.CheckConfigMetadata(&protosbft.ConfigMetadata{
+		Consenters: consenters,
+		Options:    &protosbft.Options{RequestTimeout: requestTimeout.String(), ViewChangeTimeout: viewChangeTimeout.String()},
+	})).To(Succeed())
+
+	for _, n := range net.nodes {
+		net.newChain(n, consenters, clock)
+	}
+
+	return net
+}
+
+func (net *network) newChain(n *node, consenters []*protosbft.Consenter, clock *fakeclock.FakeClock) {
+	cutter := mockblockcutter.NewReceiver()
+	cutter.CutNext = true
+	close(cutter.Block)
+
+	support := &consensusmocks.FakeConsenterSupport{}
+	support.ChainIDReturns(channelID)
+	support.SharedConfigReturns(&mockconfig.Orderer{BatchTimeoutVal: time.Second, ConsensusTypeVal: "BFT"})
+	support.BlockCutterReturns(cutter)
+	support.HeightStub = n.height
+	support.BlockStub = n.block
+	support.SignStub = n.sign
+	support.SerializeReturns(bft.SerializedIdentity(n.consenter), nil)
+	support.CreateNextBlockStub = func(envs []*common.Envelope) *common.Block {
+		data := &common.BlockData{}
+		for _, env := range envs {
+			data.Data = append(data.Data, protoutil.MarshalOrPanic(env))
+		}
+		last := n.block(n.height() - 1)
+		block := protoutil.NewBlock(last.Header.Number+1, protoutil.BlockHeaderHash(last.Header))
+		block.Header.DataHash = protoutil.BlockDataHash(data)
+		block.Data = data
+		return block
+	}
+	support.WriteBlockStub = n.write
+	support.WriteConfigBlockStub = n.write
+	n.support = support
+
+	rpc := &mocks.RPC{}
+	rpc.SendConsensusStub = func(dest uint64, msg *orderer.ConsensusRequest) error {
+		if !net.connected(n.id, dest) {
+			return errors.Errorf("node %d is unreachable", dest)
+		}
+		net.nodes[dest].inbox.put(func() { net.nodes[dest].chain.Consensus(msg, n.id) })
+		return nil
+	}
+	rpc.SendSubmitStub = func(dest uint64, req *orderer.SubmitRequest) error {
+		if !net.connected(n.id, dest) {
+			return errors.Errorf("node %d is unreachable", dest)
+		}
+		net.nodes[dest].inbox.put(func() { net.nodes[dest].chain.Submit(req, n.id) })
+		return nil
+	}
+	n.rpc = rpc
+	n.configurer = &mocks.Configurator{}
+
+	puller := func() (etcdraft.BlockPuller, error) {
+		return &blockPuller{net: net, self: n.id}, nil
+	}
+
+	chain, err := bft.NewChain(
+		support,
+		bft.Options{
+			SelfID:            n.id,
+			Consenters:        bft.ConsentersToMap(consenters),
+			RequestTimeout:    requestTimeout,
+			ViewChangeTimeout: viewChangeTimeout,
+			Clock:             clock,
+			Logger:            flogging.MustGetLogger("orderer.consensus.bft.test"),
+			Metrics:           bft.NewMetrics(&disabled.Provider{}),
+		},
+		n.configurer,
+		rpc,
+		puller,
+	)
+	Expect(err).NotTo(HaveOccurred())
+	n.chain = chain
+}
+
+func (net *network) start() {
+	for _, n := range net.nodes {
+		n.chain.Start()
+		Expect(n.configurer.ConfigureCallCount()).To(Equal(1))
+		channel, remotes := n.configurer.ConfigureArgsForCall(0)
+		Expect(channel).To(Equal(channelID))
+		Expect(remotes).To(HaveLen(len(net.nodes) - 1))
+	}
+	for _, n := range net.nodes {
+		Expect(n.chain.WaitReady()).To(Succeed())
+	}
+}
+
+func (net *network) stop() {
+	for _, n := range net.nodes {
+		n.chain.Halt()
+		n.inbox.close()
+	}
+}
+
+func (net *network) connected(from, to uint64) bool {
+	net.lock.RLock()
+	defer net.lock.RUnlock()
+	return !net.disconnected[from] && !net.disconnected[to]
+}
+
+func (net *network) disconnect(id uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.disconnected[id] = true
+}
+
+func (net *network) connect(id uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	delete(net.disconnected, id)
+}
+
+// signers returns the nodes that signed the block, after verifying their signatures
+func (net *network) signers(block *common.Block) []uint64 {
+	md := &common.Metadata{}
+	Expect(proto.Unmarshal(block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES], md)).To(Succeed())
+
+	var signers []uint64
+	for _, signature := range md.Signatures {
+		sigHdr, err := protoutil.GetSignatureHeader(signature.SignatureHeader)
+		Expect(err).NotTo(HaveOccurred())
+
+		var signer *node
+		for _, n := range net.nodes {
+			if proto.Equal(sigHdr, &common.SignatureHeader{Creator: bft.SerializedIdentity(n.consenter), Nonce: sigHdr.Nonce}) {
+				signer = n
+			}
+		}
+		Expect(signer).NotTo(BeNil())
+
+		r, s, err := utils.UnmarshalECDSASignature(signature.Signature)
+		Expect(err).NotTo(HaveOccurred())
+		digest := sha256.Sum256(util.ConcatenateBytes(md.Value, signature.SignatureHeader, protoutil.BlockHeaderBytes(block.Header)))
+		Expect(ecdsa.Verify(&signer.key.PublicKey, digest[:], r, s)).To(BeTrue())
+		signers = append(signers, signer.id)
+	}
+	return signers
+}
+
+// view returns the view in which the block was decided
+func view(block *common.Block) uint64 {
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+	Expect(err).NotTo(HaveOccurred())
+	blockMetadata, err := bft.ReadBlockMetadata(md)
+	Expect(err).NotTo(HaveOccurred())
+	return blockMetadata.View
+}
+
+func envelope(data string) *common.Envelope {
+	return &common.Envelope{
+		Payload: protoutil.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: channelID,
+				}),
+			},
+			Data: []byte(data),
+		}),
+	}
+}
+
+// blockPuller pulls blocks from the ledgers of the other nodes of the network
+type blockPuller struct {
+	net  *network
+	self uint64
+}
+
+func (bp *blockPuller) PullBlock(seq uint64) *common.Block {
+	for id, n := range bp.net.nodes {
+		if id == bp.self || !bp.net.connected(bp.self, id) {
+			continue
+		}
+		if block := n.block(seq); block != nil {
+			if bp.net.tamper != nil {
+				return bp.net.tamper(block)
+			}
+			return block
+		}
+	}
+	return nil
+}
+
+func (bp *blockPuller) HeightsByEndpoints() (map[string]uint64, error) {
+	heights := make(map[string]uint64)
+	for _, n := range bp.net.nodes {
+		heights[fmt.Sprintf("%s:%d", n.consenter.Host, n.consenter.Port)] = n.height()
+	}
+	return heights, nil
+}
+
+func (bp *blockPuller) Close() {}
+
+// inbox delivers the messages sent to a node in the order they were sent,
+// as the streams of the cluster communication do
+type inbox struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	messages []func()
+	closed   bool
+}
+
+func newInbox() *inbox {
+	in := &inbox{}
+	in.cond = sync.NewCond(&in.lock)
+	go in.deliver()
+	return in
+}
+
+func (in *inbox) put(deliver func()) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	in.messages = append(in.messages, deliver)
+	in.cond.Signal()
+}
+
+func (in *inbox) close() {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	in.closed = true
+	in.cond.Signal()
+}
+
+func (in *inbox) deliver() {
+	for {
+		in.lock.Lock()
+		for len(in.messages) == 0 && !in.closed {
+			in.cond.Wait()
+		}
+		if in.closed {
+			in.lock.Unlock()
+			return
+		}
+		deliver := in.messages[0]
+		in.messages = in.messages[1:]
+		in.lock.Unlock()
+		deliver()
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/pkg/errors"
+)
+
+// Consenter implements the BFT consenter. The chains of the consenter communicate over the
+// cluster communication of the etcdraft consenter, which dispatches the messages of a channel
+// to the chain of the channel, whatever its type.
+type Consenter struct {
+	CreateChain           func(chainName string)
+	InactiveChainRegistry etcdraft.InactiveChainRegistry
+	Dialer                *cluster.PredicateDialer
+	Communication         cluster.Communicator
+	Logger                *flogging.FabricLogger
+	OrdererConfig         localconfig.TopLevel
+	Cert                  []byte
+	Metrics               *Metrics
+}
+
+func (c *Consenter) detectSelfID(consenters map[uint64]*bft.Consenter) (uint64, error) {
+	var serverCertificates []string
+	for nodeID, cst := range consenters {
+		serverCertificates = append(serverCertificates, string(cst.ServerTlsCert))
+		if bytes.Equal(c.Cert, cst.ServerTlsCert) {
+			return nodeID, nil
+		}
+	}
+
+	c.Logger.Warning("Could not find", string(c.Cert), "among", serverCertificates)
+	return 0, cluster.ErrNotInChannel
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(support.SharedConfig().ConsensusMetadata(), m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+
+	if err := bftconfig.CheckConfigMetadata(m); err != nil {
+		return nil, errors.WithMessage(err, "invalid BFT consensus metadata")
+	}
+
+	// The view in which the last block was decided is restored from the block metadata. After a
+	// consensus-type migration, the metadata of the last block is empty and the chain starts in view 0.
+	blockMetadata, err := ReadBlockMetadata(metadata)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read BFT metadata")
+	}
+
+	if (metadata == nil || len(metadata.Value) == 0) && support.Height() > 1 {
+		c.Logger.Infof("Block metadata is nil at block height=%d, it is consensus-type migration", support.Height())
+	}
+
+	consenters := ConsentersToMap(m.Consenters)
+
+	id, err := c.detectSelfID(consenters)
+	if err != nil {
+		if c.InactiveChainRegistry != nil {
+			c.InactiveChainRegistry.TrackChain(support.ChainID(), support.Block(0), func() {
+				c.CreateChain(support.ChainID())
+			})
+		}
+		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChainID())}, nil
+	}
+
+	// the durations were validated by CheckConfigMetadata
+	requestTimeout, _ := time.ParseDuration(m.Options.RequestTimeout)
+	viewChangeTimeout, _ := time.ParseDuration(m.Options.ViewChangeTimeout)
+
+	opts := Options{
+		SelfID:            id,
+		Consenters:        consenters,
+		View:              blockMetadata.View,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+		Logger:            c.Logger,
+		Metrics:           c.Metrics,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChainID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+	return NewChain(
+		support,
+		opts,
+		c.Communication,
+		rpc,
+		func() (etcdraft.BlockPuller, error) {
+			return newBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster)
+		},
+	)
+}
+
+// ValidateConsensusMetadata determines the validity of a
+// ConsensusMetadata update during config updates on the channel.
+func (c *Consenter) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes []byte, newChannel bool) error {
+	// metadata was not updated
+	if newMetadataBytes == nil {
+		return nil
+	}
+	if oldMetadataBytes == nil {
+		c.Logger.Panic("Programming Error: ValidateConsensusMetadata called with nil old metadata")
+	}
+
+	oldMetadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(oldMetadataBytes, oldMetadata); err != nil {
+		c.Logger.Panicf("Programming Error: Failed to unmarshal old BFT consensus metadata: %v", err)
+	}
+	newMetadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(newMetadataBytes, newMetadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal new BFT metadata configuration")
+	}
+
+	if err := bftconfig.CheckConfigMetadata(newMetadata); err != nil {
+		return errors.WithMessage(err, "invalid new config metadata")
+	}
+
+	if newChannel {
+		// check if the consenters are a subset of the existing consenters (system channel consenters)
+		set := make(map[string]struct{})
+		for _, consenter := range oldMetadata.Consenters {
+			set[string(consenter.ClientTlsCert)] = struct{}{}
+		}
+		for _, consenter := range newMetadata.Consenters {
+			if _, exists := set[string(consenter.ClientTlsCert)]; !exists {
+				return errors.New("new channel has consenter that is not part of system consenter set")
+			}
+		}
+	}
+
+	return nil
+}
+
+// New creates a BFT Consenter, which communicates over the given cluster communication
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	conf *localconfig.TopLevel,
+	srvConf comm.ServerConfig,
+	r *multichannel.Registrar,
+	icr etcdraft.InactiveChainRegistry,
+	communication cluster.Communicator,
+	metricsProvider metrics.Provider,
+) *Consenter {
+	return &Consenter{
+		CreateChain:           r.CreateChain,
+		InactiveChainRegistry: icr,
+		Dialer:                clusterDialer,
+		Communication:         communication,
+		Logger:                flogging.MustGetLogger("orderer.consensus.bft"),
+		OrdererConfig:         *conf,
+		Cert:                  srvConf.SecOpts.Certificate,
+		Metrics:               NewMetrics(metricsProvider),
+	}
+}
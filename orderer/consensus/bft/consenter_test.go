@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	clustermocks "github.com/hyperledger/fabric/orderer/common/cluster/mocks"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	etcdraftmocks "github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	protosbft "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Consenter", func() {
+	var (
+		ca         tlsgen.CA
+		metadata   *protosbft.ConfigMetadata
+		support    *consensusmocks.FakeConsenterSupport
+		icr        *etcdraftmocks.InactiveChainRegistry
+		consenter  *bft.Consenter
+		newCreated []string
+	)
+
+	newConsenter := func(id uint64) *protosbft.Consenter {
+		identity, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+		serverCert, err := ca.NewServerCertKeyPair("127.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		clientCert, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+		return &protosbft.Consenter{
+			Id:            id,
+			Host:          "127.0.0.1",
+			Port:          uint32(7050 + id),
+			MspId:         "OrdererMSP",
+			Identity:      identity.Cert,
+			ServerTlsCert: serverCert.Cert,
+			ClientTlsCert: clientCert.Cert,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		ca, err = tlsgen.NewCA()
+		Expect(err).NotTo(HaveOccurred())
+
+		metadata = &protosbft.ConfigMetadata{
+			Options: &protosbft.Options{RequestTimeout: "10s", ViewChangeTimeout: "20s"},
+		}
+		for id := uint64(1); id <= 4; id++ {
+			metadata.Consenters = append(metadata.Consenters, newConsenter(id))
+		}
+
+		genesis := protoutil.NewBlock(0, nil)
+		support = &consensusmocks.FakeConsenterSupport{}
+		support.ChainIDReturns("mychannel")
+		support.HeightReturns(1)
+		support.BlockReturns(genesis)
+		support.SharedConfigReturns(&mockconfig.Orderer{
+			BatchTimeoutVal:      time.Second,
+			ConsensusTypeVal:     "BFT",
+			ConsensusMetadataVal: protoutil.MarshalOrPanic(metadata),
+		})
+
+		newCreated = nil
+		icr = &etcdraftmocks.InactiveChainRegistry{}
+		consenter = &bft.Consenter{
+			CreateChain:           func(chainName string) { newCreated = append(newCreated, chainName) },
+			InactiveChainRegistry: icr,
+			Communication:         &clustermocks.Communicator{},
+			Logger:                flogging.MustGetLogger("orderer.consensus.bft.test"),
+			Metrics:               bft.NewMetrics(&disabled.Provider{}),
+		}
+	})
+
+	Describe("HandleChain", func() {
+		It("creates a chain for a channel the node is a consenter of", func() {
+			consenter.Cert = metadata.Consenters[2].ServerTlsCert
+
+			chain, err := consenter.HandleChain(support, &common.Metadata{Value: protoutil.MarshalOrPanic(&protosbft.BlockMetadata{View: 3})})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chain).To(BeAssignableToTypeOf(&bft.Chain{}))
+			Expect(icr.Calls).To(BeEmpty())
+		})
+
+		It("returns an inactive chain for a channel the node is not a consenter of", func() {
+			consenter.Cert = newConsenter(5).ServerTlsCert
+			icr.On("TrackChain", "mychannel", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(2).(etcdraft.CreateChainCallback)()
+			})
+
+			chain, err := consenter.HandleChain(support, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chain).To(BeAssignableToTypeOf(&inactive.Chain{}))
+			Expect(chain.Order(nil, 0)).To(MatchError("channel mychannel is not serviced by me"))
+
+			icr.AssertNumberOfCalls(GinkgoT(), "TrackChain", 1)
+			Expect(newCreated).To(Equal([]string{"mychannel"}))
+		})
+
+		It("fails when the consensus metadata is invalid", func() {
+			metadata.Options = nil
+			support.SharedConfigReturns(&mockconfig.Orderer{
+				ConsensusTypeVal:     "BFT",
+				ConsensusMetadataVal: protoutil.MarshalOrPanic(metadata),
+			})
+
+			_, err := consenter.HandleChain(support, nil)
+			Expect(err).To(MatchError("invalid BFT consensus metadata: BFT options have not been provided"))
+		})
+	})
+
+	Describe("ValidateConsensusMetadata", func() {
+		var oldMetadata []byte
+
+		BeforeEach(func() {
+			oldMetadata = protoutil.MarshalOrPanic(metadata)
+		})
+
+		It("accepts a valid update of the consenters", func() {
+			newMetadata := proto.Clone(metadata).(*protosbft.ConfigMetadata)
+			newMetadata.Consenters = append(newMetadata.Consenters, newConsenter(5))
+			Expect(consenter.ValidateConsensusMetadata(oldMetadata, protoutil.MarshalOrPanic(newMetadata), false)).To(Succeed())
+		})
+
+		It("accepts an unchanged metadata", func() {
+			Expect(consenter.ValidateConsensusMetadata(oldMetadata, nil, false)).To(Succeed())
+		})
+
+		It("rejects an invalid metadata", func() {
+			newMetadata := proto.Clone(metadata).(*protosbft.ConfigMetadata)
+			newMetadata.Options.RequestTimeout = "0s"
+			err := consenter.ValidateConsensusMetadata(oldMetadata, protoutil.MarshalOrPanic(newMetadata), false)
+			Expect(err).To(MatchError("invalid new config metadata: RequestTimeout must be positive"))
+		})
+
+		It("rejects a new channel with consenters outside of the system channel", func() {
+			newMetadata := proto.Clone(metadata).(*protosbft.ConfigMetadata)
+			newMetadata.Consenters = append(newMetadata.Consenters[1:], newConsenter(5))
+			err := consenter.ValidateConsensusMetadata(oldMetadata, protoutil.MarshalOrPanic(newMetadata), true)
+			Expect(err).To(MatchError("new channel has consenter that is not part of system consenter set"))
+
+			newMetadata.Consenters = metadata.Consenters[1:]
+			Expect(consenter.ValidateConsensusMetadata(oldMetadata, protoutil.MarshalOrPanic(newMetadata), true)).To(Succeed())
+		})
+	})
+
+	Describe("CheckConfigMetadata", func() {
+		It("accepts a valid metadata", func() {
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(Succeed())
+		})
+
+		It("rejects an empty consenter set", func() {
+			metadata.Consenters = nil
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(MatchError("empty consenter set"))
+		})
+
+		It("rejects duplicate consenter ids", func() {
+			metadata.Consenters[1].Id = 1
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(MatchError("duplicate consenter id 1"))
+		})
+
+		It("rejects duplicate TLS certificates", func() {
+			metadata.Consenters[1].ClientTlsCert = metadata.Consenters[0].ClientTlsCert
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(MatchError("consenter 2 has a duplicate client TLS certificate"))
+		})
+
+		It("rejects an identity that is not a certificate", func() {
+			metadata.Consenters[0].Identity = []byte("not a certificate")
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(MatchError(ContainSubstring("consenter 1 has an invalid identity: certificate is not PEM encoded")))
+		})
+
+		It("rejects an invalid timeout", func() {
+			metadata.Options.ViewChangeTimeout = "soon"
+			Expect(bftconfig.CheckConfigMetadata(metadata)).To(MatchError(ContainSubstring("failed to parse ViewChangeTimeout (soon) to time duration")))
+		})
+	})
+
+	It("computes the quorum of a cluster", func() {
+		for n, q := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 4, 6: 4, 7: 5, 10: 7} {
+			Expect(bft.Quorum(n)).To(Equal(q), "cluster of %d nodes", n)
+		}
+		Expect(bft.MaxFaulty(4)).To(Equal(1))
+		Expect(bft.MaxFaulty(7)).To(Equal(2))
+	})
+})
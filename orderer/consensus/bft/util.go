@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// MaxFaulty returns the number of faulty nodes that a cluster of n nodes tolerates
+func MaxFaulty(n int) int {
+	return (n - 1) / 3
+}
+
+// Quorum returns the number of nodes, out of a cluster of n nodes, that need to agree
+// for a decision to be made. Any two quorums intersect in at least one correct node.
+func Quorum(n int) int {
+	f := MaxFaulty(n)
+	return (n + f + 2) / 2
+}
+
+// ReadBlockMetadata reads the BFT metadata of the last block, if available
+func ReadBlockMetadata(blockMetadata *common.Metadata) (*bft.BlockMetadata, error) {
+	m := &bft.BlockMetadata{}
+	if blockMetadata == nil || len(blockMetadata.Value) == 0 {
+		return m, nil
+	}
+	if err := proto.Unmarshal(blockMetadata.Value, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block's metadata")
+	}
+	return m, nil
+}
+
+// ConsentersToMap maps the consenters by their ids
+func ConsentersToMap(consenters []*bft.Consenter) map[uint64]*bft.Consenter {
+	m := make(map[uint64]*bft.Consenter, len(consenters))
+	for _, consenter := range consenters {
+		m[consenter.Id] = consenter
+	}
+	return m
+}
+
+// SerializedIdentity returns the serialized signing identity of the consenter,
+// as it appears in the signature headers created by the consenter
+func SerializedIdentity(consenter *bft.Consenter) []byte {
+	return protoutil.MarshalOrPanic(&msp.SerializedIdentity{
+		Mspid:   consenter.MspId,
+		IdBytes: consenter.Identity,
+	})
+}
+
+// verifySignature verifies an ECDSA or Ed25519 signature created by a fabric
+// signing identity, which signs the SHA256 hash of the message with ECDSA and
+// the message itself with Ed25519
+func verifySignature(publicKey crypto.PublicKey, msg, signature []byte) error {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		r, s, err := utils.UnmarshalECDSASignature(signature)
+		if err != nil {
+			return errors.WithMessage(err, "malformed signature")
+		}
+		digest := sha256.Sum256(msg)
+		if !ecdsa.Verify(publicKey, digest[:], r, s) {
+			return errors.New("signature is invalid")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, msg, signature) {
+			return errors.New("signature is invalid")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+// remoteNodes returns the cluster members of the consenters, except the given node
+func remoteNodes(consenters map[uint64]*bft.Consenter, self uint64) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for id, consenter := range consenters {
+		// No need to know yourself
+		if id == self {
+			continue
+		}
+		serverCertAsDER, err := bftconfig.PEMToDER(consenter.ServerTlsCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid server TLS certificate of node %d", id)
+		}
+		clientCertAsDER, err := bftconfig.PEMToDER(consenter.ClientTlsCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid client TLS certificate of node %d", id)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            id,
+			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			ServerTLSCert: serverCertAsDER,
+			ClientTLSCert: clientCertAsDER,
+		})
+	}
+	return nodes, nil
+}
+
+func sortedIDs(consenters map[uint64]*bft.Consenter) []uint64 {
+	ids := make([]uint64, 0, len(consenters))
+	for id := range consenters {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func isConfigEnvelope(env *common.Envelope) (bool, error) {
+	h, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return false, err
+	}
+	return h.Type == int32(common.HeaderType_CONFIG) || h.Type == int32(common.HeaderType_ORDERER_TRANSACTION), nil
+}
+
+// isReconfigBlock returns whether the block updates the config of the channel,
+// which makes the block the last config block of the channel
+func isReconfigBlock(block *common.Block) bool {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return false
+	}
+	h, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return false
+	}
+	return h.Type == int32(common.HeaderType_CONFIG)
+}
+
+func newBlockPuller(support consensus.ConsenterSupport,
+	baseDialer *cluster.PredicateDialer,
+	clusterConfig localconfig.Cluster) (etcdraft.BlockPuller, error) {
+
+	verifyBlockSequence := func(blocks []*common.Block, _ string) error {
+		return cluster.VerifyBlocks(blocks, support)
+	}
+
+	stdDialer := &cluster.StandardDialer{
+		Config: baseDialer.Config.Clone(),
+	}
+	stdDialer.Config.AsyncConnect = false
+	stdDialer.Config.SecOpts.VerifyCertificate = nil
+
+	// Extract the TLS CA certs and endpoints from the configuration,
+	endpoints, err := etcdraft.EndpointconfigFromFromSupport(support)
+	if err != nil {
+		return nil, err
+	}
+
+	der, _ := pem.Decode(stdDialer.Config.SecOpts.Certificate)
+	if der == nil {
+		return nil, errors.Errorf("client certificate isn't in PEM format: %v",
+			string(stdDialer.Config.SecOpts.Certificate))
+	}
+
+	bp := &cluster.BlockPuller{
+		VerifyBlockSequence: verifyBlockSequence,
+		Logger:              flogging.MustGetLogger("orderer.common.cluster.puller"),
+		RetryTimeout:        clusterConfig.ReplicationRetryTimeout,
+		MaxTotalBufferBytes: clusterConfig.ReplicationBufferSize,
+		FetchTimeout:        clusterConfig.ReplicationPullTimeout,
+		Endpoints:           endpoints,
+		Signer:              support,
+		TLSCert:             der.Bytes,
+		Channel:             support.ChainID(),
+		Dialer:              stdDialer,
+	}
+
+	return &etcdraft.LedgerBlockPuller{
+		Height:         support.Height,
+		BlockRetriever: support,
+		BlockPuller:    bp,
+	}, nil
+}
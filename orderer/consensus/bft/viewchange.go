@@ -0,0 +1,386 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// checkTimeouts starts a view change when the leader does not make progress in time,
+// and moves to the next view when a view change does not complete in time
+func (c *Chain) checkTimeouts(now time.Time) {
+	if c.viewChanging {
+		if now.Sub(c.viewChangeStart) >= c.opts.ViewChangeTimeout {
+			c.logger.Warningf("View change to view %d did not complete in %s", c.nextView, c.opts.ViewChangeTimeout)
+			c.startViewChange(c.nextView + 1)
+		}
+		return
+	}
+
+	if c.isLeader() {
+		return
+	}
+
+	if c.proposal != nil && now.Sub(c.proposal.acceptedAt) >= c.opts.RequestTimeout {
+		c.logger.Warningf("Block [%d] proposed by the leader %d was not decided in %s", c.proposal.block.Header.Number, c.leader(), c.opts.RequestTimeout)
+		c.startViewChange(c.view + 1)
+		return
+	}
+
+	for _, r := range c.trackedRequests() {
+		if now.Sub(r.since) < c.opts.RequestTimeout {
+			continue
+		}
+		if !r.forwarded {
+			// The other nodes might not know about the request, which the leader might be censoring.
+			// They start tracking it, and complain along with this node if it is not ordered in time.
+			c.logger.Infof("A request was not ordered by the leader %d in %s, forwarding it to all the nodes", c.leader(), c.opts.RequestTimeout)
+			c.forwardToAll(r.req)
+			r.forwarded = true
+			r.since = now
+			continue
+		}
+		c.logger.Warningf("A request was not ordered by the leader %d in %s", c.leader(), c.opts.RequestTimeout)
+		c.startViewChange(c.view + 1)
+		return
+	}
+}
+
+func (c *Chain) forwardToAll(req *orderer.SubmitRequest) {
+	for _, id := range c.nodes {
+		if id == c.selfID {
+			continue
+		}
+		if err := c.rpc.SendSubmit(id, req); err != nil {
+			c.logger.Debugf("Failed to forward request to node %d: %s", id, err)
+		}
+	}
+}
+
+// startViewChange stops the normal operation in the current view, and sends a signed
+// view change to the other nodes, which carries the latest prepared certificate
+func (c *Chain) startViewChange(view uint64) {
+	c.logger.Infof("Starting view change from view %d to view %d", c.view, view)
+	c.Metrics.ViewChanges.Add(1)
+	c.Metrics.IsLeader.Set(0)
+
+	if c.isLeader() && !c.viewChanging {
+		c.abdicate()
+	}
+
+	c.viewChanging = true
+	c.nextView = view
+	c.viewChangeStart = c.clock.Now()
+
+	viewChange := &bft.ViewChange{NextView: view, LastDecidedSeq: c.lastBlock.Header.Number}
+	if c.prepared != nil && c.prepared.Block.Header.Number == c.nextSeq() {
+		viewChange.Prepared = c.prepared
+	}
+	rawViewChange := protoutil.MarshalOrPanic(viewChange)
+	signature, err := c.support.Sign(rawViewChange)
+	if err != nil {
+		c.logger.Panicf("Failed to sign view change: %s", err)
+	}
+	signed := &bft.SignedViewChange{ViewChange: rawViewChange, Signer: c.selfID, Signature: signature}
+	c.viewChanges[c.selfID] = &viewChangeVote{viewChange: viewChange, signed: signed}
+
+	c.broadcast(&bft.ConsensusMessage{Payload: &bft.ConsensusMessage_ViewChange{ViewChange: signed}})
+	c.maybeSendNewView()
+}
+
+// abdicate drops the requests that the leader did not propose yet. The nodes that received
+// these requests from the clients forward them to the next leader
+func (c *Chain) abdicate() {
+	c.support.BlockCutter().Cut()
+	c.batchPending = false
+	c.pendingBatches = nil
+	c.configInflight = false
+	c.deferred = nil
+}
+
+func (c *Chain) onViewChange(sender uint64, signed *bft.SignedViewChange) {
+	if signed.Signer != sender {
+		c.logger.Warningf("Node %d sent a view change signed by node %d", sender, signed.Signer)
+		return
+	}
+
+	viewChange, err := c.verifyViewChange(signed)
+	if err != nil {
+		c.logger.Warningf("Invalid view change from node %d: %s", sender, err)
+		return
+	}
+
+	if viewChange.NextView <= c.view {
+		// The node missed the new view of a view this node is already in,
+		// the leader of the view sends the new view to it again
+		if !c.viewChanging && c.isLeader() && c.lastNewView != nil && c.lastNewView.View == c.view {
+			c.logger.Infof("Sending the new view of view %d to node %d again", c.view, sender)
+			c.send(sender, &bft.ConsensusMessage{Payload: &bft.ConsensusMessage_NewView{NewView: c.lastNewView}})
+		}
+		return
+	}
+
+	if existing, exists := c.viewChanges[sender]; exists && existing.viewChange.NextView >= viewChange.NextView {
+		return
+	}
+	c.viewChanges[sender] = &viewChangeVote{viewChange: viewChange, signed: signed}
+
+	c.maybeJoinViewChange()
+	c.maybeSendNewView()
+}
+
+// maybeJoinViewChange starts a view change once enough nodes started a view change to a higher view
+// for at least one of them to be correct. The view change targets the highest view that at least
+// one correct node started a view change to.
+func (c *Chain) maybeJoinViewChange() {
+	current := c.view
+	if c.viewChanging {
+		current = c.nextView
+	}
+
+	var views []uint64
+	for _, vote := range c.viewChanges {
+		if vote.viewChange.NextView > current {
+			views = append(views, vote.viewChange.NextView)
+		}
+	}
+	if len(views) < c.maxFaulty()+1 {
+		return
+	}
+
+	sort.Slice(views, func(i, j int) bool { return views[i] > views[j] })
+	c.startViewChange(views[c.maxFaulty()])
+}
+
+// maybeSendNewView sends a new view to the other nodes once the leader of the next view
+// receives view changes to the view from a quorum of nodes
+func (c *Chain) maybeSendNewView() {
+	if !c.viewChanging || c.leaderOf(c.nextView) != c.selfID {
+		return
+	}
+	if c.lastNewView != nil && c.lastNewView.View >= c.nextView {
+		return
+	}
+
+	var signers []uint64
+	for signer, vote := range c.viewChanges {
+		if vote.viewChange.NextView == c.nextView {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) < c.quorum() {
+		return
+	}
+
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	newView := &bft.NewView{View: c.nextView}
+	for _, signer := range signers[:c.quorum()] {
+		newView.ViewChanges = append(newView.ViewChanges, c.viewChanges[signer].signed)
+	}
+
+	c.logger.Infof("Received view changes to view %d from %d nodes, sending new view", c.nextView, len(signers))
+	c.lastNewView = newView
+	c.broadcast(&bft.ConsensusMessage{Payload: &bft.ConsensusMessage_NewView{NewView: newView}})
+	c.onNewView(c.selfID, newView)
+}
+
+func (c *Chain) onNewView(sender uint64, newView *bft.NewView) {
+	if newView.View <= c.view {
+		return
+	}
+
+	if sender != c.leaderOf(newView.View) {
+		c.logger.Warningf("Node %d sent a new view for view %d, of which it is not the leader", sender, newView.View)
+		return
+	}
+
+	lastDecidedSeq, reproposal, err := c.verifyNewView(newView)
+	if err != nil {
+		c.logger.Warningf("Invalid new view from node %d: %s", sender, err)
+		return
+	}
+
+	if lastDecidedSeq > c.lastBlock.Header.Number {
+		c.logger.Infof("Nodes decided blocks up to block [%d] before view %d, catching up", lastDecidedSeq, newView.View)
+		if !c.sync(lastDecidedSeq) {
+			c.logger.Warningf("Failed catching up before entering view %d", newView.View)
+			return
+		}
+	}
+
+	c.enterView(newView.View, reproposal)
+}
+
+// enterView starts the normal operation in the given view. The requests received
+// from the clients that are not decided yet are forwarded to the new leader.
+func (c *Chain) enterView(view uint64, reproposal *common.Block) {
+	c.logger.Infof("Entering view %d, the leader is node %d", view, c.leaderOf(view))
+
+	if c.isLeader() && !c.viewChanging {
+		c.abdicate()
+	}
+
+	c.view = view
+	c.viewChanging = false
+	c.proposal = nil
+	c.reproposal = nil
+	if reproposal != nil && reproposal.Header.Number == c.nextSeq() {
+		c.reproposal = reproposal
+	}
+	for sender, vote := range c.viewChanges {
+		if vote.viewChange.NextView <= view {
+			delete(c.viewChanges, sender)
+		}
+	}
+	for sender, higherView := range c.higherViews {
+		if higherView <= view {
+			delete(c.higherViews, sender)
+		}
+	}
+	c.pruneVotes()
+
+	c.Metrics.View.Set(float64(view))
+	if c.isLeader() {
+		c.Metrics.IsLeader.Set(1)
+	} else {
+		c.Metrics.IsLeader.Set(0)
+	}
+
+	now := c.clock.Now()
+	for _, r := range c.trackedRequests() {
+		r.since = now
+		if c.isLeader() {
+			c.order(r.req)
+			continue
+		}
+		if err := c.rpc.SendSubmit(c.leader(), r.req); err != nil {
+			c.logger.Debugf("Failed to forward request to the leader %d: %s", c.leader(), err)
+		}
+	}
+
+	c.proposeNext()
+}
+
+// observeHigherView records that the sender operates in a higher view. When enough nodes do so for
+// at least one of them to be correct, this node missed a view change and starts a view change, for
+// which the leader of the current view of the other nodes sends its new view again.
+func (c *Chain) observeHigherView(sender, view uint64) {
+	if view > c.higherViews[sender] {
+		c.higherViews[sender] = view
+	}
+	if c.viewChanging || len(c.higherViews) < c.maxFaulty()+1 {
+		return
+	}
+
+	c.logger.Infof("%d nodes operate in a view higher than view %d", len(c.higherViews), c.view)
+	c.startViewChange(c.view + 1)
+}
+
+func (c *Chain) verifyViewChange(signed *bft.SignedViewChange) (*bft.ViewChange, error) {
+	publicKey, exists := c.publicKeys[signed.Signer]
+	if !exists {
+		return nil, errors.Errorf("node %d is not a consenter", signed.Signer)
+	}
+	if err := verifySignature(publicKey, signed.ViewChange, signed.Signature); err != nil {
+		return nil, err
+	}
+	viewChange := &bft.ViewChange{}
+	if err := proto.Unmarshal(signed.ViewChange, viewChange); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal view change")
+	}
+	if viewChange.Prepared != nil {
+		if err := c.verifyPrepared(viewChange.Prepared); err != nil {
+			return nil, errors.WithMessage(err, "invalid prepared certificate")
+		}
+	}
+	return viewChange, nil
+}
+
+// verifyPrepared verifies that a quorum of nodes prepared the block of the certificate
+func (c *Chain) verifyPrepared(prepared *bft.Prepared) error {
+	block := prepared.Block
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("block is empty")
+	}
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return errors.New("data hash does not match the data of the block")
+	}
+
+	digest := protoutil.BlockHeaderHash(block.Header)
+	signers := make(map[uint64]struct{})
+	for _, signed := range prepared.Prepares {
+		prepare, err := c.verifyPrepare(signed)
+		if err != nil {
+			return err
+		}
+		if prepare.View != prepared.View || prepare.Seq != block.Header.Number || !bytes.Equal(prepare.Digest, digest) {
+			return errors.Errorf("prepare of node %d is not for the block of the certificate", signed.Signer)
+		}
+		signers[signed.Signer] = struct{}{}
+	}
+	if len(signers) < c.quorum() {
+		return errors.Errorf("certificate has prepares from %d nodes, but a quorum is %d nodes", len(signers), c.quorum())
+	}
+	return nil
+}
+
+// verifyNewView verifies that a quorum of nodes started a view change to the view, and returns the
+// highest sequence that the nodes decided before the view, and the block prepared for the next sequence
+// in the highest view, if any, which must be proposed again in the new view
+func (c *Chain) verifyNewView(newView *bft.NewView) (uint64, *common.Block, error) {
+	signers := make(map[uint64]struct{})
+	var viewChanges []*bft.ViewChange
+	for _, signed := range newView.ViewChanges {
+		viewChange, err := c.verifyViewChange(signed)
+		if err != nil {
+			return 0, nil, errors.WithMessagef(err, "invalid view change of node %d", signed.Signer)
+		}
+		if viewChange.NextView != newView.View {
+			return 0, nil, errors.Errorf("view change of node %d is to view %d", signed.Signer, viewChange.NextView)
+		}
+		if _, exists := signers[signed.Signer]; exists {
+			return 0, nil, errors.Errorf("duplicate view change of node %d", signed.Signer)
+		}
+		signers[signed.Signer] = struct{}{}
+		viewChanges = append(viewChanges, viewChange)
+	}
+	if len(signers) < c.quorum() {
+		return 0, nil, errors.Errorf("new view has view changes from %d nodes, but a quorum is %d nodes", len(signers), c.quorum())
+	}
+
+	var lastDecidedSeq uint64
+	for _, viewChange := range viewChanges {
+		if viewChange.LastDecidedSeq > lastDecidedSeq {
+			lastDecidedSeq = viewChange.LastDecidedSeq
+		}
+	}
+
+	var reproposal *bft.Prepared
+	for _, viewChange := range viewChanges {
+		prepared := viewChange.Prepared
+		if prepared == nil || prepared.Block.Header.Number != lastDecidedSeq+1 {
+			continue
+		}
+		if reproposal == nil || prepared.View > reproposal.View {
+			reproposal = prepared
+		}
+	}
+
+	if reproposal == nil {
+		return lastDecidedSeq, nil, nil
+	}
+	return lastDecidedSeq, reproposal.Block, nil
+}
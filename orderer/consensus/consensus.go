@@ -26,6 +26,16 @@ type Consenter interface {
 	HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error)
 }
 
+// StorageRemover removes the storage that a Consenter keeps for a channel besides its ledger,
+// when the channel is removed from the orderer.
+// NOTE: We expect the StorageRemover interface to be optionally implemented by the Consenter implementation.
+//       If a Consenter does not implement StorageRemover, it is assumed not to keep any storage for a channel.
+type StorageRemover interface {
+	// RemoveStorage removes the storage of the channel. It is invoked once the chain of the channel
+	// is halted, and succeeds when there is no storage for the channel.
+	RemoveStorage(channelID string) error
+}
+
 // MetadataValidator performs the validation of updates to ConsensusMetadata during config updates to the channel.
 // NOTE: We expect the MetadataValidator interface to be optionally implemented by the Consenter implementation.
 //       If a Consenter does not implement MetadataValidator, we default to using a no-op MetadataValidator.
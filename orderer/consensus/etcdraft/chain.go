@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/pem"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
@@ -110,10 +112,11 @@ type Options struct {
 	MaxSizePerMsg     uint64
 	MaxInflightBlocks int
 
-	// BlockMetdata and Consenters should only be modified while under lock
-	// of raftMetadataLock
-	BlockMetadata *etcdraft.BlockMetadata
-	Consenters    map[uint64]*etcdraft.Consenter
+	// BlockMetdata, Consenters and AutoPromoteLearners should only be
+	// modified while under lock of raftMetadataLock
+	BlockMetadata       *etcdraft.BlockMetadata
+	Consenters          map[uint64]*etcdraft.Consenter
+	AutoPromoteLearners bool
 
 	// MigrationInit is set when the node starts right after consensus-type migration
 	MigrationInit bool
@@ -420,6 +423,98 @@ func (c *Chain) isRunning() error {
 	return nil
 }
 
+// RaftStatus returns the status of the Raft node of the chain. The replication
+// progress of the other nodes is only known when this node is the leader.
+func (c *Chain) RaftStatus() (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	status := c.Node.Status()
+	result := types.RaftStatus{
+		Channel: c.channelID,
+		ID:      status.ID,
+		Leader:  status.Lead,
+		State:   status.RaftState.String(),
+		Term:    status.Term,
+		Commit:  status.Commit,
+		Applied: status.Applied,
+	}
+
+	if snap := c.Node.storage.Snapshot(); !raft.IsEmptySnap(snap) {
+		block, err := protoutil.UnmarshalBlock(snap.Data)
+		if err != nil {
+			return types.RaftStatus{}, errors.WithMessage(err, "failed to unmarshal snapshot block")
+		}
+		result.Snapshot = types.RaftSnapshotInfo{
+			Index:       snap.Metadata.Index,
+			Term:        snap.Metadata.Term,
+			BlockNumber: block.Header.Number,
+		}
+	}
+
+	for id, pr := range status.Progress {
+		result.Progress = append(result.Progress, types.RaftNodeProgress{
+			ID:           id,
+			Match:        pr.Match,
+			Next:         pr.Next,
+			State:        pr.State.String(),
+			RecentActive: pr.RecentActive,
+			Paused:       pr.Paused,
+			IsLearner:    pr.IsLearner,
+		})
+	}
+	sort.Slice(result.Progress, func(i, j int) bool {
+		return result.Progress[i].ID < result.Progress[j].ID
+	})
+
+	return result, nil
+}
+
+// TransferLeadership transfers leadership from this node, which must be the
+// leader, to the given transferee and returns the new leader once it is elected.
+// If the transferee is raft.None, leadership is transferred to any follower
+// qualified to take it over, and nothing is done if this node is not the leader.
+func (c *Chain) TransferLeadership(transferee uint64) (uint64, error) {
+	if err := c.isRunning(); err != nil {
+		return raft.None, err
+	}
+
+	status := c.Node.Status()
+	if status.RaftState != raft.StateLeader {
+		if transferee == raft.None {
+			c.logger.Infof("Not the leader, no need to transfer leadership away (leader: %d)", status.Lead)
+			return status.Lead, nil
+		}
+		return raft.None, errors.WithMessagef(types.ErrNotLeader, "cannot transfer leadership to %d, leader is %d", transferee, status.Lead)
+	}
+
+	switch pr, exists := status.Progress[transferee]; {
+	case transferee == raft.None:
+		transferee = c.Node.qualifiedTransferee(status)
+		if transferee == raft.None {
+			return raft.None, errors.WithMessage(types.ErrInvalidTransferee, "no follower is qualified to take over leadership")
+		}
+	case transferee == status.ID:
+		return status.ID, nil
+	case !exists:
+		return raft.None, errors.WithMessagef(types.ErrInvalidTransferee, "node %d is not a consenter of the channel", transferee)
+	case pr.IsLearner:
+		return raft.None, errors.WithMessagef(types.ErrInvalidTransferee, "node %d is a learner", transferee)
+	}
+
+	lead, err := c.Node.transferLeadership(transferee)
+	if err != nil {
+		return raft.None, errors.WithMessagef(err, "failed to transfer leadership to %d", transferee)
+	}
+	if lead != transferee {
+		return lead, errors.Errorf("leadership was taken over by %d instead of %d", lead, transferee)
+	}
+
+	c.logger.Infof("Leadership has been transferred to %d", lead)
+	return lead, nil
+}
+
 // Consensus passes the given ConsensusRequest message to the raft.Node instance
 func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
 	if err := c.isRunning(); err != nil {
@@ -643,9 +738,10 @@ func (c *Chain) run() {
 					select {
 					case <-c.errorC:
 					default:
-						nodeCount := len(c.opts.BlockMetadata.ConsenterIds)
+						nodeCount := len(c.opts.BlockMetadata.ConsenterIds) - len(c.opts.BlockMetadata.LearnerIds)
 						// Only close the error channel (to signal the broadcast/deliver front-end a consensus backend error)
 						// If we are a cluster of size 3 or more, otherwise we can't expand a cluster of size 1 to 2 nodes.
+						// Learners do not take part in elections, hence only voters are counted.
 						if nodeCount > 2 {
 							close(c.errorC)
 						} else {
@@ -901,7 +997,15 @@ func (c *Chain) detectConfChange(block *common.Block) *MembershipChanges {
 		c.sizeLimit = configMetadata.Options.SnapshotIntervalSize
 	}
 
-	changes, err := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, configMetadata.Consenters)
+	if configMetadata.Options != nil &&
+		configMetadata.Options.AutoPromoteLearners != c.opts.AutoPromoteLearners {
+		c.logger.Infof("Update automatic promotion of learners to %t", configMetadata.Options.AutoPromoteLearners)
+		c.raftMetadataLock.Lock()
+		c.opts.AutoPromoteLearners = configMetadata.Options.AutoPromoteLearners
+		c.raftMetadataLock.Unlock()
+	}
+
+	changes, err := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, configMetadata.Consenters, configMetadata.LearnerClientTlsCerts)
 	if err != nil {
 		c.logger.Panicf("illegal configuration change detected: %s", err)
 	}
@@ -951,11 +1055,21 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 				continue
 			}
 
+			wasLearner := NodeExists(cc.NodeID, c.confState.Learners)
 			c.confState = *c.Node.ApplyConfChange(cc)
 
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
+				if wasLearner {
+					// A learner promoted by a config update has already been removed from
+					// the learners in block metadata, unless it was promoted automatically.
+					c.removeLearner(cc.NodeID)
+					c.logger.Infof("Applied config change to promote learner %d, current nodes in channel: %+v, learners: %+v", cc.NodeID, c.confState.Nodes, c.confState.Learners)
+					break
+				}
 				c.logger.Infof("Applied config change to add node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
+			case raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Applied config change to add learner %d, current nodes in channel: %+v, learners: %+v", cc.NodeID, c.confState.Nodes, c.confState.Learners)
 			case raftpb.ConfChangeRemoveNode:
 				c.logger.Infof("Applied config change to remove node %d, current nodes in channel: %+v", cc.NodeID, c.confState.Nodes)
 			default:
@@ -1147,7 +1261,13 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 
 			switch configMembership.ConfChange.Type {
 			case raftpb.ConfChangeAddNode:
+				if len(configMembership.PromotedNodes) > 0 {
+					c.logger.Infof("Config block just committed promotes learner %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+					break
+				}
 				c.logger.Infof("Config block just committed adds node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+			case raftpb.ConfChangeAddLearnerNode:
+				c.logger.Infof("Config block just committed adds learner %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
 			case raftpb.ConfChangeRemoveNode:
 				c.logger.Infof("Config block just committed removes node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
 			default:
@@ -1205,12 +1325,8 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.Node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(c.opts.BlockMetadata.ConsenterIds) {
-		// Raft configuration change could only add one node or
-		// remove one node at a time, if raft conf state size is
-		// equal to membership stored in block metadata field,
-		// that means everything is in sync and no need to propose
-		// config update.
+	if ConfStateInSync(c.opts.BlockMetadata, confState) {
+		// no need to propose config update
 		return nil
 	}
 
@@ -1226,6 +1342,33 @@ func (c *Chain) newConfigMetadata(block *common.Block) *etcdraft.ConfigMetadata
 	return metadata
 }
 
+// removeLearner removes the node from the learners stored in block metadata,
+// once it has been promoted to voter.
+func (c *Chain) removeLearner(nodeID uint64) {
+	c.raftMetadataLock.Lock()
+	defer c.raftMetadataLock.Unlock()
+
+	learners := c.opts.BlockMetadata.LearnerIds
+	for i, id := range learners {
+		if id == nodeID {
+			c.opts.BlockMetadata.LearnerIds = append(learners[:i:i], learners[i+1:]...)
+			return
+		}
+	}
+}
+
+// learnersToPromote returns the learners which are to be promoted to voters
+// as soon as they have caught up, if automatic promotion is enabled.
+func (c *Chain) learnersToPromote() []uint64 {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	if !c.opts.AutoPromoteLearners {
+		return nil
+	}
+	return append([]uint64(nil), c.opts.BlockMetadata.LearnerIds...)
+}
+
 func (c *Chain) suspectEviction() bool {
 	if c.isRunning() != nil {
 		return false
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	orderertypes "github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
 	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
@@ -1933,6 +1934,135 @@ var _ = Describe("Chain", func() {
 					})
 				})
 
+				Context("adding node to the cluster as a learner", func() {
+					var (
+						learnerOptions *raftprotos.Options
+						newConsenter   *raftprotos.Consenter
+					)
+
+					configWithLearner := func(learners ...*raftprotos.Consenter) *common.Envelope {
+						metadata := &raftprotos.ConfigMetadata{Options: learnerOptions}
+						for _, id := range []uint64{1, 2, 3} {
+							metadata.Consenters = append(metadata.Consenters, consenters[id])
+						}
+						metadata.Consenters = append(metadata.Consenters, newConsenter)
+						for _, learner := range learners {
+							metadata.LearnerClientTlsCerts = append(metadata.LearnerClientTlsCerts, learner.ClientTlsCert)
+						}
+						return newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(metadata)))
+					}
+
+					isLearner := func(id uint64) func() bool {
+						return func() bool {
+							c1.clock.Increment(interval)
+							pr, exists := c1.Node.Status().Progress[id]
+							return exists && pr.IsLearner
+						}
+					}
+
+					isVoter := func(id uint64) func() bool {
+						return func() bool {
+							c1.clock.Increment(interval)
+							pr, exists := c1.Node.Status().Progress[id]
+							return exists && !pr.IsLearner
+						}
+					}
+
+					startLearner := func() *chain {
+						By("adding the learner")
+						c1.cutter.CutNext = true
+						Expect(c1.Configure(configWithLearner(newConsenter), 0)).To(Succeed())
+						network.exec(func(c *chain) {
+							Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+						})
+
+						_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+						raftmeta, err := etcdraft.ReadBlockMetadata(&common.Metadata{Value: raftmetabytes}, nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(raftmeta.ConsenterIds).To(ConsistOf([]uint64{1, 2, 3, 4}))
+						Expect(raftmeta.LearnerIds).To(Equal([]uint64{4}))
+
+						consenters[4] = newConsenter
+						c4 := newChain(timeout, channelID, dataDir, 4, raftmeta, consenters)
+						c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+						c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+						c4.init()
+
+						network.addChain(c4)
+						c4.Start()
+
+						Eventually(func() <-chan raft.SoftState {
+							c1.clock.Increment(interval)
+							return c4.observe
+						}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+						Eventually(isLearner(4), defaultTimeout).Should(BeTrue())
+
+						return c4
+					}
+
+					BeforeEach(func() {
+						learnerOptions = proto.Clone(options).(*raftprotos.Options)
+						newConsenter = &raftprotos.Consenter{
+							Host:          "localhost",
+							Port:          7050,
+							ServerTlsCert: serverTLSCert(tlsCA),
+							ClientTlsCert: clientTLSCert(tlsCA),
+						}
+					})
+
+					It("replicates blocks to the learner and promotes it by config update", func() {
+						c4 := startLearner()
+
+						By("submitting new transaction to the learner")
+						c1.cutter.CutNext = true
+						Expect(c4.Order(env, 0)).To(Succeed())
+						network.exec(func(c *chain) {
+							Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+						})
+						Consistently(isLearner(4)).Should(BeTrue())
+
+						By("promoting the learner")
+						c1.cutter.CutNext = true
+						Expect(c1.Configure(configWithLearner(), 0)).To(Succeed())
+						network.exec(func(c *chain) {
+							Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(2))
+						})
+						Eventually(isVoter(4), defaultTimeout).Should(BeTrue())
+
+						_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(1)
+						raftmeta, err := etcdraft.ReadBlockMetadata(&common.Metadata{Value: raftmetabytes}, nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(raftmeta.LearnerIds).To(BeEmpty())
+
+						By("ordering once the promotion is applied")
+						c1.cutter.CutNext = true
+						Expect(c4.Order(env, 0)).To(Succeed())
+						network.exec(func(c *chain) {
+							Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(3))
+						})
+					})
+
+					It("promotes the learner automatically once it has caught up", func() {
+						learnerOptions.AutoPromoteLearners = true
+						c4 := startLearner()
+
+						Eventually(isVoter(4), LongEventualTimeout).Should(BeTrue())
+
+						By("writing the promotion into the metadata of the next block")
+						c1.cutter.CutNext = true
+						Expect(c4.Order(env, 0)).To(Succeed())
+						network.exec(func(c *chain) {
+							Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+						})
+
+						_, raftmetabytes := c1.support.WriteBlockArgsForCall(1)
+						raftmeta, err := etcdraft.ReadBlockMetadata(&common.Metadata{Value: raftmetabytes}, nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(raftmeta.ConsenterIds).To(ConsistOf([]uint64{1, 2, 3, 4}))
+						Expect(raftmeta.LearnerIds).To(BeEmpty())
+					})
+				})
+
 				It("does not reconfigure raft cluster if it's a channel creation tx", func() {
 					configEnv := newConfigEnv("another-channel",
 						common.HeaderType_CONFIG,
@@ -2550,6 +2680,105 @@ var _ = Describe("Chain", func() {
 				})
 			})
 
+			Context("Raft status and leadership transfer", func() {
+				transferLeadership := func(c *chain, transferee uint64) <-chan error {
+					errC := make(chan error, 1)
+					go func() {
+						lead, err := c.TransferLeadership(transferee)
+						if err == nil && transferee != raft.None && lead != transferee {
+							err = fmt.Errorf("expected leader %d, got %d", transferee, lead)
+						}
+						errC <- err
+					}()
+					return errC
+				}
+
+				It("reports the status of the Raft node", func() {
+					c1.cutter.CutNext = true
+					Expect(c1.Order(env, 0)).To(Succeed())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+
+					status, err := c1.RaftStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Channel).To(Equal(channelID))
+					Expect(status.ID).To(Equal(uint64(1)))
+					Expect(status.Leader).To(Equal(uint64(1)))
+					Expect(status.State).To(Equal("StateLeader"))
+					Expect(status.Term).NotTo(BeZero())
+					Expect(status.Applied).To(Equal(status.Commit))
+					Expect(status.Progress).To(HaveLen(3))
+					for i, pr := range status.Progress {
+						Expect(pr.ID).To(Equal(uint64(i + 1)))
+						Expect(pr.IsLearner).To(BeFalse())
+					}
+
+					Eventually(func() uint64 {
+						status, err := c2.RaftStatus()
+						Expect(err).NotTo(HaveOccurred())
+						return status.Applied
+					}, LongEventualTimeout).Should(Equal(status.Commit))
+					status, err = c2.RaftStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.ID).To(Equal(uint64(2)))
+					Expect(status.Leader).To(Equal(uint64(1)))
+					Expect(status.State).To(Equal("StateFollower"))
+					Expect(status.Progress).To(BeEmpty())
+				})
+
+				It("transfers leadership to the given consenter", func() {
+					errC := transferLeadership(c1, 2)
+					Eventually(c2.observe, LongEventualTimeout).Should(Receive(StateEqual(2, raft.StateLeader)))
+					Eventually(errC, LongEventualTimeout).Should(Receive(BeNil()))
+					Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(2, raft.StateFollower)))
+
+					By("ordering envelopes on the new leader")
+					c2.cutter.CutNext = true
+					Expect(c1.Order(env, 0)).To(Succeed())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+				})
+
+				It("transfers leadership away from the local node", func() {
+					errC := transferLeadership(c1, raft.None)
+					Eventually(errC, LongEventualTimeout).Should(Receive(BeNil()))
+
+					status, err := c1.RaftStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Leader).To(SatisfyAny(Equal(uint64(2)), Equal(uint64(3))))
+					Expect(status.State).To(Equal("StateFollower"))
+
+					By("doing nothing once the local node is not the leader")
+					lead, err := c1.TransferLeadership(raft.None)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lead).To(Equal(status.Leader))
+				})
+
+				It("rejects invalid transfers", func() {
+					_, err := c2.TransferLeadership(3)
+					Expect(errors.Cause(err)).To(Equal(orderertypes.ErrNotLeader))
+					Expect(err).To(MatchError("cannot transfer leadership to 3, leader is 1: not the Raft leader"))
+
+					_, err = c1.TransferLeadership(5)
+					Expect(errors.Cause(err)).To(Equal(orderertypes.ErrInvalidTransferee))
+
+					lead, err := c1.TransferLeadership(1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lead).To(Equal(uint64(1)))
+				})
+
+				It("times out when the transferee does not take over leadership", func() {
+					network.disconnect(2)
+
+					errC := transferLeadership(c1, 2)
+					Eventually(c1.clock.WatcherCount, LongEventualTimeout).Should(Equal(2))
+					c1.clock.Increment(time.Duration(ELECTION_TICK) * interval)
+					Eventually(errC, LongEventualTimeout).Should(Receive(MatchError("failed to transfer leadership to 2: leader transfer timeout")))
+				})
+			})
+
 			When("leader is disconnected", func() {
 				It("proactively steps down to follower", func() {
 					network.disconnect(1)
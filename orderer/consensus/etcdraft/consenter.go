@@ -8,6 +8,7 @@ package etcdraft
 
 import (
 	"bytes"
+	"os"
 	"path"
 	"reflect"
 	"time"
@@ -97,10 +98,11 @@ func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	if cs.Chain == nil {
 		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
 	}
-	if etcdRaftChain, isEtcdRaftChain := cs.Chain.(*Chain); isEtcdRaftChain {
-		return etcdRaftChain
+	// Chains of other consensus types that communicate over the cluster, such as BFT, receive their messages too
+	if receiver, isReceiver := cs.Chain.(MessageReceiver); isReceiver {
+		return receiver
 	}
-	c.Logger.Warningf("Chain %s is of type %v and not etcdraft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	c.Logger.Warningf("Chain %s is of type %v and does not receive cluster messages", channelID, reflect.TypeOf(cs.Chain))
 	return nil
 }
 
@@ -183,8 +185,9 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		MaxSizePerMsg:        uint64(support.SharedConfig().BatchSize().PreferredMaxBytes),
 		SnapshotIntervalSize: m.Options.SnapshotIntervalSize,
 
-		BlockMetadata: blockMetadata,
-		Consenters:    consenters,
+		BlockMetadata:       blockMetadata,
+		Consenters:          consenters,
+		AutoPromoteLearners: m.Options.AutoPromoteLearners,
 
 		MigrationInit: isMigration,
 
@@ -212,6 +215,19 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 	)
 }
 
+// RemoveStorage removes the WAL and the snapshots of the channel.
+func (c *Consenter) RemoveStorage(channelID string) error {
+	walDir := path.Join(c.EtcdRaftConfig.WALDir, channelID)
+	if err := os.RemoveAll(walDir); err != nil {
+		return errors.Wrapf(err, "failed to remove WAL directory %s", walDir)
+	}
+	snapDir := path.Join(c.EtcdRaftConfig.SnapDir, channelID)
+	if err := os.RemoveAll(snapDir); err != nil {
+		return errors.Wrapf(err, "failed to remove snapshot directory %s", snapDir)
+	}
+	return nil
+}
+
 // ValidateConsensusMetadata determines the validity of a
 // ConsensusMetadata update during config updates on the channel.
 // Since the ConsensusMetadata is specific to the consensus implementation (independent of the particular
@@ -253,7 +269,11 @@ func (c *Consenter) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes
 	// create the dummy parameters for ComputeMembershipChanges
 	dummyOldBlockMetadata, _ := ReadBlockMetadata(nil, oldMetadata)
 	dummyOldConsentersMap := CreateConsentersMap(dummyOldBlockMetadata, oldMetadata)
-	_, err = ComputeMembershipChanges(dummyOldBlockMetadata, dummyOldConsentersMap, newMetadata.Consenters)
+	oldConsentersByCert := MembershipByCert(dummyOldConsentersMap)
+	for _, cert := range oldMetadata.LearnerClientTlsCerts {
+		dummyOldBlockMetadata.LearnerIds = append(dummyOldBlockMetadata.LearnerIds, oldConsentersByCert[string(cert)])
+	}
+	_, err = ComputeMembershipChanges(dummyOldBlockMetadata, dummyOldConsentersMap, newMetadata.Consenters, newMetadata.LearnerClientTlsCerts)
 
 	return err
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	clustermocks "github.com/hyperledger/fabric/orderer/common/cluster/mocks"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
 	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
@@ -116,6 +117,14 @@ var _ = Describe("Consenter", func() {
 			Expect(chain).NotTo(BeNil())
 			Expect(chain).To(BeIdenticalTo(chainInstance))
 		})
+		It("calls the chain getter and returns the reference of a chain of another type that receives messages", func() {
+			receiver := &receiverChain{Chain: &multichannel.ChainSupport{}, MessageReceiver: &mocks.MessageReceiver{}}
+			chainGetter.On("GetChain", "bftchain").Return(&multichannel.ChainSupport{Chain: receiver})
+			consenter := newConsenter(chainGetter)
+
+			chain := consenter.ReceiverByChain("bftchain")
+			Expect(chain).To(BeIdenticalTo(receiver))
+		})
 		It("calls the chain getter and returns nil when it's not found", func() {
 			consenter := newConsenter(chainGetter)
 			Expect(consenter).NotTo(BeNil())
@@ -123,7 +132,7 @@ var _ = Describe("Consenter", func() {
 			chain := consenter.ReceiverByChain("notmychannel")
 			Expect(chain).To(BeNil())
 		})
-		It("calls the chain getter and returns nil when it's not a raft chain", func() {
+		It("calls the chain getter and returns nil when it does not receive messages", func() {
 			consenter := newConsenter(chainGetter)
 			Expect(consenter).NotTo(BeNil())
 
@@ -180,6 +189,25 @@ var _ = Describe("Consenter", func() {
 		Expect(defaultSuspicionFallback).To(BeTrue())
 	})
 
+	It("removes the WAL and the snapshots of a channel", func() {
+		for _, dir := range []string{path.Join(walDir, "mychannel"), path.Join(snapDir, "mychannel"), path.Join(walDir, "otherchannel")} {
+			Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(path.Join(dir, "data"), []byte("data"), 0644)).To(Succeed())
+		}
+
+		consenter := newConsenter(chainGetter)
+		consenter.EtcdRaftConfig.WALDir = walDir
+		consenter.EtcdRaftConfig.SnapDir = snapDir
+
+		Expect(consenter.RemoveStorage("mychannel")).To(Succeed())
+		Expect(path.Join(walDir, "mychannel")).NotTo(BeADirectory())
+		Expect(path.Join(snapDir, "mychannel")).NotTo(BeADirectory())
+		Expect(path.Join(walDir, "otherchannel")).To(BeADirectory())
+
+		// there is nothing left to remove
+		Expect(consenter.RemoveStorage("mychannel")).To(Succeed())
+	})
+
 	It("fails to handle chain if no matching cert found", func() {
 		m := &etcdraftproto.ConfigMetadata{
 			Consenters: []*etcdraftproto.Consenter{
@@ -481,3 +509,8 @@ func newConsenter(chainGetter *mocks.ChainGetter) *consenter {
 		icr:       icr,
 	}
 }
+
+type receiverChain struct {
+	consensus.Chain
+	etcdraft.MessageReceiver
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 )
@@ -45,6 +46,8 @@ type node struct {
 
 	subscriberC chan chan uint64
 
+	promotionTicks int // ticks elapsed since learners were last checked for promotion
+
 	raft.Node
 }
 
@@ -120,6 +123,7 @@ func (n *node) run(campaign bool) {
 		select {
 		case <-raftTicker.C():
 			n.Tick()
+			n.maybePromoteLearners()
 
 		case rd := <-n.Ready():
 			startStoring := n.clock.Now()
@@ -219,29 +223,14 @@ func (n *node) abdicateLeader(currentLead uint64) {
 	}
 
 	// register a leader subscriberC
-	notifyc := make(chan uint64, 1)
-	select {
-	case n.subscriberC <- notifyc:
-	case <-n.chain.doneC:
+	notifyc, err := n.subscribeLeaderChange()
+	if err != nil {
 		return
 	}
 
 	// Leader initiates leader transfer
 	if status.RaftState == raft.StateLeader {
-		var transferee uint64
-		for id, pr := range status.Progress {
-			if id == status.ID {
-				continue // skip self
-			}
-
-			if pr.RecentActive && !pr.Paused {
-				transferee = id
-				break
-			}
-
-			n.logger.Debugf("Node %d is not qualified as transferee because it's either paused or not active", id)
-		}
-
+		transferee := n.qualifiedTransferee(status)
 		if transferee == raft.None {
 			n.logger.Errorf("No follower is qualified as transferee, abort leader transfer")
 			return
@@ -251,12 +240,118 @@ func (n *node) abdicateLeader(currentLead uint64) {
 		n.TransferLeadership(context.TODO(), status.ID, transferee)
 	}
 
+	l, err := n.waitLeaderChange(notifyc)
+	if err != nil {
+		n.logger.Warnf("Leader transfer failed: %s", err)
+		return
+	}
+	n.logger.Infof("Leader has been transferred from %d to %d", currentLead, l)
+}
+
+// transferLeadership transfers leadership from this node, which must be the
+// leader, to the transferee and waits for the leader to change till timeout
+// (ElectionTimeout). It returns the new leader.
+func (n *node) transferLeadership(transferee uint64) (uint64, error) {
+	notifyc, err := n.subscribeLeaderChange()
+	if err != nil {
+		return raft.None, err
+	}
+
+	n.logger.Infof("Transferring leadership to %d", transferee)
+	n.TransferLeadership(context.TODO(), n.config.ID, transferee)
+
+	return n.waitLeaderChange(notifyc)
+}
+
+// qualifiedTransferee returns a follower that is recently active and able to
+// take over leadership, or raft.None if there is none.
+func (n *node) qualifiedTransferee(status raft.Status) uint64 {
+	for id, pr := range status.Progress {
+		if id == status.ID {
+			continue // skip self
+		}
+
+		if pr.IsLearner {
+			continue // learners cannot be elected
+		}
+
+		if pr.RecentActive && !pr.Paused {
+			return id
+		}
+
+		n.logger.Debugf("Node %d is not qualified as transferee because it's either paused or not active", id)
+	}
+
+	return raft.None
+}
+
+// subscribeLeaderChange registers a channel which is notified of the next leader.
+func (n *node) subscribeLeaderChange() (chan uint64, error) {
+	notifyc := make(chan uint64, 1)
+	select {
+	case n.subscriberC <- notifyc:
+		return notifyc, nil
+	case <-n.chain.doneC:
+		return nil, errors.Errorf("chain is stopped")
+	}
+}
+
+func (n *node) waitLeaderChange(notifyc chan uint64) (uint64, error) {
 	select {
 	case <-n.clock.After(time.Duration(n.config.ElectionTick) * n.tickInterval):
-		n.logger.Warn("Leader transfer timeout")
+		return raft.None, errors.Errorf("leader transfer timeout")
 	case l := <-notifyc:
-		n.logger.Infof("Leader has been transferred from %d to %d", currentLead, l)
+		return l, nil
 	case <-n.chain.doneC:
+		return raft.None, errors.Errorf("chain is stopped")
+	}
+}
+
+// maybePromoteLearners proposes to promote to voter a learner which has caught
+// up with the leader, if this node is the leader and automatic promotion of
+// learners is enabled. It is called on every tick, but learners are only
+// checked once per election timeout, to leave time to the proposed promotion
+// to be applied before checking again.
+func (n *node) maybePromoteLearners() {
+	n.promotionTicks++
+	if n.promotionTicks < n.config.ElectionTick {
+		return
+	}
+	n.promotionTicks = 0
+
+	learners := n.chain.learnersToPromote()
+	if len(learners) == 0 {
+		return
+	}
+
+	status := n.Status()
+	if status.RaftState != raft.StateLeader {
+		return
+	}
+
+	for _, id := range learners {
+		pr, exists := status.Progress[id]
+		if !exists || !pr.IsLearner {
+			continue
+		}
+
+		// A learner is caught up when it lags behind the commit index by no
+		// more than the entries which may be in flight towards it.
+		if pr.Match+uint64(n.config.MaxInflightMsgs) < status.Commit {
+			n.logger.Debugf("Learner %d is not caught up yet, its match index is %d while commit index is %d", id, pr.Match, status.Commit)
+			continue
+		}
+
+		n.logger.Infof("Learner %d has caught up at index %d (commit index: %d), proposing to promote it to voter", id, pr.Match, status.Commit)
+		cc := raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: id}
+		// Proposing blocks until the proposal is accepted by the raft node,
+		// which may in turn wait for this go routine to consume Ready.
+		go func() {
+			if err := n.ProposeConfChange(context.TODO(), cc); err != nil {
+				n.logger.Warnf("Failed to propose promotion of learner %d: %s", cc.NodeID, err)
+			}
+		}()
+		return // Raft only allows one configuration change at a time
 	}
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -38,18 +39,19 @@ type MembershipChanges struct {
 	NewConsenters    map[uint64]*etcdraft.Consenter
 	AddedNodes       []*etcdraft.Consenter
 	RemovedNodes     []*etcdraft.Consenter
+	PromotedNodes    []*etcdraft.Consenter
 	ConfChange       *raftpb.ConfChange
 	RotatedNode      uint64
 }
 
 // Stringer implements fmt.Stringer interface
 func (mc *MembershipChanges) String() string {
-	return fmt.Sprintf("add %d node(s), remove %d node(s)", len(mc.AddedNodes), len(mc.RemovedNodes))
+	return fmt.Sprintf("add %d node(s), remove %d node(s), promote %d learner(s)", len(mc.AddedNodes), len(mc.RemovedNodes), len(mc.PromotedNodes))
 }
 
 // Changed indicates whether these changes actually do anything
 func (mc *MembershipChanges) Changed() bool {
-	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0
+	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0 || len(mc.PromotedNodes) > 0
 }
 
 // Rotated indicates whether the change was a rotation
@@ -159,14 +161,16 @@ func MembershipByCert(consenters map[uint64]*etcdraft.Consenter) map[string]uint
 	return set
 }
 
-// ComputeMembershipChanges computes membership update based on information about new consenters, returns
-// two slices: a slice of added consenters and a slice of consenters to be removed
-func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter) (mc *MembershipChanges, err error) {
+// ComputeMembershipChanges computes membership update based on information about new consenters and
+// learners, returns three slices: a slice of added consenters, a slice of consenters to be removed and
+// a slice of learners to be promoted to voters
+func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter, newLearners [][]byte) (mc *MembershipChanges, err error) {
 	result := &MembershipChanges{
 		NewConsenters:    map[uint64]*etcdraft.Consenter{},
 		NewBlockMetadata: proto.Clone(oldMetadata).(*etcdraft.BlockMetadata),
 		AddedNodes:       []*etcdraft.Consenter{},
 		RemovedNodes:     []*etcdraft.Consenter{},
+		PromotedNodes:    []*etcdraft.Consenter{},
 	}
 
 	result.NewBlockMetadata.ConsenterIds = make([]uint64, len(newConsenters))
@@ -192,13 +196,39 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 		}
 	}
 
+	// A learner stays so as long as it is listed among the learners, a voter listed
+	// there is left untouched since Raft does not support demoting voters.
+	learnersSet := map[string]struct{}{}
+	for _, cert := range newLearners {
+		learnersSet[string(cert)] = struct{}{}
+	}
+
+	var promotedNodeID uint64
+	result.NewBlockMetadata.LearnerIds = nil
+	for _, nodeID := range oldMetadata.LearnerIds {
+		c, exists := result.NewConsenters[nodeID]
+		if !exists {
+			// either removed, or rotated which is handled below
+			continue
+		}
+		if _, isLearner := learnersSet[string(c.ClientTlsCert)]; isLearner {
+			result.NewBlockMetadata.LearnerIds = append(result.NewBlockMetadata.LearnerIds, nodeID)
+			continue
+		}
+		result.PromotedNodes = append(result.PromotedNodes, c)
+		promotedNodeID = nodeID
+	}
+
 	switch {
-	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1:
+	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1 && len(result.PromotedNodes) == 0:
 		// cert rotation
 		result.RotatedNode = deletedNodeID
 		result.NewBlockMetadata.ConsenterIds[addedNodeIndex] = deletedNodeID
 		result.NewConsenters[deletedNodeID] = result.AddedNodes[0]
-	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 0:
+		if NodeExists(deletedNodeID, oldMetadata.LearnerIds) {
+			result.NewBlockMetadata.LearnerIds = append(result.NewBlockMetadata.LearnerIds, deletedNodeID)
+		}
+	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 0 && len(result.PromotedNodes) == 0:
 		// new node
 		nodeID := result.NewBlockMetadata.NextConsenterId
 		result.NewConsenters[nodeID] = result.AddedNodes[0]
@@ -208,7 +238,17 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 			NodeID: nodeID,
 			Type:   raftpb.ConfChangeAddNode,
 		}
-	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 1:
+		if _, isLearner := learnersSet[string(result.AddedNodes[0].ClientTlsCert)]; isLearner {
+			result.NewBlockMetadata.LearnerIds = append(result.NewBlockMetadata.LearnerIds, nodeID)
+			result.ConfChange.Type = raftpb.ConfChangeAddLearnerNode
+		}
+	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 0 && len(result.PromotedNodes) == 1:
+		// learner promotion
+		result.ConfChange = &raftpb.ConfChange{
+			NodeID: promotedNodeID,
+			Type:   raftpb.ConfChangeAddNode,
+		}
+	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 1 && len(result.PromotedNodes) == 0:
 		// removed node
 		nodeID := deletedNodeID
 		result.ConfChange = &raftpb.ConfChange{
@@ -216,10 +256,10 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 			NodeID: nodeID,
 		}
 		delete(result.NewConsenters, nodeID)
-	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 0:
+	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 0 && len(result.PromotedNodes) == 0:
 		// no change
 	default:
-		// len(result.AddedNodes) > 1 || len(result.RemovedNodes) > 1 {
+		// more than one node is added, removed or promoted
 		return nil, errors.Errorf("update of more than one consenter at a time is not supported, requested changes: %s", result)
 	}
 
@@ -263,6 +303,11 @@ func MetadataFromConfigValue(configValue *common.ConfigValue) (*etcdraft.ConfigM
 		return nil, errors.Wrap(err, "failed to unmarshal consensusType config update")
 	}
 
+	// A consensus-type migration away from etcdraft carries the metadata of the next consensus type
+	if consensusTypeValue.Type != "" && consensusTypeValue.Type != "etcdraft" {
+		return nil, nil
+	}
+
 	updatedMetadata := &etcdraft.ConfigMetadata{}
 	if err := proto.Unmarshal(consensusTypeValue.Metadata, updatedMetadata); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal updated (new) etcdraft metadata configuration")
@@ -426,6 +471,33 @@ func CheckConfigMetadata(metadata *etcdraft.ConfigMetadata) error {
 		return err
 	}
 
+	if err := checkLearners(metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkLearners validates that every learner is a consenter, and that
+// some consenters are left to vote
+func checkLearners(metadata *etcdraft.ConfigMetadata) error {
+	consenters := ConsentersToMap(metadata.Consenters)
+	learners := map[string]struct{}{}
+	for _, cert := range metadata.LearnerClientTlsCerts {
+		if _, exists := consenters[string(cert)]; !exists {
+			return errors.Errorf("learner is not a consenter: %s", string(cert))
+		}
+		learners[string(cert)] = struct{}{}
+	}
+
+	if len(learners) != len(metadata.LearnerClientTlsCerts) {
+		return errors.Errorf("duplicate learner in metadata")
+	}
+
+	if len(learners) == len(metadata.Consenters) {
+		return errors.Errorf("no voter left among the consenters, all of them are learners")
+	}
+
 	return nil
 }
 
@@ -464,6 +536,19 @@ func (conCert ConsenterCertificate) IsConsenterOfChannel(configBlock *common.Blo
 	if !exists {
 		return errors.New("no orderer config in bundle")
 	}
+	if oc.ConsensusType() == "BFT" {
+		m := &bft.ConfigMetadata{}
+		if err := proto.Unmarshal(oc.ConsensusMetadata(), m); err != nil {
+			return err
+		}
+		for _, consenter := range m.Consenters {
+			if bytes.Equal(conCert, consenter.ServerTlsCert) || bytes.Equal(conCert, consenter.ClientTlsCert) {
+				return nil
+			}
+		}
+		return cluster.ErrNotInChannel
+	}
+
 	m := &etcdraft.ConfigMetadata{}
 	if err := proto.Unmarshal(oc.ConsensusMetadata(), m); err != nil {
 		return err
@@ -493,30 +578,58 @@ func NodeExists(id uint64, nodes []uint64) bool {
 func ConfChange(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) *raftpb.ConfChange {
 	raftConfChange := &raftpb.ConfChange{}
 
+	members := make([]uint64, 0, len(confState.Nodes)+len(confState.Learners))
+	members = append(members, confState.Nodes...)
+	members = append(members, confState.Learners...)
+
 	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(blockMetadata.ConsenterIds) {
+	switch {
+	case len(members) < len(blockMetadata.ConsenterIds):
 		// adding new node
 		raftConfChange.Type = raftpb.ConfChangeAddNode
 		for _, consenterID := range blockMetadata.ConsenterIds {
-			if NodeExists(consenterID, confState.Nodes) {
+			if NodeExists(consenterID, members) {
 				continue
 			}
 			raftConfChange.NodeID = consenterID
+			if NodeExists(consenterID, blockMetadata.LearnerIds) {
+				raftConfChange.Type = raftpb.ConfChangeAddLearnerNode
+			}
 		}
-	} else {
+	case len(members) > len(blockMetadata.ConsenterIds):
 		// removing node
 		raftConfChange.Type = raftpb.ConfChangeRemoveNode
-		for _, nodeID := range confState.Nodes {
+		for _, nodeID := range members {
 			if NodeExists(nodeID, blockMetadata.ConsenterIds) {
 				continue
 			}
 			raftConfChange.NodeID = nodeID
 		}
+	default:
+		// promoting learner
+		raftConfChange.Type = raftpb.ConfChangeAddNode
+		for _, nodeID := range confState.Learners {
+			if NodeExists(nodeID, blockMetadata.LearnerIds) {
+				continue
+			}
+			raftConfChange.NodeID = nodeID
+		}
 	}
 
 	return raftConfChange
 }
 
+// ConfStateInSync returns whether the voters and learners of the Raft
+// configuration state match the ones stored in RaftMetadata.
+func ConfStateInSync(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) bool {
+	// Raft configuration change could only add, remove or promote
+	// one node at a time, if the number of voters and learners is
+	// equal to the ones stored in block metadata field, that means
+	// everything is in sync.
+	voters := len(blockMetadata.ConsenterIds) - len(blockMetadata.LearnerIds)
+	return len(confState.Nodes) == voters && len(confState.Learners) == len(blockMetadata.LearnerIds)
+}
+
 // PeriodicCheck checks periodically a condition, and reports
 // the cumulative consecutive period the condition was fulfilled.
 type PeriodicCheck struct {
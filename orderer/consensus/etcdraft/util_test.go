@@ -25,6 +25,8 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	etcdraftproto "github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/onsi/gomega"
@@ -59,6 +61,23 @@ func TestIsConsenterOfChannel(t *testing.T) {
 		assert.NoError(t, err)
 		return block
 	}
+	bftBlock := func() *common.Block {
+		block := validBlock()
+		env := protoutil.UnmarshalEnvelopeOrPanic(block.Data.Data[0])
+		payload := protoutil.UnmarshalPayloadOrPanic(env.Payload)
+		configEnv := &common.ConfigEnvelope{}
+		assert.NoError(t, proto.Unmarshal(payload.Data, configEnv))
+		configEnv.Config.ChannelGroup.Groups["Orderer"].Values["ConsensusType"].Value = protoutil.MarshalOrPanic(&orderer.ConsensusType{
+			Type: "BFT",
+			Metadata: protoutil.MarshalOrPanic(&bft.ConfigMetadata{
+				Consenters: []*bft.Consenter{{Id: 1, ServerTlsCert: certInsideConfigBlock}},
+			}),
+		})
+		payload.Data = protoutil.MarshalOrPanic(configEnv)
+		env.Payload = protoutil.MarshalOrPanic(payload)
+		block.Data.Data[0] = protoutil.MarshalOrPanic(env)
+		return block
+	}
 	for _, testCase := range []struct {
 		name          string
 		expectedError string
@@ -97,6 +116,17 @@ func TestIsConsenterOfChannel(t *testing.T) {
 			configBlock: validBlock(),
 			certificate: certInsideConfigBlock,
 		},
+		{
+			name:          "BFT config block with cert mismatch",
+			configBlock:   bftBlock(),
+			certificate:   certInsideConfigBlock[2:],
+			expectedError: cluster.ErrNotInChannel.Error(),
+		},
+		{
+			name:        "BFT config block with matching cert",
+			configBlock: bftBlock(),
+			certificate: certInsideConfigBlock,
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			err := ConsenterCertificate(testCase.certificate).IsConsenterOfChannel(testCase.configBlock)
@@ -550,6 +580,32 @@ func TestCheckConfigMetadata(t *testing.T) {
 	}
 	assert.Nil(t, CheckConfigMetadata(goodMetadata))
 
+	learnerServerPair, err := tlsCA.NewServerCertKeyPair("localhost")
+	if err != nil {
+		panic(err)
+	}
+	learnerClientPair, err := tlsCA.NewClientCertKeyPair()
+	if err != nil {
+		panic(err)
+	}
+	learnerConsenter := &etcdraftproto.Consenter{
+		Host:          "host2",
+		Port:          10002,
+		ClientTlsCert: learnerClientPair.Cert,
+		ServerTlsCert: learnerServerPair.Cert,
+	}
+
+	// a consenter may join as a learner as long as a voter is left
+	goodMetadata = &etcdraftproto.ConfigMetadata{
+		Options: validOptions,
+		Consenters: []*etcdraftproto.Consenter{
+			singleConsenter,
+			learnerConsenter,
+		},
+		LearnerClientTlsCerts: [][]byte{learnerClientPair.Cert},
+	}
+	assert.Nil(t, CheckConfigMetadata(goodMetadata))
+
 	// test variety of bad metadata
 	for _, testCase := range []struct {
 		description string
@@ -681,9 +737,181 @@ func TestCheckConfigMetadata(t *testing.T) {
 			},
 			errRegex: "duplicate consenter",
 		},
+		{
+			description: "learner is not a consenter",
+			metadata: &etcdraftproto.ConfigMetadata{
+				Options: validOptions,
+				Consenters: []*etcdraftproto.Consenter{
+					singleConsenter,
+				},
+				LearnerClientTlsCerts: [][]byte{[]byte("unknown")},
+			},
+			errRegex: "learner is not a consenter",
+		},
+		{
+			description: "all consenters are learners",
+			metadata: &etcdraftproto.ConfigMetadata{
+				Options: validOptions,
+				Consenters: []*etcdraftproto.Consenter{
+					singleConsenter,
+				},
+				LearnerClientTlsCerts: [][]byte{clientCert},
+			},
+			errRegex: "no voter left among the consenters",
+		},
+		{
+			description: "metadata has duplicate learners",
+			metadata: &etcdraftproto.ConfigMetadata{
+				Options: validOptions,
+				Consenters: []*etcdraftproto.Consenter{
+					singleConsenter,
+					learnerConsenter,
+				},
+				LearnerClientTlsCerts: [][]byte{learnerClientPair.Cert, learnerClientPair.Cert},
+			},
+			errRegex: "duplicate learner",
+		},
 	} {
 		err := CheckConfigMetadata(testCase.metadata)
 		assert.NotNil(t, err, testCase.description)
 		assert.Regexp(t, testCase.errRegex, err)
 	}
 }
+
+func TestMetadataFromConfigValue(t *testing.T) {
+	metadata := &etcdraftproto.ConfigMetadata{
+		Consenters: []*etcdraftproto.Consenter{{Host: "localhost", Port: 7050}},
+	}
+
+	for _, consensusType := range []string{"", "etcdraft"} {
+		m, err := MetadataFromConfigValue(&common.ConfigValue{
+			Value: protoutil.MarshalOrPanic(&orderer.ConsensusType{Type: consensusType, Metadata: protoutil.MarshalOrPanic(metadata)}),
+		})
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(metadata, m))
+	}
+
+	// migration to another consensus type
+	m, err := MetadataFromConfigValue(&common.ConfigValue{
+		Value: protoutil.MarshalOrPanic(&orderer.ConsensusType{
+			Type:     "BFT",
+			Metadata: protoutil.MarshalOrPanic(&bft.ConfigMetadata{Consenters: []*bft.Consenter{{Id: 1}}}),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestComputeMembershipChangesWithLearners(t *testing.T) {
+	c1 := &etcdraftproto.Consenter{ClientTlsCert: []byte("c1"), ServerTlsCert: []byte("s1")}
+	c2 := &etcdraftproto.Consenter{ClientTlsCert: []byte("c2"), ServerTlsCert: []byte("s2")}
+	c3 := &etcdraftproto.Consenter{ClientTlsCert: []byte("c3"), ServerTlsCert: []byte("s3")}
+	c4 := &etcdraftproto.Consenter{ClientTlsCert: []byte("c4"), ServerTlsCert: []byte("s4")}
+
+	for _, testCase := range []struct {
+		description     string
+		oldMetadata     *etcdraftproto.BlockMetadata
+		oldConsenters   map[uint64]*etcdraftproto.Consenter
+		newConsenters   []*etcdraftproto.Consenter
+		newLearners     [][]byte
+		expectedAdded   int
+		expectedRemoved int
+		expectedPromote int
+		expectedLearner []uint64
+		expectedErr     string
+	}{
+		{
+			description:     "add a learner",
+			oldMetadata:     &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4},
+			oldConsenters:   map[uint64]*etcdraftproto.Consenter{1: c1, 2: c2, 3: c3},
+			newConsenters:   []*etcdraftproto.Consenter{c1, c2, c3, c4},
+			newLearners:     [][]byte{c4.ClientTlsCert},
+			expectedAdded:   1,
+			expectedLearner: []uint64{4},
+		},
+		{
+			description:     "keep a learner",
+			oldMetadata:     &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3, 4}, LearnerIds: []uint64{4}, NextConsenterId: 5},
+			oldConsenters:   map[uint64]*etcdraftproto.Consenter{1: c1, 2: c2, 3: c3, 4: c4},
+			newConsenters:   []*etcdraftproto.Consenter{c1, c2, c3, c4},
+			newLearners:     [][]byte{c4.ClientTlsCert},
+			expectedLearner: []uint64{4},
+		},
+		{
+			description:     "promote a learner",
+			oldMetadata:     &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3, 4}, LearnerIds: []uint64{4}, NextConsenterId: 5},
+			oldConsenters:   map[uint64]*etcdraftproto.Consenter{1: c1, 2: c2, 3: c3, 4: c4},
+			newConsenters:   []*etcdraftproto.Consenter{c1, c2, c3, c4},
+			expectedPromote: 1,
+		},
+		{
+			description:   "voters cannot be demoted",
+			oldMetadata:   &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4},
+			oldConsenters: map[uint64]*etcdraftproto.Consenter{1: c1, 2: c2, 3: c3},
+			newConsenters: []*etcdraftproto.Consenter{c1, c2, c3},
+			newLearners:   [][]byte{c3.ClientTlsCert},
+		},
+		{
+			description:   "add a node and promote a learner at once",
+			oldMetadata:   &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, LearnerIds: []uint64{3}, NextConsenterId: 4},
+			oldConsenters: map[uint64]*etcdraftproto.Consenter{1: c1, 2: c2, 3: c3},
+			newConsenters: []*etcdraftproto.Consenter{c1, c2, c3, c4},
+			expectedErr:   "update of more than one consenter at a time is not supported",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			changes, err := ComputeMembershipChanges(testCase.oldMetadata, testCase.oldConsenters, testCase.newConsenters, testCase.newLearners)
+			if testCase.expectedErr != "" {
+				assert.Contains(t, err.Error(), testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, changes.AddedNodes, testCase.expectedAdded)
+			assert.Len(t, changes.RemovedNodes, testCase.expectedRemoved)
+			assert.Len(t, changes.PromotedNodes, testCase.expectedPromote)
+			assert.Equal(t, testCase.expectedLearner, changes.NewBlockMetadata.LearnerIds)
+		})
+	}
+}
+
+func TestConfChangeWithLearners(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		metadata    *etcdraftproto.BlockMetadata
+		confState   *raftpb.ConfState
+		expected    *raftpb.ConfChange
+		inSync      bool
+	}{
+		{
+			description: "add a learner",
+			metadata:    &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3, 4}, LearnerIds: []uint64{4}},
+			confState:   &raftpb.ConfState{Nodes: []uint64{1, 2, 3}},
+			expected:    &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 4},
+		},
+		{
+			description: "promote a learner",
+			metadata:    &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3, 4}},
+			confState:   &raftpb.ConfState{Nodes: []uint64{1, 2, 3}, Learners: []uint64{4}},
+			expected:    &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 4},
+		},
+		{
+			description: "remove a learner",
+			metadata:    &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}},
+			confState:   &raftpb.ConfState{Nodes: []uint64{1, 2, 3}, Learners: []uint64{4}},
+			expected:    &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 4},
+		},
+		{
+			description: "learner in sync",
+			metadata:    &etcdraftproto.BlockMetadata{ConsenterIds: []uint64{1, 2, 3, 4}, LearnerIds: []uint64{4}},
+			confState:   &raftpb.ConfState{Nodes: []uint64{1, 2, 3}, Learners: []uint64{4}},
+			inSync:      true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.inSync, ConfStateInSync(testCase.metadata, testCase.confState))
+			if !testCase.inSync {
+				assert.Equal(t, testCase.expected, ConfChange(testCase.metadata, testCase.confState))
+			}
+		})
+	}
+}
@@ -160,10 +160,14 @@ type StaticCollectionConfig struct {
 	// can write the private data (if set to true), or even non members can
 	// write the data (if set to false, for example if you want to implement more granular
 	// access logic in the chaincode)
-	MemberOnlyWrite      bool     `protobuf:"varint,7,opt,name=member_only_write,json=memberOnlyWrite,proto3" json:"member_only_write,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MemberOnlyWrite bool `protobuf:"varint,7,opt,name=member_only_write,json=memberOnlyWrite,proto3" json:"member_only_write,omitempty"`
+	// The endorsement policy of the writes to the collection. If set, it
+	// supersedes the chaincode endorsement policy for the transactions
+	// writing to the collection
+	EndorsementPolicy    *CollectionEndorsementPolicy `protobuf:"bytes,8,opt,name=endorsement_policy,json=endorsementPolicy,proto3" json:"endorsement_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
 func (m *StaticCollectionConfig) Reset()         { *m = StaticCollectionConfig{} }
@@ -240,6 +244,13 @@ func (m *StaticCollectionConfig) GetMemberOnlyWrite() bool {
 	return false
 }
 
+func (m *StaticCollectionConfig) GetEndorsementPolicy() *CollectionEndorsementPolicy {
+	if m != nil {
+		return m.EndorsementPolicy
+	}
+	return nil
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -374,48 +385,138 @@ func (m *CollectionCriteria) GetNamespace() string {
 	return ""
 }
 
+// CollectionEndorsementPolicy captures the policy types that can be used as
+// the endorsement policy of a collection. It is wire compatible with the
+// ApplicationPolicy message of the peer protos, so that its serialized form
+// can be used wherever a serialized ApplicationPolicy is expected.
+type CollectionEndorsementPolicy struct {
+	// Types that are valid to be assigned to Type:
+	//	*CollectionEndorsementPolicy_SignaturePolicy
+	//	*CollectionEndorsementPolicy_ChannelConfigPolicyReference
+	Type                 isCollectionEndorsementPolicy_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *CollectionEndorsementPolicy) Reset()         { *m = CollectionEndorsementPolicy{} }
+func (m *CollectionEndorsementPolicy) String() string { return proto.CompactTextString(m) }
+func (*CollectionEndorsementPolicy) ProtoMessage()    {}
+func (*CollectionEndorsementPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89f245fc544906c7, []int{5}
+}
+
+func (m *CollectionEndorsementPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionEndorsementPolicy.Unmarshal(m, b)
+}
+func (m *CollectionEndorsementPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionEndorsementPolicy.Marshal(b, m, deterministic)
+}
+func (m *CollectionEndorsementPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionEndorsementPolicy.Merge(m, src)
+}
+func (m *CollectionEndorsementPolicy) XXX_Size() int {
+	return xxx_messageInfo_CollectionEndorsementPolicy.Size(m)
+}
+func (m *CollectionEndorsementPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionEndorsementPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionEndorsementPolicy proto.InternalMessageInfo
+
+type isCollectionEndorsementPolicy_Type interface {
+	isCollectionEndorsementPolicy_Type()
+}
+
+type CollectionEndorsementPolicy_SignaturePolicy struct {
+	SignaturePolicy *SignaturePolicyEnvelope `protobuf:"bytes,1,opt,name=signature_policy,json=signaturePolicy,proto3,oneof"`
+}
+
+type CollectionEndorsementPolicy_ChannelConfigPolicyReference struct {
+	ChannelConfigPolicyReference string `protobuf:"bytes,2,opt,name=channel_config_policy_reference,json=channelConfigPolicyReference,proto3,oneof"`
+}
+
+func (*CollectionEndorsementPolicy_SignaturePolicy) isCollectionEndorsementPolicy_Type() {}
+
+func (*CollectionEndorsementPolicy_ChannelConfigPolicyReference) isCollectionEndorsementPolicy_Type() {
+}
+
+func (m *CollectionEndorsementPolicy) GetType() isCollectionEndorsementPolicy_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *CollectionEndorsementPolicy) GetSignaturePolicy() *SignaturePolicyEnvelope {
+	if x, ok := m.GetType().(*CollectionEndorsementPolicy_SignaturePolicy); ok {
+		return x.SignaturePolicy
+	}
+	return nil
+}
+
+func (m *CollectionEndorsementPolicy) GetChannelConfigPolicyReference() string {
+	if x, ok := m.GetType().(*CollectionEndorsementPolicy_ChannelConfigPolicyReference); ok {
+		return x.ChannelConfigPolicyReference
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CollectionEndorsementPolicy) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CollectionEndorsementPolicy_SignaturePolicy)(nil),
+		(*CollectionEndorsementPolicy_ChannelConfigPolicyReference)(nil),
+	}
+}
+
 func init() {
 	proto.RegisterType((*CollectionConfigPackage)(nil), "common.CollectionConfigPackage")
 	proto.RegisterType((*CollectionConfig)(nil), "common.CollectionConfig")
 	proto.RegisterType((*StaticCollectionConfig)(nil), "common.StaticCollectionConfig")
 	proto.RegisterType((*CollectionPolicyConfig)(nil), "common.CollectionPolicyConfig")
 	proto.RegisterType((*CollectionCriteria)(nil), "common.CollectionCriteria")
+	proto.RegisterType((*CollectionEndorsementPolicy)(nil), "common.CollectionEndorsementPolicy")
 }
 
 func init() { proto.RegisterFile("common/collection.proto", fileDescriptor_89f245fc544906c7) }
 
 var fileDescriptor_89f245fc544906c7 = []byte{
-	// 500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x5f, 0x6b, 0xdb, 0x3c,
-	0x14, 0xc6, 0xeb, 0x36, 0x7f, 0x5e, 0x9f, 0xf0, 0xae, 0xa9, 0xca, 0x52, 0x33, 0x46, 0x17, 0xc2,
-	0x2e, 0xcc, 0x36, 0x9c, 0xd1, 0x7d, 0x83, 0x86, 0x41, 0xc7, 0x02, 0x0b, 0xee, 0x60, 0xd0, 0x1b,
-	0xa3, 0xc8, 0xa7, 0x8e, 0xa8, 0x2d, 0xb9, 0xb2, 0x92, 0xc5, 0x97, 0xfb, 0x8a, 0xfb, 0x44, 0x23,
-	0x92, 0x1d, 0xbb, 0x21, 0x77, 0xd1, 0xf3, 0xfc, 0xce, 0xc9, 0xd1, 0x79, 0x64, 0xb8, 0x62, 0x32,
-	0xcb, 0xa4, 0x98, 0x32, 0x99, 0xa6, 0xc8, 0x34, 0x97, 0x22, 0xc8, 0x95, 0xd4, 0x92, 0xf4, 0xac,
-	0xf1, 0xe6, 0x75, 0x05, 0xe4, 0x32, 0xe5, 0x8c, 0x63, 0x61, 0xed, 0xc9, 0x77, 0xb8, 0x9a, 0xed,
-	0x4b, 0x66, 0x52, 0x3c, 0xf2, 0x64, 0x41, 0xd9, 0x13, 0x4d, 0x90, 0x7c, 0x86, 0x1e, 0x33, 0x82,
-	0xe7, 0x8c, 0xcf, 0xfc, 0xc1, 0x8d, 0x17, 0xd8, 0x16, 0xc1, 0x61, 0x41, 0x58, 0x71, 0x93, 0x12,
-	0x86, 0x87, 0x1e, 0x79, 0x00, 0xaf, 0xd0, 0x54, 0x73, 0x16, 0x35, 0xa3, 0x45, 0xfb, 0xbe, 0x8e,
-	0x3f, 0xb8, 0xb9, 0xae, 0xfb, 0xde, 0x1b, 0xee, 0xb0, 0xc3, 0xdd, 0x49, 0x38, 0x2a, 0x8e, 0x3a,
-	0xb7, 0x2e, 0xf4, 0x73, 0x5a, 0xa6, 0x92, 0xc6, 0x93, 0xbf, 0xa7, 0x30, 0x3a, 0x5e, 0x4f, 0x08,
-	0x74, 0x04, 0xcd, 0xd0, 0xfc, 0x9b, 0x1b, 0x9a, 0xdf, 0x64, 0x0e, 0x24, 0xc3, 0x6c, 0x89, 0x2a,
-	0x92, 0x2a, 0x29, 0x22, 0xb3, 0x94, 0xd2, 0x3b, 0x7d, 0x39, 0x4f, 0xd3, 0x69, 0x61, 0xfc, 0xea,
-	0xb6, 0x43, 0x5b, 0xf9, 0x43, 0x25, 0x85, 0xd5, 0x49, 0x00, 0x97, 0x0a, 0x9f, 0xd7, 0x5c, 0x61,
-	0x1c, 0xe5, 0x88, 0x2a, 0x62, 0x72, 0x2d, 0xb4, 0x77, 0x36, 0x76, 0xfc, 0x6e, 0x78, 0x51, 0x5b,
-	0x0b, 0x44, 0x35, 0xdb, 0x19, 0xe4, 0x13, 0x90, 0x8c, 0x6e, 0x79, 0xb6, 0xce, 0xda, 0x78, 0xc7,
-	0xe0, 0xc3, 0xca, 0x69, 0xe8, 0x09, 0xfc, 0xbf, 0x4c, 0x25, 0x7b, 0x8a, 0xb4, 0x8c, 0x52, 0xbe,
-	0x41, 0xaf, 0x3b, 0x76, 0xfc, 0x4e, 0x38, 0x30, 0xe2, 0x4f, 0x39, 0xe7, 0x1b, 0x24, 0x3e, 0x0c,
-	0xeb, 0xfb, 0x88, 0xb4, 0x8c, 0x14, 0xd2, 0xd8, 0xeb, 0x8d, 0x1d, 0xff, 0xbf, 0xf0, 0x55, 0x35,
-	0xad, 0x48, 0xcb, 0x10, 0x69, 0x4c, 0x3e, 0xc0, 0x45, 0x9b, 0xfc, 0xad, 0xb8, 0x46, 0xaf, 0x6f,
-	0xd0, 0xf3, 0x06, 0xfd, 0xb5, 0x93, 0x27, 0xcf, 0x30, 0x3a, 0xbe, 0x03, 0x32, 0x87, 0x61, 0xc1,
-	0x13, 0x41, 0xf5, 0x5a, 0x61, 0xbd, 0x3d, 0x9b, 0xe6, 0xbb, 0x7d, 0x9a, 0xb5, 0x6f, 0x0b, 0xbf,
-	0x8a, 0x0d, 0xa6, 0x32, 0xc7, 0xbb, 0x93, 0xf0, 0xbc, 0x78, 0x69, 0xb5, 0x73, 0xfc, 0xe3, 0x00,
-	0x69, 0x25, 0xb8, 0x1b, 0x43, 0x71, 0x4a, 0x3c, 0xe8, 0xb3, 0x15, 0x15, 0x02, 0xd3, 0x2a, 0xc6,
-	0xfa, 0x48, 0x2e, 0xa1, 0xab, 0xb7, 0x11, 0x8f, 0x4d, 0x78, 0x6e, 0xd8, 0xd1, 0xdb, 0x6f, 0x31,
-	0xb9, 0x06, 0x68, 0x5e, 0x9b, 0xc9, 0xc1, 0x0d, 0x5b, 0x0a, 0x79, 0x0b, 0xee, 0xee, 0x19, 0x14,
-	0x39, 0x65, 0x68, 0xf6, 0xee, 0x86, 0x8d, 0x70, 0x7b, 0x0f, 0xef, 0xa5, 0x4a, 0x82, 0x55, 0x99,
-	0xa3, 0x4a, 0x31, 0x4e, 0x50, 0x05, 0x8f, 0x74, 0xa9, 0x38, 0xb3, 0xdf, 0x4c, 0x51, 0xdd, 0xf0,
-	0xe1, 0x63, 0xc2, 0xf5, 0x6a, 0xbd, 0xdc, 0x1d, 0xa7, 0x2d, 0x78, 0x6a, 0xe1, 0xa9, 0x85, 0xa7,
-	0x16, 0x5e, 0xf6, 0xcc, 0xf1, 0xcb, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb1, 0x4b, 0x91, 0x18,
-	0xa9, 0x03, 0x00, 0x00,
+	// 574 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xd1, 0x4e, 0xdb, 0x3c,
+	0x14, 0xc7, 0xc9, 0x47, 0x29, 0xf4, 0xa0, 0x6f, 0x14, 0xa3, 0x41, 0xb4, 0x21, 0xa8, 0xba, 0x5d,
+	0x44, 0xdb, 0x94, 0x4e, 0xec, 0x0d, 0x40, 0x68, 0x4c, 0x43, 0x1a, 0x32, 0x93, 0x26, 0x71, 0x13,
+	0xb9, 0xce, 0x21, 0x58, 0x24, 0x76, 0x70, 0x5c, 0x46, 0x2e, 0xf7, 0x32, 0x7b, 0x8a, 0x3d, 0xdc,
+	0x54, 0xdb, 0x21, 0xa1, 0xab, 0x76, 0xb5, 0xbb, 0xfa, 0xfc, 0x7f, 0xe7, 0xe4, 0x9c, 0xe3, 0xbf,
+	0x0b, 0x7b, 0x5c, 0x15, 0x85, 0x92, 0x13, 0xae, 0xf2, 0x1c, 0xb9, 0x11, 0x4a, 0xc6, 0xa5, 0x56,
+	0x46, 0x91, 0xbe, 0x13, 0x5e, 0x3c, 0xf7, 0x40, 0xa9, 0x72, 0xc1, 0x05, 0x56, 0x4e, 0x1e, 0x7f,
+	0x86, 0xbd, 0x93, 0xc7, 0x94, 0x13, 0x25, 0xaf, 0x45, 0x76, 0xc1, 0xf8, 0x2d, 0xcb, 0x90, 0xbc,
+	0x87, 0x3e, 0xb7, 0x81, 0x30, 0x18, 0xad, 0x46, 0x9b, 0x47, 0x61, 0xec, 0x4a, 0xc4, 0x8b, 0x09,
+	0xd4, 0x73, 0xe3, 0x1a, 0x86, 0x8b, 0x1a, 0xb9, 0x82, 0xb0, 0x32, 0xcc, 0x08, 0x9e, 0xb4, 0xad,
+	0x25, 0x8f, 0x75, 0x83, 0x68, 0xf3, 0xe8, 0xa0, 0xa9, 0x7b, 0x69, 0xb9, 0xc5, 0x0a, 0x67, 0x2b,
+	0x74, 0xb7, 0x5a, 0xaa, 0x1c, 0x0f, 0x60, 0xbd, 0x64, 0x75, 0xae, 0x58, 0x3a, 0xfe, 0xb9, 0x0a,
+	0xbb, 0xcb, 0xf3, 0x09, 0x81, 0x9e, 0x64, 0x05, 0xda, 0xaf, 0x0d, 0xa8, 0xfd, 0x4d, 0xce, 0x81,
+	0x14, 0x58, 0x4c, 0x51, 0x27, 0x4a, 0x67, 0x55, 0x62, 0x97, 0x52, 0x87, 0xff, 0x3d, 0xed, 0xa7,
+	0xad, 0x74, 0x61, 0x75, 0x3f, 0xed, 0xd0, 0x65, 0x7e, 0xd1, 0x59, 0xe5, 0xe2, 0x24, 0x86, 0x1d,
+	0x8d, 0x77, 0x33, 0xa1, 0x31, 0x4d, 0x4a, 0x44, 0x9d, 0x70, 0x35, 0x93, 0x26, 0x5c, 0x1d, 0x05,
+	0xd1, 0x1a, 0xdd, 0x6e, 0xa4, 0x0b, 0x44, 0x7d, 0x32, 0x17, 0xc8, 0x3b, 0x20, 0x05, 0x7b, 0x10,
+	0xc5, 0xac, 0xe8, 0xe2, 0x3d, 0x8b, 0x0f, 0xbd, 0xd2, 0xd2, 0x63, 0xf8, 0x7f, 0x9a, 0x2b, 0x7e,
+	0x9b, 0x18, 0x95, 0xe4, 0xe2, 0x1e, 0xc3, 0xb5, 0x51, 0x10, 0xf5, 0xe8, 0xa6, 0x0d, 0x7e, 0x55,
+	0xe7, 0xe2, 0x1e, 0x49, 0x04, 0xc3, 0x66, 0x1e, 0x99, 0xd7, 0x89, 0x46, 0x96, 0x86, 0xfd, 0x51,
+	0x10, 0x6d, 0xd0, 0x67, 0xbe, 0x5b, 0x99, 0xd7, 0x14, 0x59, 0x4a, 0xde, 0xc0, 0x76, 0x97, 0xfc,
+	0xae, 0x85, 0xc1, 0x70, 0xdd, 0xa2, 0x5b, 0x2d, 0xfa, 0x6d, 0x1e, 0x26, 0x14, 0x08, 0xca, 0x54,
+	0xe9, 0x0a, 0x0b, 0x94, 0xa6, 0xd9, 0xd2, 0x86, 0xdd, 0xd2, 0xab, 0x3f, 0xb7, 0x74, 0xda, 0xb2,
+	0x6e, 0x31, 0x74, 0x1b, 0x17, 0x43, 0xe3, 0x3b, 0xd8, 0x5d, 0xbe, 0x57, 0x72, 0x0e, 0xc3, 0x4a,
+	0x64, 0x92, 0x99, 0x99, 0xc6, 0xe6, 0x5b, 0xce, 0x21, 0x87, 0x8f, 0x0e, 0x69, 0x74, 0x97, 0x78,
+	0x2a, 0xef, 0x31, 0x57, 0x25, 0x9e, 0xad, 0xd0, 0xad, 0xea, 0xa9, 0xd4, 0xf5, 0xc6, 0x8f, 0x00,
+	0x48, 0xc7, 0x15, 0xf3, 0xd1, 0xb4, 0x60, 0x24, 0x84, 0x75, 0x7e, 0xc3, 0xa4, 0xc4, 0xdc, 0x5b,
+	0xa3, 0x39, 0x92, 0x1d, 0x58, 0x33, 0x0f, 0x89, 0x48, 0xad, 0x21, 0x06, 0xb4, 0x67, 0x1e, 0x3e,
+	0xa5, 0xe4, 0x00, 0xa0, 0x75, 0xb0, 0xbd, 0xdb, 0x01, 0xed, 0x44, 0xc8, 0x3e, 0x0c, 0xe6, 0xd6,
+	0xaa, 0x4a, 0xc6, 0xd1, 0xde, 0xe5, 0x80, 0xb6, 0x81, 0xf1, 0xaf, 0x00, 0x5e, 0xfe, 0x65, 0x53,
+	0xff, 0x76, 0x78, 0xf2, 0x11, 0x0e, 0xfd, 0x2c, 0xfe, 0xa9, 0xf9, 0x92, 0x89, 0xc6, 0x6b, 0xd4,
+	0x28, 0x39, 0xba, 0xd1, 0xce, 0x56, 0xe8, 0xbe, 0x07, 0xfd, 0xdb, 0x77, 0xf7, 0xd6, 0x50, 0xc7,
+	0x7d, 0xe8, 0x99, 0xba, 0xc4, 0xe3, 0x4b, 0x78, 0xad, 0x74, 0x16, 0xdf, 0xd4, 0x25, 0xea, 0x1c,
+	0xd3, 0x0c, 0x75, 0x7c, 0xcd, 0xa6, 0x5a, 0x70, 0xf7, 0x37, 0x52, 0xf9, 0x1e, 0xaf, 0xde, 0x66,
+	0xc2, 0xdc, 0xcc, 0xa6, 0xf3, 0xe3, 0xa4, 0x03, 0x4f, 0x1c, 0x3c, 0x71, 0xf0, 0xc4, 0xc1, 0xd3,
+	0xbe, 0x3d, 0x7e, 0xf8, 0x3d, 0x00, 0x7e, 0xf2, 0xcc, 0xe2, 0xbc, 0x04, 0x00, 0x00,
 }
@@ -142,9 +142,84 @@ func (m *KeyModification) GetIsDelete() bool {
 	return false
 }
 
+// KeyHashModification -- QueryResult for private data hash history query. Holds the block number,
+// transaction ID, hash of the value, timestamp, and delete marker of a modification of the hash of
+// a private data key.
+type KeyHashModification struct {
+	BlockNum             uint64               `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxId                 string               `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	ValueHash            []byte               `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsDelete             bool                 `protobuf:"varint,5,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KeyHashModification) Reset()         { *m = KeyHashModification{} }
+func (m *KeyHashModification) String() string { return proto.CompactTextString(m) }
+func (*KeyHashModification) ProtoMessage()    {}
+func (*KeyHashModification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f8ee2fe66594a8f2, []int{2}
+}
+
+func (m *KeyHashModification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyHashModification.Unmarshal(m, b)
+}
+func (m *KeyHashModification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyHashModification.Marshal(b, m, deterministic)
+}
+func (m *KeyHashModification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyHashModification.Merge(m, src)
+}
+func (m *KeyHashModification) XXX_Size() int {
+	return xxx_messageInfo_KeyHashModification.Size(m)
+}
+func (m *KeyHashModification) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyHashModification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyHashModification proto.InternalMessageInfo
+
+func (m *KeyHashModification) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *KeyHashModification) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *KeyHashModification) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *KeyHashModification) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *KeyHashModification) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*KV)(nil), "queryresult.KV")
 	proto.RegisterType((*KeyModification)(nil), "queryresult.KeyModification")
+	proto.RegisterType((*KeyHashModification)(nil), "queryresult.KeyHashModification")
 }
 
 func init() {
@@ -152,23 +227,27 @@ func init() {
 }
 
 var fileDescriptor_f8ee2fe66594a8f2 = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x51, 0x4f, 0x4b, 0xc3, 0x30,
-	0x1c, 0xa5, 0xdd, 0x26, 0x6b, 0x26, 0x28, 0xd1, 0x43, 0x99, 0x82, 0x65, 0xa7, 0x9e, 0x12, 0xd1,
-	0x83, 0x9e, 0xc5, 0x8b, 0x0e, 0x2f, 0x45, 0x3c, 0x78, 0x29, 0x69, 0xfb, 0x6b, 0x17, 0xda, 0x2e,
-	0x35, 0x7f, 0xc6, 0xfa, 0x39, 0xfc, 0xc2, 0x62, 0xb2, 0xd9, 0x82, 0xb7, 0xbc, 0xf7, 0x7b, 0xef,
-	0xf1, 0x78, 0x41, 0x71, 0x03, 0x45, 0x05, 0x92, 0x7e, 0x19, 0x90, 0xbd, 0x04, 0x65, 0x1a, 0x4d,
-	0xeb, 0x5d, 0x6a, 0x61, 0xea, 0x30, 0xe9, 0xa4, 0xd0, 0x02, 0x2f, 0x46, 0x92, 0xe5, 0x4d, 0x25,
-	0x44, 0xd5, 0x00, 0xb5, 0xa7, 0xcc, 0x94, 0x54, 0xf3, 0x16, 0x94, 0x66, 0x6d, 0xe7, 0xd4, 0xab,
-	0x57, 0xe4, 0xaf, 0x3f, 0xf0, 0x35, 0x0a, 0xb6, 0xac, 0x05, 0xd5, 0xb1, 0x1c, 0x42, 0x2f, 0xf2,
-	0xe2, 0x20, 0x19, 0x08, 0x7c, 0x8e, 0x26, 0x35, 0xf4, 0xa1, 0x6f, 0xf9, 0xdf, 0x27, 0xbe, 0x44,
-	0xb3, 0x1d, 0x6b, 0x0c, 0x84, 0x93, 0xc8, 0x8b, 0x4f, 0x13, 0x07, 0x56, 0xdf, 0x1e, 0x3a, 0x5b,
-	0x43, 0xff, 0x26, 0x0a, 0x5e, 0xf2, 0x9c, 0x69, 0x2e, 0xb6, 0xf8, 0x02, 0xcd, 0xf4, 0x3e, 0xe5,
-	0xc5, 0x21, 0x75, 0xaa, 0xf7, 0x2f, 0xc5, 0x60, 0xf7, 0x47, 0x76, 0xfc, 0x88, 0x82, 0xbf, 0x76,
-	0x36, 0x78, 0x71, 0xb7, 0x24, 0xae, 0x3f, 0x39, 0xf6, 0x27, 0xef, 0x47, 0x45, 0x32, 0x88, 0xf1,
-	0x15, 0x0a, 0xb8, 0x4a, 0x0b, 0x68, 0x40, 0x43, 0x38, 0x8d, 0xbc, 0x78, 0x9e, 0xcc, 0xb9, 0x7a,
-	0xb6, 0xf8, 0xa9, 0x46, 0xb7, 0x42, 0x56, 0x64, 0xd3, 0x77, 0x20, 0xdd, 0x88, 0xa4, 0x64, 0x99,
-	0xe4, 0xb9, 0x0b, 0x55, 0xe4, 0x40, 0x8e, 0x66, 0xfb, 0x7c, 0xa8, 0xb8, 0xde, 0x98, 0x8c, 0xe4,
-	0xa2, 0xa5, 0x23, 0x23, 0x75, 0x46, 0xb7, 0xa6, 0xa2, 0xff, 0xbf, 0x24, 0x3b, 0xb1, 0xa7, 0xfb,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa2, 0xb7, 0x3e, 0x86, 0xaf, 0x01, 0x00, 0x00,
+	// 347 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x41, 0x4f, 0xab, 0x40,
+	0x14, 0x85, 0x03, 0xa5, 0x2f, 0xe5, 0xf6, 0x25, 0xef, 0x85, 0xba, 0x20, 0xad, 0xc6, 0xa6, 0x2b,
+	0x56, 0x83, 0xd1, 0x85, 0xae, 0x8d, 0x0b, 0xb5, 0xd1, 0x05, 0x31, 0x2e, 0xdc, 0x90, 0x01, 0x6e,
+	0x61, 0x02, 0x74, 0x90, 0x19, 0x9a, 0xf2, 0x3b, 0xfc, 0x3d, 0xfe, 0x37, 0xd3, 0x99, 0x56, 0x68,
+	0xdc, 0xb9, 0xe3, 0x9e, 0x7b, 0xce, 0xc9, 0x47, 0xe6, 0x82, 0x57, 0x60, 0x92, 0x62, 0xed, 0xbf,
+	0x37, 0x58, 0xb7, 0x35, 0x8a, 0xa6, 0x90, 0x7e, 0xbe, 0x09, 0xd5, 0x18, 0xea, 0x99, 0x54, 0x35,
+	0x97, 0xdc, 0x19, 0xf7, 0x2c, 0xd3, 0xf3, 0x94, 0xf3, 0xb4, 0x40, 0x5f, 0xad, 0xa2, 0x66, 0xe5,
+	0x4b, 0x56, 0xa2, 0x90, 0xb4, 0xac, 0xb4, 0x7b, 0xf1, 0x08, 0xe6, 0xf2, 0xd5, 0x39, 0x05, 0x7b,
+	0x4d, 0x4b, 0x14, 0x15, 0x8d, 0xd1, 0x35, 0xe6, 0x86, 0x67, 0x07, 0x9d, 0xe0, 0xfc, 0x87, 0x41,
+	0x8e, 0xad, 0x6b, 0x2a, 0x7d, 0xf7, 0xe9, 0x9c, 0xc0, 0x70, 0x43, 0x8b, 0x06, 0xdd, 0xc1, 0xdc,
+	0xf0, 0xfe, 0x06, 0x7a, 0x58, 0x7c, 0x18, 0xf0, 0x6f, 0x89, 0xed, 0x13, 0x4f, 0xd8, 0x8a, 0xc5,
+	0x54, 0x32, 0xbe, 0x76, 0x26, 0x30, 0x94, 0xdb, 0x90, 0x25, 0xfb, 0x56, 0x4b, 0x6e, 0x1f, 0x92,
+	0x2e, 0x6e, 0xf6, 0xe2, 0xce, 0x0d, 0xd8, 0xdf, 0x74, 0xaa, 0x78, 0x7c, 0x39, 0x25, 0x9a, 0x9f,
+	0x1c, 0xf8, 0xc9, 0xcb, 0xc1, 0x11, 0x74, 0x66, 0x67, 0x06, 0x36, 0x13, 0x61, 0x82, 0x05, 0x4a,
+	0x74, 0xad, 0xb9, 0xe1, 0x8d, 0x82, 0x11, 0x13, 0x77, 0x6a, 0x5e, 0x7c, 0x1a, 0x30, 0x59, 0x62,
+	0x7b, 0x4f, 0x45, 0x76, 0x44, 0x36, 0x03, 0x3b, 0x2a, 0x78, 0x9c, 0x87, 0xeb, 0xa6, 0x54, 0x74,
+	0x56, 0x30, 0x52, 0xc2, 0x73, 0x53, 0x76, 0xd8, 0x66, 0x0f, 0xfb, 0x0c, 0x40, 0x91, 0x86, 0x19,
+	0x15, 0xd9, 0xfe, 0xd7, 0x6d, 0xa5, 0xec, 0xca, 0x8f, 0xf9, 0xad, 0x5f, 0xf3, 0x0f, 0x8f, 0xf9,
+	0x6f, 0x73, 0xb8, 0xe0, 0x75, 0x4a, 0xb2, 0xb6, 0xc2, 0x5a, 0x1f, 0x01, 0x59, 0xd1, 0xa8, 0x66,
+	0xb1, 0x2e, 0x15, 0x64, 0x2f, 0xf6, 0x9e, 0xfd, 0xed, 0x3a, 0x65, 0x32, 0x6b, 0x22, 0x12, 0xf3,
+	0xd2, 0xef, 0x05, 0x7d, 0x1d, 0xd4, 0xd7, 0x20, 0xfc, 0x9f, 0x27, 0x15, 0xfd, 0x51, 0xab, 0xab,
+	0xaf, 0x01, 0x00, 0xae, 0xac, 0x3a, 0x2e, 0x6f, 0x02, 0x00, 0x00,
 }
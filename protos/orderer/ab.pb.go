@@ -85,6 +85,35 @@ func (SeekInfo_SeekErrorResponse) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_79fce58dd8d86d62, []int{5, 1}
 }
 
+// SeekContentType indicates what content the deliver service should send for each block.  By default,
+// whole blocks are delivered.  If HEADER_WITH_SIG is specified, the blocks are delivered without their
+// data, carrying only the header and the metadata, so that a client can track the progress and the
+// signatures of the ordering service nodes it does not pull blocks from.
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK           SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_SIG SeekInfo_SeekContentType = 1
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_SIG",
+}
+
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":           0,
+	"HEADER_WITH_SIG": 1,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_79fce58dd8d86d62, []int{5, 2}
+}
+
 type BroadcastResponse struct {
 	// Status code, which may be used to programatically respond to success/failure
 	Status common.Status `protobuf:"varint,1,opt,name=status,proto3,enum=common.Status" json:"status,omitempty"`
@@ -339,6 +368,8 @@ type SeekInfo struct {
 	Stop                 *SeekPosition              `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
 	Behavior             SeekInfo_SeekBehavior      `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
 	ErrorResponse        SeekInfo_SeekErrorResponse `protobuf:"varint,4,opt,name=error_response,json=errorResponse,proto3,enum=orderer.SeekInfo_SeekErrorResponse" json:"error_response,omitempty"`
+	ContentType          SeekInfo_SeekContentType   `protobuf:"varint,5,opt,name=content_type,json=contentType,proto3,enum=orderer.SeekInfo_SeekContentType" json:"content_type,omitempty"`
+	Filter               *SeekFilter                `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -397,6 +428,102 @@ func (m *SeekInfo) GetErrorResponse() SeekInfo_SeekErrorResponse {
 	return SeekInfo_STRICT
 }
 
+func (m *SeekInfo) GetContentType() SeekInfo_SeekContentType {
+	if m != nil {
+		return m.ContentType
+	}
+	return SeekInfo_BLOCK
+}
+
+func (m *SeekInfo) GetFilter() *SeekFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// SeekFilter selects the transactions of the delivered blocks. A transaction matches the filter
+// if it matches every criterion that is set. The envelopes of the transactions that do not match
+// are removed from the delivered blocks, leaving their entry in the block data empty so that the
+// block metadata still applies, and blocks without any matching transaction are delivered as
+// empty markers so that clients can keep track of their position.
+type SeekFilter struct {
+	// The transaction invokes one of the chaincodes
+	ChaincodeNames []string `protobuf:"bytes,1,rep,name=chaincode_names,json=chaincodeNames,proto3" json:"chaincode_names,omitempty"`
+	// The transaction sets a chaincode event whose name matches the regular expression.
+	// Combined with chaincode_names, the event must be set by one of the chaincodes
+	ChaincodeEventNameRegex string `protobuf:"bytes,2,opt,name=chaincode_event_name_regex,json=chaincodeEventNameRegex,proto3" json:"chaincode_event_name_regex,omitempty"`
+	// The transaction has the given ID
+	TxId string `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The transaction is created by an identity of one of the MSPs
+	CreatorMspIds []string `protobuf:"bytes,4,rep,name=creator_msp_ids,json=creatorMspIds,proto3" json:"creator_msp_ids,omitempty"`
+	// The transaction has one of the validation codes, as defined by protos.TxValidationCode
+	ValidationCodes      []int32  `protobuf:"varint,5,rep,packed,name=validation_codes,json=validationCodes,proto3" json:"validation_codes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SeekFilter) Reset()         { *m = SeekFilter{} }
+func (m *SeekFilter) String() string { return proto.CompactTextString(m) }
+func (*SeekFilter) ProtoMessage()    {}
+func (*SeekFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79fce58dd8d86d62, []int{6}
+}
+
+func (m *SeekFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SeekFilter.Unmarshal(m, b)
+}
+func (m *SeekFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SeekFilter.Marshal(b, m, deterministic)
+}
+func (m *SeekFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeekFilter.Merge(m, src)
+}
+func (m *SeekFilter) XXX_Size() int {
+	return xxx_messageInfo_SeekFilter.Size(m)
+}
+func (m *SeekFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeekFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SeekFilter proto.InternalMessageInfo
+
+func (m *SeekFilter) GetChaincodeNames() []string {
+	if m != nil {
+		return m.ChaincodeNames
+	}
+	return nil
+}
+
+func (m *SeekFilter) GetChaincodeEventNameRegex() string {
+	if m != nil {
+		return m.ChaincodeEventNameRegex
+	}
+	return ""
+}
+
+func (m *SeekFilter) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *SeekFilter) GetCreatorMspIds() []string {
+	if m != nil {
+		return m.CreatorMspIds
+	}
+	return nil
+}
+
+func (m *SeekFilter) GetValidationCodes() []int32 {
+	if m != nil {
+		return m.ValidationCodes
+	}
+	return nil
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
@@ -411,7 +538,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_79fce58dd8d86d62, []int{6}
+	return fileDescriptor_79fce58dd8d86d62, []int{7}
 }
 
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
@@ -480,54 +607,69 @@ func (*DeliverResponse) XXX_OneofWrappers() []interface{} {
 func init() {
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 	proto.RegisterEnum("orderer.SeekInfo_SeekErrorResponse", SeekInfo_SeekErrorResponse_name, SeekInfo_SeekErrorResponse_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
 	proto.RegisterType((*BroadcastResponse)(nil), "orderer.BroadcastResponse")
 	proto.RegisterType((*SeekNewest)(nil), "orderer.SeekNewest")
 	proto.RegisterType((*SeekOldest)(nil), "orderer.SeekOldest")
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*SeekFilter)(nil), "orderer.SeekFilter")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 }
 
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_79fce58dd8d86d62) }
 
 var fileDescriptor_79fce58dd8d86d62 = []byte{
-	// 559 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x51, 0x6f, 0xda, 0x30,
-	0x10, 0xc7, 0x49, 0x47, 0xd3, 0xf6, 0xda, 0x52, 0x70, 0xd5, 0x0a, 0xf1, 0x30, 0x55, 0x99, 0xba,
-	0x31, 0x6d, 0x4b, 0x2a, 0x26, 0x4d, 0xda, 0xb4, 0x3d, 0x90, 0x16, 0x04, 0x1b, 0x82, 0xc9, 0xa4,
-	0x0f, 0xdb, 0x4b, 0x94, 0x84, 0x03, 0xb2, 0x42, 0x1c, 0x39, 0x81, 0xa9, 0x9f, 0x61, 0x5f, 0x65,
-	0x9f, 0x68, 0x9f, 0x66, 0xb2, 0xe3, 0x40, 0x69, 0xbb, 0x3d, 0x25, 0x77, 0xfe, 0xfd, 0xef, 0xfe,
-	0xf6, 0xd9, 0x50, 0x66, 0x7c, 0x84, 0x1c, 0xb9, 0xe5, 0xf9, 0x66, 0xcc, 0x59, 0xca, 0xc8, 0x8e,
-	0xca, 0xd4, 0x8e, 0x03, 0x36, 0x9f, 0xb3, 0xc8, 0xca, 0x3e, 0xd9, 0xaa, 0x31, 0x80, 0x8a, 0xcd,
-	0x99, 0x37, 0x0a, 0xbc, 0x24, 0xa5, 0x98, 0xc4, 0x2c, 0x4a, 0x90, 0x3c, 0x07, 0x3d, 0x49, 0xbd,
-	0x74, 0x91, 0x54, 0xb5, 0x33, 0xad, 0x5e, 0x6a, 0x94, 0x4c, 0xa5, 0x19, 0xca, 0x2c, 0x55, 0xab,
-	0x84, 0x40, 0x31, 0x8c, 0xc6, 0xac, 0xba, 0x75, 0xa6, 0xd5, 0xf7, 0xa8, 0xfc, 0x37, 0x0e, 0x00,
-	0x86, 0x88, 0x37, 0x7d, 0xfc, 0x89, 0x49, 0x9a, 0x47, 0x83, 0xd9, 0x48, 0x44, 0x2f, 0xe0, 0x50,
-	0x44, 0xc3, 0x18, 0x83, 0x70, 0x1c, 0xe2, 0x88, 0x9c, 0x82, 0x1e, 0x2d, 0xe6, 0x3e, 0x72, 0xd9,
-	0xa8, 0x48, 0x55, 0x64, 0xfc, 0xd6, 0xe0, 0x40, 0x90, 0x5f, 0x59, 0x12, 0xa6, 0x21, 0x8b, 0xc8,
-	0x1b, 0xd0, 0x23, 0x59, 0x51, 0x82, 0xfb, 0x8d, 0x63, 0x53, 0xed, 0xca, 0x5c, 0x37, 0xeb, 0x14,
-	0xa8, 0x82, 0x04, 0xce, 0x64, 0x4b, 0x69, 0xed, 0x3e, 0x9e, 0xb9, 0x11, 0x78, 0x06, 0x91, 0x77,
-	0xb0, 0x97, 0xe4, 0x9e, 0xaa, 0x4f, 0xa4, 0xe2, 0x74, 0x43, 0xb1, 0x72, 0xdc, 0x29, 0xd0, 0x35,
-	0x6a, 0xeb, 0x50, 0x74, 0x6e, 0x63, 0x34, 0xfe, 0x6c, 0xc1, 0xae, 0xc0, 0xba, 0xd1, 0x98, 0x91,
-	0x57, 0xb0, 0x9d, 0xa4, 0x1e, 0xcf, 0x9d, 0x9e, 0x6c, 0x14, 0xca, 0x37, 0x44, 0x33, 0x86, 0xbc,
-	0x84, 0x62, 0x92, 0xb2, 0x58, 0xd9, 0xfc, 0x07, 0x2b, 0x11, 0xf2, 0x01, 0x76, 0x7d, 0x9c, 0x7a,
-	0xcb, 0x90, 0x71, 0xe9, 0xb1, 0xd4, 0x78, 0xba, 0x81, 0x8b, 0xe6, 0xf2, 0xc7, 0x56, 0x14, 0x5d,
-	0xf1, 0xe4, 0x33, 0x94, 0x90, 0x73, 0xc6, 0x5d, 0xae, 0x46, 0x5c, 0x2d, 0xca, 0x0a, 0xcf, 0x1e,
-	0xaf, 0xd0, 0x12, 0x6c, 0x7e, 0x1b, 0xe8, 0x21, 0xde, 0x0d, 0x8d, 0x8f, 0xd9, 0x68, 0xf2, 0x2e,
-	0xe4, 0x04, 0x2a, 0x76, 0x6f, 0x70, 0xf9, 0xc5, 0xbd, 0xee, 0x3b, 0xdd, 0x9e, 0x4b, 0x5b, 0xcd,
-	0xab, 0x6f, 0xe5, 0x82, 0x48, 0xb7, 0x9b, 0xdd, 0x9e, 0xdb, 0x6d, 0xbb, 0xfd, 0x81, 0xa3, 0xd2,
-	0x9a, 0x71, 0x01, 0x95, 0x07, 0x1d, 0x08, 0x80, 0x3e, 0x74, 0x68, 0xf7, 0xd2, 0x29, 0x17, 0xc8,
-	0x11, 0xec, 0xdb, 0xad, 0xa1, 0xe3, 0xb6, 0xda, 0xed, 0x01, 0x75, 0xca, 0x9a, 0xf1, 0x03, 0x8e,
-	0xae, 0x70, 0x16, 0x2e, 0x71, 0xcd, 0xd7, 0xff, 0x7f, 0x3f, 0xc5, 0x64, 0xd5, 0x0d, 0x3d, 0x87,
-	0x6d, 0x7f, 0xc6, 0x82, 0x1b, 0x75, 0xc0, 0x87, 0x39, 0x68, 0x8b, 0x64, 0xa7, 0x40, 0xb3, 0xd5,
-	0x7c, 0x90, 0x8d, 0x5f, 0x1a, 0x1c, 0x35, 0x53, 0x36, 0x0f, 0x83, 0xd5, 0xa3, 0x20, 0x9f, 0x60,
-	0x6f, 0x1d, 0x94, 0xf3, 0x02, 0xad, 0x68, 0x89, 0x33, 0x16, 0x63, 0xad, 0xb6, 0x3a, 0xc2, 0x07,
-	0xef, 0xa8, 0xae, 0x5d, 0x68, 0xe4, 0x3d, 0xec, 0x28, 0xfb, 0x8f, 0x88, 0xab, 0x2b, 0xf1, 0xbd,
-	0x2d, 0x0a, 0xa9, 0x7d, 0x0d, 0xe7, 0x8c, 0x4f, 0xcc, 0xe9, 0x6d, 0x8c, 0x7c, 0x86, 0xa3, 0x09,
-	0x72, 0x73, 0xec, 0xf9, 0x3c, 0x0c, 0xb2, 0xb7, 0x9b, 0xe4, 0xe2, 0xef, 0xaf, 0x27, 0x61, 0x3a,
-	0x5d, 0xf8, 0xa2, 0xbc, 0x75, 0x87, 0xb6, 0x32, 0xda, 0xca, 0x68, 0x4b, 0xd1, 0xbe, 0x2e, 0xe3,
-	0xb7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xf1, 0x8d, 0x09, 0x12, 0x2b, 0x04, 0x00, 0x00,
+	// 754 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0xdd, 0x6e, 0xdb, 0x36,
+	0x14, 0xc7, 0xad, 0x44, 0x56, 0xeb, 0x93, 0xc4, 0x52, 0x18, 0xb4, 0x13, 0x72, 0x31, 0x64, 0x1a,
+	0xda, 0xba, 0xe8, 0x66, 0x77, 0x19, 0x30, 0x60, 0x5f, 0x17, 0xb1, 0x2d, 0xcf, 0xda, 0x32, 0x7b,
+	0xa0, 0x55, 0x0c, 0xdb, 0x8d, 0x20, 0x4b, 0xb4, 0xad, 0xd5, 0x16, 0x05, 0x92, 0xf1, 0x92, 0xbb,
+	0xdd, 0xef, 0x55, 0xf6, 0x44, 0x7b, 0x9a, 0x82, 0x14, 0x65, 0x25, 0xad, 0xd1, 0x2b, 0xfb, 0xfc,
+	0xf9, 0x3b, 0x5f, 0xd4, 0xe1, 0x01, 0x87, 0xb2, 0x94, 0x30, 0xc2, 0x7a, 0xf1, 0xbc, 0x5b, 0x30,
+	0x2a, 0x28, 0x7a, 0xa4, 0x95, 0xf3, 0xb3, 0x84, 0x6e, 0x36, 0x34, 0xef, 0x95, 0x3f, 0xe5, 0xa9,
+	0x37, 0x85, 0xd3, 0x3e, 0xa3, 0x71, 0x9a, 0xc4, 0x5c, 0x60, 0xc2, 0x0b, 0x9a, 0x73, 0x82, 0x9e,
+	0x83, 0xc5, 0x45, 0x2c, 0x6e, 0xb8, 0x6b, 0x5c, 0x18, 0x9d, 0xf6, 0x65, 0xbb, 0xab, 0x7d, 0x66,
+	0x4a, 0xc5, 0xfa, 0x14, 0x21, 0x30, 0xb3, 0x7c, 0x41, 0xdd, 0x83, 0x0b, 0xa3, 0xd3, 0xc2, 0xea,
+	0xbf, 0x77, 0x0c, 0x30, 0x23, 0xe4, 0xed, 0x84, 0xfc, 0x4d, 0xb8, 0xa8, 0xac, 0xe9, 0x3a, 0x95,
+	0xd6, 0x0b, 0x38, 0x91, 0xd6, 0xac, 0x20, 0x49, 0xb6, 0xc8, 0x48, 0x8a, 0x9e, 0x82, 0x95, 0xdf,
+	0x6c, 0xe6, 0x84, 0xa9, 0x44, 0x26, 0xd6, 0x96, 0xf7, 0x9f, 0x01, 0xc7, 0x92, 0xfc, 0x8d, 0xf2,
+	0x4c, 0x64, 0x34, 0x47, 0x5f, 0x82, 0x95, 0xab, 0x88, 0x0a, 0x3c, 0xba, 0x3c, 0xeb, 0xea, 0xae,
+	0xba, 0x75, 0xb2, 0x71, 0x03, 0x6b, 0x48, 0xe2, 0x54, 0xa5, 0x74, 0x0f, 0xf6, 0xe0, 0x65, 0x35,
+	0x12, 0x2f, 0x21, 0xf4, 0x0d, 0xb4, 0x78, 0x55, 0x93, 0x7b, 0xa8, 0x3c, 0x9e, 0x3e, 0xf0, 0xd8,
+	0x55, 0x3c, 0x6e, 0xe0, 0x1a, 0xed, 0x5b, 0x60, 0x86, 0x77, 0x05, 0xf1, 0xfe, 0x31, 0xe1, 0xb1,
+	0xc4, 0x82, 0x7c, 0x41, 0xd1, 0x2b, 0x68, 0x72, 0x11, 0xb3, 0xaa, 0xd2, 0x27, 0x0f, 0x02, 0x55,
+	0x0d, 0xe1, 0x92, 0x41, 0x2f, 0xc1, 0xe4, 0x82, 0x16, 0xee, 0xc1, 0xc7, 0x58, 0x85, 0xa0, 0xef,
+	0xe0, 0xf1, 0x9c, 0xac, 0xe2, 0x6d, 0x46, 0x99, 0xaa, 0xb1, 0x7d, 0xf9, 0xe9, 0x03, 0x5c, 0x26,
+	0x57, 0x7f, 0xfa, 0x9a, 0xc2, 0x3b, 0x1e, 0xfd, 0x0c, 0x6d, 0xc2, 0x18, 0x65, 0x11, 0xd3, 0x9f,
+	0xd8, 0x35, 0x55, 0x84, 0xcf, 0xf7, 0x47, 0xf0, 0x25, 0x5b, 0x4d, 0x03, 0x3e, 0x21, 0xf7, 0x4d,
+	0x34, 0x84, 0xe3, 0x84, 0xe6, 0x82, 0xe4, 0x22, 0x12, 0x77, 0x05, 0x71, 0x9b, 0x2a, 0xd2, 0x67,
+	0xfb, 0x23, 0x0d, 0x4a, 0x52, 0xde, 0x12, 0x3e, 0x4a, 0x6a, 0x03, 0xbd, 0x02, 0x6b, 0x91, 0xad,
+	0x05, 0x61, 0xae, 0xb5, 0xe7, 0x0b, 0x8d, 0xd4, 0x11, 0xd6, 0x88, 0xf7, 0x03, 0x1c, 0xdf, 0x6f,
+	0x0c, 0x3d, 0x81, 0xd3, 0xfe, 0xf5, 0x74, 0xf0, 0x4b, 0xf4, 0x66, 0x12, 0x06, 0xd7, 0x11, 0xf6,
+	0xaf, 0x86, 0x7f, 0x38, 0x0d, 0x29, 0x8f, 0xae, 0x82, 0xeb, 0x28, 0x18, 0x45, 0x93, 0x69, 0xa8,
+	0x65, 0xc3, 0x7b, 0x0d, 0xa7, 0x1f, 0x34, 0x85, 0x00, 0xac, 0x59, 0x88, 0x83, 0x41, 0xe8, 0x34,
+	0x90, 0x0d, 0x47, 0x7d, 0x7f, 0x16, 0x46, 0xfe, 0x68, 0x34, 0xc5, 0xa1, 0x63, 0x78, 0x5f, 0x81,
+	0xfd, 0x5e, 0xf1, 0xa8, 0x05, 0x4d, 0x95, 0xd2, 0x69, 0xa0, 0x33, 0xb0, 0xc7, 0xfe, 0xd5, 0xd0,
+	0xc7, 0xd1, 0xef, 0x41, 0x38, 0x8e, 0x66, 0xc1, 0x4f, 0x8e, 0xe1, 0xfd, 0x6f, 0x00, 0xd4, 0x95,
+	0xa3, 0x17, 0x60, 0x27, 0xab, 0x38, 0xcb, 0x13, 0x9a, 0x92, 0x28, 0x8f, 0x37, 0x44, 0x3e, 0xa5,
+	0xc3, 0x4e, 0x0b, 0xb7, 0x77, 0xf2, 0x44, 0xaa, 0xe8, 0x7b, 0x38, 0xaf, 0x41, 0xb2, 0x95, 0xb7,
+	0x2a, 0xf1, 0x88, 0x91, 0x25, 0xb9, 0xd5, 0x0f, 0xeb, 0x93, 0x1d, 0xe1, 0x4b, 0x40, 0x3a, 0x62,
+	0x79, 0x8c, 0xce, 0xa0, 0x29, 0x6e, 0xa3, 0xac, 0x9c, 0xd9, 0x16, 0x36, 0xc5, 0x6d, 0x90, 0xa2,
+	0xe7, 0x60, 0x27, 0x8c, 0xc4, 0x82, 0xb2, 0x68, 0xc3, 0x8b, 0x28, 0x4b, 0xb9, 0x6b, 0xaa, 0xd4,
+	0x27, 0x5a, 0xfe, 0x95, 0x17, 0x41, 0xca, 0xd1, 0x4b, 0x70, 0xb6, 0xf1, 0x3a, 0x4b, 0x63, 0x39,
+	0x63, 0x91, 0x8c, 0xce, 0xdd, 0xe6, 0xc5, 0x61, 0xa7, 0x89, 0xed, 0x5a, 0x1f, 0x48, 0xd9, 0xfb,
+	0x0b, 0xec, 0x21, 0x59, 0x67, 0x5b, 0x52, 0xdf, 0x5f, 0xe7, 0xe3, 0x2b, 0x42, 0x3e, 0x2e, 0xbd,
+	0x24, 0x9e, 0x41, 0x73, 0xbe, 0xa6, 0xc9, 0x5b, 0x3d, 0xe3, 0x27, 0x15, 0xd8, 0x97, 0xe2, 0xb8,
+	0x81, 0xcb, 0xd3, 0xea, 0x2d, 0x5d, 0xfe, 0x6b, 0x80, 0x7d, 0x25, 0xe8, 0x26, 0x4b, 0x76, 0x7b,
+	0x09, 0xfd, 0x08, 0xad, 0xda, 0x70, 0xaa, 0x00, 0x7e, 0xbe, 0x25, 0x6b, 0x5a, 0x90, 0xf3, 0xf3,
+	0xdd, 0xec, 0x7c, 0xb0, 0xca, 0x3a, 0xc6, 0x6b, 0x03, 0x7d, 0x0b, 0x8f, 0x74, 0xf9, 0x7b, 0x9c,
+	0xdd, 0x9d, 0xf3, 0x7b, 0x2d, 0x4a, 0xd7, 0xfe, 0x1b, 0x78, 0x46, 0xd9, 0xb2, 0xbb, 0xba, 0x2b,
+	0x08, 0x5b, 0x93, 0x74, 0x49, 0x58, 0x77, 0x11, 0xcf, 0x59, 0x96, 0x94, 0xeb, 0x93, 0x57, 0xce,
+	0x7f, 0x7e, 0xb1, 0xcc, 0xc4, 0xea, 0x66, 0x2e, 0xc3, 0xf7, 0xee, 0xd1, 0xbd, 0x92, 0xee, 0x95,
+	0x74, 0x4f, 0xd3, 0x73, 0x4b, 0xd9, 0x5f, 0xbf, 0x1b, 0x00, 0xb8, 0x58, 0xc9, 0x9d, 0xae, 0x05,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
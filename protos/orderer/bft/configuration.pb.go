@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/configuration.proto
+
+package bft
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ConfigMetadata is serialized and set as the value of ConsensusType.Metadata in
+// a channel configuration when the ConsensusType.Type is set "BFT".
+type ConfigMetadata struct {
+	Consenters           []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
+	Options              *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
+func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
+func (*ConfigMetadata) ProtoMessage()    {}
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3aab8dc28954bb30, []int{0}
+}
+
+func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
+}
+func (m *ConfigMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigMetadata.Marshal(b, m, deterministic)
+}
+func (m *ConfigMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigMetadata.Merge(m, src)
+}
+func (m *ConfigMetadata) XXX_Size() int {
+	return xxx_messageInfo_ConfigMetadata.Size(m)
+}
+func (m *ConfigMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigMetadata proto.InternalMessageInfo
+
+func (m *ConfigMetadata) GetConsenters() []*Consenter {
+	if m != nil {
+		return m.Consenters
+	}
+	return nil
+}
+
+func (m *ConfigMetadata) GetOptions() *Options {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// Consenter represents a consenting node (i.e. replica).
+type Consenter struct {
+	// Unique, non zero, identifier of the node.
+	Id   uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// MSP of the signing identity of the node.
+	MspId string `protobuf:"bytes,4,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// PEM encoded certificate of the signing identity of the node,
+	// which signs the blocks and the consensus messages of the node.
+	Identity             []byte   `protobuf:"bytes,5,opt,name=identity,proto3" json:"identity,omitempty"`
+	ClientTlsCert        []byte   `protobuf:"bytes,6,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTlsCert        []byte   `protobuf:"bytes,7,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Consenter) Reset()         { *m = Consenter{} }
+func (m *Consenter) String() string { return proto.CompactTextString(m) }
+func (*Consenter) ProtoMessage()    {}
+func (*Consenter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3aab8dc28954bb30, []int{1}
+}
+
+func (m *Consenter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consenter.Unmarshal(m, b)
+}
+func (m *Consenter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consenter.Marshal(b, m, deterministic)
+}
+func (m *Consenter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consenter.Merge(m, src)
+}
+func (m *Consenter) XXX_Size() int {
+	return xxx_messageInfo_Consenter.Size(m)
+}
+func (m *Consenter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consenter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consenter proto.InternalMessageInfo
+
+func (m *Consenter) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Consenter) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Consenter) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Consenter) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *Consenter) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *Consenter) GetClientTlsCert() []byte {
+	if m != nil {
+		return m.ClientTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetServerTlsCert() []byte {
+	if m != nil {
+		return m.ServerTlsCert
+	}
+	return nil
+}
+
+// Options to be specified for all the BFT nodes. These can be modified on a
+// per-channel basis.
+type Options struct {
+	// Time a node waits for a request it received to be ordered, or for a proposal to
+	// be decided, before it suspects the leader and starts a view change, e.g. 10s.
+	RequestTimeout string `protobuf:"bytes,1,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	// Time a node waits for a view change to complete before it moves to the next view, e.g. 20s.
+	ViewChangeTimeout    string   `protobuf:"bytes,2,opt,name=view_change_timeout,json=viewChangeTimeout,proto3" json:"view_change_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Options) Reset()         { *m = Options{} }
+func (m *Options) String() string { return proto.CompactTextString(m) }
+func (*Options) ProtoMessage()    {}
+func (*Options) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3aab8dc28954bb30, []int{2}
+}
+
+func (m *Options) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Options.Unmarshal(m, b)
+}
+func (m *Options) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Options.Marshal(b, m, deterministic)
+}
+func (m *Options) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Options.Merge(m, src)
+}
+func (m *Options) XXX_Size() int {
+	return xxx_messageInfo_Options.Size(m)
+}
+func (m *Options) XXX_DiscardUnknown() {
+	xxx_messageInfo_Options.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Options proto.InternalMessageInfo
+
+func (m *Options) GetRequestTimeout() string {
+	if m != nil {
+		return m.RequestTimeout
+	}
+	return ""
+}
+
+func (m *Options) GetViewChangeTimeout() string {
+	if m != nil {
+		return m.ViewChangeTimeout
+	}
+	return ""
+}
+
+// BlockMetadata stores data used by the BFT OSNs when
+// coordinating with each other, to be serialized into
+// block meta data field and used after failures and restarts.
+type BlockMetadata struct {
+	// The view in which the block was decided.
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
+func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
+func (*BlockMetadata) ProtoMessage()    {}
+func (*BlockMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3aab8dc28954bb30, []int{3}
+}
+
+func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
+}
+func (m *BlockMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockMetadata.Marshal(b, m, deterministic)
+}
+func (m *BlockMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockMetadata.Merge(m, src)
+}
+func (m *BlockMetadata) XXX_Size() int {
+	return xxx_messageInfo_BlockMetadata.Size(m)
+}
+func (m *BlockMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockMetadata proto.InternalMessageInfo
+
+func (m *BlockMetadata) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ConfigMetadata)(nil), "bft.ConfigMetadata")
+	proto.RegisterType((*Consenter)(nil), "bft.Consenter")
+	proto.RegisterType((*Options)(nil), "bft.Options")
+	proto.RegisterType((*BlockMetadata)(nil), "bft.BlockMetadata")
+}
+
+func init() { proto.RegisterFile("orderer/bft/configuration.proto", fileDescriptor_3aab8dc28954bb30) }
+
+var fileDescriptor_3aab8dc28954bb30 = []byte{
+	// 377 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x92, 0xc1, 0x6a, 0xdb, 0x40,
+	0x10, 0x86, 0x91, 0xed, 0xd8, 0xf5, 0x24, 0x76, 0xe8, 0x96, 0x82, 0xe8, 0xa5, 0xc2, 0x85, 0x54,
+	0xbd, 0xac, 0x4a, 0xfa, 0x06, 0xf1, 0xa9, 0x87, 0x52, 0x10, 0x39, 0x15, 0x8a, 0x90, 0x76, 0x47,
+	0xd2, 0x52, 0x59, 0xab, 0xce, 0x8e, 0x53, 0xf2, 0x82, 0x7d, 0xae, 0xa2, 0x5d, 0x45, 0xf5, 0x6d,
+	0xf4, 0xfd, 0xdf, 0x0c, 0x8c, 0x66, 0xe1, 0xbd, 0x25, 0x8d, 0x84, 0x94, 0x55, 0x35, 0x67, 0xca,
+	0xf6, 0xb5, 0x69, 0xce, 0x54, 0xb2, 0xb1, 0xbd, 0x1c, 0xc8, 0xb2, 0x15, 0xcb, 0xaa, 0xe6, 0x43,
+	0x0b, 0xfb, 0xa3, 0xcf, 0xbe, 0x21, 0x97, 0xba, 0xe4, 0x52, 0x48, 0x00, 0x65, 0x7b, 0x87, 0x3d,
+	0x23, 0xb9, 0x38, 0x4a, 0x96, 0xe9, 0xf5, 0xfd, 0x5e, 0x56, 0x35, 0xcb, 0xe3, 0x0b, 0xce, 0x2f,
+	0x0c, 0x71, 0x07, 0x1b, 0x3b, 0x8c, 0x63, 0x5d, 0xbc, 0x48, 0xa2, 0xf4, 0xfa, 0xfe, 0xc6, 0xcb,
+	0xdf, 0x03, 0xcb, 0x5f, 0xc2, 0xc3, 0xdf, 0x08, 0xb6, 0xf3, 0x04, 0xb1, 0x87, 0x85, 0xd1, 0x71,
+	0x94, 0x44, 0xe9, 0x2a, 0x5f, 0x18, 0x2d, 0x04, 0xac, 0x5a, 0xeb, 0xd8, 0x8f, 0xd8, 0xe6, 0xbe,
+	0x1e, 0xd9, 0x60, 0x89, 0xe3, 0x65, 0x12, 0xa5, 0xbb, 0xdc, 0xd7, 0xe2, 0x2d, 0xac, 0x4f, 0x6e,
+	0x28, 0x8c, 0x8e, 0x57, 0xde, 0xbc, 0x3a, 0xb9, 0xe1, 0xab, 0x16, 0xef, 0xe0, 0x95, 0xd1, 0xd8,
+	0xb3, 0xe1, 0xe7, 0xf8, 0x2a, 0x89, 0xd2, 0x9b, 0x7c, 0xfe, 0x16, 0x77, 0x70, 0xab, 0x3a, 0x83,
+	0x3d, 0x17, 0xdc, 0xb9, 0x42, 0x21, 0x71, 0xbc, 0xf6, 0xca, 0x2e, 0xe0, 0xc7, 0xce, 0x1d, 0x91,
+	0x78, 0xf4, 0x1c, 0xd2, 0x13, 0xd2, 0x7f, 0x6f, 0x13, 0xbc, 0x80, 0x27, 0xef, 0x50, 0xc1, 0x66,
+	0x5a, 0x4e, 0x7c, 0x84, 0x5b, 0xc2, 0xdf, 0x67, 0x74, 0x5c, 0xb0, 0x39, 0xa1, 0x3d, 0xb3, 0x5f,
+	0x69, 0x9b, 0xef, 0x27, 0xfc, 0x18, 0xa8, 0x90, 0xf0, 0xe6, 0xc9, 0xe0, 0x9f, 0x42, 0xb5, 0x65,
+	0xdf, 0xe0, 0x2c, 0x87, 0x6d, 0x5f, 0x8f, 0xd1, 0xd1, 0x27, 0x93, 0x7f, 0xf8, 0x00, 0xbb, 0x87,
+	0xce, 0xaa, 0x5f, 0xf3, 0x55, 0x04, 0xac, 0x46, 0x6b, 0xfa, 0x63, 0xbe, 0x7e, 0xf8, 0x09, 0x9f,
+	0x2c, 0x35, 0xb2, 0x7d, 0x1e, 0x90, 0x3a, 0xd4, 0x0d, 0x92, 0xac, 0xcb, 0x8a, 0x8c, 0x0a, 0x07,
+	0x76, 0x72, 0x7a, 0x01, 0xe3, 0x3d, 0x7e, 0x7c, 0x6e, 0x0c, 0xb7, 0xe7, 0x4a, 0x2a, 0x7b, 0xca,
+	0x2e, 0x3a, 0xb2, 0xd0, 0x91, 0x85, 0x8e, 0xec, 0xe2, 0xcd, 0x54, 0x6b, 0xcf, 0xbe, 0xfc, 0x1b,
+	0x00, 0x96, 0x34, 0x93, 0x44, 0x49, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,657 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/consensus.proto
+
+package bft
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric/protos/common"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ConsensusMessage is the payload of the ConsensusRequest exchanged
+// between the BFT OSNs of a channel.
+type ConsensusMessage struct {
+	// Types that are valid to be assigned to Payload:
+	//	*ConsensusMessage_PrePrepare
+	//	*ConsensusMessage_Prepare
+	//	*ConsensusMessage_Commit
+	//	*ConsensusMessage_ViewChange
+	//	*ConsensusMessage_NewView
+	Payload              isConsensusMessage_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ConsensusMessage) Reset()         { *m = ConsensusMessage{} }
+func (m *ConsensusMessage) String() string { return proto.CompactTextString(m) }
+func (*ConsensusMessage) ProtoMessage()    {}
+func (*ConsensusMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{0}
+}
+
+func (m *ConsensusMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusMessage.Unmarshal(m, b)
+}
+func (m *ConsensusMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusMessage.Marshal(b, m, deterministic)
+}
+func (m *ConsensusMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusMessage.Merge(m, src)
+}
+func (m *ConsensusMessage) XXX_Size() int {
+	return xxx_messageInfo_ConsensusMessage.Size(m)
+}
+func (m *ConsensusMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusMessage proto.InternalMessageInfo
+
+type isConsensusMessage_Payload interface {
+	isConsensusMessage_Payload()
+}
+
+type ConsensusMessage_PrePrepare struct {
+	PrePrepare *PrePrepare `protobuf:"bytes,1,opt,name=pre_prepare,json=prePrepare,proto3,oneof"`
+}
+
+type ConsensusMessage_Prepare struct {
+	Prepare *SignedPrepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof"`
+}
+
+type ConsensusMessage_Commit struct {
+	Commit *Commit `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
+}
+
+type ConsensusMessage_ViewChange struct {
+	ViewChange *SignedViewChange `protobuf:"bytes,4,opt,name=view_change,json=viewChange,proto3,oneof"`
+}
+
+type ConsensusMessage_NewView struct {
+	NewView *NewView `protobuf:"bytes,5,opt,name=new_view,json=newView,proto3,oneof"`
+}
+
+func (*ConsensusMessage_PrePrepare) isConsensusMessage_Payload() {}
+
+func (*ConsensusMessage_Prepare) isConsensusMessage_Payload() {}
+
+func (*ConsensusMessage_Commit) isConsensusMessage_Payload() {}
+
+func (*ConsensusMessage_ViewChange) isConsensusMessage_Payload() {}
+
+func (*ConsensusMessage_NewView) isConsensusMessage_Payload() {}
+
+func (m *ConsensusMessage) GetPayload() isConsensusMessage_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ConsensusMessage) GetPrePrepare() *PrePrepare {
+	if x, ok := m.GetPayload().(*ConsensusMessage_PrePrepare); ok {
+		return x.PrePrepare
+	}
+	return nil
+}
+
+func (m *ConsensusMessage) GetPrepare() *SignedPrepare {
+	if x, ok := m.GetPayload().(*ConsensusMessage_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *ConsensusMessage) GetCommit() *Commit {
+	if x, ok := m.GetPayload().(*ConsensusMessage_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *ConsensusMessage) GetViewChange() *SignedViewChange {
+	if x, ok := m.GetPayload().(*ConsensusMessage_ViewChange); ok {
+		return x.ViewChange
+	}
+	return nil
+}
+
+func (m *ConsensusMessage) GetNewView() *NewView {
+	if x, ok := m.GetPayload().(*ConsensusMessage_NewView); ok {
+		return x.NewView
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConsensusMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConsensusMessage_PrePrepare)(nil),
+		(*ConsensusMessage_Prepare)(nil),
+		(*ConsensusMessage_Commit)(nil),
+		(*ConsensusMessage_ViewChange)(nil),
+		(*ConsensusMessage_NewView)(nil),
+	}
+}
+
+// PrePrepare is sent by the leader of a view to propose the block
+// of the next sequence, which is the number of the block.
+type PrePrepare struct {
+	View                 uint64        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64        `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Block                *common.Block `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PrePrepare) Reset()         { *m = PrePrepare{} }
+func (m *PrePrepare) String() string { return proto.CompactTextString(m) }
+func (*PrePrepare) ProtoMessage()    {}
+func (*PrePrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{1}
+}
+
+func (m *PrePrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrePrepare.Unmarshal(m, b)
+}
+func (m *PrePrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrePrepare.Marshal(b, m, deterministic)
+}
+func (m *PrePrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrePrepare.Merge(m, src)
+}
+func (m *PrePrepare) XXX_Size() int {
+	return xxx_messageInfo_PrePrepare.Size(m)
+}
+func (m *PrePrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrePrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrePrepare proto.InternalMessageInfo
+
+func (m *PrePrepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// Prepare is sent by a node that accepted the proposal of the leader.
+// The digest is the hash of the header of the proposed block.
+type Prepare struct {
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Prepare) Reset()         { *m = Prepare{} }
+func (m *Prepare) String() string { return proto.CompactTextString(m) }
+func (*Prepare) ProtoMessage()    {}
+func (*Prepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{2}
+}
+
+func (m *Prepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepare.Unmarshal(m, b)
+}
+func (m *Prepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepare.Marshal(b, m, deterministic)
+}
+func (m *Prepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepare.Merge(m, src)
+}
+func (m *Prepare) XXX_Size() int {
+	return xxx_messageInfo_Prepare.Size(m)
+}
+func (m *Prepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepare proto.InternalMessageInfo
+
+func (m *Prepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Prepare) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+// SignedPrepare is a Prepare signed by the node that sent it, so that
+// it can be relayed in a prepared certificate.
+type SignedPrepare struct {
+	Prepare              []byte   `protobuf:"bytes,1,opt,name=prepare,proto3" json:"prepare,omitempty"`
+	Signer               uint64   `protobuf:"varint,2,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedPrepare) Reset()         { *m = SignedPrepare{} }
+func (m *SignedPrepare) String() string { return proto.CompactTextString(m) }
+func (*SignedPrepare) ProtoMessage()    {}
+func (*SignedPrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{3}
+}
+
+func (m *SignedPrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedPrepare.Unmarshal(m, b)
+}
+func (m *SignedPrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedPrepare.Marshal(b, m, deterministic)
+}
+func (m *SignedPrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedPrepare.Merge(m, src)
+}
+func (m *SignedPrepare) XXX_Size() int {
+	return xxx_messageInfo_SignedPrepare.Size(m)
+}
+func (m *SignedPrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedPrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedPrepare proto.InternalMessageInfo
+
+func (m *SignedPrepare) GetPrepare() []byte {
+	if m != nil {
+		return m.Prepare
+	}
+	return nil
+}
+
+func (m *SignedPrepare) GetSigner() uint64 {
+	if m != nil {
+		return m.Signer
+	}
+	return 0
+}
+
+func (m *SignedPrepare) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Commit is sent by a node that received a quorum of prepares for a proposal.
+// It carries the signature of the node on the metadata of the proposed block.
+type Commit struct {
+	View                 uint64                    `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64                    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte                    `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature            *common.MetadataSignature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{4}
+}
+
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (m *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(m, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Commit) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Commit) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() *common.MetadataSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Prepared is a certificate that a quorum of nodes accepted a
+// proposal that has not necessarily been decided yet.
+type Prepared struct {
+	View                 uint64           `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Block                *common.Block    `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	Prepares             []*SignedPrepare `protobuf:"bytes,3,rep,name=prepares,proto3" json:"prepares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Prepared) Reset()         { *m = Prepared{} }
+func (m *Prepared) String() string { return proto.CompactTextString(m) }
+func (*Prepared) ProtoMessage()    {}
+func (*Prepared) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{5}
+}
+
+func (m *Prepared) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepared.Unmarshal(m, b)
+}
+func (m *Prepared) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepared.Marshal(b, m, deterministic)
+}
+func (m *Prepared) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepared.Merge(m, src)
+}
+func (m *Prepared) XXX_Size() int {
+	return xxx_messageInfo_Prepared.Size(m)
+}
+func (m *Prepared) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepared.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepared proto.InternalMessageInfo
+
+func (m *Prepared) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepared) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *Prepared) GetPrepares() []*SignedPrepare {
+	if m != nil {
+		return m.Prepares
+	}
+	return nil
+}
+
+// ViewChange is sent by a node that suspects the leader of its view.
+type ViewChange struct {
+	NextView       uint64 `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	LastDecidedSeq uint64 `protobuf:"varint,2,opt,name=last_decided_seq,json=lastDecidedSeq,proto3" json:"last_decided_seq,omitempty"`
+	// The latest proposal prepared by the node, if any.
+	Prepared             *Prepared `protobuf:"bytes,3,opt,name=prepared,proto3" json:"prepared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ViewChange) Reset()         { *m = ViewChange{} }
+func (m *ViewChange) String() string { return proto.CompactTextString(m) }
+func (*ViewChange) ProtoMessage()    {}
+func (*ViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{6}
+}
+
+func (m *ViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewChange.Unmarshal(m, b)
+}
+func (m *ViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewChange.Marshal(b, m, deterministic)
+}
+func (m *ViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewChange.Merge(m, src)
+}
+func (m *ViewChange) XXX_Size() int {
+	return xxx_messageInfo_ViewChange.Size(m)
+}
+func (m *ViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewChange proto.InternalMessageInfo
+
+func (m *ViewChange) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+func (m *ViewChange) GetLastDecidedSeq() uint64 {
+	if m != nil {
+		return m.LastDecidedSeq
+	}
+	return 0
+}
+
+func (m *ViewChange) GetPrepared() *Prepared {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+// SignedViewChange is a ViewChange signed by the node that sent it, so that
+// it can be relayed in a NewView.
+type SignedViewChange struct {
+	ViewChange           []byte   `protobuf:"bytes,1,opt,name=view_change,json=viewChange,proto3" json:"view_change,omitempty"`
+	Signer               uint64   `protobuf:"varint,2,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedViewChange) Reset()         { *m = SignedViewChange{} }
+func (m *SignedViewChange) String() string { return proto.CompactTextString(m) }
+func (*SignedViewChange) ProtoMessage()    {}
+func (*SignedViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{7}
+}
+
+func (m *SignedViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedViewChange.Unmarshal(m, b)
+}
+func (m *SignedViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedViewChange.Marshal(b, m, deterministic)
+}
+func (m *SignedViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedViewChange.Merge(m, src)
+}
+func (m *SignedViewChange) XXX_Size() int {
+	return xxx_messageInfo_SignedViewChange.Size(m)
+}
+func (m *SignedViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedViewChange proto.InternalMessageInfo
+
+func (m *SignedViewChange) GetViewChange() []byte {
+	if m != nil {
+		return m.ViewChange
+	}
+	return nil
+}
+
+func (m *SignedViewChange) GetSigner() uint64 {
+	if m != nil {
+		return m.Signer
+	}
+	return 0
+}
+
+func (m *SignedViewChange) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// NewView is sent by the leader of a view to the other nodes to prove
+// that a quorum of nodes moved to the view.
+type NewView struct {
+	View                 uint64              `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	ViewChanges          []*SignedViewChange `protobuf:"bytes,2,rep,name=view_changes,json=viewChanges,proto3" json:"view_changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *NewView) Reset()         { *m = NewView{} }
+func (m *NewView) String() string { return proto.CompactTextString(m) }
+func (*NewView) ProtoMessage()    {}
+func (*NewView) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2d3f45b75b81bee, []int{8}
+}
+
+func (m *NewView) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewView.Unmarshal(m, b)
+}
+func (m *NewView) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewView.Marshal(b, m, deterministic)
+}
+func (m *NewView) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewView.Merge(m, src)
+}
+func (m *NewView) XXX_Size() int {
+	return xxx_messageInfo_NewView.Size(m)
+}
+func (m *NewView) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewView.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewView proto.InternalMessageInfo
+
+func (m *NewView) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *NewView) GetViewChanges() []*SignedViewChange {
+	if m != nil {
+		return m.ViewChanges
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ConsensusMessage)(nil), "bft.ConsensusMessage")
+	proto.RegisterType((*PrePrepare)(nil), "bft.PrePrepare")
+	proto.RegisterType((*Prepare)(nil), "bft.Prepare")
+	proto.RegisterType((*SignedPrepare)(nil), "bft.SignedPrepare")
+	proto.RegisterType((*Commit)(nil), "bft.Commit")
+	proto.RegisterType((*Prepared)(nil), "bft.Prepared")
+	proto.RegisterType((*ViewChange)(nil), "bft.ViewChange")
+	proto.RegisterType((*SignedViewChange)(nil), "bft.SignedViewChange")
+	proto.RegisterType((*NewView)(nil), "bft.NewView")
+}
+
+func init() { proto.RegisterFile("orderer/bft/consensus.proto", fileDescriptor_d2d3f45b75b81bee) }
+
+var fileDescriptor_d2d3f45b75b81bee = []byte{
+	// 541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4b, 0x6f, 0xd3, 0x40,
+	0x10, 0x6e, 0xe3, 0x34, 0x8f, 0x49, 0x0a, 0xd1, 0x22, 0x90, 0xa1, 0x48, 0x44, 0x46, 0x48, 0xed,
+	0xc5, 0x46, 0xe5, 0x40, 0xcf, 0x09, 0x12, 0xbd, 0x14, 0x55, 0x8e, 0x44, 0x25, 0x24, 0x64, 0xad,
+	0xbd, 0x13, 0xc7, 0x22, 0xb5, 0xdd, 0xdd, 0x4d, 0x43, 0x2f, 0xf0, 0x0b, 0xf8, 0xcf, 0x68, 0x1f,
+	0x7e, 0x14, 0xa5, 0x07, 0xe0, 0xe4, 0x9d, 0x6f, 0xbf, 0x79, 0x7d, 0x33, 0x5e, 0x38, 0x2a, 0x38,
+	0x43, 0x8e, 0x3c, 0x88, 0x97, 0x32, 0x48, 0x8a, 0x5c, 0x60, 0x2e, 0x36, 0xc2, 0x2f, 0x79, 0x21,
+	0x0b, 0xe2, 0xc4, 0x4b, 0xf9, 0xe2, 0x49, 0x52, 0x5c, 0x5f, 0x17, 0x79, 0x60, 0x3e, 0xe6, 0xc6,
+	0xfb, 0xd5, 0x81, 0xc9, 0xbc, 0x62, 0x5f, 0xa0, 0x10, 0x34, 0x45, 0x72, 0x0a, 0xa3, 0x92, 0x63,
+	0x54, 0x72, 0x2c, 0x29, 0x47, 0x77, 0x7f, 0xba, 0x7f, 0x3c, 0x3a, 0x7d, 0xec, 0xc7, 0x4b, 0xe9,
+	0x5f, 0x72, 0xbc, 0x34, 0xf0, 0xf9, 0x5e, 0x08, 0x65, 0x6d, 0x11, 0x1f, 0xfa, 0x15, 0xbf, 0xa3,
+	0xf9, 0x44, 0xf3, 0x17, 0x59, 0x9a, 0x23, 0x6b, 0x5c, 0x2a, 0x12, 0x79, 0x03, 0x3d, 0x55, 0x48,
+	0x26, 0x5d, 0x47, 0xd3, 0x47, 0x9a, 0x3e, 0xd7, 0xd0, 0xf9, 0x5e, 0x68, 0x2f, 0xc9, 0x19, 0x8c,
+	0x6e, 0x33, 0xdc, 0x46, 0xc9, 0x8a, 0xe6, 0x29, 0xba, 0x5d, 0xcd, 0x7d, 0xda, 0x0a, 0xfd, 0x39,
+	0xc3, 0xed, 0x5c, 0x5f, 0xaa, 0x82, 0x6e, 0x6b, 0x8b, 0x9c, 0xc0, 0x20, 0xc7, 0x6d, 0xa4, 0x10,
+	0xf7, 0x40, 0xbb, 0x8d, 0xb5, 0xdb, 0x27, 0xdc, 0x2a, 0x1f, 0x55, 0x4b, 0x6e, 0x8e, 0xb3, 0x21,
+	0xf4, 0x4b, 0x7a, 0xb7, 0x2e, 0x28, 0xf3, 0xae, 0x00, 0x9a, 0x16, 0x09, 0x81, 0xae, 0xf6, 0x57,
+	0x0a, 0x74, 0x43, 0x7d, 0x26, 0x13, 0x70, 0x04, 0xde, 0xe8, 0x26, 0xbb, 0xa1, 0x3a, 0x92, 0xd7,
+	0x70, 0x10, 0xaf, 0x8b, 0xe4, 0x9b, 0xed, 0xe4, 0xd0, 0xb7, 0x0a, 0xcf, 0x14, 0x18, 0x9a, 0x3b,
+	0xef, 0x23, 0xf4, 0xff, 0x2e, 0xea, 0x33, 0xe8, 0xb1, 0x2c, 0x45, 0x61, 0x04, 0x1a, 0x87, 0xd6,
+	0xf2, 0x22, 0x38, 0xbc, 0x27, 0x2a, 0x71, 0x1b, 0xe5, 0xf7, 0x35, 0xb3, 0x32, 0x55, 0x08, 0xa1,
+	0xa8, 0xdc, 0xc6, 0xb5, 0x16, 0x79, 0x09, 0x43, 0x75, 0xa2, 0x72, 0xc3, 0xd1, 0x46, 0x6f, 0x00,
+	0xef, 0x27, 0xf4, 0xcc, 0x18, 0xfe, 0xaf, 0x50, 0xf2, 0xbe, 0x9d, 0xc5, 0x0c, 0xee, 0x79, 0x25,
+	0xcd, 0x05, 0x4a, 0xca, 0xa8, 0xa4, 0x8b, 0x8a, 0xd0, 0x2e, 0x40, 0xc0, 0xc0, 0xf6, 0xc6, 0x76,
+	0x96, 0x50, 0xeb, 0xdd, 0x79, 0x58, 0x6f, 0xe2, 0xc3, 0xc0, 0xca, 0x20, 0x5c, 0x67, 0xea, 0xec,
+	0x5e, 0xc8, 0xb0, 0xe6, 0x78, 0x3f, 0x00, 0x9a, 0x55, 0x22, 0x47, 0x30, 0xcc, 0xf1, 0xbb, 0x8c,
+	0x5a, 0xb9, 0x07, 0x0a, 0x50, 0x14, 0x72, 0x0c, 0x93, 0x35, 0x15, 0x32, 0x62, 0x98, 0x64, 0x0c,
+	0x59, 0xd4, 0xe8, 0xf1, 0x48, 0xe1, 0x1f, 0x0c, 0xbc, 0xc0, 0x1b, 0xb5, 0x83, 0x36, 0x01, 0xab,
+	0x97, 0xc3, 0xfe, 0x45, 0x1a, 0xac, 0xf3, 0x33, 0x2f, 0x83, 0xc9, 0x9f, 0x0b, 0x4d, 0x5e, 0xdd,
+	0x5f, 0x7e, 0x33, 0xdd, 0xf6, 0x8e, 0xff, 0xdb, 0x80, 0xaf, 0xa0, 0x6f, 0x7f, 0x82, 0x9d, 0xf2,
+	0x9e, 0xc1, 0xb8, 0x95, 0x55, 0xb8, 0x9d, 0xa9, 0xf3, 0xe0, 0x3f, 0x17, 0x8e, 0x9a, 0x6a, 0xc4,
+	0xec, 0x2b, 0x9c, 0x14, 0x3c, 0xf5, 0x57, 0x77, 0x25, 0xf2, 0x35, 0xb2, 0x14, 0xb9, 0xbf, 0xa4,
+	0x31, 0xcf, 0x12, 0xf3, 0xd8, 0x08, 0xdf, 0xbe, 0x51, 0x2a, 0xd4, 0x97, 0xb7, 0x69, 0x26, 0x57,
+	0x9b, 0x58, 0x0d, 0x2f, 0x68, 0x79, 0x04, 0xc6, 0x23, 0x30, 0x1e, 0x41, 0xeb, 0x55, 0x8b, 0x7b,
+	0x1a, 0x7b, 0xf7, 0x7b, 0x00, 0x38, 0x81, 0xc1, 0x20, 0xeb, 0x04, 0x00, 0x00,
+}
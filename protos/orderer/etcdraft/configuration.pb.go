@@ -23,11 +23,18 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // ConfigMetadata is serialized and set as the value of ConsensusType.Metadata in
 // a channel configuration when the ConsensusType.Type is set "etcdraft".
 type ConfigMetadata struct {
-	Consenters           []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
-	Options              *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Consenters []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
+	Options    *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// Client TLS certificates of the consenters which take part in the
+	// channel as non-voting Raft learners. A consenter listed here joins
+	// the cluster as a learner when it is added to the channel, and it is
+	// promoted to voter by a config update which removes it from this list,
+	// or once it has caught up if Options.auto_promote_learners is set.
+	// The consenters of a new channel are always voters.
+	LearnerClientTlsCerts [][]byte `protobuf:"bytes,3,rep,name=learner_client_tls_certs,json=learnerClientTlsCerts,proto3" json:"learner_client_tls_certs,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
@@ -69,6 +76,13 @@ func (m *ConfigMetadata) GetOptions() *Options {
 	return nil
 }
 
+func (m *ConfigMetadata) GetLearnerClientTlsCerts() [][]byte {
+	if m != nil {
+		return m.LearnerClientTlsCerts
+	}
+	return nil
+}
+
 // Consenter represents a consenting node (i.e. replica).
 type Consenter struct {
 	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
@@ -141,7 +155,9 @@ type Options struct {
 	HeartbeatTick     uint32 `protobuf:"varint,3,opt,name=heartbeat_tick,json=heartbeatTick,proto3" json:"heartbeat_tick,omitempty"`
 	MaxInflightBlocks uint32 `protobuf:"varint,4,opt,name=max_inflight_blocks,json=maxInflightBlocks,proto3" json:"max_inflight_blocks,omitempty"`
 	// Take snapshot when cumulative data exceeds certain size in bytes.
-	SnapshotIntervalSize uint32   `protobuf:"varint,5,opt,name=snapshot_interval_size,json=snapshotIntervalSize,proto3" json:"snapshot_interval_size,omitempty"`
+	SnapshotIntervalSize uint32 `protobuf:"varint,5,opt,name=snapshot_interval_size,json=snapshotIntervalSize,proto3" json:"snapshot_interval_size,omitempty"`
+	// Promote learners to voters as soon as they have caught up with the leader.
+	AutoPromoteLearners  bool     `protobuf:"varint,6,opt,name=auto_promote_learners,json=autoPromoteLearners,proto3" json:"auto_promote_learners,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -207,6 +223,13 @@ func (m *Options) GetSnapshotIntervalSize() uint32 {
 	return 0
 }
 
+func (m *Options) GetAutoPromoteLearners() bool {
+	if m != nil {
+		return m.AutoPromoteLearners
+	}
+	return false
+}
+
 // BlockMetadata stores data used by the Raft OSNs when
 // coordinating with each other, to be serialized into
 // block meta dta field and used after failres and restarts.
@@ -218,7 +241,9 @@ type BlockMetadata struct {
 	// to the next OSN that will join this cluster.
 	NextConsenterId uint64 `protobuf:"varint,2,opt,name=next_consenter_id,json=nextConsenterId,proto3" json:"next_consenter_id,omitempty"`
 	// Index of etcd/raft entry for current block.
-	RaftIndex            uint64   `protobuf:"varint,3,opt,name=raft_index,json=raftIndex,proto3" json:"raft_index,omitempty"`
+	RaftIndex uint64 `protobuf:"varint,3,opt,name=raft_index,json=raftIndex,proto3" json:"raft_index,omitempty"`
+	// Raft IDs of the consenters which are non-voting learners.
+	LearnerIds           []uint64 `protobuf:"varint,4,rep,packed,name=learner_ids,json=learnerIds,proto3" json:"learner_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -270,6 +295,13 @@ func (m *BlockMetadata) GetRaftIndex() uint64 {
 	return 0
 }
 
+func (m *BlockMetadata) GetLearnerIds() []uint64 {
+	if m != nil {
+		return m.LearnerIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConfigMetadata)(nil), "etcdraft.ConfigMetadata")
 	proto.RegisterType((*Consenter)(nil), "etcdraft.Consenter")
@@ -282,33 +314,38 @@ func init() {
 }
 
 var fileDescriptor_6f12d215c949b072 = []byte{
-	// 448 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xc1, 0x6f, 0xd3, 0x30,
-	0x14, 0xc6, 0x15, 0x56, 0x18, 0x7d, 0x6b, 0x36, 0xd5, 0x43, 0x28, 0x17, 0xa4, 0xaa, 0x03, 0x54,
-	0x81, 0x94, 0x48, 0x1b, 0xfc, 0x03, 0xeb, 0xa9, 0x07, 0x84, 0x14, 0x76, 0xe2, 0x62, 0x39, 0xce,
-	0x6b, 0x62, 0x35, 0x8d, 0x23, 0xfb, 0x6d, 0x2a, 0xbb, 0x70, 0xe0, 0x1f, 0xe5, 0x4f, 0x41, 0xb6,
-	0x93, 0xb4, 0xe2, 0x66, 0x7d, 0xdf, 0xef, 0xb3, 0x3f, 0xeb, 0x3d, 0x78, 0xaf, 0x4d, 0x89, 0x06,
-	0x4d, 0x86, 0x24, 0x4b, 0x23, 0xb6, 0x94, 0x49, 0xdd, 0x6e, 0x55, 0xf5, 0x68, 0x04, 0x29, 0xdd,
-	0xa6, 0x9d, 0xd1, 0xa4, 0xd9, 0xeb, 0xc1, 0x5d, 0x1a, 0xb8, 0x5c, 0x7b, 0xe0, 0x1b, 0x92, 0x28,
-	0x05, 0x09, 0x76, 0x07, 0x20, 0x75, 0x6b, 0xb1, 0x25, 0x34, 0x36, 0x89, 0x16, 0x67, 0xab, 0x8b,
-	0xdb, 0xeb, 0x74, 0x08, 0xa4, 0xeb, 0xc1, 0xcb, 0x4f, 0x30, 0xf6, 0x19, 0xce, 0x75, 0xe7, 0x1e,
-	0xb0, 0xc9, 0x8b, 0x45, 0xb4, 0xba, 0xb8, 0x9d, 0x1f, 0x13, 0xdf, 0x83, 0x91, 0x0f, 0xc4, 0xf2,
-	0x4f, 0x04, 0xd3, 0xf1, 0x1a, 0xc6, 0x60, 0x52, 0x6b, 0x4b, 0x49, 0xb4, 0x88, 0x56, 0xd3, 0xdc,
-	0x9f, 0x9d, 0xd6, 0x69, 0x43, 0xfe, 0xae, 0x38, 0xf7, 0x67, 0xf6, 0x11, 0xae, 0x64, 0xa3, 0xb0,
-	0x25, 0x4e, 0x8d, 0xe5, 0x12, 0x0d, 0x25, 0x67, 0x8b, 0x68, 0x35, 0xcb, 0xe3, 0x20, 0x3f, 0x34,
-	0x76, 0x8d, 0x81, 0xb3, 0x68, 0x9e, 0xd0, 0x1c, 0xb9, 0x49, 0xe0, 0x82, 0xdc, 0x73, 0xcb, 0xbf,
-	0x11, 0x9c, 0xf7, 0xd5, 0xd8, 0x0d, 0xc4, 0xa4, 0xe4, 0x8e, 0x2b, 0xd7, 0xe8, 0x49, 0x34, 0x7d,
-	0x99, 0x99, 0x13, 0x37, 0xbd, 0xe6, 0x20, 0x6c, 0x50, 0xba, 0x04, 0x77, 0x46, 0xdf, 0x6e, 0x36,
-	0x88, 0x0f, 0x4a, 0xee, 0xd8, 0x07, 0xb8, 0xac, 0x51, 0x18, 0x2a, 0x50, 0x50, 0xa0, 0xce, 0x3c,
-	0x15, 0x8f, 0xaa, 0xc7, 0x52, 0xb8, 0xde, 0x8b, 0x03, 0x57, 0xed, 0xb6, 0x51, 0x55, 0x4d, 0xbc,
-	0x68, 0xb4, 0xdc, 0x59, 0x5f, 0x34, 0xce, 0xe7, 0x7b, 0x71, 0xd8, 0xf4, 0xce, 0xbd, 0x37, 0xd8,
-	0x17, 0x78, 0x6b, 0x5b, 0xd1, 0xd9, 0x5a, 0xd3, 0x58, 0x92, 0x5b, 0xf5, 0x8c, 0xc9, 0x4b, 0x1f,
-	0x79, 0x33, 0xb8, 0x43, 0xdb, 0x1f, 0xea, 0x19, 0x97, 0xbf, 0x21, 0xf6, 0xf9, 0x71, 0xb6, 0x37,
-	0x10, 0x8f, 0x43, 0xe3, 0xaa, 0x0c, 0xe3, 0x9d, 0xe4, 0xb3, 0x51, 0xdc, 0x94, 0x96, 0x7d, 0x82,
-	0x79, 0x8b, 0x07, 0xe2, 0xa7, 0xa4, 0xff, 0xeb, 0x24, 0xbf, 0x72, 0xc6, 0xfa, 0x08, 0xb3, 0x77,
-	0x00, 0x6e, 0xc6, 0x5c, 0xb5, 0x25, 0x1e, 0xfc, 0x57, 0x27, 0xf9, 0xd4, 0x29, 0x1b, 0x27, 0xdc,
-	0x57, 0x90, 0x6a, 0x53, 0xa5, 0xf5, 0xaf, 0x0e, 0x4d, 0x83, 0x65, 0x85, 0x26, 0xdd, 0x8a, 0xc2,
-	0x28, 0x19, 0xf6, 0xd0, 0xa6, 0xfd, 0xb6, 0x8e, 0xcb, 0xf2, 0xf3, 0x6b, 0xa5, 0xa8, 0x7e, 0x2c,
-	0x52, 0xa9, 0xf7, 0xd9, 0x49, 0x2c, 0x0b, 0xb1, 0x2c, 0xc4, 0xb2, 0xff, 0x97, 0xbc, 0x78, 0xe5,
-	0x8d, 0xbb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x1e, 0x2b, 0x0a, 0xff, 0x02, 0x00, 0x00,
+	// 519 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x93, 0xcd, 0x8e, 0xd3, 0x3e,
+	0x14, 0xc5, 0x95, 0x69, 0xfe, 0xf3, 0x71, 0xa7, 0x99, 0x51, 0xdd, 0xff, 0xa0, 0x6c, 0x10, 0x51,
+	0x07, 0x50, 0x04, 0x52, 0x22, 0x75, 0x40, 0xec, 0xa7, 0xab, 0x4a, 0x20, 0x50, 0x98, 0x15, 0x1b,
+	0xcb, 0x75, 0x6e, 0x13, 0xab, 0x69, 0x1c, 0xd9, 0xee, 0xa8, 0xcc, 0x96, 0x57, 0xe0, 0x01, 0xd8,
+	0xf3, 0x92, 0xc8, 0xce, 0x47, 0xab, 0xd9, 0x59, 0xe7, 0x77, 0x4e, 0xef, 0xa9, 0x73, 0x0d, 0xaf,
+	0xa5, 0xca, 0x51, 0xa1, 0x4a, 0xd1, 0xf0, 0x5c, 0xb1, 0xb5, 0x49, 0xb9, 0xac, 0xd7, 0xa2, 0xd8,
+	0x29, 0x66, 0x84, 0xac, 0x93, 0x46, 0x49, 0x23, 0xc9, 0x79, 0x4f, 0x67, 0x7f, 0x3d, 0xb8, 0x5a,
+	0x38, 0xc7, 0x17, 0x34, 0x2c, 0x67, 0x86, 0x91, 0x3b, 0x00, 0x2e, 0x6b, 0x8d, 0xb5, 0x41, 0xa5,
+	0x43, 0x2f, 0x1a, 0xc5, 0x97, 0xf3, 0x69, 0xd2, 0x27, 0x92, 0x45, 0xcf, 0xb2, 0x23, 0x1b, 0x79,
+	0x0f, 0x67, 0xb2, 0xb1, 0x13, 0x74, 0x78, 0x12, 0x79, 0xf1, 0xe5, 0x7c, 0x72, 0x48, 0x7c, 0x6d,
+	0x41, 0xd6, 0x3b, 0xc8, 0x27, 0x08, 0x2b, 0x64, 0xaa, 0x46, 0x45, 0x79, 0x25, 0xb0, 0x36, 0xd4,
+	0x54, 0x9a, 0x72, 0x54, 0x46, 0x87, 0xa3, 0x68, 0x14, 0x8f, 0xb3, 0x9b, 0x8e, 0x2f, 0x1c, 0x7e,
+	0xa8, 0xf4, 0xc2, 0xc2, 0xd9, 0x2f, 0x0f, 0x2e, 0x86, 0xf9, 0x84, 0x80, 0x5f, 0x4a, 0x6d, 0x42,
+	0x2f, 0xf2, 0xe2, 0x8b, 0xcc, 0x9d, 0xad, 0xd6, 0x48, 0x65, 0x5c, 0x89, 0x20, 0x73, 0x67, 0xf2,
+	0x16, 0xae, 0x9f, 0x8d, 0x09, 0x47, 0x91, 0x17, 0x8f, 0xb3, 0x80, 0x1f, 0xff, 0xbc, 0xf5, 0x69,
+	0x54, 0x8f, 0xa8, 0x0e, 0x3e, 0xbf, 0xf5, 0xb5, 0x72, 0xe7, 0x9b, 0xfd, 0x3e, 0x81, 0xb3, 0xee,
+	0x3f, 0x91, 0x5b, 0x08, 0x8c, 0xe0, 0x1b, 0x2a, 0x6c, 0xa3, 0x47, 0x56, 0x75, 0x65, 0xc6, 0x56,
+	0x5c, 0x76, 0x9a, 0x35, 0x61, 0x85, 0xdc, 0x26, 0xa8, 0x05, 0x5d, 0xbb, 0x71, 0x2f, 0x3e, 0x08,
+	0xbe, 0x21, 0x6f, 0xe0, 0xaa, 0x44, 0xa6, 0xcc, 0x0a, 0x99, 0x69, 0x5d, 0x23, 0xe7, 0x0a, 0x06,
+	0xd5, 0xd9, 0x12, 0x98, 0x6e, 0xd9, 0x9e, 0x8a, 0x7a, 0x5d, 0x89, 0xa2, 0x34, 0x74, 0x55, 0x49,
+	0xbe, 0xd1, 0xae, 0x68, 0x90, 0x4d, 0xb6, 0x6c, 0xbf, 0xec, 0xc8, 0xbd, 0x03, 0xe4, 0x03, 0xbc,
+	0xd0, 0x35, 0x6b, 0x74, 0x29, 0xcd, 0x50, 0x92, 0x6a, 0xf1, 0x84, 0xe1, 0x7f, 0x2e, 0xf2, 0x7f,
+	0x4f, 0xfb, 0xb6, 0xdf, 0xc5, 0x13, 0x92, 0x39, 0xdc, 0xb0, 0x9d, 0x91, 0xb4, 0x51, 0x72, 0x2b,
+	0x0d, 0xd2, 0xee, 0x73, 0xe8, 0xf0, 0x34, 0xf2, 0xe2, 0xf3, 0x6c, 0x6a, 0xe1, 0xb7, 0x96, 0x7d,
+	0xee, 0xd0, 0xec, 0x8f, 0x07, 0x81, 0x1b, 0x3a, 0x6c, 0xd2, 0x2d, 0x04, 0xc3, 0x8a, 0x50, 0x91,
+	0xb7, 0xcb, 0xe4, 0x67, 0xe3, 0x41, 0x5c, 0xe6, 0x9a, 0xbc, 0x83, 0x49, 0x8d, 0x7b, 0x43, 0x8f,
+	0x9d, 0xee, 0x82, 0xfc, 0xec, 0xda, 0x82, 0xc5, 0xc1, 0x4c, 0x5e, 0x02, 0xd8, 0x8d, 0xa2, 0xa2,
+	0xce, 0x71, 0xef, 0xee, 0xc7, 0xcf, 0x2e, 0xac, 0xb2, 0xb4, 0x02, 0x79, 0x05, 0x97, 0xfd, 0x5e,
+	0xd9, 0x69, 0xbe, 0x9b, 0x06, 0x9d, 0xb4, 0xcc, 0xf5, 0x7d, 0x01, 0x89, 0x54, 0x45, 0x52, 0xfe,
+	0x6c, 0x50, 0x55, 0x98, 0x17, 0xa8, 0x92, 0x35, 0x5b, 0x29, 0xc1, 0xdb, 0x77, 0xa1, 0x93, 0xee,
+	0xf5, 0x0c, 0xbb, 0xfb, 0xe3, 0x63, 0x21, 0x4c, 0xb9, 0x5b, 0x25, 0x5c, 0x6e, 0xd3, 0xa3, 0x58,
+	0xda, 0xc6, 0xd2, 0x36, 0x96, 0x3e, 0x7f, 0x74, 0xab, 0x53, 0x07, 0xee, 0xfe, 0x0d, 0x00, 0x10,
+	0x8c, 0x07, 0x14, 0x8f, 0x03, 0x00, 0x00,
 }
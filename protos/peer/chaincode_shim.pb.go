@@ -26,28 +26,30 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
-	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
-	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
-	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_UNDEFINED                     ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER                      ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED                    ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                          ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                         ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION                   ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED                     ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                         ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE                     ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE                     ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE                     ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE              ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE                      ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE            ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT              ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT              ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE             ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE                     ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY           ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_METADATA            ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_METADATA            ChaincodeMessage_Type = 21
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH         ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH_HISTORY ChaincodeMessage_Type = 23
+	ChaincodeMessage_PURGE_PRIVATE_DATA            ChaincodeMessage_Type = 24
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -73,31 +75,35 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "GET_PRIVATE_DATA_HASH_HISTORY",
+	24: "PURGE_PRIVATE_DATA",
 }
 
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":             0,
-	"REGISTER":              1,
-	"REGISTERED":            2,
-	"INIT":                  3,
-	"READY":                 4,
-	"TRANSACTION":           5,
-	"COMPLETED":             6,
-	"ERROR":                 7,
-	"GET_STATE":             8,
-	"PUT_STATE":             9,
-	"DEL_STATE":             10,
-	"INVOKE_CHAINCODE":      11,
-	"RESPONSE":              13,
-	"GET_STATE_BY_RANGE":    14,
-	"GET_QUERY_RESULT":      15,
-	"QUERY_STATE_NEXT":      16,
-	"QUERY_STATE_CLOSE":     17,
-	"KEEPALIVE":             18,
-	"GET_HISTORY_FOR_KEY":   19,
-	"GET_STATE_METADATA":    20,
-	"PUT_STATE_METADATA":    21,
-	"GET_PRIVATE_DATA_HASH": 22,
+	"UNDEFINED":                     0,
+	"REGISTER":                      1,
+	"REGISTERED":                    2,
+	"INIT":                          3,
+	"READY":                         4,
+	"TRANSACTION":                   5,
+	"COMPLETED":                     6,
+	"ERROR":                         7,
+	"GET_STATE":                     8,
+	"PUT_STATE":                     9,
+	"DEL_STATE":                     10,
+	"INVOKE_CHAINCODE":              11,
+	"RESPONSE":                      13,
+	"GET_STATE_BY_RANGE":            14,
+	"GET_QUERY_RESULT":              15,
+	"QUERY_STATE_NEXT":              16,
+	"QUERY_STATE_CLOSE":             17,
+	"KEEPALIVE":                     18,
+	"GET_HISTORY_FOR_KEY":           19,
+	"GET_STATE_METADATA":            20,
+	"PUT_STATE_METADATA":            21,
+	"GET_PRIVATE_DATA_HASH":         22,
+	"GET_PRIVATE_DATA_HASH_HISTORY": 23,
+	"PURGE_PRIVATE_DATA":            24,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -638,9 +644,13 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved. The metadata hold
+// the byte representation of HistoryQueryMetadata. The collection is specified
+// for retrieving the history of the hash of a private data key.
 type GetHistoryForKey struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Collection           string   `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -678,6 +688,114 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetHistoryForKey) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// HistoryQueryMetadata is the metadata of a GetHistoryForKey. It restricts the
+// history to the blocks between startBlock and endBlock (both inclusive) and to
+// the transactions with a timestamp between startTime (inclusive) and endTime
+// (exclusive). A zero endBlock and an unset startTime or endTime denote an
+// unbounded range. The history is returned newest first if newestFirst is set.
+// It also contains a pageSize which denotes the number of records to be fetched
+// and a bookmark.
+type HistoryQueryMetadata struct {
+	StartBlock           uint64               `protobuf:"varint,1,opt,name=startBlock,proto3" json:"startBlock,omitempty"`
+	EndBlock             uint64               `protobuf:"varint,2,opt,name=endBlock,proto3" json:"endBlock,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	NewestFirst          bool                 `protobuf:"varint,5,opt,name=newestFirst,proto3" json:"newestFirst,omitempty"`
+	PageSize             int32                `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Bookmark             string               `protobuf:"bytes,7,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HistoryQueryMetadata) Reset()         { *m = HistoryQueryMetadata{} }
+func (m *HistoryQueryMetadata) String() string { return proto.CompactTextString(m) }
+func (*HistoryQueryMetadata) ProtoMessage()    {}
+func (*HistoryQueryMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5819fec16c96da2, []int{10}
+}
+
+func (m *HistoryQueryMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryQueryMetadata.Unmarshal(m, b)
+}
+func (m *HistoryQueryMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryQueryMetadata.Marshal(b, m, deterministic)
+}
+func (m *HistoryQueryMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryQueryMetadata.Merge(m, src)
+}
+func (m *HistoryQueryMetadata) XXX_Size() int {
+	return xxx_messageInfo_HistoryQueryMetadata.Size(m)
+}
+func (m *HistoryQueryMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryQueryMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryQueryMetadata proto.InternalMessageInfo
+
+func (m *HistoryQueryMetadata) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryMetadata) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryMetadata) GetNewestFirst() bool {
+	if m != nil {
+		return m.NewestFirst
+	}
+	return false
+}
+
+func (m *HistoryQueryMetadata) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -689,7 +807,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{10}
+	return fileDescriptor_e5819fec16c96da2, []int{11}
 }
 
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
@@ -728,7 +846,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{11}
+	return fileDescriptor_e5819fec16c96da2, []int{12}
 }
 
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
@@ -768,7 +886,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{12}
+	return fileDescriptor_e5819fec16c96da2, []int{13}
 }
 
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
@@ -815,7 +933,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{13}
+	return fileDescriptor_e5819fec16c96da2, []int{14}
 }
 
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
@@ -878,7 +996,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{14}
+	return fileDescriptor_e5819fec16c96da2, []int{15}
 }
 
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
@@ -925,7 +1043,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{15}
+	return fileDescriptor_e5819fec16c96da2, []int{16}
 }
 
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
@@ -971,7 +1089,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e5819fec16c96da2, []int{16}
+	return fileDescriptor_e5819fec16c96da2, []int{17}
 }
 
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
@@ -1011,6 +1129,7 @@ func init() {
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*HistoryQueryMetadata)(nil), "protos.HistoryQueryMetadata")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1023,72 +1142,79 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_e5819fec16c96da2) }
 
 var fileDescriptor_e5819fec16c96da2 = []byte{
-	// 1025 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x6f, 0xe2, 0x46,
-	0x14, 0x2e, 0x01, 0x82, 0x79, 0x24, 0x64, 0x76, 0x12, 0x52, 0x82, 0xb4, 0x2d, 0x45, 0x3d, 0xd0,
-	0x0b, 0x74, 0x69, 0x0f, 0x3d, 0x54, 0x5a, 0x11, 0x98, 0x10, 0x94, 0x04, 0xd8, 0xb1, 0x13, 0x35,
-	0xbd, 0x58, 0xc6, 0x9e, 0x18, 0x2b, 0xc6, 0xe3, 0xda, 0xc3, 0x76, 0xe9, 0xad, 0xd7, 0x1e, 0xfb,
-	0xc7, 0xf5, 0xef, 0xa9, 0xc6, 0xbf, 0x02, 0xa4, 0xd9, 0xad, 0xf6, 0x84, 0xbf, 0xf7, 0xbe, 0xf9,
-	0xde, 0xaf, 0x79, 0xd8, 0x70, 0xe6, 0x33, 0x16, 0x74, 0xcd, 0x85, 0xe1, 0x78, 0x26, 0xb7, 0x98,
-	0x1e, 0x2e, 0x9c, 0x65, 0xc7, 0x0f, 0xb8, 0xe0, 0x78, 0x3f, 0xfa, 0x09, 0x1b, 0x8d, 0x1d, 0x0a,
-	0x7b, 0xcf, 0x3c, 0x11, 0x73, 0x1a, 0xc7, 0x91, 0xcf, 0x0f, 0xb8, 0xcf, 0x43, 0xc3, 0x4d, 0x8c,
-	0x5f, 0xdb, 0x9c, 0xdb, 0x2e, 0xeb, 0x46, 0x68, 0xbe, 0x7a, 0xe8, 0x0a, 0x67, 0xc9, 0x42, 0x61,
-	0x2c, 0xfd, 0x98, 0xd0, 0xfa, 0xa7, 0x08, 0x68, 0x90, 0xea, 0xdd, 0xb0, 0x30, 0x34, 0x6c, 0x86,
-	0xdf, 0x40, 0x41, 0xac, 0x7d, 0x56, 0xcf, 0x35, 0x73, 0xed, 0x6a, 0xef, 0x75, 0x4c, 0x0d, 0x3b,
-	0xbb, 0xbc, 0x8e, 0xb6, 0xf6, 0x19, 0x8d, 0xa8, 0xf8, 0x27, 0x28, 0x67, 0xd2, 0xf5, 0xbd, 0x66,
-	0xae, 0x5d, 0xe9, 0x35, 0x3a, 0x71, 0xf0, 0x4e, 0x1a, 0xbc, 0xa3, 0xa5, 0x0c, 0xfa, 0x44, 0xc6,
-	0x75, 0x28, 0xf9, 0xc6, 0xda, 0xe5, 0x86, 0x55, 0xcf, 0x37, 0x73, 0xed, 0x03, 0x9a, 0x42, 0x8c,
-	0xa1, 0x20, 0x3e, 0x38, 0x56, 0xbd, 0xd0, 0xcc, 0xb5, 0xcb, 0x34, 0x7a, 0xc6, 0x3d, 0x50, 0xd2,
-	0x12, 0xeb, 0xc5, 0x28, 0xcc, 0x69, 0x9a, 0x9e, 0xea, 0xd8, 0x1e, 0xb3, 0x66, 0x89, 0x97, 0x66,
-	0x3c, 0xfc, 0x16, 0x8e, 0x76, 0x5a, 0x56, 0xdf, 0xdf, 0x3e, 0x9a, 0x55, 0x46, 0xa4, 0x97, 0x56,
-	0xcd, 0x2d, 0x8c, 0x5f, 0x03, 0x98, 0x0b, 0xc3, 0xf3, 0x98, 0xab, 0x3b, 0x56, 0xbd, 0x14, 0xa5,
-	0x53, 0x4e, 0x2c, 0x63, 0xab, 0xf5, 0x77, 0x1e, 0x0a, 0xb2, 0x15, 0xf8, 0x10, 0xca, 0xb7, 0x93,
-	0x21, 0xb9, 0x18, 0x4f, 0xc8, 0x10, 0x7d, 0x81, 0x0f, 0x40, 0xa1, 0x64, 0x34, 0x56, 0x35, 0x42,
-	0x51, 0x0e, 0x57, 0x01, 0x52, 0x44, 0x86, 0x68, 0x0f, 0x2b, 0x50, 0x18, 0x4f, 0xc6, 0x1a, 0xca,
-	0xe3, 0x32, 0x14, 0x29, 0xe9, 0x0f, 0xef, 0x51, 0x01, 0x1f, 0x41, 0x45, 0xa3, 0xfd, 0x89, 0xda,
-	0x1f, 0x68, 0xe3, 0xe9, 0x04, 0x15, 0xa5, 0xe4, 0x60, 0x7a, 0x33, 0xbb, 0x26, 0x1a, 0x19, 0xa2,
-	0x7d, 0x49, 0x25, 0x94, 0x4e, 0x29, 0x2a, 0x49, 0xcf, 0x88, 0x68, 0xba, 0xaa, 0xf5, 0x35, 0x82,
-	0x14, 0x09, 0x67, 0xb7, 0x29, 0x2c, 0x4b, 0x38, 0x24, 0xd7, 0x09, 0x04, 0x7c, 0x02, 0x68, 0x3c,
-	0xb9, 0x9b, 0x5e, 0x11, 0x7d, 0x70, 0xd9, 0x1f, 0x4f, 0x06, 0xd3, 0x21, 0x41, 0x95, 0x38, 0x41,
-	0x75, 0x36, 0x9d, 0xa8, 0x04, 0x1d, 0xe2, 0x53, 0xc0, 0x99, 0xa0, 0x7e, 0x7e, 0xaf, 0xd3, 0xfe,
-	0x64, 0x44, 0x50, 0x55, 0x9e, 0x95, 0xf6, 0x77, 0xb7, 0x84, 0xde, 0xeb, 0x94, 0xa8, 0xb7, 0xd7,
-	0x1a, 0x3a, 0x92, 0xd6, 0xd8, 0x12, 0xf3, 0x27, 0xe4, 0x17, 0x0d, 0x21, 0x5c, 0x83, 0x57, 0x9b,
-	0xd6, 0xc1, 0xf5, 0x54, 0x25, 0xe8, 0x95, 0xcc, 0xe6, 0x8a, 0x90, 0x59, 0xff, 0x7a, 0x7c, 0x47,
-	0x10, 0xc6, 0x5f, 0xc2, 0xb1, 0x54, 0xbc, 0x1c, 0xab, 0xda, 0x94, 0xde, 0xeb, 0x17, 0x53, 0xaa,
-	0x5f, 0x91, 0x7b, 0x74, 0xbc, 0x9d, 0xc2, 0x0d, 0xd1, 0xfa, 0xc3, 0xbe, 0xd6, 0x47, 0x27, 0xd2,
-	0x9e, 0x15, 0xf7, 0x64, 0xaf, 0xe1, 0x33, 0xa8, 0x49, 0xfe, 0x8c, 0x8e, 0xef, 0xa4, 0x47, 0x5a,
-	0xf5, 0xcb, 0xbe, 0x7a, 0x89, 0x4e, 0x5b, 0x3f, 0x83, 0x32, 0x62, 0x42, 0x15, 0x86, 0x60, 0x18,
-	0x41, 0xfe, 0x91, 0xad, 0xa3, 0xeb, 0x5c, 0xa6, 0xf2, 0x11, 0x7f, 0x05, 0x60, 0x72, 0xd7, 0x65,
-	0xa6, 0x70, 0xb8, 0x17, 0xdd, 0xd7, 0x32, 0xdd, 0xb0, 0xb4, 0x86, 0x80, 0xd2, 0xd3, 0x37, 0x4c,
-	0x18, 0x96, 0x21, 0x8c, 0xcf, 0x50, 0xa1, 0xa0, 0xcc, 0x56, 0x2f, 0xe6, 0x70, 0x02, 0xc5, 0xf7,
-	0x86, 0xbb, 0x62, 0xd1, 0xc1, 0x03, 0x1a, 0x83, 0x1d, 0xcd, 0xfc, 0x33, 0xcd, 0xdf, 0x01, 0xa5,
-	0x9a, 0xff, 0x3b, 0xb3, 0x67, 0x2a, 0xf8, 0x0d, 0x28, 0xcb, 0xe4, 0x74, 0xb4, 0x5e, 0x95, 0x5e,
-	0x2d, 0x5b, 0xa3, 0x4d, 0x69, 0x9a, 0xd1, 0x64, 0x43, 0x87, 0xcc, 0xfd, 0xdc, 0x86, 0xfe, 0x99,
-	0x83, 0xa3, 0xb4, 0xa3, 0xe7, 0x6b, 0x6a, 0x78, 0x36, 0xc3, 0x0d, 0x50, 0x42, 0x61, 0x04, 0xe2,
-	0x2a, 0x93, 0xca, 0x30, 0x3e, 0x85, 0x7d, 0xe6, 0x59, 0xd2, 0x13, 0x6b, 0x25, 0xe8, 0x93, 0x85,
-	0x35, 0x76, 0x0a, 0x3b, 0xd8, 0xa8, 0x60, 0x0e, 0xd5, 0x11, 0x13, 0xef, 0x56, 0x2c, 0x58, 0x53,
-	0x16, 0xae, 0x5c, 0x21, 0x47, 0xf0, 0x9b, 0x84, 0x49, 0xf8, 0x18, 0x7c, 0xaa, 0x96, 0xad, 0x18,
-	0xf9, 0x9d, 0x18, 0x23, 0x38, 0x8c, 0x02, 0x64, 0xb3, 0x69, 0x80, 0xe2, 0x1b, 0x36, 0x53, 0x9d,
-	0x3f, 0xe2, 0xff, 0xd3, 0x22, 0xcd, 0xb0, 0xf4, 0xcd, 0x39, 0x7f, 0x5c, 0x1a, 0xc1, 0x63, 0x12,
-	0x26, 0xc3, 0xad, 0x6f, 0xa3, 0x1b, 0x78, 0xe9, 0x84, 0x82, 0x07, 0xeb, 0x0b, 0x1e, 0xc8, 0xe2,
-	0x9f, 0xb5, 0xbd, 0xd5, 0x84, 0x6a, 0x14, 0x2e, 0xea, 0xeb, 0x84, 0x7d, 0x10, 0xb8, 0x0a, 0x7b,
-	0x8e, 0x95, 0x50, 0xf6, 0x1c, 0xab, 0xf5, 0x0d, 0x1c, 0x3d, 0x31, 0x06, 0x2e, 0x0f, 0xd9, 0x33,
-	0xca, 0x8f, 0x80, 0x36, 0x9a, 0x72, 0xbe, 0x16, 0x2c, 0xc4, 0x4d, 0xa8, 0x04, 0x4f, 0x30, 0x22,
-	0x1f, 0xd0, 0x4d, 0x53, 0xeb, 0xaf, 0x5c, 0x52, 0x2a, 0x65, 0xa1, 0xcf, 0xbd, 0x90, 0xe1, 0x1e,
-	0x94, 0x62, 0x82, 0xe4, 0xe7, 0xdb, 0x95, 0x5e, 0x3d, 0xbd, 0x53, 0xbb, 0xf2, 0x34, 0x25, 0xe2,
-	0x33, 0x50, 0x16, 0x46, 0xa8, 0x2f, 0x79, 0x10, 0xef, 0x81, 0x42, 0x4b, 0x0b, 0x23, 0xbc, 0xe1,
-	0x41, 0x9a, 0x66, 0x3e, 0x4d, 0xf3, 0xa3, 0xa3, 0xb5, 0xa1, 0xb6, 0x95, 0x4b, 0xd6, 0xfe, 0x1e,
-	0xd4, 0x1e, 0x98, 0x30, 0x17, 0xcc, 0xd2, 0x03, 0x66, 0xf2, 0xc0, 0x0a, 0x75, 0x93, 0xaf, 0x3c,
-	0x91, 0xcc, 0xe2, 0x38, 0x71, 0xd2, 0xd8, 0x37, 0x90, 0xae, 0x8f, 0x8e, 0xe5, 0x2d, 0x1c, 0x6e,
-	0xef, 0x5e, 0x1d, 0x4a, 0x32, 0x8b, 0xa7, 0xb9, 0xa4, 0xf0, 0xbf, 0xf7, 0xbb, 0x75, 0x01, 0xc7,
-	0xdb, 0x1b, 0x16, 0xdf, 0xc4, 0x2e, 0x94, 0x98, 0x27, 0x02, 0x87, 0xa5, 0xbd, 0x7b, 0x61, 0x1f,
-	0x53, 0x56, 0xef, 0x6e, 0xe3, 0xbd, 0xad, 0xae, 0x7c, 0x9f, 0x07, 0x02, 0x9f, 0x83, 0x42, 0x99,
-	0xed, 0x84, 0x82, 0x05, 0xb8, 0xfe, 0xd2, 0x5b, 0xbb, 0xf1, 0xa2, 0xa7, 0x9d, 0xfb, 0x3e, 0x77,
-	0x3e, 0x85, 0x16, 0x0f, 0xec, 0xce, 0x62, 0xed, 0xb3, 0xc0, 0x65, 0x96, 0xcd, 0x82, 0xce, 0x83,
-	0x31, 0x0f, 0x1c, 0x33, 0x3d, 0x25, 0x3f, 0x33, 0x7e, 0xfd, 0xce, 0x76, 0xc4, 0x62, 0x35, 0xef,
-	0x98, 0x7c, 0xd9, 0xdd, 0xa0, 0x76, 0x63, 0x6a, 0xfc, 0xb9, 0x11, 0x76, 0x25, 0x75, 0x1e, 0x7f,
-	0xbb, 0xfc, 0xf0, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x45, 0xde, 0x24, 0x26, 0xdf, 0x08, 0x00,
-	0x00,
+	// 1149 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x73, 0xdb, 0x36,
+	0x13, 0x7e, 0xf5, 0x61, 0x8b, 0x5a, 0xdb, 0x32, 0x02, 0x7f, 0x84, 0xd1, 0x4c, 0xf2, 0x3a, 0x3a,
+	0xb9, 0x17, 0xa9, 0x51, 0x73, 0xe8, 0xa1, 0x33, 0x19, 0x5a, 0x82, 0x65, 0x8d, 0x6d, 0x4a, 0x01,
+	0x69, 0x4f, 0xdd, 0x0b, 0x4b, 0x93, 0x88, 0xc4, 0x31, 0x45, 0xb0, 0x24, 0x94, 0x44, 0xbd, 0xf5,
+	0xda, 0x5b, 0xef, 0xfd, 0x4b, 0xfd, 0x4f, 0x1d, 0xf0, 0xcb, 0x92, 0x1c, 0xc7, 0xd3, 0x9c, 0xa4,
+	0x67, 0xf7, 0xd9, 0x67, 0x17, 0x8b, 0x05, 0x08, 0x78, 0x11, 0x32, 0x16, 0x75, 0x9c, 0xa9, 0xed,
+	0x05, 0x0e, 0x77, 0x99, 0x15, 0x4f, 0xbd, 0x59, 0x3b, 0x8c, 0xb8, 0xe0, 0x78, 0x33, 0xf9, 0x89,
+	0x9b, 0xcd, 0x35, 0x0a, 0xfb, 0xc8, 0x02, 0x91, 0x72, 0x9a, 0x7b, 0x89, 0x2f, 0x8c, 0x78, 0xc8,
+	0x63, 0xdb, 0xcf, 0x8c, 0xff, 0x9f, 0x70, 0x3e, 0xf1, 0x59, 0x27, 0x41, 0xb7, 0xf3, 0x0f, 0x1d,
+	0xe1, 0xcd, 0x58, 0x2c, 0xec, 0x59, 0x98, 0x12, 0x5a, 0x7f, 0x6d, 0x02, 0xea, 0xe5, 0x7a, 0x97,
+	0x2c, 0x8e, 0xed, 0x09, 0xc3, 0x6f, 0xa0, 0x2a, 0x16, 0x21, 0x53, 0x4b, 0x47, 0xa5, 0xe3, 0x46,
+	0xf7, 0x65, 0x4a, 0x8d, 0xdb, 0xeb, 0xbc, 0xb6, 0xb9, 0x08, 0x19, 0x4d, 0xa8, 0xf8, 0x47, 0xa8,
+	0x17, 0xd2, 0x6a, 0xf9, 0xa8, 0x74, 0xbc, 0xd5, 0x6d, 0xb6, 0xd3, 0xe4, 0xed, 0x3c, 0x79, 0xdb,
+	0xcc, 0x19, 0xf4, 0x9e, 0x8c, 0x55, 0xa8, 0x85, 0xf6, 0xc2, 0xe7, 0xb6, 0xab, 0x56, 0x8e, 0x4a,
+	0xc7, 0xdb, 0x34, 0x87, 0x18, 0x43, 0x55, 0x7c, 0xf6, 0x5c, 0xb5, 0x7a, 0x54, 0x3a, 0xae, 0xd3,
+	0xe4, 0x3f, 0xee, 0x82, 0x92, 0x2f, 0x51, 0xdd, 0x48, 0xd2, 0x1c, 0xe6, 0xe5, 0x19, 0xde, 0x24,
+	0x60, 0xee, 0x38, 0xf3, 0xd2, 0x82, 0x87, 0xdf, 0xc1, 0xee, 0x5a, 0xcb, 0xd4, 0xcd, 0xd5, 0xd0,
+	0x62, 0x65, 0x44, 0x7a, 0x69, 0xc3, 0x59, 0xc1, 0xf8, 0x25, 0x80, 0x33, 0xb5, 0x83, 0x80, 0xf9,
+	0x96, 0xe7, 0xaa, 0xb5, 0xa4, 0x9c, 0x7a, 0x66, 0x19, 0xba, 0xad, 0x7f, 0x2a, 0x50, 0x95, 0xad,
+	0xc0, 0x3b, 0x50, 0xbf, 0xd2, 0xfb, 0xe4, 0x74, 0xa8, 0x93, 0x3e, 0xfa, 0x1f, 0xde, 0x06, 0x85,
+	0x92, 0xc1, 0xd0, 0x30, 0x09, 0x45, 0x25, 0xdc, 0x00, 0xc8, 0x11, 0xe9, 0xa3, 0x32, 0x56, 0xa0,
+	0x3a, 0xd4, 0x87, 0x26, 0xaa, 0xe0, 0x3a, 0x6c, 0x50, 0xa2, 0xf5, 0x6f, 0x50, 0x15, 0xef, 0xc2,
+	0x96, 0x49, 0x35, 0xdd, 0xd0, 0x7a, 0xe6, 0x70, 0xa4, 0xa3, 0x0d, 0x29, 0xd9, 0x1b, 0x5d, 0x8e,
+	0x2f, 0x88, 0x49, 0xfa, 0x68, 0x53, 0x52, 0x09, 0xa5, 0x23, 0x8a, 0x6a, 0xd2, 0x33, 0x20, 0xa6,
+	0x65, 0x98, 0x9a, 0x49, 0x90, 0x22, 0xe1, 0xf8, 0x2a, 0x87, 0x75, 0x09, 0xfb, 0xe4, 0x22, 0x83,
+	0x80, 0xf7, 0x01, 0x0d, 0xf5, 0xeb, 0xd1, 0x39, 0xb1, 0x7a, 0x67, 0xda, 0x50, 0xef, 0x8d, 0xfa,
+	0x04, 0x6d, 0xa5, 0x05, 0x1a, 0xe3, 0x91, 0x6e, 0x10, 0xb4, 0x83, 0x0f, 0x01, 0x17, 0x82, 0xd6,
+	0xc9, 0x8d, 0x45, 0x35, 0x7d, 0x40, 0x50, 0x43, 0xc6, 0x4a, 0xfb, 0xfb, 0x2b, 0x42, 0x6f, 0x2c,
+	0x4a, 0x8c, 0xab, 0x0b, 0x13, 0xed, 0x4a, 0x6b, 0x6a, 0x49, 0xf9, 0x3a, 0xf9, 0xd9, 0x44, 0x08,
+	0x1f, 0xc0, 0xb3, 0x65, 0x6b, 0xef, 0x62, 0x64, 0x10, 0xf4, 0x4c, 0x56, 0x73, 0x4e, 0xc8, 0x58,
+	0xbb, 0x18, 0x5e, 0x13, 0x84, 0xf1, 0x73, 0xd8, 0x93, 0x8a, 0x67, 0x43, 0xc3, 0x1c, 0xd1, 0x1b,
+	0xeb, 0x74, 0x44, 0xad, 0x73, 0x72, 0x83, 0xf6, 0x56, 0x4b, 0xb8, 0x24, 0xa6, 0xd6, 0xd7, 0x4c,
+	0x0d, 0xed, 0x4b, 0xfb, 0xf8, 0xea, 0x81, 0xfd, 0x00, 0xbf, 0x80, 0x03, 0xc9, 0x1f, 0xd3, 0xe1,
+	0xb5, 0xf4, 0x48, 0xab, 0x75, 0xa6, 0x19, 0x67, 0xe8, 0x10, 0xbf, 0x86, 0x97, 0x5f, 0x74, 0xe5,
+	0x59, 0xd1, 0xf3, 0x54, 0x95, 0x0e, 0xc8, 0x0a, 0x09, 0xa9, 0xad, 0x9f, 0x40, 0x19, 0x30, 0x61,
+	0x08, 0x5b, 0x30, 0x8c, 0xa0, 0x72, 0xc7, 0x16, 0xc9, 0x49, 0xa8, 0x53, 0xf9, 0x17, 0xbf, 0x02,
+	0x70, 0xb8, 0xef, 0x33, 0x47, 0x78, 0x3c, 0x48, 0x46, 0xbd, 0x4e, 0x97, 0x2c, 0xad, 0x3e, 0xa0,
+	0x3c, 0xfa, 0x92, 0x09, 0xdb, 0xb5, 0x85, 0xfd, 0x0d, 0x2a, 0x14, 0x94, 0xf1, 0xfc, 0xd1, 0x1a,
+	0xf6, 0x61, 0xe3, 0xa3, 0xed, 0xcf, 0x59, 0x12, 0xb8, 0x4d, 0x53, 0xb0, 0xa6, 0x59, 0x79, 0xa0,
+	0xf9, 0x09, 0xd0, 0x78, 0xfe, 0x1f, 0x2b, 0x7b, 0xa0, 0x82, 0xdf, 0x80, 0x32, 0xcb, 0xa2, 0x93,
+	0x93, 0xb9, 0xd5, 0x3d, 0x28, 0x4e, 0xe0, 0xb2, 0x34, 0x2d, 0x68, 0xb2, 0xa1, 0x7d, 0xe6, 0x7f,
+	0x6b, 0x43, 0xff, 0x28, 0xc1, 0x6e, 0xde, 0xd1, 0x93, 0x05, 0xb5, 0x83, 0x09, 0xc3, 0x4d, 0x50,
+	0x62, 0x61, 0x47, 0xe2, 0xbc, 0x90, 0x2a, 0x30, 0x3e, 0x84, 0x4d, 0x16, 0xb8, 0xd2, 0x93, 0x6a,
+	0x65, 0xe8, 0xc9, 0x85, 0x35, 0xd7, 0x16, 0xb6, 0xbd, 0xb4, 0x82, 0x5b, 0x68, 0x0c, 0x98, 0x78,
+	0x3f, 0x67, 0xd1, 0x82, 0xb2, 0x78, 0xee, 0x0b, 0xb9, 0x05, 0xbf, 0x49, 0x98, 0xa5, 0x4f, 0xc1,
+	0x53, 0x6b, 0x59, 0xc9, 0x51, 0x59, 0xcb, 0x31, 0x80, 0x9d, 0x24, 0x41, 0xb1, 0x37, 0x4d, 0x50,
+	0x42, 0x7b, 0xc2, 0x0c, 0xef, 0xf7, 0xf4, 0x2a, 0xde, 0xa0, 0x05, 0x96, 0xbe, 0x5b, 0xce, 0xef,
+	0x66, 0x76, 0x74, 0x97, 0xa5, 0x29, 0x70, 0xeb, 0xd7, 0x64, 0x02, 0xcf, 0xbc, 0x58, 0xf0, 0x68,
+	0x71, 0xca, 0x23, 0xb9, 0xf8, 0x87, 0x6d, 0x5f, 0x2e, 0xa5, 0xbc, 0x5a, 0xca, 0x93, 0x93, 0xf4,
+	0x77, 0x19, 0xf6, 0x33, 0xfd, 0xd5, 0x92, 0x5f, 0x01, 0x24, 0xfb, 0x70, 0xe2, 0x73, 0xe7, 0x2e,
+	0xc9, 0x56, 0xa5, 0x4b, 0x16, 0x99, 0x94, 0x05, 0x6e, 0xea, 0x2d, 0x27, 0xde, 0x02, 0xcb, 0x4f,
+	0x48, 0xc2, 0x94, 0x5f, 0x09, 0xb5, 0xf2, 0xf4, 0x27, 0xa4, 0x20, 0xe3, 0xb7, 0x50, 0x63, 0x81,
+	0x9b, 0xc4, 0x55, 0x9f, 0x8c, 0xcb, 0xa9, 0xf8, 0x08, 0xb6, 0x02, 0xf6, 0x89, 0xc5, 0xe2, 0xd4,
+	0x8b, 0x62, 0x91, 0x7c, 0x4d, 0x14, 0xba, 0x6c, 0x5a, 0xd9, 0x80, 0xcd, 0xaf, 0x6c, 0x40, 0x6d,
+	0x6d, 0x03, 0x8e, 0xa0, 0x91, 0xb4, 0x25, 0x19, 0x59, 0x9d, 0x7d, 0x16, 0xb8, 0x01, 0x65, 0xcf,
+	0xcd, 0xba, 0x5f, 0xf6, 0xdc, 0xd6, 0x6b, 0xd8, 0xbd, 0x67, 0xf4, 0x7c, 0x1e, 0xb3, 0x07, 0x94,
+	0xb7, 0x80, 0x96, 0xe6, 0xed, 0x64, 0x21, 0x58, 0x2c, 0x4b, 0x8e, 0xee, 0x61, 0x42, 0xde, 0xa6,
+	0xcb, 0xa6, 0xd6, 0x9f, 0xa5, 0x6c, 0x8a, 0x28, 0x8b, 0x43, 0x1e, 0xc4, 0x0c, 0x77, 0xa1, 0x96,
+	0x12, 0x24, 0xbf, 0x72, 0xbc, 0xd5, 0x55, 0xf3, 0xe3, 0xba, 0x2e, 0x4f, 0x73, 0x22, 0x7e, 0x01,
+	0xca, 0xd4, 0x8e, 0xad, 0x19, 0x8f, 0xd2, 0x2b, 0x46, 0xa1, 0xb5, 0xa9, 0x1d, 0x5f, 0xf2, 0x28,
+	0x2f, 0xb3, 0x92, 0x97, 0xf9, 0xd5, 0x53, 0x33, 0x81, 0x83, 0x95, 0x5a, 0x8a, 0x31, 0xe9, 0xc2,
+	0xc1, 0x07, 0x26, 0x9c, 0x29, 0x73, 0xad, 0x88, 0x39, 0x3c, 0x72, 0x63, 0xcb, 0xe1, 0xf3, 0x40,
+	0x64, 0x63, 0xbe, 0x97, 0x39, 0x69, 0xea, 0xeb, 0x49, 0xd7, 0x57, 0x27, 0xfe, 0x1d, 0xec, 0xac,
+	0x5e, 0x6b, 0x2a, 0xd4, 0x64, 0x15, 0xf7, 0x23, 0x9f, 0xc3, 0x2f, 0x5f, 0x9d, 0xad, 0x53, 0xd8,
+	0x5b, 0xbd, 0xbc, 0xd2, 0x43, 0xde, 0x91, 0x83, 0x25, 0x22, 0x8f, 0xe5, 0xbd, 0x7b, 0xe4, 0xaa,
+	0xcb, 0x59, 0xdd, 0xeb, 0xa5, 0xd7, 0x94, 0x31, 0x0f, 0x43, 0x1e, 0x09, 0x7c, 0x02, 0x0a, 0x65,
+	0x13, 0x2f, 0x16, 0x2c, 0xc2, 0xea, 0x63, 0x6f, 0xa9, 0xe6, 0xa3, 0x9e, 0xe3, 0xd2, 0xf7, 0xa5,
+	0xae, 0x0e, 0xf5, 0xc2, 0x8e, 0x35, 0xa8, 0xf5, 0x78, 0x10, 0x30, 0x47, 0x7c, 0xab, 0xde, 0xc9,
+	0x08, 0x5a, 0x3c, 0x9a, 0xb4, 0xa7, 0x8b, 0x90, 0x45, 0x3e, 0x73, 0x27, 0x2c, 0x6a, 0x7f, 0xb0,
+	0x6f, 0x23, 0xcf, 0xc9, 0xa3, 0xe4, 0x63, 0xf2, 0x97, 0xef, 0x26, 0x9e, 0x98, 0xce, 0x6f, 0xdb,
+	0x0e, 0x9f, 0x75, 0x96, 0xa8, 0x9d, 0x94, 0x9a, 0x3e, 0x2a, 0xe3, 0x8e, 0xa4, 0xde, 0xa6, 0x2f,
+	0xd4, 0x1f, 0xfe, 0x1d, 0x00, 0x58, 0xb5, 0xe3, 0x5c, 0xc5, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1194,3 +1320,99 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "peer/chaincode_shim.proto",
 }
+
+// ChaincodeClient is the client API for Chaincode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[0], "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeServer is the server API for Chaincode service.
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
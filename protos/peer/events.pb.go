@@ -9,6 +9,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
 	common "github.com/hyperledger/fabric/protos/common"
+	rwset "github.com/hyperledger/fabric/protos/ledger/rwset"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -253,12 +254,63 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// BlockAndPrivateData contains a block and the private write sets of its
+// transactions that the requester is eligible to receive
+type BlockAndPrivateData struct {
+	Block *common.Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// map from the sequence of the transaction in the block to its private write set
+	PrivateDataMap       map[uint64]*rwset.TxPvtReadWriteSet `protobuf:"bytes,2,rep,name=private_data_map,json=privateDataMap,proto3" json:"private_data_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *BlockAndPrivateData) Reset()         { *m = BlockAndPrivateData{} }
+func (m *BlockAndPrivateData) String() string { return proto.CompactTextString(m) }
+func (*BlockAndPrivateData) ProtoMessage()    {}
+func (*BlockAndPrivateData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5eedcc5fab2714e6, []int{4}
+}
+
+func (m *BlockAndPrivateData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAndPrivateData.Unmarshal(m, b)
+}
+func (m *BlockAndPrivateData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAndPrivateData.Marshal(b, m, deterministic)
+}
+func (m *BlockAndPrivateData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAndPrivateData.Merge(m, src)
+}
+func (m *BlockAndPrivateData) XXX_Size() int {
+	return xxx_messageInfo_BlockAndPrivateData.Size(m)
+}
+func (m *BlockAndPrivateData) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAndPrivateData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAndPrivateData proto.InternalMessageInfo
+
+func (m *BlockAndPrivateData) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BlockAndPrivateData) GetPrivateDataMap() map[uint64]*rwset.TxPvtReadWriteSet {
+	if m != nil {
+		return m.PrivateDataMap
+	}
+	return nil
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_BlockAndPrivateData
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -269,7 +321,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5eedcc5fab2714e6, []int{4}
+	return fileDescriptor_5eedcc5fab2714e6, []int{5}
 }
 
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
@@ -306,12 +358,18 @@ type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,proto3,oneof"`
 }
 
+type DeliverResponse_BlockAndPrivateData struct {
+	BlockAndPrivateData *BlockAndPrivateData `protobuf:"bytes,4,opt,name=block_and_private_data,json=blockAndPrivateData,proto3,oneof"`
+}
+
 func (*DeliverResponse_Status) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_Block) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
 
+func (*DeliverResponse_BlockAndPrivateData) isDeliverResponse_Type() {}
+
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
 		return m.Type
@@ -340,12 +398,20 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetBlockAndPrivateData() *BlockAndPrivateData {
+	if x, ok := m.GetType().(*DeliverResponse_BlockAndPrivateData); ok {
+		return x.BlockAndPrivateData
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_BlockAndPrivateData)(nil),
 	}
 }
 
@@ -354,48 +420,60 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*BlockAndPrivateData)(nil), "protos.BlockAndPrivateData")
+	proto.RegisterMapType((map[uint64]*rwset.TxPvtReadWriteSet)(nil), "protos.BlockAndPrivateData.PrivateDataMapEntry")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 }
 
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_5eedcc5fab2714e6) }
 
 var fileDescriptor_5eedcc5fab2714e6 = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+	// 715 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x5d, 0x6f, 0xe2, 0x46,
+	0x14, 0xc5, 0x40, 0xa8, 0x32, 0x08, 0x42, 0x86, 0x86, 0x58, 0x44, 0x55, 0x22, 0x57, 0xad, 0xe8,
+	0x8b, 0x5d, 0xd1, 0x97, 0x2a, 0x0f, 0xad, 0x42, 0x3e, 0x44, 0xa4, 0x56, 0x42, 0x13, 0x76, 0xa3,
+	0xcd, 0x4a, 0x6b, 0x0d, 0xf6, 0x05, 0xbc, 0x31, 0xb6, 0x35, 0x33, 0xb0, 0xf0, 0x4f, 0xf6, 0x87,
+	0xed, 0x2f, 0xd9, 0xa7, 0x7d, 0x5a, 0xad, 0x3c, 0xe3, 0xe1, 0x2b, 0x24, 0x52, 0x5e, 0xf0, 0xf8,
+	0xde, 0x73, 0xee, 0x99, 0x7b, 0x7c, 0x67, 0x40, 0x87, 0x09, 0x00, 0x73, 0x60, 0x06, 0x91, 0xe0,
+	0x76, 0xc2, 0x62, 0x11, 0xe3, 0x92, 0x7c, 0xf0, 0x66, 0xdd, 0x8b, 0x27, 0x93, 0x38, 0x72, 0xd4,
+	0x43, 0x25, 0x9b, 0xa7, 0xa3, 0x38, 0x1e, 0x85, 0xe0, 0xc8, 0xb7, 0xc1, 0x74, 0xe8, 0x88, 0x60,
+	0x02, 0x5c, 0xd0, 0x49, 0x92, 0x01, 0xcc, 0x10, 0xfc, 0x11, 0x30, 0x87, 0x7d, 0xe2, 0x20, 0xd4,
+	0x6f, 0x96, 0x69, 0x4a, 0x29, 0x6f, 0x4c, 0x83, 0xc8, 0x8b, 0x7d, 0x70, 0xa5, 0x68, 0x96, 0x6b,
+	0xc8, 0x9c, 0x60, 0x34, 0xe2, 0xd4, 0x13, 0x81, 0x96, 0xb3, 0x3e, 0x1b, 0xa8, 0x72, 0x13, 0x84,
+	0x02, 0x18, 0xf8, 0x9d, 0x30, 0xf6, 0x1e, 0xf1, 0x2f, 0x08, 0x79, 0x63, 0x1a, 0x45, 0x10, 0xba,
+	0x81, 0x6f, 0x1a, 0x67, 0x46, 0x6b, 0x9f, 0xec, 0x67, 0x91, 0x5b, 0x1f, 0x37, 0x50, 0x29, 0x9a,
+	0x4e, 0x06, 0xc0, 0xcc, 0xfc, 0x99, 0xd1, 0x2a, 0x92, 0xec, 0x0d, 0xf7, 0xd0, 0xd1, 0x30, 0xab,
+	0xe3, 0xae, 0xc9, 0x70, 0xb3, 0x78, 0x56, 0x68, 0x95, 0xdb, 0x27, 0x4a, 0x8f, 0xdb, 0x5a, 0xac,
+	0xbf, 0xc2, 0x90, 0x9f, 0x87, 0x4f, 0x83, 0xdc, 0xfa, 0x66, 0xa0, 0xfa, 0x0e, 0x34, 0xc6, 0xa8,
+	0x28, 0xe6, 0xcb, 0xad, 0xc9, 0x35, 0xfe, 0x1d, 0x15, 0xc5, 0x22, 0x01, 0xb9, 0xa7, 0x6a, 0x1b,
+	0xdb, 0x99, 0xa5, 0x5d, 0xa0, 0x3e, 0xb0, 0xfe, 0x22, 0x01, 0x22, 0xf3, 0xf8, 0x06, 0x61, 0x31,
+	0x77, 0x67, 0x34, 0x0c, 0x7c, 0x9a, 0x16, 0x73, 0x53, 0xa3, 0xcc, 0x82, 0x64, 0x99, 0x7a, 0x8b,
+	0xfd, 0xf9, 0xdb, 0x25, 0xe0, 0x32, 0xf6, 0x81, 0xd4, 0xc4, 0x56, 0x04, 0xbf, 0x41, 0xf5, 0xb5,
+	0x26, 0xdd, 0x55, 0xaf, 0x46, 0xab, 0xdc, 0xb6, 0x5e, 0xe8, 0xf5, 0x42, 0x21, 0xbb, 0x39, 0x82,
+	0xc5, 0x93, 0x68, 0xa7, 0x84, 0x8a, 0x57, 0x54, 0x50, 0xeb, 0x23, 0x6a, 0x3e, 0xcf, 0xc5, 0xff,
+	0xa1, 0xc3, 0xd5, 0x47, 0xd6, 0xd2, 0x86, 0xb4, 0xf9, 0x74, 0x5b, 0xfa, 0x52, 0x03, 0x15, 0x99,
+	0xd4, 0xbc, 0xcd, 0x00, 0xb7, 0x1e, 0xd0, 0xf1, 0x33, 0x60, 0xfc, 0x2f, 0x3a, 0xd8, 0x9a, 0x26,
+	0x69, 0x7a, 0xb9, 0xdd, 0xd0, 0x32, 0x4b, 0xc6, 0x75, 0x9a, 0x25, 0x55, 0x6f, 0xe3, 0xdd, 0xfa,
+	0x6a, 0xa0, 0xba, 0x9c, 0xaa, 0x8b, 0xc8, 0xef, 0xb1, 0x60, 0x46, 0x05, 0xa4, 0xfd, 0xe1, 0x5f,
+	0xd1, 0xde, 0x20, 0x0d, 0x67, 0xe5, 0x2a, 0xfa, 0x7b, 0x49, 0x2c, 0x51, 0x39, 0xfc, 0x0e, 0xd5,
+	0x12, 0xc5, 0x71, 0x7d, 0x2a, 0xa8, 0x3b, 0xa1, 0x89, 0x99, 0x97, 0x5d, 0x3a, 0x5a, 0x7e, 0x47,
+	0x6d, 0x7b, 0x6d, 0xfd, 0x3f, 0x4d, 0xae, 0x23, 0xc1, 0x16, 0xa4, 0x9a, 0x6c, 0x04, 0x9b, 0xef,
+	0x51, 0x7d, 0x07, 0x0c, 0xd7, 0x50, 0xe1, 0x11, 0x16, 0x72, 0x53, 0x45, 0x92, 0x2e, 0xb1, 0x8d,
+	0xf6, 0x66, 0x34, 0x9c, 0xaa, 0xc1, 0x2a, 0xb7, 0x4d, 0x5b, 0x9d, 0xb7, 0xfe, 0xbc, 0x37, 0x13,
+	0x04, 0xa8, 0x7f, 0xcf, 0x02, 0x01, 0x77, 0x20, 0x88, 0x82, 0x9d, 0xe7, 0xff, 0x36, 0xac, 0xef,
+	0x06, 0x3a, 0xb8, 0x82, 0x30, 0x98, 0x01, 0x23, 0xc0, 0x93, 0x38, 0xe2, 0x80, 0x5b, 0xa8, 0xc4,
+	0x05, 0x15, 0x53, 0x2e, 0x8b, 0x57, 0xdb, 0x55, 0xdd, 0xf1, 0x9d, 0x8c, 0x76, 0x73, 0x24, 0xcb,
+	0xe3, 0xdf, 0xb4, 0x35, 0xf9, 0x1d, 0xd6, 0x74, 0x73, 0xda, 0x9c, 0x7f, 0x50, 0x75, 0x79, 0xdc,
+	0x14, 0xbe, 0x20, 0xf1, 0x47, 0xdb, 0x03, 0xa0, 0x79, 0x95, 0xe1, 0xc6, 0x29, 0x27, 0xa8, 0x21,
+	0x69, 0x2e, 0x8d, 0x7c, 0x77, 0xdd, 0xe6, 0x6c, 0x86, 0x4f, 0x5e, 0xb0, 0xb8, 0x9b, 0x23, 0xf5,
+	0xc1, 0xd3, 0x70, 0x3a, 0xbd, 0xe9, 0x51, 0x6b, 0x7f, 0x31, 0xd0, 0x4f, 0x99, 0x01, 0xf8, 0x7c,
+	0xb5, 0xac, 0xe9, 0x56, 0xae, 0xa3, 0x19, 0x84, 0x71, 0x02, 0xcd, 0x63, 0x2d, 0xb2, 0x65, 0x97,
+	0x95, 0x6b, 0x19, 0x7f, 0x1a, 0xb8, 0xb3, 0xf4, 0x51, 0x37, 0xf3, 0xfa, 0x1a, 0xb7, 0xa8, 0x91,
+	0x25, 0xee, 0x03, 0x31, 0x5e, 0x9f, 0xc1, 0xd7, 0x96, 0xea, 0x7c, 0x40, 0x56, 0xcc, 0x46, 0xf6,
+	0x78, 0x91, 0x00, 0x53, 0x77, 0xb0, 0x3d, 0xa4, 0x03, 0x16, 0x78, 0x9a, 0x96, 0x5e, 0xb1, 0x9d,
+	0x8a, 0x9c, 0x7c, 0xde, 0xa3, 0xde, 0x23, 0x1d, 0xc1, 0xc3, 0x1f, 0xa3, 0x40, 0x8c, 0xa7, 0x83,
+	0x54, 0xcb, 0x59, 0x63, 0x3a, 0x8a, 0xa9, 0x6e, 0x79, 0xee, 0xa4, 0xcc, 0x81, 0xfa, 0x5b, 0xf8,
+	0xeb, 0xc7, 0x00, 0xf6, 0x15, 0x3b, 0x27, 0x32, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -418,6 +496,11 @@ type DeliverClient interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of blocks along with the private data the requester is
+	// a member of is received
+	DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error)
 }
 
 type deliverClient struct {
@@ -490,6 +573,37 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Deliver_serviceDesc.Streams[2], "/protos.Deliver/DeliverWithPrivateData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverWithPrivateDataClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverWithPrivateDataClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverWithPrivateDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DeliverServer is the server API for Deliver service.
 type DeliverServer interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
@@ -500,6 +614,11 @@ type DeliverServer interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of blocks along with the private data the requester is
+	// a member of is received
+	DeliverWithPrivateData(Deliver_DeliverWithPrivateDataServer) error
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -558,6 +677,32 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverWithPrivateData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverWithPrivateData(&deliverDeliverWithPrivateDataServer{stream})
+}
+
+type Deliver_DeliverWithPrivateDataServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverWithPrivateDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
@@ -575,6 +720,12 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverWithPrivateData",
+			Handler:       _Deliver_DeliverWithPrivateData_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
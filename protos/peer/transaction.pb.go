@@ -260,6 +260,170 @@ func (m *ProcessedTransaction) GetValidationCode() int32 {
 	return 0
 }
 
+// ProcessedTransactionsPage is a page of the transactions returned by the qscc functions
+// that look the transactions up by chaincode namespace or by creator. The bookmark is
+// passed to the next invocation to get the next page, and is empty for the last page.
+type ProcessedTransactionsPage struct {
+	Transactions         []*ProcessedTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Bookmark             string                  `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ProcessedTransactionsPage) Reset()         { *m = ProcessedTransactionsPage{} }
+func (m *ProcessedTransactionsPage) String() string { return proto.CompactTextString(m) }
+func (*ProcessedTransactionsPage) ProtoMessage()    {}
+func (*ProcessedTransactionsPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25804bbfb0752368, []int{2}
+}
+
+func (m *ProcessedTransactionsPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessedTransactionsPage.Unmarshal(m, b)
+}
+func (m *ProcessedTransactionsPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessedTransactionsPage.Marshal(b, m, deterministic)
+}
+func (m *ProcessedTransactionsPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessedTransactionsPage.Merge(m, src)
+}
+func (m *ProcessedTransactionsPage) XXX_Size() int {
+	return xxx_messageInfo_ProcessedTransactionsPage.Size(m)
+}
+func (m *ProcessedTransactionsPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessedTransactionsPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessedTransactionsPage proto.InternalMessageInfo
+
+func (m *ProcessedTransactionsPage) GetTransactions() []*ProcessedTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *ProcessedTransactionsPage) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+// PurgeRecord identifies a private data key purged by a valid transaction. The key
+// is identified by its hash, as only the hash of the key is present in the block.
+type PurgeRecord struct {
+	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxNum                uint64   `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+	KeyHash              []byte   `protobuf:"bytes,5,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeRecord) Reset()         { *m = PurgeRecord{} }
+func (m *PurgeRecord) String() string { return proto.CompactTextString(m) }
+func (*PurgeRecord) ProtoMessage()    {}
+func (*PurgeRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25804bbfb0752368, []int{3}
+}
+
+func (m *PurgeRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeRecord.Unmarshal(m, b)
+}
+func (m *PurgeRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeRecord.Marshal(b, m, deterministic)
+}
+func (m *PurgeRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeRecord.Merge(m, src)
+}
+func (m *PurgeRecord) XXX_Size() int {
+	return xxx_messageInfo_PurgeRecord.Size(m)
+}
+func (m *PurgeRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeRecord proto.InternalMessageInfo
+
+func (m *PurgeRecord) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *PurgeRecord) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *PurgeRecord) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PurgeRecord) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *PurgeRecord) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+// PurgeRecords are the records returned by the qscc function
+// that looks up the keys purged within a range of blocks.
+type PurgeRecords struct {
+	Records              []*PurgeRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PurgeRecords) Reset()         { *m = PurgeRecords{} }
+func (m *PurgeRecords) String() string { return proto.CompactTextString(m) }
+func (*PurgeRecords) ProtoMessage()    {}
+func (*PurgeRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25804bbfb0752368, []int{4}
+}
+
+func (m *PurgeRecords) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeRecords.Unmarshal(m, b)
+}
+func (m *PurgeRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeRecords.Marshal(b, m, deterministic)
+}
+func (m *PurgeRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeRecords.Merge(m, src)
+}
+func (m *PurgeRecords) XXX_Size() int {
+	return xxx_messageInfo_PurgeRecords.Size(m)
+}
+func (m *PurgeRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeRecords proto.InternalMessageInfo
+
+func (m *PurgeRecords) GetRecords() []*PurgeRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 // The transaction to be sent to the ordering service. A transaction contains
 // one or more TransactionAction. Each TransactionAction binds a proposal to
 // potentially multiple actions. The transaction is atomic meaning that either
@@ -285,7 +449,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25804bbfb0752368, []int{2}
+	return fileDescriptor_25804bbfb0752368, []int{5}
 }
 
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
@@ -330,7 +494,7 @@ func (m *TransactionAction) Reset()         { *m = TransactionAction{} }
 func (m *TransactionAction) String() string { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()    {}
 func (*TransactionAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25804bbfb0752368, []int{3}
+	return fileDescriptor_25804bbfb0752368, []int{6}
 }
 
 func (m *TransactionAction) XXX_Unmarshal(b []byte) error {
@@ -390,7 +554,7 @@ func (m *ChaincodeActionPayload) Reset()         { *m = ChaincodeActionPayload{}
 func (m *ChaincodeActionPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()    {}
 func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25804bbfb0752368, []int{4}
+	return fileDescriptor_25804bbfb0752368, []int{7}
 }
 
 func (m *ChaincodeActionPayload) XXX_Unmarshal(b []byte) error {
@@ -444,7 +608,7 @@ func (m *ChaincodeEndorsedAction) Reset()         { *m = ChaincodeEndorsedAction
 func (m *ChaincodeEndorsedAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()    {}
 func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25804bbfb0752368, []int{5}
+	return fileDescriptor_25804bbfb0752368, []int{8}
 }
 
 func (m *ChaincodeEndorsedAction) XXX_Unmarshal(b []byte) error {
@@ -484,6 +648,9 @@ func init() {
 	proto.RegisterEnum("protos.MetaDataKeys", MetaDataKeys_name, MetaDataKeys_value)
 	proto.RegisterType((*SignedTransaction)(nil), "protos.SignedTransaction")
 	proto.RegisterType((*ProcessedTransaction)(nil), "protos.ProcessedTransaction")
+	proto.RegisterType((*ProcessedTransactionsPage)(nil), "protos.ProcessedTransactionsPage")
+	proto.RegisterType((*PurgeRecord)(nil), "protos.PurgeRecord")
+	proto.RegisterType((*PurgeRecords)(nil), "protos.PurgeRecords")
 	proto.RegisterType((*Transaction)(nil), "protos.Transaction")
 	proto.RegisterType((*TransactionAction)(nil), "protos.TransactionAction")
 	proto.RegisterType((*ChaincodeActionPayload)(nil), "protos.ChaincodeActionPayload")
@@ -493,60 +660,70 @@ func init() {
 func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_25804bbfb0752368) }
 
 var fileDescriptor_25804bbfb0752368 = []byte{
-	// 872 bytes of a gzipped FileDescriptorProto
+	// 1027 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x5d, 0x6f, 0xe2, 0x46,
-	0x14, 0x5d, 0xb2, 0x4d, 0xd2, 0x5c, 0x48, 0x32, 0x0c, 0x84, 0x00, 0x8d, 0xda, 0x15, 0x0f, 0xd5,
-	0x76, 0x2b, 0x81, 0x94, 0x7d, 0xa8, 0x54, 0xf5, 0x65, 0xb0, 0x27, 0xc1, 0x5a, 0x33, 0x63, 0x8d,
-	0x07, 0x42, 0xfa, 0xd0, 0x91, 0x03, 0x53, 0x82, 0x4a, 0x6c, 0x64, 0xb3, 0xab, 0xe6, 0xb5, 0x3f,
-	0xa0, 0xfd, 0x21, 0xfd, 0x8f, 0x6d, 0x35, 0xfe, 0x00, 0x92, 0x4d, 0x5f, 0x30, 0x73, 0xce, 0xb9,
-	0xf7, 0x9e, 0x7b, 0x2f, 0x8c, 0xa1, 0xb1, 0xd2, 0x3a, 0xee, 0xad, 0xe3, 0x20, 0x4c, 0x82, 0xe9,
-	0x7a, 0x11, 0x85, 0xdd, 0x55, 0x1c, 0xad, 0x23, 0x7c, 0x90, 0x3e, 0x92, 0xf6, 0x45, 0xca, 0xaf,
-	0xe2, 0x68, 0x15, 0x25, 0xc1, 0x52, 0xc5, 0x3a, 0x59, 0x45, 0x61, 0xa2, 0x33, 0x55, 0xbb, 0x36,
-	0x8d, 0x1e, 0x1e, 0xa2, 0xb0, 0x97, 0x3d, 0x32, 0xb0, 0xf3, 0x0b, 0x54, 0xfd, 0xc5, 0x3c, 0xd4,
-	0x33, 0xb9, 0xcd, 0x8a, 0xbf, 0x87, 0xea, 0x4e, 0x11, 0x75, 0xf7, 0xb8, 0xd6, 0x49, 0xb3, 0xf4,
-	0xa6, 0xf4, 0xb6, 0x22, 0xd0, 0x0e, 0xd1, 0x37, 0x38, 0xbe, 0x80, 0xa3, 0x64, 0x31, 0x0f, 0x83,
-	0xf5, 0xc7, 0x58, 0x37, 0xf7, 0x52, 0xd1, 0x16, 0xe8, 0xfc, 0x51, 0x82, 0xba, 0x17, 0x47, 0x53,
-	0x9d, 0x24, 0x4f, 0x6b, 0xf4, 0xa1, 0xb6, 0x93, 0x8a, 0x86, 0x9f, 0xf4, 0x32, 0x5a, 0xe9, 0xb4,
-	0x4a, 0xf9, 0x12, 0x75, 0x73, 0x93, 0x05, 0x2e, 0x5e, 0x12, 0xe3, 0x6f, 0xe1, 0xe4, 0x53, 0xb0,
-	0x5c, 0xcc, 0x02, 0x83, 0x5a, 0xd1, 0x2c, 0xab, 0xbf, 0x2f, 0x9e, 0xa1, 0x9d, 0x3e, 0x94, 0x77,
-	0x4b, 0xbf, 0x87, 0xc3, 0xec, 0x9b, 0x69, 0xea, 0xf5, 0xdb, 0xf2, 0x65, 0x2b, 0x1b, 0x46, 0xd2,
-	0xdd, 0x51, 0x91, 0xf4, 0x53, 0x14, 0xca, 0x0e, 0x85, 0xea, 0x67, 0x2c, 0x6e, 0xc0, 0xc1, 0xbd,
-	0x0e, 0x66, 0x3a, 0xce, 0xa7, 0x93, 0x9f, 0x70, 0x13, 0x0e, 0x57, 0xc1, 0xe3, 0x32, 0x0a, 0x66,
-	0xf9, 0x44, 0x8a, 0x63, 0xe7, 0xaf, 0x12, 0x34, 0xac, 0xfb, 0x60, 0x11, 0x4e, 0xa3, 0x99, 0xce,
-	0xb2, 0x78, 0x19, 0x85, 0x7f, 0x82, 0xf6, 0xb4, 0x60, 0xd4, 0x66, 0x89, 0x45, 0x9e, 0xac, 0x40,
-	0x73, 0xa3, 0xf0, 0x72, 0x41, 0x11, 0xfd, 0x03, 0x1c, 0x64, 0xd6, 0xd2, 0x8a, 0xe5, 0xcb, 0x6f,
-	0x8a, 0x9e, 0x36, 0xd5, 0x68, 0x38, 0x8b, 0xe2, 0x44, 0xcf, 0xf2, 0xce, 0x72, 0x79, 0xe7, 0xcf,
-	0x12, 0x9c, 0xff, 0x8f, 0x06, 0xff, 0x08, 0xad, 0xcf, 0x7e, 0x4d, 0xcf, 0x1c, 0x9d, 0x17, 0x02,
-	0x91, 0xf3, 0x5b, 0x43, 0x15, 0x9d, 0x65, 0x7b, 0xd0, 0xe1, 0x3a, 0x69, 0xee, 0xa5, 0xa3, 0xae,
-	0x15, 0xb6, 0xe8, 0x96, 0x13, 0x4f, 0x84, 0xef, 0xfe, 0xde, 0x07, 0x24, 0x7f, 0x1f, 0x3f, 0x59,
-	0x21, 0x3e, 0x82, 0xfd, 0x31, 0x71, 0x1d, 0x1b, 0xbd, 0xc2, 0x08, 0x2a, 0xcc, 0x71, 0x15, 0x65,
-	0x63, 0xea, 0x72, 0x8f, 0xa2, 0x12, 0x3e, 0x85, 0x72, 0x9f, 0xd8, 0xca, 0x23, 0xb7, 0x2e, 0x27,
-	0x36, 0xda, 0xc3, 0x67, 0x50, 0x35, 0x80, 0xc5, 0x87, 0x43, 0xce, 0xd4, 0x80, 0x12, 0x9b, 0x0a,
-	0xf4, 0x1a, 0xb7, 0xe0, 0x2c, 0x85, 0x05, 0x25, 0x92, 0x0b, 0xe5, 0x3b, 0xd7, 0x8c, 0xc8, 0x91,
-	0xa0, 0xe8, 0x0b, 0xfc, 0x06, 0x2e, 0x1c, 0x96, 0x56, 0x50, 0x94, 0xd9, 0x5c, 0xf8, 0x54, 0x28,
-	0x29, 0x08, 0xf3, 0x89, 0x25, 0x1d, 0xce, 0xd0, 0x3e, 0xfe, 0x1a, 0xda, 0x85, 0xc2, 0xe2, 0xec,
-	0xca, 0xb9, 0x7e, 0xc2, 0x1f, 0xe0, 0x36, 0x34, 0x46, 0xcc, 0x1f, 0x79, 0x1e, 0x17, 0x92, 0xda,
-	0x4a, 0x4e, 0x36, 0x7e, 0x0e, 0x0b, 0x3f, 0x9e, 0xe0, 0x1e, 0xf7, 0x89, 0xab, 0xe4, 0xc4, 0xb1,
-	0xd1, 0x97, 0x18, 0xc3, 0x89, 0x3d, 0xf2, 0x5c, 0xc7, 0x22, 0x92, 0x66, 0xd8, 0x91, 0x29, 0x93,
-	0x1b, 0x18, 0x52, 0x26, 0x95, 0xc7, 0x5d, 0xc7, 0xba, 0x55, 0x57, 0xc4, 0x71, 0x8d, 0x51, 0xc0,
-	0x0d, 0xc0, 0xc3, 0xb1, 0x65, 0x29, 0x41, 0x49, 0x66, 0xc4, 0x75, 0x2c, 0x89, 0xca, 0xa6, 0x37,
-	0x6f, 0x40, 0x98, 0xe4, 0xc3, 0x67, 0x54, 0x05, 0xd7, 0xe0, 0x74, 0xc4, 0x3e, 0x30, 0x7e, 0xc3,
-	0x8c, 0x2b, 0x79, 0xeb, 0x51, 0x74, 0x6c, 0xec, 0x4a, 0x22, 0xae, 0xa9, 0x54, 0xd6, 0x80, 0x38,
-	0x4c, 0x31, 0x2e, 0xd5, 0x15, 0x1f, 0x31, 0x1b, 0x9d, 0xe0, 0x3a, 0xa0, 0x21, 0x11, 0xfe, 0x20,
-	0x75, 0xaa, 0xa8, 0x10, 0x5c, 0xa0, 0xd3, 0x62, 0xee, 0x72, 0x92, 0xb7, 0x8c, 0x4c, 0x5b, 0x74,
-	0xe2, 0x39, 0x82, 0xda, 0x59, 0x12, 0x8b, 0xdb, 0x14, 0x55, 0x4d, 0x0b, 0x9b, 0xa3, 0x1a, 0x53,
-	0xe1, 0x3b, 0x9c, 0x6d, 0xfd, 0x60, 0xdc, 0x84, 0xba, 0x99, 0x46, 0xb6, 0x16, 0x45, 0x27, 0x92,
-	0x32, 0x23, 0x41, 0x35, 0xd3, 0x5c, 0xba, 0xa0, 0x01, 0x61, 0x8c, 0xba, 0xc5, 0xe2, 0xea, 0x45,
-	0x84, 0xa0, 0xbe, 0xc7, 0x99, 0x4f, 0x37, 0x93, 0x3d, 0xc3, 0xc7, 0x70, 0x94, 0x32, 0x37, 0x3e,
-	0x95, 0xa8, 0x61, 0x9c, 0x3b, 0xae, 0x4b, 0xaf, 0x89, 0xab, 0x6e, 0x84, 0x23, 0xa9, 0x41, 0xcf,
-	0x53, 0x34, 0x5f, 0xdd, 0x06, 0x6d, 0x1a, 0xf7, 0x9b, 0x85, 0x6e, 0xdc, 0xb7, 0x30, 0x86, 0x63,
-	0x33, 0x8b, 0x94, 0x20, 0x92, 0xda, 0xe8, 0x9f, 0x12, 0x6e, 0x41, 0xbd, 0x90, 0x72, 0x39, 0xa0,
-	0xc2, 0x8c, 0xd8, 0xe7, 0x0c, 0xfd, 0x5b, 0x7a, 0x47, 0xa1, 0x32, 0xd4, 0xeb, 0xc0, 0x0e, 0xd6,
-	0xc1, 0x07, 0xfd, 0x98, 0x18, 0xab, 0x79, 0xa8, 0xe9, 0xda, 0x23, 0x82, 0x0c, 0xa9, 0xa4, 0x02,
-	0xbd, 0xc2, 0x5f, 0xc1, 0xf9, 0x4b, 0x8c, 0x1a, 0x5f, 0xa2, 0x52, 0x7f, 0x0a, 0x9d, 0x28, 0x9e,
-	0x77, 0xef, 0x1f, 0x57, 0x3a, 0x5e, 0xea, 0xd9, 0x5c, 0xc7, 0xdd, 0x5f, 0x83, 0xbb, 0x78, 0x31,
-	0x2d, 0xfe, 0x2f, 0xe6, 0x6a, 0xef, 0xe3, 0x9d, 0x2b, 0xc8, 0x0b, 0xa6, 0xbf, 0x05, 0x73, 0xfd,
-	0xf3, 0x77, 0xf3, 0xc5, 0xfa, 0xfe, 0xe3, 0x9d, 0xb9, 0x31, 0x7b, 0x3b, 0xe1, 0xbd, 0x2c, 0xbc,
-	0x97, 0x85, 0xf7, 0x4c, 0xf8, 0x5d, 0xf6, 0x9e, 0x78, 0xff, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x87, 0xb2, 0x02, 0x42, 0x48, 0x06, 0x00, 0x00,
+	0x14, 0x5d, 0xb2, 0x4b, 0x12, 0x2e, 0xec, 0xee, 0x64, 0x92, 0x10, 0x60, 0xa3, 0xed, 0x8a, 0x87,
+	0x6a, 0xbb, 0x55, 0x83, 0x94, 0x7d, 0xa8, 0x54, 0xb5, 0x52, 0x8d, 0x3d, 0x09, 0xd6, 0x9a, 0xb1,
+	0x35, 0x1e, 0x48, 0xd2, 0x87, 0x8e, 0x8c, 0x99, 0x02, 0x02, 0x6c, 0x64, 0x93, 0x55, 0x78, 0xed,
+	0x0f, 0x68, 0xdf, 0xfb, 0x17, 0xfa, 0x1f, 0xdb, 0x6a, 0xfc, 0xc1, 0x47, 0x36, 0x7d, 0x89, 0x33,
+	0xe7, 0x9c, 0x7b, 0xef, 0xb9, 0xf7, 0x7a, 0x30, 0x54, 0x17, 0x52, 0x46, 0xad, 0x65, 0xe4, 0x05,
+	0xb1, 0xe7, 0x2f, 0x27, 0x61, 0x70, 0xb1, 0x88, 0xc2, 0x65, 0x88, 0xf7, 0x93, 0x47, 0xdc, 0x38,
+	0x4f, 0xf8, 0x45, 0x14, 0x2e, 0xc2, 0xd8, 0x9b, 0x89, 0x48, 0xc6, 0x8b, 0x30, 0x88, 0x65, 0xaa,
+	0x6a, 0x1c, 0xfb, 0xe1, 0x7c, 0x1e, 0x06, 0xad, 0xf4, 0x91, 0x82, 0xcd, 0x5f, 0xe1, 0xc8, 0x9d,
+	0x8c, 0x02, 0x39, 0xe4, 0x9b, 0xac, 0xf8, 0x5b, 0x38, 0xda, 0x2a, 0x22, 0x06, 0xab, 0xa5, 0x8c,
+	0x6b, 0x85, 0x77, 0x85, 0xf7, 0x15, 0x86, 0xb6, 0x88, 0xb6, 0xc2, 0xf1, 0x39, 0x94, 0xe2, 0xc9,
+	0x28, 0xf0, 0x96, 0xf7, 0x91, 0xac, 0xed, 0x25, 0xa2, 0x0d, 0xd0, 0xfc, 0xbd, 0x00, 0x27, 0x4e,
+	0x14, 0xfa, 0x32, 0x8e, 0x77, 0x6b, 0xb4, 0xe1, 0x78, 0x2b, 0x15, 0x09, 0x3e, 0xcb, 0x59, 0xb8,
+	0x90, 0x49, 0x95, 0xf2, 0x25, 0xba, 0xc8, 0x4c, 0xe6, 0x38, 0x7b, 0x4a, 0x8c, 0xbf, 0x86, 0x57,
+	0x9f, 0xbd, 0xd9, 0x64, 0xe8, 0x29, 0x54, 0x0f, 0x87, 0x69, 0xfd, 0x22, 0x7b, 0x84, 0x36, 0x57,
+	0x50, 0x7f, 0xca, 0x43, 0xec, 0x78, 0x23, 0x89, 0x7f, 0x86, 0xca, 0x56, 0x6e, 0xd5, 0xe7, 0xf3,
+	0xf7, 0xe5, 0xcb, 0xf3, 0x74, 0x3e, 0xf1, 0xc5, 0x53, 0x81, 0x6c, 0x27, 0x02, 0x37, 0xe0, 0x70,
+	0x10, 0x86, 0xd3, 0xb9, 0x17, 0x4d, 0x13, 0x03, 0x25, 0xb6, 0x3e, 0x37, 0xff, 0x2a, 0x40, 0xd9,
+	0xb9, 0x8f, 0x46, 0x92, 0x49, 0x3f, 0x8c, 0x86, 0xf8, 0x0d, 0x94, 0x06, 0xb3, 0xd0, 0x9f, 0x8a,
+	0xe0, 0x7e, 0x9e, 0x34, 0xfb, 0x82, 0x1d, 0x26, 0x00, 0xbd, 0x9f, 0xe3, 0x53, 0xd8, 0x5f, 0x3e,
+	0x24, 0xcc, 0x5e, 0xc2, 0x14, 0x97, 0x0f, 0x0a, 0x3e, 0x87, 0x52, 0xe0, 0xcd, 0x65, 0xbc, 0xf0,
+	0x7c, 0x59, 0x7b, 0x9e, 0x14, 0xd8, 0x00, 0xf8, 0x2d, 0x80, 0x1f, 0xce, 0x66, 0x32, 0x31, 0x53,
+	0x7b, 0x91, 0xd0, 0x5b, 0x08, 0xae, 0xc3, 0xe1, 0x54, 0xae, 0xc4, 0xd8, 0x8b, 0xc7, 0xb5, 0x62,
+	0xb2, 0x9e, 0x83, 0xa9, 0x5c, 0x75, 0xbc, 0x78, 0xdc, 0xfc, 0x09, 0x2a, 0x5b, 0xde, 0x62, 0xfc,
+	0x1d, 0x1c, 0x44, 0xe9, 0xbf, 0xd9, 0x14, 0x8e, 0xd7, 0x53, 0xd8, 0xc8, 0x58, 0xae, 0x69, 0xb6,
+	0xa1, 0xbc, 0xbd, 0xd1, 0x8f, 0x70, 0xb0, 0x3b, 0xc3, 0x7a, 0x1e, 0xbd, 0xa5, 0xd2, 0x92, 0xbf,
+	0x2c, 0x57, 0x36, 0x09, 0x1c, 0x7d, 0xc1, 0xe2, 0x2a, 0xec, 0x8f, 0xa5, 0x37, 0x94, 0x51, 0xf6,
+	0xd2, 0x65, 0x27, 0x5c, 0x83, 0x83, 0x85, 0xb7, 0x9a, 0x85, 0xde, 0x30, 0x7b, 0xd1, 0xf2, 0x63,
+	0xf3, 0xcf, 0x02, 0x54, 0xf5, 0xb1, 0x37, 0x09, 0xfc, 0x70, 0x28, 0xd3, 0x2c, 0x4e, 0x4a, 0xe1,
+	0x1f, 0xa1, 0xe1, 0xe7, 0x8c, 0x58, 0xdf, 0x8d, 0x3c, 0x4f, 0x5a, 0xa0, 0xb6, 0x56, 0x38, 0x99,
+	0x20, 0x8f, 0xfe, 0x1e, 0xf6, 0x53, 0x6b, 0x49, 0xc5, 0xf2, 0xe5, 0x57, 0x79, 0x4f, 0xeb, 0x6a,
+	0x24, 0x18, 0x86, 0x51, 0x2c, 0x87, 0x59, 0x67, 0x99, 0xbc, 0xf9, 0x47, 0x01, 0xce, 0xfe, 0x47,
+	0x83, 0x7f, 0x80, 0xfa, 0x17, 0x97, 0xf4, 0x91, 0xa3, 0xb3, 0x5c, 0xc0, 0x32, 0x7e, 0x63, 0xa8,
+	0x22, 0xd3, 0x6c, 0x73, 0x19, 0x2c, 0xe3, 0xda, 0xde, 0xee, 0xa2, 0xc8, 0x86, 0x63, 0x3b, 0xc2,
+	0x0f, 0x7f, 0x17, 0x01, 0xf1, 0x87, 0xfe, 0xce, 0xcd, 0xc0, 0x25, 0x28, 0xf6, 0x35, 0xcb, 0x34,
+	0xd0, 0x33, 0x8c, 0xa0, 0x42, 0x4d, 0x4b, 0x10, 0xda, 0x27, 0x96, 0xed, 0x10, 0x54, 0xc0, 0xaf,
+	0xa1, 0xdc, 0xd6, 0x0c, 0xe1, 0x68, 0x77, 0x96, 0xad, 0x19, 0x68, 0x0f, 0x9f, 0xc2, 0x91, 0x02,
+	0x74, 0xbb, 0xdb, 0xb5, 0xa9, 0xe8, 0x10, 0xcd, 0x20, 0x0c, 0x3d, 0xc7, 0x75, 0x38, 0x4d, 0x60,
+	0x46, 0x34, 0x6e, 0x33, 0xe1, 0x9a, 0xd7, 0x54, 0xe3, 0x3d, 0x46, 0xd0, 0x0b, 0xfc, 0x0e, 0xce,
+	0x4d, 0x9a, 0x54, 0x10, 0x84, 0x1a, 0x36, 0x73, 0x09, 0x13, 0x9c, 0x69, 0xd4, 0xd5, 0x74, 0x6e,
+	0xda, 0x14, 0x15, 0xf1, 0x5b, 0x68, 0xe4, 0x0a, 0xdd, 0xa6, 0x57, 0xe6, 0xf5, 0x0e, 0xbf, 0x8f,
+	0x1b, 0x50, 0xed, 0x51, 0xb7, 0xe7, 0x38, 0x36, 0xe3, 0xc4, 0x10, 0xfc, 0x76, 0xed, 0xe7, 0x20,
+	0xf7, 0xe3, 0x30, 0xdb, 0xb1, 0x5d, 0xcd, 0x12, 0xfc, 0xd6, 0x34, 0xd0, 0x21, 0xc6, 0xf0, 0xca,
+	0xe8, 0x39, 0x96, 0xa9, 0x6b, 0x9c, 0xa4, 0x58, 0x49, 0x95, 0xc9, 0x0c, 0x74, 0x09, 0xe5, 0xc2,
+	0xb1, 0x2d, 0x53, 0xbf, 0x13, 0x57, 0x9a, 0x69, 0x29, 0xa3, 0x80, 0xab, 0x80, 0xbb, 0x7d, 0x5d,
+	0x17, 0x8c, 0x68, 0xa9, 0x11, 0xcb, 0xd4, 0x39, 0x2a, 0xab, 0xde, 0x9c, 0x8e, 0x46, 0xb9, 0xdd,
+	0x7d, 0x44, 0x55, 0xf0, 0x31, 0xbc, 0xee, 0xd1, 0x4f, 0xd4, 0xbe, 0xa1, 0xca, 0x15, 0xbf, 0x73,
+	0x08, 0x7a, 0xa9, 0xec, 0x72, 0x8d, 0x5d, 0x13, 0x2e, 0xf4, 0x8e, 0x66, 0x52, 0x41, 0x6d, 0x2e,
+	0xae, 0xec, 0x1e, 0x35, 0xd0, 0x2b, 0x7c, 0x02, 0xa8, 0xab, 0x31, 0xb7, 0x93, 0x38, 0x15, 0x84,
+	0x31, 0x9b, 0xa1, 0xd7, 0xf9, 0xdc, 0xf9, 0x6d, 0xd6, 0x32, 0x52, 0x6d, 0x91, 0x5b, 0xc7, 0x64,
+	0xc4, 0x48, 0x93, 0xe8, 0xb6, 0x41, 0xd0, 0x91, 0x6a, 0x61, 0x7d, 0x14, 0x7d, 0xc2, 0x5c, 0xd3,
+	0xa6, 0x1b, 0x3f, 0x18, 0xd7, 0xe0, 0x44, 0x4d, 0x23, 0x5d, 0x8b, 0x20, 0xb7, 0x9c, 0x50, 0x25,
+	0x41, 0xc7, 0xaa, 0xb9, 0x64, 0x41, 0x1d, 0x8d, 0x52, 0x62, 0xe5, 0x8b, 0x3b, 0xc9, 0x23, 0x18,
+	0x71, 0x1d, 0x9b, 0xba, 0x64, 0x3d, 0xd9, 0x53, 0xfc, 0x12, 0x4a, 0x09, 0x73, 0xe3, 0x12, 0x8e,
+	0xaa, 0xca, 0xb9, 0x69, 0x59, 0xe4, 0x5a, 0xb3, 0xc4, 0x0d, 0x33, 0x39, 0x51, 0xe8, 0x59, 0x82,
+	0x66, 0xab, 0x5b, 0xa3, 0x35, 0xe5, 0x7e, 0xbd, 0xd0, 0xb5, 0xfb, 0x3a, 0xc6, 0xf0, 0x52, 0xcd,
+	0x22, 0x21, 0x34, 0x4e, 0x0c, 0xf4, 0x4f, 0x01, 0xd7, 0xe1, 0x24, 0x97, 0xda, 0xbc, 0x43, 0x98,
+	0x1a, 0xb1, 0x6b, 0x53, 0xf4, 0x6f, 0xe1, 0x03, 0x81, 0x4a, 0x57, 0x2e, 0x3d, 0xc3, 0x5b, 0x7a,
+	0x9f, 0xe4, 0x2a, 0x56, 0x56, 0xb3, 0x50, 0xd5, 0xb5, 0xa3, 0x31, 0xad, 0x4b, 0x38, 0x61, 0xe8,
+	0x19, 0x7e, 0x03, 0x67, 0x4f, 0x31, 0xa2, 0x7f, 0x89, 0x0a, 0x6d, 0x1f, 0x9a, 0x61, 0x34, 0xba,
+	0x18, 0xaf, 0x16, 0x32, 0x9a, 0xc9, 0xe1, 0x48, 0x46, 0x17, 0xbf, 0x79, 0x83, 0x68, 0xe2, 0xe7,
+	0xf7, 0x45, 0x7d, 0x31, 0xdb, 0x78, 0xeb, 0x27, 0xc8, 0xf1, 0xfc, 0xa9, 0x37, 0x92, 0xbf, 0x7c,
+	0x33, 0x9a, 0x2c, 0xc7, 0xf7, 0x03, 0xf5, 0x21, 0x6a, 0x6d, 0x85, 0xb7, 0xd2, 0xf0, 0x56, 0x1a,
+	0xde, 0x52, 0xe1, 0x83, 0xf4, 0xf3, 0xfb, 0xf1, 0xbf, 0x01, 0x00, 0x89, 0x02, 0xa3, 0x81, 0x9f,
+	0x07, 0x00, 0x00,
 }
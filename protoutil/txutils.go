@@ -147,12 +147,6 @@ func CreateSignedTx(
 		return nil, err
 	}
 
-	// the original payload
-	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
-	if err != nil {
-		return nil, err
-	}
-
 	// check that the signer is the same that is referenced in the header
 	// TODO: maybe worth removing?
 	signerBytes, err := signer.Serialize()
@@ -169,6 +163,45 @@ func CreateSignedTx(
 		return nil, errors.New("signer must be the same as the one referenced in the header")
 	}
 
+	env, err := CreateTx(proposal, resps...)
+	if err != nil {
+		return nil, err
+	}
+
+	// sign the payload
+	sig, err := signer.Sign(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	env.Signature = sig
+
+	// here's the envelope
+	return env, nil
+}
+
+// CreateTx assembles an unsigned Envelope message from proposal and
+// endorsements. The envelope must be signed by the creator of the proposal
+// before it is submitted for ordering
+func CreateTx(
+	proposal *peer.Proposal,
+	resps ...*peer.ProposalResponse,
+) (*common.Envelope, error) {
+	if len(resps) == 0 {
+		return nil, errors.New("at least one proposal response is required")
+	}
+
+	// the original header
+	hdr, err := GetHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	// the original payload
+	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, err
+	}
+
 	// get header extensions so we have the visibility field
 	hdrExt, err := GetChaincodeHeaderExtension(hdr)
 	if err != nil {
@@ -232,14 +265,7 @@ func CreateSignedTx(
 		return nil, err
 	}
 
-	// sign the payload
-	sig, err := signer.Sign(paylBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	// here's the envelope
-	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
+	return &common.Envelope{Payload: paylBytes}, nil
 }
 
 // CreateProposalResponse creates a proposal response.
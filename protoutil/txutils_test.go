@@ -284,6 +284,54 @@ func TestCreateSignedTxStatus(t *testing.T) {
 	}
 }
 
+func TestCreateTx(t *testing.T) {
+	ccHeaderExtensionBytes, err := proto.Marshal(&pb.ChaincodeHeaderExtension{})
+	assert.NoError(t, err)
+	chdrBytes, err := proto.Marshal(&cb.ChannelHeader{
+		Extension: ccHeaderExtensionBytes,
+	})
+	assert.NoError(t, err)
+	shdrBytes, err := proto.Marshal(&cb.SignatureHeader{
+		Creator: []byte("creator"),
+	})
+	assert.NoError(t, err)
+	headerBytes, err := proto.Marshal(&cb.Header{
+		ChannelHeader:   chdrBytes,
+		SignatureHeader: shdrBytes,
+	})
+	assert.NoError(t, err)
+	prop := &pb.Proposal{Header: headerBytes}
+
+	// no proposal responses
+	_, err = protoutil.CreateTx(prop)
+	assert.EqualError(t, err, "at least one proposal response is required")
+
+	// non-matching responses
+	responses := []*pb.ProposalResponse{
+		{Payload: []byte("payload"), Endorsement: &pb.Endorsement{}, Response: &pb.Response{Status: 200}},
+		{Payload: []byte("payload2"), Endorsement: &pb.Endorsement{}, Response: &pb.Response{Status: 200}},
+	}
+	_, err = protoutil.CreateTx(prop, responses...)
+	assert.EqualError(t, err, "ProposalResponsePayloads do not match")
+
+	// success
+	responses[1].Payload = []byte("payload")
+	env, err := protoutil.CreateTx(prop, responses...)
+	assert.NoError(t, err)
+	assert.Nil(t, env.Signature)
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&cb.Header{ChannelHeader: chdrBytes, SignatureHeader: shdrBytes}, payload.Header))
+	tx, err := protoutil.GetTransaction(payload.Data)
+	assert.NoError(t, err)
+	assert.Len(t, tx.Actions, 1)
+	cap, err := protoutil.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), cap.Action.ProposalResponsePayload)
+	assert.Len(t, cap.Action.Endorsements, 2)
+}
+
 func TestCreateSignedEnvelope(t *testing.T) {
 	var env *cb.Envelope
 	channelID := "mychannelID"